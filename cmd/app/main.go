@@ -5,7 +5,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -23,6 +22,7 @@ import (
 	"github.com/Harvey-AU/blue-banded-bee/internal/loops"
 	"github.com/Harvey-AU/blue-banded-bee/internal/notifications"
 	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 	"github.com/getsentry/sentry-go"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
@@ -81,73 +81,109 @@ func startJobScheduler(ctx context.Context, wg *sync.WaitGroup, jobsManager *job
 					continue
 				}
 
-				// Check if a job started too recently (within half the schedule interval)
-				lastJobStart, err := pgDB.GetLastJobStartTimeForScheduler(ctx, scheduler.ID)
-				if err != nil {
-					log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to get last job start time")
-					continue
-				}
+				runDueScheduler(ctx, jobsManager, pgDB, scheduler, domainName)
+			}
+		}
+	}
+}
 
-				if lastJobStart != nil {
-					minInterval := time.Duration(scheduler.ScheduleIntervalHours) * time.Hour / 2
-					timeSinceLastJob := time.Since(*lastJobStart)
-
-					if timeSinceLastJob < minInterval {
-						log.Info().
-							Str("scheduler_id", scheduler.ID).
-							Str("domain", domainName).
-							Dur("time_since_last_job", timeSinceLastJob).
-							Dur("minimum_interval", minInterval).
-							Msg("Skipping scheduled job - last job started too recently")
-
-						// Update next_run_at to the next valid time slot
-						nextRun := time.Now().UTC().Add(time.Duration(scheduler.ScheduleIntervalHours) * time.Hour)
-						if err := pgDB.UpdateSchedulerNextRun(ctx, scheduler.ID, nextRun); err != nil {
-							log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to update scheduler next run")
-						}
-						continue
-					}
-				}
+// runDueScheduler creates the job for a scheduler whose next run has arrived,
+// unless its previous job is still going, and moves next_run_at on
+func runDueScheduler(ctx context.Context, jobsManager *jobs.JobManager, pgDB *db.DB, scheduler *db.Scheduler, domainName string) {
+	// Don't double-fire while the previous run is still pending or running
+	active, err := pgDB.HasActiveJobForScheduler(ctx, scheduler.ID)
+	if err != nil {
+		return
+	}
+	if active {
+		log.Info().
+			Str("scheduler_id", scheduler.ID).
+			Str("domain", domainName).
+			Msg("Skipping scheduled job - previous run still in progress")
+		advanceScheduler(ctx, pgDB, scheduler)
+		return
+	}
 
-				// Create JobOptions from scheduler
-				sourceType := "scheduler"
-				opts := &jobs.JobOptions{
-					Domain:          domainName,
-					OrganisationID:  &scheduler.OrganisationID,
-					UseSitemap:      true,
-					Concurrency:     scheduler.Concurrency,
-					FindLinks:       scheduler.FindLinks,
-					MaxPages:        scheduler.MaxPages,
-					IncludePaths:    scheduler.IncludePaths,
-					ExcludePaths:    scheduler.ExcludePaths,
-					RequiredWorkers: scheduler.RequiredWorkers,
-					SourceType:      &sourceType,
-					SourceDetail:    &scheduler.ID,
-					SchedulerID:     &scheduler.ID,
-				}
+	// Interval schedules also skip if a job started too recently (within half
+	// the interval); cron schedules fire exactly when their spec says
+	if scheduler.CronExpression == "" {
+		lastJobStart, err := pgDB.GetLastJobStartTimeForScheduler(ctx, scheduler.ID)
+		if err != nil {
+			log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to get last job start time")
+			return
+		}
 
-				// Create job (standard flow)
-				job, err := jobsManager.CreateJob(ctx, opts)
-				if err != nil {
-					log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to create scheduled job")
-					continue
-				}
+		if lastJobStart != nil {
+			minInterval := time.Duration(scheduler.ScheduleIntervalHours) * time.Hour / 2
+			timeSinceLastJob := time.Since(*lastJobStart)
 
-				// Update scheduler next_run_at
-				nextRun := time.Now().UTC().Add(time.Duration(scheduler.ScheduleIntervalHours) * time.Hour)
-				if err := pgDB.UpdateSchedulerNextRun(ctx, scheduler.ID, nextRun); err != nil {
-					log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to update scheduler next run")
-				} else {
-					log.Info().
-						Str("scheduler_id", scheduler.ID).
-						Str("job_id", job.ID).
-						Str("domain", domainName).
-						Time("next_run_at", nextRun).
-						Msg("Created scheduled job")
-				}
+			if timeSinceLastJob < minInterval {
+				log.Info().
+					Str("scheduler_id", scheduler.ID).
+					Str("domain", domainName).
+					Dur("time_since_last_job", timeSinceLastJob).
+					Dur("minimum_interval", minInterval).
+					Msg("Skipping scheduled job - last job started too recently")
+
+				// Update next_run_at to the next valid time slot
+				advanceScheduler(ctx, pgDB, scheduler)
+				return
 			}
 		}
 	}
+
+	// Create JobOptions from scheduler
+	sourceType := "scheduler"
+	opts := &jobs.JobOptions{
+		Domain:          domainName,
+		OrganisationID:  &scheduler.OrganisationID,
+		UseSitemap:      true,
+		Concurrency:     scheduler.Concurrency,
+		FindLinks:       scheduler.FindLinks,
+		SecondRequest:   true,
+		MaxPages:        scheduler.MaxPages,
+		IncludePaths:    scheduler.IncludePaths,
+		ExcludePaths:    scheduler.ExcludePaths,
+		RequiredWorkers: scheduler.RequiredWorkers,
+		SourceType:      &sourceType,
+		SourceDetail:    &scheduler.ID,
+		SchedulerID:     &scheduler.ID,
+	}
+
+	// Create job (standard flow)
+	job, err := jobsManager.CreateJob(ctx, opts)
+	if err != nil {
+		log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to create scheduled job")
+		return
+	}
+
+	// Update scheduler next_run_at
+	if nextRun, ok := advanceScheduler(ctx, pgDB, scheduler); ok {
+		log.Info().
+			Str("scheduler_id", scheduler.ID).
+			Str("job_id", job.ID).
+			Str("domain", domainName).
+			Time("next_run_at", nextRun).
+			Msg("Created scheduled job")
+	}
+}
+
+// advanceScheduler moves a scheduler's next_run_at to its next slot after now.
+// A cron spec that can no longer be evaluated is pushed back a day rather than
+// retried on every tick.
+func advanceScheduler(ctx context.Context, pgDB *db.DB, scheduler *db.Scheduler) (time.Time, bool) {
+	now := time.Now().UTC()
+	nextRun, err := jobs.NextScheduledRun(scheduler, now)
+	if err != nil {
+		log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to calculate scheduler next run")
+		nextRun = now.Add(24 * time.Hour)
+	}
+
+	if err := pgDB.UpdateSchedulerNextRun(ctx, scheduler.ID, nextRun); err != nil {
+		log.Error().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to update scheduler next run")
+		return nextRun, false
+	}
+	return nextRun, true
 }
 
 // startHealthMonitoring starts background monitoring for job completion and system health
@@ -201,6 +237,7 @@ func startHealthMonitoring(ctx context.Context, wg *sync.WaitGroup, pgDB *db.DB)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to count stuck jobs")
 		}
+		stuckJobsCounted := err == nil
 
 		// Get sample of stuck jobs for details
 		type stuckJobInfo struct {
@@ -261,6 +298,16 @@ func startHealthMonitoring(ctx context.Context, wg *sync.WaitGroup, pgDB *db.DB)
 				Msg("CRITICAL: Jobs stuck without progress for >5 minutes")
 		}
 
+		if stuckJobsCounted {
+			sampleIDs := make([]string, len(stuckJobs))
+			for i, job := range stuckJobs {
+				sampleIDs[i] = job.ID
+			}
+			syncHealthWarning(ctx, pgDB, db.HealthWarningStuckJobs, totalStuckJobs,
+				fmt.Sprintf("%d jobs running for over 5 minutes with 0%% progress", totalStuckJobs),
+				map[string]any{"sample_job_ids": sampleIDs})
+		}
+
 		// Check for stuck tasks - get total counts first
 		var totalStuckTasks int
 		var totalAffectedJobs int
@@ -275,6 +322,7 @@ func startHealthMonitoring(ctx context.Context, wg *sync.WaitGroup, pgDB *db.DB)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to count stuck tasks")
 		}
+		stuckTasksCounted := err == nil
 
 		// Get sample of stuck tasks for details
 		type stuckTaskInfo struct {
@@ -345,6 +393,27 @@ func startHealthMonitoring(ctx context.Context, wg *sync.WaitGroup, pgDB *db.DB)
 				Time("oldest_stuck_at", stuckTasks[0].StartedAt).
 				Msg("CRITICAL: Tasks stuck in running state for >3 minutes")
 		}
+
+		if stuckTasksCounted {
+			sampleIDs := make([]string, len(stuckTasks))
+			for i, task := range stuckTasks {
+				sampleIDs[i] = task.ID
+			}
+			syncHealthWarning(ctx, pgDB, db.HealthWarningStuckTasks, totalStuckTasks,
+				fmt.Sprintf("%d tasks running for over 3 minutes across %d jobs", totalStuckTasks, totalAffectedJobs),
+				map[string]any{"affected_jobs": totalAffectedJobs, "sample_task_ids": sampleIDs})
+		}
+
+		checkCounterLeaks(ctx, pgDB)
+		checkCleanupBacklog(ctx, pgDB)
+
+		// Expired GA OAuth sessions are otherwise only swept when a new one
+		// starts; deleting them also removes their tokens from Vault
+		if deleted, err := pgDB.DeleteExpiredPendingGASessions(ctx); err != nil {
+			log.Warn().Err(err).Msg("Failed to delete expired pending GA sessions")
+		} else if deleted > 0 {
+			log.Info().Int64("deleted", deleted).Msg("Deleted expired pending GA sessions")
+		}
 	}
 
 	// Run initial checks
@@ -365,6 +434,114 @@ func startHealthMonitoring(ctx context.Context, wg *sync.WaitGroup, pgDB *db.DB)
 	}
 }
 
+// Health warning thresholds. Small counter drift is expected while running task
+// releases are batched, so only sustained leaks are raised.
+const (
+	counterLeakWarningThreshold    = 5
+	cleanupBacklogWarningThreshold = 5
+	healthWarningSampleSize        = 10
+)
+
+// syncHealthWarning records a persisted health warning when count > 0 and
+// resolves any open warning of that type otherwise
+func syncHealthWarning(ctx context.Context, pgDB *db.DB, warningType string, count int, message string, details map[string]any) {
+	if count > 0 {
+		if err := pgDB.RecordHealthWarning(ctx, warningType, message, count, details); err != nil {
+			log.Error().Err(err).Str("warning_type", warningType).Msg("Failed to persist health warning")
+		}
+		return
+	}
+	if err := pgDB.ResolveHealthWarning(ctx, warningType); err != nil {
+		log.Error().Err(err).Str("warning_type", warningType).Msg("Failed to resolve health warning")
+	}
+}
+
+// checkCounterLeaks compares running_tasks counters with actual running tasks.
+// Leaks are only reconciled on startup, so they're surfaced here in between.
+func checkCounterLeaks(ctx context.Context, pgDB *db.DB) {
+	rows, err := pgDB.GetDB().QueryContext(ctx, `
+		SELECT j.id, j.running_tasks - COALESCE(actual.running, 0) AS leaked
+		FROM jobs j
+		LEFT JOIN (
+			SELECT job_id, COUNT(*) AS running
+			FROM tasks
+			WHERE status = 'running'
+			GROUP BY job_id
+		) actual ON actual.job_id = j.id
+		WHERE j.status IN ('running', 'pending')
+		  AND j.running_tasks > COALESCE(actual.running, 0)
+		ORDER BY leaked DESC
+	`)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check running_tasks counter leaks")
+		return
+	}
+	defer rows.Close()
+
+	totalLeaked := 0
+	var sampleJobIDs []string
+	for rows.Next() {
+		var jobID string
+		var leaked int
+		if err := rows.Scan(&jobID, &leaked); err != nil {
+			log.Warn().Err(err).Msg("Failed to scan counter leak row")
+			continue
+		}
+		totalLeaked += leaked
+		if len(sampleJobIDs) < healthWarningSampleSize {
+			sampleJobIDs = append(sampleJobIDs, jobID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to read running_tasks counter leaks")
+		return
+	}
+
+	if totalLeaked < counterLeakWarningThreshold {
+		totalLeaked = 0
+	} else {
+		log.Warn().
+			Int("total_leaked_tasks", totalLeaked).
+			Strs("sample_job_ids", sampleJobIDs).
+			Msg("Running_tasks counters exceed actual running tasks")
+	}
+
+	syncHealthWarning(ctx, pgDB, db.HealthWarningCounterLeak, totalLeaked,
+		fmt.Sprintf("running_tasks counters exceed actual running tasks by %d", totalLeaked),
+		map[string]any{"sample_job_ids": sampleJobIDs})
+}
+
+// checkCleanupBacklog counts failed jobs still holding pending/waiting tasks.
+// The orphaned task cleanup loop clears one job per cycle, so a growing
+// count means it's falling behind.
+func checkCleanupBacklog(ctx context.Context, pgDB *db.DB) {
+	var backlogJobs, backlogTasks int
+	err := pgDB.GetDB().QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT t.job_id), COUNT(*)
+		FROM tasks t
+		JOIN jobs j ON j.id = t.job_id
+		WHERE j.status = 'failed'
+		  AND t.status IN ('pending', 'waiting')
+	`).Scan(&backlogJobs, &backlogTasks)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check orphaned task cleanup backlog")
+		return
+	}
+
+	if backlogJobs < cleanupBacklogWarningThreshold {
+		backlogJobs = 0
+	} else {
+		log.Warn().
+			Int("backlog_jobs", backlogJobs).
+			Int("backlog_tasks", backlogTasks).
+			Msg("Orphaned task cleanup is falling behind")
+	}
+
+	syncHealthWarning(ctx, pgDB, db.HealthWarningCleanupBacklog, backlogJobs,
+		fmt.Sprintf("%d failed jobs still have %d orphaned tasks awaiting cleanup", backlogJobs, backlogTasks),
+		map[string]any{"orphaned_tasks": backlogTasks})
+}
+
 // Config holds the application configuration loaded from environment variables
 type Config struct {
 	Port                  string // HTTP port to listen on
@@ -566,6 +743,12 @@ func main() {
 
 	// Initialise crawler
 	crawlerConfig := crawler.DefaultConfig()
+	crawlerConfig.MaxRedirects = getEnvInt("BBB_CRAWLER_MAX_REDIRECTS", crawler.DefaultMaxRedirects)
+	crawlerConfig.MaxSitemapURLs = getEnvInt("BBB_CRAWLER_MAX_SITEMAP_URLS", crawler.DefaultMaxSitemapURLs)
+	crawlerConfig.BodySampleBytes = getEnvInt("BBB_CRAWLER_BODY_SAMPLE_BYTES", crawler.DefaultBodySampleBytes)
+	crawlerConfig.DialTimeout = time.Duration(getEnvInt("BBB_CRAWLER_DIAL_TIMEOUT_SECONDS", int(crawler.DefaultDialTimeout/time.Second))) * time.Second
+	crawlerConfig.TLSHandshakeTimeout = time.Duration(getEnvInt("BBB_CRAWLER_TLS_HANDSHAKE_TIMEOUT_SECONDS", int(crawler.DefaultTLSHandshakeTimeout/time.Second))) * time.Second
+	crawlerConfig.ResponseHeaderTimeout = time.Duration(getEnvInt("BBB_CRAWLER_RESPONSE_HEADER_TIMEOUT_SECONDS", 0)) * time.Second
 	cr := crawler.New(crawlerConfig) // QUESTION: Should we change cr to crawler for clarity, as others have clearer names.
 
 	// Create database queue for operations
@@ -649,6 +832,7 @@ func main() {
 
 	// Create a rate limiter
 	limiter := newRateLimiter()
+	trustedProxies := loadTrustedProxies()
 
 	// Check GA4 integration availability
 	googleClientID := os.Getenv("GOOGLE_CLIENT_ID")
@@ -675,6 +859,16 @@ func main() {
 		googleClientSecret,
 	)
 
+	// Per-organisation limit applied after auth, on top of the per-IP guard
+	apiHandler.OrgRateLimiter = api.NewOrgRateLimiter(
+		getEnvInt("ORG_RATE_LIMIT_RPS", api.DefaultOrgRateLimit),
+		getEnvInt("ORG_RATE_LIMIT_BURST", api.DefaultOrgRateBurst),
+	)
+
+	// Fan job_progress notifications out to SSE streams on /v1/jobs/:id/events
+	// and the dashboard WebSocket on /v1/ws
+	apiHandler.JobEvents = api.NewJobEventHub()
+
 	// Create HTTP multiplexer
 	mux := http.NewServeMux()
 
@@ -694,7 +888,7 @@ func main() {
 			p == "/config.js" ||
 			p == "/favicon.ico"
 		if !isStatic {
-			ip := getClientIP(r)
+			ip := getClientIP(r, trustedProxies)
 			if !limiter.getLimiter(ip).Allow() {
 				api.WriteErrorMessage(w, r, "Too many requests", http.StatusTooManyRequests, api.ErrCodeRateLimit)
 				return
@@ -754,6 +948,9 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 		defer cancel()
 
+		// Close SSE streams so Shutdown doesn't wait on them
+		apiHandler.JobEvents.Close()
+
 		if err := server.Shutdown(ctx); err != nil {
 			sentry.CaptureException(err)
 			log.Error().Err(err).Msg("Server forced to shutdown")
@@ -764,10 +961,14 @@ func main() {
 
 	// Start the worker pool once the HTTP server goroutine is running
 	workerPool.Start(context.Background())
-	// Defer worker pool stop - will execute BEFORE database close due to defer LIFO order
+	// Defer worker pool drain - will execute BEFORE database close due to defer LIFO order.
+	// Keep BBB_WORKER_DRAIN_TIMEOUT_SECONDS below fly.toml's kill_timeout.
 	defer func() {
-		log.Info().Msg("Stopping worker pool (waiting for in-flight tasks to complete)")
-		workerPool.Stop()
+		log.Info().Msg("Draining worker pool (waiting for in-flight tasks to complete)")
+		drainTimeout := time.Duration(getEnvInt("BBB_WORKER_DRAIN_TIMEOUT_SECONDS", 45)) * time.Second
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancelDrain()
+		workerPool.Drain(drainCtx)
 		log.Info().Msg("Worker pool stopped - all tasks completed and batches flushed")
 	}()
 
@@ -792,6 +993,23 @@ func main() {
 		notifications.StartWithFallback(appCtx, pgDB.GetConfig().ConnectionString(), notificationService)
 	})
 
+	// Start job progress listener (SSE streams poll on heartbeat without it)
+	if listenConnStr := notifications.ListenConnString(pgDB.GetConfig().ConnectionString()); listenConnStr != "" {
+		backgroundWG.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Error().
+						Any("panic", r).
+						Msg("Recovered panic in job progress listener")
+				}
+			}()
+
+			apiHandler.JobEvents.Listen(appCtx, listenConnStr)
+		})
+	} else {
+		log.Info().Msg("Job progress streams using polling mode (connection pooler detected)")
+	}
+
 	// Wait for either the server to exit or shutdown signal completion
 	var serverErr error
 	select {
@@ -894,7 +1112,9 @@ func setupLogging(config *Config) {
 	}
 }
 
-// RateLimiter represents a rate limiting system based on client IP addresses
+// RateLimiter represents a rate limiting system based on client IP addresses.
+// It's a coarse pre-auth guard; authenticated requests are also limited per
+// organisation by api.OrgRateLimiter.
 type RateLimiter struct {
 	limits   map[string]*IPRateLimiter
 	mu       sync.Mutex
@@ -937,18 +1157,28 @@ func (ipl *IPRateLimiter) Allow() bool {
 	return ipl.limiter.Allow()
 }
 
-// getClientIP extracts the client's IP address from a request
-func getClientIP(r *http.Request) string {
-	// Check for X-Forwarded-For header first (for clients behind proxies)
-	ip := r.Header.Get("X-Forwarded-For")
-	if ip != "" {
-		// X-Forwarded-For might contain multiple IPs, take the first one
-		ips := strings.Split(ip, ",")
-		ip = strings.TrimSpace(ips[0])
-		return ip
+// getClientIP extracts the client's IP address from a request. Clients can
+// write any X-Forwarded-For they like, so it's only believed as far back as
+// the chain of trusted proxies goes.
+func getClientIP(r *http.Request, proxies *util.TrustedProxies) string {
+	return proxies.ClientIP(r)
+}
+
+// loadTrustedProxies reads TRUSTED_PROXY_CIDRS, falling back to the private
+// ranges Fly.io's edge connects from
+func loadTrustedProxies() *util.TrustedProxies {
+	cidrs := os.Getenv("TRUSTED_PROXY_CIDRS")
+	if cidrs != "" {
+		proxies, err := util.ParseTrustedProxies(cidrs)
+		if err == nil {
+			return proxies
+		}
+		log.Warn().Err(err).Msg("Invalid TRUSTED_PROXY_CIDRS, using default trusted proxies")
 	}
 
-	// If no X-Forwarded-For, use RemoteAddr
-	ip, _, _ = net.SplitHostPort(r.RemoteAddr)
-	return ip
+	proxies, err := util.ParseTrustedProxies(util.DefaultTrustedProxyCIDRs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid default trusted proxy CIDRs")
+	}
+	return proxies
 }
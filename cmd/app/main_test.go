@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -68,14 +69,16 @@ func TestTestCrawlEndpoint(t *testing.T) {
 func TestRateLimiter(t *testing.T) {
 	// Create a new rate limiter
 	limiter := newRateLimiter()
+	proxies := loadTrustedProxies()
 
-	// Mock request with X-Forwarded-For
+	// Mock request with X-Forwarded-For, arriving through the edge proxy
 	req1, _ := http.NewRequest("GET", "/test", nil)
-	req1.Header.Set("X-Forwarded-For", "192.168.1.1")
+	req1.RemoteAddr = "172.16.0.2:41000"
+	req1.Header.Set("X-Forwarded-For", "203.0.113.1")
 
 	// Test basic allowance - should allow up to burst capacity (10)
 	for i := range 10 {
-		ip := getClientIP(req1)
+		ip := getClientIP(req1, proxies)
 		rLimiter := limiter.getLimiter(ip)
 		if !rLimiter.Allow() {
 			t.Errorf("Request %d should be allowed", i+1)
@@ -83,7 +86,7 @@ func TestRateLimiter(t *testing.T) {
 	}
 
 	// This should be blocked (11th request exceeds burst capacity)
-	ip := getClientIP(req1)
+	ip := getClientIP(req1, proxies)
 	rLimiter := limiter.getLimiter(ip)
 	if rLimiter.Allow() {
 		t.Errorf("Request should be blocked after burst capacity exceeded")
@@ -91,10 +94,30 @@ func TestRateLimiter(t *testing.T) {
 
 	// Different IP should be allowed
 	req2, _ := http.NewRequest("GET", "/test", nil)
-	req2.Header.Set("X-Forwarded-For", "192.168.1.2")
-	ip2 := getClientIP(req2)
+	req2.RemoteAddr = "172.16.0.2:41000"
+	req2.Header.Set("X-Forwarded-For", "203.0.113.2")
+	ip2 := getClientIP(req2, proxies)
 	rLimiter2 := limiter.getLimiter(ip2)
 	if !rLimiter2.Allow() {
 		t.Errorf("Request from different IP should be allowed")
 	}
 }
+
+func TestRateLimiterIgnoresSpoofedForwardedFor(t *testing.T) {
+	limiter := newRateLimiter()
+	proxies := loadTrustedProxies()
+
+	// A client connecting directly can't pick a fresh bucket per request
+	for i := range 11 {
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "198.51.100.7:52000"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i))
+		allowed := limiter.getLimiter(getClientIP(req, proxies)).Allow()
+		if i < 10 && !allowed {
+			t.Errorf("Request %d should be allowed", i+1)
+		}
+		if i == 10 && allowed {
+			t.Errorf("Spoofed X-Forwarded-For should not bypass the limit")
+		}
+	}
+}
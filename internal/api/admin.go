@@ -1,11 +1,19 @@
 package api
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/auth"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
 	"github.com/getsentry/sentry-go"
 )
 
@@ -272,3 +280,317 @@ func hasSystemAdminRole(claims *auth.UserClaims) bool {
 
 	return false
 }
+
+// AdminForceJobStatusRequest is the body for forcing a job into a terminal status
+type AdminForceJobStatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// AdminJobHandler handles requests to /v1/admin/jobs/:id/...
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminJobHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/admin/jobs/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" {
+		NotFound(w, r, "Endpoint not found")
+		return
+	}
+
+	switch parts[1] {
+	case "force-status":
+		h.adminForceJobStatus(w, r, parts[0])
+	default:
+		NotFound(w, r, "Endpoint not found")
+	}
+}
+
+// adminForceJobStatus handles POST /v1/admin/jobs/:id/force-status
+func (h *Handler) adminForceJobStatus(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	claims, ok := auth.GetUserFromContext(r.Context())
+	if !ok {
+		Unauthorised(w, r, "Authentication required for admin endpoint")
+		return
+	}
+
+	var req AdminForceJobStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+
+	status := jobs.JobStatus(strings.ToLower(strings.TrimSpace(req.Status)))
+	if !jobs.IsTerminalJobStatus(status) {
+		BadRequest(w, r, jobs.ErrInvalidForceStatus.Error())
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	// Log the manual intervention before acting so it's recorded even on failure
+	logger.Warn().
+		Str("user_id", claims.UserID).
+		Str("job_id", jobID).
+		Str("target_status", string(status)).
+		Str("reason", req.Reason).
+		Str("remote_addr", r.RemoteAddr).
+		Msg("Admin force job status requested")
+
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetTag("event_type", "admin_action")
+		scope.SetTag("action", "force_job_status")
+		scope.SetUser(sentry.User{ID: claims.UserID})
+		scope.SetContext("admin_action", map[string]any{
+			"endpoint":      "/v1/admin/jobs/{id}/force-status",
+			"job_id":        jobID,
+			"target_status": string(status),
+			"reason":        req.Reason,
+		})
+		sentry.CaptureMessage("Admin force job status action")
+	})
+
+	result, err := h.JobsManager.ForceJobStatus(r.Context(), jobID, status, req.Reason)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			NotFound(w, r, "Job not found")
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to force job status")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, result, fmt.Sprintf("Job marked as %s", status))
+}
+
+// AdminThroughput handles GET /v1/admin/throughput
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminThroughput(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	if h.JobsManager == nil {
+		ServiceUnavailable(w, r, "Job manager not available")
+		return
+	}
+
+	throughput, err := h.JobsManager.SystemThroughput(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get system throughput")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, throughput, "System throughput retrieved successfully")
+}
+
+// AdminPool handles GET /v1/admin/pool
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminPool(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	if h.JobsManager == nil {
+		ServiceUnavailable(w, r, "Job manager not available")
+		return
+	}
+
+	snapshot, err := h.JobsManager.PoolSnapshot()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get worker pool snapshot")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, snapshot, "Worker pool state retrieved successfully")
+}
+
+// AdminReconcile handles POST /v1/admin/reconcile
+// Resets leaked running_tasks counters without waiting for a restart.
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminReconcile(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if !h.startAdminMaintenance(w, r, "reconcile") {
+		return
+	}
+
+	result, err := h.JobsManager.ReconcileRunningTaskCounters(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to reconcile running task counters")
+		writeMaintenanceError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Int("jobs_fixed", result.JobsFixed).
+		Int("leaked_tasks", result.LeakedTasks).
+		Msg("Admin reconcile completed")
+
+	WriteSuccess(w, r, result, "Running task counters reconciled")
+}
+
+// AdminRebalance handles POST /v1/admin/rebalance
+// Demotes excess pending tasks without waiting for the next rebalance tick.
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminRebalance(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if !h.startAdminMaintenance(w, r, "rebalance") {
+		return
+	}
+
+	result, err := h.JobsManager.RebalancePendingQueues(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to rebalance pending queues")
+		writeMaintenanceError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Int("jobs_rebalanced", result.JobsRebalanced).
+		Int("demoted_tasks", result.DemotedTasks).
+		Msg("Admin rebalance completed")
+
+	WriteSuccess(w, r, result, "Pending queues rebalanced")
+}
+
+// startAdminMaintenance checks an on-demand maintenance request and records
+// who triggered it. It writes the error response and returns false if the
+// request can't proceed.
+func (h *Handler) startAdminMaintenance(w http.ResponseWriter, r *http.Request, action string) bool {
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return false
+	}
+
+	claims, ok := auth.GetUserFromContext(r.Context())
+	if !ok {
+		Unauthorised(w, r, "Authentication required for admin endpoint")
+		return false
+	}
+
+	if h.JobsManager == nil {
+		ServiceUnavailable(w, r, "Job manager not available")
+		return false
+	}
+
+	logger := loggerWithRequest(r)
+	logger.Warn().
+		Str("user_id", claims.UserID).
+		Str("action", action).
+		Str("remote_addr", r.RemoteAddr).
+		Msg("Admin maintenance requested")
+
+	return true
+}
+
+func writeMaintenanceError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, jobs.ErrWorkerPoolUnavailable) {
+		ServiceUnavailable(w, r, "Worker pool not available")
+		return
+	}
+	InternalError(w, r, err)
+}
+
+// healthWarningsListLimit caps warnings returned by GET /v1/admin/health-warnings
+const healthWarningsListLimit = 100
+
+// AdminHealthWarnings handles GET /v1/admin/health-warnings
+// Returns open warnings; ?include_resolved=true adds recently resolved ones.
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminHealthWarnings(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	includeResolved := r.URL.Query().Get("include_resolved") == "true"
+
+	warnings, err := h.DB.ListHealthWarnings(r.Context(), includeResolved, healthWarningsListLimit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list health warnings")
+		InternalError(w, r, err)
+		return
+	}
+	if warnings == nil {
+		warnings = []*db.HealthWarning{}
+	}
+
+	WriteSuccess(w, r, map[string]any{"warnings": warnings}, "Health warnings retrieved successfully")
+}
+
+// AdminHealthWarningHandler handles requests to /v1/admin/health-warnings/:id/...
+// Requires system admin (enforced by requireSystemAdmin middleware)
+func (h *Handler) AdminHealthWarningHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/admin/health-warnings/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" {
+		NotFound(w, r, "Endpoint not found")
+		return
+	}
+
+	warningID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		BadRequest(w, r, "Invalid health warning ID")
+		return
+	}
+
+	switch parts[1] {
+	case "acknowledge":
+		h.adminAcknowledgeHealthWarning(w, r, warningID)
+	default:
+		NotFound(w, r, "Endpoint not found")
+	}
+}
+
+// adminAcknowledgeHealthWarning handles POST /v1/admin/health-warnings/:id/acknowledge
+func (h *Handler) adminAcknowledgeHealthWarning(w http.ResponseWriter, r *http.Request, warningID int64) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	claims, ok := auth.GetUserFromContext(r.Context())
+	if !ok {
+		Unauthorised(w, r, "Authentication required for admin endpoint")
+		return
+	}
+
+	warning, err := h.DB.AcknowledgeHealthWarning(r.Context(), warningID, claims.UserID)
+	if err != nil {
+		if errors.Is(err, db.ErrHealthWarningNotFound) {
+			NotFound(w, r, "Health warning not found")
+			return
+		}
+		logger.Error().Err(err).Int64("warning_id", warningID).Msg("Failed to acknowledge health warning")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Str("user_id", claims.UserID).
+		Int64("warning_id", warningID).
+		Str("warning_type", warning.Type).
+		Msg("Health warning acknowledged")
+
+	WriteSuccess(w, r, warning, "Health warning acknowledged")
+}
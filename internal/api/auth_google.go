@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/auth"
@@ -18,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 )
 
 // extractGoogleAccountIDFromPath extracts Google account ID from path like "accounts/accounts/123456/properties"
@@ -38,71 +38,114 @@ func extractGoogleAccountIDFromPath(path string) string {
 	return trimmed
 }
 
-// Pending OAuth sessions - stores properties and tokens temporarily after OAuth callback.
-// Key is session ID, value is PendingGASession.
-//
-// LIMITATION: This in-memory map will not work correctly in multi-instance deployments
-// because OAuth callbacks may land on a different process than the one that initiated
-// the OAuth flow. For production multi-instance deployments, this should be replaced
-// with a shared session store (e.g., Redis cache or DB-backed sessions) or use
-// signed/encrypted cookie sessions for portability.
-var (
-	pendingGASessions   = make(map[string]*PendingGASession)
-	pendingGASessionsMu sync.RWMutex
-)
+// pendingGASessionTTL is how long a user has to finish account/property
+// selection after the OAuth callback
+const pendingGASessionTTL = 10 * time.Minute
 
-// PendingGASession stores OAuth data temporarily until user completes account/property selection
+// PendingGASession stores OAuth data temporarily until user completes account/property selection.
+// Sessions live in the database so the OAuth callback and the follow-up
+// requests can land on different app instances. The tokens are kept in
+// Vault, apart from the session row.
 type PendingGASession struct {
-	Accounts     []GA4Account  // Accounts fetched during OAuth
-	Properties   []GA4Property // Properties fetched when account selected (optional, for backwards compat)
-	RefreshToken string
-	AccessToken  string
-	State        string
-	UserID       string
-	Email        string
-	OrgID        string // Organisation ID from OAuth state
-	ExpiresAt    time.Time
+	Accounts     []GA4Account  `json:"accounts"`             // Accounts fetched during OAuth
+	Properties   []GA4Property `json:"properties,omitempty"` // Properties fetched when account selected (optional, for backwards compat)
+	RefreshToken string        `json:"-"`
+	AccessToken  string        `json:"-"`
+	State        string        `json:"state"`
+	UserID       string        `json:"user_id"`
+	Email        string        `json:"email"`
+	OrgID        string        `json:"org_id"` // Organisation ID from OAuth state
+	ExpiresAt    time.Time     `json:"-"`
+}
+
+// pendingGATokens is the part of a pending session stored in Vault
+type pendingGATokens struct {
+	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token"`
 }
 
-// storePendingGASession stores a pending session and returns the session ID
-func storePendingGASession(session *PendingGASession) string {
+// storePendingGASession stores a pending session and its tokens and returns
+// the session ID
+func (h *Handler) storePendingGASession(ctx context.Context, session *PendingGASession) (string, error) {
 	sessionID := uuid.New().String()
-	session.ExpiresAt = time.Now().Add(10 * time.Minute)
+	session.ExpiresAt = time.Now().Add(pendingGASessionTTL)
 
-	pendingGASessionsMu.Lock()
-	pendingGASessions[sessionID] = session
-	pendingGASessionsMu.Unlock()
+	if err := h.savePendingGASession(ctx, sessionID, session); err != nil {
+		return "", err
+	}
+
+	tokens, err := json.Marshal(pendingGATokens{RefreshToken: session.RefreshToken, AccessToken: session.AccessToken})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode pending GA tokens: %w", err)
+	}
+	if err := h.DB.StorePendingGATokens(ctx, sessionID, tokens); err != nil {
+		h.deletePendingGASession(ctx, sessionID)
+		return "", err
+	}
 
 	// Cleanup old sessions in background
-	go cleanupExpiredGASessions()
+	go h.cleanupExpiredGASessions()
 
-	return sessionID
+	return sessionID, nil
 }
 
-// getPendingGASession retrieves and removes a pending session
-func getPendingGASession(sessionID string) *PendingGASession {
-	pendingGASessionsMu.Lock()
-	defer pendingGASessionsMu.Unlock()
+// savePendingGASession writes a session's non-secret data under an existing
+// ID, keeping its expiry and stored tokens
+func (h *Handler) savePendingGASession(ctx context.Context, sessionID string, session *PendingGASession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode pending GA session: %w", err)
+	}
+	return h.DB.StorePendingGASession(ctx, sessionID, data, session.ExpiresAt)
+}
 
-	session, ok := pendingGASessions[sessionID]
-	if !ok || time.Now().After(session.ExpiresAt) {
-		delete(pendingGASessions, sessionID)
+// getPendingGASession retrieves a pending session, or nil if it is missing or expired
+func (h *Handler) getPendingGASession(ctx context.Context, sessionID string) *PendingGASession {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil
+	}
+
+	data, tokens, expiresAt, err := h.DB.GetPendingGASession(ctx, sessionID)
+	if err != nil {
+		if !errors.Is(err, db.ErrPendingGASessionNotFound) {
+			log.Error().Err(err).Msg("Failed to load pending GA session")
+		}
 		return nil
 	}
 
+	var session PendingGASession
+	if err := json.Unmarshal(data, &session); err != nil {
+		log.Error().Err(err).Msg("Failed to decode pending GA session")
+		return nil
+	}
+	if tokens != nil {
+		var stored pendingGATokens
+		if err := json.Unmarshal(tokens, &stored); err != nil {
+			log.Error().Err(err).Msg("Failed to decode pending GA tokens")
+			return nil
+		}
+		session.RefreshToken = stored.RefreshToken
+		session.AccessToken = stored.AccessToken
+	}
+	session.ExpiresAt = expiresAt
+
 	// Don't delete yet - user might refresh the page
-	return session
+	return &session
 }
 
-func cleanupExpiredGASessions() {
-	pendingGASessionsMu.Lock()
-	defer pendingGASessionsMu.Unlock()
+// deletePendingGASession removes a session once its properties are saved
+func (h *Handler) deletePendingGASession(ctx context.Context, sessionID string) {
+	if err := h.DB.DeletePendingGASession(ctx, sessionID); err != nil {
+		log.Warn().Err(err).Msg("Failed to delete pending GA session; it will expire")
+	}
+}
 
-	now := time.Now()
-	for id, session := range pendingGASessions {
-		if now.After(session.ExpiresAt) {
-			delete(pendingGASessions, id)
-		}
+func (h *Handler) cleanupExpiredGASessions() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := h.DB.DeleteExpiredPendingGASessions(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to clean up expired GA sessions")
 	}
 }
 
@@ -238,7 +281,7 @@ func (h *Handler) HandleGoogleOAuthCallback(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Exchange code for access token
-	tokenResp, err := h.exchangeGoogleCode(code)
+	tokenResp, err := h.exchangeGoogleCode(r.Context(), code)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to exchange Google OAuth code")
 		h.redirectToSettingsWithError(w, r, "Google", "Failed to connect to Google", "analytics", "google-analytics")
@@ -334,7 +377,12 @@ func (h *Handler) HandleGoogleOAuthCallback(w http.ResponseWriter, r *http.Reque
 		session.Properties = properties
 	}
 
-	sessionID := storePendingGASession(session)
+	sessionID, err := h.storePendingGASession(r.Context(), session)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to store GA4 session")
+		h.redirectToSettingsWithError(w, r, "Google", "Failed to save Google Analytics session", "analytics", "google-analytics")
+		return
+	}
 
 	logger.Info().
 		Int("account_count", len(accounts)).
@@ -481,7 +529,7 @@ func (h *Handler) SaveGoogleProperties(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get session data
-	session := getPendingGASession(req.SessionID)
+	session := h.getPendingGASession(r.Context(), req.SessionID)
 	if session == nil {
 		BadRequest(w, r, "Session expired or not found. Please reconnect to Google Analytics.")
 		return
@@ -577,9 +625,7 @@ func (h *Handler) SaveGoogleProperties(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Clean up the session after saving
-	pendingGASessionsMu.Lock()
-	delete(pendingGASessions, req.SessionID)
-	pendingGASessionsMu.Unlock()
+	h.deletePendingGASession(r.Context(), req.SessionID)
 
 	logger.Info().
 		Str("organisation_id", orgID).
@@ -760,27 +806,22 @@ func (h *Handler) UpdateGoogleConnection(w http.ResponseWriter, r *http.Request,
 	WriteSuccess(w, r, response, "Connection updated")
 }
 
-func (h *Handler) exchangeGoogleCode(code string) (*GoogleTokenResponse, error) {
+func (h *Handler) exchangeGoogleCode(ctx context.Context, code string) (*GoogleTokenResponse, error) {
+	return h.exchangeGoogleCodeForRedirect(ctx, code, getGoogleRedirectURI())
+}
+
+// exchangeGoogleCodeForRedirect exchanges an authorisation code issued to the
+// given redirect URI, which must match the one the flow started with
+func (h *Handler) exchangeGoogleCodeForRedirect(ctx context.Context, code, redirectURI string) (*GoogleTokenResponse, error) {
 	values := url.Values{}
 	values.Set("client_id", h.GoogleClientID)
 	values.Set("client_secret", h.GoogleClientSecret)
 	values.Set("grant_type", "authorization_code")
 	values.Set("code", code)
-	values.Set("redirect_uri", getGoogleRedirectURI())
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.PostForm("https://oauth2.googleapis.com/token", values)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("google API returned status: %d", resp.StatusCode)
-	}
+	values.Set("redirect_uri", redirectURI)
 
 	var tokenResp GoogleTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+	if err := postGoogleTokenForm(ctx, values, &tokenResp); err != nil {
 		return nil, err
 	}
 
@@ -1114,9 +1155,9 @@ func (h *Handler) handlePendingSession(w http.ResponseWriter, r *http.Request, p
 	MethodNotAllowed(w, r)
 }
 
-// getPendingSession returns the pending OAuth session data (accounts, properties, tokens)
+// getPendingSession returns the pending OAuth session data (accounts, properties)
 func (h *Handler) getPendingSession(w http.ResponseWriter, r *http.Request, sessionID string) {
-	session := getPendingGASession(sessionID)
+	session := h.getPendingGASession(r.Context(), sessionID)
 	if session == nil {
 		BadRequest(w, r, "Session expired or not found. Please reconnect to Google Analytics.")
 		return
@@ -1137,7 +1178,7 @@ func (h *Handler) getPendingSession(w http.ResponseWriter, r *http.Request, sess
 func (h *Handler) fetchAccountProperties(w http.ResponseWriter, r *http.Request, sessionID, accountID string) {
 	logger := loggerWithRequest(r)
 
-	session := getPendingGASession(sessionID)
+	session := h.getPendingGASession(r.Context(), sessionID)
 	if session == nil {
 		BadRequest(w, r, "Session expired or not found. Please reconnect to Google Analytics.")
 		return
@@ -1170,11 +1211,12 @@ func (h *Handler) fetchAccountProperties(w http.ResponseWriter, r *http.Request,
 	logger.Info().Str("account_id", accountID).Int("property_count", len(properties)).Msg("Properties fetched successfully")
 
 	// Update session with these properties
-	pendingGASessionsMu.Lock()
-	if s, ok := pendingGASessions[sessionID]; ok {
-		s.Properties = properties
+	session.Properties = properties
+	if err := h.savePendingGASession(r.Context(), sessionID, session); err != nil {
+		logger.Error().Err(err).Msg("Failed to update GA4 session with properties")
+		InternalError(w, r, err)
+		return
 	}
-	pendingGASessionsMu.Unlock()
 
 	WriteSuccess(w, r, accountPropertiesResponse{
 		Properties: properties,
@@ -1454,17 +1496,9 @@ func (h *Handler) RefreshGA4Accounts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Refresh the access token using the refresh token
-	accessToken, err := h.refreshGoogleAccessToken(refreshToken)
+	accessToken, err := h.refreshGoogleAccessToken(r.Context(), refreshToken)
 	if err != nil {
-		logger.Warn().
-			Err(err).
-			Str("next_action", "reauth_required").
-			Msg("Failed to refresh Google access token")
-		// Token might be revoked
-		WriteSuccess(w, r, googleReauthResponse{
-			NeedsReauth: true,
-			Message:     "Unable to refresh token. Please reconnect to Google Analytics.",
-		}, "")
+		writeGoogleRefreshFailure(w, r, logger, err)
 		return
 	}
 
@@ -1527,37 +1561,49 @@ func (h *Handler) RefreshGA4Accounts(w http.ResponseWriter, r *http.Request) {
 	}, "Accounts refreshed successfully")
 }
 
-// refreshGoogleAccessToken exchanges a refresh token for a new access token
-func (h *Handler) refreshGoogleAccessToken(refreshToken string) (string, error) {
+// refreshGoogleAccessToken exchanges a refresh token for a new access token.
+// Use isGoogleTokenRevoked on the error to tell a revoked token from an outage.
+func (h *Handler) refreshGoogleAccessToken(ctx context.Context, refreshToken string) (string, error) {
 	values := url.Values{}
 	values.Set("client_id", h.GoogleClientID)
 	values.Set("client_secret", h.GoogleClientSecret)
 	values.Set("grant_type", "refresh_token")
 	values.Set("refresh_token", refreshToken)
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.PostForm("https://oauth2.googleapis.com/token", values)
-	if err != nil {
-		return "", fmt.Errorf("failed to refresh token: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token refresh returned status: %d", resp.StatusCode)
-	}
-
 	var tokenResp struct {
 		AccessToken string `json:"access_token"`
 		ExpiresIn   int    `json:"expires_in"`
 		TokenType   string `json:"token_type"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", fmt.Errorf("failed to decode token response: %w", err)
+	if err := postGoogleTokenForm(ctx, values, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to refresh token: %w", err)
 	}
 
 	return tokenResp.AccessToken, nil
 }
 
+// writeGoogleRefreshFailure tells the dashboard whether a failed token refresh
+// needs the user to reconnect or is a Google outage worth retrying
+func writeGoogleRefreshFailure(w http.ResponseWriter, r *http.Request, logger zerolog.Logger, err error) {
+	if !isGoogleTokenRevoked(err) {
+		logger.Warn().
+			Err(err).
+			Str("next_action", "retry_later").
+			Msg("Google token endpoint unavailable")
+		ServiceUnavailable(w, r, "Google is temporarily unavailable. Please try again shortly.")
+		return
+	}
+
+	logger.Warn().
+		Err(err).
+		Str("next_action", "reauth_required").
+		Msg("Failed to refresh Google access token")
+	WriteSuccess(w, r, googleReauthResponse{
+		NeedsReauth: true,
+		Message:     "Unable to refresh token. Please reconnect to Google Analytics.",
+	}, "")
+}
+
 // getGARefreshToken resolves a usable refresh token for the organisation.
 // It prefers account-level tokens, then falls back to any connection-level token.
 func (h *Handler) getGARefreshToken(ctx context.Context, logger zerolog.Logger, organisationID string) (*db.GoogleAnalyticsAccount, string, error) {
@@ -1659,16 +1705,9 @@ func (h *Handler) GetAccountProperties(w http.ResponseWriter, r *http.Request, g
 	}
 
 	// Refresh the access token
-	accessToken, err := h.refreshGoogleAccessToken(refreshToken)
+	accessToken, err := h.refreshGoogleAccessToken(r.Context(), refreshToken)
 	if err != nil {
-		logger.Warn().
-			Err(err).
-			Str("next_action", "reauth_required").
-			Msg("Failed to refresh Google access token")
-		WriteSuccess(w, r, googleReauthResponse{
-			NeedsReauth: true,
-			Message:     "Unable to refresh token. Please reconnect to Google Analytics.",
-		}, "")
+		writeGoogleRefreshFailure(w, r, logger, err)
 		return
 	}
 
@@ -1753,16 +1792,9 @@ func (h *Handler) SaveGA4AccountProperties(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	accessToken, err := h.refreshGoogleAccessToken(refreshToken)
+	accessToken, err := h.refreshGoogleAccessToken(r.Context(), refreshToken)
 	if err != nil {
-		logger.Warn().
-			Err(err).
-			Str("next_action", "reauth_required").
-			Msg("Failed to refresh Google access token")
-		WriteSuccess(w, r, googleReauthResponse{
-			NeedsReauth: true,
-			Message:     "Unable to refresh token. Please reconnect to Google Analytics.",
-		}, "")
+		writeGoogleRefreshFailure(w, r, logger, err)
 		return
 	}
 
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingSessionDB stores pending GA sessions in memory; other DBClient methods
+// are left unimplemented
+type pendingSessionDB struct {
+	DBClient
+	data    map[string][]byte
+	tokens  map[string][]byte
+	expires map[string]time.Time
+}
+
+func newPendingSessionDB() *pendingSessionDB {
+	return &pendingSessionDB{data: map[string][]byte{}, tokens: map[string][]byte{}, expires: map[string]time.Time{}}
+}
+
+func (m *pendingSessionDB) StorePendingGASession(_ context.Context, sessionID string, data []byte, expiresAt time.Time) error {
+	m.data[sessionID] = data
+	m.expires[sessionID] = expiresAt
+	return nil
+}
+
+func (m *pendingSessionDB) StorePendingGATokens(_ context.Context, sessionID string, tokens []byte) error {
+	m.tokens[sessionID] = tokens
+	return nil
+}
+
+func (m *pendingSessionDB) GetPendingGASession(_ context.Context, sessionID string) ([]byte, []byte, time.Time, error) {
+	data, ok := m.data[sessionID]
+	if !ok || time.Now().After(m.expires[sessionID]) {
+		return nil, nil, time.Time{}, db.ErrPendingGASessionNotFound
+	}
+	return data, m.tokens[sessionID], m.expires[sessionID], nil
+}
+
+func (m *pendingSessionDB) DeletePendingGASession(_ context.Context, sessionID string) error {
+	delete(m.data, sessionID)
+	delete(m.tokens, sessionID)
+	return nil
+}
+
+func (m *pendingSessionDB) DeleteExpiredPendingGASessions(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestPendingGASessionRoundTrip(t *testing.T) {
+	store := newPendingSessionDB()
+	h := &Handler{DB: store}
+	ctx := context.Background()
+
+	sessionID, err := h.storePendingGASession(ctx, &PendingGASession{
+		Accounts:     []GA4Account{{AccountID: "accounts/1", DisplayName: "Main"}},
+		RefreshToken: "refresh",
+		AccessToken:  "access",
+		OrgID:        "org-1",
+	})
+	require.NoError(t, err)
+
+	session := h.getPendingGASession(ctx, sessionID)
+	require.NotNil(t, session)
+	assert.Equal(t, "org-1", session.OrgID)
+	assert.Equal(t, "refresh", session.RefreshToken)
+	assert.Equal(t, "access", session.AccessToken)
+	assert.Len(t, session.Accounts, 1)
+
+	// The session row never carries the tokens; they're stored separately
+	assert.NotContains(t, string(store.data[sessionID]), "refresh")
+	assert.NotContains(t, string(store.data[sessionID]), "access")
+	assert.Contains(t, string(store.tokens[sessionID]), "refresh")
+	assert.WithinDuration(t, time.Now().Add(pendingGASessionTTL), session.ExpiresAt, time.Minute)
+
+	session.Properties = []GA4Property{{PropertyID: "123", DisplayName: "Site"}}
+	require.NoError(t, h.savePendingGASession(ctx, sessionID, session))
+	updated := h.getPendingGASession(ctx, sessionID)
+	assert.Len(t, updated.Properties, 1)
+	assert.Equal(t, "refresh", updated.RefreshToken)
+
+	h.deletePendingGASession(ctx, sessionID)
+	assert.Nil(t, h.getPendingGASession(ctx, sessionID))
+}
+
+func TestGetPendingGASessionMissing(t *testing.T) {
+	h := &Handler{DB: newPendingSessionDB()}
+
+	assert.Nil(t, h.getPendingGASession(context.Background(), "not-a-uuid"))
+	assert.Nil(t, h.getPendingGASession(context.Background(), "7f1c2d3e-0000-4000-8000-000000000000"))
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/auth"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+func getSearchConsoleRedirectURI() string {
+	return getAppURL() + "/v1/integrations/search-console/callback"
+}
+
+// SearchConsoleConnectionResponse represents a Search Console connection in API responses
+type SearchConsoleConnectionResponse struct {
+	ID           string  `json:"id"`
+	SiteURL      string  `json:"site_url"`
+	DomainID     *int    `json:"domain_id,omitempty"`
+	GoogleEmail  string  `json:"google_email,omitempty"`
+	Status       string  `json:"status"`
+	LastSyncedAt *string `json:"last_synced_at,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+// SearchConsoleConnectionsHandler handles requests to /v1/integrations/search-console
+func (h *Handler) SearchConsoleConnectionsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listSearchConsoleConnections(w, r)
+	case http.MethodPost:
+		h.InitiateSearchConsoleOAuth(w, r)
+	default:
+		MethodNotAllowed(w, r)
+	}
+}
+
+// SearchConsoleConnectionHandler handles requests to /v1/integrations/search-console/:id
+func (h *Handler) SearchConsoleConnectionHandler(w http.ResponseWriter, r *http.Request) {
+	connectionID := strings.TrimPrefix(r.URL.Path, "/v1/integrations/search-console/")
+	if connectionID == "" {
+		BadRequest(w, r, "Connection ID is required")
+		return
+	}
+	if _, err := uuid.Parse(connectionID); err != nil {
+		BadRequest(w, r, "Invalid connection ID format")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		h.deleteSearchConsoleConnection(w, r, connectionID)
+	default:
+		MethodNotAllowed(w, r)
+	}
+}
+
+// InitiateSearchConsoleOAuth starts the Search Console OAuth flow
+func (h *Handler) InitiateSearchConsoleOAuth(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	userClaims, ok := auth.GetUserFromContext(r.Context())
+	if !ok {
+		Unauthorised(w, r, "User information not found")
+		return
+	}
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	if h.GoogleClientID == "" {
+		logger.Error().Msg("GOOGLE_CLIENT_ID not configured")
+		InternalError(w, r, fmt.Errorf("google integration not configured"))
+		return
+	}
+
+	state, err := h.generateOAuthState(userClaims.UserID, orgID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to generate OAuth state")
+		InternalError(w, r, err)
+		return
+	}
+
+	// Scopes needed:
+	// - webmasters.readonly: Read Search Console sites and search analytics
+	// - userinfo.email: Get user's email for display
+	scopes := "https://www.googleapis.com/auth/webmasters.readonly https://www.googleapis.com/auth/userinfo.email"
+
+	authURL := fmt.Sprintf(
+		"https://accounts.google.com/o/oauth2/v2/auth?client_id=%s&redirect_uri=%s&response_type=code&scope=%s&access_type=offline&prompt=consent&state=%s",
+		url.QueryEscape(h.GoogleClientID),
+		url.QueryEscape(getSearchConsoleRedirectURI()),
+		url.QueryEscape(scopes),
+		url.QueryEscape(state),
+	)
+
+	WriteSuccess(w, r, map[string]string{"auth_url": authURL}, "Redirect to this URL to connect Search Console")
+}
+
+// HandleSearchConsoleOAuthCallback processes the OAuth callback from Google.
+// Every verified site is stored as a connection; jobs use the one matching
+// their domain.
+func (h *Handler) HandleSearchConsoleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	stateParam := r.URL.Query().Get("state")
+
+	if errParam := r.URL.Query().Get("error"); errParam != "" {
+		logger.Warn().Str("error", errParam).Msg("Search Console OAuth denied")
+		h.redirectToSettingsWithError(w, r, "search_console", "Search Console connection was cancelled", "analytics", "search-console")
+		return
+	}
+
+	if code == "" || stateParam == "" {
+		BadRequest(w, r, "Missing code or state parameter")
+		return
+	}
+
+	state, err := h.validateOAuthState(stateParam)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid OAuth state")
+		h.redirectToSettingsWithError(w, r, "search_console", "Invalid or expired state", "analytics", "search-console")
+		return
+	}
+
+	tokenResp, err := h.exchangeGoogleCodeForRedirect(r.Context(), code, getSearchConsoleRedirectURI())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to exchange Search Console OAuth code")
+		h.redirectToSettingsWithError(w, r, "search_console", "Failed to connect to Google", "analytics", "search-console")
+		return
+	}
+	if tokenResp.RefreshToken == "" {
+		logger.Error().Msg("Google returned no refresh token for Search Console")
+		h.redirectToSettingsWithError(w, r, "search_console", "Google did not grant offline access. Please try again.", "analytics", "search-console")
+		return
+	}
+
+	userInfo, err := h.fetchGoogleUserInfo(r.Context(), tokenResp.AccessToken)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to fetch Google user info")
+	}
+
+	sites, err := fetchSearchConsoleSites(r.Context(), tokenResp.AccessToken)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to fetch Search Console sites")
+		h.redirectToSettingsWithError(w, r, "search_console", "Failed to fetch Search Console sites", "analytics", "search-console")
+		return
+	}
+	if len(sites) == 0 {
+		h.redirectToSettingsWithError(w, r, "search_console", "No verified Search Console sites found", "analytics", "search-console")
+		return
+	}
+
+	connected := h.saveSearchConsoleSites(r.Context(), logger, state, userInfo, sites, tokenResp.RefreshToken)
+	if connected == 0 {
+		h.redirectToSettingsWithError(w, r, "search_console", "Failed to save Search Console sites", "analytics", "search-console")
+		return
+	}
+
+	logger.Info().
+		Str("organisation_id", state.OrgID).
+		Int("site_count", connected).
+		Msg("Search Console sites connected")
+
+	h.redirectToSettingsWithSuccess(w, r, "search_console", fmt.Sprintf("%d sites", connected), "", "analytics", "search-console")
+}
+
+// saveSearchConsoleSites stores a connection and vault token for each site and
+// returns how many were saved
+func (h *Handler) saveSearchConsoleSites(ctx context.Context, logger zerolog.Logger, state *OAuthState, userInfo *GoogleUserInfo, sites []searchConsoleSite, refreshToken string) int {
+	var saved int
+	for _, site := range sites {
+		conn := &db.SearchConsoleConnection{
+			OrganisationID:   state.OrgID,
+			SiteURL:          site.SiteURL,
+			InstallingUserID: state.UserID,
+		}
+		if userInfo != nil {
+			conn.GoogleUserID = userInfo.ID
+			conn.GoogleEmail = userInfo.Email
+		}
+
+		if domain := searchConsoleSiteDomain(site.SiteURL); domain != "" {
+			domainID, err := h.DB.GetOrCreateDomainID(ctx, domain)
+			if err != nil {
+				logger.Warn().Err(err).Str("site_url", site.SiteURL).Msg("Failed to resolve domain for Search Console site")
+			} else {
+				conn.DomainID = &domainID
+			}
+		}
+
+		if err := h.DB.UpsertSearchConsoleConnection(ctx, conn); err != nil {
+			logger.Warn().Err(err).
+				Str("site_url", site.SiteURL).
+				Str("next_action", "retry_connection_or_check_db_connectivity").
+				Msg("Failed to save Search Console site")
+			continue
+		}
+		if err := h.DB.StoreSearchConsoleToken(ctx, conn.ID, refreshToken); err != nil {
+			logger.Warn().Err(err).
+				Str("connection_id", conn.ID).
+				Str("next_action", "reconnect_or_check_vault_permissions").
+				Msg("Failed to store Search Console token")
+			continue
+		}
+		saved++
+	}
+	return saved
+}
+
+// listSearchConsoleConnections lists the organisation's Search Console connections
+func (h *Handler) listSearchConsoleConnections(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	connections, err := h.DB.ListSearchConsoleConnections(r.Context(), orgID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list Search Console connections")
+		InternalError(w, r, err)
+		return
+	}
+
+	response := make([]SearchConsoleConnectionResponse, 0, len(connections))
+	for _, conn := range connections {
+		item := SearchConsoleConnectionResponse{
+			ID:          conn.ID,
+			SiteURL:     conn.SiteURL,
+			DomainID:    conn.DomainID,
+			GoogleEmail: conn.GoogleEmail,
+			Status:      conn.Status,
+			CreatedAt:   conn.CreatedAt.Format(time.RFC3339),
+		}
+		if !conn.LastSyncedAt.IsZero() {
+			synced := conn.LastSyncedAt.Format(time.RFC3339)
+			item.LastSyncedAt = &synced
+		}
+		response = append(response, item)
+	}
+
+	WriteSuccess(w, r, response, "")
+}
+
+// deleteSearchConsoleConnection deletes a Search Console connection and its token
+func (h *Handler) deleteSearchConsoleConnection(w http.ResponseWriter, r *http.Request, connectionID string) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	if err := h.DB.DeleteSearchConsoleConnection(r.Context(), connectionID, orgID); err != nil {
+		if errors.Is(err, db.ErrSearchConsoleConnectionNotFound) {
+			NotFound(w, r, "Search Console connection not found")
+			return
+		}
+		logger.Error().Err(err).Msg("Failed to delete Search Console connection")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().Str("connection_id", connectionID).Msg("Search Console connection deleted")
+	WriteNoContent(w, r)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/auth"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+)
+
+// CancelJobsResponse reports the outcome of a bulk cancel
+type CancelJobsResponse struct {
+	Domain    string `json:"domain,omitempty"` // Empty for an organisation-wide cancel
+	Cancelled int    `json:"cancelled"`
+}
+
+// cancelDomainJobs handles POST /v1/domains/{domain}/cancel - cancels every
+// pending, initialising, running or paused job the organisation has for a domain
+func (h *Handler) cancelDomainJobs(w http.ResponseWriter, r *http.Request, domain string) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	normalisedDomain := util.NormaliseDomain(domain)
+	if err := util.ValidateDomain(normalisedDomain); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	domains, err := h.DB.GetDomainsForOrganisation(r.Context(), orgID)
+	if err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to list organisation domains")
+		InternalError(w, r, err)
+		return
+	}
+	if !slices.ContainsFunc(domains, func(d db.OrganisationDomain) bool { return d.Name == normalisedDomain }) {
+		NotFound(w, r, "Domain not found")
+		return
+	}
+
+	cancelled, err := h.JobsManager.CancelActiveJobs(r.Context(), orgID, normalisedDomain)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).
+			Str("organisation_id", orgID).
+			Str("domain", normalisedDomain).
+			Int("cancelled", cancelled).
+			Msg("Failed to cancel active jobs for domain")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, CancelJobsResponse{Domain: normalisedDomain, Cancelled: cancelled}, "Active jobs cancelled")
+}
+
+// OrganisationCancelJobsHandler handles POST /v1/organisations/cancel-jobs -
+// cancels every active job in the organisation. Admins only.
+func (h *Handler) OrganisationCancelJobsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	userClaims, ok := auth.GetUserFromContext(r.Context())
+	if !ok {
+		Unauthorised(w, r, "User information not found")
+		return
+	}
+
+	if ok := h.requireOrganisationAdmin(w, r, orgID, userClaims.UserID); !ok {
+		return
+	}
+
+	cancelled, err := h.JobsManager.CancelActiveJobs(r.Context(), orgID, "")
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).
+			Str("organisation_id", orgID).
+			Int("cancelled", cancelled).
+			Msg("Failed to cancel active jobs for organisation")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, CancelJobsResponse{Cancelled: cancelled}, "Active jobs cancelled")
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/cdnpurge"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+)
+
+// CDNPurgeConnectionResponse represents a CDN purge connection in API responses.
+// The API token is write-only and never returned.
+type CDNPurgeConnectionResponse struct {
+	ID        string `json:"id"`
+	Provider  string `json:"provider"`
+	ZoneID    string `json:"zone_id"`
+	SoftPurge bool   `json:"soft_purge"`
+	Enabled   bool   `json:"enabled"`
+	HasToken  bool   `json:"has_token"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// CDNPurgeConnectionRequest configures the post-completion CDN purge hook
+type CDNPurgeConnectionRequest struct {
+	Provider  string `json:"provider"`
+	ZoneID    string `json:"zone_id"`
+	APIToken  string `json:"api_token,omitempty"` // Optional on update to keep the stored token
+	SoftPurge *bool  `json:"soft_purge,omitempty"`
+	Enabled   *bool  `json:"enabled,omitempty"`
+}
+
+// CDNPurgeHandler handles requests to /v1/integrations/cdn-purge
+func (h *Handler) CDNPurgeHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getCDNPurgeConnection(w, r)
+	case http.MethodPut:
+		h.saveCDNPurgeConnection(w, r)
+	case http.MethodDelete:
+		h.deleteCDNPurgeConnection(w, r)
+	default:
+		MethodNotAllowed(w, r)
+	}
+}
+
+func toCDNPurgeConnectionResponse(conn *db.CDNPurgeConnection) CDNPurgeConnectionResponse {
+	return CDNPurgeConnectionResponse{
+		ID:        conn.ID,
+		Provider:  conn.Provider,
+		ZoneID:    conn.ZoneID,
+		SoftPurge: conn.SoftPurge,
+		Enabled:   conn.Enabled,
+		HasToken:  conn.VaultSecretName != "",
+		UpdatedAt: conn.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *Handler) getCDNPurgeConnection(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	conn, err := h.DB.GetCDNPurgeConnection(r.Context(), orgID)
+	if err != nil {
+		if errors.Is(err, db.ErrCDNPurgeConnectionNotFound) {
+			WriteSuccess(w, r, nil, "No CDN purge connection configured")
+			return
+		}
+		logger.Error().Err(err).Msg("Failed to get CDN purge connection")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, toCDNPurgeConnectionResponse(conn), "")
+}
+
+func (h *Handler) saveCDNPurgeConnection(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	user, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return
+	}
+	if !h.requireOrganisationAdmin(w, r, orgID, user.ID) {
+		return
+	}
+
+	var req CDNPurgeConnectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	req.Provider = strings.ToLower(strings.TrimSpace(req.Provider))
+	req.ZoneID = strings.TrimSpace(req.ZoneID)
+	req.APIToken = strings.TrimSpace(req.APIToken)
+
+	if !cdnpurge.IsSupportedProvider(req.Provider) {
+		BadRequest(w, r, "provider must be 'cloudflare' or 'fastly'")
+		return
+	}
+	if req.Provider == cdnpurge.ProviderCloudflare && req.ZoneID == "" {
+		BadRequest(w, r, "zone_id is required for Cloudflare")
+		return
+	}
+	if req.Provider == cdnpurge.ProviderCloudflare && req.SoftPurge != nil && *req.SoftPurge {
+		BadRequest(w, r, "soft_purge is only supported for Fastly; Cloudflare purges evict cached copies")
+		return
+	}
+
+	existing, err := h.DB.GetCDNPurgeConnection(r.Context(), orgID)
+	if err != nil && !errors.Is(err, db.ErrCDNPurgeConnectionNotFound) {
+		logger.Error().Err(err).Msg("Failed to get CDN purge connection")
+		InternalError(w, r, err)
+		return
+	}
+	if req.APIToken == "" && (existing == nil || existing.VaultSecretName == "") {
+		BadRequest(w, r, "api_token is required")
+		return
+	}
+
+	conn := &db.CDNPurgeConnection{
+		OrganisationID:  orgID,
+		Provider:        req.Provider,
+		ZoneID:          req.ZoneID,
+		SoftPurge:       req.Provider == cdnpurge.ProviderFastly, // Cloudflare can only evict
+		Enabled:         true,
+		CreatedByUserID: &user.ID,
+	}
+	if req.SoftPurge != nil {
+		conn.SoftPurge = *req.SoftPurge
+	}
+	if req.Enabled != nil {
+		conn.Enabled = *req.Enabled
+	}
+
+	if err := h.DB.UpsertCDNPurgeConnection(r.Context(), conn); err != nil {
+		logger.Error().Err(err).Msg("Failed to save CDN purge connection")
+		InternalError(w, r, err)
+		return
+	}
+
+	if req.APIToken != "" {
+		if err := h.DB.StoreCDNPurgeToken(r.Context(), conn.ID, req.APIToken); err != nil {
+			logger.Error().Err(err).Msg("Failed to store CDN purge token")
+			InternalError(w, r, err)
+			return
+		}
+	}
+
+	logger.Info().
+		Str("organisation_id", orgID).
+		Str("provider", conn.Provider).
+		Bool("enabled", conn.Enabled).
+		Msg("CDN purge connection saved")
+
+	response := toCDNPurgeConnectionResponse(conn)
+	response.HasToken = true // Either just stored or already present
+	WriteSuccess(w, r, response, "CDN purge connection saved")
+}
+
+func (h *Handler) deleteCDNPurgeConnection(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	user, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return
+	}
+	if !h.requireOrganisationAdmin(w, r, orgID, user.ID) {
+		return
+	}
+
+	if err := h.DB.DeleteCDNPurgeConnection(r.Context(), orgID); err != nil {
+		if errors.Is(err, db.ErrCDNPurgeConnectionNotFound) {
+			NotFound(w, r, "CDN purge connection not found")
+			return
+		}
+		logger.Error().Err(err).Msg("Failed to delete CDN purge connection")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().Str("organisation_id", orgID).Msg("CDN purge connection deleted")
+	WriteNoContent(w, r)
+}
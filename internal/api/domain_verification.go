@@ -0,0 +1,61 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+)
+
+// verifyDomain handles POST /v1/domains/{domain}/verify - checks the
+// organisation has published its verification token for the domain and
+// returns the verification state with instructions
+func (h *Handler) verifyDomain(w http.ResponseWriter, r *http.Request, domain string) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	normalisedDomain := util.NormaliseDomain(domain)
+	if err := util.ValidateDomain(normalisedDomain); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	verification, err := h.JobsManager.VerifyDomain(r.Context(), orgID, normalisedDomain)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("organisation_id", orgID).Str("domain", normalisedDomain).Msg("Failed to verify domain")
+		InternalError(w, r, err)
+		return
+	}
+
+	message := "Domain verified successfully"
+	if !verification.Verified {
+		message = "Verification token not found; publish the DNS record or file and retry"
+	}
+	WriteSuccess(w, r, verification, message)
+}
+
+// handleCreateJobError writes the response for job creation errors the
+// client can act on, returning false for anything else
+func handleCreateJobError(w http.ResponseWriter, r *http.Request, err error) bool {
+	if errors.Is(err, jobs.ErrDomainNotVerified) {
+		Forbidden(w, r, err.Error())
+		return true
+	}
+	if errors.Is(err, jobs.ErrDuplicateJob) {
+		WriteErrorMessage(w, r, err.Error(), http.StatusConflict, ErrCodeConflict)
+		return true
+	}
+	if errors.Is(err, jobs.ErrInvalidJobOptions) {
+		BadRequest(w, r, err.Error())
+		return true
+	}
+	return HandlePoolSaturation(w, r, err)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleCreateJobError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		handled bool
+		status  int
+	}{
+		{name: "unverified_domain", err: fmt.Errorf("create job: %w", jobs.ErrDomainNotVerified), handled: true, status: http.StatusForbidden},
+		{name: "duplicate_job", err: fmt.Errorf("%w (job job-1 is running)", jobs.ErrDuplicateJob), handled: true, status: http.StatusConflict},
+		{name: "invalid_options", err: fmt.Errorf("%w: max_depth must not be negative", jobs.ErrInvalidJobOptions), handled: true, status: http.StatusBadRequest},
+		{name: "pool_saturated", err: db.ErrPoolSaturated, handled: true, status: http.StatusServiceUnavailable},
+		{name: "other_error", err: errors.New("boom"), handled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/v1/jobs", nil)
+
+			assert.Equal(t, tt.handled, handleCreateJobError(w, r, tt.err))
+			if tt.handled {
+				assert.Equal(t, tt.status, w.Code)
+			}
+		})
+	}
+}
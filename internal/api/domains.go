@@ -2,8 +2,15 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 )
 
@@ -18,6 +25,12 @@ type DomainResponse struct {
 	Domain   string `json:"domain"`
 }
 
+// RobotsPreviewRequest represents the request body for POST /v1/domains/{id}/robots-preview
+type RobotsPreviewRequest struct {
+	IncludePaths []string `json:"include_paths,omitempty"`
+	ExcludePaths []string `json:"exclude_paths,omitempty"`
+}
+
 // DomainsHandler handles requests to /v1/domains
 func (h *Handler) DomainsHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -77,3 +90,194 @@ func (h *Handler) createDomain(w http.ResponseWriter, r *http.Request) {
 
 	WriteCreated(w, r, response, "Domain registered successfully")
 }
+
+// DomainHandler handles requests to /v1/domains/{id}/... and /v1/domains/{domain}/{stats,technologies,cancel,verify}
+func (h *Handler) DomainHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/domains/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		NotFound(w, r, "Endpoint not found")
+		return
+	}
+
+	switch parts[1] {
+	case "robots-preview":
+		if r.Method != http.MethodPost {
+			MethodNotAllowed(w, r)
+			return
+		}
+		domainID, err := strconv.Atoi(parts[0])
+		if err != nil || domainID <= 0 {
+			BadRequest(w, r, "Invalid domain ID")
+			return
+		}
+		h.previewDomainRobots(w, r, domainID)
+	case "stats":
+		if r.Method != http.MethodGet {
+			MethodNotAllowed(w, r)
+			return
+		}
+		h.getDomainStats(w, r, parts[0])
+	case "technologies":
+		if r.Method != http.MethodGet {
+			MethodNotAllowed(w, r)
+			return
+		}
+		h.getDomainTechnologies(w, r, parts[0])
+	case "cancel":
+		if r.Method != http.MethodPost {
+			MethodNotAllowed(w, r)
+			return
+		}
+		h.cancelDomainJobs(w, r, parts[0])
+	case "verify":
+		if r.Method != http.MethodPost {
+			MethodNotAllowed(w, r)
+			return
+		}
+		h.verifyDomain(w, r, parts[0])
+	default:
+		NotFound(w, r, "Endpoint not found")
+	}
+}
+
+// previewDomainRobots handles POST /v1/domains/{id}/robots-preview - reports how
+// robots.txt and the intended path filters would limit a job's sitemap URLs
+func (h *Handler) previewDomainRobots(w http.ResponseWriter, r *http.Request, domainID int) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	// Body is optional; without one the preview covers all sitemap URLs
+	var req RobotsPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+
+	domains, err := h.DB.GetDomainsForOrganisation(r.Context(), orgID)
+	if err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to list organisation domains")
+		InternalError(w, r, err)
+		return
+	}
+
+	domainName := ""
+	for _, d := range domains {
+		if d.ID == domainID {
+			domainName = d.Name
+			break
+		}
+	}
+	if domainName == "" {
+		NotFound(w, r, "Domain not found")
+		return
+	}
+
+	preview, err := h.JobsManager.PreviewRobots(r.Context(), domainName, req.IncludePaths, req.ExcludePaths)
+	if err != nil {
+		logger.Error().Err(err).Str("domain", domainName).Msg("Failed to preview robots.txt impact")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, preview, "Robots.txt preview generated")
+}
+
+// getDomainStats handles GET /v1/domains/{domain}/stats - aggregates warming
+// results for a domain across all of the organisation's jobs
+func (h *Handler) getDomainStats(w http.ResponseWriter, r *http.Request, domain string) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	normalisedDomain := util.NormaliseDomain(domain)
+	if err := util.ValidateDomain(normalisedDomain); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	since, err := parseSinceParam(r.URL.Query().Get("since"))
+	if err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	domains, err := h.DB.GetDomainsForOrganisation(r.Context(), orgID)
+	if err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to list organisation domains")
+		InternalError(w, r, err)
+		return
+	}
+	if !slices.ContainsFunc(domains, func(d db.OrganisationDomain) bool { return d.Name == normalisedDomain }) {
+		NotFound(w, r, "Domain not found")
+		return
+	}
+
+	stats, err := h.DB.GetDomainStats(r.Context(), orgID, normalisedDomain, since)
+	if err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Str("domain", normalisedDomain).Msg("Failed to get domain stats")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, stats, "Domain stats retrieved successfully")
+}
+
+// getDomainTechnologies handles GET /v1/domains/{domain}/technologies - the
+// tech stack, response headers and HTML sample path from the domain's last
+// technology detection
+func (h *Handler) getDomainTechnologies(w http.ResponseWriter, r *http.Request, domain string) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	normalisedDomain := util.NormaliseDomain(domain)
+	if err := util.ValidateDomain(normalisedDomain); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	domains, err := h.DB.GetDomainsForOrganisation(r.Context(), orgID)
+	if err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to list organisation domains")
+		InternalError(w, r, err)
+		return
+	}
+	index := slices.IndexFunc(domains, func(d db.OrganisationDomain) bool { return d.Name == normalisedDomain })
+	if index < 0 {
+		NotFound(w, r, "Domain not found")
+		return
+	}
+
+	tech, err := h.DB.GetDomainTechnologies(r.Context(), domains[index].ID)
+	if err != nil {
+		logger.Error().Err(err).Str("domain", normalisedDomain).Msg("Failed to get domain technologies")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, tech, "Domain technologies retrieved successfully")
+}
+
+// parseSinceParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date
+func parseSinceParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, errors.New("since must be an RFC 3339 timestamp or YYYY-MM-DD date")
+}
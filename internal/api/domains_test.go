@@ -0,0 +1,26 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSinceParam(t *testing.T) {
+	since, err := parseSinceParam("")
+	require.NoError(t, err)
+	assert.Nil(t, since, "an empty value covers all history")
+
+	since, err = parseSinceParam("2026-10-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), *since)
+
+	since, err = parseSinceParam("2026-10-01T09:00:00+10:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 30, 23, 0, 0, 0, time.UTC), *since)
+
+	_, err = parseSinceParam("last week")
+	assert.Error(t, err)
+}
@@ -37,6 +37,7 @@ const (
 	// Server errors (5xx)
 	ErrCodeInternal           ErrorCode = "INTERNAL_ERROR"
 	ErrCodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrCodeServiceBusy        ErrorCode = "SERVICE_BUSY"
 	ErrCodeDatabaseError      ErrorCode = "DATABASE_ERROR"
 )
 
@@ -162,22 +163,38 @@ func ServiceUnavailable(w http.ResponseWriter, r *http.Request, message string)
 
 // TooManyRequests responds with 429 and Retry-After header
 func TooManyRequests(w http.ResponseWriter, r *http.Request, message string, retryAfter time.Duration) {
+	setRetryAfter(w, retryAfter)
+	WriteErrorMessage(w, r, message, http.StatusTooManyRequests, ErrCodeRateLimit)
+}
+
+// ServiceBusy responds with 503 and Retry-After header when the service is
+// temporarily overloaded
+func ServiceBusy(w http.ResponseWriter, r *http.Request, message string, retryAfter time.Duration) {
+	setRetryAfter(w, retryAfter)
+	WriteErrorMessage(w, r, message, http.StatusServiceUnavailable, ErrCodeServiceBusy)
+}
+
+// setRetryAfter sets Retry-After in whole seconds, defaulting to 3
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
 	seconds := int(math.Ceil(retryAfter.Seconds()))
 	if seconds <= 0 {
 		seconds = 3
 	}
 	w.Header().Set("Retry-After", strconv.Itoa(seconds))
-	WriteErrorMessage(w, r, message, http.StatusTooManyRequests, ErrCodeRateLimit)
 }
 
-// HandlePoolSaturation writes a 429 when the error indicates pool exhaustion.
+// HandlePoolSaturation writes a 503 with Retry-After when the error indicates
+// pool exhaustion.
 func HandlePoolSaturation(w http.ResponseWriter, r *http.Request, err error) bool {
-	if err == nil {
+	if err == nil || !errors.Is(err, db.ErrPoolSaturated) {
 		return false
 	}
-	if errors.Is(err, db.ErrPoolSaturated) {
-		TooManyRequests(w, r, "Database is busy, please retry shortly", 3*time.Second)
-		return true
+
+	retryAfter := db.PoolBusyRetryAfter
+	var busy *db.PoolBusyError
+	if errors.As(err, &busy) && busy.RetryAfter > 0 {
+		retryAfter = busy.RetryAfter
 	}
-	return false
+	ServiceBusy(w, r, "Database is busy, please retry shortly", retryAfter)
+	return true
 }
@@ -3,10 +3,13 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -85,3 +88,37 @@ func TestErrorCodesConstants(t *testing.T) {
 	assert.Equal(t, ErrorCode("NOT_FOUND"), ErrCodeNotFound)
 	assert.Equal(t, ErrorCode("INTERNAL_ERROR"), ErrCodeInternal)
 }
+
+// TestHandlePoolSaturation verifies saturation errors map to 503 with Retry-After
+func TestHandlePoolSaturation(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		handled    bool
+		retryAfter string
+	}{
+		{name: "busy_error", err: fmt.Errorf("create job: %w", &db.PoolBusyError{RetryAfter: 5 * time.Second}), handled: true, retryAfter: "5"},
+		{name: "pool_saturated", err: db.ErrPoolSaturated, handled: true, retryAfter: "3"},
+		{name: "other_error", err: errors.New("boom"), handled: false},
+		{name: "nil", err: nil, handled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/v1/jobs", nil)
+
+			assert.Equal(t, tt.handled, HandlePoolSaturation(w, r, tt.err))
+			if !tt.handled {
+				return
+			}
+
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+
+			var response ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, string(ErrCodeServiceBusy), response.Code)
+		})
+	}
+}
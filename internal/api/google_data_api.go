@@ -154,7 +154,8 @@ func NewGA4Client(accessToken, clientID, clientSecret string) *GA4Client {
 }
 
 // RefreshAccessToken exchanges a refresh token for a new access token
-// Uses application/x-www-form-urlencoded as required by OAuth 2.0 RFC 6749
+// Uses application/x-www-form-urlencoded as required by OAuth 2.0 RFC 6749.
+// Transient failures are retried with backoff.
 func (c *GA4Client) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
 	// Build form data per OAuth 2.0 spec (RFC 6749)
 	formData := url.Values{}
@@ -163,26 +164,9 @@ func (c *GA4Client) RefreshAccessToken(ctx context.Context, refreshToken string)
 	formData.Set("refresh_token", refreshToken)
 	formData.Set("grant_type", "refresh_token")
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://oauth2.googleapis.com/token", strings.NewReader(formData.Encode()))
-	if err != nil {
-		return "", fmt.Errorf("failed to create token refresh request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute token refresh request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("token refresh failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var tokenResp tokenRefreshResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", fmt.Errorf("failed to decode token refresh response: %w", err)
+	if err := postGoogleTokenForm(ctx, formData, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to refresh access token: %w", err)
 	}
 
 	log.Debug().
@@ -506,17 +490,21 @@ func (pf *ProgressiveFetcher) FetchAndUpdatePages(ctx context.Context, organisat
 	client := NewGA4Client("", pf.clientID, pf.clientSecret)
 	accessToken, err := client.RefreshAccessToken(ctx, refreshToken)
 	if err != nil {
-		// Mark connection inactive on auth failure
 		log.Error().
 			Err(err).
 			Str("connection_id", conn.ID).
+			Bool("revoked", isGoogleTokenRevoked(err)).
 			Msg("Failed to refresh access token")
 
-		if markErr := pf.db.MarkConnectionInactive(ctx, conn.ID, "token refresh failed"); markErr != nil {
-			log.Error().
-				Err(markErr).
-				Str("connection_id", conn.ID).
-				Msg("Failed to mark connection inactive after token refresh failure")
+		// Only a rejected token needs reconnecting; outages leave the
+		// connection active for the next job
+		if isGoogleTokenRevoked(err) {
+			if markErr := pf.db.MarkConnectionInactive(ctx, conn.ID, "token refresh failed"); markErr != nil {
+				log.Error().
+					Err(markErr).
+					Str("connection_id", conn.ID).
+					Msg("Failed to mark connection inactive after token refresh failure")
+			}
 		}
 
 		return fmt.Errorf("failed to refresh access token: %w", err)
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Google token endpoint retry policy. Only network errors, 429 and 5xx are
+// retried; a 400 or 401 means the code or refresh token is bad.
+const (
+	googleTokenMaxAttempts = 3
+	googleTokenTimeout     = 30 * time.Second
+)
+
+var (
+	// googleTokenURL is Google's OAuth token endpoint, overridden in tests
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+	// googleTokenBaseBackoff doubles per retry, plus up to the same again in jitter
+	googleTokenBaseBackoff = 500 * time.Millisecond
+)
+
+// GoogleTokenError is a non-200 response from Google's token endpoint
+type GoogleTokenError struct {
+	StatusCode int
+}
+
+func (err *GoogleTokenError) Error() string {
+	return fmt.Sprintf("google token endpoint returned status %d", err.StatusCode)
+}
+
+// isGoogleTokenRevoked reports whether a token request failed because the
+// code or refresh token was rejected, so the user has to reconnect. Other
+// failures are transient and worth retrying later.
+func isGoogleTokenRevoked(err error) bool {
+	var tokenErr *GoogleTokenError
+	if !errors.As(err, &tokenErr) {
+		return false
+	}
+	return tokenErr.StatusCode == http.StatusBadRequest || tokenErr.StatusCode == http.StatusUnauthorized
+}
+
+func isRetryableGoogleTokenStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// googleTokenBackoff returns the wait before retry n (1-based): the base
+// doubled per retry plus random jitter of up to the same again
+func googleTokenBackoff(n int) time.Duration {
+	backoff := googleTokenBaseBackoff << (n - 1)
+	return backoff + rand.N(backoff+1) //nolint:gosec // jitter is not security-sensitive
+}
+
+// postGoogleTokenForm posts form values to Google's token endpoint and decodes
+// the JSON response into out, retrying transient failures with backoff
+func postGoogleTokenForm(ctx context.Context, values url.Values, out any) error {
+	client := &http.Client{Timeout: googleTokenTimeout}
+	body := values.Encode()
+
+	var lastErr error
+	for attempt := 1; attempt <= googleTokenMaxAttempts; attempt++ {
+		if attempt > 1 {
+			wait := googleTokenBackoff(attempt - 1)
+			log.Debug().Err(lastErr).Int("attempt", attempt).Dur("backoff", wait).Msg("Retrying Google token request")
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("google token request cancelled: %w", errors.Join(ctx.Err(), lastErr))
+			case <-time.After(wait):
+			}
+		}
+
+		retry, err := doGoogleTokenRequest(ctx, client, body, out)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			return err
+		}
+	}
+
+	return fmt.Errorf("google token request failed after %d attempts: %w", googleTokenMaxAttempts, lastErr)
+}
+
+// doGoogleTokenRequest makes one token request and reports whether a failure
+// is worth retrying
+func doGoogleTokenRequest(ctx context.Context, client *http.Client, body string, out any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleTokenURL, strings.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Network errors are transient unless the caller gave up
+		return ctx.Err() == nil, fmt.Errorf("failed to execute token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return isRetryableGoogleTokenStatus(resp.StatusCode), &GoogleTokenError{StatusCode: resp.StatusCode}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	return false, nil
+}
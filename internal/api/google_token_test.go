@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useGoogleTokenServer points the token endpoint at a test server that fails
+// with the given statuses before succeeding, and returns the request count
+func useGoogleTokenServer(t *testing.T, failures ...int) *atomic.Int32 {
+	t.Helper()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		assert.Equal(t, "refresh_token", r.FormValue("grant_type"))
+		if n <= len(failures) {
+			w.WriteHeader(failures[n-1])
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "fresh", "expires_in": 3600})
+	}))
+	t.Cleanup(server.Close)
+
+	originalURL, originalBackoff := googleTokenURL, googleTokenBaseBackoff
+	googleTokenURL = server.URL
+	googleTokenBaseBackoff = time.Millisecond
+	t.Cleanup(func() {
+		googleTokenURL = originalURL
+		googleTokenBaseBackoff = originalBackoff
+	})
+
+	return &calls
+}
+
+func TestRefreshGoogleAccessTokenRetriesServerErrors(t *testing.T) {
+	calls := useGoogleTokenServer(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	h := &Handler{GoogleClientID: "id", GoogleClientSecret: "secret"}
+
+	token, err := h.refreshGoogleAccessToken(context.Background(), "refresh")
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", token)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRefreshGoogleAccessTokenGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := useGoogleTokenServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	h := &Handler{GoogleClientID: "id", GoogleClientSecret: "secret"}
+
+	_, err := h.refreshGoogleAccessToken(context.Background(), "refresh")
+	require.Error(t, err)
+	assert.False(t, isGoogleTokenRevoked(err))
+	assert.Equal(t, int32(googleTokenMaxAttempts), calls.Load())
+}
+
+func TestRefreshGoogleAccessTokenDoesNotRetryRevoked(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized} {
+		t.Run(fmt.Sprint(status), func(t *testing.T) {
+			calls := useGoogleTokenServer(t, status)
+			h := &Handler{GoogleClientID: "id", GoogleClientSecret: "secret"}
+
+			_, err := h.refreshGoogleAccessToken(context.Background(), "refresh")
+			require.Error(t, err)
+			assert.True(t, isGoogleTokenRevoked(err))
+			assert.Equal(t, int32(1), calls.Load())
+		})
+	}
+}
+
+func TestPostGoogleTokenFormRetriesNetworkErrors(t *testing.T) {
+	calls := useGoogleTokenServer(t)
+	server := googleTokenURL
+
+	// Nothing listens on the first URL, so the request fails before reaching Google
+	googleTokenURL = "http://127.0.0.1:1"
+
+	var out tokenRefreshResponse
+	err := postGoogleTokenForm(context.Background(), url.Values{"grant_type": {"refresh_token"}}, &out)
+	require.Error(t, err)
+	assert.False(t, isGoogleTokenRevoked(err))
+	assert.Contains(t, err.Error(), fmt.Sprintf("after %d attempts", googleTokenMaxAttempts))
+	assert.Zero(t, calls.Load())
+
+	googleTokenURL = server
+	require.NoError(t, postGoogleTokenForm(context.Background(), url.Values{"grant_type": {"refresh_token"}}, &out))
+	assert.Equal(t, "fresh", out.AccessToken)
+}
+
+func TestPostGoogleTokenFormStopsWhenCancelled(t *testing.T) {
+	useGoogleTokenServer(t, http.StatusInternalServerError)
+	googleTokenBaseBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var out tokenRefreshResponse
+	err := postGoogleTokenForm(ctx, url.Values{"grant_type": {"refresh_token"}}, &out)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestIsGoogleTokenRevoked(t *testing.T) {
+	assert.True(t, isGoogleTokenRevoked(fmt.Errorf("wrapped: %w", &GoogleTokenError{StatusCode: http.StatusBadRequest})))
+	assert.False(t, isGoogleTokenRevoked(&GoogleTokenError{StatusCode: http.StatusInternalServerError}))
+	assert.False(t, isGoogleTokenRevoked(errors.New("connection refused")))
+}
@@ -109,7 +109,7 @@ type DBClient interface {
 	CreateUser(userID, email string, firstName, lastName, fullName *string, orgName string) (*db.User, *db.Organisation, error)
 	GetOrganisation(organisationID string) (*db.Organisation, error)
 	ListJobs(organisationID string, limit, offset int, status, dateRange, timezone string) ([]db.JobWithDomain, int, error)
-	ListJobsWithOffset(organisationID string, limit, offset int, status, dateRange string, tzOffsetMinutes int) ([]db.JobWithDomain, int, error)
+	ListJobsPage(organisationID string, opts db.JobListOptions) (*db.JobListPage, error)
 	// Scheduler methods
 	CreateScheduler(ctx context.Context, scheduler *db.Scheduler) error
 	GetScheduler(ctx context.Context, schedulerID string) (*db.Scheduler, error)
@@ -119,6 +119,7 @@ type DBClient interface {
 	GetSchedulersReadyToRun(ctx context.Context, limit int) ([]*db.Scheduler, error)
 	UpdateSchedulerNextRun(ctx context.Context, schedulerID string, nextRun time.Time) error
 	GetLastJobStartTimeForScheduler(ctx context.Context, schedulerID string) (*time.Time, error)
+	HasActiveJobForScheduler(ctx context.Context, schedulerID string) (bool, error)
 	GetDomainNameByID(ctx context.Context, domainID int) (string, error)
 	GetDomainNames(ctx context.Context, domainIDs []int) (map[int]string, error)
 	// Organisation membership methods
@@ -142,6 +143,10 @@ type DBClient interface {
 	AcceptOrganisationInvite(ctx context.Context, token, userID string) (*db.OrganisationInvite, error)
 	SetOrganisationPlan(ctx context.Context, organisationID, planID string) error
 	GetOrganisationPlanID(ctx context.Context, organisationID string) (string, error)
+	GetOrganisationCrawlDenyHosts(ctx context.Context, organisationID string) ([]string, error)
+	SetOrganisationCrawlDenyHosts(ctx context.Context, organisationID string, hosts []string) error
+	GetOrganisationSlackWebhookURL(ctx context.Context, organisationID string) (string, error)
+	SetOrganisationSlackWebhookURL(ctx context.Context, organisationID, url string) error
 	ListDailyUsage(ctx context.Context, organisationID string, startDate, endDate time.Time) ([]db.DailyUsageEntry, error)
 	// Slack integration methods
 	CreateSlackConnection(ctx context.Context, conn *db.SlackConnection) error
@@ -177,6 +182,10 @@ type DBClient interface {
 	GetActiveGAConnectionForOrganisation(ctx context.Context, orgID string) (*db.GoogleAnalyticsConnection, error)
 	GetActiveGAConnectionForDomain(ctx context.Context, organisationID string, domainID int) (*db.GoogleAnalyticsConnection, error)
 	GetDomainsForOrganisation(ctx context.Context, organisationID string) ([]db.OrganisationDomain, error)
+	GetDomainStats(ctx context.Context, organisationID, domain string, since *time.Time) (*db.DomainStats, error)
+	GetDomainTechnologies(ctx context.Context, domainID int) (*db.DomainTechnologies, error)
+	GetOrganisationWebhookSecret(ctx context.Context, organisationID string) (string, error)
+	RotateOrganisationWebhookSecret(ctx context.Context, organisationID string) (string, error)
 	UpdateConnectionLastSync(ctx context.Context, connectionID string) error
 	UpdateConnectionDomains(ctx context.Context, connectionID string, domainIDs []int) error
 	MarkConnectionInactive(ctx context.Context, connectionID, reason string) error
@@ -194,6 +203,24 @@ type DBClient interface {
 	GetGA4AccountToken(ctx context.Context, accountID string) (string, error)
 	GetGA4AccountWithToken(ctx context.Context, organisationID string) (*db.GoogleAnalyticsAccount, error)
 	GetGAConnectionWithToken(ctx context.Context, organisationID string) (*db.GoogleAnalyticsConnection, error)
+	// Google Search Console connections and search-based prioritisation
+	UpsertSearchConsoleConnection(ctx context.Context, conn *db.SearchConsoleConnection) error
+	StoreSearchConsoleToken(ctx context.Context, connectionID, refreshToken string) error
+	GetSearchConsoleToken(ctx context.Context, connectionID string) (string, error)
+	ListSearchConsoleConnections(ctx context.Context, organisationID string) ([]*db.SearchConsoleConnection, error)
+	GetActiveSearchConsoleConnectionForDomain(ctx context.Context, organisationID string, domainID int) (*db.SearchConsoleConnection, error)
+	DeleteSearchConsoleConnection(ctx context.Context, connectionID, organisationID string) error
+	MarkSearchConsoleConnectionInactive(ctx context.Context, connectionID, reason string) error
+	UpdateSearchConsoleLastSync(ctx context.Context, connectionID string) error
+	UpsertPageSearchStats(ctx context.Context, organisationID string, domainID int, connectionID string, stats []db.PageSearchStats) error
+	CalculateSearchScores(ctx context.Context, organisationID string, domainID int) error
+	ApplySearchScoresToTasks(ctx context.Context, organisationID string, domainID int) error
+	// Pending Google Analytics OAuth sessions, shared across app instances
+	StorePendingGASession(ctx context.Context, sessionID string, data []byte, expiresAt time.Time) error
+	StorePendingGATokens(ctx context.Context, sessionID string, tokens []byte) error
+	GetPendingGASession(ctx context.Context, sessionID string) (data, tokens []byte, expiresAt time.Time, err error)
+	DeletePendingGASession(ctx context.Context, sessionID string) error
+	DeleteExpiredPendingGASessions(ctx context.Context) (int64, error)
 	// Platform integration mappings
 	UpsertPlatformOrgMapping(ctx context.Context, mapping *db.PlatformOrgMapping) error
 	GetPlatformOrgMapping(ctx context.Context, platform, platformID string) (*db.PlatformOrgMapping, error)
@@ -211,6 +238,14 @@ type DBClient interface {
 	UpdateSiteAutoPublish(ctx context.Context, organisationID, webflowSiteID string, enabled bool, webhookID string) error
 	DeleteSiteSetting(ctx context.Context, organisationID, webflowSiteID string) error
 	DeleteSiteSettingsByConnection(ctx context.Context, connectionID string) error
+	// CDN purge integration methods
+	UpsertCDNPurgeConnection(ctx context.Context, conn *db.CDNPurgeConnection) error
+	GetCDNPurgeConnection(ctx context.Context, organisationID string) (*db.CDNPurgeConnection, error)
+	DeleteCDNPurgeConnection(ctx context.Context, organisationID string) error
+	StoreCDNPurgeToken(ctx context.Context, connectionID, token string) error
+	// Health warning methods (system admin)
+	ListHealthWarnings(ctx context.Context, includeResolved bool, limit int) ([]*db.HealthWarning, error)
+	AcknowledgeHealthWarning(ctx context.Context, warningID int64, userID string) (*db.HealthWarning, error)
 }
 
 // Handler holds dependencies for API handlers
@@ -220,6 +255,12 @@ type Handler struct {
 	Loops              *loops.Client
 	GoogleClientID     string
 	GoogleClientSecret string
+
+	// OrgRateLimiter throttles authenticated requests per organisation; nil disables it
+	OrgRateLimiter *OrgRateLimiter
+
+	// JobEvents pushes job progress to SSE and WebSocket streams; nil falls back to polling
+	JobEvents *JobEventHub
 }
 
 // NewHandler creates a new API handler with dependencies
@@ -255,6 +296,10 @@ func (h *Handler) GetActiveOrganisation(w http.ResponseWriter, r *http.Request)
 		return ""
 	}
 
+	if !h.allowOrgRequest(w, r, orgID) {
+		return ""
+	}
+
 	return orgID
 }
 
@@ -279,6 +324,10 @@ func (h *Handler) GetActiveOrganisationWithUser(w http.ResponseWriter, r *http.R
 		return nil, "", false
 	}
 
+	if !h.allowOrgRequest(w, r, orgID) {
+		return nil, "", false
+	}
+
 	return user, orgID, true
 }
 
@@ -287,10 +336,17 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	// Health check endpoints (no auth required)
 	mux.HandleFunc("/health", h.HealthCheck)
 	mux.HandleFunc("/health/db", h.DatabaseHealthCheck)
+	mux.HandleFunc("/health/detailed", h.DetailedHealthCheck)
+	mux.HandleFunc("/health/ready", h.ReadinessHandler)
+
+	// Machine-readable API description (no auth required)
+	mux.HandleFunc("/v1/openapi.json", h.OpenAPISpec)
 
 	// V1 API routes with authentication
 	mux.Handle("/v1/jobs", auth.AuthMiddleware(http.HandlerFunc(h.JobsHandler)))
 	mux.Handle("/v1/jobs/", auth.AuthMiddleware(http.HandlerFunc(h.JobHandler))) // For /v1/jobs/:id
+	// Progress for all of the organisation's active jobs over one WebSocket
+	mux.Handle("/v1/ws", WebSocketTokenMiddleware(auth.AuthMiddleware(http.HandlerFunc(h.JobsWebSocket))))
 	mux.Handle("/v1/schedulers", auth.AuthMiddleware(http.HandlerFunc(h.SchedulersHandler)))
 	mux.Handle("/v1/schedulers/", auth.AuthMiddleware(http.HandlerFunc(h.SchedulerHandler))) // For /v1/schedulers/:id
 	// Shared job routes (public)
@@ -323,9 +379,13 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	mux.Handle("/v1/organisations/invites", auth.AuthMiddleware(http.HandlerFunc(h.OrganisationInvitesHandler)))
 	mux.Handle("/v1/organisations/invites/", auth.AuthMiddleware(http.HandlerFunc(h.OrganisationInviteHandler)))
 	mux.Handle("/v1/organisations/plan", auth.AuthMiddleware(http.HandlerFunc(h.OrganisationPlanHandler)))
+	mux.Handle("/v1/organisations/deny-hosts", auth.AuthMiddleware(http.HandlerFunc(h.OrganisationDenyHostsHandler)))
+	mux.Handle("/v1/organisations/slack-webhook", auth.AuthMiddleware(http.HandlerFunc(h.OrganisationSlackWebhookHandler)))
+	mux.Handle("/v1/organisations/cancel-jobs", auth.AuthMiddleware(http.HandlerFunc(h.OrganisationCancelJobsHandler)))
 
 	// Domain routes (require auth)
 	mux.Handle("/v1/domains", auth.AuthMiddleware(http.HandlerFunc(h.DomainsHandler)))
+	mux.Handle("/v1/domains/", auth.AuthMiddleware(http.HandlerFunc(h.DomainHandler)))
 
 	// Usage routes (require auth)
 	mux.Handle("/v1/usage", auth.AuthMiddleware(http.HandlerFunc(h.UsageHandler)))
@@ -355,6 +415,17 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/v1/integrations/google/callback", h.HandleGoogleOAuthCallback) // No auth - state validation
 	mux.Handle("/v1/integrations/google/save-property", auth.AuthMiddleware(http.HandlerFunc(h.SaveGoogleProperty)))
 
+	// Google Search Console integration endpoints
+	mux.Handle("/v1/integrations/search-console", auth.AuthMiddleware(http.HandlerFunc(h.SearchConsoleConnectionsHandler)))
+	mux.Handle("/v1/integrations/search-console/", auth.AuthMiddleware(http.HandlerFunc(h.SearchConsoleConnectionHandler)))
+	mux.HandleFunc("/v1/integrations/search-console/callback", h.HandleSearchConsoleOAuthCallback) // No auth - state validation
+
+	// CDN purge integration endpoint
+	mux.Handle("/v1/integrations/cdn-purge", auth.AuthMiddleware(http.HandlerFunc(h.CDNPurgeHandler)))
+
+	// Job completion webhook signing secret
+	mux.Handle("/v1/integrations/webhook-secret", auth.AuthMiddleware(http.HandlerFunc(h.WebhookSecretHandler)))
+
 	// Notification endpoints
 	mux.Handle("/v1/notifications", auth.AuthMiddleware(http.HandlerFunc(h.NotificationsHandler)))
 	mux.Handle("/v1/notifications/read-all", auth.AuthMiddleware(http.HandlerFunc(h.NotificationsReadAllHandler)))
@@ -363,6 +434,13 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	// Admin endpoints (require authentication and admin role)
 	mux.Handle("/v1/admin/reset-db", auth.AuthMiddleware(http.HandlerFunc(h.AdminResetDatabase)))
 	mux.Handle("/v1/admin/reset-data", auth.AuthMiddleware(http.HandlerFunc(h.AdminResetData)))
+	mux.Handle("/v1/admin/jobs/", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminJobHandler))))
+	mux.Handle("/v1/admin/throughput", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminThroughput))))
+	mux.Handle("/v1/admin/pool", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminPool))))
+	mux.Handle("/v1/admin/reconcile", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminReconcile))))
+	mux.Handle("/v1/admin/rebalance", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminRebalance))))
+	mux.Handle("/v1/admin/health-warnings", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminHealthWarnings))))
+	mux.Handle("/v1/admin/health-warnings/", auth.AuthMiddleware(requireSystemAdmin(http.HandlerFunc(h.AdminHealthWarningHandler))))
 
 	// Protected pprof endpoints (system admin + auth required)
 	pprofProtected := func(handler http.Handler) http.Handler {
@@ -474,6 +552,45 @@ func (h *Handler) DatabaseHealthCheck(w http.ResponseWriter, r *http.Request) {
 	WriteHealthy(w, r, "postgresql", "")
 }
 
+// DetailedHealthCheck reports the database and LISTEN/NOTIFY listener state.
+// A lost listener only degrades the service since workers fall back to polling.
+func (h *Handler) DetailedHealthCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	status := "healthy"
+	httpStatus := http.StatusOK
+	checks := map[string]any{}
+
+	if h.DB == nil {
+		status, httpStatus = "unhealthy", http.StatusServiceUnavailable
+		checks["database"] = map[string]any{"status": "unhealthy", "error": "database connection not configured"}
+	} else if err := h.DB.GetDB().PingContext(r.Context()); err != nil {
+		status, httpStatus = "unhealthy", http.StatusServiceUnavailable
+		checks["database"] = map[string]any{"status": "unhealthy", "error": err.Error()}
+	} else {
+		checks["database"] = map[string]any{"status": "healthy"}
+	}
+
+	if h.JobsManager != nil {
+		listener := h.JobsManager.NotificationListenerStatus()
+		checks["notification_listener"] = listener
+		if listener.Enabled && !listener.Connected && status == "healthy" {
+			status = "degraded"
+		}
+	}
+
+	WriteJSON(w, r, map[string]any{
+		"status":    status,
+		"timestamp": time.Now().Format(time.RFC3339),
+		"service":   "blue-banded-bee",
+		"version":   Version,
+		"checks":    checks,
+	}, httpStatus)
+}
+
 // ServeTestLogin serves the test login page
 func (h *Handler) ServeTestLogin(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "test-login.html")
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+)
+
+// fetchErrorBreakdown counts a job's failed tasks by error class, classifying
+// each stored error the same way the worker did when deciding on retries
+func (h *Handler) fetchErrorBreakdown(ctx context.Context, jobID string) (*jobs.ErrorBreakdown, error) {
+	rows, err := h.DB.GetDB().QueryContext(ctx, `
+		SELECT COALESCE(error, ''), COUNT(*)
+		FROM tasks
+		WHERE job_id = $1 AND status = 'failed'
+		GROUP BY error
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := &jobs.ErrorBreakdown{}
+	for rows.Next() {
+		var message string
+		var count int
+		if err := rows.Scan(&message, &count); err != nil {
+			return nil, err
+		}
+		breakdown.Add(jobs.ClassifyErrorMessage(message), count)
+	}
+	return breakdown, rows.Err()
+}
@@ -0,0 +1,362 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// jobProgressChannel is notified with the job id by the on_job_progress_change trigger
+	jobProgressChannel = "job_progress"
+
+	// jobEventsHeartbeat keeps idle streams open through proxies and doubles as
+	// a polling fallback when LISTEN isn't available
+	jobEventsHeartbeat = 15 * time.Second
+
+	// jobEventsMinInterval caps progress events per stream; notifications that
+	// arrive in between are coalesced into the next event
+	jobEventsMinInterval = time.Second
+)
+
+// JobEventHub fans job_progress notifications out to SSE subscribers keyed by
+// job id, and to watchers that follow every job
+type JobEventHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+	watchers    map[*JobWatcher]struct{}
+	closing     chan struct{}
+	closeOnce   sync.Once
+}
+
+// JobWatcher collects the ids of jobs notified since it was last drained.
+// Repeated notifications for a job coalesce into one pending id.
+type JobWatcher struct {
+	mu      sync.Mutex
+	pending map[string]struct{}
+	resync  bool
+	signal  chan struct{}
+}
+
+// NewJobEventHub creates an empty hub; call Listen to start receiving notifications
+func NewJobEventHub() *JobEventHub {
+	return &JobEventHub{
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+		watchers:    make(map[*JobWatcher]struct{}),
+		closing:     make(chan struct{}),
+	}
+}
+
+// Subscribe registers for updates to a job. The channel holds at most one
+// pending signal, so a slow reader sees one wake-up rather than a backlog.
+// Call the returned function to unsubscribe.
+func (hub *JobEventHub) Subscribe(jobID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	hub.mu.Lock()
+	if hub.subscribers[jobID] == nil {
+		hub.subscribers[jobID] = make(map[chan struct{}]struct{})
+	}
+	hub.subscribers[jobID][ch] = struct{}{}
+	hub.mu.Unlock()
+
+	return ch, func() {
+		hub.mu.Lock()
+		delete(hub.subscribers[jobID], ch)
+		if len(hub.subscribers[jobID]) == 0 {
+			delete(hub.subscribers, jobID)
+		}
+		hub.mu.Unlock()
+	}
+}
+
+// Watch registers for updates to every job. Watchers filter the ids they're
+// allowed to see themselves. Call the returned function to stop watching.
+func (hub *JobEventHub) Watch() (*JobWatcher, func()) {
+	watcher := &JobWatcher{
+		pending: make(map[string]struct{}),
+		signal:  make(chan struct{}, 1),
+	}
+
+	hub.mu.Lock()
+	hub.watchers[watcher] = struct{}{}
+	hub.mu.Unlock()
+
+	return watcher, func() {
+		hub.mu.Lock()
+		delete(hub.watchers, watcher)
+		hub.mu.Unlock()
+	}
+}
+
+// Publish wakes every subscriber of a job, and every watcher, without blocking
+func (hub *JobEventHub) Publish(jobID string) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for ch := range hub.subscribers[jobID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	for watcher := range hub.watchers {
+		watcher.notify(jobID, false)
+	}
+}
+
+// Close ends all open streams, e.g. on server shutdown
+func (hub *JobEventHub) Close() {
+	hub.closeOnce.Do(func() { close(hub.closing) })
+}
+
+// Listen relays job_progress notifications to subscribers until ctx is
+// cancelled, reconnecting after errors
+func (hub *JobEventHub) Listen(ctx context.Context, connStr string) {
+	for {
+		if err := hub.listen(ctx, connStr); err != nil {
+			log.Warn().Err(err).Msg("Job progress listener error, retrying in 5s")
+		}
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Job progress listener stopped")
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (hub *JobEventHub) listen(ctx context.Context, connStr string) error {
+	listener := pq.NewListener(connStr, 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warn().Err(err).Msg("Job progress listener event error")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(jobProgressChannel); err != nil {
+		return err
+	}
+
+	log.Info().Msg("Job progress listener started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// Connection lost; the listener reconnects, but updates may
+				// have been missed, so wake everyone to re-read
+				hub.publishAll()
+				continue
+			}
+			hub.Publish(notification.Extra)
+
+		case <-time.After(90 * time.Second):
+			if err := listener.Ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (hub *JobEventHub) publishAll() {
+	hub.mu.Lock()
+	jobIDs := make([]string, 0, len(hub.subscribers))
+	for jobID := range hub.subscribers {
+		jobIDs = append(jobIDs, jobID)
+	}
+	hub.mu.Unlock()
+
+	for _, jobID := range jobIDs {
+		hub.Publish(jobID)
+	}
+
+	hub.mu.Lock()
+	for watcher := range hub.watchers {
+		watcher.notify("", true)
+	}
+	hub.mu.Unlock()
+}
+
+// Updates signals when ids are pending; it holds at most one signal
+func (watcher *JobWatcher) Updates() <-chan struct{} {
+	return watcher.signal
+}
+
+// Take drains the pending job ids. resync is true when notifications may
+// have been missed and everything the watcher follows should be re-read.
+func (watcher *JobWatcher) Take() (jobIDs []string, resync bool) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	jobIDs = make([]string, 0, len(watcher.pending))
+	for jobID := range watcher.pending {
+		jobIDs = append(jobIDs, jobID)
+	}
+	clear(watcher.pending)
+	resync, watcher.resync = watcher.resync, false
+	return jobIDs, resync
+}
+
+func (watcher *JobWatcher) notify(jobID string, resync bool) {
+	watcher.mu.Lock()
+	if jobID != "" {
+		watcher.pending[jobID] = struct{}{}
+	}
+	watcher.resync = watcher.resync || resync
+	watcher.mu.Unlock()
+
+	select {
+	case watcher.signal <- struct{}{}:
+	default:
+	}
+}
+
+// JobProgressEvent is the payload of each progress event on the stream
+type JobProgressEvent struct {
+	JobID          string  `json:"job_id"`
+	Status         string  `json:"status"`
+	TotalTasks     int     `json:"total_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	FailedTasks    int     `json:"failed_tasks"`
+	SkippedTasks   int     `json:"skipped_tasks"`
+	Progress       float64 `json:"progress"`
+}
+
+// isTerminalJobStatus reports whether a job has stopped changing
+func isTerminalJobStatus(status string) bool {
+	switch jobs.JobStatus(status) {
+	case jobs.JobStatusCompleted, jobs.JobStatusFailed, jobs.JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// writeSSEEvent writes one named event with a JSON payload
+func writeSSEEvent(w http.ResponseWriter, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+// getJobEvents handles GET /v1/jobs/:id/events, streaming progress as
+// Server-Sent Events until the job finishes or the client disconnects
+func (h *Handler) getJobEvents(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	ctx := r.Context()
+	current, err := h.loadJobProgress(ctx, jobID)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to load job progress")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Stop proxies buffering the stream
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeSSEEvent(w, "progress", current); err != nil || rc.Flush() != nil {
+		return
+	}
+	if isTerminalJobStatus(current.Status) {
+		return
+	}
+
+	// Without a hub, updates and closing stay nil and the heartbeat polls instead
+	var updates <-chan struct{}
+	var closing <-chan struct{}
+	if h.JobEvents != nil {
+		var unsubscribe func()
+		updates, unsubscribe = h.JobEvents.Subscribe(jobID)
+		defer unsubscribe()
+		closing = h.JobEvents.closing
+	}
+
+	heartbeat := time.NewTicker(jobEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closing:
+			return
+		case <-updates:
+		case <-heartbeat.C:
+		}
+
+		next, err := h.loadJobProgress(ctx, jobID)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn().Err(err).Str("job_id", jobID).Msg("Failed to refresh job progress")
+			}
+			return
+		}
+
+		if next == current {
+			// Comment lines are ignored by EventSource but keep the connection warm
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+			continue
+		}
+
+		current = next
+		if err := writeSSEEvent(w, "progress", current); err != nil || rc.Flush() != nil {
+			return
+		}
+		if isTerminalJobStatus(current.Status) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-closing:
+			return
+		case <-time.After(jobEventsMinInterval):
+		}
+	}
+}
+
+// loadJobProgress reads the counters sent in a progress event
+func (h *Handler) loadJobProgress(ctx context.Context, jobID string) (JobProgressEvent, error) {
+	event := JobProgressEvent{JobID: jobID}
+	err := h.DB.GetDB().QueryRowContext(ctx, `
+		SELECT status, total_tasks, completed_tasks, failed_tasks, skipped_tasks, progress
+		FROM jobs
+		WHERE id = $1
+	`, jobID).Scan(&event.Status, &event.TotalTasks, &event.CompletedTasks,
+		&event.FailedTasks, &event.SkippedTasks, &event.Progress)
+	return event, err
+}
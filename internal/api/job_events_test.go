@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobEventHubPublish(t *testing.T) {
+	hub := NewJobEventHub()
+
+	first, unsubscribeFirst := hub.Subscribe("job-1")
+	second, unsubscribeSecond := hub.Subscribe("job-1")
+	other, unsubscribeOther := hub.Subscribe("job-2")
+	defer unsubscribeOther()
+
+	// Repeated notifications coalesce into one pending wake-up
+	hub.Publish("job-1")
+	hub.Publish("job-1")
+
+	assert.Len(t, first, 1)
+	assert.Len(t, second, 1)
+	assert.Empty(t, other, "other jobs' subscribers shouldn't wake")
+
+	<-first
+	unsubscribeFirst()
+	unsubscribeSecond()
+	hub.Publish("job-1")
+	assert.Empty(t, first, "unsubscribed channels shouldn't receive")
+
+	hub.mu.Lock()
+	_, exists := hub.subscribers["job-1"]
+	hub.mu.Unlock()
+	assert.False(t, exists, "job entry should be removed with its last subscriber")
+}
+
+func TestJobEventHubClose(t *testing.T) {
+	hub := NewJobEventHub()
+	hub.Close()
+	hub.Close() // Safe to call twice
+
+	select {
+	case <-hub.closing:
+	default:
+		t.Fatal("closing channel should be closed")
+	}
+}
+
+func TestWriteSSEEvent(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := writeSSEEvent(rec, "progress", JobProgressEvent{
+		JobID:          "job-1",
+		Status:         "running",
+		TotalTasks:     10,
+		CompletedTasks: 4,
+		FailedTasks:    1,
+		Progress:       50,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"event: progress\ndata: {\"job_id\":\"job-1\",\"status\":\"running\",\"total_tasks\":10,"+
+			"\"completed_tasks\":4,\"failed_tasks\":1,\"skipped_tasks\":0,\"progress\":50}\n\n",
+		rec.Body.String())
+}
+
+func TestIsTerminalJobStatus(t *testing.T) {
+	assert.True(t, isTerminalJobStatus("completed"))
+	assert.True(t, isTerminalJobStatus("failed"))
+	assert.True(t, isTerminalJobStatus("cancelled"))
+	assert.False(t, isTerminalJobStatus("running"))
+	assert.False(t, isTerminalJobStatus("paused"))
+}
+
+func TestJobEventHubWatch(t *testing.T) {
+	hub := NewJobEventHub()
+	watcher, unwatch := hub.Watch()
+
+	// Every job wakes a watcher, coalescing repeats into one pending id
+	hub.Publish("job-1")
+	hub.Publish("job-2")
+	hub.Publish("job-1")
+
+	assert.Len(t, watcher.Updates(), 1)
+	<-watcher.Updates()
+	jobIDs, resync := watcher.Take()
+	assert.ElementsMatch(t, []string{"job-1", "job-2"}, jobIDs)
+	assert.False(t, resync)
+
+	jobIDs, _ = watcher.Take()
+	assert.Empty(t, jobIDs, "taking should drain pending ids")
+
+	// A lost listener connection asks watchers to re-read everything
+	hub.publishAll()
+	_, resync = watcher.Take()
+	assert.True(t, resync)
+
+	unwatch()
+	<-watcher.Updates()
+	hub.Publish("job-3")
+	assert.Empty(t, watcher.Updates(), "removed watchers shouldn't receive")
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Download formats for GET /v1/jobs/:id/export?format=...
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+)
+
+// taskExportHeader is the CSV header row, matching TaskExportRow's JSON keys
+var taskExportHeader = []string{"path", "status", "status_code", "cache_status", "ttfb_ms", "content_type", "error"}
+
+// TaskExportRow is one task in a downloaded job report
+type TaskExportRow struct {
+	Path        string `json:"path"`
+	Status      string `json:"status"`
+	StatusCode  *int   `json:"status_code"`
+	CacheStatus string `json:"cache_status"`
+	TTFBMs      *int   `json:"ttfb_ms"`
+	ContentType string `json:"content_type"`
+	Error       string `json:"error"`
+}
+
+// exportWhereClause narrows an export to the tasks for its type, reporting
+// false for an unknown type
+func exportWhereClause(exportType string) (string, bool) {
+	switch exportType {
+	case "broken-links":
+		return " AND t.status = 'failed'", true
+	case "soft-404s":
+		return " AND t.soft_404", true
+	case "slow-pages":
+		// Use second_response_time (cache HIT) when available, fallback to response_time
+		return " AND COALESCE(t.second_response_time, t.response_time) > 3000", true
+	case "job":
+		// Export all tasks
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// exportJobDownload handles GET /v1/jobs/:id/export?format=csv|json. Unlike the
+// dashboard export it isn't capped: rows stream from the database cursor
+// straight to the response. Jobs outside the caller's organisation are a 404.
+func (h *Handler) exportJobDownload(w http.ResponseWriter, r *http.Request, jobID, format string) {
+	logger := loggerWithRequest(r)
+
+	orgID := h.GetActiveOrganisation(w, r)
+	if orgID == "" {
+		return
+	}
+
+	var exists bool
+	err := h.DB.GetDB().QueryRowContext(r.Context(), `
+		SELECT EXISTS (SELECT 1 FROM jobs WHERE id = $1 AND organisation_id = $2)
+	`, jobID, orgID).Scan(&exists)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to check job for export")
+		DatabaseError(w, r, err)
+		return
+	}
+	if !exists {
+		NotFound(w, r, "Job not found")
+		return
+	}
+
+	h.streamJobExport(w, r, jobID, format)
+}
+
+// streamJobExport writes every matching task as a CSV or JSON download
+func (h *Handler) streamJobExport(w http.ResponseWriter, r *http.Request, jobID, format string) {
+	logger := loggerWithRequest(r)
+
+	if format != exportFormatCSV && format != exportFormatJSON {
+		BadRequest(w, r, fmt.Sprintf("Invalid export format: %s (use csv or json)", format))
+		return
+	}
+
+	exportType := r.URL.Query().Get("type")
+	if exportType == "" {
+		exportType = "job"
+	}
+	whereClause, ok := exportWhereClause(exportType)
+	if !ok {
+		BadRequest(w, r, fmt.Sprintf("Invalid export type: %s", exportType))
+		return
+	}
+
+	rows, err := h.queryTaskExportRows(r.Context(), jobID, whereClause)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to export tasks")
+		DatabaseError(w, r, err)
+		return
+	}
+	defer rows.Close()
+
+	contentType := "text/csv; charset=utf-8"
+	write := writeTaskExportCSV
+	if format == exportFormatJSON {
+		contentType = "application/json"
+		write = writeTaskExportJSON
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="job_%s_results.%s"`, jobID, format))
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent, so a failure here can only cut the file short
+	count, err := write(w, rows)
+	if err != nil {
+		logger.Warn().Err(err).Str("job_id", jobID).Int("rows", count).Msg("Task export stream ended early")
+		return
+	}
+
+	logger.Info().
+		Str("job_id", jobID).
+		Str("format", format).
+		Str("export_type", exportType).
+		Int("rows", count).
+		Msg("Streamed task export")
+}
+
+func (h *Handler) queryTaskExportRows(ctx context.Context, jobID, whereClause string) (*sql.Rows, error) {
+	return h.DB.GetDB().QueryContext(ctx, fmt.Sprintf(`
+		SELECT p.path, t.status, t.status_code, t.cache_status, t.ttfb, t.content_type, t.error
+		FROM tasks t
+		JOIN pages p ON t.page_id = p.id
+		WHERE t.job_id = $1%s
+		ORDER BY p.path
+	`, whereClause), jobID)
+}
+
+func scanTaskExportRow(rows *sql.Rows) (TaskExportRow, error) {
+	var row TaskExportRow
+	var statusCode, ttfb sql.NullInt64
+	var cacheStatus, contentType, taskError sql.NullString
+	if err := rows.Scan(&row.Path, &row.Status, &statusCode, &cacheStatus, &ttfb, &contentType, &taskError); err != nil {
+		return row, err
+	}
+	if statusCode.Valid {
+		code := int(statusCode.Int64)
+		row.StatusCode = &code
+	}
+	if ttfb.Valid {
+		ms := int(ttfb.Int64)
+		row.TTFBMs = &ms
+	}
+	row.CacheStatus = cacheStatus.String
+	row.ContentType = contentType.String
+	row.Error = taskError.String
+	return row, nil
+}
+
+// writeTaskExportCSV streams rows as CSV, returning how many were written
+func writeTaskExportCSV(w io.Writer, rows *sql.Rows) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(taskExportHeader); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for rows.Next() {
+		row, err := scanTaskExportRow(rows)
+		if err != nil {
+			return count, err
+		}
+		if err := cw.Write(row.csvRecord()); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	cw.Flush()
+	return count, cw.Error()
+}
+
+// writeTaskExportJSON streams rows as a JSON array, returning how many were written
+func writeTaskExportJSON(w io.Writer, rows *sql.Rows) (int, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for rows.Next() {
+		row, err := scanTaskExportRow(rows)
+		if err != nil {
+			return count, err
+		}
+		payload, err := json.Marshal(row)
+		if err != nil {
+			return count, err
+		}
+		if count > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return count, err
+			}
+		}
+		if _, err := w.Write(payload); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	_, err := io.WriteString(w, "]\n")
+	return count, err
+}
+
+// csvRecord formats the row for CSV; missing numbers are left blank
+func (row TaskExportRow) csvRecord() []string {
+	optionalInt := func(v *int) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	}
+	return []string{
+		csvSafe(row.Path),
+		row.Status,
+		optionalInt(row.StatusCode),
+		csvSafe(row.CacheStatus),
+		optionalInt(row.TTFBMs),
+		csvSafe(row.ContentType),
+		csvSafe(row.Error),
+	}
+}
+
+// csvSafe stops spreadsheet apps evaluating text from crawled sites as a formula
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
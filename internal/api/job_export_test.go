@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestRows(t *testing.T) *sql.Rows {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	mock.ExpectQuery("SELECT p.path").WillReturnRows(
+		sqlmock.NewRows([]string{"path", "status", "status_code", "cache_status", "ttfb", "content_type", "error"}).
+			AddRow("/", "completed", 200, "HIT", 42, "text/html", nil).
+			AddRow("/search?q=a,b", "failed", nil, nil, nil, nil, `timeout: "slow" origin`).
+			AddRow("/pricing", "completed", 404, "MISS", 310, "text/html", "=1+1"),
+	)
+
+	rows, err := mockDB.Query("SELECT p.path FROM tasks")
+	require.NoError(t, err)
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+func TestWriteTaskExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := writeTaskExportCSV(&buf, exportTestRows(t))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	want := "path,status,status_code,cache_status,ttfb_ms,content_type,error\n" +
+		"/,completed,200,HIT,42,text/html,\n" +
+		"\"/search?q=a,b\",failed,,,,,\"timeout: \"\"slow\"\" origin\"\n" +
+		"/pricing,completed,404,MISS,310,text/html,'=1+1\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriteTaskExportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := writeTaskExportJSON(&buf, exportTestRows(t))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	var rows []TaskExportRow
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "/", rows[0].Path)
+	require.NotNil(t, rows[0].TTFBMs)
+	assert.Equal(t, 42, *rows[0].TTFBMs)
+	assert.Nil(t, rows[1].StatusCode)
+	assert.Equal(t, `timeout: "slow" origin`, rows[1].Error)
+	assert.Equal(t, "=1+1", rows[2].Error) // Only CSV guards against formulas
+}
+
+func TestCSVSafe(t *testing.T) {
+	assert.Equal(t, "/about", csvSafe("/about"))
+	assert.Equal(t, "'=HYPERLINK(\"x\")", csvSafe("=HYPERLINK(\"x\")"))
+	assert.Equal(t, "'+1", csvSafe("+1"))
+	assert.Equal(t, "'@SUM(A1)", csvSafe("@SUM(A1)"))
+	assert.Equal(t, "", csvSafe(""))
+}
+
+func TestExportWhereClause(t *testing.T) {
+	clause, ok := exportWhereClause("job")
+	assert.True(t, ok)
+	assert.Empty(t, clause)
+
+	clause, ok = exportWhereClause("broken-links")
+	assert.True(t, ok)
+	assert.Contains(t, clause, "failed")
+
+	clause, ok = exportWhereClause("soft-404s")
+	assert.True(t, ok)
+	assert.Contains(t, clause, "soft_404")
+
+	_, ok = exportWhereClause("everything")
+	assert.False(t, ok)
+}
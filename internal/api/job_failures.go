@@ -0,0 +1,199 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+)
+
+// taskFailureHints explain each failure category in terms a client can act on
+var taskFailureHints = map[string]string{
+	jobs.FailureCategoryBlocking:  "Requests were refused or rate limited, likely by a WAF or bot protection",
+	jobs.FailureCategoryRetryable: "The server timed out or returned server errors on every attempt",
+	jobs.FailureCategoryClient:    "Pages are missing, need authentication or redirect",
+	jobs.FailureCategoryOther:     "Requests failed before a usable response was received",
+}
+
+// TaskFailure is one permanently failed task
+type TaskFailure struct {
+	TaskID          string              `json:"task_id"`
+	Path            string              `json:"path"`
+	StatusCode      *int                `json:"status_code,omitempty"`
+	ErrorMessage    string              `json:"error_message"`
+	Attempts        int                 `json:"attempts"`
+	ResponseHeaders map[string][]string `json:"response_headers"`
+	FailedAt        string              `json:"failed_at"`
+}
+
+// TaskFailureGroup collects a job's failures in one error category
+type TaskFailureGroup struct {
+	Category    string         `json:"category"`
+	Hint        string         `json:"hint"`
+	Count       int            `json:"count"`
+	StatusCodes map[string]int `json:"status_codes"` // Failures per HTTP status; "none" when no response arrived
+	Failures    []TaskFailure  `json:"failures"`     // Most recent first, capped by limit
+}
+
+// JobFailuresResponse lists a job's permanently failed tasks grouped by category
+type JobFailuresResponse struct {
+	JobID      string             `json:"job_id"`
+	Total      int                `json:"total"`
+	Categories []TaskFailureGroup `json:"categories"`
+}
+
+// getJobFailures handles GET /v1/jobs/:id/failures
+func (h *Handler) getJobFailures(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	// Failures listed per category; counts always cover every failure
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 500 {
+			limit = parsedLimit
+		}
+	}
+
+	groups := make(map[string]*TaskFailureGroup)
+
+	countRows, err := h.DB.GetDB().QueryContext(r.Context(), `
+		SELECT error_category, status_code, COUNT(*)
+		FROM task_failures
+		WHERE job_id = $1
+		GROUP BY error_category, status_code
+	`, jobID)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to count task failures")
+		DatabaseError(w, r, err)
+		return
+	}
+	defer countRows.Close()
+
+	for countRows.Next() {
+		var category string
+		var statusCode sql.NullInt64
+		var count int
+		if err := countRows.Scan(&category, &statusCode, &count); err != nil {
+			logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to scan task failure counts")
+			DatabaseError(w, r, err)
+			return
+		}
+
+		status := "none"
+		if statusCode.Valid {
+			status = strconv.FormatInt(statusCode.Int64, 10)
+		}
+		group := taskFailureGroup(groups, category)
+		group.Count += count
+		group.StatusCodes[status] += count
+	}
+	if err := countRows.Err(); err != nil {
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to iterate task failure counts")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	rows, err := h.DB.GetDB().QueryContext(r.Context(), `
+		SELECT task_id, path, status_code, error_category, error_message, attempts, response_headers, failed_at
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY error_category ORDER BY failed_at DESC, id DESC) AS category_rank
+			FROM task_failures
+			WHERE job_id = $1
+		) ranked
+		WHERE category_rank <= $2
+		ORDER BY error_category, category_rank
+	`, jobID, limit)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to get task failures")
+		DatabaseError(w, r, err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var failure TaskFailure
+		var category string
+		var statusCode sql.NullInt64
+		var headers []byte
+		var failedAt time.Time
+
+		if err := rows.Scan(&failure.TaskID, &failure.Path, &statusCode, &category,
+			&failure.ErrorMessage, &failure.Attempts, &headers, &failedAt); err != nil {
+			logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to scan task failure")
+			DatabaseError(w, r, err)
+			return
+		}
+
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			failure.StatusCode = &code
+		}
+		if err := json.Unmarshal(headers, &failure.ResponseHeaders); err != nil || failure.ResponseHeaders == nil {
+			failure.ResponseHeaders = map[string][]string{}
+		}
+		failure.FailedAt = failedAt.Format(time.RFC3339)
+
+		group := taskFailureGroup(groups, category)
+		group.Failures = append(group.Failures, failure)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to iterate task failures")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, buildJobFailuresResponse(jobID, groups), "Job failures retrieved successfully")
+}
+
+// taskFailureGroup returns the category's group, creating it on first use
+func taskFailureGroup(groups map[string]*TaskFailureGroup, category string) *TaskFailureGroup {
+	group, ok := groups[category]
+	if !ok {
+		group = &TaskFailureGroup{
+			Category:    category,
+			Hint:        taskFailureHints[category],
+			StatusCodes: map[string]int{},
+			Failures:    []TaskFailure{},
+		}
+		groups[category] = group
+	}
+	return group
+}
+
+// buildJobFailuresResponse orders categories with the most failures first
+func buildJobFailuresResponse(jobID string, groups map[string]*TaskFailureGroup) JobFailuresResponse {
+	response := JobFailuresResponse{JobID: jobID, Categories: make([]TaskFailureGroup, 0, len(groups))}
+	for _, group := range groups {
+		response.Total += group.Count
+		response.Categories = append(response.Categories, *group)
+	}
+
+	sort.Slice(response.Categories, func(i, j int) bool {
+		if response.Categories[i].Count != response.Categories[j].Count {
+			return response.Categories[i].Count > response.Categories[j].Count
+		}
+		return response.Categories[i].Category < response.Categories[j].Category
+	})
+
+	return response
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildJobFailuresResponse(t *testing.T) {
+	groups := make(map[string]*TaskFailureGroup)
+
+	client := taskFailureGroup(groups, jobs.FailureCategoryClient)
+	client.Count = 3
+	client.StatusCodes["404"] = 3
+
+	blocking := taskFailureGroup(groups, jobs.FailureCategoryBlocking)
+	blocking.Count = 47
+	blocking.StatusCodes["403"] = 47
+	blocking.Failures = append(blocking.Failures, TaskFailure{TaskID: "task-1", Path: "/pricing"})
+
+	assert.Same(t, blocking, taskFailureGroup(groups, jobs.FailureCategoryBlocking))
+
+	response := buildJobFailuresResponse("job-1", groups)
+
+	assert.Equal(t, "job-1", response.JobID)
+	assert.Equal(t, 50, response.Total)
+	require.Len(t, response.Categories, 2)
+	assert.Equal(t, jobs.FailureCategoryBlocking, response.Categories[0].Category)
+	assert.Equal(t, 47, response.Categories[0].StatusCodes["403"])
+	assert.Contains(t, response.Categories[0].Hint, "WAF")
+	assert.Len(t, response.Categories[0].Failures, 1)
+	assert.Equal(t, jobs.FailureCategoryClient, response.Categories[1].Category)
+	assert.Empty(t, response.Categories[1].Failures)
+}
+
+func TestBuildJobFailuresResponseEmpty(t *testing.T) {
+	response := buildJobFailuresResponse("job-1", map[string]*TaskFailureGroup{})
+	assert.Zero(t, response.Total)
+	assert.NotNil(t, response.Categories)
+}
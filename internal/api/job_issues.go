@@ -0,0 +1,116 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HTTPSRedirectIssue is an HTTP URL that failed to redirect to HTTPS
+type HTTPSRedirectIssue struct {
+	HTTPURL       string   `json:"http_url"`
+	Status        string   `json:"status"`
+	StatusCode    *int     `json:"status_code,omitempty"`
+	FinalURL      *string  `json:"final_url,omitempty"`
+	RedirectChain []string `json:"redirect_chain"`
+	CheckedAt     string   `json:"checked_at"`
+}
+
+// HTTPSRedirectSummary summarises HTTP -> HTTPS redirect checks for a job
+type HTTPSRedirectSummary struct {
+	Checked int                  `json:"checked"`
+	Failing int                  `json:"failing"`
+	Issues  []HTTPSRedirectIssue `json:"issues"`
+}
+
+// JobIssuesResponse lists security/SEO findings collected while warming a job
+type JobIssuesResponse struct {
+	JobID          string               `json:"job_id"`
+	HTTPSRedirects HTTPSRedirectSummary `json:"https_redirects"`
+}
+
+// getJobIssues handles GET /v1/jobs/:id/issues
+func (h *Handler) getJobIssues(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	response := JobIssuesResponse{
+		JobID:          jobID,
+		HTTPSRedirects: HTTPSRedirectSummary{Issues: []HTTPSRedirectIssue{}},
+	}
+
+	err := h.DB.GetDB().QueryRowContext(r.Context(), `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE status <> 'redirects_to_https')
+		FROM job_https_redirect_checks
+		WHERE job_id = $1
+	`, jobID).Scan(&response.HTTPSRedirects.Checked, &response.HTTPSRedirects.Failing)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to count HTTPS redirect checks")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	rows, err := h.DB.GetDB().QueryContext(r.Context(), `
+		SELECT http_url, status, status_code, final_url, redirect_chain, checked_at
+		FROM job_https_redirect_checks
+		WHERE job_id = $1 AND status <> 'redirects_to_https'
+		ORDER BY http_url
+	`, jobID)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to get HTTPS redirect issues")
+		DatabaseError(w, r, err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var issue HTTPSRedirectIssue
+		var statusCode sql.NullInt64
+		var finalURL sql.NullString
+		var chain []byte
+		var checkedAt time.Time
+
+		if err := rows.Scan(&issue.HTTPURL, &issue.Status, &statusCode, &finalURL, &chain, &checkedAt); err != nil {
+			logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to scan HTTPS redirect issue")
+			DatabaseError(w, r, err)
+			return
+		}
+
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			issue.StatusCode = &code
+		}
+		if finalURL.Valid && finalURL.String != "" {
+			issue.FinalURL = &finalURL.String
+		}
+		if err := json.Unmarshal(chain, &issue.RedirectChain); err != nil || issue.RedirectChain == nil {
+			issue.RedirectChain = []string{}
+		}
+		issue.CheckedAt = checkedAt.Format(time.RFC3339)
+
+		response.HTTPSRedirects.Issues = append(response.HTTPSRedirects.Issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to iterate HTTPS redirect issues")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, response, "Job issues retrieved successfully")
+}
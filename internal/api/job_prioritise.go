@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+)
+
+// defaultPriorityOverride puts prioritised pages level with the homepage, at
+// the front of the queue
+const defaultPriorityOverride = 1.0
+
+// PrioritiseTasksRequest names the pages to move and the priority to give them
+type PrioritiseTasksRequest struct {
+	Paths    []string `json:"paths"`              // Page paths, e.g. /launch
+	Priority *float64 `json:"priority,omitempty"` // 0 to 1, defaults to 1
+}
+
+// PrioritiseTasksResponse reports how many queued tasks were repriced
+type PrioritiseTasksResponse struct {
+	JobID         string  `json:"job_id"`
+	Priority      float64 `json:"priority"`
+	TasksRepriced int     `json:"tasks_repriced"`
+}
+
+// priority returns the requested priority or the default
+func (req PrioritiseTasksRequest) priority() float64 {
+	if req.Priority != nil {
+		return *req.Priority
+	}
+	return defaultPriorityOverride
+}
+
+// prioritiseJobTasks handles POST /v1/jobs/:id/prioritise, repricing the
+// pending and waiting tasks for the given paths
+func (h *Handler) prioritiseJobTasks(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	var req PrioritiseTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+	priority := req.priority()
+	if err := jobs.ValidatePriorityOverride(req.Paths, priority); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	repriced, err := h.JobsManager.PrioritiseTasks(r.Context(), jobID, req.Paths, priority)
+	if errors.Is(err, jobs.ErrJobNotPrioritisable) {
+		BadRequest(w, r, err.Error())
+		return
+	}
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to prioritise tasks")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Str("job_id", jobID).
+		Str("user_id", user.ID).
+		Int("tasks_repriced", repriced).
+		Float64("priority", priority).
+		Msg("Prioritised job tasks")
+
+	WriteSuccess(w, r, PrioritiseTasksResponse{
+		JobID:         jobID,
+		Priority:      priority,
+		TasksRepriced: repriced,
+	}, "Tasks prioritised successfully")
+}
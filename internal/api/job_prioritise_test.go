@@ -0,0 +1,14 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrioritiseTasksRequestPriority(t *testing.T) {
+	assert.Equal(t, defaultPriorityOverride, PrioritiseTasksRequest{Paths: []string{"/launch"}}.priority())
+
+	low := 0.2
+	assert.Equal(t, 0.2, PrioritiseTasksRequest{Paths: []string{"/launch"}, Priority: &low}.priority())
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+)
+
+// maxRewarmFilterValues bounds each list in a rewarm request
+const maxRewarmFilterValues = 50
+
+// RewarmJobRequest selects which outcomes of the source job to warm again
+type RewarmJobRequest struct {
+	StatusCodes   []int    `json:"status_codes,omitempty"`   // 0 matches failures without a response
+	CacheStatuses []string `json:"cache_statuses,omitempty"` // e.g. MISS, EXPIRED, BYPASS
+}
+
+// RewarmJobResponse is the created rewarm job and the job it came from
+type RewarmJobResponse struct {
+	JobResponse
+	SourceJobID string `json:"source_job_id"`
+}
+
+// validate checks the filter selects something and holds plausible values
+func (req RewarmJobRequest) validate() error {
+	if len(req.StatusCodes) == 0 && len(req.CacheStatuses) == 0 {
+		return jobs.ErrEmptyRewarmFilter
+	}
+	if len(req.StatusCodes) > maxRewarmFilterValues || len(req.CacheStatuses) > maxRewarmFilterValues {
+		return fmt.Errorf("status_codes and cache_statuses support at most %d values each", maxRewarmFilterValues)
+	}
+	for _, code := range req.StatusCodes {
+		if code != 0 && (code < 100 || code > 599) {
+			return fmt.Errorf("status_codes must be 0 or a valid HTTP status, got %d", code)
+		}
+	}
+	for _, status := range req.CacheStatuses {
+		if status == "" || len(status) > 32 {
+			return fmt.Errorf("cache_statuses must be non-empty and at most 32 characters")
+		}
+	}
+	return nil
+}
+
+// rewarmJob handles POST /v1/jobs/:id/rewarm, creating a job that warms only
+// the pages whose task ended with the requested outcomes
+func (h *Handler) rewarmJob(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	var req RewarmJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+	if err := req.validate(); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	job, err := h.JobsManager.CreateRewarmJob(r.Context(), jobID, req.StatusCodes, req.CacheStatuses)
+	if errors.Is(err, jobs.ErrSourceJobActive) || errors.Is(err, jobs.ErrNothingToRewarm) || errors.Is(err, jobs.ErrEmptyRewarmFilter) {
+		BadRequest(w, r, err.Error())
+		return
+	}
+	if err != nil {
+		if handleCreateJobError(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to create rewarm job")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Str("job_id", job.ID).
+		Str("source_job_id", jobID).
+		Msg("Created rewarm job")
+
+	domainID, err := h.DB.GetOrCreateDomainID(r.Context(), job.Domain)
+	if err != nil {
+		logger.Error().Err(err).Str("job_id", job.ID).Msg("Failed to get domain ID")
+		// Continue without domain_id rather than failing the whole request
+		domainID = 0
+	}
+
+	WriteCreated(w, r, RewarmJobResponse{
+		JobResponse: JobResponse{
+			ID:                   job.ID,
+			DomainID:             domainID,
+			Domain:               job.Domain,
+			Status:               string(job.Status),
+			Progress:             0.0,
+			CreatedAt:            job.CreatedAt.Format(time.RFC3339),
+			PriorityTier:         string(job.PriorityTier),
+			SlowOriginPolicy:     string(job.SlowOriginPolicy),
+			AutoConcurrency:      job.AutoConcurrency,
+			SkipCachedURLs:       job.SkipCachedURLs,
+			ContentTypes:         job.ContentTypes,
+			URLScheme:            string(job.URLScheme),
+			Tags:                 job.Tags,
+			RetryBudget:          job.RetryBudget,
+			Method:               string(job.Method),
+			GroupSubdomains:      job.GroupSubdomains,
+			SecondRequest:        job.SecondRequest,
+			MinCrawlDelaySeconds: job.MinCrawlDelaySeconds,
+			MaxCrawlDelaySeconds: job.MaxCrawlDelaySeconds,
+			PrioritiseBySearch:   job.PrioritiseBySearch,
+			MaxRuntimeMinutes:    job.MaxRuntimeMinutes,
+			MaxRuntimeAction:     string(job.MaxRuntimeAction),
+			HasCredentials:       job.HasCredentials,
+		},
+		SourceJobID: jobID,
+	}, "Rewarm job created successfully")
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewarmJobRequestValidate(t *testing.T) {
+	assert.NoError(t, RewarmJobRequest{StatusCodes: []int{500, 502, 0}}.validate())
+	assert.NoError(t, RewarmJobRequest{CacheStatuses: []string{"MISS", "EXPIRED"}}.validate())
+	assert.ErrorIs(t, RewarmJobRequest{}.validate(), jobs.ErrEmptyRewarmFilter)
+	assert.Error(t, RewarmJobRequest{StatusCodes: []int{42}}.validate())
+	assert.Error(t, RewarmJobRequest{CacheStatuses: []string{""}}.validate())
+	assert.Error(t, RewarmJobRequest{StatusCodes: make([]int, maxRewarmFilterValues+1)}.validate())
+}
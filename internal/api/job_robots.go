@@ -0,0 +1,31 @@
+package api
+
+import "net/http"
+
+// getJobRobots handles GET /v1/jobs/:id/robots, reporting the robots.txt
+// rules enforced for the job and the sitemaps its discovery found
+func (h *Handler) getJobRobots(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	robots, err := h.JobsManager.GetJobRobots(r.Context(), jobID)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to get job robots.txt rules")
+		InternalError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, robots, "Job robots.txt rules retrieved successfully")
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+)
+
+// taskSortClaim lists a job's tasks in the order workers claim them
+const taskSortClaim = "claim"
+
+// errInvalidTaskCursor is returned when a claim-order cursor can't be decoded
+var errInvalidTaskCursor = errors.New("invalid cursor")
+
+// claimListStatuses are the task statuses GET /v1/jobs/:id/tasks?sort=claim filters on
+var claimListStatuses = map[string]bool{
+	string(jobs.TaskStatusPending):   true,
+	string(jobs.TaskStatusRunning):   true,
+	string(jobs.TaskStatusWaiting):   true,
+	string(jobs.TaskStatusCompleted): true,
+	string(jobs.TaskStatusFailed):    true,
+	string(jobs.TaskStatusSkipped):   true,
+}
+
+// ClaimOrderTask is a task as listed in claim order
+type ClaimOrderTask struct {
+	ID            string  `json:"id"`
+	Path          string  `json:"path"`
+	Status        string  `json:"status"`
+	PriorityScore float64 `json:"priority_score"`
+	StatusCode    *int    `json:"status_code,omitempty"`
+	CacheStatus   *string `json:"cache_status,omitempty"`
+	RetryCount    int     `json:"retry_count"`
+	Error         *string `json:"error,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+
+	cursor taskClaimCursor
+}
+
+// taskClaimCursor is the claim-order position of the last task on a page:
+// priority_score DESC, then created_at and id ascending
+type taskClaimCursor struct {
+	priority  string
+	createdAt time.Time
+	id        string
+}
+
+func encodeTaskClaimCursor(c taskClaimCursor) string {
+	raw := c.priority + "|" + c.createdAt.UTC().Format(time.RFC3339Nano) + "|" + c.id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTaskClaimCursor(s string) (taskClaimCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return taskClaimCursor{}, errInvalidTaskCursor
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return taskClaimCursor{}, errInvalidTaskCursor
+	}
+	if _, err := strconv.ParseFloat(parts[0], 64); err != nil {
+		return taskClaimCursor{}, errInvalidTaskCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return taskClaimCursor{}, errInvalidTaskCursor
+	}
+	return taskClaimCursor{priority: parts[0], createdAt: createdAt, id: parts[2]}, nil
+}
+
+// buildClaimOrderTaskQuery selects one page of a job's tasks in claim order,
+// plus one extra row to tell whether another page follows
+func buildClaimOrderTaskQuery(jobID, status string, cursor *taskClaimCursor, limit int) (string, []any) {
+	query := `
+		SELECT t.id, p.path, t.status, COALESCE(t.priority_score, 0)::text,
+		       t.status_code, t.cache_status, t.retry_count, t.error, t.created_at
+		FROM tasks t
+		JOIN pages p ON t.page_id = p.id
+		WHERE t.job_id = $1`
+	args := []any{jobID}
+
+	if status != "" {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND t.status = $%d", len(args))
+	}
+
+	// Mixed sort directions rule out a single row comparison
+	if cursor != nil {
+		args = append(args, cursor.priority, cursor.createdAt, cursor.id)
+		query += fmt.Sprintf(` AND (t.priority_score < $%[1]d::numeric
+			OR (t.priority_score = $%[1]d::numeric AND (t.created_at, t.id) > ($%[2]d::timestamptz, $%[3]d)))`,
+			len(args)-2, len(args)-1, len(args))
+	}
+
+	args = append(args, limit+1)
+	query += fmt.Sprintf(" ORDER BY t.priority_score DESC, t.created_at ASC, t.id ASC LIMIT $%d", len(args))
+	return query, args
+}
+
+// listTasksInClaimOrder handles GET /v1/jobs/:id/tasks?sort=claim. Tasks come
+// back in the order workers claim them, paged by an opaque cursor so pages stay
+// stable while tasks complete. The caller has already checked job access.
+func (h *Handler) listTasksInClaimOrder(w http.ResponseWriter, r *http.Request, jobID string, limit int) {
+	logger := loggerWithRequest(r)
+
+	status := r.URL.Query().Get("status")
+	if status != "" && !claimListStatuses[status] {
+		BadRequest(w, r, fmt.Sprintf("Invalid task status: %s", status))
+		return
+	}
+
+	var cursor *taskClaimCursor
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		decoded, err := decodeTaskClaimCursor(raw)
+		if err != nil {
+			BadRequest(w, r, "Invalid cursor")
+			return
+		}
+		cursor = &decoded
+	}
+
+	tasks, err := h.queryClaimOrderTasks(r.Context(), jobID, status, cursor, limit)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to list tasks in claim order")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	pagination := map[string]any{
+		"limit":    limit,
+		"has_next": len(tasks) > limit,
+		"has_prev": cursor != nil,
+	}
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		pagination["next_cursor"] = encodeTaskClaimCursor(tasks[len(tasks)-1].cursor)
+	}
+
+	WriteSuccess(w, r, map[string]any{
+		"tasks":      tasks,
+		"pagination": pagination,
+	}, "Tasks retrieved successfully")
+}
+
+func (h *Handler) queryClaimOrderTasks(ctx context.Context, jobID, status string, cursor *taskClaimCursor, limit int) ([]ClaimOrderTask, error) {
+	query, args := buildClaimOrderTaskQuery(jobID, status, cursor, limit)
+	rows, err := h.DB.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]ClaimOrderTask, 0, limit+1)
+	for rows.Next() {
+		var task ClaimOrderTask
+		var priority string
+		var statusCode sql.NullInt32
+		var cacheStatus, taskError sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&task.ID, &task.Path, &task.Status, &priority,
+			&statusCode, &cacheStatus, &task.RetryCount, &taskError, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+
+		task.PriorityScore, _ = strconv.ParseFloat(priority, 64)
+		if statusCode.Valid {
+			code := int(statusCode.Int32)
+			task.StatusCode = &code
+		}
+		if cacheStatus.Valid {
+			task.CacheStatus = &cacheStatus.String
+		}
+		if taskError.Valid {
+			task.Error = &taskError.String
+		}
+		task.CreatedAt = createdAt.Format(time.RFC3339)
+		task.cursor = taskClaimCursor{priority: priority, createdAt: createdAt, id: task.ID}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskClaimCursorRoundTrip(t *testing.T) {
+	original := taskClaimCursor{
+		priority:  "0.850",
+		createdAt: time.Date(2026, 10, 16, 9, 30, 0, 123456789, time.UTC),
+		id:        "task-1",
+	}
+
+	decoded, err := decodeTaskClaimCursor(encodeTaskClaimCursor(original))
+	require.NoError(t, err)
+	assert.Equal(t, original.priority, decoded.priority)
+	assert.True(t, original.createdAt.Equal(decoded.createdAt))
+	assert.Equal(t, original.id, decoded.id)
+}
+
+func TestDecodeTaskClaimCursorInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not base64":   "!!!",
+		"missing id":   encodeTaskClaimCursor(taskClaimCursor{priority: "1", createdAt: time.Now()}),
+		"bad priority": "YWJjfDIwMjYtMTAtMTZUMDk6MzA6MDBafHRhc2stMQ",
+		"bad time":     "MXxub3QtYS10aW1lfHRhc2stMQ",
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeTaskClaimCursor(raw)
+			assert.ErrorIs(t, err, errInvalidTaskCursor)
+		})
+	}
+}
+
+func TestBuildClaimOrderTaskQuery(t *testing.T) {
+	t.Run("first page", func(t *testing.T) {
+		query, args := buildClaimOrderTaskQuery("job-1", "", nil, 50)
+		assert.Equal(t, []any{"job-1", 51}, args)
+		assert.Contains(t, query, "ORDER BY t.priority_score DESC, t.created_at ASC, t.id ASC LIMIT $2")
+		assert.NotContains(t, query, "t.status =")
+	})
+
+	t.Run("status and cursor", func(t *testing.T) {
+		createdAt := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+		cursor := &taskClaimCursor{priority: "0.5", createdAt: createdAt, id: "task-9"}
+
+		query, args := buildClaimOrderTaskQuery("job-1", "pending", cursor, 20)
+		assert.Equal(t, []any{"job-1", "pending", "0.5", createdAt, "task-9", 21}, args)
+		assert.Contains(t, query, "t.status = $2")
+		assert.Contains(t, query, "t.priority_score < $3::numeric")
+		assert.Contains(t, query, "(t.created_at, t.id) > ($4::timestamptz, $5)")
+		assert.Contains(t, query, "LIMIT $6")
+	})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+// TimingPercentiles summarises a duration distribution in milliseconds.
+// Percentiles are null when there are no samples.
+type TimingPercentiles struct {
+	Count int      `json:"count"`
+	P50Ms *float64 `json:"p50_ms"`
+	P95Ms *float64 `json:"p95_ms"`
+	P99Ms *float64 `json:"p99_ms"`
+}
+
+// JobTimingResponse separates time spent waiting in our queue from time
+// spent crawling, to tell queue backpressure apart from a slow origin
+type JobTimingResponse struct {
+	JobID         string            `json:"job_id"`
+	QueueWait     TimingPercentiles `json:"queue_wait"`     // Task created to claimed by a worker
+	CrawlDuration TimingPercentiles `json:"crawl_duration"` // Claimed to completed
+}
+
+// newTimingPercentiles converts scanned percentile_cont results
+func newTimingPercentiles(count int, p50, p95, p99 sql.NullFloat64) TimingPercentiles {
+	percentiles := TimingPercentiles{Count: count}
+	if p50.Valid {
+		percentiles.P50Ms = &p50.Float64
+	}
+	if p95.Valid {
+		percentiles.P95Ms = &p95.Float64
+	}
+	if p99.Valid {
+		percentiles.P99Ms = &p99.Float64
+	}
+	return percentiles
+}
+
+// getJobTiming handles GET /v1/jobs/:id/timing
+func (h *Handler) getJobTiming(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	// Both distributions cover the same completed tasks, so their counts match
+	var count int
+	var waitP50, waitP95, waitP99 sql.NullFloat64
+	var crawlP50, crawlP95, crawlP99 sql.NullFloat64
+	err := h.DB.GetDB().QueryRowContext(r.Context(), `
+		WITH timings AS (
+			SELECT EXTRACT(EPOCH FROM (started_at - created_at)) * 1000 AS queue_wait_ms,
+			       EXTRACT(EPOCH FROM (completed_at - started_at)) * 1000 AS crawl_ms
+			FROM tasks
+			WHERE job_id = $1
+			  AND status = 'completed'
+			  AND started_at IS NOT NULL
+			  AND completed_at IS NOT NULL
+		)
+		SELECT COUNT(*),
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY queue_wait_ms),
+		       percentile_cont(0.95) WITHIN GROUP (ORDER BY queue_wait_ms),
+		       percentile_cont(0.99) WITHIN GROUP (ORDER BY queue_wait_ms),
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY crawl_ms),
+		       percentile_cont(0.95) WITHIN GROUP (ORDER BY crawl_ms),
+		       percentile_cont(0.99) WITHIN GROUP (ORDER BY crawl_ms)
+		FROM timings
+	`, jobID).Scan(&count, &waitP50, &waitP95, &waitP99, &crawlP50, &crawlP95, &crawlP99)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to compute job timing")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, JobTimingResponse{
+		JobID:         jobID,
+		QueueWait:     newTimingPercentiles(count, waitP50, waitP95, waitP99),
+		CrawlDuration: newTimingPercentiles(count, crawlP50, crawlP95, crawlP99),
+	}, "Job timing retrieved successfully")
+}
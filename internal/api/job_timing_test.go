@@ -0,0 +1,32 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimingPercentiles(t *testing.T) {
+	percentiles := newTimingPercentiles(120,
+		sql.NullFloat64{Float64: 250, Valid: true},
+		sql.NullFloat64{Float64: 1800, Valid: true},
+		sql.NullFloat64{Float64: 4200.5, Valid: true},
+	)
+
+	assert.Equal(t, 120, percentiles.Count)
+	require.NotNil(t, percentiles.P50Ms)
+	assert.Equal(t, 250.0, *percentiles.P50Ms)
+	assert.Equal(t, 1800.0, *percentiles.P95Ms)
+	assert.Equal(t, 4200.5, *percentiles.P99Ms)
+}
+
+func TestNewTimingPercentilesNoSamples(t *testing.T) {
+	percentiles := newTimingPercentiles(0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{})
+
+	body, err := json.Marshal(percentiles)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"count":0,"p50_ms":null,"p95_ms":null,"p99_ms":null}`, string(body))
+}
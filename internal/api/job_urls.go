@@ -0,0 +1,125 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+)
+
+// JobURL is one URL discovered for a job
+type JobURL struct {
+	URL      string  `json:"url"`
+	Path     string  `json:"path"`
+	Source   string  `json:"source"` // sitemap, fallback, warm_list, seed, manual or link
+	Status   string  `json:"status"` // discovered for dry runs, otherwise the task status
+	Priority float64 `json:"priority"`
+}
+
+// JobURLsResponse lists the URLs a job discovered, highest priority first
+type JobURLsResponse struct {
+	JobID      string         `json:"job_id"`
+	DryRun     bool           `json:"dry_run"`
+	URLs       []JobURL       `json:"urls"`
+	Pagination map[string]any `json:"pagination"`
+}
+
+// parseJobURLsPaging reads limit (default 1000, max 5000) and offset
+func parseJobURLsPaging(r *http.Request) (limit, offset int) {
+	limit = 1000
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 5000 {
+			limit = parsedLimit
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+	return limit, offset
+}
+
+// getJobURLs handles GET /v1/jobs/:id/urls
+func (h *Handler) getJobURLs(w http.ResponseWriter, r *http.Request, jobID string) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user := h.validateJobAccess(w, r, jobID)
+	if user == nil {
+		return // validateJobAccess already wrote the error response
+	}
+
+	limit, offset := parseJobURLsPaging(r)
+
+	var domain string
+	var dryRun bool
+	var total int
+	err := h.DB.GetDB().QueryRowContext(r.Context(), `
+		SELECT d.name, j.dry_run,
+		       (SELECT COUNT(*) FROM tasks t WHERE t.job_id = j.id AND t.status <> 'skipped')
+		FROM jobs j
+		JOIN domains d ON j.domain_id = d.id
+		WHERE j.id = $1
+	`, jobID).Scan(&domain, &dryRun, &total)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to load job for URL listing")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	// Skipped tasks are URLs cut by max_pages, so they aren't listed
+	rows, err := h.DB.GetDB().QueryContext(r.Context(), `
+		SELECT path, source_type, status, COALESCE(priority_score, 0)
+		FROM tasks
+		WHERE job_id = $1 AND status <> 'skipped'
+		ORDER BY priority_score DESC, path
+		LIMIT $2 OFFSET $3
+	`, jobID, limit, offset)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to list job URLs")
+		DatabaseError(w, r, err)
+		return
+	}
+	defer rows.Close()
+
+	urls := make([]JobURL, 0, min(limit, total))
+	for rows.Next() {
+		var u JobURL
+		if err := rows.Scan(&u.Path, &u.Source, &u.Status, &u.Priority); err != nil {
+			logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to scan job URL")
+			DatabaseError(w, r, err)
+			return
+		}
+		u.URL = util.ConstructURL(domain, u.Path)
+		urls = append(urls, u)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to iterate job URLs")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	WriteSuccess(w, r, JobURLsResponse{
+		JobID:  jobID,
+		DryRun: dryRun,
+		URLs:   urls,
+		Pagination: map[string]any{
+			"limit":    limit,
+			"offset":   offset,
+			"total":    total,
+			"has_next": offset+limit < total,
+			"has_prev": offset > 0,
+		},
+	}, "Job URLs retrieved successfully")
+}
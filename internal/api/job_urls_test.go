@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJobURLsPaging(t *testing.T) {
+	tests := []struct {
+		query          string
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{"", 1000, 0},
+		{"?limit=250&offset=500", 250, 500},
+		{"?limit=5000", 5000, 0},
+		{"?limit=5001&offset=-1", 1000, 0},
+		{"?limit=abc&offset=abc", 1000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			limit, offset := parseJobURLsPaging(httptest.NewRequest("GET", "/v1/jobs/job-1/urls"+tt.query, nil))
+			assert.Equal(t, tt.expectedLimit, limit)
+			assert.Equal(t, tt.expectedOffset, offset)
+		})
+	}
+}
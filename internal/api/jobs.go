@@ -7,13 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
 	"github.com/Harvey-AU/blue-banded-bee/internal/db"
 	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
 )
 
@@ -38,6 +41,16 @@ func (h *Handler) JobHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if path == "from-har" {
+		h.createJobFromHAR(w, r)
+		return
+	}
+
+	if path == "batch" {
+		h.createJobsBatch(w, r)
+		return
+	}
+
 	// Handle sub-routes like /v1/jobs/:id/tasks
 	parts := strings.Split(path, "/")
 	jobID := parts[0]
@@ -51,6 +64,30 @@ func (h *Handler) JobHandler(w http.ResponseWriter, r *http.Request) {
 		case "export":
 			h.exportJobTasks(w, r, jobID)
 			return
+		case "issues":
+			h.getJobIssues(w, r, jobID)
+			return
+		case "failures":
+			h.getJobFailures(w, r, jobID)
+			return
+		case "urls":
+			h.getJobURLs(w, r, jobID)
+			return
+		case "events":
+			h.getJobEvents(w, r, jobID)
+			return
+		case "timing":
+			h.getJobTiming(w, r, jobID)
+			return
+		case "robots":
+			h.getJobRobots(w, r, jobID)
+			return
+		case "rewarm":
+			h.rewarmJob(w, r, jobID)
+			return
+		case "prioritise":
+			h.prioritiseJobTasks(w, r, jobID)
+			return
 		case "cancel":
 			if r.Method == http.MethodPost {
 				h.cancelJob(w, r, jobID)
@@ -93,7 +130,7 @@ func (h *Handler) JobHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
 		h.getJob(w, r, jobID)
-	case http.MethodPut:
+	case http.MethodPut, http.MethodPatch:
 		h.updateJob(w, r, jobID)
 	case http.MethodDelete:
 		h.cancelJob(w, r, jobID)
@@ -104,14 +141,54 @@ func (h *Handler) JobHandler(w http.ResponseWriter, r *http.Request) {
 
 // CreateJobRequest represents the request body for creating a job
 type CreateJobRequest struct {
-	Domain       string  `json:"domain"`
-	UseSitemap   *bool   `json:"use_sitemap,omitempty"`
-	FindLinks    *bool   `json:"find_links,omitempty"`
-	Concurrency  *int    `json:"concurrency,omitempty"`
-	MaxPages     *int    `json:"max_pages,omitempty"`
-	SourceType   *string `json:"source_type,omitempty"`
-	SourceDetail *string `json:"source_detail,omitempty"`
-	SourceInfo   *string `json:"source_info,omitempty"`
+	Domain            string  `json:"domain"`
+	UseSitemap        *bool   `json:"use_sitemap,omitempty"`
+	FindLinks         *bool   `json:"find_links,omitempty"`
+	Concurrency       *int    `json:"concurrency,omitempty"`
+	VerifyConcurrency *int    `json:"verify_concurrency,omitempty"`
+	MaxPages          *int    `json:"max_pages,omitempty"`
+	MaxDepth          *int    `json:"max_depth,omitempty"` // Link hops followed from the homepage; 0 for no limit
+	MaxRetries        *int    `json:"max_retries,omitempty"`
+	RetryBudget       *int    `json:"retry_budget,omitempty"` // Total retries across all tasks; 0 for no cap
+	SourceType        *string `json:"source_type,omitempty"`
+	SourceDetail      *string `json:"source_detail,omitempty"`
+	SourceInfo        *string `json:"source_info,omitempty"`
+
+	ConcurrencySchedule  *jobs.ConcurrencySchedule `json:"concurrency_schedule,omitempty"`
+	CacheableStatusCodes []int                     `json:"cacheable_status_codes,omitempty"`
+	ChangedOnly          *bool                     `json:"changed_only,omitempty"`
+	SkipCachedURLs       *bool                     `json:"skip_cached_urls,omitempty"` // Skip pages the edge already has cached
+	ContentTypes         []string                  `json:"content_types,omitempty"`    // Media types to warm; defaults to HTML, or */* with warm or seed URLs
+	URLScheme            *string                   `json:"url_scheme,omitempty"`       // https (default) or http
+	Tags                 []string                  `json:"tags,omitempty"`             // Labels for filtering job history, e.g. pre-deploy
+	PriorityTier         *string                   `json:"priority_tier,omitempty"`
+	SlowOriginPolicy     *string                   `json:"slow_origin_policy,omitempty"`
+	AutoConcurrency      *bool                     `json:"auto_concurrency,omitempty"` // Tune concurrency up to the concurrency value
+	UserAgent            *string                   `json:"user_agent,omitempty"`
+	CustomHeaders        map[string]string         `json:"custom_headers,omitempty"` // Extra headers on warming requests
+	ConditionalWarm      *bool                     `json:"conditional_warm,omitempty"`
+	DetectSoft404        *bool                     `json:"detect_soft_404,omitempty"`     // Flag 200 pages that look like "not found" pages
+	HashContent          *bool                     `json:"hash_content,omitempty"`        // Report which pages changed since the last warm
+	FullBodyDetection    *bool                     `json:"full_body_detection,omitempty"` // Detect technologies from the whole page
+	NotifyWebhookURL     *string                   `json:"notify_webhook_url,omitempty"`
+	PurgeBeforeWarm      *bool                     `json:"purge_before_warm,omitempty"`
+	FreshnessWindowDays  *int                      `json:"freshness_window_days,omitempty"`
+	TaskTimeoutSeconds   *int                      `json:"task_timeout_seconds,omitempty"`
+	DryRun               *bool                     `json:"dry_run,omitempty"`
+	Credentials          *crawler.Credentials      `json:"credentials,omitempty"` // Stored in Vault, never returned
+	Method               *string                   `json:"method,omitempty"`      // GET (default) or HEAD
+	GroupSubdomains      *bool                     `json:"group_subdomains,omitempty"`
+	SecondRequest        *bool                     `json:"second_request,omitempty"` // Default true; false only primes the cache
+	MinCrawlDelaySeconds *int                      `json:"min_crawl_delay_seconds,omitempty"`
+	MaxCrawlDelaySeconds *int                      `json:"max_crawl_delay_seconds,omitempty"`
+	PrioritiseBySearch   *bool                     `json:"prioritise_by_search,omitempty"` // Boost pages by Search Console impressions
+	MaxRuntimeMinutes    *int                      `json:"max_runtime_minutes,omitempty"`  // Stop the job this long after it starts
+	MaxRuntimeAction     *string                   `json:"max_runtime_action,omitempty"`   // fail (default) or complete
+	SeedURLs             []string                  `json:"seed_urls,omitempty"`            // Warm exactly these URLs instead of sitemap or root discovery
+	OnDuplicate          *string                   `json:"on_duplicate,omitempty"`         // cancel (default), reuse or error when the domain already has an active job
+
+	// WarmURLs is set by the HAR import; it isn't accepted in JSON bodies
+	WarmURLs []string `json:"-"`
 }
 
 // JobResponse represents a job in API responses
@@ -135,10 +212,117 @@ type JobResponse struct {
 	SchedulerID           *string        `json:"scheduler_id,omitempty"`
 	// Job configuration fields
 	Concurrency          int     `json:"concurrency"`
+	VerifyConcurrency    int     `json:"verify_concurrency"`
 	MaxPages             int     `json:"max_pages"`
+	MaxDepth             int     `json:"max_depth"`
+	MaxRetries           int     `json:"max_retries"`
 	SourceType           *string `json:"source_type,omitempty"`
 	CrawlDelaySeconds    *int    `json:"crawl_delay_seconds,omitempty"`
 	AdaptiveDelaySeconds int     `json:"adaptive_delay_seconds"`
+
+	ConcurrencySchedule  json.RawMessage `json:"concurrency_schedule,omitempty"`
+	CacheableStatusCodes []int64         `json:"cacheable_status_codes,omitempty"`
+
+	// Queue delay caused by the job's own concurrency limit rather than the origin
+	ConcurrencyBlocks    int64 `json:"concurrency_blocks"`
+	ConcurrencyBlockedMs int64 `json:"concurrency_blocked_ms"`
+
+	// Changed-only warming: pages skipped because they matched the previous job
+	ChangedOnly    bool `json:"changed_only"`
+	UnchangedTasks int  `json:"unchanged_tasks"`
+
+	// Skip-cached warming: pages skipped because the edge already had them cached
+	SkipCachedURLs bool `json:"skip_cached_urls"`
+	CachedTasks    int  `json:"cached_tasks"`
+
+	// Content type filtering: pages skipped because their type wasn't allowed
+	ContentTypes     []string `json:"content_types,omitempty"`
+	TypeSkippedTasks int      `json:"type_skipped_tasks"`
+
+	Tags []string `json:"tags,omitempty"`
+
+	// Scheme pages are warmed over and sitemap entries listed with the other one
+	URLScheme       string `json:"url_scheme"`
+	MixedSchemeURLs int    `json:"mixed_scheme_urls"`
+
+	// Retry budget: total retries allowed across the job's tasks and spent so far
+	RetryBudget int `json:"retry_budget"`
+	RetriesUsed int `json:"retries_used"`
+
+	PriorityTier     string `json:"priority_tier"`
+	SlowOriginPolicy string `json:"slow_origin_policy"`
+	UserAgent        string `json:"user_agent,omitempty"`
+
+	// Auto-tuned concurrency: the limit found so far and whether it has settled
+	AutoConcurrency bool `json:"auto_concurrency"`
+	AutoLimit       *int `json:"auto_concurrency_limit,omitempty"`
+	AutoLimitStable bool `json:"auto_concurrency_stable"`
+
+	// Extra headers sent on every warming request
+	CustomHeaders json.RawMessage `json:"custom_headers,omitempty"`
+
+	// HEAD jobs record status, timing and cache headers without bodies
+	Method string `json:"method"`
+
+	// Requests are paced under the registrable domain, shared with its subdomains
+	GroupSubdomains bool `json:"group_subdomains"`
+
+	// Cache misses are re-fetched to measure the warmed response
+	SecondRequest bool `json:"second_request"`
+
+	// Bounds on the robots.txt Crawl-delay, 0 when unset
+	MinCrawlDelaySeconds int `json:"min_crawl_delay_seconds"`
+	MaxCrawlDelaySeconds int `json:"max_crawl_delay_seconds"`
+
+	// Pages are boosted by Search Console impressions when the site is connected
+	PrioritiseBySearch bool `json:"prioritise_by_search"`
+
+	// Runtime ceiling (0 for none) and the terminal state when it's reached
+	MaxRuntimeMinutes int    `json:"max_runtime_minutes"`
+	MaxRuntimeAction  string `json:"max_runtime_action"`
+
+	// Conditional warming: pages the origin answered 304 Not Modified
+	ConditionalWarm  bool `json:"conditional_warm"`
+	NotModifiedTasks int  `json:"not_modified_tasks"`
+
+	// Soft-404 detection: completed pages that look like "not found" pages
+	DetectSoft404 bool `json:"detect_soft_404"`
+	Soft404Tasks  int  `json:"soft_404_tasks"`
+
+	// Content hashing: completed pages whose body changed since their last warm
+	HashContent      bool `json:"hash_content"`
+	ContentChanged   int  `json:"content_changed_tasks"`
+	ContentUnchanged int  `json:"content_unchanged_tasks"`
+
+	// Technology detection runs on the whole page rather than the body sample
+	FullBodyDetection bool `json:"full_body_detection"`
+
+	// Sitemaps discovery tried to load and those still unreachable after retries
+	SitemapsTotal  int      `json:"sitemaps_total"`
+	FailedSitemaps []string `json:"failed_sitemaps,omitempty"`
+	SitemapSummary string   `json:"sitemap_summary,omitempty"` // e.g. "3 of 12 sitemaps failed to load"
+
+	// Completion webhook and its delivery outcome
+	NotifyWebhookURL    string  `json:"notify_webhook_url,omitempty"`
+	NotifyWebhookStatus *string `json:"notify_webhook_status,omitempty"` // sending, delivered or failed
+
+	// Purge the CDN before warming; purge failures surface as a warning
+	PurgeBeforeWarm bool    `json:"purge_before_warm"`
+	WarningMessage  *string `json:"warning_message,omitempty"`
+
+	TaskTimeoutSeconds int `json:"task_timeout_seconds"`
+
+	// Dry runs list discovered URLs (GET /v1/jobs/:id/urls) without warming
+	DryRun bool `json:"dry_run"`
+
+	// Credentials themselves are never returned, only whether they're set
+	HasCredentials bool `json:"has_credentials"`
+
+	// Set when job creation returned an existing active job (on_duplicate: reuse)
+	AlreadyRunning bool `json:"already_running,omitempty"`
+
+	// Failed tasks by cause; omitted until a task fails
+	ErrorBreakdown *jobs.ErrorBreakdown `json:"error_breakdown,omitempty"`
 }
 
 // listJobs handles GET /v1/jobs
@@ -151,37 +335,20 @@ func (h *Handler) listJobs(w http.ResponseWriter, r *http.Request) {
 		return // Error already written
 	}
 
-	// Parse query parameters
-	limit := 10 // default
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
-			limit = parsedLimit
-		}
-	}
-
-	offset := 0
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
-	}
-
-	status := r.URL.Query().Get("status")        // Optional status filter
-	dateRange := r.URL.Query().Get("range")      // Optional date range filter
-	tzOffsetStr := r.URL.Query().Get("tzOffset") // Optional timezone offset in minutes
-	include := r.URL.Query().Get("include")      // Optional includes (domain, progress, etc.)
-
-	// Parse timezone offset (default to 0 for UTC)
-	tzOffset := 0
-	if tzOffsetStr != "" {
-		if parsed, err := strconv.Atoi(tzOffsetStr); err == nil {
-			tzOffset = parsed
-		}
+	opts, err := parseJobListOptions(r.URL.Query())
+	if err != nil {
+		BadRequest(w, r, err.Error())
+		return
 	}
+	include := r.URL.Query().Get("include") // Optional includes (domain, progress, etc.)
 
 	// Get jobs from database
-	jobs, total, err := h.DB.ListJobsWithOffset(orgID, limit, offset, status, dateRange, tzOffset)
+	page, err := h.DB.ListJobsPage(orgID, opts)
 	if err != nil {
+		if errors.Is(err, db.ErrInvalidJobCursor) {
+			BadRequest(w, r, "Invalid cursor")
+			return
+		}
 		if HandlePoolSaturation(w, r, err) {
 			return
 		}
@@ -190,20 +357,21 @@ func (h *Handler) listJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Calculate pagination info
-	hasNext := offset+limit < total
-	hasPrev := offset > 0
+	pagination := map[string]any{
+		"limit":    opts.Limit,
+		"offset":   opts.Offset,
+		"total":    page.Total,
+		"has_next": page.HasNext,
+		"has_prev": opts.Offset > 0 || opts.Cursor != "",
+	}
+	if page.NextCursor != "" {
+		pagination["next_cursor"] = page.NextCursor
+	}
 
 	// Prepare response
 	response := map[string]any{
-		"jobs": jobs,
-		"pagination": map[string]any{
-			"limit":    limit,
-			"offset":   offset,
-			"total":    total,
-			"has_next": hasNext,
-			"has_prev": hasPrev,
-		},
+		"jobs":       page.Jobs,
+		"pagination": pagination,
 	}
 
 	if include != "" {
@@ -214,6 +382,273 @@ func (h *Handler) listJobs(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, r, response, "Jobs retrieved successfully")
 }
 
+// parseJobListOptions reads GET /v1/jobs query parameters. Unparseable paging
+// values fall back to defaults; unknown sort fields and orders are rejected.
+func parseJobListOptions(query url.Values) (db.JobListOptions, error) {
+	opts := db.JobListOptions{
+		Limit:     10,
+		Cursor:    query.Get("cursor"),
+		Status:    query.Get("status"),
+		DateRange: query.Get("range"),
+		SortBy:    db.JobSortCreatedAt,
+	}
+
+	if parsed, err := strconv.Atoi(query.Get("limit")); err == nil && parsed > 0 && parsed <= 100 {
+		opts.Limit = parsed
+	}
+	if parsed, err := strconv.Atoi(query.Get("offset")); err == nil && parsed >= 0 {
+		opts.Offset = parsed
+	}
+	if opts.Cursor != "" {
+		opts.Offset = 0 // Cursor paging replaces offsets
+	}
+	// Timezone offset in minutes, defaulting to UTC
+	if parsed, err := strconv.Atoi(query.Get("tzOffset")); err == nil {
+		opts.TZOffsetMinutes = parsed
+	}
+	if domain := query.Get("domain"); domain != "" {
+		opts.Domain = util.NormaliseDomain(domain)
+	}
+	if tag := query.Get("tag"); tag != "" {
+		normalised, err := jobs.NormaliseTag(tag)
+		if err != nil {
+			return opts, err
+		}
+		opts.Tag = normalised
+	}
+
+	switch sort := query.Get("sort"); sort {
+	case "", db.JobSortCreatedAt:
+	case db.JobSortCompletedAt:
+		opts.SortBy = sort
+	default:
+		return opts, fmt.Errorf("sort must be '%s' or '%s'", db.JobSortCreatedAt, db.JobSortCompletedAt)
+	}
+
+	switch order := strings.ToLower(query.Get("order")); order {
+	case "", "desc":
+	case "asc":
+		opts.Ascending = true
+	default:
+		return opts, fmt.Errorf("order must be 'asc' or 'desc'")
+	}
+
+	return opts, nil
+}
+
+// effectiveConcurrency returns the requested concurrency clamped to 1-100, defaulting to 20
+func (req CreateJobRequest) effectiveConcurrency() int {
+	if req.Concurrency != nil && *req.Concurrency > 0 {
+		return min(*req.Concurrency, 100)
+	}
+	return 20
+}
+
+// retryBudget returns the requested total retry budget, 0 (no cap) when unset
+func (req CreateJobRequest) retryBudget() int {
+	if req.RetryBudget != nil {
+		return *req.RetryBudget
+	}
+	return 0
+}
+
+// crawlDelayBounds returns the requested crawl delay floor and ceiling, 0 when unset
+func (req CreateJobRequest) crawlDelayBounds() (minSeconds, maxSeconds int) {
+	if req.MinCrawlDelaySeconds != nil {
+		minSeconds = *req.MinCrawlDelaySeconds
+	}
+	if req.MaxCrawlDelaySeconds != nil {
+		maxSeconds = *req.MaxCrawlDelaySeconds
+	}
+	return minSeconds, maxSeconds
+}
+
+// maxRuntime returns the requested runtime ceiling and action, zero values when unset
+func (req CreateJobRequest) maxRuntime() (minutes int, action jobs.MaxRuntimeAction) {
+	if req.MaxRuntimeMinutes != nil {
+		minutes = *req.MaxRuntimeMinutes
+	}
+	if req.MaxRuntimeAction != nil {
+		action = jobs.MaxRuntimeAction(*req.MaxRuntimeAction)
+	}
+	return minutes, action
+}
+
+// duplicatePolicy returns the requested duplicate policy, cancel when unset
+func (req CreateJobRequest) duplicatePolicy() (jobs.DuplicatePolicy, error) {
+	var raw string
+	if req.OnDuplicate != nil {
+		raw = *req.OnDuplicate
+	}
+	return jobs.ParseDuplicatePolicy(raw)
+}
+
+// maxDepth returns the requested link depth limit, 0 (no limit) when unset
+func (req CreateJobRequest) maxDepth() int {
+	if req.MaxDepth != nil {
+		return *req.MaxDepth
+	}
+	return 0
+}
+
+// validateRunLimits checks the crawl delay bounds, max runtime and max depth
+// together
+func (req CreateJobRequest) validateRunLimits() error {
+	if err := jobs.ValidateCrawlDelayBounds(req.crawlDelayBounds()); err != nil {
+		return err
+	}
+	if err := jobs.ValidateMaxDepth(req.maxDepth()); err != nil {
+		return err
+	}
+	minutes, action := req.maxRuntime()
+	if err := jobs.ValidateMaxRuntimeMinutes(minutes); err != nil {
+		return err
+	}
+	_, err := jobs.ParseMaxRuntimeAction(string(action))
+	return err
+}
+
+// validateRequestHeaders checks the user agent override and custom headers
+func (req CreateJobRequest) validateRequestHeaders() error {
+	if req.UserAgent != nil {
+		if err := jobs.ValidateUserAgent(*req.UserAgent); err != nil {
+			return err
+		}
+	}
+	return jobs.ValidateCustomHeaders(req.CustomHeaders)
+}
+
+// validate checks a create job request, returning the first problem in a
+// message suitable for a 400 response
+func (req CreateJobRequest) validate() error {
+	if req.Domain == "" {
+		return errors.New("domain is required")
+	}
+
+	// Validate domain format
+	if err := util.ValidateDomain(req.Domain); err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+
+	if req.MaxRetries != nil {
+		if err := jobs.ValidateMaxRetries(*req.MaxRetries); err != nil {
+			return err
+		}
+	}
+
+	if req.RetryBudget != nil {
+		if err := jobs.ValidateRetryBudget(*req.RetryBudget); err != nil {
+			return err
+		}
+	}
+
+	if req.TaskTimeoutSeconds != nil && *req.TaskTimeoutSeconds < 0 {
+		return errors.New("task_timeout_seconds cannot be negative")
+	}
+
+	if req.ConcurrencySchedule != nil {
+		if err := req.ConcurrencySchedule.Validate(req.effectiveConcurrency()); err != nil {
+			return err
+		}
+	}
+
+	if err := jobs.ValidateCacheableStatusCodes(req.CacheableStatusCodes); err != nil {
+		return err
+	}
+
+	if _, err := jobs.NormaliseContentTypes(req.ContentTypes); err != nil {
+		return err
+	}
+
+	if _, err := jobs.NormaliseTags(req.Tags); err != nil {
+		return err
+	}
+
+	if req.PriorityTier != nil {
+		if _, err := jobs.ParsePriorityTier(*req.PriorityTier); err != nil {
+			return err
+		}
+	}
+
+	if req.SlowOriginPolicy != nil {
+		if _, err := jobs.ParseSlowOriginPolicy(*req.SlowOriginPolicy); err != nil {
+			return err
+		}
+	}
+
+	if req.URLScheme != nil {
+		if _, err := jobs.ParseURLScheme(*req.URLScheme); err != nil {
+			return err
+		}
+	}
+
+	if err := req.validateRequestHeaders(); err != nil {
+		return err
+	}
+
+	if req.Method != nil {
+		if _, err := jobs.ParseWarmMethod(*req.Method); err != nil {
+			return err
+		}
+	}
+
+	if req.FreshnessWindowDays != nil {
+		if err := jobs.ValidateFreshnessWindowDays(*req.FreshnessWindowDays); err != nil {
+			return err
+		}
+	}
+
+	if err := req.validateRunLimits(); err != nil {
+		return err
+	}
+
+	if err := jobs.ValidateSeedURLs(req.SeedURLs, req.Domain); err != nil {
+		return err
+	}
+
+	if _, err := req.duplicatePolicy(); err != nil {
+		return err
+	}
+
+	if req.NotifyWebhookURL != nil {
+		if err := jobs.ValidateNotifyWebhookURL(strings.TrimSpace(*req.NotifyWebhookURL)); err != nil {
+			return err
+		}
+	}
+
+	if req.Credentials != nil && !req.Credentials.IsZero() {
+		if err := jobs.ValidateCredentials(*req.Credentials); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setSourceDefaults fills in source information the client didn't provide,
+// treating the job as created from the dashboard
+func (req *CreateJobRequest) setSourceDefaults(r *http.Request, sourceDetail string) {
+	if req.SourceType == nil {
+		sourceType := "dashboard"
+		req.SourceType = &sourceType
+	}
+	if req.SourceDetail == nil {
+		req.SourceDetail = &sourceDetail
+	}
+	if req.SourceInfo == nil {
+		sourceInfoData := map[string]any{
+			"ip":        util.GetClientIP(r),
+			"userAgent": r.UserAgent(),
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"endpoint":  r.URL.Path,
+			"method":    r.Method,
+		}
+		sourceInfoBytes, _ := json.Marshal(sourceInfoData)
+		sourceInfo := string(sourceInfoBytes)
+		req.SourceInfo = &sourceInfo
+	}
+}
+
 // createJobFromRequest creates a job from a CreateJobRequest with user context
 func (h *Handler) createJobFromRequest(ctx context.Context, user *db.User, req CreateJobRequest, logger zerolog.Logger) (*jobs.Job, error) {
 	// Set defaults
@@ -227,9 +662,11 @@ func (h *Handler) createJobFromRequest(ctx context.Context, user *db.User, req C
 		findLinks = *req.FindLinks
 	}
 
-	concurrency := 20 // Default concurrency
-	if req.Concurrency != nil && *req.Concurrency > 0 {
-		concurrency = min(*req.Concurrency, 100)
+	concurrency := req.effectiveConcurrency()
+
+	verifyConcurrency := 0 // Verification shares the warming limit by default
+	if req.VerifyConcurrency != nil && *req.VerifyConcurrency > 0 {
+		verifyConcurrency = min(*req.VerifyConcurrency, 100)
 	}
 
 	maxPages := 0
@@ -244,17 +681,93 @@ func (h *Handler) createJobFromRequest(ctx context.Context, user *db.User, req C
 		orgIDPtr = &effectiveOrgID
 	}
 
+	var priorityTier jobs.PriorityTier
+	if req.PriorityTier != nil {
+		priorityTier = jobs.PriorityTier(*req.PriorityTier)
+	}
+
+	var slowOriginPolicy jobs.SlowOriginPolicy
+	if req.SlowOriginPolicy != nil {
+		slowOriginPolicy = jobs.SlowOriginPolicy(*req.SlowOriginPolicy)
+	}
+
+	var urlScheme jobs.URLScheme
+	if req.URLScheme != nil {
+		urlScheme = jobs.URLScheme(*req.URLScheme)
+	}
+
+	var userAgent string
+	if req.UserAgent != nil {
+		userAgent = *req.UserAgent
+	}
+
+	var method jobs.WarmMethod
+	if req.Method != nil {
+		method = jobs.WarmMethod(*req.Method)
+	}
+
+	taskTimeoutSeconds := 0 // Job default
+	if req.TaskTimeoutSeconds != nil {
+		taskTimeoutSeconds = *req.TaskTimeoutSeconds
+	}
+
+	var notifyWebhookURL string
+	if req.NotifyWebhookURL != nil {
+		notifyWebhookURL = strings.TrimSpace(*req.NotifyWebhookURL)
+	}
+
+	minCrawlDelay, maxCrawlDelay := req.crawlDelayBounds()
+	maxRuntimeMinutes, maxRuntimeAction := req.maxRuntime()
+	onDuplicate, _ := req.duplicatePolicy() // Validated by createJob
+
 	opts := &jobs.JobOptions{
-		Domain:         req.Domain,
-		UserID:         &user.ID,
-		OrganisationID: orgIDPtr,
-		UseSitemap:     useSitemap,
-		Concurrency:    concurrency,
-		FindLinks:      findLinks,
-		MaxPages:       maxPages,
-		SourceType:     req.SourceType,
-		SourceDetail:   req.SourceDetail,
-		SourceInfo:     req.SourceInfo,
+		Domain:               req.Domain,
+		UserID:               &user.ID,
+		OrganisationID:       orgIDPtr,
+		UseSitemap:           useSitemap,
+		Concurrency:          concurrency,
+		VerifyConcurrency:    verifyConcurrency,
+		FindLinks:            findLinks,
+		MaxPages:             maxPages,
+		MaxDepth:             req.maxDepth(),
+		MaxRetries:           req.MaxRetries,
+		ConcurrencySchedule:  req.ConcurrencySchedule,
+		CacheableStatusCodes: req.CacheableStatusCodes,
+		ChangedOnly:          req.ChangedOnly != nil && *req.ChangedOnly,
+		SkipCachedURLs:       req.SkipCachedURLs != nil && *req.SkipCachedURLs,
+		ContentTypes:         req.ContentTypes,
+		URLScheme:            urlScheme,
+		Tags:                 req.Tags,
+		RetryBudget:          req.retryBudget(),
+		PriorityTier:         priorityTier,
+		SlowOriginPolicy:     slowOriginPolicy,
+		AutoConcurrency:      req.AutoConcurrency != nil && *req.AutoConcurrency,
+		UserAgent:            userAgent,
+		CustomHeaders:        req.CustomHeaders,
+		ConditionalWarm:      req.ConditionalWarm != nil && *req.ConditionalWarm,
+		DetectSoft404:        req.DetectSoft404 != nil && *req.DetectSoft404,
+		HashContent:          req.HashContent != nil && *req.HashContent,
+		FullBodyDetection:    req.FullBodyDetection != nil && *req.FullBodyDetection,
+		NotifyWebhookURL:     notifyWebhookURL,
+		PurgeBeforeWarm:      req.PurgeBeforeWarm != nil && *req.PurgeBeforeWarm,
+		FreshnessWindowDays:  req.FreshnessWindowDays,
+		TaskTimeoutSeconds:   taskTimeoutSeconds,
+		DryRun:               req.DryRun != nil && *req.DryRun,
+		Credentials:          req.Credentials,
+		Method:               method,
+		GroupSubdomains:      req.GroupSubdomains != nil && *req.GroupSubdomains,
+		SecondRequest:        req.SecondRequest == nil || *req.SecondRequest,
+		MinCrawlDelaySeconds: minCrawlDelay,
+		MaxCrawlDelaySeconds: maxCrawlDelay,
+		PrioritiseBySearch:   req.PrioritiseBySearch != nil && *req.PrioritiseBySearch,
+		MaxRuntimeMinutes:    maxRuntimeMinutes,
+		MaxRuntimeAction:     maxRuntimeAction,
+		OnDuplicate:          onDuplicate,
+		WarmURLs:             req.WarmURLs,
+		SeedURLs:             req.SeedURLs,
+		SourceType:           req.SourceType,
+		SourceDetail:         req.SourceDetail,
+		SourceInfo:           req.SourceInfo,
 	}
 
 	// Trigger GA4 data fetch in background if findLinks is enabled and organisation has GA4 connection
@@ -277,6 +790,16 @@ func (h *Handler) createJobFromRequest(ctx context.Context, user *db.User, req C
 			Msg("Skipping GA4 fetch - conditions not met")
 	}
 
+	// Boost pages by Search Console impressions; sites without a connection
+	// keep normal prioritisation
+	if opts.PrioritiseBySearch && effectiveOrgID != "" && h.GoogleClientID != "" && h.GoogleClientSecret != "" {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			h.fetchSearchConsoleDataBeforeJob(ctx, logger, effectiveOrgID, req.Domain)
+		}()
+	}
+
 	return h.JobsManager.CreateJob(ctx, opts)
 }
 
@@ -298,42 +821,16 @@ func (h *Handler) createJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Domain == "" {
-		BadRequest(w, r, "Domain is required")
+	if err := req.validate(); err != nil {
+		BadRequest(w, r, err.Error())
 		return
 	}
 
-	// Validate domain format
-	if err := util.ValidateDomain(req.Domain); err != nil {
-		BadRequest(w, r, fmt.Sprintf("Invalid domain: %s", err.Error()))
-		return
-	}
-
-	// Set source information if not provided (dashboard creation)
-	if req.SourceType == nil {
-		sourceType := "dashboard"
-		req.SourceType = &sourceType
-	}
-	if req.SourceDetail == nil {
-		sourceDetail := "create_job"
-		req.SourceDetail = &sourceDetail
-	}
-	if req.SourceInfo == nil {
-		sourceInfoData := map[string]any{
-			"ip":        util.GetClientIP(r),
-			"userAgent": r.UserAgent(),
-			"timestamp": time.Now().UTC().Format(time.RFC3339),
-			"endpoint":  r.URL.Path,
-			"method":    r.Method,
-		}
-		sourceInfoBytes, _ := json.Marshal(sourceInfoData)
-		sourceInfo := string(sourceInfoBytes)
-		req.SourceInfo = &sourceInfo
-	}
+	req.setSourceDefaults(r, "create_job")
 
 	job, err := h.createJobFromRequest(r.Context(), user, req, logger)
 	if err != nil {
-		if HandlePoolSaturation(w, r, err) {
+		if handleCreateJobError(w, r, err) {
 			return
 		}
 		logger.Error().Err(err).Msg("Failed to create job")
@@ -366,6 +863,18 @@ func (h *Handler) createJob(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:      job.CreatedAt.Format(time.RFC3339),
 	}
 
+	writeCreatedJob(w, r, job, response)
+}
+
+// writeCreatedJob answers 201 for a new job, or 200 when an active job for
+// the domain was reused under on_duplicate: reuse
+func writeCreatedJob(w http.ResponseWriter, r *http.Request, job *jobs.Job, response JobResponse) {
+	if job.Reused {
+		response.AlreadyRunning = true
+		response.Progress = job.Progress
+		WriteSuccess(w, r, response, "Job already running for this domain")
+		return
+	}
 	WriteCreated(w, r, response, "Job created successfully")
 }
 
@@ -408,9 +917,51 @@ func (h *Handler) fetchJobResponse(ctx context.Context, jobID string, organisati
 	var avgTimePerTaskSeconds sql.NullFloat64
 	var statsJSON []byte
 	var schedulerID sql.NullString
-	var concurrency, maxPages, adaptiveDelaySeconds int
+	var concurrency, verifyConcurrency, maxPages, maxDepth, maxRetries, adaptiveDelaySeconds int
 	var sourceType sql.NullString
 	var crawlDelaySeconds sql.NullInt64
+	var concurrencySchedule []byte
+	var cacheableStatusCodes []int64
+	var concurrencyBlocks, concurrencyBlockedMs int64
+	var changedOnly bool
+	var unchangedTasks int
+	var skipCachedURLs bool
+	var cachedTasks int
+	var contentTypes []string
+	var typeSkippedTasks int
+	var tags []byte
+	var urlScheme string
+	var mixedSchemeURLs int
+	var retryBudget, retriesUsed int
+	var priorityTier string
+	var slowOriginPolicy string
+	var autoConcurrency, autoLimitStable bool
+	var autoLimit sql.NullInt64
+	var userAgent string
+	var conditionalWarm bool
+	var notModifiedTasks int
+	var detectSoft404 bool
+	var soft404Tasks int
+	var hashContent bool
+	var contentChangedTasks, contentUnchangedTasks int
+	var fullBodyDetection bool
+	var sitemapsTotal int
+	var failedSitemaps []string
+	var notifyWebhookURL string
+	var notifyWebhookStatus sql.NullString
+	var purgeBeforeWarm bool
+	var warningMessage sql.NullString
+	var taskTimeoutSeconds int
+	var dryRun bool
+	var hasCredentials bool
+	var method string
+	var groupSubdomains bool
+	var secondRequest bool
+	var minCrawlDelaySeconds, maxCrawlDelaySeconds int
+	var prioritiseBySearch bool
+	var maxRuntimeMinutes int
+	var maxRuntimeAction string
+	var customHeaders []byte
 
 	query := `
 		SELECT j.total_tasks, j.completed_tasks, j.failed_tasks, j.skipped_tasks, j.status,
@@ -420,8 +971,55 @@ func (h *Handler) fetchJobResponse(ctx context.Context, jobID string, organisati
 		           EXTRACT(EPOCH FROM (j.completed_at - j.started_at)) / j.completed_tasks
 		       END as avg_time_per_task_seconds,
 		       j.stats, j.scheduler_id,
-		       j.concurrency, j.max_pages, j.source_type,
-		       d.crawl_delay_seconds, d.adaptive_delay_seconds
+		       j.concurrency - j.verify_concurrency, j.verify_concurrency,
+		       j.max_pages, j.max_depth, j.max_retries, j.source_type,
+		       d.crawl_delay_seconds, d.adaptive_delay_seconds, j.concurrency_schedule,
+		       j.cacheable_status_codes, j.concurrency_blocks,
+		       j.concurrency_blocked_ms + COALESCE(
+		           (EXTRACT(EPOCH FROM (NOW() - j.concurrency_blocked_since)) * 1000)::bigint, 0),
+		       j.changed_only,
+		       CASE WHEN j.changed_only THEN (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'skipped' AND t.skip_reason = 'unchanged'
+		       ) ELSE 0 END,
+		       j.skip_cached_urls,
+		       CASE WHEN j.skip_cached_urls THEN (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'skipped' AND t.skip_reason = 'cached'
+		       ) ELSE 0 END,
+		       j.content_types,
+		       (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'skipped' AND t.skip_reason = 'content_type'
+		       ),
+		       j.tags, j.url_scheme, j.mixed_scheme_urls, j.retry_budget, j.retries_used,
+		       j.priority_tier, j.slow_origin_policy, COALESCE(j.user_agent, ''),
+		       j.auto_concurrency, j.auto_concurrency_limit, j.auto_concurrency_stable,
+		       j.conditional_warm,
+		       CASE WHEN j.conditional_warm THEN (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'completed' AND t.not_modified
+		       ) ELSE 0 END,
+		       COALESCE(j.notify_webhook_url, ''), j.notify_webhook_status,
+		       j.purge_before_warm, j.warning_message, j.task_timeout_seconds,
+		       j.dry_run, j.credentials_secret_name IS NOT NULL, j.method, j.group_subdomains,
+		       j.second_request, j.min_crawl_delay_seconds, j.max_crawl_delay_seconds,
+		       j.prioritise_by_search, j.max_runtime_minutes, j.max_runtime_action,
+		       j.custom_headers, j.detect_soft_404,
+		       CASE WHEN j.detect_soft_404 THEN (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'completed' AND t.soft_404
+		       ) ELSE 0 END,
+		       j.sitemaps_total, j.failed_sitemaps, j.hash_content,
+		       CASE WHEN j.hash_content THEN (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'completed' AND t.content_changed
+		       ) ELSE 0 END,
+		       CASE WHEN j.hash_content THEN (
+		           SELECT COUNT(*) FROM tasks t
+		           WHERE t.job_id = j.id AND t.status = 'completed' AND NOT t.content_changed
+		       ) ELSE 0 END,
+		       j.full_body_detection
 		FROM jobs j
 		JOIN domains d ON j.domain_id = d.id
 		WHERE j.id = $1`
@@ -441,9 +1039,62 @@ func (h *Handler) fetchJobResponse(ctx context.Context, jobID string, organisati
 		// Computed metrics
 		&durationSeconds, &avgTimePerTaskSeconds, &statsJSON, &schedulerID,
 		// Job config
-		&concurrency, &maxPages, &sourceType,
+		&concurrency, &verifyConcurrency, &maxPages, &maxDepth, &maxRetries, &sourceType,
 		// Domain delays
-		&crawlDelaySeconds, &adaptiveDelaySeconds,
+		&crawlDelaySeconds, &adaptiveDelaySeconds, &concurrencySchedule,
+		pq.Array(&cacheableStatusCodes),
+		// Concurrency blocking (including any open blocked period)
+		&concurrencyBlocks, &concurrencyBlockedMs,
+		// Changed-only warming
+		&changedOnly, &unchangedTasks,
+		// Skip-cached warming
+		&skipCachedURLs, &cachedTasks,
+		// Content type filtering
+		pq.Array(&contentTypes), &typeSkippedTasks,
+		// Labels
+		&tags,
+		// URL scheme canonicalisation
+		&urlScheme, &mixedSchemeURLs,
+		// Retry budget
+		&retryBudget, &retriesUsed,
+		// Priority tier, slow origin policy and user agent override
+		&priorityTier, &slowOriginPolicy, &userAgent,
+		// Auto-tuned concurrency
+		&autoConcurrency, &autoLimit, &autoLimitStable,
+		// Conditional warming
+		&conditionalWarm, &notModifiedTasks,
+		// Completion webhook
+		&notifyWebhookURL, &notifyWebhookStatus,
+		// Pre-warm CDN purge
+		&purgeBeforeWarm, &warningMessage,
+		// Per-task processing timeout
+		&taskTimeoutSeconds,
+		// Dry-run discovery
+		&dryRun,
+		// Site credentials (stored in Vault)
+		&hasCredentials,
+		// Warm method
+		&method,
+		// Limiter keyed on the registrable domain
+		&groupSubdomains,
+		// Cache verification re-fetch
+		&secondRequest,
+		// Job bounds on the robots.txt Crawl-delay
+		&minCrawlDelaySeconds, &maxCrawlDelaySeconds,
+		// Search Console prioritisation
+		&prioritiseBySearch,
+		// Runtime ceiling
+		&maxRuntimeMinutes, &maxRuntimeAction,
+		// Extra warming request headers
+		&customHeaders,
+		// Soft-404 detection
+		&detectSoft404, &soft404Tasks,
+		// Sitemaps that failed to load
+		&sitemapsTotal, pq.Array(&failedSitemaps),
+		// Content hashing
+		&hashContent, &contentChangedTasks, &contentUnchangedTasks,
+		// Technology detection scope
+		&fullBodyDetection,
 	)
 	if err != nil {
 		return JobResponse{}, err
@@ -465,8 +1116,65 @@ func (h *Handler) fetchJobResponse(ctx context.Context, jobID string, organisati
 		SkippedTasks:         skipped,
 		Progress:             progress,
 		Concurrency:          concurrency,
+		VerifyConcurrency:    verifyConcurrency,
 		MaxPages:             maxPages,
+		MaxDepth:             maxDepth,
+		MaxRetries:           maxRetries,
 		AdaptiveDelaySeconds: adaptiveDelaySeconds,
+		ConcurrencySchedule:  concurrencySchedule,
+		CacheableStatusCodes: cacheableStatusCodes,
+		ConcurrencyBlocks:    concurrencyBlocks,
+		ConcurrencyBlockedMs: concurrencyBlockedMs,
+		ChangedOnly:          changedOnly,
+		UnchangedTasks:       unchangedTasks,
+		SkipCachedURLs:       skipCachedURLs,
+		CachedTasks:          cachedTasks,
+		ContentTypes:         contentTypes,
+		TypeSkippedTasks:     typeSkippedTasks,
+		URLScheme:            urlScheme,
+		MixedSchemeURLs:      mixedSchemeURLs,
+		RetryBudget:          retryBudget,
+		RetriesUsed:          retriesUsed,
+		PriorityTier:         priorityTier,
+		SlowOriginPolicy:     slowOriginPolicy,
+		UserAgent:            userAgent,
+		AutoConcurrency:      autoConcurrency,
+		AutoLimitStable:      autoLimitStable,
+		CustomHeaders:        customHeaders,
+		ConditionalWarm:      conditionalWarm,
+		NotModifiedTasks:     notModifiedTasks,
+		DetectSoft404:        detectSoft404,
+		Soft404Tasks:         soft404Tasks,
+		HashContent:          hashContent,
+		ContentChanged:       contentChangedTasks,
+		ContentUnchanged:     contentUnchangedTasks,
+		FullBodyDetection:    fullBodyDetection,
+		SitemapsTotal:        sitemapsTotal,
+		FailedSitemaps:       failedSitemaps,
+		SitemapSummary:       jobs.SitemapLoadSummary(sitemapsTotal, failedSitemaps),
+		NotifyWebhookURL:     notifyWebhookURL,
+		PurgeBeforeWarm:      purgeBeforeWarm,
+		TaskTimeoutSeconds:   taskTimeoutSeconds,
+		DryRun:               dryRun,
+		HasCredentials:       hasCredentials,
+		Method:               method,
+		GroupSubdomains:      groupSubdomains,
+		SecondRequest:        secondRequest,
+		MinCrawlDelaySeconds: minCrawlDelaySeconds,
+		MaxCrawlDelaySeconds: maxCrawlDelaySeconds,
+		PrioritiseBySearch:   prioritiseBySearch,
+		MaxRuntimeMinutes:    maxRuntimeMinutes,
+		MaxRuntimeAction:     maxRuntimeAction,
+	}
+	if notifyWebhookStatus.Valid {
+		response.NotifyWebhookStatus = &notifyWebhookStatus.String
+	}
+	if autoLimit.Valid {
+		limit := int(autoLimit.Int64)
+		response.AutoLimit = &limit
+	}
+	if warningMessage.Valid {
+		response.WarningMessage = &warningMessage.String
 	}
 	if sourceType.Valid {
 		response.SourceType = &sourceType.String
@@ -495,6 +1203,12 @@ func (h *Handler) fetchJobResponse(ctx context.Context, jobID string, organisati
 		}
 	}
 
+	if len(tags) > 0 {
+		if err := json.Unmarshal(tags, &response.Tags); err != nil {
+			return JobResponse{}, fmt.Errorf("failed to unmarshal job tags: %w", err)
+		}
+	}
+
 	if createdAt.Valid {
 		response.CreatedAt = createdAt.Time.Format(time.RFC3339)
 	} else {
@@ -509,6 +1223,13 @@ func (h *Handler) fetchJobResponse(ctx context.Context, jobID string, organisati
 		response.CompletedAt = &completed
 	}
 
+	if failed > 0 {
+		response.ErrorBreakdown, err = h.fetchErrorBreakdown(ctx, jobID)
+		if err != nil {
+			return JobResponse{}, err
+		}
+	}
+
 	return response, nil
 }
 
@@ -517,7 +1238,7 @@ type JobActionRequest struct {
 	Action string `json:"action"`
 }
 
-// updateJob handles PUT /v1/jobs/:id for job actions
+// updateJob handles PUT and PATCH /v1/jobs/:id for job actions
 func (h *Handler) updateJob(w http.ResponseWriter, r *http.Request, jobID string) {
 	logger := loggerWithRequest(r)
 
@@ -552,14 +1273,29 @@ func (h *Handler) updateJob(w http.ResponseWriter, r *http.Request, jobID string
 	_ = logger // logger available for future use
 
 	resultJobID := jobID
+	var message string
 	switch req.Action {
 	case "cancel":
 		err = h.JobsManager.CancelJob(r.Context(), jobID)
+		message = "Job cancelled successfully"
+	case "pause":
+		err = h.JobsManager.PauseJob(r.Context(), jobID)
+		message = "Job paused successfully"
+	case "resume":
+		err = h.JobsManager.ResumeJob(r.Context(), jobID)
+		message = "Job resumed successfully"
 	default:
-		BadRequest(w, r, "Invalid action. Supported actions: cancel")
+		BadRequest(w, r, "Invalid action. Supported actions: cancel, pause, resume")
 		return
 	}
 
+	if errors.Is(err, jobs.ErrJobNotRunning) || errors.Is(err, jobs.ErrJobNotPaused) {
+		BadRequest(w, r, err.Error())
+		return
+	}
+	if HandlePoolSaturation(w, r, err) {
+		return
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("job_id", jobID).Str("action", req.Action).Msg("Failed to perform job action")
 		InternalError(w, r, err)
@@ -573,7 +1309,7 @@ func (h *Handler) updateJob(w http.ResponseWriter, r *http.Request, jobID string
 		return
 	}
 
-	WriteSuccess(w, r, response, "Job cancelled successfully")
+	WriteSuccess(w, r, response, message)
 }
 
 // cancelJob handles DELETE /v1/jobs/:id
@@ -606,6 +1342,9 @@ func (h *Handler) cancelJob(w http.ResponseWriter, r *http.Request, jobID string
 
 	err = h.JobsManager.CancelJob(r.Context(), jobID)
 	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
 		logger.Error().Err(err).Str("job_id", jobID).Msg("Failed to cancel job")
 		InternalError(w, r, err)
 		return
@@ -738,8 +1477,9 @@ type TaskQueryBuilder struct {
 func buildTaskQuery(jobID string, params TaskQueryParams) TaskQueryBuilder {
 	baseQuery := `
 		SELECT t.id, t.job_id, p.path, d.name as domain, t.status, t.status_code, t.response_time,
-		       t.cache_status, t.second_response_time, t.second_cache_status, t.content_type, t.error, t.source_type, t.source_url,
+		       t.cache_status, t.origin_cache_status, t.second_response_time, t.second_cache_status, t.content_type, t.error, t.source_type, t.source_url, t.depth,
 		       t.created_at, t.started_at, t.completed_at, t.retry_count,
+		       t.concurrency_block_count, t.concurrency_wait_ms, t.skip_reason, t.not_modified, t.soft_404,
 		       pa.page_views_7d, pa.page_views_28d, pa.page_views_180d
 		FROM tasks t
 		JOIN pages p ON t.page_id = p.id
@@ -804,12 +1544,14 @@ func formatTasksFromRows(rows *sql.Rows) ([]TaskResponse, error) {
 		var startedAt, completedAt, createdAt sql.NullTime
 		var statusCode, responseTime, secondResponseTime sql.NullInt32
 		var pageViews7d, pageViews28d, pageViews180d sql.NullInt64
-		var cacheStatus, secondCacheStatus, contentType, errorMsg, sourceType, sourceURL sql.NullString
+		var cacheStatus, originCacheStatus, secondCacheStatus, contentType, errorMsg, sourceType, sourceURL sql.NullString
+		var skipReason sql.NullString
 
 		err := rows.Scan(
 			&task.ID, &task.JobID, &task.Path, &domain, &task.Status,
-			&statusCode, &responseTime, &cacheStatus, &secondResponseTime, &secondCacheStatus, &contentType, &errorMsg, &sourceType, &sourceURL,
+			&statusCode, &responseTime, &cacheStatus, &originCacheStatus, &secondResponseTime, &secondCacheStatus, &contentType, &errorMsg, &sourceType, &sourceURL, &task.Depth,
 			&createdAt, &startedAt, &completedAt, &task.RetryCount,
+			&task.ConcurrencyBlockCount, &task.ConcurrencyWaitMs, &skipReason, &task.NotModified, &task.Soft404,
 			&pageViews7d, &pageViews28d, &pageViews180d,
 		)
 		if err != nil {
@@ -831,6 +1573,9 @@ func formatTasksFromRows(rows *sql.Rows) ([]TaskResponse, error) {
 		if cacheStatus.Valid {
 			task.CacheStatus = &cacheStatus.String
 		}
+		if originCacheStatus.Valid && originCacheStatus.String != "" {
+			task.OriginCacheStatus = &originCacheStatus.String
+		}
 		if secondResponseTime.Valid {
 			srt := int(secondResponseTime.Int32)
 			task.SecondResponseTime = &srt
@@ -844,6 +1589,9 @@ func formatTasksFromRows(rows *sql.Rows) ([]TaskResponse, error) {
 		if errorMsg.Valid {
 			task.Error = &errorMsg.String
 		}
+		if skipReason.Valid {
+			task.SkipReason = &skipReason.String
+		}
 		if sourceType.Valid {
 			task.SourceType = &sourceType.String
 		}
@@ -892,19 +1640,27 @@ type TaskResponse struct {
 	StatusCode         *int    `json:"status_code,omitempty"`
 	ResponseTime       *int    `json:"response_time,omitempty"`
 	CacheStatus        *string `json:"cache_status,omitempty"`
+	OriginCacheStatus  *string `json:"origin_cache_status,omitempty"`
 	SecondResponseTime *int    `json:"second_response_time,omitempty"`
 	SecondCacheStatus  *string `json:"second_cache_status,omitempty"`
 	ContentType        *string `json:"content_type,omitempty"`
 	Error              *string `json:"error,omitempty"`
 	SourceType         *string `json:"source_type,omitempty"`
 	SourceURL          *string `json:"source_url,omitempty"`
+	Depth              int     `json:"depth"`
 	CreatedAt          string  `json:"created_at"`
 	StartedAt          *string `json:"started_at,omitempty"`
 	CompletedAt        *string `json:"completed_at,omitempty"`
 	RetryCount         int     `json:"retry_count"`
-	PageViews7d        *int    `json:"page_views_7d,omitempty"`
-	PageViews28d       *int    `json:"page_views_28d,omitempty"`
-	PageViews180d      *int    `json:"page_views_180d,omitempty"`
+	// Time the job was held at its own concurrency limit while this task waited
+	ConcurrencyBlockCount int     `json:"concurrency_block_count"`
+	ConcurrencyWaitMs     int64   `json:"concurrency_wait_ms"`
+	SkipReason            *string `json:"skip_reason,omitempty"`
+	NotModified           bool    `json:"not_modified,omitempty"` // Conditional warm answered 304
+	Soft404               bool    `json:"soft_404,omitempty"`     // 200 response that looks like a "not found" page
+	PageViews7d           *int    `json:"page_views_7d,omitempty"`
+	PageViews28d          *int    `json:"page_views_28d,omitempty"`
+	PageViews180d         *int    `json:"page_views_180d,omitempty"`
 }
 
 // ExportColumn describes a column in exported task datasets
@@ -931,6 +1687,22 @@ func taskExportColumns(exportType string, includeAnalytics bool) []ExportColumn
 			)
 		}
 		return columns
+	case "soft-404s":
+		columns := []ExportColumn{
+			{Key: "url", Label: "Page"},
+			{Key: "source_type", Label: "Source Type"},
+			{Key: "source_url", Label: "Found on"},
+			{Key: "content_type", Label: "Content Type"},
+			{Key: "created_at", Label: "Date"},
+		}
+		if includeAnalytics {
+			columns = append(columns,
+				ExportColumn{Key: "page_views_7d", Label: "Views (7d)"},
+				ExportColumn{Key: "page_views_28d", Label: "Views (28d)"},
+				ExportColumn{Key: "page_views_180d", Label: "Views (180d)"},
+			)
+		}
+		return columns
 	case "slow-pages":
 		columns := []ExportColumn{
 			{Key: "url", Label: "Page"},
@@ -957,11 +1729,13 @@ func taskExportColumns(exportType string, includeAnalytics bool) []ExportColumn
 			{Key: "content_type", Label: "Content Type"},
 			{Key: "status", Label: "Status"},
 			{Key: "cache_status", Label: "Cache Status"},
+			{Key: "origin_cache_status", Label: "Origin Cache Status"},
 			{Key: "status_code", Label: "Status Code"},
 			{Key: "response_time", Label: "Load Time (ms)"},
 			{Key: "second_cache_status", Label: "Second Cache Status"},
 			{Key: "second_response_time", Label: "Load Response Time (ms)"},
 			{Key: "retry_count", Label: "Retry Count"},
+			{Key: "concurrency_wait_ms", Label: "Concurrency Wait (ms)"},
 			{Key: "error", Label: "Error"},
 			{Key: "source_type", Label: "Source"},
 			{Key: "source_url", Label: "Source page"},
@@ -992,6 +1766,10 @@ func (h *Handler) getJobTasks(w http.ResponseWriter, r *http.Request, jobID stri
 
 	// Parse query parameters and build queries
 	params := parseTaskQueryParams(r)
+	if r.URL.Query().Get("sort") == taskSortClaim {
+		h.listTasksInClaimOrder(w, r, jobID, params.Limit)
+		return
+	}
 	queries := buildTaskQuery(jobID, params)
 
 	// Get total count
@@ -1046,8 +1824,13 @@ func (h *Handler) getJobTasks(w http.ResponseWriter, r *http.Request, jobID stri
 	WriteSuccess(w, r, response, "Tasks retrieved successfully")
 }
 
-// exportJobTasks handles GET /v1/jobs/:id/export
+// exportJobTasks handles GET /v1/jobs/:id/export; with ?format=csv|json it
+// streams a download of every task instead
 func (h *Handler) exportJobTasks(w http.ResponseWriter, r *http.Request, jobID string) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		h.exportJobDownload(w, r, jobID, format)
+		return
+	}
 	h.serveJobExport(w, r, jobID, true)
 }
 
@@ -1067,18 +1850,8 @@ func (h *Handler) serveJobExport(w http.ResponseWriter, r *http.Request, jobID s
 	}
 
 	// Build query based on export type
-	var whereClause string
-
-	switch exportType {
-	case "broken-links":
-		whereClause = " AND t.status = 'failed'"
-	case "slow-pages":
-		// Use second_response_time (cache HIT) when available, fallback to response_time
-		whereClause = " AND COALESCE(t.second_response_time, t.response_time) > 3000"
-	case "job":
-		// Export all tasks
-		whereClause = ""
-	default:
+	whereClause, ok := exportWhereClause(exportType)
+	if !ok {
 		BadRequest(w, r, fmt.Sprintf("Invalid export type: %s", exportType))
 		return
 	}
@@ -1087,10 +1860,11 @@ func (h *Handler) serveJobExport(w http.ResponseWriter, r *http.Request, jobID s
 	query := fmt.Sprintf(`
 		SELECT
 			t.id, t.job_id, p.path, d.name as domain,
-			t.status, t.status_code, t.response_time, t.cache_status,
+			t.status, t.status_code, t.response_time, t.cache_status, t.origin_cache_status,
 			t.second_response_time, t.second_cache_status,
 			t.content_type, t.error, t.source_type, t.source_url,
 			t.created_at, t.started_at, t.completed_at, t.retry_count,
+			t.concurrency_block_count, t.concurrency_wait_ms, t.skip_reason, t.not_modified, t.soft_404,
 			pa.page_views_7d, pa.page_views_28d, pa.page_views_180d
 		FROM tasks t
 		JOIN pages p ON t.page_id = p.id
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+)
+
+// MaxBatchJobs caps how many jobs one batch request can create
+const MaxBatchJobs = 50
+
+// BatchCreateJobsRequest creates several jobs in one call
+type BatchCreateJobsRequest struct {
+	Jobs []CreateJobRequest `json:"jobs"`
+}
+
+// BatchJobResult is the outcome of one job in a batch
+type BatchJobResult struct {
+	Index          int       `json:"index"` // Position in the request's jobs array
+	Domain         string    `json:"domain"`
+	Success        bool      `json:"success"`
+	JobID          string    `json:"job_id,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	AlreadyRunning bool      `json:"already_running,omitempty"` // An active job was reused (on_duplicate: reuse)
+	Error          string    `json:"error,omitempty"`
+	Code           ErrorCode `json:"code,omitempty"`
+}
+
+// BatchCreateJobsResponse reports each job's outcome in request order
+type BatchCreateJobsResponse struct {
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BatchJobResult `json:"results"`
+}
+
+// createJobsBatch handles POST /v1/jobs/batch. Each job is validated and
+// created on its own, so one bad domain doesn't fail the rest.
+func (h *Handler) createJobsBatch(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return // Error already written
+	}
+
+	var req BatchCreateJobsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+	if len(req.Jobs) == 0 {
+		BadRequest(w, r, "jobs must list at least one job")
+		return
+	}
+	if len(req.Jobs) > MaxBatchJobs {
+		BadRequest(w, r, fmt.Sprintf("jobs cannot list more than %d jobs", MaxBatchJobs))
+		return
+	}
+
+	// Each job counts as a request; authentication already took one
+	if !h.allowOrgRequests(w, r, orgID, len(req.Jobs)-1) {
+		return
+	}
+
+	response := BatchCreateJobsResponse{
+		Total:   len(req.Jobs),
+		Results: make([]BatchJobResult, 0, len(req.Jobs)),
+	}
+
+	// A repeated domain would cancel or reuse the job created moments earlier
+	seen := make(map[string]bool, len(req.Jobs))
+
+	for i, jobReq := range req.Jobs {
+		result := BatchJobResult{Index: i, Domain: jobReq.Domain}
+
+		if err := jobReq.validate(); err != nil {
+			result.Error, result.Code = err.Error(), ErrCodeBadRequest
+			response.add(result)
+			continue
+		}
+
+		domain := util.NormaliseDomain(jobReq.Domain)
+		if seen[domain] {
+			result.Error, result.Code = "domain appears earlier in the batch", ErrCodeBadRequest
+			response.add(result)
+			continue
+		}
+		seen[domain] = true
+
+		jobReq.setSourceDefaults(r, "create_job_batch")
+
+		job, err := h.createJobFromRequest(r.Context(), user, jobReq, logger)
+		if err != nil {
+			result.Error, result.Code = batchJobError(err)
+			if result.Code == ErrCodeInternal {
+				logger.Error().Err(err).Str("domain", jobReq.Domain).Int("index", i).Msg("Failed to create batch job")
+			}
+			response.add(result)
+			continue
+		}
+
+		result.Success = true
+		result.JobID = job.ID
+		result.Domain = job.Domain
+		result.Status = string(job.Status)
+		result.AlreadyRunning = job.Reused
+		response.add(result)
+	}
+
+	logger.Info().
+		Str("organisation_id", orgID).
+		Int("total", response.Total).
+		Int("succeeded", response.Succeeded).
+		Int("failed", response.Failed).
+		Msg("Batch job creation finished")
+
+	WriteSuccess(w, r, response, fmt.Sprintf("Created %d of %d jobs", response.Succeeded, response.Total))
+}
+
+// add records a job's outcome and updates the totals
+func (resp *BatchCreateJobsResponse) add(result BatchJobResult) {
+	if result.Success {
+		resp.Succeeded++
+	} else {
+		resp.Failed++
+	}
+	resp.Results = append(resp.Results, result)
+}
+
+// batchJobError maps a job creation error to the message and code reported
+// for that job, mirroring handleCreateJobError. Unexpected errors are not
+// echoed back.
+func batchJobError(err error) (string, ErrorCode) {
+	switch {
+	case errors.Is(err, jobs.ErrDomainNotVerified):
+		return err.Error(), ErrCodeForbidden
+	case errors.Is(err, jobs.ErrDuplicateJob):
+		return err.Error(), ErrCodeConflict
+	case errors.Is(err, jobs.ErrInvalidJobOptions):
+		return err.Error(), ErrCodeBadRequest
+	case errors.Is(err, db.ErrPoolSaturated):
+		return "Database is busy, please retry shortly", ErrCodeServiceBusy
+	default:
+		return "Failed to create job", ErrCodeInternal
+	}
+}
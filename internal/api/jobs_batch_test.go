@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchJobError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    ErrorCode
+		message string
+	}{
+		{"unverified domain", fmt.Errorf("create job: %w", jobs.ErrDomainNotVerified), ErrCodeForbidden, jobs.ErrDomainNotVerified.Error()},
+		{"duplicate job", jobs.ErrDuplicateJob, ErrCodeConflict, jobs.ErrDuplicateJob.Error()},
+		{"invalid options", fmt.Errorf("%w: warm list too long", jobs.ErrInvalidJobOptions), ErrCodeBadRequest, "warm list too long"},
+		{"pool saturated", db.ErrPoolSaturated, ErrCodeServiceBusy, "Database is busy, please retry shortly"},
+		{"unexpected", errors.New("pq: connection refused to 10.0.0.1"), ErrCodeInternal, "Failed to create job"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, code := batchJobError(tt.err)
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, message, tt.message)
+		})
+	}
+}
+
+func TestBatchCreateJobsResponseAdd(t *testing.T) {
+	var resp BatchCreateJobsResponse
+	resp.add(BatchJobResult{Index: 0, Success: true, JobID: "job-1"})
+	resp.add(BatchJobResult{Index: 1, Error: "domain is required", Code: ErrCodeBadRequest})
+	resp.add(BatchJobResult{Index: 2, Success: true, JobID: "job-2"})
+
+	assert.Equal(t, 2, resp.Succeeded)
+	assert.Equal(t, 1, resp.Failed)
+	assert.Len(t, resp.Results, 3)
+}
+
+func TestCreateJobRequestValidate(t *testing.T) {
+	negative := -1
+	badPolicy := "replace"
+
+	assert.NoError(t, CreateJobRequest{Domain: "example.com"}.validate())
+	assert.EqualError(t, CreateJobRequest{}.validate(), "domain is required")
+	assert.Error(t, CreateJobRequest{Domain: "not a domain"}.validate())
+	assert.Error(t, CreateJobRequest{Domain: "example.com", TaskTimeoutSeconds: &negative}.validate())
+	assert.Error(t, CreateJobRequest{Domain: "example.com", OnDuplicate: &badPolicy}.validate())
+}
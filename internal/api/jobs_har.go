@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+)
+
+// maxHARBytes bounds HAR uploads; exports with response bodies get large
+const maxHARBytes = 50 << 20
+
+// HARJobResponse is a created job plus a summary of the imported warm list
+type HARJobResponse struct {
+	JobResponse
+	WarmURLs       int `json:"warm_urls"`
+	SkippedEntries int `json:"skipped_entries"`
+}
+
+// createJobFromHAR handles POST /v1/jobs/from-har
+// The body is a HAR file; same-domain GET requests become the job's warm list.
+// Optional query params: domain, concurrency, priority_tier, slow_origin_policy.
+func (h *Handler) createJobFromHAR(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	user, _, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return // Error already written
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxHARBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			BadRequest(w, r, fmt.Sprintf("HAR file exceeds %d MB", maxHARBytes>>20))
+			return
+		}
+		BadRequest(w, r, "Failed to read HAR file")
+		return
+	}
+
+	query := r.URL.Query()
+	warmList, err := jobs.ParseHARWarmList(data, query.Get("domain"))
+	if err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := util.ValidateDomain(warmList.Domain); err != nil {
+		BadRequest(w, r, fmt.Sprintf("Invalid domain: %s", err.Error()))
+		return
+	}
+
+	useSitemap := false
+	findLinks := false
+	sourceType := "har"
+	sourceDetail := "from_har"
+	sourceInfoBytes, _ := json.Marshal(map[string]any{
+		"ip":              util.GetClientIP(r),
+		"userAgent":       r.UserAgent(),
+		"timestamp":       time.Now().UTC().Format(time.RFC3339),
+		"endpoint":        r.URL.Path,
+		"method":          r.Method,
+		"warm_urls":       len(warmList.Paths),
+		"skipped_entries": warmList.Skipped,
+	})
+	sourceInfo := string(sourceInfoBytes)
+
+	req := CreateJobRequest{
+		Domain:       warmList.Domain,
+		UseSitemap:   &useSitemap,
+		FindLinks:    &findLinks,
+		SourceType:   &sourceType,
+		SourceDetail: &sourceDetail,
+		SourceInfo:   &sourceInfo,
+		WarmURLs:     warmList.Paths,
+	}
+
+	if raw := query.Get("concurrency"); raw != "" {
+		concurrency, err := strconv.Atoi(raw)
+		if err != nil || concurrency < 1 {
+			BadRequest(w, r, "concurrency must be a positive integer")
+			return
+		}
+		req.Concurrency = &concurrency
+	}
+
+	if raw := query.Get("priority_tier"); raw != "" {
+		if _, err := jobs.ParsePriorityTier(raw); err != nil {
+			BadRequest(w, r, err.Error())
+			return
+		}
+		req.PriorityTier = &raw
+	}
+
+	if raw := query.Get("slow_origin_policy"); raw != "" {
+		if _, err := jobs.ParseSlowOriginPolicy(raw); err != nil {
+			BadRequest(w, r, err.Error())
+			return
+		}
+		req.SlowOriginPolicy = &raw
+	}
+
+	job, err := h.createJobFromRequest(r.Context(), user, req, logger)
+	if err != nil {
+		if handleCreateJobError(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Msg("Failed to create job from HAR")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Str("job_id", job.ID).
+		Str("domain", job.Domain).
+		Int("warm_urls", len(warmList.Paths)).
+		Int("skipped_entries", warmList.Skipped).
+		Msg("Created job from HAR warm list")
+
+	domainID, err := h.DB.GetOrCreateDomainID(r.Context(), job.Domain)
+	if err != nil {
+		logger.Error().Err(err).Str("job_id", job.ID).Msg("Failed to get domain ID")
+		// Continue without domain_id rather than failing the whole request
+		domainID = 0
+	}
+
+	response := HARJobResponse{
+		JobResponse: JobResponse{
+			ID:               job.ID,
+			DomainID:         domainID,
+			Domain:           job.Domain,
+			Status:           string(job.Status),
+			Progress:         0.0,
+			CreatedAt:        job.CreatedAt.Format(time.RFC3339),
+			PriorityTier:     string(job.PriorityTier),
+			SlowOriginPolicy: string(job.SlowOriginPolicy),
+		},
+		WarmURLs:       len(warmList.Paths),
+		SkippedEntries: warmList.Skipped,
+	}
+
+	WriteCreated(w, r, response, "Job created from HAR successfully")
+}
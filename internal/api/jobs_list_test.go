@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJobListOptions(t *testing.T) {
+	opts, err := parseJobListOptions(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, 10, opts.Limit)
+	assert.Equal(t, db.JobSortCreatedAt, opts.SortBy)
+	assert.False(t, opts.Ascending)
+
+	opts, err = parseJobListOptions(url.Values{
+		"limit":  {"50"},
+		"offset": {"100"},
+		"status": {"completed"},
+		"domain": {"https://www.example.com/"},
+		"sort":   {"completed_at"},
+		"order":  {"ASC"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 50, opts.Limit)
+	assert.Equal(t, 100, opts.Offset)
+	assert.Equal(t, "completed", opts.Status)
+	assert.Equal(t, "example.com", opts.Domain)
+	assert.Equal(t, db.JobSortCompletedAt, opts.SortBy)
+	assert.True(t, opts.Ascending)
+
+	opts, err = parseJobListOptions(url.Values{"limit": {"500"}, "offset": {"20"}, "cursor": {"abc"}})
+	require.NoError(t, err)
+	assert.Equal(t, 10, opts.Limit, "out of range limits fall back to the default")
+	assert.Equal(t, 0, opts.Offset, "a cursor replaces the offset")
+	assert.Equal(t, "abc", opts.Cursor)
+
+	opts, err = parseJobListOptions(url.Values{"tag": {" Pre-Deploy "}})
+	require.NoError(t, err)
+	assert.Equal(t, "pre-deploy", opts.Tag)
+
+	_, err = parseJobListOptions(url.Values{"tag": {"not a tag"}})
+	assert.Error(t, err)
+
+	_, err = parseJobListOptions(url.Values{"sort": {"domain"}})
+	assert.Error(t, err)
+
+	_, err = parseJobListOptions(url.Values{"order": {"sideways"}})
+	assert.Error(t, err)
+}
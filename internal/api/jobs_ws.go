@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lib/pq"
+)
+
+const (
+	// jobsSocketWriteTimeout drops clients that stop reading rather than
+	// letting their messages queue up
+	jobsSocketWriteTimeout = 10 * time.Second
+
+	// jobsSocketReadLimit bounds client messages; the stream is server to client
+	jobsSocketReadLimit = 512
+
+	// jobsSocketProtocol is the subprotocol browsers offer alongside their
+	// token, since the WebSocket API can't set an Authorization header
+	jobsSocketProtocol = "bearer"
+)
+
+var jobsUpgrader = websocket.Upgrader{
+	Subprotocols: []string{jobsSocketProtocol},
+	// Requests authenticate with a bearer token rather than cookies, so a
+	// cross-origin page can't ride an existing session
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// JobsStreamMessage is sent on /v1/ws. The first message is a snapshot of
+// the organisation's active jobs; later ones carry only jobs that changed.
+type JobsStreamMessage struct {
+	Type string             `json:"type"` // snapshot or progress
+	Jobs []JobProgressEvent `json:"jobs"`
+}
+
+// WebSocketTokenMiddleware lets browsers authenticate a WebSocket by offering
+// the "bearer" subprotocol followed by their token, i.e.
+// new WebSocket(url, ["bearer", token]). An Authorization header still wins.
+func WebSocketTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			protocols := websocket.Subprotocols(r)
+			if len(protocols) >= 2 && protocols[0] == jobsSocketProtocol && protocols[1] != "" {
+				r = r.Clone(r.Context())
+				r.Header.Set("Authorization", "Bearer "+protocols[1])
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// JobsWebSocket handles GET /v1/ws, streaming progress for every active job in
+// the caller's organisation over one connection. Notifications for other
+// organisations' jobs are filtered out when the changed jobs are read, and
+// bursts of updates are coalesced to at most one message per second.
+func (h *Handler) JobsWebSocket(w http.ResponseWriter, r *http.Request) {
+	logger := loggerWithRequest(r)
+
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	_, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return // Error already written
+	}
+
+	// Watch before the snapshot so nothing between the two is missed. Without
+	// a hub, updates and closing stay nil and the heartbeat polls instead.
+	var watcher *JobWatcher
+	var updates <-chan struct{}
+	var closing <-chan struct{}
+	if h.JobEvents != nil {
+		var unwatch func()
+		watcher, unwatch = h.JobEvents.Watch()
+		defer unwatch()
+		updates = watcher.Updates()
+		closing = h.JobEvents.closing
+	}
+
+	snapshot, err := h.loadOrgJobProgress(r.Context(), orgID, true, nil)
+	if err != nil {
+		if HandlePoolSaturation(w, r, err) {
+			return
+		}
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to load active job progress")
+		DatabaseError(w, r, err)
+		return
+	}
+
+	conn, err := jobsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade already wrote the error response
+	}
+	defer conn.Close()
+
+	// A hijacked connection no longer cancels the request context, so the
+	// read loop does it once the client goes away
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	conn.SetReadLimit(jobsSocketReadLimit)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * jobEventsHeartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * jobEventsHeartbeat))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	sent := make(map[string]JobProgressEvent, len(snapshot))
+	for _, event := range snapshot {
+		sent[event.JobID] = event
+	}
+	if err := writeJobsMessage(conn, JobsStreamMessage{Type: "snapshot", Jobs: snapshot}); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(jobEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var jobIDs []string
+		resync := false
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-closing:
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(time.Second))
+			return
+		case <-updates:
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(jobsSocketWriteTimeout)); err != nil {
+				return
+			}
+			// Doubles as a poll in case notifications were missed
+			resync = true
+		}
+
+		if watcher != nil {
+			var missed bool
+			jobIDs, missed = watcher.Take()
+			resync = resync || missed
+		}
+		if resync {
+			// Jobs that finished unnoticed have left the active set, so
+			// re-read everything still being followed by id
+			for jobID, event := range sent {
+				if !isTerminalJobStatus(event.Status) {
+					jobIDs = append(jobIDs, jobID)
+				}
+			}
+		}
+		if !resync && len(jobIDs) == 0 {
+			continue
+		}
+
+		events, err := h.loadOrgJobProgress(ctx, orgID, resync, jobIDs)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn().Err(err).Str("organisation_id", orgID).Msg("Failed to refresh job progress")
+			}
+			return
+		}
+
+		if changed := progressDeltas(sent, events); len(changed) > 0 {
+			if err := writeJobsMessage(conn, JobsStreamMessage{Type: "progress", Jobs: changed}); err != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-closing:
+			return
+		case <-time.After(jobEventsMinInterval):
+		}
+	}
+}
+
+// progressDeltas returns the events that differ from what was last sent,
+// recording them as sent
+func progressDeltas(sent map[string]JobProgressEvent, events []JobProgressEvent) []JobProgressEvent {
+	changed := make([]JobProgressEvent, 0, len(events))
+	for _, event := range events {
+		if previous, ok := sent[event.JobID]; ok && previous == event {
+			continue
+		}
+		sent[event.JobID] = event
+		changed = append(changed, event)
+	}
+	return changed
+}
+
+func writeJobsMessage(conn *websocket.Conn, message JobsStreamMessage) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(jobsSocketWriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(message)
+}
+
+// loadOrgJobProgress reads progress for the organisation's jobs among jobIDs,
+// plus all of its active jobs when includeActive is set. Ids belonging to
+// other organisations are silently dropped.
+func (h *Handler) loadOrgJobProgress(ctx context.Context, orgID string, includeActive bool, jobIDs []string) ([]JobProgressEvent, error) {
+	rows, err := h.DB.GetDB().QueryContext(ctx, `
+		SELECT id, status, total_tasks, completed_tasks, failed_tasks, skipped_tasks, progress
+		FROM jobs
+		WHERE organisation_id = $1
+		  AND (($2 AND status NOT IN ('completed', 'failed', 'cancelled')) OR id = ANY($3))
+		ORDER BY created_at DESC
+	`, orgID, includeActive, pq.Array(jobIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []JobProgressEvent{}
+	for rows.Next() {
+		var event JobProgressEvent
+		if err := rows.Scan(&event.JobID, &event.Status, &event.TotalTasks, &event.CompletedTasks,
+			&event.FailedTasks, &event.SkippedTasks, &event.Progress); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressDeltas(t *testing.T) {
+	sent := map[string]JobProgressEvent{
+		"job-1": {JobID: "job-1", Status: "running", TotalTasks: 10, CompletedTasks: 4},
+		"job-2": {JobID: "job-2", Status: "running", TotalTasks: 5, CompletedTasks: 1},
+	}
+
+	changed := progressDeltas(sent, []JobProgressEvent{
+		{JobID: "job-1", Status: "running", TotalTasks: 10, CompletedTasks: 4},
+		{JobID: "job-2", Status: "completed", TotalTasks: 5, CompletedTasks: 5},
+		{JobID: "job-3", Status: "pending"},
+	})
+
+	assert.Equal(t, []JobProgressEvent{
+		{JobID: "job-2", Status: "completed", TotalTasks: 5, CompletedTasks: 5},
+		{JobID: "job-3", Status: "pending"},
+	}, changed, "unchanged jobs shouldn't be resent")
+	assert.Equal(t, "completed", sent["job-2"].Status)
+	assert.Contains(t, sent, "job-3")
+}
+
+func TestWebSocketTokenMiddleware(t *testing.T) {
+	var authorization string
+	handler := WebSocketTokenMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+
+	tests := []struct {
+		name      string
+		header    string
+		protocols string
+		expected  string
+	}{
+		{name: "token from subprotocol", protocols: "bearer, abc.def.ghi", expected: "Bearer abc.def.ghi"},
+		{name: "header wins", header: "Bearer header-token", protocols: "bearer, abc", expected: "Bearer header-token"},
+		{name: "other subprotocol", protocols: "graphql-ws", expected: ""},
+		{name: "bearer without token", protocols: "bearer", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/ws", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			req.Header.Set("Sec-WebSocket-Protocol", tt.protocols)
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.expected, authorization)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"bufio"
 	"context"
 	"crypto/rand"
 	"fmt"
+	"net"
 	"net/http"
 	"runtime/debug"
 	"strings"
@@ -34,7 +36,7 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 		r = r.WithContext(ctx)
 
 		// Log the incoming request (skip health checks to reduce noise)
-		if r.URL.Path != "/health" {
+		if !isProbePath(r.URL.Path) {
 			log.Info().
 				Str("request_id", requestID).
 				Str("method", r.Method).
@@ -91,7 +93,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		duration := time.Since(start)
 
 		// Log the completed request (skip health checks to reduce noise)
-		if r.URL.Path != "/health" {
+		if !isProbePath(r.URL.Path) {
 			log.Info().
 				Str("request_id", requestID).
 				Str("method", r.Method).
@@ -135,7 +137,7 @@ func (rw *responseWrapper) WriteHeader(code int) {
 
 	// Log the first WriteHeader call for diagnostic purposes
 	// Only log for non-health-check paths to reduce noise
-	if rw.requestPath != "/health" {
+	if !isProbePath(rw.requestPath) {
 		log.Debug().
 			Str("request_id", rw.requestID).
 			Str("method", rw.requestMethod).
@@ -157,6 +159,17 @@ func (rw *responseWrapper) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
 }
 
+// Unwrap exposes the underlying writer so http.ResponseController can flush
+// streaming responses
+func (rw *responseWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Hijack lets WebSocket upgrades take over the underlying connection
+func (rw *responseWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(rw.ResponseWriter).Hijack()
+}
+
 // CORSMiddleware adds CORS headers for browser requests
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -210,3 +223,9 @@ func SecurityHeadersMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// isProbePath reports whether a path is polled by the orchestrator's liveness
+// or readiness checks, which are left out of request logs
+func isProbePath(path string) bool {
+	return path == "/health" || path == "/health/ready"
+}
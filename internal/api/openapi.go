@@ -0,0 +1,29 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the hand-maintained OpenAPI 3 description of the job,
+// domain and integration endpoints. Keep it in step with the request and
+// response types; openapi_test.go checks their JSON fields against it.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPISpec handles GET /v1/openapi.json
+func (h *Handler) OpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	logger := loggerWithRequest(r)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if _, err := w.Write(openAPISpec); err != nil {
+		logger.Error().Err(err).Msg("Failed to write OpenAPI spec")
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIDocument struct {
+	OpenAPI    string         `json:"openapi"`
+	Paths      map[string]any `json:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPISchema struct {
+	Ref        string                   `json:"$ref"`
+	AllOf      []openAPISchema          `json:"allOf"`
+	Enum       []string                 `json:"enum"`
+	Properties map[string]openAPISchema `json:"properties"`
+}
+
+func loadOpenAPIDocument(t *testing.T) openAPIDocument {
+	t.Helper()
+	var doc openAPIDocument
+	require.NoError(t, json.Unmarshal(openAPISpec, &doc))
+	return doc
+}
+
+// schemaProperties returns a component schema's property names, following
+// $ref and allOf
+func (doc openAPIDocument) schemaProperties(t *testing.T, schema openAPISchema) []string {
+	t.Helper()
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[name]
+		require.True(t, ok, "unresolved $ref %s", schema.Ref)
+		return doc.schemaProperties(t, resolved)
+	}
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	for _, part := range schema.AllOf {
+		names = append(names, doc.schemaProperties(t, part)...)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// jsonFieldNames returns the JSON keys a struct encodes, including those of
+// embedded structs
+func jsonFieldNames(typ reflect.Type) []string {
+	var names []string
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			names = append(names, jsonFieldNames(field.Type)...)
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		names = append(names, tag)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func TestOpenAPISpecServed(t *testing.T) {
+	mux := http.NewServeMux()
+	(&Handler{}).SetupRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc openAPIDocument
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.True(t, strings.HasPrefix(doc.OpenAPI, "3."))
+	assert.Contains(t, doc.Paths, "/v1/jobs")
+	assert.Contains(t, doc.Paths, "/v1/jobs/batch")
+	assert.Contains(t, doc.Paths, "/v1/domains/{domain}/verify")
+	assert.Contains(t, doc.Paths, "/v1/integrations/cdn-purge")
+	assert.Contains(t, doc.Paths, "/v1/ws")
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/openapi.json", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestOpenAPISpecErrorCodes(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+
+	codes := []ErrorCode{
+		ErrCodeBadRequest, ErrCodeUnauthorised, ErrCodeForbidden, ErrCodeNotFound,
+		ErrCodeMethodNotAllowed, ErrCodeConflict, ErrCodeValidation, ErrCodeRateLimit,
+		ErrCodeInternal, ErrCodeServiceUnavailable, ErrCodeServiceBusy, ErrCodeDatabaseError,
+	}
+	var want []string
+	for _, code := range codes {
+		want = append(want, string(code))
+	}
+	assert.ElementsMatch(t, want, doc.Components.Schemas["ErrorCode"].Enum)
+}
+
+func TestOpenAPISpecMatchesTypes(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+
+	types := map[string]any{
+		"SuccessResponse":                 SuccessResponse{},
+		"ErrorResponse":                   ErrorResponse{},
+		"CreateJobRequest":                CreateJobRequest{},
+		"JobActionRequest":                JobActionRequest{},
+		"JobResponse":                     JobResponse{},
+		"ErrorBreakdown":                  jobs.ErrorBreakdown{},
+		"HARJobResponse":                  HARJobResponse{},
+		"BatchCreateJobsRequest":          BatchCreateJobsRequest{},
+		"BatchJobResult":                  BatchJobResult{},
+		"BatchCreateJobsResponse":         BatchCreateJobsResponse{},
+		"RewarmJobRequest":                RewarmJobRequest{},
+		"RewarmJobResponse":               RewarmJobResponse{},
+		"PrioritiseTasksRequest":          PrioritiseTasksRequest{},
+		"PrioritiseTasksResponse":         PrioritiseTasksResponse{},
+		"JobListItem":                     db.JobWithDomain{},
+		"TaskResponse":                    TaskResponse{},
+		"ClaimOrderTask":                  ClaimOrderTask{},
+		"JobFailuresResponse":             JobFailuresResponse{},
+		"JobIssuesResponse":               JobIssuesResponse{},
+		"JobURLsResponse":                 JobURLsResponse{},
+		"TimingPercentiles":               TimingPercentiles{},
+		"JobTimingResponse":               JobTimingResponse{},
+		"JobRobots":                       jobs.JobRobots{},
+		"JobProgressEvent":                JobProgressEvent{},
+		"JobsStreamMessage":               JobsStreamMessage{},
+		"ConcurrencySchedule":             jobs.ConcurrencySchedule{},
+		"Credentials":                     crawler.Credentials{},
+		"CreateDomainRequest":             CreateDomainRequest{},
+		"DomainResponse":                  DomainResponse{},
+		"RobotsPreviewRequest":            RobotsPreviewRequest{},
+		"RobotsPreview":                   jobs.RobotsPreview{},
+		"DomainStats":                     db.DomainStats{},
+		"DomainTechnologies":              db.DomainTechnologies{},
+		"CancelJobsResponse":              CancelJobsResponse{},
+		"DomainVerification":              jobs.DomainVerification{},
+		"SlackConnectionResponse":         SlackConnectionResponse{},
+		"WebflowConnectionResponse":       WebflowConnectionResponse{},
+		"GoogleConnectionResponse":        GoogleConnectionResponse{},
+		"SearchConsoleConnectionResponse": SearchConsoleConnectionResponse{},
+		"CDNPurgeConnectionRequest":       CDNPurgeConnectionRequest{},
+		"CDNPurgeConnectionResponse":      CDNPurgeConnectionResponse{},
+		"WebhookSecretResponse":           WebhookSecretResponse{},
+	}
+
+	for name, value := range types {
+		t.Run(name, func(t *testing.T) {
+			schema, ok := doc.Components.Schemas[name]
+			require.True(t, ok, "schema %s missing from openapi.json", name)
+			assert.Equal(t, jsonFieldNames(reflect.TypeOf(value)), doc.schemaProperties(t, schema))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRateLimiterAllow(t *testing.T) {
+	limiter := NewOrgRateLimiter(1, 3)
+
+	for i := range 3 {
+		allowed, _ := limiter.allow("org-1")
+		assert.True(t, allowed, "request %d should be within the burst", i+1)
+	}
+
+	allowed, retryAfter := limiter.allow("org-1")
+	assert.False(t, allowed)
+	assert.Positive(t, retryAfter)
+
+	// Each organisation has its own bucket
+	allowed, _ = limiter.allow("org-2")
+	assert.True(t, allowed)
+}
+
+func TestNewOrgRateLimiterDefaults(t *testing.T) {
+	limiter := NewOrgRateLimiter(0, -1)
+	assert.InDelta(t, DefaultOrgRateLimit, float64(limiter.rate), 0)
+	assert.Equal(t, DefaultOrgRateBurst, limiter.burst)
+}
+
+func TestAllowOrgRequest(t *testing.T) {
+	h := &Handler{}
+	assert.True(t, h.allowOrgRequest(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/jobs", nil), "org-1"))
+
+	h.OrgRateLimiter = NewOrgRateLimiter(1, 1)
+	assert.True(t, h.allowOrgRequest(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/jobs", nil), "org-1"))
+
+	rr := httptest.NewRecorder()
+	assert.False(t, h.allowOrgRequest(rr, httptest.NewRequest(http.MethodGet, "/v1/jobs", nil), "org-1"))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, string(ErrCodeRateLimit), body.Code)
+}
+
+func TestOrgRateLimiterAllowN(t *testing.T) {
+	limiter := NewOrgRateLimiter(1, 5)
+
+	allowed, _ := limiter.allowN("org-1", 4)
+	assert.True(t, allowed)
+
+	allowed, retryAfter := limiter.allowN("org-1", 2)
+	assert.False(t, allowed)
+	assert.Positive(t, retryAfter)
+
+	// Larger requests than the burst draw a full bucket rather than never fitting
+	allowed, _ = limiter.allowN("org-2", 50)
+	assert.True(t, allowed)
+}
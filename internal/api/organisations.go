@@ -13,6 +13,7 @@ import (
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/auth"
 	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
 	"github.com/Harvey-AU/blue-banded-bee/internal/loops"
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 	"github.com/google/uuid"
@@ -739,6 +740,125 @@ func (h *Handler) OrganisationPlanHandler(w http.ResponseWriter, r *http.Request
 	}, "Organisation plan updated successfully")
 }
 
+// OrganisationDenyHostsHandler handles /v1/organisations/deny-hosts.
+// GET returns the crawl deny list; PUT replaces it and requires an admin.
+func (h *Handler) OrganisationDenyHostsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	logger := loggerWithRequest(r)
+
+	user, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		hosts, err := h.DB.GetOrganisationCrawlDenyHosts(r.Context(), orgID)
+		if err != nil {
+			logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to get crawl deny list")
+			InternalError(w, r, err)
+			return
+		}
+		if hosts == nil {
+			hosts = []string{}
+		}
+		WriteSuccess(w, r, map[string]any{"hosts": hosts}, "")
+		return
+	}
+
+	if !h.requireOrganisationAdmin(w, r, orgID, user.ID) {
+		return
+	}
+
+	var req struct {
+		Hosts []string `json:"hosts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+
+	hosts, err := jobs.NormaliseDenyHostPatterns(req.Hosts)
+	if err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.DB.SetOrganisationCrawlDenyHosts(r.Context(), orgID, hosts); err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to update crawl deny list")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Str("organisation_id", orgID).
+		Int("host_count", len(hosts)).
+		Msg("Crawl deny list updated")
+	WriteSuccess(w, r, map[string]any{"hosts": hosts}, "Crawl deny list updated")
+}
+
+// OrganisationSlackWebhookHandler handles /v1/organisations/slack-webhook.
+// GET reports whether job failure alerts are configured; PUT sets or, with
+// an empty url, clears the incoming webhook and requires an admin. The URL
+// itself is never returned since anyone holding it can post to the channel.
+func (h *Handler) OrganisationSlackWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	logger := loggerWithRequest(r)
+
+	user, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		url, err := h.DB.GetOrganisationSlackWebhookURL(r.Context(), orgID)
+		if err != nil {
+			logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to get Slack webhook")
+			InternalError(w, r, err)
+			return
+		}
+		WriteSuccess(w, r, map[string]any{"configured": url != ""}, "")
+		return
+	}
+
+	if !h.requireOrganisationAdmin(w, r, orgID, user.ID) {
+		return
+	}
+
+	var req struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, r, "Invalid JSON request body")
+		return
+	}
+
+	url := strings.TrimSpace(req.URL)
+	if err := jobs.ValidateSlackWebhookURL(url); err != nil {
+		BadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.DB.SetOrganisationSlackWebhookURL(r.Context(), orgID, url); err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to update Slack webhook")
+		InternalError(w, r, err)
+		return
+	}
+
+	logger.Info().
+		Str("organisation_id", orgID).
+		Bool("configured", url != "").
+		Msg("Slack job failure webhook updated")
+	WriteSuccess(w, r, map[string]any{"configured": url != ""}, "Slack webhook updated")
+}
+
 // UsageHistoryHandler handles GET /v1/usage/history
 func (h *Handler) UsageHistoryHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
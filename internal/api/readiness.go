@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+)
+
+// readinessPingTimeout keeps a hung database from stalling the probe past the
+// orchestrator's own check timeout
+const readinessPingTimeout = 3 * time.Second
+
+// ReadinessCheck is a single dependency in a readiness response
+type ReadinessCheck struct {
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+// ReadinessResponse reports whether the instance can take traffic, naming the
+// dependencies that aren't ready
+type ReadinessResponse struct {
+	Status    string                    `json:"status"` // ready or not_ready
+	Failed    []string                  `json:"failed,omitempty"`
+	Checks    map[string]ReadinessCheck `json:"checks"`
+	Timestamp string                    `json:"timestamp"`
+	Version   string                    `json:"version"`
+}
+
+// ReadinessHandler handles GET /health/ready. It returns 200 only once the
+// database is reachable, the worker pool has started and, when configured, the
+// LISTEN/NOTIFY listener is connected. /health stays a cheap liveness probe.
+func (h *Handler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	var dbErr error
+	if h.DB == nil {
+		dbErr = errors.New("database connection not configured")
+	} else {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessPingTimeout)
+		dbErr = h.DB.GetDB().PingContext(ctx)
+		cancel()
+	}
+
+	var started bool
+	var listener jobs.NotificationListenerStatus
+	if h.JobsManager != nil {
+		started = h.JobsManager.WorkerPoolStarted()
+		listener = h.JobsManager.NotificationListenerStatus()
+	}
+
+	response := buildReadiness(dbErr, started, listener)
+	if len(response.Failed) > 0 {
+		logger := loggerWithRequest(r)
+		logger.Warn().Strs("failed", response.Failed).Msg("Readiness check failed")
+	}
+
+	status := http.StatusOK
+	if response.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+	WriteJSON(w, r, response, status)
+}
+
+// buildReadiness combines the dependency states into a readiness response
+func buildReadiness(dbErr error, workerPoolStarted bool, listener jobs.NotificationListenerStatus) ReadinessResponse {
+	response := ReadinessResponse{
+		Status:    "ready",
+		Checks:    make(map[string]ReadinessCheck),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Version:   Version,
+	}
+
+	check := func(name string, ready bool, reason string) {
+		result := ReadinessCheck{Ready: ready}
+		if !ready {
+			result.Error = reason
+			response.Status = "not_ready"
+			response.Failed = append(response.Failed, name)
+		}
+		response.Checks[name] = result
+	}
+
+	dbReason := ""
+	if dbErr != nil {
+		dbReason = dbErr.Error()
+	}
+	check("database", dbErr == nil, dbReason)
+	check("worker_pool", workerPoolStarted, "worker pool has not started")
+	if listener.Enabled {
+		check("notification_listener", listener.Connected, "LISTEN/NOTIFY connection is down")
+	}
+
+	return response
+}
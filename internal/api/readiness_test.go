@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReadiness(t *testing.T) {
+	connected := jobs.NotificationListenerStatus{Enabled: true, Connected: true}
+
+	tests := []struct {
+		name      string
+		dbErr     error
+		started   bool
+		listener  jobs.NotificationListenerStatus
+		wantReady bool
+		wantFail  []string
+	}{
+		{
+			name:      "all_ready",
+			started:   true,
+			listener:  connected,
+			wantReady: true,
+		},
+		{
+			name:      "listener_not_configured",
+			started:   true,
+			wantReady: true,
+		},
+		{
+			name:     "database_down",
+			dbErr:    errors.New("connection refused"),
+			started:  true,
+			listener: connected,
+			wantFail: []string{"database"},
+		},
+		{
+			name:     "pool_starting_and_listener_down",
+			listener: jobs.NotificationListenerStatus{Enabled: true},
+			wantFail: []string{"worker_pool", "notification_listener"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := buildReadiness(tt.dbErr, tt.started, tt.listener)
+			if tt.wantReady {
+				assert.Equal(t, "ready", response.Status)
+			} else {
+				assert.Equal(t, "not_ready", response.Status)
+			}
+			assert.Equal(t, tt.wantFail, response.Failed)
+			for _, name := range tt.wantFail {
+				assert.NotEmpty(t, response.Checks[name].Error)
+			}
+		})
+	}
+}
+
+func TestReadinessHandlerWithoutDependencies(t *testing.T) {
+	h := &Handler{}
+	rec := httptest.NewRecorder()
+	h.ReadinessHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"failed":["database","worker_pool"]`)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/jobs"
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 	"github.com/google/uuid"
 )
@@ -19,6 +20,8 @@ import (
 type SchedulerRequest struct {
 	Domain                string   `json:"domain"`                            // Only used for creation, not update
 	ScheduleIntervalHours *int     `json:"schedule_interval_hours,omitempty"` // Pointer for explicit optional updates
+	CronExpression        *string  `json:"cron_expression,omitempty"`         // Alternative to schedule_interval_hours
+	Timezone              *string  `json:"timezone,omitempty"`                // IANA timezone for cron_expression, default UTC
 	Concurrency           *int     `json:"concurrency,omitempty"`
 	FindLinks             *bool    `json:"find_links,omitempty"`
 	MaxPages              *int     `json:"max_pages,omitempty"`
@@ -33,6 +36,8 @@ type SchedulerResponse struct {
 	ID                    string   `json:"id"`
 	Domain                string   `json:"domain"`
 	ScheduleIntervalHours int      `json:"schedule_interval_hours"`
+	CronExpression        string   `json:"cron_expression,omitempty"`
+	Timezone              string   `json:"timezone"`
 	NextRunAt             string   `json:"next_run_at"`
 	IsEnabled             bool     `json:"is_enabled"`
 	Concurrency           int      `json:"concurrency"`
@@ -123,13 +128,21 @@ func (h *Handler) createScheduler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.ScheduleIntervalHours == nil {
-		BadRequest(w, r, "schedule_interval_hours is required")
+	if req.ScheduleIntervalHours == nil && req.CronExpression == nil {
+		BadRequest(w, r, "schedule_interval_hours or cron_expression is required")
 		return
 	}
-	if *req.ScheduleIntervalHours != 6 && *req.ScheduleIntervalHours != 12 &&
-		*req.ScheduleIntervalHours != 24 && *req.ScheduleIntervalHours != 48 {
-		BadRequest(w, r, "schedule_interval_hours must be 6, 12, 24, or 48")
+
+	now := time.Now().UTC()
+	scheduler := &db.Scheduler{
+		ID:              uuid.New().String(),
+		OrganisationID:  orgID,
+		RequiredWorkers: 1,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := applyScheduleTiming(scheduler, &req, now); err != nil {
+		BadRequest(w, r, err.Error())
 		return
 	}
 
@@ -188,23 +201,13 @@ func (h *Handler) createScheduler(w http.ResponseWriter, r *http.Request) {
 		isEnabled = *req.IsEnabled
 	}
 
-	now := time.Now().UTC()
-	scheduler := &db.Scheduler{
-		ID:                    uuid.New().String(),
-		DomainID:              domainID,
-		OrganisationID:        orgID,
-		ScheduleIntervalHours: *req.ScheduleIntervalHours,
-		NextRunAt:             now.Add(time.Duration(*req.ScheduleIntervalHours) * time.Hour),
-		IsEnabled:             isEnabled,
-		Concurrency:           concurrency,
-		FindLinks:             findLinks,
-		MaxPages:              maxPages,
-		IncludePaths:          req.IncludePaths,
-		ExcludePaths:          req.ExcludePaths,
-		RequiredWorkers:       1,
-		CreatedAt:             now,
-		UpdatedAt:             now,
-	}
+	scheduler.DomainID = domainID
+	scheduler.IsEnabled = isEnabled
+	scheduler.Concurrency = concurrency
+	scheduler.FindLinks = findLinks
+	scheduler.MaxPages = maxPages
+	scheduler.IncludePaths = req.IncludePaths
+	scheduler.ExcludePaths = req.ExcludePaths
 
 	if err := h.DB.CreateScheduler(r.Context(), scheduler); err != nil {
 		logger.Error().Err(err).Str("domain", normalisedDomain).Msg("Failed to create scheduler")
@@ -339,13 +342,11 @@ func (h *Handler) updateScheduler(w http.ResponseWriter, r *http.Request, schedu
 	}
 
 	// Update fields if provided
-	if req.ScheduleIntervalHours != nil {
-		if *req.ScheduleIntervalHours != 6 && *req.ScheduleIntervalHours != 12 &&
-			*req.ScheduleIntervalHours != 24 && *req.ScheduleIntervalHours != 48 {
-			BadRequest(w, r, "schedule_interval_hours must be 6, 12, 24, or 48")
+	if req.ScheduleIntervalHours != nil || req.CronExpression != nil || req.Timezone != nil {
+		if err := applyScheduleTiming(scheduler, &req, time.Now().UTC()); err != nil {
+			BadRequest(w, r, err.Error())
 			return
 		}
-		scheduler.ScheduleIntervalHours = *req.ScheduleIntervalHours
 	}
 
 	if req.Concurrency != nil {
@@ -565,6 +566,8 @@ func schedulerToResponse(scheduler *db.Scheduler, domainName string) SchedulerRe
 		ID:                    scheduler.ID,
 		Domain:                domainName,
 		ScheduleIntervalHours: scheduler.ScheduleIntervalHours,
+		CronExpression:        scheduler.CronExpression,
+		Timezone:              scheduler.Timezone,
 		NextRunAt:             scheduler.NextRunAt.Format(time.RFC3339),
 		IsEnabled:             scheduler.IsEnabled,
 		Concurrency:           scheduler.Concurrency,
@@ -576,3 +579,53 @@ func schedulerToResponse(scheduler *db.Scheduler, domainName string) SchedulerRe
 		UpdatedAt:             scheduler.UpdatedAt.Format(time.RFC3339),
 	}
 }
+
+// applyScheduleTiming applies a request's interval, cron expression and
+// timezone to a scheduler and recalculates its next run. A scheduler runs on
+// either an interval or a cron expression, so setting one clears the other.
+func applyScheduleTiming(scheduler *db.Scheduler, req *SchedulerRequest, now time.Time) error {
+	if req.ScheduleIntervalHours != nil && req.CronExpression != nil {
+		return errors.New("set either schedule_interval_hours or cron_expression, not both")
+	}
+
+	if req.ScheduleIntervalHours != nil {
+		hours := *req.ScheduleIntervalHours
+		if hours != 6 && hours != 12 && hours != 24 && hours != 48 {
+			return errors.New("schedule_interval_hours must be 6, 12, 24, or 48")
+		}
+		scheduler.ScheduleIntervalHours = hours
+		scheduler.CronExpression = ""
+	}
+
+	if req.CronExpression != nil {
+		expr := strings.TrimSpace(*req.CronExpression)
+		if expr == "" {
+			return errors.New("cron_expression cannot be empty")
+		}
+		scheduler.CronExpression = expr
+		scheduler.ScheduleIntervalHours = 0
+	}
+
+	if req.Timezone != nil {
+		scheduler.Timezone = strings.TrimSpace(*req.Timezone)
+	}
+	if scheduler.Timezone == "" {
+		scheduler.Timezone = "UTC"
+	}
+	if _, err := jobs.LoadScheduleLocation(scheduler.Timezone); err != nil {
+		return fmt.Errorf("timezone: %w", err)
+	}
+
+	if scheduler.CronExpression != "" {
+		if err := jobs.ValidateCronSchedule(scheduler.CronExpression, scheduler.Timezone); err != nil {
+			return fmt.Errorf("cron_expression: %w", err)
+		}
+	}
+
+	nextRun, err := jobs.NextScheduledRun(scheduler, now)
+	if err != nil {
+		return err
+	}
+	scheduler.NextRunAt = nextRun
+	return nil
+}
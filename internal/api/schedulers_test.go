@@ -0,0 +1,54 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyScheduleTiming(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	strPtr := func(s string) *string { return &s }
+	intPtr := func(i int) *int { return &i }
+
+	t.Run("interval", func(t *testing.T) {
+		scheduler := &db.Scheduler{}
+		require.NoError(t, applyScheduleTiming(scheduler, &SchedulerRequest{ScheduleIntervalHours: intPtr(24)}, now))
+		assert.Equal(t, 24, scheduler.ScheduleIntervalHours)
+		assert.Equal(t, "UTC", scheduler.Timezone)
+		assert.Equal(t, now.Add(24*time.Hour), scheduler.NextRunAt)
+	})
+
+	t.Run("cron replaces interval", func(t *testing.T) {
+		scheduler := &db.Scheduler{ScheduleIntervalHours: 24, Timezone: "UTC"}
+		req := &SchedulerRequest{CronExpression: strPtr("0 2 * * *"), Timezone: strPtr("Australia/Sydney")}
+		require.NoError(t, applyScheduleTiming(scheduler, req, now))
+		assert.Equal(t, 0, scheduler.ScheduleIntervalHours)
+		assert.Equal(t, "0 2 * * *", scheduler.CronExpression)
+		assert.Equal(t, time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), scheduler.NextRunAt)
+	})
+
+	t.Run("interval replaces cron", func(t *testing.T) {
+		scheduler := &db.Scheduler{CronExpression: "0 2 * * *", Timezone: "UTC"}
+		require.NoError(t, applyScheduleTiming(scheduler, &SchedulerRequest{ScheduleIntervalHours: intPtr(6)}, now))
+		assert.Empty(t, scheduler.CronExpression)
+		assert.Equal(t, 6, scheduler.ScheduleIntervalHours)
+	})
+
+	for name, req := range map[string]*SchedulerRequest{
+		"both":             {ScheduleIntervalHours: intPtr(24), CronExpression: strPtr("0 2 * * *")},
+		"bad interval":     {ScheduleIntervalHours: intPtr(3)},
+		"empty cron":       {CronExpression: strPtr(" ")},
+		"bad cron":         {CronExpression: strPtr("0 25 * * *")},
+		"never runs":       {CronExpression: strPtr("0 0 31 2 *")},
+		"too frequent":     {CronExpression: strPtr("* * * * *")},
+		"unknown timezone": {CronExpression: strPtr("0 2 * * *"), Timezone: strPtr("Nowhere/Special")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, applyScheduleTiming(&db.Scheduler{}, req, now))
+		})
+	}
+}
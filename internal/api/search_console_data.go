@@ -0,0 +1,260 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Search Console API limits
+const (
+	// SearchConsoleTopPagesLimit is how many pages are fetched per job; the API
+	// returns rows ordered by clicks
+	SearchConsoleTopPagesLimit = 1000
+
+	// SearchConsoleLookbackDays matches the 28-day window of GA4 traffic scores
+	SearchConsoleLookbackDays = 28
+)
+
+// searchConsoleAPIBase is the Search Console API root, overridden in tests
+var searchConsoleAPIBase = "https://www.googleapis.com/webmasters/v3"
+
+// searchConsoleSite is a site from the Search Console sites.list API
+type searchConsoleSite struct {
+	SiteURL         string `json:"siteUrl"`
+	PermissionLevel string `json:"permissionLevel"`
+}
+
+// searchAnalyticsRow is one row of a searchAnalytics.query response
+type searchAnalyticsRow struct {
+	Keys        []string `json:"keys"`
+	Clicks      float64  `json:"clicks"`
+	Impressions float64  `json:"impressions"`
+}
+
+// searchConsoleSiteDomain returns the normalised domain a Search Console site
+// covers: "sc-domain:example.com" and "https://www.example.com/" both give
+// "example.com". Returns "" when the site URL can't be parsed.
+func searchConsoleSiteDomain(siteURL string) string {
+	if domain, ok := strings.CutPrefix(siteURL, "sc-domain:"); ok {
+		return strings.ToLower(util.NormaliseDomain(domain))
+	}
+	parsed, err := url.Parse(siteURL)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+	return strings.ToLower(util.NormaliseDomain(parsed.Hostname()))
+}
+
+// searchRowsToPageStats converts page rows to per-path stats for a domain.
+// Rows on other hosts (a domain property also covers subdomains) are dropped,
+// and rows that normalise to the same path, such as http and https, are summed.
+func searchRowsToPageStats(rows []searchAnalyticsRow, domain string) []db.PageSearchStats {
+	index := make(map[string]int, len(rows))
+	stats := make([]db.PageSearchStats, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Keys) == 0 {
+			continue
+		}
+		parsed, err := url.Parse(row.Keys[0])
+		if err != nil || util.NormaliseDomain(strings.ToLower(parsed.Hostname())) != domain {
+			continue
+		}
+		path, err := db.NormaliseURLPath(row.Keys[0], domain)
+		if err != nil {
+			continue
+		}
+
+		if i, ok := index[path]; ok {
+			stats[i].Clicks += int64(row.Clicks)
+			stats[i].Impressions += int64(row.Impressions)
+			continue
+		}
+		index[path] = len(stats)
+		stats = append(stats, db.PageSearchStats{
+			Path:        path,
+			Clicks:      int64(row.Clicks),
+			Impressions: int64(row.Impressions),
+		})
+	}
+	return stats
+}
+
+// searchConsoleRequest calls the Search Console API and decodes the JSON response
+func searchConsoleRequest(ctx context.Context, method, endpoint, accessToken string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal Search Console request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, searchConsoleAPIBase+endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create Search Console request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute Search Console request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("search console API returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Search Console response: %w", err)
+	}
+	return nil
+}
+
+// fetchSearchConsoleSites lists the sites the user can read, skipping ones
+// they haven't verified
+func fetchSearchConsoleSites(ctx context.Context, accessToken string) ([]searchConsoleSite, error) {
+	var resp struct {
+		SiteEntry []searchConsoleSite `json:"siteEntry"`
+	}
+	if err := searchConsoleRequest(ctx, http.MethodGet, "/sites", accessToken, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	sites := make([]searchConsoleSite, 0, len(resp.SiteEntry))
+	for _, site := range resp.SiteEntry {
+		if site.PermissionLevel == "siteUnverifiedUser" {
+			continue
+		}
+		sites = append(sites, site)
+	}
+	return sites, nil
+}
+
+// fetchSearchConsoleTopPages fetches a site's top pages over the lookback window
+func fetchSearchConsoleTopPages(ctx context.Context, accessToken, siteURL string) ([]searchAnalyticsRow, error) {
+	end := time.Now().UTC()
+	body := map[string]any{
+		"startDate":  end.AddDate(0, 0, -SearchConsoleLookbackDays).Format(time.DateOnly),
+		"endDate":    end.Format(time.DateOnly),
+		"dimensions": []string{"page"},
+		"rowLimit":   SearchConsoleTopPagesLimit,
+	}
+
+	var resp struct {
+		Rows []searchAnalyticsRow `json:"rows"`
+	}
+	endpoint := "/sites/" + url.PathEscape(siteURL) + "/searchAnalytics/query"
+	if err := searchConsoleRequest(ctx, http.MethodPost, endpoint, accessToken, body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Rows, nil
+}
+
+// fetchSearchConsoleDataBeforeJob boosts a job's pages by Search Console
+// impressions. Sites without an active connection keep normal prioritisation.
+func (h *Handler) fetchSearchConsoleDataBeforeJob(ctx context.Context, logger zerolog.Logger, organisationID, domain string) {
+	normalisedDomain := util.NormaliseDomain(domain)
+
+	domainID, err := h.DB.GetOrCreateDomainID(ctx, normalisedDomain)
+	if err != nil {
+		logger.Warn().Err(err).
+			Str("organisation_id", organisationID).
+			Str("next_action", "search_prioritisation_skipped").
+			Msg("Failed to get domain ID for Search Console fetch")
+		return
+	}
+
+	conn, err := h.DB.GetActiveSearchConsoleConnectionForDomain(ctx, organisationID, domainID)
+	if err != nil {
+		logger.Warn().Err(err).Int("domain_id", domainID).Msg("Failed to look up Search Console connection")
+		return
+	}
+	if conn == nil {
+		logger.Debug().
+			Str("organisation_id", organisationID).
+			Int("domain_id", domainID).
+			Msg("No Search Console connection for domain, using normal prioritisation")
+		return
+	}
+
+	pageCount, err := h.syncSearchConsolePages(ctx, conn, organisationID, domainID, strings.ToLower(normalisedDomain))
+	if err != nil {
+		logger.Warn().Err(err).
+			Str("connection_id", conn.ID).
+			Str("next_action", "job_continues_without_search_data").
+			Msg("Failed to fetch Search Console data, continuing with normal prioritisation")
+		return
+	}
+
+	logger.Info().
+		Str("organisation_id", organisationID).
+		Int("domain_id", domainID).
+		Int("pages_count", pageCount).
+		Msg("Applied Search Console data to task priorities")
+}
+
+// syncSearchConsolePages fetches a connected site's top pages, scores them and
+// raises pending tasks. Returns the number of pages stored.
+func (h *Handler) syncSearchConsolePages(ctx context.Context, conn *db.SearchConsoleConnection, organisationID string, domainID int, domain string) (int, error) {
+	refreshToken, err := h.DB.GetSearchConsoleToken(ctx, conn.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrGoogleTokenNotFound) {
+			h.markSearchConsoleInactive(ctx, conn.ID, "token missing")
+		}
+		return 0, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	accessToken, err := h.refreshGoogleAccessToken(ctx, refreshToken)
+	if err != nil {
+		if isGoogleTokenRevoked(err) {
+			h.markSearchConsoleInactive(ctx, conn.ID, "token refresh failed")
+		}
+		return 0, err
+	}
+
+	rows, err := fetchSearchConsoleTopPages(ctx, accessToken, conn.SiteURL)
+	if err != nil {
+		return 0, err
+	}
+
+	stats := searchRowsToPageStats(rows, domain)
+	if err := h.DB.UpsertPageSearchStats(ctx, organisationID, domainID, conn.ID, stats); err != nil {
+		return 0, err
+	}
+	if err := h.DB.CalculateSearchScores(ctx, organisationID, domainID); err != nil {
+		return 0, err
+	}
+	if err := h.DB.ApplySearchScoresToTasks(ctx, organisationID, domainID); err != nil {
+		return 0, err
+	}
+
+	if err := h.DB.UpdateSearchConsoleLastSync(ctx, conn.ID); err != nil {
+		// Not critical - the next job retries
+		log.Warn().Err(err).Str("connection_id", conn.ID).Msg("Failed to update Search Console last sync")
+	}
+	return len(stats), nil
+}
+
+func (h *Handler) markSearchConsoleInactive(ctx context.Context, connectionID, reason string) {
+	if err := h.DB.MarkSearchConsoleConnectionInactive(ctx, connectionID, reason); err != nil {
+		log.Error().Err(err).Str("connection_id", connectionID).Msg("Failed to mark Search Console connection inactive")
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchConsoleSiteDomain(t *testing.T) {
+	tests := map[string]string{
+		"sc-domain:Example.com":     "example.com",
+		"https://www.example.com/":  "example.com",
+		"http://shop.example.com/":  "shop.example.com",
+		"https://example.com/blog/": "example.com",
+		"not a url":                 "",
+	}
+	for siteURL, expected := range tests {
+		assert.Equal(t, expected, searchConsoleSiteDomain(siteURL), siteURL)
+	}
+}
+
+func TestSearchRowsToPageStats(t *testing.T) {
+	rows := []searchAnalyticsRow{
+		{Keys: []string{"https://www.example.com/"}, Clicks: 40, Impressions: 900},
+		{Keys: []string{"https://example.com/pricing/"}, Clicks: 10, Impressions: 300},
+		{Keys: []string{"http://example.com/pricing"}, Clicks: 2, Impressions: 50},
+		{Keys: []string{"https://blog.example.com/post"}, Clicks: 5, Impressions: 100},
+		{Keys: nil, Clicks: 1, Impressions: 1},
+	}
+
+	stats := searchRowsToPageStats(rows, "example.com")
+	assert.Equal(t, []db.PageSearchStats{
+		{Path: "/", Clicks: 40, Impressions: 900},
+		{Path: "/pricing", Clicks: 12, Impressions: 350},
+	}, stats)
+}
+
+func TestFetchSearchConsoleSitesSkipsUnverified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sites", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"siteEntry": []searchConsoleSite{
+				{SiteURL: "sc-domain:example.com", PermissionLevel: "siteOwner"},
+				{SiteURL: "https://other.com/", PermissionLevel: "siteUnverifiedUser"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	original := searchConsoleAPIBase
+	searchConsoleAPIBase = server.URL
+	defer func() { searchConsoleAPIBase = original }()
+
+	sites, err := fetchSearchConsoleSites(context.Background(), "token")
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, "sc-domain:example.com", sites[0].SiteURL)
+}
+
+func TestFetchSearchConsoleTopPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/sites/sc-domain:example.com/searchAnalytics/query", r.URL.Path)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []any{"page"}, body["dimensions"])
+		assert.InDelta(t, SearchConsoleTopPagesLimit, body["rowLimit"], 0)
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"rows": []searchAnalyticsRow{{Keys: []string{"https://example.com/"}, Clicks: 3, Impressions: 40}},
+		})
+	}))
+	defer server.Close()
+
+	original := searchConsoleAPIBase
+	searchConsoleAPIBase = server.URL
+	defer func() { searchConsoleAPIBase = original }()
+
+	rows, err := fetchSearchConsoleTopPages(context.Background(), "token", "sc-domain:example.com")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.InDelta(t, 40, rows[0].Impressions, 0)
+}
@@ -316,6 +316,10 @@ func (h *Handler) exportSharedJobTasks(w http.ResponseWriter, r *http.Request, t
 		return
 	}
 
+	if format := r.URL.Query().Get("format"); format != "" {
+		h.streamJobExport(w, r, record.JobID, format)
+		return
+	}
 	h.serveJobExport(w, r, record.JobID, false)
 }
 
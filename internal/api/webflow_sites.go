@@ -368,6 +368,7 @@ func (h *Handler) updateSiteSchedule(w http.ResponseWriter, r *http.Request, sit
 		if existingScheduler != nil {
 			// Update existing scheduler
 			existingScheduler.ScheduleIntervalHours = *req.ScheduleIntervalHours
+			existingScheduler.CronExpression = ""
 			existingScheduler.NextRunAt = time.Now().Add(time.Duration(*req.ScheduleIntervalHours) * time.Hour)
 			if err := h.DB.UpdateScheduler(ctx, existingScheduler.ID, existingScheduler, nil); err != nil {
 				logger.Error().Err(err).Str("scheduler_id", existingScheduler.ID).Msg("Failed to update scheduler")
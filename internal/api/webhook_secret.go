@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/webhook"
+)
+
+// WebhookSecretResponse carries the key job completion webhooks are signed with
+type WebhookSecretResponse struct {
+	Secret          string `json:"secret"`
+	SignatureHeader string `json:"signature_header"`
+	TimestampHeader string `json:"timestamp_header"`
+}
+
+// WebhookSecretHandler handles requests to /v1/integrations/webhook-secret.
+// GET returns the organisation's signing secret and POST rotates it; both
+// require an organisation admin.
+func (h *Handler) WebhookSecretHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		MethodNotAllowed(w, r)
+		return
+	}
+
+	logger := loggerWithRequest(r)
+
+	user, orgID, ok := h.GetActiveOrganisationWithUser(w, r)
+	if !ok {
+		return
+	}
+	if !h.requireOrganisationAdmin(w, r, orgID, user.ID) {
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		secret, err := h.DB.RotateOrganisationWebhookSecret(r.Context(), orgID)
+		if err != nil {
+			logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to rotate webhook secret")
+			InternalError(w, r, err)
+			return
+		}
+		logger.Info().Str("organisation_id", orgID).Msg("Webhook secret rotated")
+		WriteSuccess(w, r, newWebhookSecretResponse(secret), "Webhook secret rotated")
+		return
+	}
+
+	secret, err := h.DB.GetOrganisationWebhookSecret(r.Context(), orgID)
+	if err != nil {
+		logger.Error().Err(err).Str("organisation_id", orgID).Msg("Failed to get webhook secret")
+		InternalError(w, r, err)
+		return
+	}
+	WriteSuccess(w, r, newWebhookSecretResponse(secret), "")
+}
+
+func newWebhookSecretResponse(secret string) WebhookSecretResponse {
+	return WebhookSecretResponse{
+		Secret:          secret,
+		SignatureHeader: webhook.SignatureHeader,
+		TimestampHeader: webhook.TimestampHeader,
+	}
+}
@@ -0,0 +1,184 @@
+// Package cdnpurge provides clients for CDN purge APIs.
+// Cloudflare and Fastly are supported initially.
+package cdnpurge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	ProviderCloudflare = "cloudflare"
+	ProviderFastly     = "fastly"
+
+	cloudflareBaseURL = "https://api.cloudflare.com/client/v4"
+	fastlyBaseURL     = "https://api.fastly.com"
+
+	// cloudflareBatchSize is the per-request file limit on all Cloudflare plans
+	cloudflareBatchSize = 30
+	defaultTimeout      = 30 * time.Second
+	maxErrorBodyBytes   = 4 * 1024
+)
+
+// ErrSoftPurgeUnsupported is returned for soft purge on a provider that
+// can only evict
+var ErrSoftPurgeUnsupported = errors.New("cdnpurge: soft purge is only supported for Fastly")
+
+// Purger purges cached copies of URLs from a CDN
+type Purger interface {
+	Provider() string
+	Purge(ctx context.Context, urls []string) error
+}
+
+// Config holds the credentials and target for a purge client
+type Config struct {
+	Provider string
+	// ZoneID is the Cloudflare zone ID or Fastly service ID
+	ZoneID   string
+	APIToken string
+	// SoftPurge marks URLs stale rather than evicting them. Fastly only;
+	// Cloudflare has no soft purge, so New rejects it.
+	SoftPurge bool
+}
+
+// New creates a Purger for the configured provider
+func New(cfg Config) (Purger, error) {
+	if cfg.APIToken == "" {
+		return nil, fmt.Errorf("cdnpurge: API token is required")
+	}
+
+	httpClient := &http.Client{Timeout: defaultTimeout}
+
+	switch cfg.Provider {
+	case ProviderCloudflare:
+		if cfg.ZoneID == "" {
+			return nil, fmt.Errorf("cdnpurge: Cloudflare zone ID is required")
+		}
+		if cfg.SoftPurge {
+			return nil, ErrSoftPurgeUnsupported
+		}
+		return &cloudflarePurger{
+			baseURL:    cloudflareBaseURL,
+			zoneID:     cfg.ZoneID,
+			apiToken:   cfg.APIToken,
+			httpClient: httpClient,
+		}, nil
+	case ProviderFastly:
+		return &fastlyPurger{
+			baseURL:    fastlyBaseURL,
+			apiToken:   cfg.APIToken,
+			softPurge:  cfg.SoftPurge,
+			httpClient: httpClient,
+		}, nil
+	default:
+		return nil, fmt.Errorf("cdnpurge: unsupported provider %q", cfg.Provider)
+	}
+}
+
+// IsSupportedProvider reports whether the provider name is recognised
+func IsSupportedProvider(provider string) bool {
+	return provider == ProviderCloudflare || provider == ProviderFastly
+}
+
+type cloudflarePurger struct {
+	baseURL    string
+	zoneID     string
+	apiToken   string
+	httpClient *http.Client
+}
+
+func (c *cloudflarePurger) Provider() string {
+	return ProviderCloudflare
+}
+
+// Purge removes URLs from the Cloudflare cache in batches of 30
+func (c *cloudflarePurger) Purge(ctx context.Context, urls []string) error {
+	endpoint := fmt.Sprintf("%s/zones/%s/purge_cache", c.baseURL, url.PathEscape(c.zoneID))
+
+	for start := 0; start < len(urls); start += cloudflareBatchSize {
+		end := min(start+cloudflareBatchSize, len(urls))
+
+		body, err := json.Marshal(map[string][]string{"files": urls[start:end]})
+		if err != nil {
+			return fmt.Errorf("cdnpurge: failed to marshal request: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("cdnpurge: failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		if err := do(c.httpClient, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type fastlyPurger struct {
+	baseURL    string
+	apiToken   string
+	softPurge  bool
+	httpClient *http.Client
+}
+
+func (f *fastlyPurger) Provider() string {
+	return ProviderFastly
+}
+
+// Purge removes each URL from the Fastly cache. Soft purges mark content
+// stale so it can still be served while the origin revalidates.
+func (f *fastlyPurger) Purge(ctx context.Context, urls []string) error {
+	for _, rawURL := range urls {
+		parsed, err := url.Parse(rawURL)
+		if err != nil || parsed.Host == "" {
+			return fmt.Errorf("cdnpurge: invalid URL %q", rawURL)
+		}
+
+		endpoint := fmt.Sprintf("%s/purge/%s%s", f.baseURL, parsed.Host, parsed.EscapedPath())
+		if parsed.RawQuery != "" {
+			endpoint += "?" + parsed.RawQuery
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+		if err != nil {
+			return fmt.Errorf("cdnpurge: failed to create request: %w", err)
+		}
+		req.Header.Set("Fastly-Key", f.apiToken)
+		req.Header.Set("Accept", "application/json")
+		if f.softPurge {
+			req.Header.Set("Fastly-Soft-Purge", "1")
+		}
+
+		if err := do(f.httpClient, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cdnpurge: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("cdnpurge: purge failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
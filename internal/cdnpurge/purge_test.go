@@ -0,0 +1,110 @@
+package cdnpurge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		expectErr bool
+		provider  string
+	}{
+		{name: "cloudflare", cfg: Config{Provider: ProviderCloudflare, ZoneID: "zone", APIToken: "token"}, provider: ProviderCloudflare},
+		{name: "fastly", cfg: Config{Provider: ProviderFastly, APIToken: "token"}, provider: ProviderFastly},
+		{name: "missing token", cfg: Config{Provider: ProviderFastly}, expectErr: true},
+		{name: "cloudflare soft purge", cfg: Config{Provider: ProviderCloudflare, ZoneID: "zone", APIToken: "token", SoftPurge: true}, expectErr: true},
+		{name: "cloudflare missing zone", cfg: Config{Provider: ProviderCloudflare, APIToken: "token"}, expectErr: true},
+		{name: "unsupported provider", cfg: Config{Provider: "akamai", APIToken: "token"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.cfg)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.provider, p.Provider())
+		})
+	}
+}
+
+func TestCloudflarePurge_Batches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-123/purge_cache", r.URL.Path)
+		assert.Equal(t, "Bearer cf-token", r.Header.Get("Authorization"))
+
+		var body struct {
+			Files []string `json:"files"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		batches = append(batches, body.Files)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	p := &cloudflarePurger{baseURL: server.URL, zoneID: "zone-123", apiToken: "cf-token", httpClient: server.Client()}
+
+	urls := make([]string, 45)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/page-%d", i)
+	}
+
+	require.NoError(t, p.Purge(context.Background(), urls))
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], 30)
+	assert.Len(t, batches[1], 15)
+}
+
+func TestFastlyPurge_SoftPurge(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "fastly-token", r.Header.Get("Fastly-Key"))
+		assert.Equal(t, "1", r.Header.Get("Fastly-Soft-Purge"))
+		paths = append(paths, r.URL.RequestURI())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := &fastlyPurger{baseURL: server.URL, apiToken: "fastly-token", softPurge: true, httpClient: server.Client()}
+
+	err := p.Purge(context.Background(), []string{"https://example.com/", "https://example.com/blog?page=2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/purge/example.com/", "/purge/example.com/blog?page=2"}, paths)
+}
+
+func TestPurge_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false}`))
+	}))
+	defer server.Close()
+
+	p := &cloudflarePurger{baseURL: server.URL, zoneID: "zone", apiToken: "bad", httpClient: server.Client()}
+
+	err := p.Purge(context.Background(), []string{"https://example.com/"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+}
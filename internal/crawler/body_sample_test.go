@@ -0,0 +1,43 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWarmURLBodySampleSize(t *testing.T) {
+	html := bytes.Repeat([]byte("<p>padding</p>"), 20)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(html)
+	}))
+	defer ts.Close()
+
+	config := testConfig()
+	config.BodySampleBytes = 64
+	result, err := New(config).WarmURL(context.Background(), ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(result.BodySample, html[:64]) {
+		t.Fatalf("Expected a 64 byte sample, got %d bytes", len(result.BodySample))
+	}
+	if !bytes.Equal(result.Body, html) {
+		t.Fatalf("Expected the full body alongside the sample, got %d bytes", len(result.Body))
+	}
+
+	// Unset falls back to the default, which this page fits inside
+	config.BodySampleBytes = 0
+	result, err = New(config).WarmURL(context.Background(), ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(result.BodySample, html) {
+		t.Fatalf("Expected the whole page as the sample, got %d bytes", len(result.BodySample))
+	}
+}
@@ -0,0 +1,89 @@
+package crawler
+
+import (
+	"net/http"
+	"strings"
+)
+
+// edgeCacheHeaders lists CDN cache headers in order of precedence. The first
+// one present determines the edge cache status.
+var edgeCacheHeaders = []string{
+	"CF-Cache-Status", // Cloudflare
+	"X-Cache",         // CloudFront/Fastly ("Miss from cloudfront", "HIT", etc.)
+	"X-Cache-Remote",  // Akamai ("TCP_HIT", "TCP_MISS", etc.)
+	"x-vercel-cache",  // Vercel
+	"Cache-Status",    // RFC 9211 (newer standardised approach)
+}
+
+// originCacheHeaders are checked for an origin-level cache (reverse proxy or
+// shield) sitting behind the edge. Any header already used for the edge
+// status is skipped.
+var originCacheHeaders = []string{
+	"X-Cache",
+	"X-Cache-Status",    // nginx proxy_cache
+	"X-Proxy-Cache",     // nginx/Kinsta style proxies
+	"X-LiteSpeed-Cache", // LiteSpeed
+	"Cache-Status",
+	"X-Varnish",
+}
+
+// detectCacheLayers returns the normalised edge cache status and, when a
+// second cache tier is visible, the origin-level status. Both layers are
+// reported separately so multi-tier setups (edge + origin shield) don't
+// collapse into one ambiguous value.
+func detectCacheLayers(headers http.Header) (edge, origin string) {
+	edgeHeader := ""
+	for _, name := range edgeCacheHeaders {
+		if status := normaliseCacheStatus(headers.Get(name)); status != "" {
+			edge, edgeHeader = status, name
+			break
+		}
+	}
+
+	// Varnish (the presence of X-Varnish indicates it was processed by Varnish)
+	if edge == "" {
+		edge = varnishCacheStatus(headers.Get("X-Varnish"))
+		if edge == "" {
+			return "", ""
+		}
+		edgeHeader = "X-Varnish"
+	}
+
+	// Tiered values list the cache nearest the origin first
+	// (e.g. Fastly shielding "HIT, MISS", RFC 9211 multi-cache entries)
+	if tiers := strings.Split(headers.Get(edgeHeader), ","); len(tiers) > 1 {
+		if status := normaliseCacheStatus(tiers[0]); status != "" {
+			return edge, status
+		}
+	}
+
+	for _, name := range originCacheHeaders {
+		if strings.EqualFold(name, edgeHeader) {
+			continue
+		}
+		value := headers.Get(name)
+		if value == "" {
+			continue
+		}
+		if name == "X-Varnish" {
+			return edge, varnishCacheStatus(value)
+		}
+		if status := normaliseCacheStatus(value); status != "" {
+			return edge, status
+		}
+	}
+
+	return edge, ""
+}
+
+// varnishCacheStatus infers a status from X-Varnish request IDs
+func varnishCacheStatus(varnishID string) string {
+	varnishID = strings.TrimSpace(varnishID)
+	if varnishID == "" {
+		return ""
+	}
+	if strings.Contains(varnishID, " ") {
+		return "HIT" // Multiple IDs indicate a cache hit
+	}
+	return "MISS" // Single ID indicates a cache miss
+}
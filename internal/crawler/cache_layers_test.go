@@ -0,0 +1,43 @@
+package crawler
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDetectCacheLayers(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedEdge   string
+		expectedOrigin string
+	}{
+		{"no cache headers", map[string]string{}, "", ""},
+		{"cloudflare only", map[string]string{"CF-Cache-Status": "HIT"}, "HIT", ""},
+		{"cloudflare with origin x-cache", map[string]string{"CF-Cache-Status": "HIT", "X-Cache": "MISS"}, "HIT", "MISS"},
+		{"cloudflare with nginx proxy cache", map[string]string{"CF-Cache-Status": "MISS", "X-Cache-Status": "HIT"}, "MISS", "HIT"},
+		{"cloudflare with litespeed", map[string]string{"CF-Cache-Status": "DYNAMIC", "X-LiteSpeed-Cache": "hit"}, "DYNAMIC", "HIT"},
+		{"cloudflare with varnish hit", map[string]string{"CF-Cache-Status": "MISS", "X-Varnish": "123 456"}, "MISS", "HIT"},
+		{"fastly shield", map[string]string{"X-Cache": "HIT, MISS"}, "MISS", "HIT"},
+		{"rfc9211 multi cache", map[string]string{"Cache-Status": `"Origin"; fwd=miss, "CDN"; hit`}, "HIT", "MISS"},
+		{"varnish only", map[string]string{"X-Varnish": "123"}, "MISS", ""},
+		{"blank edge falls through", map[string]string{"CF-Cache-Status": " ", "X-Cache": "Hit from cloudfront"}, "HIT", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+
+			edge, origin := detectCacheLayers(headers)
+			if edge != tt.expectedEdge {
+				t.Errorf("Expected edge status %q, got %q", tt.expectedEdge, edge)
+			}
+			if origin != tt.expectedOrigin {
+				t.Errorf("Expected origin status %q, got %q", tt.expectedOrigin, origin)
+			}
+		})
+	}
+}
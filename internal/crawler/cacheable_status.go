@@ -0,0 +1,29 @@
+package crawler
+
+import (
+	"context"
+	"slices"
+)
+
+type cacheableStatusKey struct{}
+
+// WithCacheableStatusCodes marks non-2xx status codes that WarmURL should
+// treat as successful warms, for sites whose CDN deliberately caches error
+// pages (e.g. cached 404s). The response is recorded and cache-verified like
+// any 2xx page.
+func WithCacheableStatusCodes(ctx context.Context, codes []int) context.Context {
+	return context.WithValue(ctx, cacheableStatusKey{}, codes)
+}
+
+func cacheableStatusCodesFromContext(ctx context.Context) []int {
+	codes, _ := ctx.Value(cacheableStatusKey{}).([]int)
+	return codes
+}
+
+// isSuccessStatus reports whether code counts as a successful warm
+func isSuccessStatus(code int, cacheable []int) bool {
+	if code >= 200 && code < 300 {
+		return true
+	}
+	return slices.Contains(cacheable, code)
+}
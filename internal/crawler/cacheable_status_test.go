@@ -0,0 +1,39 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWarmURLCacheableStatusCodes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CF-Cache-Status", "HIT")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+
+	if _, err := c.WarmURL(context.Background(), ts.URL, false); err == nil {
+		t.Error("Expected 404 to fail without cacheable status codes")
+	}
+
+	ctx := WithCacheableStatusCodes(context.Background(), []int{404})
+	result, err := c.WarmURL(ctx, ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected cacheable 404 to succeed, got %v", err)
+	}
+	if result.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", result.StatusCode)
+	}
+	if result.CacheStatus != "HIT" {
+		t.Errorf("Expected cache status HIT, got %s", result.CacheStatus)
+	}
+
+	ctx = WithCacheableStatusCodes(context.Background(), []int{503})
+	if _, err := c.WarmURL(ctx, ts.URL, false); err == nil {
+		t.Error("Expected 404 to fail when only 503 is cacheable")
+	}
+}
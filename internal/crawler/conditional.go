@@ -0,0 +1,106 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+)
+
+// Validators are the content validators a page returned on a previous warm
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// IsZero reports whether there's nothing to compare against
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+type validatorsKey struct{}
+
+// WithValidators makes WarmURL send If-None-Match/If-Modified-Since from a
+// previous warm. A 304 then counts as a successful warm without a body
+// transfer and is flagged NotModified on the result.
+func WithValidators(ctx context.Context, v Validators) context.Context {
+	return context.WithValue(ctx, validatorsKey{}, v)
+}
+
+func validatorsFromContext(ctx context.Context) Validators {
+	v, _ := ctx.Value(validatorsKey{}).(Validators)
+	return v
+}
+
+// setConditionalHeaders adds the validators as conditional request headers
+func setConditionalHeaders(headers *http.Header, v Validators) {
+	if v.ETag != "" {
+		headers.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		headers.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// carryForwardValidators fills validators a 304 omitted, since the 304
+// confirms they still apply. Keeps them in the stored headers for the next job.
+func carryForwardValidators(headers http.Header, v Validators) {
+	if headers == nil {
+		return
+	}
+	if headers.Get("ETag") == "" && v.ETag != "" {
+		headers.Set("ETag", v.ETag)
+	}
+	if headers.Get("Last-Modified") == "" && v.LastModified != "" {
+		headers.Set("Last-Modified", v.LastModified)
+	}
+}
+
+// CheckUnchanged sends a conditional HEAD request and reports whether the page
+// still matches previous. A 304, or a matching ETag (else Last-Modified),
+// counts as unchanged. Any doubt is reported as changed so the page is warmed.
+func (c *Crawler) CheckUnchanged(ctx context.Context, targetURL string, previous Validators) (bool, error) {
+	if previous.IsZero() {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, targetURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	setCustomHeaders(ctx, &req.Header)
+	req.Header.Set("User-Agent", c.userAgent(ctx))
+	setAuthorization(ctx, &req.Header)
+	setConditionalHeaders(&req.Header, previous)
+
+	// Use SSRF-safe transport if protection is enabled
+	client := &http.Client{
+		Timeout:       c.config.DefaultTimeout,
+		Transport:     newTransport(c.config),
+		CheckRedirect: checkRedirect(c.maxRedirects()),
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	return validatorsUnchanged(resp.StatusCode, resp.Header, previous), nil
+}
+
+func validatorsUnchanged(statusCode int, headers http.Header, previous Validators) bool {
+	if statusCode == http.StatusNotModified {
+		return true
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return false
+	}
+
+	if etag := headers.Get("ETag"); etag != "" && previous.ETag != "" {
+		return etag == previous.ETag
+	}
+	if lastModified := headers.Get("Last-Modified"); lastModified != "" && previous.LastModified != "" {
+		return lastModified == previous.LastModified
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidatorsUnchanged(t *testing.T) {
+	previous := Validators{ETag: `"abc"`, LastModified: "Wed, 14 Oct 2026 10:00:00 GMT"}
+
+	tests := []struct {
+		name     string
+		status   int
+		headers  map[string]string
+		previous Validators
+		want     bool
+	}{
+		{"not modified", http.StatusNotModified, nil, previous, true},
+		{"matching etag", http.StatusOK, map[string]string{"ETag": `"abc"`}, previous, true},
+		{"changed etag", http.StatusOK, map[string]string{"ETag": `"def"`, "Last-Modified": previous.LastModified}, previous, false},
+		{"matching last-modified", http.StatusOK, map[string]string{"Last-Modified": previous.LastModified}, Validators{LastModified: previous.LastModified}, true},
+		{"no validators returned", http.StatusOK, nil, previous, false},
+		{"error status", http.StatusNotFound, map[string]string{"ETag": `"abc"`}, previous, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+			if got := validatorsUnchanged(tt.status, headers, tt.previous); got != tt.want {
+				t.Errorf("validatorsUnchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckUnchangedSendsConditionalHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Expected HEAD request, got %s", r.Method)
+		}
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+
+	unchanged, err := c.CheckUnchanged(context.Background(), ts.URL, Validators{ETag: `"abc"`})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !unchanged {
+		t.Error("Expected page to be unchanged")
+	}
+
+	unchanged, err = c.CheckUnchanged(context.Background(), ts.URL, Validators{ETag: `"old"`})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if unchanged {
+		t.Error("Expected page to be changed")
+	}
+}
+
+func TestWarmURLConditional(t *testing.T) {
+	const lastModified = "Wed, 14 Oct 2026 10:00:00 GMT"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CF-Cache-Status", "HIT")
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			// Only the ETag is repeated, as many origins do
+			w.Header().Set("ETag", `"abc"`)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"new"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("<html><body>fresh</body></html>"))
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+
+	ctx := WithValidators(context.Background(), Validators{ETag: `"abc"`, LastModified: lastModified})
+	result, err := c.WarmURL(ctx, ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected 304 to count as a successful warm, got %v", err)
+	}
+	if !result.NotModified {
+		t.Error("Expected result to be flagged not modified")
+	}
+	if result.StatusCode != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", result.StatusCode)
+	}
+	if got := result.Headers.Get("Last-Modified"); got != lastModified {
+		t.Errorf("Expected Last-Modified carried forward, got %q", got)
+	}
+
+	ctx = WithValidators(context.Background(), Validators{ETag: `"old"`})
+	result, err = c.WarmURL(ctx, ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected changed page to warm, got %v", err)
+	}
+	if result.NotModified {
+		t.Error("Expected changed page not to be flagged not modified")
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", result.StatusCode)
+	}
+}
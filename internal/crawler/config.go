@@ -12,7 +12,6 @@ type Config struct {
 	UserAgent      string        // User agent string for requests
 	RetryAttempts  int           // Number of retry attempts for failed requests
 	RetryDelay     time.Duration // Delay between retry attempts
-	SkipCachedURLs bool          // Whether to skip URLs that are already cached (HIT)
 	Port           string        // Server port
 	Env            string        // Environment (development/production)
 	LogLevel       string        // Logging level
@@ -21,8 +20,29 @@ type Config struct {
 	SentryDSN      string        // Sentry DSN for error tracking
 	FindLinks      bool          // Whether to extract links (e.g. PDFs/docs) from pages
 	SkipSSRFCheck  bool          // Skip SSRF protection (for tests only, never enable in production)
+	MaxSitemapSize int64         // Maximum decompressed sitemap size in bytes (0 = DefaultMaxSitemapSize)
+	MaxSitemapURLs int           // URLs kept from a single sitemap file, the rest dropped (0 = DefaultMaxSitemapURLs)
+	MaxRedirects   int           // Redirects followed before failing with ErrTooManyRedirects (0 = DefaultMaxRedirects)
+
+	// BodySampleBytes is how much of each page tech detection sees unless the
+	// job asks for the full body (0 = DefaultBodySampleBytes)
+	BodySampleBytes int
+
+	// Connection limits on warming requests (0 = the Default* values)
+	DialTimeout           time.Duration // DNS resolution plus TCP connect
+	TLSHandshakeTimeout   time.Duration // TLS handshake once connected
+	ResponseHeaderTimeout time.Duration // Wait for response headers after the request is sent; 0 leaves it to the request deadline
 }
 
+// DefaultMaxSitemapSize caps a decompressed sitemap. The sitemap protocol
+// allows 50MB uncompressed, so this leaves headroom while stopping gzip bombs.
+const DefaultMaxSitemapSize int64 = 64 << 20
+
+// DefaultMaxSitemapURLs caps the URLs taken from one sitemap file. The protocol
+// allows 50,000, so this tolerates sites slightly over it while stopping a
+// single file from listing millions.
+const DefaultMaxSitemapURLs = 100_000
+
 // DefaultConfig returns a Config instance with default values
 func DefaultConfig() *Config {
 	return &Config{
@@ -32,7 +52,38 @@ func DefaultConfig() *Config {
 		UserAgent:      "BlueBandedBee/1.0 (+https://www.bluebandedbee.co/pages/about-the-bot)",
 		RetryAttempts:  3,
 		RetryDelay:     500 * time.Millisecond,
-		SkipCachedURLs: false, // Default to crawling all URLs
 		FindLinks:      false,
+		MaxSitemapSize: DefaultMaxSitemapSize,
+		MaxSitemapURLs: DefaultMaxSitemapURLs,
+		MaxRedirects:   DefaultMaxRedirects,
+
+		BodySampleBytes: DefaultBodySampleBytes,
+
+		DialTimeout:         DefaultDialTimeout,
+		TLSHandshakeTimeout: DefaultTLSHandshakeTimeout,
+	}
+}
+
+// bodySampleBytes returns the configured body sample size, or the default
+func (c *Crawler) bodySampleBytes() int {
+	if c.config != nil && c.config.BodySampleBytes > 0 {
+		return c.config.BodySampleBytes
+	}
+	return DefaultBodySampleBytes
+}
+
+// maxSitemapSize returns the configured sitemap size cap, or the default
+func (c *Crawler) maxSitemapSize() int64 {
+	if c.config != nil && c.config.MaxSitemapSize > 0 {
+		return c.config.MaxSitemapSize
+	}
+	return DefaultMaxSitemapSize
+}
+
+// maxSitemapURLs returns the configured per-file sitemap URL cap, or the default
+func (c *Crawler) maxSitemapURLs() int {
+	if c.config != nil && c.config.MaxSitemapURLs > 0 {
+		return c.config.MaxSitemapURLs
 	}
+	return DefaultMaxSitemapURLs
 }
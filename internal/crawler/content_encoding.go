@@ -0,0 +1,30 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// maxDecodedBodySize caps a decompressed page body, matching colly's default
+// MaxBodySize so a small compressed response can't expand without bound
+const maxDecodedBodySize = 10 * 1024 * 1024
+
+// decodeResponseBody undoes content encodings colly leaves in place. colly
+// only handles gzip itself, so brotli bodies arrive still compressed. Bodies
+// with no encoding, gzip (already decoded) or an unknown encoding are returned
+// unchanged, and the bool reports whether the body was decompressed.
+func decodeResponseBody(contentEncoding string, body []byte) ([]byte, bool, error) {
+	if !strings.EqualFold(strings.TrimSpace(contentEncoding), "br") || len(body) == 0 {
+		return body, false, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(brotli.NewReader(bytes.NewReader(body)), maxDecodedBodySize))
+	if err != nil {
+		return body, false, fmt.Errorf("failed to decode brotli body: %w", err)
+	}
+	return data, true, nil
+}
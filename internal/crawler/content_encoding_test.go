@@ -0,0 +1,63 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestWarmURLDecodesBrotliBody(t *testing.T) {
+	html := []byte(`<html><head><meta name="generator" content="WordPress 6.5"></head><body><a href="/about">About</a></body></html>`)
+
+	var compressed bytes.Buffer
+	bw := brotli.NewWriter(&compressed)
+	if _, err := bw.Write(html); err != nil {
+		t.Fatal(err)
+	}
+	if err := bw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "br")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer ts.Close()
+
+	result, err := New(testConfig()).WarmURL(context.Background(), ts.URL, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(result.Body, html) {
+		t.Fatalf("Expected decompressed body, got %q", result.Body)
+	}
+	if !bytes.Equal(result.BodySample, html) {
+		t.Fatalf("Expected decompressed body sample, got %q", result.BodySample)
+	}
+	if result.ContentLength != int64(len(html)) {
+		t.Fatalf("Expected content length %d, got %d", len(html), result.ContentLength)
+	}
+	if len(result.Links["body"]) == 0 {
+		t.Fatalf("Expected links extracted from decompressed HTML, got %v", result.Links)
+	}
+}
+
+func TestDecodeResponseBodyFallback(t *testing.T) {
+	raw := []byte("not brotli")
+
+	body, decoded, err := decodeResponseBody("br", raw)
+	if err == nil || decoded || !bytes.Equal(body, raw) {
+		t.Fatalf("Expected invalid brotli to keep the raw body with an error, got %q, %v, %v", body, decoded, err)
+	}
+
+	body, decoded, err = decodeResponseBody("zstd", raw)
+	if err != nil || decoded || !bytes.Equal(body, raw) {
+		t.Fatalf("Expected unknown encoding to pass through unchanged, got %q, %v, %v", body, decoded, err)
+	}
+}
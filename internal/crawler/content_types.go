@@ -0,0 +1,110 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// ErrContentTypeNotAllowed is returned by WarmURL when the response's
+// Content-Type isn't on the job's allow list. The body transfer is aborted
+// once the headers arrive, so large binaries are never downloaded.
+var ErrContentTypeNotAllowed = errors.New("content type not allowed")
+
+type contentTypesKey struct{}
+
+// WithContentTypes limits WarmURL to responses whose media type is in types.
+// Entries are media types such as "text/html", "image/*" for a whole family,
+// or "*/*" for anything. An empty list leaves every response warmed.
+func WithContentTypes(ctx context.Context, types []string) context.Context {
+	if len(types) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, contentTypesKey{}, types)
+}
+
+func contentTypesFromContext(ctx context.Context) []string {
+	types, _ := ctx.Value(contentTypesKey{}).([]string)
+	return types
+}
+
+// contentTypeAllowed reports whether a Content-Type header value matches the
+// allow list. Responses without a Content-Type can't be judged, so they're
+// allowed.
+func contentTypeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 || strings.TrimSpace(contentType) == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	family, _, _ := strings.Cut(mediaType, "/")
+
+	for _, pattern := range allowed {
+		switch {
+		case pattern == "*/*":
+			return true
+		case strings.HasSuffix(pattern, "/*"):
+			if strings.TrimSuffix(pattern, "/*") == family {
+				return true
+			}
+		case pattern == mediaType:
+			return true
+		}
+	}
+	return false
+}
+
+// rejectedResponse records the headers of a response aborted by the
+// content type filter, since Colly's error path doesn't carry them
+type rejectedResponse struct {
+	statusCode  int
+	contentType string
+	headers     http.Header
+}
+
+// setupContentTypeFilter aborts successful responses whose Content-Type isn't
+// allowed as soon as their headers arrive. Error responses are left to the
+// usual status handling. Returns nil when there's no allow list.
+func setupContentTypeFilter(collyClone *colly.Collector, allowed []string, cacheable []int) *rejectedResponse {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	rejected := &rejectedResponse{}
+	collyClone.OnResponseHeaders(func(r *colly.Response) {
+		if !isSuccessStatus(r.StatusCode, cacheable) {
+			return
+		}
+		contentType := r.Headers.Get("Content-Type")
+		if contentTypeAllowed(contentType, allowed) {
+			return
+		}
+
+		rejected.statusCode = r.StatusCode
+		rejected.contentType = contentType
+		rejected.headers = r.Headers.Clone()
+		r.Request.Abort()
+	})
+	return rejected
+}
+
+// apply copies the rejected response onto the result and returns
+// ErrContentTypeNotAllowed, or nil if no response was rejected
+func (rejected *rejectedResponse) apply(res *CrawlResult) error {
+	if rejected == nil || rejected.headers == nil {
+		return nil
+	}
+	res.StatusCode = rejected.statusCode
+	res.ContentType = rejected.contentType
+	res.Headers = rejected.headers
+	res.Error = ErrContentTypeNotAllowed.Error()
+	return fmt.Errorf("%w: %s", ErrContentTypeNotAllowed, rejected.contentType)
+}
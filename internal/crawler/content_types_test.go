@@ -0,0 +1,77 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContentTypeAllowed(t *testing.T) {
+	html := []string{"text/html", "application/xhtml+xml"}
+
+	tests := []struct {
+		name        string
+		contentType string
+		allowed     []string
+		want        bool
+	}{
+		{"html with charset", "text/html; charset=utf-8", html, true},
+		{"upper case", "TEXT/HTML", html, true},
+		{"xhtml", "application/xhtml+xml", html, true},
+		{"pdf", "application/pdf", html, false},
+		{"image", "image/png", html, false},
+		{"missing header", "", html, true},
+		{"no allow list", "application/pdf", nil, true},
+		{"family wildcard", "image/webp", []string{"image/*"}, true},
+		{"family wildcard other family", "text/html", []string{"image/*"}, false},
+		{"match anything", "application/pdf", []string{"*/*"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentTypeAllowed(tt.contentType, tt.allowed); got != tt.want {
+				t.Errorf("contentTypeAllowed(%q) = %v, want %v", tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarmURLSkipsDisallowedContentTypes(t *testing.T) {
+	largeBody := strings.Repeat("x", 1<<20)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/report.pdf" {
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte(largeBody))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+	ctx := WithContentTypes(context.Background(), []string{"text/html"})
+
+	result, err := c.WarmURL(ctx, ts.URL+"/report.pdf", false)
+	if !errors.Is(err, ErrContentTypeNotAllowed) {
+		t.Fatalf("Expected ErrContentTypeNotAllowed, got %v", err)
+	}
+	if result.ContentType != "application/pdf" {
+		t.Errorf("Expected content type application/pdf, got %q", result.ContentType)
+	}
+	if len(result.Body) != 0 {
+		t.Errorf("Expected body transfer to be aborted, got %d bytes", len(result.Body))
+	}
+
+	if _, err := c.WarmURL(ctx, ts.URL+"/page", false); err != nil {
+		t.Errorf("Expected HTML page to warm, got %v", err)
+	}
+
+	// Without an allow list every response is warmed
+	if _, err := c.WarmURL(context.Background(), ts.URL+"/report.pdf", false); err != nil {
+		t.Errorf("Expected PDF to warm without an allow list, got %v", err)
+	}
+}
@@ -227,21 +227,15 @@ func New(config *Config, id ...string) *Crawler {
 	// Create metrics map for this crawler instance
 	metricsMap := &sync.Map{}
 
-	// Set up base transport with SSRF-safe dialer
-	baseTransport := &http.Transport{
-		MaxIdleConnsPerHost: 25,
-		MaxConnsPerHost:     50,
-		IdleConnTimeout:     120 * time.Second,
-		TLSHandshakeTimeout: 10 * time.Second,
-		DisableCompression:  true,
-		ForceAttemptHTTP2:   true,
-	}
-
-	// Add SSRF-safe DialContext if protection is enabled
-	// This validates IPs at connection time to prevent DNS rebinding attacks
-	if !config.SkipSSRFCheck {
-		baseTransport.DialContext = ssrfSafeDialContext()
-	}
+	// Set up base transport with connection timeouts and, unless disabled, an
+	// SSRF-safe dialer that validates IPs at connection time to prevent DNS
+	// rebinding attacks
+	baseTransport := newTransport(config)
+	baseTransport.MaxIdleConnsPerHost = 25
+	baseTransport.MaxConnsPerHost = 50
+	baseTransport.IdleConnTimeout = 120 * time.Second
+	baseTransport.DisableCompression = true
+	baseTransport.ForceAttemptHTTP2 = true
 
 	// Wrap the base transport with our custom tracing transport
 	tracingTransport := &tracingRoundTripper{
@@ -249,10 +243,18 @@ func New(config *Config, id ...string) *Crawler {
 		metricsMap: metricsMap,
 	}
 
-	// Set HTTP client with tracing transport and proper timeout
+	// Set HTTP client with tracing transport. Warming requests take their
+	// deadline from the request context (DefaultTimeout unless overridden via
+	// WithRequestTimeout) since the client is shared by every collector clone.
+	// Redirects are capped at MaxRedirects and to another host drop any
+	// per-job credentials.
+	maxRedirects := config.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
 	httpClient := &http.Client{
-		Timeout:   config.DefaultTimeout,
-		Transport: tracingTransport,
+		Transport:     tracingTransport,
+		CheckRedirect: checkRedirect(maxRedirects),
 	}
 	c.SetClient(httpClient)
 
@@ -316,16 +318,24 @@ func isPrivateOrLocalIP(ip net.IP) bool {
 // ssrfSafeDialContext returns a DialContext function that validates resolved IPs
 // before connecting, preventing DNS rebinding attacks and SSRF to private networks.
 // It performs DNS resolution, validates all IPs are public, then connects using
-// the validated IP (preferring IPv4 for compatibility).
-func ssrfSafeDialContext() func(ctx context.Context, network, addr string) (net.Conn, error) {
+// the validated IP (preferring IPv4 for compatibility). timeout bounds the DNS
+// lookup and connect together.
+func ssrfSafeDialContext(timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
 	return func(ctx context.Context, network, addr string) (net.Conn, error) {
 		host, port, err := net.SplitHostPort(addr)
 		if err != nil {
 			return nil, err
 		}
 
+		// Only the dial is bounded; the connection outlives this context
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
 		// Resolve hostname and validate all IPs
-		ips, err := net.LookupIP(host)
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
 		if err != nil {
 			return nil, fmt.Errorf("DNS lookup failed: %w", err)
 		}
@@ -358,6 +368,18 @@ func ssrfSafeDialContext() func(ctx context.Context, network, addr string) (net.
 	}
 }
 
+// SSRFSafeDialContext returns the crawler's SSRF-checked dialer for other
+// outbound clients that send requests to user-supplied URLs, such as webhooks
+func SSRFSafeDialContext(timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return ssrfSafeDialContext(timeout)
+}
+
+// IsPrivateOrLocalIP reports whether ip is loopback, link-local, private or
+// unspecified, i.e. an address the SSRF check refuses to dial
+func IsPrivateOrLocalIP(ip net.IP) bool {
+	return isPrivateOrLocalIP(ip)
+}
+
 // validateCrawlRequest validates the crawl request parameters and URL format.
 // Note: SSRF protection is handled at connection time by ssrfSafeDialContext(),
 // which prevents DNS rebinding attacks by validating IPs after resolution.
@@ -395,6 +417,17 @@ func (c *Crawler) setupResponseHandlers(collyClone *colly.Collector, result *Cra
 			result.Performance = *performanceMetrics
 		}
 
+		// Decompress brotli bodies before anything reads them, including the
+		// OnHTML link extraction that runs after this handler
+		if body, decoded, err := decodeResponseBody(r.Headers.Get("Content-Encoding"), r.Body); err != nil {
+			log.Warn().
+				Err(err).
+				Str("url", r.Request.URL.String()).
+				Msg("Failed to decompress response body, keeping raw bytes")
+		} else if decoded {
+			r.Body = body
+		}
+
 		// Calculate response time
 		result.ResponseTime = time.Since(startTime).Milliseconds()
 		result.StatusCode = r.StatusCode
@@ -402,12 +435,13 @@ func (c *Crawler) setupResponseHandlers(collyClone *colly.Collector, result *Cra
 		result.ContentLength = int64(len(r.Body))
 		result.Headers = r.Headers.Clone()
 		result.RedirectURL = r.Request.URL.String()
+		result.RetryAfter = retryAfterFromResponse(r.StatusCode, r.Headers)
 
 		// Store body for tech detection and storage upload
 		// BodySample is truncated for wappalyzer detection, Body is the full content
 		result.Body = r.Body
-		if len(r.Body) > MaxBodySampleSize {
-			result.BodySample = r.Body[:MaxBodySampleSize]
+		if sampleBytes := c.bodySampleBytes(); len(r.Body) > sampleBytes {
+			result.BodySample = r.Body[:sampleBytes]
 		} else {
 			result.BodySample = r.Body
 		}
@@ -430,38 +464,20 @@ func (c *Crawler) setupResponseHandlers(collyClone *colly.Collector, result *Cra
 			Msg("Cloudflare headers analysis")
 
 		// Check for cache status headers from different CDNs
-		// Normalise all values to standard HIT/MISS/BYPASS format
-		// Cloudflare
-		if cacheStatus := r.Headers.Get("CF-Cache-Status"); cacheStatus != "" {
-			result.CacheStatus = normaliseCacheStatus(cacheStatus)
-		}
-		// CloudFront/Fastly (X-Cache: "Miss from cloudfront", "HIT", etc.)
-		if cacheStatus := r.Headers.Get("X-Cache"); cacheStatus != "" && result.CacheStatus == "" {
-			result.CacheStatus = normaliseCacheStatus(cacheStatus)
-		}
-		// Akamai (X-Cache-Remote: "TCP_HIT", "TCP_MISS", etc.)
-		if cacheStatus := r.Headers.Get("X-Cache-Remote"); cacheStatus != "" && result.CacheStatus == "" {
-			result.CacheStatus = normaliseCacheStatus(cacheStatus)
-		}
-		// Vercel
-		if cacheStatus := r.Headers.Get("x-vercel-cache"); cacheStatus != "" && result.CacheStatus == "" {
-			result.CacheStatus = normaliseCacheStatus(cacheStatus)
-		}
-		// Standard Cache-Status header (newer standardised approach)
-		if cacheStatus := r.Headers.Get("Cache-Status"); cacheStatus != "" && result.CacheStatus == "" {
-			result.CacheStatus = normaliseCacheStatus(cacheStatus)
-		}
-		// Varnish (the presence of X-Varnish indicates it was processed by Varnish)
-		if varnishID := r.Headers.Get("X-Varnish"); varnishID != "" && result.CacheStatus == "" {
-			if strings.Contains(varnishID, " ") {
-				result.CacheStatus = "HIT" // Multiple IDs indicate a cache hit
-			} else {
-				result.CacheStatus = "MISS" // Single ID indicates a cache miss
-			}
+		// Normalise all values to standard HIT/MISS/BYPASS format, keeping the
+		// origin-level cache (if any) separate from the edge
+		result.CacheStatus, result.OriginCacheStatus = detectCacheLayers(*r.Headers)
+
+		// A 304 to our own conditional request is a successful warm
+		if validators, ok := r.Ctx.GetAny("validators").(Validators); ok && r.StatusCode == http.StatusNotModified {
+			result.NotModified = true
+			carryForwardValidators(result.Headers, validators)
 		}
 
-		// Set error for non-2xx status codes (to match test expectations)
-		if r.StatusCode < 200 || r.StatusCode >= 300 {
+		// Set error for non-2xx status codes (to match test expectations),
+		// unless the job treats this error page as cacheable
+		cacheable, _ := r.Ctx.GetAny("cacheable_status_codes").([]int)
+		if !result.NotModified && !isSuccessStatus(r.StatusCode, cacheable) {
 			result.Error = fmt.Sprintf("non-success status code: %d", r.StatusCode)
 		}
 	})
@@ -477,6 +493,7 @@ func (c *Crawler) setupResponseHandlers(collyClone *colly.Collector, result *Cra
 		startTime := r.Ctx.GetAny("start_time").(time.Time)
 		result.ResponseTime = time.Since(startTime).Milliseconds()
 		result.StatusCode = r.StatusCode
+		result.RetryAfter = retryAfterFromResponse(r.StatusCode, r.Headers)
 
 		log.Debug().
 			Err(err).
@@ -497,6 +514,29 @@ func (c *Crawler) performCacheValidation(ctx context.Context, targetURL string,
 		return nil
 	}
 
+	if !secondRequestEnabled(ctx) {
+		log.Debug().
+			Str("url", targetURL).
+			Str("cache_status", res.CacheStatus).
+			Msg("Skipping cache validation - second request disabled for job")
+		return nil
+	}
+
+	// Move into the verification phase's own concurrency slot when the caller
+	// has configured one. Stripped from ctx so the second WarmURL doesn't re-enter.
+	if gate := verificationGateFromContext(ctx); gate != nil {
+		ctx = WithVerificationGate(ctx, nil)
+		release, err := gate(ctx)
+		if err != nil {
+			log.Debug().
+				Err(err).
+				Str("url", targetURL).
+				Msg("Skipping cache validation - verification slot unavailable")
+			return nil
+		}
+		defer release()
+	}
+
 	// Apply randomized delay between 500-1000ms to avoid hammering origins
 	randomInt := 0
 	if n, err := crand.Int(crand.Reader, big.NewInt(501)); err == nil {
@@ -707,13 +747,18 @@ func setupLinkExtraction(collyClone *colly.Collector) {
 }
 
 // executeCollyRequest performs the HTTP request using Colly with context cancellation support
-func executeCollyRequest(ctx context.Context, collyClone *colly.Collector, targetURL string, res *CrawlResult) error {
+func executeCollyRequest(ctx context.Context, collyClone *colly.Collector, method string, targetURL string, res *CrawlResult) error {
 	// Set up context cancellation handling
 	done := make(chan error, 1)
 
 	// Visit the URL with Colly in a goroutine to support context cancellation
 	go func() {
-		visitErr := collyClone.Visit(targetURL)
+		var visitErr error
+		if method == http.MethodHead {
+			visitErr = collyClone.Head(targetURL)
+		} else {
+			visitErr = collyClone.Visit(targetURL)
+		}
 		if visitErr != nil {
 			done <- visitErr
 			return
@@ -724,8 +769,8 @@ func executeCollyRequest(ctx context.Context, collyClone *colly.Collector, targe
 	}()
 
 	// Wait for either completion or context cancellation
-	// Note: HTTP client timeout (DefaultTimeout) enforces request-level timeout
-	// Context timeout enforces overall task timeout
+	// Note: the context carries both the request-level timeout and the
+	// overall task timeout
 	select {
 	case err := <-done:
 		if err != nil {
@@ -748,7 +793,9 @@ func executeCollyRequest(ctx context.Context, collyClone *colly.Collector, targe
 }
 
 // WarmURL performs a crawl of the specified URL and returns the result.
-// It respects context cancellation, enforces timeout, and treats non-2xx statuses as errors.
+// It respects context cancellation, enforces timeout, and treats non-2xx statuses as errors
+// unless they're listed via WithCacheableStatusCodes. Responses whose type isn't allowed via
+// WithContentTypes return ErrContentTypeNotAllowed.
 func (c *Crawler) WarmURL(ctx context.Context, targetURL string, findLinks bool) (*CrawlResult, error) {
 	// Validate the crawl request (with SSRF protection unless skipped for tests)
 	_, err := validateCrawlRequest(ctx, targetURL, c.config.SkipSSRFCheck)
@@ -758,6 +805,12 @@ func (c *Crawler) WarmURL(ctx context.Context, targetURL string, findLinks bool)
 		return res, err
 	}
 
+	// HEAD responses have no body, so there are no links to find
+	method := methodFromContext(ctx)
+	if method == http.MethodHead {
+		findLinks = false
+	}
+
 	start := time.Now()
 	res := &CrawlResult{
 		URL:       targetURL,
@@ -767,12 +820,31 @@ func (c *Crawler) WarmURL(ctx context.Context, targetURL string, findLinks bool)
 
 	log.Debug().
 		Str("url", targetURL).
+		Str("method", method).
 		Bool("find_links", findLinks).
 		Msg("Starting URL warming with Colly")
 
 	// Use Colly for everything - single request handles cache warming and link extraction
 	collyClone := c.colly.Clone()
 
+	// Per-job agents are sent verbatim (no worker suffix) so WAF allow-lists match
+	if userAgent := userAgentFromContext(ctx); userAgent != "" {
+		collyClone.UserAgent = userAgent
+	}
+
+	// Cacheable error pages need OnResponse (headers, cache status) rather
+	// than Colly's error path
+	cacheable := cacheableStatusCodesFromContext(ctx)
+	if len(cacheable) > 0 {
+		collyClone.ParseHTTPErrorResponse = true
+	}
+
+	// Conditional warms need OnResponse for 304s too
+	validators := validatorsFromContext(ctx)
+	if !validators.IsZero() {
+		collyClone.ParseHTTPErrorResponse = true
+	}
+
 	// Set up link extraction
 	setupLinkExtraction(collyClone)
 
@@ -781,19 +853,46 @@ func (c *Crawler) WarmURL(ctx context.Context, targetURL string, findLinks bool)
 		r.Ctx.Put("result", res)
 		r.Ctx.Put("start_time", start)
 		r.Ctx.Put("find_links", findLinks)
+		r.Ctx.Put("cacheable_status_codes", cacheable)
+		setCustomHeaders(ctx, r.Headers)
+		// Only set on the first request; the client drops it on cross-host redirects
+		setAuthorization(ctx, r.Headers)
+		if !validators.IsZero() {
+			setConditionalHeaders(r.Headers, validators)
+			r.Ctx.Put("validators", validators)
+		}
 	})
 
 	// Set up response and error handlers
 	c.setupResponseHandlers(collyClone, res, start, targetURL)
 
+	// Abort non-page responses (PDFs, images, feeds) before their bodies download
+	rejected := setupContentTypeFilter(collyClone, contentTypesFromContext(ctx), cacheable)
+
+	// Bound the request itself; the clone carries the deadline into Colly's
+	// HTTP request so a timed-out request is abandoned rather than left running
+	requestCtx, cancel := context.WithTimeout(ctx, c.requestTimeout(ctx))
+	defer cancel()
+	requestCtx, chain := withRedirectChain(requestCtx)
+	collyClone.Context = requestCtx
+
 	// Execute the HTTP request
-	if err := executeCollyRequest(ctx, collyClone, targetURL, res); err != nil {
+	err = executeCollyRequest(requestCtx, collyClone, method, targetURL, res)
+	res.RedirectChain = chain.from(targetURL)
+	if rejectErr := rejected.apply(res); rejectErr != nil {
+		log.Debug().
+			Str("url", targetURL).
+			Str("content_type", res.ContentType).
+			Msg("Skipping URL warming - content type not allowed")
+		return res, rejectErr
+	}
+	if err != nil {
 		return res, err
 	}
 
 	// Log results and return error if needed
 	if res.Error != "" {
-		if res.StatusCode < 200 || res.StatusCode >= 300 {
+		if !res.NotModified && !isSuccessStatus(res.StatusCode, cacheable) {
 			log.Debug().
 				Int("status", res.StatusCode).
 				Str("url", targetURL).
@@ -843,23 +942,19 @@ func (c *Crawler) CheckCacheStatus(ctx context.Context, targetURL string) (strin
 		return "", err
 	}
 
-	req.Header.Set("User-Agent", c.config.UserAgent)
+	// Match the warming request so caches keyed on custom headers report the same entry
+	setCustomHeaders(ctx, &req.Header)
+	req.Header.Set("User-Agent", c.userAgent(ctx))
+	setAuthorization(ctx, &req.Header)
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
 	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
 
 	// Use SSRF-safe transport if protection is enabled
-	transport := &http.Transport{
-		TLSHandshakeTimeout: 10 * time.Second,
-	}
-
-	if !c.config.SkipSSRFCheck {
-		transport.DialContext = ssrfSafeDialContext()
-	}
-
 	client := &http.Client{
-		Timeout:   c.config.DefaultTimeout,
-		Transport: transport,
+		Timeout:       c.config.DefaultTimeout,
+		Transport:     newTransport(c.config),
+		CheckRedirect: checkRedirect(c.maxRedirects()),
 	}
 
 	resp, err := client.Do(req)
@@ -877,19 +972,13 @@ func (c *Crawler) CreateHTTPClient(timeout time.Duration) *http.Client {
 		timeout = c.config.DefaultTimeout
 	}
 
-	transport := &http.Transport{
-		MaxIdleConnsPerHost: 25,
-		MaxConnsPerHost:     50,
-		IdleConnTimeout:     120 * time.Second,
-		TLSHandshakeTimeout: 10 * time.Second,
-		DisableCompression:  true,
-		ForceAttemptHTTP2:   true,
-	}
-
-	// Add SSRF-safe DialContext if protection is enabled
-	if !c.config.SkipSSRFCheck {
-		transport.DialContext = ssrfSafeDialContext()
-	}
+	// Connection timeouts and SSRF protection come from the crawler config
+	transport := newTransport(c.config)
+	transport.MaxIdleConnsPerHost = 25
+	transport.MaxConnsPerHost = 50
+	transport.IdleConnTimeout = 120 * time.Second
+	transport.DisableCompression = true
+	transport.ForceAttemptHTTP2 = true
 
 	return &http.Client{
 		Timeout:   timeout,
@@ -375,7 +375,7 @@ func TestSSRFProtection(t *testing.T) {
 	ctx := context.Background()
 
 	// Get the SSRF-safe dialer
-	dialFunc := ssrfSafeDialContext()
+	dialFunc := ssrfSafeDialContext(DefaultDialTimeout)
 
 	// Test blocking localhost - dial should fail for private IPs
 	_, err := dialFunc(ctx, "tcp", "127.0.0.1:80")
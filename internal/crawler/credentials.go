@@ -0,0 +1,66 @@
+package crawler
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// Credentials authenticate requests to a protected site, e.g. a staging
+// environment behind basic auth. A bearer token takes precedence over
+// username and password.
+type Credentials struct {
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	BearerToken string `json:"bearer_token,omitempty"`
+}
+
+// IsZero reports whether there's nothing to authenticate with
+func (c Credentials) IsZero() bool {
+	return c.Username == "" && c.Password == "" && c.BearerToken == ""
+}
+
+// authorization returns the Authorization header value, or "" when unset
+func (c Credentials) authorization() string {
+	if c.BearerToken != "" {
+		return "Bearer " + c.BearerToken
+	}
+	if c.Username != "" || c.Password != "" {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password))
+	}
+	return ""
+}
+
+type credentialsKey struct{}
+
+// WithCredentials makes WarmURL, robots.txt and sitemap fetches made with the
+// returned context send an Authorization header. Redirects to another host
+// drop the header so credentials never leave the site they were given for.
+// Zero credentials leave ctx unchanged.
+func WithCredentials(ctx context.Context, creds Credentials) context.Context {
+	if creds.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, credentialsKey{}, creds)
+}
+
+func credentialsFromContext(ctx context.Context) Credentials {
+	creds, _ := ctx.Value(credentialsKey{}).(Credentials)
+	return creds
+}
+
+// setAuthorization adds the context's credentials to an outgoing request
+func setAuthorization(ctx context.Context, headers *http.Header) {
+	if auth := credentialsFromContext(ctx).authorization(); auth != "" {
+		headers.Set("Authorization", auth)
+	}
+}
+
+// dropCrossHostAuthorization removes credentials from a redirect that leaves
+// the host of the original request
+func dropCrossHostAuthorization(req *http.Request, via []*http.Request) {
+	if len(via) > 0 && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		req.Header.Del("Authorization")
+	}
+}
@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWarmURLSendsCredentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "staging" || pass != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	crawler := New(testConfig())
+
+	if _, err := crawler.WarmURL(context.Background(), ts.URL, false); err == nil {
+		t.Fatal("Expected 401 without credentials")
+	}
+
+	ctx := WithCredentials(context.Background(), Credentials{Username: "staging", Password: "s3cret"})
+	result, err := crawler.WarmURL(ctx, ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected no error with credentials, got %v", err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", result.StatusCode)
+	}
+}
+
+func TestWarmURLDropsCredentialsOnCrossHostRedirect(t *testing.T) {
+	var mu sync.Mutex
+	var leaked []string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		leaked = append(leaked, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Redirect(w, r, other.URL+"/landing", http.StatusFound)
+	}))
+	defer origin.Close()
+
+	ctx := WithCredentials(context.Background(), Credentials{BearerToken: "token-123"})
+	if _, err := New(testConfig()).WarmURL(ctx, origin.URL, false); err != nil {
+		t.Fatalf("Expected redirect to be followed, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(leaked) == 0 {
+		t.Fatal("Expected the redirect target to be requested")
+	}
+	for _, auth := range leaked {
+		if auth != "" {
+			t.Fatalf("Expected no Authorization header on the other host, got %q", auth)
+		}
+	}
+}
+
+func TestParseRobotsTxtSendsCredentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+	}))
+	defer ts.Close()
+
+	ctx := WithCredentials(context.Background(), Credentials{BearerToken: "token-123"})
+	rules, err := ParseRobotsTxt(ctx, ts.URL, "TestBot/1.0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rules.DisallowPatterns) != 1 || rules.DisallowPatterns[0] != "/private" {
+		t.Fatalf("Expected robots rules from the protected robots.txt, got %v", rules.DisallowPatterns)
+	}
+}
+
+func TestCredentialsAuthorization(t *testing.T) {
+	if got := (Credentials{}).authorization(); got != "" {
+		t.Fatalf("Expected no header for zero credentials, got %q", got)
+	}
+	if got := (Credentials{Username: "a", Password: "b"}).authorization(); got != "Basic YTpi" {
+		t.Fatalf("Expected basic auth header, got %q", got)
+	}
+	if got := (Credentials{Username: "a", Password: "b", BearerToken: "t"}).authorization(); got != "Bearer t" {
+		t.Fatalf("Expected bearer token to take precedence, got %q", got)
+	}
+	if ctx := WithCredentials(context.Background(), Credentials{}); ctx != context.Background() {
+		t.Fatal("Expected zero credentials to leave the context unchanged")
+	}
+}
@@ -0,0 +1,31 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+)
+
+type customHeadersKey struct{}
+
+// WithCustomHeaders makes WarmURL and its cache checks send extra request
+// headers, e.g. to tag warming traffic for analytics exclusion. Callers
+// validate the headers; an empty map leaves ctx unchanged.
+func WithCustomHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, customHeadersKey{}, headers)
+}
+
+func customHeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(customHeadersKey{}).(map[string]string)
+	return headers
+}
+
+// setCustomHeaders adds the context's custom headers to an outgoing request.
+// Call it before setting crawler-managed headers so those always win.
+func setCustomHeaders(ctx context.Context, headers *http.Header) {
+	for name, value := range customHeadersFromContext(ctx) {
+		headers.Set(name, value)
+	}
+}
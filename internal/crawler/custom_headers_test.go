@@ -0,0 +1,65 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWarmURLSendsCustomHeaders(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		first := len(requests) == 1
+		mu.Unlock()
+		// Miss on the first request so the cache check and second request run
+		if first {
+			w.Header().Set("CF-Cache-Status", "MISS")
+		} else {
+			w.Header().Set("CF-Cache-Status", "HIT")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+	ctx := WithCustomHeaders(context.Background(), map[string]string{
+		"X-Warm-Request":   "blue-banded-bee",
+		"X-Forwarded-Host": "www.example.com",
+	})
+
+	if _, err := c.WarmURL(ctx, ts.URL, false); err != nil {
+		t.Fatalf("Expected warm to succeed, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 3 {
+		t.Fatalf("Expected first request, cache check and second request, got %d requests", len(requests))
+	}
+	for i, r := range requests {
+		if got := r.Header.Get("X-Warm-Request"); got != "blue-banded-bee" {
+			t.Errorf("Request %d (%s): expected X-Warm-Request header, got %q", i+1, r.Method, got)
+		}
+		if got := r.Header.Get("X-Forwarded-Host"); got != "www.example.com" {
+			t.Errorf("Request %d (%s): expected X-Forwarded-Host header, got %q", i+1, r.Method, got)
+		}
+		if !strings.HasPrefix(r.UserAgent(), c.GetUserAgent()) {
+			t.Errorf("Request %d (%s): expected crawler user agent, got %q", i+1, r.Method, r.UserAgent())
+		}
+	}
+}
+
+func TestWithCustomHeadersEmptyLeavesContext(t *testing.T) {
+	ctx := context.Background()
+	if WithCustomHeaders(ctx, nil) != ctx {
+		t.Error("Expected empty custom headers to leave ctx unchanged")
+	}
+}
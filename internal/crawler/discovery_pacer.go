@@ -0,0 +1,52 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DiscoveryPacer spaces out the requests sitemap discovery makes to a domain:
+// robots.txt, the common sitemap location checks and every sitemap shard.
+type DiscoveryPacer interface {
+	// Wait blocks until the next request may be sent. done must be called
+	// with the response status, or 0 when there was no response, and any
+	// Retry-After the server sent.
+	Wait(ctx context.Context) (done func(statusCode int, retryAfter time.Duration), err error)
+	// SetCrawlDelay applies robots.txt's Crawl-delay to the requests after it
+	SetCrawlDelay(seconds int)
+}
+
+type discoveryPacerKey struct{}
+
+// WithDiscoveryPacer makes discovery wait on pacer before each request, so
+// sites behind aggressive rate limiting aren't hit with a burst of sitemap
+// fetches before warming even starts
+func WithDiscoveryPacer(ctx context.Context, pacer DiscoveryPacer) context.Context {
+	return context.WithValue(ctx, discoveryPacerKey{}, pacer)
+}
+
+func discoveryPacerFromContext(ctx context.Context) DiscoveryPacer {
+	pacer, _ := ctx.Value(discoveryPacerKey{}).(DiscoveryPacer)
+	return pacer
+}
+
+// paceDiscovery waits for the context's pacer, if any. The returned func
+// reports the response and must always be called.
+func paceDiscovery(ctx context.Context) (func(resp *http.Response), error) {
+	pacer := discoveryPacerFromContext(ctx)
+	if pacer == nil {
+		return func(*http.Response) {}, nil
+	}
+	done, err := pacer.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func(resp *http.Response) {
+		if resp == nil {
+			done(0, 0)
+			return
+		}
+		done(resp.StatusCode, retryAfterFromResponse(resp.StatusCode, &resp.Header))
+	}, nil
+}
@@ -0,0 +1,95 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPacer struct {
+	mu         sync.Mutex
+	waits      int
+	statuses   []int
+	retryAfter []time.Duration
+}
+
+func (p *recordingPacer) Wait(context.Context) (func(int, time.Duration), error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.waits++
+	return func(statusCode int, retryAfter time.Duration) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.statuses = append(p.statuses, statusCode)
+		p.retryAfter = append(p.retryAfter, retryAfter)
+	}, nil
+}
+
+func (p *recordingPacer) SetCrawlDelay(int) {}
+
+func TestParseSitemapWaitsOnDiscoveryPacer(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Children normalise to https, which this plain HTTP server can't serve
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<sitemap><loc>` + server.URL + `/pages.xml</loc></sitemap>
+	<sitemap><loc>` + server.URL + `/posts.xml</loc></sitemap>
+</sitemapindex>`))
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0"}}
+	pacer := &recordingPacer{}
+
+	_, err := c.ParseSitemapWithReport(WithDiscoveryPacer(context.Background(), pacer), server.URL+"/sitemap_index.xml")
+	require.NoError(t, err)
+
+	// One wait per request, each reporting its outcome; 0 is no response
+	assert.Equal(t, 3, pacer.waits)
+	assert.Equal(t, []int{http.StatusOK, 0, 0}, pacer.statuses)
+}
+
+func TestDiscoveryPacerSeesRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0"}}
+	pacer := &recordingPacer{}
+
+	_, err := c.fetchSitemap(WithDiscoveryPacer(context.Background(), pacer), server.URL+"/sitemap.xml", Validators{})
+	require.Error(t, err)
+	assert.Equal(t, []int{http.StatusTooManyRequests}, pacer.statuses)
+	assert.Equal(t, []time.Duration{7 * time.Second}, pacer.retryAfter)
+}
+
+func TestDiscoveryPacerErrorStopsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent despite the pacer failing")
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.fetchSitemap(WithDiscoveryPacer(ctx, cancelledPacer{}), server.URL+"/sitemap.xml", Validators{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type cancelledPacer struct{}
+
+func (cancelledPacer) Wait(ctx context.Context) (func(int, time.Duration), error) {
+	return nil, ctx.Err()
+}
+
+func (cancelledPacer) SetCrawlDelay(int) {}
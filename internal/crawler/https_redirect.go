@@ -0,0 +1,150 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// HTTPS redirect check outcomes
+const (
+	HTTPSRedirectOK       = "redirects_to_https"
+	HTTPSRedirectMissing  = "no_redirect"       // Content served over plain HTTP
+	HTTPSRedirectInsecure = "insecure_redirect" // Redirects, but never reaches HTTPS
+)
+
+// maxHTTPSRedirectHops bounds how far we follow an HTTP redirect chain
+const maxHTTPSRedirectHops = 5
+
+// HTTPSRedirectCheck records whether the HTTP variant of a URL redirects to HTTPS
+type HTTPSRedirectCheck struct {
+	HTTPURL       string   `json:"http_url"`
+	Status        string   `json:"status"`
+	StatusCode    int      `json:"status_code"`
+	FinalURL      string   `json:"final_url,omitempty"`
+	RedirectChain []string `json:"redirect_chain,omitempty"`
+}
+
+// HTTPSRedirectFromResult reads the HTTPS redirect outcome from a crawl of a
+// plain HTTP URL, using the redirect chain the crawl already followed. Returns
+// nil for URLs crawled over HTTPS, which say nothing about their HTTP variant.
+// StatusCode is the final response's, as intermediate codes aren't recorded.
+func HTTPSRedirectFromResult(result *CrawlResult) *HTTPSRedirectCheck {
+	if result == nil {
+		return nil
+	}
+	original := result.URL
+	if len(result.RedirectChain) > 0 {
+		original = result.RedirectChain[0]
+	}
+	parsed, err := url.Parse(original)
+	if err != nil || parsed.Scheme != "http" {
+		return nil
+	}
+
+	check := &HTTPSRedirectCheck{HTTPURL: original, StatusCode: result.StatusCode}
+	if len(result.RedirectChain) < 2 {
+		check.Status = HTTPSRedirectMissing
+		check.FinalURL = original
+		return check
+	}
+
+	for _, hop := range result.RedirectChain[1:] {
+		check.RedirectChain = append(check.RedirectChain, hop)
+		if next, err := url.Parse(hop); err == nil && next.Scheme == "https" {
+			check.Status = HTTPSRedirectOK
+			check.FinalURL = hop
+			return check
+		}
+	}
+	check.Status = HTTPSRedirectInsecure
+	check.FinalURL = check.RedirectChain[len(check.RedirectChain)-1]
+	return check
+}
+
+// CheckHTTPSRedirect requests the plain HTTP variant of targetURL and follows
+// its redirect chain (without leaving HTTP) to see whether it upgrades to HTTPS.
+// Returns an error when the HTTP variant is unreachable, which is not a finding.
+func (c *Crawler) CheckHTTPSRedirect(ctx context.Context, targetURL string) (*HTTPSRedirectCheck, error) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid URL format: %s", targetURL)
+	}
+	parsed.Scheme = "http"
+	parsed.Fragment = ""
+
+	client := c.CreateHTTPClient(0)
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	check := &HTTPSRedirectCheck{HTTPURL: parsed.String()}
+	current := parsed
+
+	for hop := 0; hop <= maxHTTPSRedirectHops; hop++ {
+		resp, err := c.fetchWithoutRedirect(ctx, client, current.String())
+		if err != nil {
+			if hop == 0 {
+				return nil, err
+			}
+			// Upstream of the chain worked but a later hop failed; still insecure
+			check.Status = HTTPSRedirectInsecure
+			check.FinalURL = current.String()
+			return check, nil
+		}
+
+		check.StatusCode = resp.StatusCode
+		location := resp.Header.Get("Location")
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" {
+			check.FinalURL = current.String()
+			if hop == 0 {
+				check.Status = HTTPSRedirectMissing
+			} else {
+				check.Status = HTTPSRedirectInsecure
+			}
+			return check, nil
+		}
+
+		next, err := current.Parse(location)
+		if err != nil {
+			check.Status = HTTPSRedirectInsecure
+			check.FinalURL = location
+			return check, nil
+		}
+		check.RedirectChain = append(check.RedirectChain, next.String())
+
+		if next.Scheme == "https" {
+			check.Status = HTTPSRedirectOK
+			check.FinalURL = next.String()
+			return check, nil
+		}
+		current = next
+	}
+
+	// Too many HTTP-only hops
+	check.Status = HTTPSRedirectInsecure
+	check.FinalURL = current.String()
+	return check, nil
+}
+
+func (c *Crawler) fetchWithoutRedirect(ctx context.Context, client *http.Client, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Credentials are deliberately left off: the request is plain HTTP
+	setCustomHeaders(ctx, &req.Header)
+	req.Header.Set("User-Agent", c.userAgent(ctx))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	// Only headers matter; drain a little so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4*1024))
+	resp.Body.Close()
+
+	return resp, nil
+}
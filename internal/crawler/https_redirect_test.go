@@ -0,0 +1,128 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHTTPSRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		switch r.URL.Path {
+		case "/secure":
+			http.Redirect(w, r, "https://"+host+"/secure", http.StatusMovedPermanently)
+		case "/hop":
+			http.Redirect(w, r, "/secure", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "http://"+host+"/loop", http.StatusFound)
+		case "/dead-end":
+			http.Redirect(w, r, "/plain", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	c := New(testConfig())
+	httpsBase := "https://" + strings.TrimPrefix(server.URL, "http://")
+
+	tests := []struct {
+		path     string
+		status   string
+		chainLen int
+	}{
+		{path: "/secure", status: HTTPSRedirectOK, chainLen: 1},
+		{path: "/hop", status: HTTPSRedirectOK, chainLen: 2},
+		{path: "/plain", status: HTTPSRedirectMissing, chainLen: 0},
+		{path: "/dead-end", status: HTTPSRedirectInsecure, chainLen: 1},
+		{path: "/loop", status: HTTPSRedirectInsecure, chainLen: maxHTTPSRedirectHops + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			check, err := c.CheckHTTPSRedirect(context.Background(), httpsBase+tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, server.URL+tt.path, check.HTTPURL)
+			assert.Equal(t, tt.status, check.Status)
+			assert.Len(t, check.RedirectChain, tt.chainLen)
+		})
+	}
+}
+
+func TestCheckHTTPSRedirectUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	target := "https://" + strings.TrimPrefix(server.URL, "http://") + "/"
+	server.Close()
+
+	_, err := New(testConfig()).CheckHTTPSRedirect(context.Background(), target)
+	assert.Error(t, err)
+}
+
+func TestHTTPSRedirectFromResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *CrawlResult
+		status   string
+		finalURL string
+		chainLen int
+	}{
+		{
+			name: "upgraded",
+			result: &CrawlResult{URL: "http://example.com/a", StatusCode: 200,
+				RedirectChain: []string{"http://example.com/a", "https://example.com/a"}},
+			status: HTTPSRedirectOK, finalURL: "https://example.com/a", chainLen: 1,
+		},
+		{
+			name:   "served over http",
+			result: &CrawlResult{URL: "http://example.com/a", StatusCode: 200},
+			status: HTTPSRedirectMissing, finalURL: "http://example.com/a",
+		},
+		{
+			name: "http only chain",
+			result: &CrawlResult{URL: "http://example.com/a", StatusCode: 200,
+				RedirectChain: []string{"http://example.com/a", "http://example.com/b"}},
+			status: HTTPSRedirectInsecure, finalURL: "http://example.com/b", chainLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := HTTPSRedirectFromResult(tt.result)
+			require.NotNil(t, check)
+			assert.Equal(t, "http://example.com/a", check.HTTPURL)
+			assert.Equal(t, tt.status, check.Status)
+			assert.Equal(t, tt.finalURL, check.FinalURL)
+			assert.Len(t, check.RedirectChain, tt.chainLen)
+		})
+	}
+
+	// An HTTPS crawl says nothing about the HTTP variant
+	assert.Nil(t, HTTPSRedirectFromResult(&CrawlResult{URL: "https://example.com/a", StatusCode: 200}))
+}
+
+func TestCheckHTTPSRedirectSendsJobHeaders(t *testing.T) {
+	var gotAgent, gotHeader, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAgent = r.UserAgent()
+		gotHeader = r.Header.Get("X-Warm")
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := WithUserAgent(context.Background(), "JobBot/1.0")
+	ctx = WithCustomHeaders(ctx, map[string]string{"X-Warm": "1"})
+	ctx = WithCredentials(ctx, Credentials{Username: "user", Password: "pass"})
+
+	_, err := New(testConfig()).CheckHTTPSRedirect(ctx, "https://"+strings.TrimPrefix(server.URL, "http://")+"/")
+	require.NoError(t, err)
+	assert.Equal(t, "JobBot/1.0", gotAgent)
+	assert.Equal(t, "1", gotHeader)
+	assert.Empty(t, gotAuth, "credentials must not be sent over plain HTTP")
+}
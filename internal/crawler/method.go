@@ -0,0 +1,27 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+)
+
+type methodKey struct{}
+
+// WithMethod sets the HTTP method WarmURL uses for requests made with the
+// returned context. HEAD primes CDNs that cache on HEAD without transferring
+// bodies, so there's nothing to extract links from or sample. Anything other
+// than HEAD leaves the default GET.
+func WithMethod(ctx context.Context, method string) context.Context {
+	if method != http.MethodHead {
+		return ctx
+	}
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+// methodFromContext returns the warm method, defaulting to GET
+func methodFromContext(ctx context.Context) string {
+	if method, ok := ctx.Value(methodKey{}).(string); ok {
+		return method
+	}
+	return http.MethodGet
+}
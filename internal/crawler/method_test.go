@@ -0,0 +1,51 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWarmURLHeadMode(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("CF-Cache-Status", "HIT")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<html><body><a href="/about">About</a></body></html>`))
+	}))
+	defer ts.Close()
+
+	ctx := WithMethod(context.Background(), http.MethodHead)
+	result, err := New(testConfig()).WarmURL(ctx, ts.URL, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(methods) != 1 || methods[0] != http.MethodHead {
+		t.Fatalf("Expected a single HEAD request, got %v", methods)
+	}
+	if result.StatusCode != http.StatusOK || result.CacheStatus != "HIT" {
+		t.Fatalf("Expected 200 HIT, got %d %q", result.StatusCode, result.CacheStatus)
+	}
+	if len(result.Body) != 0 || len(result.BodySample) != 0 {
+		t.Fatalf("Expected no body, got %d bytes", len(result.Body))
+	}
+	if len(result.Links) != 0 {
+		t.Fatalf("Expected no links in HEAD mode, got %v", result.Links)
+	}
+}
+
+func TestWithMethodIgnoresUnknownMethods(t *testing.T) {
+	if got := methodFromContext(WithMethod(context.Background(), http.MethodPost)); got != http.MethodGet {
+		t.Fatalf("Expected GET fallback, got %s", got)
+	}
+}
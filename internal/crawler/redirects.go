@@ -0,0 +1,84 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxRedirects matches the Go http.Client default
+const DefaultMaxRedirects = 10
+
+// ErrTooManyRedirects is returned when a request exceeds the redirect limit
+// or redirects back to a URL it has already visited
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// maxRedirects returns the configured redirect limit, or the default
+func (c *Crawler) maxRedirects() int {
+	if c.config != nil && c.config.MaxRedirects > 0 {
+		return c.config.MaxRedirects
+	}
+	return DefaultMaxRedirects
+}
+
+type redirectChainKey struct{}
+
+// redirectChain records each URL a request is redirected to
+type redirectChain struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+// withRedirectChain returns a context whose requests record their redirects
+func withRedirectChain(ctx context.Context) (context.Context, *redirectChain) {
+	chain := &redirectChain{}
+	return context.WithValue(ctx, redirectChainKey{}, chain), chain
+}
+
+func (rc *redirectChain) add(url string) {
+	rc.mu.Lock()
+	rc.urls = append(rc.urls, url)
+	rc.mu.Unlock()
+}
+
+// from returns the full chain starting at the original URL, or nil when the
+// request wasn't redirected
+func (rc *redirectChain) from(original string) []string {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.urls) == 0 {
+		return nil
+	}
+	return append([]string{original}, rc.urls...)
+}
+
+// checkRedirect returns a redirect policy that follows up to maxRedirects
+// redirects, fails fast on loops and drops credentials when leaving the
+// original host. The error spells out the chain, e.g. "A → B → A (loop)".
+func checkRedirect(maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		target := req.URL.String()
+		if chain, ok := req.Context().Value(redirectChainKey{}).(*redirectChain); ok {
+			chain.add(target)
+		}
+
+		hops := make([]string, 0, len(via)+1)
+		for _, prev := range via {
+			hops = append(hops, prev.URL.String())
+		}
+		for _, hop := range hops {
+			if hop == target {
+				return fmt.Errorf("%w: %s → %s (loop)", ErrTooManyRedirects, strings.Join(hops, " → "), target)
+			}
+		}
+		if len(via) > maxRedirects {
+			return fmt.Errorf("%w: stopped after %d redirects: %s → %s", ErrTooManyRedirects, maxRedirects, strings.Join(hops, " → "), target)
+		}
+
+		dropCrossHostAuthorization(req, via)
+		return nil
+	}
+}
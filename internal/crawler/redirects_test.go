@@ -0,0 +1,74 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWarmURLStopsAtMaxRedirects(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// /1 → /2 → /3 → ... never settles
+		next := "/1"
+		if n := strings.TrimPrefix(r.URL.Path, "/"); n != "" {
+			next = "/" + n + "1"
+		}
+		http.Redirect(w, r, ts.URL+next, http.StatusFound)
+	}))
+	defer ts.Close()
+
+	config := testConfig()
+	config.MaxRedirects = 3
+	result, err := New(config).WarmURL(context.Background(), ts.URL+"/", false)
+	if err == nil {
+		t.Fatal("Expected too many redirects error")
+	}
+	if !strings.Contains(err.Error(), "too many redirects") || !strings.Contains(err.Error(), "stopped after 3 redirects") {
+		t.Fatalf("Expected too many redirects error, got %v", err)
+	}
+	// Original URL, three followed redirects and the one that was refused
+	if len(result.RedirectChain) != 5 {
+		t.Fatalf("Expected a 5 entry redirect chain, got %v", result.RedirectChain)
+	}
+}
+
+func TestWarmURLDetectsRedirectLoop(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, ts.URL+"/b", http.StatusMovedPermanently)
+		case "/b":
+			http.Redirect(w, r, ts.URL+"/c", http.StatusMovedPermanently)
+		default:
+			http.Redirect(w, r, ts.URL+"/a", http.StatusMovedPermanently)
+		}
+	}))
+	defer ts.Close()
+
+	result, err := New(testConfig()).WarmURL(context.Background(), ts.URL+"/a", false)
+	if err == nil || !strings.Contains(err.Error(), "(loop)") {
+		t.Fatalf("Expected redirect loop error, got %v", err)
+	}
+	want := []string{ts.URL + "/a", ts.URL + "/b", ts.URL + "/c", ts.URL + "/a"}
+	if strings.Join(result.RedirectChain, " ") != strings.Join(want, " ") {
+		t.Fatalf("Expected chain %v, got %v", want, result.RedirectChain)
+	}
+}
+
+func TestCheckRedirectAllowsShortChains(t *testing.T) {
+	policy := checkRedirect(2)
+	first, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	next, _ := http.NewRequest(http.MethodGet, "https://example.com/home", nil)
+
+	if err := policy(next, []*http.Request{first}); err != nil {
+		t.Fatalf("Expected redirect to be allowed, got %v", err)
+	}
+	if err := policy(first, []*http.Request{first, next}); !errors.Is(err, ErrTooManyRedirects) {
+		t.Fatalf("Expected loop to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package crawler
+
+import (
+	"context"
+	"time"
+)
+
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout overrides DefaultTimeout for warming requests made with
+// the returned context, for slow server-rendered pages whose first uncached
+// hit legitimately takes minutes. A zero or negative timeout leaves the
+// default in place.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// requestTimeout returns the context override, falling back to DefaultTimeout
+func (c *Crawler) requestTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok && timeout > 0 {
+		return timeout
+	}
+	return c.config.DefaultTimeout
+}
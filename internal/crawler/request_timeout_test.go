@@ -0,0 +1,31 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWarmURLRequestTimeoutOverride(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.Header().Set("CF-Cache-Status", "HIT")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := testConfig()
+	cfg.DefaultTimeout = 100 * time.Millisecond
+	c := New(cfg)
+
+	if _, err := c.WarmURL(context.Background(), ts.URL, false); err == nil {
+		t.Fatal("Expected slow page to time out with the default request timeout")
+	}
+
+	ctx := WithRequestTimeout(context.Background(), 2*time.Second)
+	if _, err := c.WarmURL(ctx, ts.URL, false); err != nil {
+		t.Fatalf("Expected slow page to warm with a longer request timeout, got %v", err)
+	}
+}
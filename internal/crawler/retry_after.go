@@ -0,0 +1,50 @@
+package crawler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRetryAfter parses a Retry-After header value, which is either
+// delta-seconds or an HTTP-date. It returns false for missing, malformed or
+// already-past values so callers fall back to their own backoff.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0, false
+		}
+		// Clamp before converting so absurd values can't overflow
+		seconds = min(seconds, int64(24*time.Hour/time.Second))
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	when, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := when.Sub(now)
+	if wait <= 0 {
+		return 0, false
+	}
+	return wait, true
+}
+
+// retryAfterFromResponse returns the Retry-After delay for 429 and 503
+// responses, or 0 when the server didn't ask us to wait
+func retryAfterFromResponse(statusCode int, headers *http.Header) time.Duration {
+	if headers == nil {
+		return 0
+	}
+	if statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	wait, _ := ParseRetryAfter(headers.Get("Retry-After"), time.Now())
+	return wait
+}
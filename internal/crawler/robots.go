@@ -22,7 +22,8 @@ type RobotsRules struct {
 	Sitemaps []string
 	// DisallowPatterns are URL patterns that should not be crawled
 	DisallowPatterns []string
-	// AllowPatterns override DisallowPatterns (more specific)
+	// AllowPatterns are URL patterns that may be crawled. When both lists match
+	// a path, the longest matching pattern wins, and Allow wins a tie.
 	AllowPatterns []string
 }
 
@@ -53,13 +54,8 @@ func ParseRobotsTxt(ctx context.Context, domain string, userAgent string) (*Robo
 
 	// Create a client with shorter timeout
 	client := &http.Client{
-		Timeout: 10 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
-			return nil
-		},
+		Timeout:       10 * time.Second,
+		CheckRedirect: checkRedirect(DefaultMaxRedirects),
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil)
@@ -67,10 +63,16 @@ func ParseRobotsTxt(ctx context.Context, domain string, userAgent string) (*Robo
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Use the provided user agent
+	// Use the provided user agent, and the job's credentials for protected sites
 	req.Header.Set("User-Agent", userAgent)
+	setAuthorization(ctx, &req.Header)
 
+	paced, err := paceDiscovery(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch robots.txt: %w", err)
+	}
 	resp, err := client.Do(req)
+	paced(resp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch robots.txt: %w", err)
 	}
@@ -121,6 +123,10 @@ func parseRobotsTxtContent(r io.Reader, userAgent string) (*RobotsRules, error)
 	// Extract bot name from user agent (e.g., "BlueBandedBee/1.0" -> "bluebandedbee")
 	botName := strings.ToLower(strings.Split(userAgent, "/")[0])
 
+	// Sitemap directives apply regardless of section, so collect them
+	// separately from the per-agent rules
+	sitemaps := []string{}
+
 	// Temporary storage for wildcard rules
 	wildcardRules := &RobotsRules{
 		Sitemaps:         []string{},
@@ -170,11 +176,7 @@ func parseRobotsTxtContent(r io.Reader, userAgent string) (*RobotsRules, error)
 		if strings.HasPrefix(lowerLine, "sitemap:") {
 			sitemapURL := strings.TrimSpace(line[8:])
 			if sitemapURL != "" {
-				// Always add sitemaps to the main rules
-				rules.Sitemaps = append(rules.Sitemaps, sitemapURL)
-				if inWildcardSection && !foundSpecificSection {
-					wildcardRules.Sitemaps = append(wildcardRules.Sitemaps, sitemapURL)
-				}
+				sitemaps = append(sitemaps, sitemapURL)
 			}
 			continue
 		}
@@ -212,7 +214,7 @@ func parseRobotsTxtContent(r io.Reader, userAgent string) (*RobotsRules, error)
 			continue
 		}
 
-		// Parse Allow directive (overrides Disallow)
+		// Parse Allow directive (overrides a shorter or equal Disallow)
 		if strings.HasPrefix(lowerLine, "allow:") {
 			path := strings.TrimSpace(line[6:])
 			if path != "" {
@@ -226,6 +228,7 @@ func parseRobotsTxtContent(r io.Reader, userAgent string) (*RobotsRules, error)
 	if !foundSpecificSection {
 		rules = wildcardRules
 	}
+	rules.Sitemaps = sitemaps
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading robots.txt: %w", err)
@@ -243,27 +246,40 @@ func parseRobotsTxtContent(r io.Reader, userAgent string) (*RobotsRules, error)
 
 // IsPathAllowed checks if a path is allowed by robots.txt rules
 func IsPathAllowed(rules *RobotsRules, path string) bool {
+	return DisallowedBy(rules, path) == ""
+}
+
+// DisallowedBy returns the Disallow pattern blocking path, or "" when the path
+// is allowed. When Allow and Disallow patterns both match, the longest pattern
+// wins and Allow wins a tie, so "Allow: /folder/public/" carves an exception
+// out of "Disallow: /folder/" but "Allow: /" doesn't undo it.
+func DisallowedBy(rules *RobotsRules, path string) string {
 	// No rules means everything is allowed
 	if rules == nil || len(rules.DisallowPatterns) == 0 {
-		return true
+		return ""
 	}
 
-	// Check Allow patterns first (they override Disallow)
-	for _, pattern := range rules.AllowPatterns {
-		if matchesRobotsPattern(path, pattern) {
-			return true
-		}
+	disallow, ok := longestRobotsMatch(rules.DisallowPatterns, path)
+	if !ok {
+		return ""
 	}
+	if allow, ok := longestRobotsMatch(rules.AllowPatterns, path); ok && len(allow) >= len(disallow) {
+		return ""
+	}
+	return disallow
+}
 
-	// Check Disallow patterns
-	for _, pattern := range rules.DisallowPatterns {
-		if matchesRobotsPattern(path, pattern) {
-			return false
+// longestRobotsMatch returns the longest of patterns matching path
+func longestRobotsMatch(patterns []string, path string) (string, bool) {
+	var longest string
+	found := false
+	for _, pattern := range patterns {
+		if matchesRobotsPattern(path, pattern) && (!found || len(pattern) > len(longest)) {
+			longest = pattern
+			found = true
 		}
 	}
-
-	// If no patterns match, it's allowed
-	return true
+	return longest, found
 }
 
 // matchesRobotsPattern checks if a path matches a robots.txt pattern
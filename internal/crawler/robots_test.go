@@ -90,6 +90,40 @@ Allow: /cart/view
 			wantDisallow: []string{"/admin"},
 			wantAllow:    []string{},
 		},
+		{
+			name: "Sitemaps outside our section are kept",
+			robotsTxt: `
+Sitemap: https://cdn.example.com/sitemaps/index.xml
+
+User-agent: Googlebot
+Disallow: /nogoogle
+Sitemap: https://example.com/news-sitemap.xml
+
+User-agent: *
+Disallow: /admin
+`,
+			userAgent:    "BlueBandedBee/1.0",
+			wantDelay:    0,
+			wantSitemaps: []string{"https://cdn.example.com/sitemaps/index.xml", "https://example.com/news-sitemap.xml"},
+			wantDisallow: []string{"/admin"},
+			wantAllow:    []string{},
+		},
+		{
+			name: "Sitemaps before specific section are kept",
+			robotsTxt: `
+User-agent: *
+Disallow: /admin
+Sitemap: https://example.com/sitemap.xml
+
+User-agent: BlueBandedBee
+Disallow: /checkout
+`,
+			userAgent:    "BlueBandedBee/1.0",
+			wantDelay:    0,
+			wantSitemaps: []string{"https://example.com/sitemap.xml"},
+			wantDisallow: []string{"/checkout"},
+			wantAllow:    []string{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,3 +183,74 @@ func TestIsPathAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPathAllowedLongestMatchWins(t *testing.T) {
+	content := `User-agent: *
+Disallow: /folder/
+Allow: /folder/public/
+Allow: /
+Disallow: /folder/public/drafts/
+`
+	rules, err := parseRobotsTxtContent(strings.NewReader(content), "BlueBandedBee/1.0")
+	if err != nil {
+		t.Fatalf("parseRobotsTxtContent() error = %v", err)
+	}
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/folder/", false},
+		{"/folder/private", false},
+		{"/folder/public/", true},
+		{"/folder/public/page", true},
+		{"/folder/public/drafts/page", false}, // Longer Disallow beats the Allow
+		{"/elsewhere", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsPathAllowed(rules, tt.path); got != tt.allowed {
+				t.Errorf("IsPathAllowed(%q) = %v, want %v", tt.path, got, tt.allowed)
+			}
+		})
+	}
+
+	// Equal length matches go to Allow
+	tied := &RobotsRules{
+		DisallowPatterns: []string{"/page"},
+		AllowPatterns:    []string{"/page"},
+	}
+	if !IsPathAllowed(tied, "/page") {
+		t.Error("IsPathAllowed with tied Allow and Disallow = false, want true")
+	}
+}
+
+func TestDisallowedBy(t *testing.T) {
+	rules := &RobotsRules{
+		DisallowPatterns: []string{"/admin", "/tmp/*"},
+		AllowPatterns:    []string{"/admin/public"},
+	}
+
+	tests := []struct {
+		path    string
+		pattern string
+	}{
+		{"/", ""},
+		{"/admin/secret", "/admin"},
+		{"/admin/public", ""}, // Allow overrides Disallow
+		{"/tmp/file", "/tmp/*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := DisallowedBy(rules, tt.path); got != tt.pattern {
+				t.Errorf("DisallowedBy(%q) = %q, want %q", tt.path, got, tt.pattern)
+			}
+		})
+	}
+
+	if got := DisallowedBy(nil, "/admin"); got != "" {
+		t.Errorf("DisallowedBy with nil rules = %q, want empty", got)
+	}
+}
@@ -0,0 +1,22 @@
+package crawler
+
+import "context"
+
+type skipSecondRequestKey struct{}
+
+// WithSecondRequest controls whether WarmURL follows a cache miss with HEAD
+// checks and a second GET to measure the warmed response. Disabling it roughly
+// halves origin load for jobs that only need to prime the cache; the result's
+// Second* fields stay empty. Enabled leaves ctx unchanged.
+func WithSecondRequest(ctx context.Context, enabled bool) context.Context {
+	if enabled {
+		return ctx
+	}
+	return context.WithValue(ctx, skipSecondRequestKey{}, true)
+}
+
+// secondRequestEnabled reports whether cache misses should be re-fetched
+func secondRequestEnabled(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipSecondRequestKey{}).(bool)
+	return !skip
+}
@@ -0,0 +1,53 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmURLSecondRequestDisabled(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("CF-Cache-Status", "MISS")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	gateCalled := false
+	ctx := WithVerificationGate(context.Background(), func(context.Context) (func(), error) {
+		gateCalled = true
+		return func() {}, nil
+	})
+	ctx = WithSecondRequest(ctx, false)
+
+	result, err := New(testConfig()).WarmURL(ctx, ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected only the priming request, got %d requests", got)
+	}
+	if gateCalled {
+		t.Error("Expected verification gate not to be called")
+	}
+	if result.SecondCacheStatus != "" || result.SecondResponseTime != 0 || result.SecondPerformance != nil {
+		t.Errorf("Expected empty second request fields, got %q/%d", result.SecondCacheStatus, result.SecondResponseTime)
+	}
+	if len(result.CacheCheckAttempts) != 0 {
+		t.Errorf("Expected no cache checks, got %d", len(result.CacheCheckAttempts))
+	}
+}
+
+func TestWithSecondRequestEnabledLeavesContext(t *testing.T) {
+	ctx := context.Background()
+	if WithSecondRequest(ctx, true) != ctx {
+		t.Error("Expected enabled second request to leave ctx unchanged")
+	}
+	if !secondRequestEnabled(ctx) {
+		t.Error("Expected second request enabled by default")
+	}
+}
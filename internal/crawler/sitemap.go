@@ -1,34 +1,57 @@
 package crawler
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/net/html/charset"
 )
 
-// decompressGzip decompresses gzip-encoded data
-func decompressGzip(data []byte) ([]byte, error) {
-	reader, err := gzip.NewReader(bytes.NewReader(data))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
-	}
-	defer reader.Close()
+// ErrSitemapTooLarge is returned when a sitemap exceeds Config.MaxSitemapSize once decompressed
+var ErrSitemapTooLarge = errors.New("sitemap exceeds maximum decompressed size")
 
-	decompressed, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decompress gzip data: %w", err)
-	}
+// gzipMagic is the two-byte header every gzip stream starts with
+var gzipMagic = []byte{0x1f, 0x8b}
 
-	return decompressed, nil
+// maxGzipLayers allows a .gz file that is also served with Content-Encoding: gzip
+const maxGzipLayers = 2
+
+// sitemapProtocolMaxURLs is the most URLs, or child sitemaps for an index,
+// the sitemap protocol allows in one file. Larger sites split across files.
+const sitemapProtocolMaxURLs = 50_000
+
+// sizeLimitedReader fails with ErrSitemapTooLarge once more than limit bytes
+// have been read, rather than silently truncating like io.LimitReader alone
+type sizeLimitedReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+}
+
+func newSizeLimitedReader(r io.Reader, limit int64) *sizeLimitedReader {
+	return &sizeLimitedReader{r: io.LimitReader(r, limit+1), limit: limit}
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.limit {
+		return 0, fmt.Errorf("%w (%d bytes)", ErrSitemapTooLarge, l.limit)
+	}
+	return n, err
 }
 
 // isGzipContent checks if the response is gzip-encoded based on headers or URL
@@ -43,6 +66,202 @@ func isGzipContent(contentEncoding, url string) bool {
 	return strings.HasSuffix(lowerURL, ".gz")
 }
 
+// isGzipContentType checks for gzip file media types
+func isGzipContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(contentType)), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "application/gzip", "application/x-gzip":
+		return true
+	}
+	return false
+}
+
+// readSitemap decompresses and decodes a sitemap as it streams in, so the
+// body is never held in memory. Gzip is confirmed by its magic bytes rather
+// than trusted from headers, since servers often label already-decoded .gz
+// files or omit headers entirely.
+func readSitemap(resp *http.Response, sitemapURL string, sizeLimit int64, urlLimit int) (*sitemapDocument, error) {
+	contentEncoding := resp.Header.Get("Content-Encoding")
+	hinted := isGzipContent(contentEncoding, sitemapURL) || isGzipContentType(resp.Header.Get("Content-Type"))
+
+	body := bufio.NewReader(resp.Body)
+	layers := 0
+	for layers < maxGzipLayers {
+		magic, err := body.Peek(len(gzipMagic))
+		if err != nil || !bytes.Equal(magic, gzipMagic) {
+			break
+		}
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gz.Close()
+		body = bufio.NewReader(gz)
+		layers++
+	}
+
+	if hinted && layers == 0 {
+		log.Debug().
+			Str("url", sitemapURL).
+			Str("content_encoding", contentEncoding).
+			Msg("Sitemap labelled as gzip but not compressed, parsing as plain XML")
+	}
+
+	reader := newSizeLimitedReader(body, sizeLimit)
+	doc, err := decodeSitemap(reader, sitemapURL, urlLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Str("url", sitemapURL).
+		Str("content_encoding", contentEncoding).
+		Int("gzip_layers", layers).
+		Int64("decompressed_size", reader.n).
+		Bool("index", doc.Index).
+		Int("entry_count", len(doc.URLs)+len(doc.Sitemaps)).
+		Msg("Sitemap received")
+
+	return doc, nil
+}
+
+// sitemapDocument is a decoded sitemap's entries as listed, before URLs are
+// validated and normalised
+type sitemapDocument struct {
+	Index    bool
+	Sitemaps []string     // <loc> of each <sitemap> in an index
+	URLs     []SitemapURL // Each <url> of a regular sitemap
+	Dropped  int          // Entries past the limit that were counted but not kept
+}
+
+// decodeSitemap reads each <url> entry's location and, when present and
+// parseable, its <lastmod>, <priority> and <changefreq>, or each <sitemap>
+// location of an index. It keeps at most limit entries, so a hostile sitemap
+// can't balloon memory, and counts the rest as dropped. Only direct children
+// of an entry are read, so extension tags such as <image:loc> are ignored.
+// Malformed XML keeps the entries read before the error.
+func decodeSitemap(r io.Reader, sitemapURL string, limit int) (*sitemapDocument, error) {
+	doc := &sitemapDocument{}
+
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	var (
+		depth      int
+		entryDepth int    // Depth of the open <url> or <sitemap>, 0 when none
+		field      string // Direct child of the entry being read
+		text       strings.Builder
+		values     map[string]string
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var syntaxErr *xml.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				log.Warn().
+					Err(err).
+					Str("url", sitemapURL).
+					Int("entries_read", len(doc.URLs)+len(doc.Sitemaps)).
+					Msg("Sitemap XML is malformed, keeping entries read so far")
+				break
+			}
+			if errors.Is(err, ErrSitemapTooLarge) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to read sitemap: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case entryDepth == 0 && t.Name.Local == "sitemapindex":
+				doc.Index = true
+			case entryDepth == 0 && (t.Name.Local == "url" || t.Name.Local == "sitemap"):
+				entryDepth = depth
+				if values == nil {
+					values = map[string]string{}
+				}
+				clear(values)
+			case entryDepth > 0 && depth == entryDepth+1:
+				field = t.Name.Local
+				text.Reset()
+			}
+		case xml.CharData:
+			if field != "" {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			switch {
+			case field != "" && depth == entryDepth+1:
+				// The first occurrence of a tag wins
+				if _, seen := values[field]; !seen {
+					values[field] = strings.TrimSpace(text.String())
+				}
+				field = ""
+			case entryDepth > 0 && depth == entryDepth:
+				doc.addEntry(t.Name.Local, values, limit)
+				entryDepth = 0
+			}
+			depth--
+		}
+	}
+
+	return doc, nil
+}
+
+// addEntry keeps a finished <url> or <sitemap> entry, or counts it as
+// dropped once limit entries are kept
+func (doc *sitemapDocument) addEntry(tag string, values map[string]string, limit int) {
+	loc := values["loc"]
+	if loc == "" {
+		return
+	}
+	if len(doc.URLs)+len(doc.Sitemaps) >= limit {
+		doc.Dropped++
+		return
+	}
+
+	if tag == "sitemap" {
+		doc.Sitemaps = append(doc.Sitemaps, loc)
+		return
+	}
+
+	entry := SitemapURL{URL: loc}
+	if lastMod, ok := parseLastMod(values["lastmod"]); ok {
+		entry.LastMod = lastMod
+	}
+	if priority, ok := parseSitemapPriority(values["priority"]); ok {
+		entry.Priority = &priority
+	}
+	entry.ChangeFreq = parseChangeFreq(values["changefreq"])
+	doc.URLs = append(doc.URLs, entry)
+}
+
+// SitemapURL is a page listed in a sitemap
+type SitemapURL struct {
+	URL        string
+	LastMod    time.Time // Zero when the entry has no valid <lastmod>
+	Priority   *float64  // <priority> from 0.0 to 1.0, nil when missing or invalid
+	ChangeFreq string    // Lowercase <changefreq>, e.g. "daily"; empty when missing or invalid
+	// ListedScheme is "http" or "https" as the sitemap listed the URL, before
+	// it was normalised to https; empty when the entry had no scheme
+	ListedScheme string
+}
+
+// SitemapURLStrings returns just the page URLs
+func SitemapURLStrings(entries []SitemapURL) []string {
+	urls := make([]string, len(entries))
+	for i, entry := range entries {
+		urls[i] = entry.URL
+	}
+	return urls
+}
+
 // SitemapDiscoveryResult contains both sitemaps and robots.txt rules
 type SitemapDiscoveryResult struct {
 	Sitemaps    []string
@@ -64,7 +283,7 @@ func (c *Crawler) DiscoverSitemapsAndRobots(ctx context.Context, domain string)
 	}
 
 	// Parse robots.txt first - this gets us both sitemaps and crawl rules
-	robotRules, err := ParseRobotsTxt(ctx, normalisedDomain, c.config.UserAgent)
+	robotRules, err := ParseRobotsTxt(ctx, normalisedDomain, c.userAgent(ctx))
 	if err != nil {
 		// Log error but don't fail - no robots.txt means no restrictions
 		log.Debug().
@@ -73,7 +292,21 @@ func (c *Crawler) DiscoverSitemapsAndRobots(ctx context.Context, domain string)
 			Msg("Failed to parse robots.txt, proceeding with no restrictions")
 	} else {
 		result.RobotsRules = robotRules
-		result.Sitemaps = robotRules.Sitemaps
+		if pacer := discoveryPacerFromContext(ctx); pacer != nil && robotRules.CrawlDelay > 0 {
+			pacer.SetCrawlDelay(robotRules.CrawlDelay)
+		}
+	}
+
+	// Robots.txt sitemaps come first, deduplicated; absolute URLs are used
+	// as-is even when they point at another host, e.g. a CDN subdomain
+	seen := make(map[string]bool)
+	for _, raw := range result.RobotsRules.Sitemaps {
+		sitemapURL := resolveRobotsSitemapURL(normalisedDomain, raw)
+		if sitemapURL == "" || seen[sitemapURL] {
+			continue
+		}
+		seen[sitemapURL] = true
+		result.Sitemaps = append(result.Sitemaps, sitemapURL)
 	}
 
 	// Log if sitemaps were found in robots.txt
@@ -85,59 +318,51 @@ func (c *Crawler) DiscoverSitemapsAndRobots(ctx context.Context, domain string)
 		log.Debug().Msg("No sitemaps found in robots.txt")
 	}
 
-	// If no sitemaps found in robots.txt, check common locations
-	if len(result.Sitemaps) == 0 {
-		commonPaths := []string{
-			"https://" + normalisedDomain + "/sitemap.xml",
-			"https://" + normalisedDomain + "/sitemap_index.xml",
-		}
-
-		// Create a client for checking common locations
-		client := &http.Client{
-			Timeout: 5 * time.Second,
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				if len(via) >= 10 {
-					return fmt.Errorf("too many redirects")
-				}
-				return nil
-			},
-		}
+	// Also check common locations that robots.txt didn't already declare
+	commonPaths := []string{
+		"https://" + normalisedDomain + "/sitemap.xml",
+		"https://" + normalisedDomain + "/sitemap_index.xml",
+	}
 
-		// Check common locations concurrently with a timeout
-		for _, sitemapURL := range commonPaths {
-			log.Debug().Str("checking_sitemap_url", sitemapURL).Msg("Checking common sitemap location")
-			req, err := http.NewRequestWithContext(ctx, "HEAD", sitemapURL, nil)
-			if err != nil {
-				log.Debug().Err(err).Str("url", sitemapURL).Msg("Error creating request for sitemap")
-				continue
-			}
-			req.Header.Set("User-Agent", c.config.UserAgent)
+	// Create a client for checking common locations
+	client := &http.Client{
+		Timeout:       5 * time.Second,
+		CheckRedirect: checkRedirect(c.maxRedirects()),
+	}
 
-			resp, err := client.Do(req)
-			if err != nil {
-				log.Debug().Err(err).Str("url", sitemapURL).Msg("Error fetching sitemap")
-				continue
-			}
+	for _, sitemapURL := range commonPaths {
+		if seen[sitemapURL] {
+			continue
+		}
+		log.Debug().Str("checking_sitemap_url", sitemapURL).Msg("Checking common sitemap location")
+		req, err := http.NewRequestWithContext(ctx, "HEAD", sitemapURL, nil)
+		if err != nil {
+			log.Debug().Err(err).Str("url", sitemapURL).Msg("Error creating request for sitemap")
+			continue
+		}
+		req.Header.Set("User-Agent", c.userAgent(ctx))
+		setAuthorization(ctx, &req.Header)
 
-			_ = resp.Body.Close()
-			log.Debug().Str("url", sitemapURL).Int("status", resp.StatusCode).Msg("Sitemap check response")
-			if resp.StatusCode == http.StatusOK {
-				result.Sitemaps = append(result.Sitemaps, sitemapURL)
-				log.Debug().Str("url", sitemapURL).Msg("Found sitemap at common location")
-			}
+		paced, err := paceDiscovery(ctx)
+		if err != nil {
+			log.Debug().Err(err).Str("url", sitemapURL).Msg("Gave up waiting to check sitemap")
+			continue
+		}
+		resp, err := client.Do(req)
+		paced(resp)
+		if err != nil {
+			log.Debug().Err(err).Str("url", sitemapURL).Msg("Error fetching sitemap")
+			continue
 		}
-	}
 
-	// Deduplicate sitemaps
-	seen := make(map[string]bool)
-	var uniqueSitemaps []string
-	for _, sitemap := range result.Sitemaps {
-		if !seen[sitemap] {
-			seen[sitemap] = true
-			uniqueSitemaps = append(uniqueSitemaps, sitemap)
+		_ = resp.Body.Close()
+		log.Debug().Str("url", sitemapURL).Int("status", resp.StatusCode).Msg("Sitemap check response")
+		if resp.StatusCode == http.StatusOK {
+			seen[sitemapURL] = true
+			result.Sitemaps = append(result.Sitemaps, sitemapURL)
+			log.Debug().Str("url", sitemapURL).Msg("Found sitemap at common location")
 		}
 	}
-	result.Sitemaps = uniqueSitemaps
 
 	// Log final result
 	if len(result.Sitemaps) > 0 {
@@ -158,6 +383,29 @@ func (c *Crawler) DiscoverSitemapsAndRobots(ctx context.Context, domain string)
 	return result, nil
 }
 
+// resolveRobotsSitemapURL turns a robots.txt Sitemap directive into an
+// absolute URL. Absolute URLs are returned unchanged; relative paths, which
+// some sites use despite the spec, resolve against the domain.
+func resolveRobotsSitemapURL(domain, raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ""
+	}
+	if parsed.IsAbs() {
+		return parsed.String()
+	}
+	if parsed.Host != "" {
+		// Scheme-relative, e.g. //cdn.example.com/sitemap.xml
+		parsed.Scheme = "https"
+		return parsed.String()
+	}
+	if parsed.Path == "" {
+		return ""
+	}
+	base := &url.URL{Scheme: "https", Host: domain, Path: "/"}
+	return base.ResolveReference(parsed).String()
+}
+
 // DiscoverSitemaps is a backward-compatible wrapper that only returns sitemaps
 func (c *Crawler) DiscoverSitemaps(ctx context.Context, domain string) ([]string, error) {
 	result, err := c.DiscoverSitemapsAndRobots(ctx, domain)
@@ -188,158 +436,252 @@ type URL struct {
 	Loc     string   `xml:"loc"`
 }
 
-// ParseSitemap extracts URLs from a sitemap
-func (c *Crawler) ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error) {
-	var urls []string
+// SitemapParseResult is what ParseSitemapWithReport found: the URLs, how many
+// sitemaps loaded, and the index entries still unreachable after retries
+type SitemapParseResult struct {
+	URLs   []SitemapURL
+	Loaded int      // Regular sitemaps that loaded, counting each index child
+	Failed []string // Index entries that failed to load
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", sitemapURL, nil)
+// ParseSitemap extracts URLs from a sitemap
+func (c *Crawler) ParseSitemap(ctx context.Context, sitemapURL string) ([]SitemapURL, error) {
+	result, err := c.ParseSitemapWithReport(ctx, sitemapURL)
 	if err != nil {
 		return nil, err
 	}
+	return result.URLs, nil
+}
 
-	// Request gzip encoding if server supports it
-	req.Header.Set("Accept-Encoding", "gzip")
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
+// ParseSitemapWithReport extracts URLs from a sitemap and reports which index
+// entries couldn't be loaded. It only errors when sitemapURL itself fails.
+func (c *Crawler) ParseSitemapWithReport(ctx context.Context, sitemapURL string) (*SitemapParseResult, error) {
+	result := &SitemapParseResult{}
+	if err := c.parseSitemapInto(ctx, sitemapURL, result); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return result, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch sitemap: %d", resp.StatusCode)
-	}
+func (c *Crawler) parseSitemapInto(ctx context.Context, sitemapURL string, result *SitemapParseResult) error {
+	cache := sitemapCacheFromContext(ctx)
+	cached := cachedSitemapFor(ctx, cache, sitemapURL)
 
-	body, err := io.ReadAll(resp.Body)
+	var previous Validators
+	if cached != nil {
+		previous = cached.Validators
+	}
+	resp, err := c.fetchSitemapWithRetry(ctx, sitemapURL, previous)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Decompress if gzip-encoded (via header or .gz URL suffix)
-	contentEncoding := resp.Header.Get("Content-Encoding")
-	if isGzipContent(contentEncoding, sitemapURL) {
+	if resp.NotModified {
 		log.Debug().
 			Str("url", sitemapURL).
-			Str("content_encoding", contentEncoding).
-			Int("compressed_size", len(body)).
-			Msg("Decompressing gzip sitemap")
+			Bool("index", cached.Index).
+			Int("url_count", len(cached.URLs)).
+			Msg("Sitemap not modified, reusing cached contents")
+		return c.collectSitemap(ctx, sitemapURL, cached, result)
+	}
 
-		body, err = decompressGzip(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decompress sitemap %s: %w", sitemapURL, err)
-		}
+	doc := resp.Document
+	sitemap := &CachedSitemap{Validators: resp.Validators, Index: doc.Index}
 
-		log.Debug().
-			Str("url", sitemapURL).
-			Int("decompressed_size", len(body)).
-			Msg("Sitemap decompressed successfully")
+	limit := c.maxSitemapURLs()
+	if kept := len(doc.URLs) + len(doc.Sitemaps); doc.Dropped > 0 {
+		logSitemapTruncated(sitemapURL, limit, kept+doc.Dropped)
+	} else if kept > sitemapProtocolMaxURLs {
+		logSitemapOverProtocolLimit(sitemapURL, kept)
 	}
 
-	content := string(body)
-
-	// Log the content for debugging
-	log.Debug().
-		Str("url", sitemapURL).
-		Int("content_length", len(content)).
-		Str("content_sample", content[:min(100, len(content))]).
-		Msg("Sitemap content received")
-
-	// Check if it's a sitemap index
-	if strings.Contains(content, "<sitemapindex") {
-		// Extract sitemap URLs
-		sitemapURLs := extractURLsFromXML(content, "<sitemap>", "</sitemap>", "<loc>", "</loc>")
-
-		// Process each sitemap in the index
-		for _, childSitemapURL := range sitemapURLs {
-			// Validate and normalise the child sitemap URL
-			childSitemapURL = util.NormaliseURL(childSitemapURL)
-			if childSitemapURL == "" {
+	if sitemap.Index {
+		// Validate and normalise each child sitemap URL
+		for _, childSitemapURL := range doc.Sitemaps {
+			normalised := util.NormaliseURL(childSitemapURL)
+			if normalised == "" {
 				log.Warn().Str("url", childSitemapURL).Msg("Invalid child sitemap URL, skipping")
 				continue
 			}
-
-			childURLs, err := c.ParseSitemap(ctx, childSitemapURL)
-			if err != nil {
-				log.Warn().Err(err).Str("url", childSitemapURL).Msg("Failed to parse child sitemap")
-				continue
-			}
-			urls = append(urls, childURLs...)
+			sitemap.Sitemaps = append(sitemap.Sitemaps, normalised)
 		}
 	} else {
-		// It's a regular sitemap
-		extractedURLs := extractURLsFromXML(content, "<url>", "</url>", "<loc>", "</loc>")
-
 		// Validate and normalise all extracted URLs
-		var validURLs []string
-		for _, extractedURL := range extractedURLs {
-			validURL := util.NormaliseURL(extractedURL)
+		for _, entry := range doc.URLs {
+			validURL := util.NormaliseURL(entry.URL)
 			if validURL != "" {
-				validURLs = append(validURLs, validURL)
+				entry.ListedScheme = listedScheme(entry.URL)
+				entry.URL = validURL
+				sitemap.URLs = append(sitemap.URLs, entry)
 			} else {
-				log.Debug().Str("invalid_url", extractedURL).Msg("Skipping invalid URL from sitemap")
+				log.Debug().Str("invalid_url", entry.URL).Msg("Skipping invalid URL from sitemap")
 			}
 		}
 
 		log.Debug().
 			Str("sitemap_url", sitemapURL).
-			Int("url_count", len(validURLs)).
+			Int("url_count", len(sitemap.URLs)).
 			Msg("Extracted valid URLs from regular sitemap")
-		urls = append(urls, validURLs...)
+	}
+
+	// Only sitemaps served with validators can be revalidated next time
+	if cache != nil && !sitemap.Validators.IsZero() {
+		cache.PutSitemap(ctx, sitemapURL, sitemap)
+	}
+
+	return c.collectSitemap(ctx, sitemapURL, sitemap, result)
+}
+
+// collectSitemap adds a parsed sitemap's URLs to result, loading each child
+// of an index in turn
+func (c *Crawler) collectSitemap(ctx context.Context, sitemapURL string, sitemap *CachedSitemap, result *SitemapParseResult) error {
+	if sitemap.Index {
+		for _, childSitemapURL := range sitemap.Sitemaps {
+			if err := c.parseSitemapInto(ctx, childSitemapURL, result); err != nil {
+				log.Warn().Err(err).Str("url", childSitemapURL).Msg("Failed to parse child sitemap")
+				result.Failed = append(result.Failed, childSitemapURL)
+			}
+		}
+	} else {
+		result.URLs = append(result.URLs, sitemap.URLs...)
+		result.Loaded++
 	}
 
 	log.Debug().
 		Str("sitemap_url", sitemapURL).
-		Int("total_url_count", len(urls)).
+		Int("total_url_count", len(result.URLs)).
 		Msg("Finished parsing sitemap")
 
-	return urls, nil
+	return nil
 }
 
-// Helper function to extract URLs from XML content
-func extractURLsFromXML(content, startTag, endTag, locStartTag, locEndTag string) []string {
-	var urls []string
+// fetchSitemap downloads and decodes a sitemap, decompressing it if gzip-encoded.
+// With previous validators the request is conditional, and a 304 comes back
+// as NotModified without a body.
+func (c *Crawler) fetchSitemap(ctx context.Context, sitemapURL string, previous Validators) (*sitemapResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", sitemapURL, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	// Find all instances of the outer tag
-	startIdx := 0
-	for {
-		startTagIdx := strings.Index(content[startIdx:], startTag)
-		if startTagIdx == -1 {
-			break
-		}
+	req.Header.Set("User-Agent", c.userAgent(ctx))
+	// Request gzip encoding if server supports it
+	req.Header.Set("Accept-Encoding", "gzip")
+	setAuthorization(ctx, &req.Header)
+	setConditionalHeaders(&req.Header, previous)
 
-		startTagIdx += startIdx
-		endTagIdx := strings.Index(content[startTagIdx:], endTag)
-		if endTagIdx == -1 {
-			break
+	paced, err := paceDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 30 * time.Second, CheckRedirect: checkRedirect(c.maxRedirects())}
+	resp, err := client.Do(req)
+	paced(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && !previous.IsZero() {
+		return &sitemapResponse{Validators: previous, NotModified: true}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &sitemapStatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retryAfterFromResponse(resp.StatusCode, &resp.Header),
 		}
+	}
+
+	// Decompress if gzip-encoded and decode as the body streams in, capped at
+	// the configured size
+	doc, err := readSitemap(resp, sitemapURL, c.maxSitemapSize(), c.maxSitemapURLs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sitemap %s: %w", sitemapURL, err)
+	}
+	return &sitemapResponse{
+		Document: doc,
+		Validators: Validators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		},
+	}, nil
+}
 
-		endTagIdx += startTagIdx
+// listedScheme returns the scheme a sitemap entry was listed with
+func listedScheme(rawURL string) string {
+	scheme, _, found := strings.Cut(strings.TrimSpace(rawURL), "://")
+	if !found {
+		return ""
+	}
+	switch scheme = strings.ToLower(scheme); scheme {
+	case "http", "https":
+		return scheme
+	}
+	return ""
+}
 
-		// Extract the section between tags
-		section := content[startTagIdx : endTagIdx+len(endTag)]
+// logSitemapTruncated warns that a sitemap listed more URLs than the
+// configured per-file cap and the rest were dropped
+func logSitemapTruncated(sitemapURL string, limit, listed int) {
+	log.Warn().
+		Str("sitemap_url", sitemapURL).
+		Int("listed", listed).
+		Int("kept", limit).
+		Msg("Sitemap exceeds the per-file URL limit, truncating")
+}
 
-		// Find the URL in the section
-		locStartIdx := strings.Index(section, locStartTag)
-		if locStartIdx != -1 {
-			locEndIdx := strings.Index(section[locStartIdx:], locEndTag)
-			if locEndIdx != -1 {
-				locEndIdx += locStartIdx
+// logSitemapOverProtocolLimit warns about a sitemap larger than the protocol
+// allows. It's still used in full, but the site should split it up.
+func logSitemapOverProtocolLimit(sitemapURL string, listed int) {
+	log.Warn().
+		Str("sitemap_url", sitemapURL).
+		Int("listed", listed).
+		Int("protocol_limit", sitemapProtocolMaxURLs).
+		Msg("Sitemap lists more URLs than the sitemap protocol allows")
+}
 
-				// Extract the URL
-				url := section[locStartIdx+len(locStartTag) : locEndIdx]
-				url = strings.TrimSpace(url)
+// lastModLayouts are the W3C Datetime forms the sitemap protocol allows
+var lastModLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
 
-				if url != "" {
-					urls = append(urls, url)
-				}
-			}
+// parseLastMod parses a sitemap <lastmod> value
+func parseLastMod(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range lastModLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), true
 		}
+	}
+	return time.Time{}, false
+}
 
-		startIdx = endTagIdx + len(endTag)
+// parseSitemapPriority parses a sitemap <priority>, which must be 0.0 to 1.0
+func parseSitemapPriority(value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	priority, err := strconv.ParseFloat(value, 64)
+	if err != nil || priority < 0 || priority > 1 {
+		return 0, false
 	}
+	return priority, true
+}
 
-	return urls
+// parseChangeFreq returns a valid sitemap <changefreq> in lowercase, or ""
+func parseChangeFreq(value string) string {
+	switch freq := strings.ToLower(value); freq {
+	case "always", "hourly", "daily", "weekly", "monthly", "yearly", "never":
+		return freq
+	default:
+		return ""
+	}
 }
 
 // FilterURLs filters URLs based on include/exclude patterns
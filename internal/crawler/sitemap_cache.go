@@ -0,0 +1,55 @@
+package crawler
+
+import (
+	"context"
+)
+
+// CachedSitemap is what a sitemap contained when it was last parsed, with the
+// validators it was served with
+type CachedSitemap struct {
+	Validators Validators
+	Index      bool         // A sitemap index, listing Sitemaps rather than URLs
+	Sitemaps   []string     // Child sitemaps of an index
+	URLs       []SitemapURL // Pages of a regular sitemap
+}
+
+// SitemapCache keeps parsed sitemaps between jobs. Lookups and stores are best
+// effort; a miss just means the sitemap is downloaded and parsed in full.
+type SitemapCache interface {
+	GetSitemap(ctx context.Context, sitemapURL string) (*CachedSitemap, bool)
+	PutSitemap(ctx context.Context, sitemapURL string, sitemap *CachedSitemap)
+}
+
+type sitemapCacheKey struct{}
+
+// WithSitemapCache makes sitemap parsing revalidate cached sitemaps with
+// If-None-Match/If-Modified-Since. A 304 reuses the cached contents instead of
+// re-downloading and re-parsing them. Index children are still revalidated
+// individually, since a shard can change without its index changing.
+func WithSitemapCache(ctx context.Context, cache SitemapCache) context.Context {
+	return context.WithValue(ctx, sitemapCacheKey{}, cache)
+}
+
+func sitemapCacheFromContext(ctx context.Context) SitemapCache {
+	cache, _ := ctx.Value(sitemapCacheKey{}).(SitemapCache)
+	return cache
+}
+
+// cachedSitemapFor returns the cached copy of sitemapURL, or nil without one
+func cachedSitemapFor(ctx context.Context, cache SitemapCache, sitemapURL string) *CachedSitemap {
+	if cache == nil {
+		return nil
+	}
+	cached, ok := cache.GetSitemap(ctx, sitemapURL)
+	if !ok || cached == nil || cached.Validators.IsZero() {
+		return nil
+	}
+	return cached
+}
+
+// sitemapResponse is a fetched sitemap, or a 304 confirming the cached copy
+type sitemapResponse struct {
+	Document    *sitemapDocument
+	Validators  Validators
+	NotModified bool
+}
@@ -0,0 +1,82 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySitemapCache struct {
+	mu       sync.Mutex
+	sitemaps map[string]*CachedSitemap
+}
+
+func (m *memorySitemapCache) GetSitemap(_ context.Context, sitemapURL string) (*CachedSitemap, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sitemap, ok := m.sitemaps[sitemapURL]
+	return sitemap, ok
+}
+
+func (m *memorySitemapCache) PutSitemap(_ context.Context, sitemapURL string, sitemap *CachedSitemap) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sitemaps[sitemapURL] = sitemap
+}
+
+func TestParseSitemapReusesCacheOnNotModified(t *testing.T) {
+	var bodies, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		bodies.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(retryTestSitemapXML))
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0"}}
+	cache := &memorySitemapCache{sitemaps: map[string]*CachedSitemap{}}
+	ctx := WithSitemapCache(context.Background(), cache)
+
+	first, err := c.ParseSitemapWithReport(ctx, server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	require.Len(t, first.URLs, 2)
+	assert.Equal(t, `"v1"`, cache.sitemaps[server.URL+"/sitemap.xml"].Validators.ETag)
+
+	second, err := c.ParseSitemapWithReport(ctx, server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Equal(t, first.URLs, second.URLs)
+	assert.Equal(t, 1, second.Loaded)
+	assert.Equal(t, int32(1), bodies.Load(), "unchanged sitemap isn't downloaded again")
+	assert.Equal(t, int32(1), notModified.Load())
+
+	// Without a cached copy there's nothing to revalidate, so it's a full fetch
+	_, err = c.ParseSitemapWithReport(context.Background(), server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), bodies.Load())
+}
+
+func TestParseSitemapSkipsCacheWithoutValidators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(retryTestSitemapXML))
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0"}}
+	cache := &memorySitemapCache{sitemaps: map[string]*CachedSitemap{}}
+
+	urls, err := c.ParseSitemap(WithSitemapCache(context.Background(), cache), server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Len(t, urls, 2)
+	assert.Empty(t, cache.sitemaps, "sitemaps without validators can't be revalidated")
+}
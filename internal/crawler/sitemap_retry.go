@@ -0,0 +1,85 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxSitemapRetryAfter caps how long a sitemap server's Retry-After can hold
+// up discovery
+const maxSitemapRetryAfter = 30 * time.Second
+
+// sitemapStatusError is a non-200 sitemap response
+type sitemapStatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *sitemapStatusError) Error() string {
+	return fmt.Sprintf("failed to fetch sitemap: %d", e.StatusCode)
+}
+
+// sitemapRetryPolicy returns how many times to try a sitemap and the delay
+// before the first retry, which doubles each attempt
+func (c *Crawler) sitemapRetryPolicy() (int, time.Duration) {
+	if c.config == nil {
+		return 1, 0
+	}
+	return max(c.config.RetryAttempts, 1), c.config.RetryDelay
+}
+
+// fetchSitemapWithRetry fetches a sitemap, retrying rate limits, server errors
+// and dropped connections with exponential backoff so a transient failure on
+// one shard doesn't drop its URLs from the job
+func (c *Crawler) fetchSitemapWithRetry(ctx context.Context, sitemapURL string, previous Validators) (*sitemapResponse, error) {
+	attempts, delay := c.sitemapRetryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.fetchSitemap(ctx, sitemapURL, previous)
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !isRetryableSitemapError(err) {
+			return resp, err
+		}
+
+		wait := delay
+		var statusErr *sitemapStatusError
+		if errors.As(err, &statusErr) {
+			wait = max(wait, min(statusErr.RetryAfter, maxSitemapRetryAfter))
+		}
+
+		log.Warn().
+			Err(err).
+			Str("sitemap_url", sitemapURL).
+			Int("attempt", attempt).
+			Dur("retry_in", wait).
+			Msg("Sitemap fetch failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// isRetryableSitemapError reports whether a sitemap fetch might succeed if
+// tried again. Not-found, oversized and TLS failures are permanent.
+func isRetryableSitemapError(err error) bool {
+	var statusErr *sitemapStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,77 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const retryTestSitemapXML = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<url><loc>https://example.com/page1</loc></url>
+	<url><loc>https://example.com/page2</loc></url>
+</urlset>`
+
+func TestParseSitemapRetriesTransientFailures(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky.xml":
+			if hits.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(retryTestSitemapXML))
+		default:
+			hits.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0", RetryAttempts: 3, RetryDelay: 5 * time.Millisecond}}
+
+	urls, err := c.ParseSitemap(context.Background(), server.URL+"/flaky.xml")
+	require.NoError(t, err)
+	assert.Len(t, urls, 2)
+	assert.Equal(t, int32(3), hits.Load())
+
+	hits.Store(0)
+	_, err = c.ParseSitemap(context.Background(), server.URL+"/missing.xml")
+	assert.EqualError(t, err, "failed to fetch sitemap: 404")
+	assert.Equal(t, int32(1), hits.Load(), "not-found isn't retried")
+}
+
+func TestParseSitemapWithReportRecordsFailedShards(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Children normalise to https, which this plain HTTP server can't serve
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<sitemap><loc>` + server.URL + `/pages.xml</loc></sitemap>
+	<sitemap><loc>` + server.URL + `/posts.xml</loc></sitemap>
+</sitemapindex>`))
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0"}}
+
+	result, err := c.ParseSitemapWithReport(context.Background(), server.URL+"/sitemap_index.xml")
+	require.NoError(t, err)
+	assert.Empty(t, result.URLs)
+	assert.Equal(t, 0, result.Loaded)
+	assert.Len(t, result.Failed, 2)
+}
+
+func TestIsRetryableSitemapError(t *testing.T) {
+	assert.True(t, isRetryableSitemapError(&sitemapStatusError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, isRetryableSitemapError(&sitemapStatusError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, isRetryableSitemapError(&sitemapStatusError{StatusCode: http.StatusNotFound}))
+	assert.False(t, isRetryableSitemapError(ErrSitemapTooLarge))
+}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -258,6 +262,163 @@ func TestParseSitemap(t *testing.T) {
 	}
 }
 
+func TestDecodeSitemap(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+  <url><loc>https://example.com/fresh</loc><lastmod>2026-10-15T09:30:00+10:00</lastmod></url>
+  <url>
+    <loc> https://example.com/dated </loc>
+    <lastmod>2026-09-01</lastmod>
+  </url>
+  <url><loc>https://example.com/undated</loc></url>
+  <url><loc>https://example.com/garbled</loc><lastmod>last tuesday</lastmod></url>
+  <url>
+    <loc>https://example.com/hinted</loc>
+    <changefreq> Daily </changefreq>
+    <priority>0.8</priority>
+    <image:image><image:loc>https://example.com/hinted.jpg</image:loc></image:image>
+  </url>
+  <url><loc>https://example.com/bad-hints</loc><changefreq>fortnightly</changefreq><priority>1.5</priority></url>
+</urlset>`
+
+	doc, err := decodeSitemap(strings.NewReader(content), "https://example.com/sitemap.xml", DefaultMaxSitemapURLs)
+	require.NoError(t, err)
+	assert.False(t, doc.Index)
+	assert.Zero(t, doc.Dropped)
+	entries := doc.URLs
+	require.Len(t, entries, 6)
+
+	assert.Equal(t, "https://example.com/fresh", entries[0].URL)
+	assert.Equal(t, time.Date(2026, 10, 14, 23, 30, 0, 0, time.UTC), entries[0].LastMod)
+	assert.Equal(t, "https://example.com/dated", entries[1].URL)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), entries[1].LastMod)
+	assert.True(t, entries[2].LastMod.IsZero(), "missing lastmod should stay zero")
+	assert.True(t, entries[3].LastMod.IsZero(), "unparseable lastmod should stay zero")
+	assert.Nil(t, entries[0].Priority, "missing priority should stay nil")
+	assert.Empty(t, entries[0].ChangeFreq)
+	require.NotNil(t, entries[4].Priority)
+	assert.Equal(t, 0.8, *entries[4].Priority)
+	assert.Equal(t, "daily", entries[4].ChangeFreq)
+	assert.Nil(t, entries[5].Priority, "out of range priority should be ignored")
+	assert.Empty(t, entries[5].ChangeFreq, "unknown changefreq should be ignored")
+
+	assert.Equal(t, []string{
+		"https://example.com/fresh",
+		"https://example.com/dated",
+		"https://example.com/undated",
+		"https://example.com/garbled",
+		"https://example.com/hinted",
+		"https://example.com/bad-hints",
+	}, SitemapURLStrings(entries))
+}
+
+func TestDecodeSitemapIndex(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/sitemap1.xml</loc><lastmod>2026-09-01</lastmod></sitemap>
+  <sitemap><loc> https://example.com/sitemap2.xml </loc></sitemap>
+  <sitemap></sitemap>
+</sitemapindex>`
+
+	doc, err := decodeSitemap(strings.NewReader(content), "https://example.com/sitemap_index.xml", DefaultMaxSitemapURLs)
+	require.NoError(t, err)
+	assert.True(t, doc.Index)
+	assert.Empty(t, doc.URLs)
+	assert.Equal(t, []string{"https://example.com/sitemap1.xml", "https://example.com/sitemap2.xml"}, doc.Sitemaps)
+}
+
+func TestDecodeSitemapKeepsEntriesBeforeMalformedXML(t *testing.T) {
+	content := `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/a</loc></url>
+  <url><loc>https://example.com/b</loc></url>
+  <url><loc>https://example.com/c</lo`
+
+	doc, err := decodeSitemap(strings.NewReader(content), "https://example.com/sitemap.xml", DefaultMaxSitemapURLs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, SitemapURLStrings(doc.URLs))
+}
+
+func TestDecodeSitemapStopsAtLimit(t *testing.T) {
+	var content strings.Builder
+	content.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	for i := range 10 {
+		fmt.Fprintf(&content, "<url><loc>https://example.com/page%d</loc></url>", i)
+	}
+	content.WriteString(`</urlset>`)
+
+	doc, err := decodeSitemap(strings.NewReader(content.String()), "https://example.com/sitemap.xml", 4)
+	require.NoError(t, err)
+	require.Len(t, doc.URLs, 4)
+	assert.Equal(t, "https://example.com/page3", doc.URLs[3].URL)
+	assert.Equal(t, 6, doc.Dropped)
+
+	doc, err = decodeSitemap(strings.NewReader(content.String()), "https://example.com/sitemap.xml", 10)
+	require.NoError(t, err)
+	assert.Len(t, doc.URLs, 10)
+	assert.Zero(t, doc.Dropped)
+}
+
+func TestParseSitemapTruncatesOversizedFiles(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content strings.Builder
+		if r.URL.Path == "/sitemap_index.xml" {
+			content.WriteString(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+			for i := range 5 {
+				fmt.Fprintf(&content, "<sitemap><loc>%s/sitemap%d.xml</loc></sitemap>", server.URL, i)
+			}
+			content.WriteString(`</sitemapindex>`)
+		} else {
+			content.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+			for i := range 5 {
+				fmt.Fprintf(&content, "<url><loc>https://example.com/page%d</loc></url>", i)
+			}
+			content.WriteString(`</urlset>`)
+		}
+		_, _ = w.Write([]byte(content.String()))
+	}))
+	defer server.Close()
+
+	c := &Crawler{config: &Config{UserAgent: "TestBot/1.0", MaxSitemapURLs: 3}}
+
+	urls, err := c.ParseSitemap(context.Background(), server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://example.com/page0",
+		"https://example.com/page1",
+		"https://example.com/page2",
+	}, SitemapURLStrings(urls))
+
+	// Only the first children of an oversized index are followed. Children
+	// normalise to https, which this plain HTTP server can't serve.
+	result, err := c.ParseSitemapWithReport(context.Background(), server.URL+"/sitemap_index.xml")
+	require.NoError(t, err)
+	assert.Len(t, result.Failed, 3)
+}
+
+func TestParseLastMod(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+		ok    bool
+	}{
+		{"2026-10-15T09:30:15.5Z", time.Date(2026, 10, 15, 9, 30, 15, 500000000, time.UTC), true},
+		{"2026-10-15T09:30+01:00", time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC), true},
+		{"2026-10-15T09:30:15", time.Date(2026, 10, 15, 9, 30, 15, 0, time.UTC), true},
+		{"2026-10-15", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"15/10/2026", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseLastMod(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
 func BenchmarkFilterURLs(b *testing.B) {
 	c := &Crawler{
 		config: &Config{},
@@ -336,43 +497,87 @@ func TestIsGzipContent(t *testing.T) {
 	}
 }
 
-func TestDecompressGzip(t *testing.T) {
-	t.Run("valid_gzip_data", func(t *testing.T) {
-		original := []byte("<?xml version=\"1.0\"?><urlset><url><loc>https://example.com/</loc></url></urlset>")
+func TestReadSitemap(t *testing.T) {
+	original := []byte("<?xml version=\"1.0\"?><urlset><url><loc>https://example.com/</loc></url></urlset>")
 
-		// Compress the data
+	gzipBytes := func(data []byte) []byte {
 		var buf bytes.Buffer
 		gzWriter := gzip.NewWriter(&buf)
-		_, err := gzWriter.Write(original)
+		_, err := gzWriter.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, gzWriter.Close())
+		return buf.Bytes()
+	}
+	response := func(body []byte, headers map[string]string) *http.Response {
+		resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(body))}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+	read := func(resp *http.Response, sizeLimit int64) (*sitemapDocument, error) {
+		return readSitemap(resp, "https://example.com/sitemap.xml.gz", sizeLimit, DefaultMaxSitemapURLs)
+	}
+
+	t.Run("valid_gzip_data", func(t *testing.T) {
+		doc, err := read(response(gzipBytes(original), nil), DefaultMaxSitemapSize)
 		require.NoError(t, err)
-		err = gzWriter.Close()
+		assert.Equal(t, []string{"https://example.com/"}, SitemapURLStrings(doc.URLs))
+	})
+
+	t.Run("double_gzip", func(t *testing.T) {
+		// A .gz file also served with Content-Encoding: gzip
+		resp := response(gzipBytes(gzipBytes(original)), map[string]string{"Content-Encoding": "gzip"})
+		doc, err := read(resp, DefaultMaxSitemapSize)
 		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.com/"}, SitemapURLStrings(doc.URLs))
+	})
 
-		// Decompress and verify
-		decompressed, err := decompressGzip(buf.Bytes())
+	t.Run("labelled_gzip_but_plain", func(t *testing.T) {
+		resp := response(original, map[string]string{"Content-Type": "application/gzip"})
+		doc, err := read(resp, DefaultMaxSitemapSize)
 		require.NoError(t, err)
-		assert.Equal(t, original, decompressed)
+		assert.Equal(t, []string{"https://example.com/"}, SitemapURLStrings(doc.URLs))
 	})
 
 	t.Run("invalid_gzip_data", func(t *testing.T) {
-		invalidData := []byte("not gzip data")
-		_, err := decompressGzip(invalidData)
+		corrupt := append([]byte{0x1f, 0x8b}, []byte("not gzip data")...)
+		_, err := read(response(corrupt, nil), DefaultMaxSitemapSize)
 		assert.Error(t, err)
 	})
 
 	t.Run("empty_gzip_data", func(t *testing.T) {
-		// Create empty gzip
-		var buf bytes.Buffer
-		gzWriter := gzip.NewWriter(&buf)
-		err := gzWriter.Close()
+		doc, err := read(response(gzipBytes(nil), nil), DefaultMaxSitemapSize)
 		require.NoError(t, err)
+		assert.Empty(t, doc.URLs)
+	})
 
-		decompressed, err := decompressGzip(buf.Bytes())
+	t.Run("gzip_bomb_capped", func(t *testing.T) {
+		bomb := gzipBytes(bytes.Repeat([]byte("a"), 1<<20))
+		_, err := read(response(bomb, nil), 1024)
+		assert.ErrorIs(t, err, ErrSitemapTooLarge)
+	})
+
+	t.Run("plain_body_capped", func(t *testing.T) {
+		_, err := read(response(original, nil), 10)
+		assert.ErrorIs(t, err, ErrSitemapTooLarge)
+	})
+
+	t.Run("declared_charset", func(t *testing.T) {
+		latin1 := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><urlset><url><loc>https://example.com/caf\xe9</loc></url></urlset>")
+		doc, err := read(response(latin1, nil), DefaultMaxSitemapSize)
 		require.NoError(t, err)
-		assert.Empty(t, decompressed)
+		assert.Equal(t, []string{"https://example.com/café"}, SitemapURLStrings(doc.URLs))
 	})
 }
 
+func TestIsGzipContentType(t *testing.T) {
+	assert.True(t, isGzipContentType("application/gzip"))
+	assert.True(t, isGzipContentType("application/x-gzip; charset=binary"))
+	assert.False(t, isGzipContentType("application/xml"))
+	assert.False(t, isGzipContentType(""))
+}
+
 func TestParseSitemapGzip(t *testing.T) {
 	// Helper to gzip content
 	gzipContent := func(content []byte) []byte {
@@ -460,3 +665,25 @@ func TestParseSitemapGzip(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveRobotsSitemapURL(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"absolute", "https://example.com/sitemap.xml", "https://example.com/sitemap.xml"},
+		{"other subdomain", "https://cdn.example.com/sitemaps/index.xml", "https://cdn.example.com/sitemaps/index.xml"},
+		{"http kept as-is", "http://example.com/sitemap.xml", "http://example.com/sitemap.xml"},
+		{"scheme relative", "//cdn.example.com/sitemap.xml", "https://cdn.example.com/sitemap.xml"},
+		{"relative path", "/sitemaps/pages.xml", "https://example.com/sitemaps/pages.xml"},
+		{"relative without slash", "sitemap.xml", "https://example.com/sitemap.xml"},
+		{"whitespace", "  https://example.com/sitemap.xml  ", "https://example.com/sitemap.xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveRobotsSitemapURL("example.com", tt.raw))
+		})
+	}
+}
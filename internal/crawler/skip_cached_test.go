@@ -0,0 +1,68 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEdgeCacheHit(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		want    bool
+	}{
+		{"cloudflare hit", http.StatusOK, map[string]string{"CF-Cache-Status": "HIT"}, true},
+		{"cloudflare miss", http.StatusOK, map[string]string{"CF-Cache-Status": "MISS"}, false},
+		{"cloudfront hit", http.StatusOK, map[string]string{"X-Cache": "Hit from cloudfront"}, true},
+		{"no cache headers", http.StatusOK, nil, false},
+		{"error status", http.StatusNotFound, map[string]string{"CF-Cache-Status": "HIT"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+			if got := edgeCacheHit(tt.status, headers); got != tt.want {
+				t.Errorf("edgeCacheHit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCachedSendsHeadProbe(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Expected HEAD request, got %s", r.Method)
+		}
+		if r.URL.Path == "/cached" {
+			w.Header().Set("CF-Cache-Status", "HIT")
+		} else {
+			w.Header().Set("CF-Cache-Status", "MISS")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+
+	cached, err := c.CheckCached(context.Background(), ts.URL+"/cached")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !cached {
+		t.Error("Expected HIT to report the page as cached")
+	}
+
+	cached, err = c.CheckCached(context.Background(), ts.URL+"/fresh")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cached {
+		t.Error("Expected MISS to report the page as not cached")
+	}
+}
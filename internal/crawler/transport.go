@@ -0,0 +1,56 @@
+package crawler
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Connection limits fail an unresponsive origin well inside the task timeout,
+// so the task can retry rather than spend its whole budget connecting
+const (
+	DefaultDialTimeout         = 10 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// dialTimeout bounds DNS resolution plus the TCP connect
+func (cfg *Config) dialTimeout() time.Duration {
+	if cfg != nil && cfg.DialTimeout > 0 {
+		return cfg.DialTimeout
+	}
+	return DefaultDialTimeout
+}
+
+func (cfg *Config) tlsHandshakeTimeout() time.Duration {
+	if cfg != nil && cfg.TLSHandshakeTimeout > 0 {
+		return cfg.TLSHandshakeTimeout
+	}
+	return DefaultTLSHandshakeTimeout
+}
+
+// responseHeaderTimeout bounds the wait for response headers once the request
+// is written. It's unset by default: a slow origin can take up to the job's
+// task timeout, which the request context already enforces.
+func (cfg *Config) responseHeaderTimeout() time.Duration {
+	if cfg != nil && cfg.ResponseHeaderTimeout > 0 {
+		return cfg.ResponseHeaderTimeout
+	}
+	return 0
+}
+
+// newTransport returns a transport with the configured connection timeouts,
+// dialling through the SSRF check unless it's disabled
+func newTransport(cfg *Config) *http.Transport {
+	transport := &http.Transport{
+		TLSHandshakeTimeout:   cfg.tlsHandshakeTimeout(),
+		ResponseHeaderTimeout: cfg.responseHeaderTimeout(),
+	}
+
+	if cfg == nil || !cfg.SkipSSRFCheck {
+		transport.DialContext = ssrfSafeDialContext(cfg.dialTimeout())
+	} else {
+		dialer := &net.Dialer{Timeout: cfg.dialTimeout(), KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	return transport
+}
@@ -0,0 +1,42 @@
+package crawler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransportTimeouts(t *testing.T) {
+	transport := newTransport(&Config{})
+	assert.Equal(t, DefaultTLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	assert.Zero(t, transport.ResponseHeaderTimeout, "the request deadline bounds slow origins")
+	assert.NotNil(t, transport.DialContext)
+
+	transport = newTransport(&Config{TLSHandshakeTimeout: 3 * time.Second, ResponseHeaderTimeout: 20 * time.Second})
+	assert.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout)
+	assert.Equal(t, 20*time.Second, transport.ResponseHeaderTimeout)
+
+	assert.Equal(t, DefaultDialTimeout, (*Config)(nil).dialTimeout())
+	assert.Equal(t, 2*time.Second, (&Config{DialTimeout: 2 * time.Second}).dialTimeout())
+}
+
+func TestResponseHeaderTimeoutFailsFast(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &http.Client{Transport: newTransport(&Config{SkipSSRFCheck: true, ResponseHeaderTimeout: 50 * time.Millisecond})}
+
+	start := time.Now()
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout awaiting response headers")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
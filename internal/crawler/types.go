@@ -1,6 +1,9 @@
 package crawler
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 // CacheCheckAttempt stores the result of a single cache status check.
 type CacheCheckAttempt struct {
@@ -18,8 +21,9 @@ type PerformanceMetrics struct {
 	ContentTransferTime int64 `json:"content_transfer_time"`
 }
 
-// MaxBodySampleSize is the maximum size of body sample stored for tech detection (50KB)
-const MaxBodySampleSize = 50 * 1024
+// DefaultBodySampleBytes is how much of each body is kept as BodySample for
+// tech detection and soft-404 checks when Config.BodySampleBytes is unset
+const DefaultBodySampleBytes = 50 * 1024
 
 // CrawlResult represents the result of a URL crawl operation
 type CrawlResult struct {
@@ -29,14 +33,18 @@ type CrawlResult struct {
 	Error               string              `json:"error,omitempty"`
 	Warning             string              `json:"warning,omitempty"`
 	CacheStatus         string              `json:"cache_status"`
+	OriginCacheStatus   string              `json:"origin_cache_status,omitempty"` // Origin/shield tier behind the edge, if visible
 	ContentType         string              `json:"content_type"`
 	ContentLength       int64               `json:"content_length"`
 	Headers             http.Header         `json:"headers"`
 	RedirectURL         string              `json:"redirect_url"`
+	RedirectChain       []string            `json:"redirect_chain,omitempty"` // Original URL then each redirect, when redirected
 	Performance         PerformanceMetrics  `json:"performance"`
 	Timestamp           int64               `json:"timestamp"`
 	RetryCount          int                 `json:"retry_count"`
+	RetryAfter          time.Duration       `json:"-"` // Parsed Retry-After on 429/503 responses, 0 when absent
 	SkippedCrawl        bool                `json:"skipped_crawl,omitempty"`
+	NotModified         bool                `json:"not_modified,omitempty"` // Conditional warm answered 304, no body transferred
 	Links               map[string][]string `json:"links,omitempty"`
 	SecondResponseTime  int64               `json:"second_response_time,omitempty"`
 	SecondCacheStatus   string              `json:"second_cache_status,omitempty"`
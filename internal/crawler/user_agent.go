@@ -0,0 +1,28 @@
+package crawler
+
+import "context"
+
+type userAgentKey struct{}
+
+// WithUserAgent overrides the crawler's user agent for requests made with the
+// returned context, for sites whose WAF only allows specific bot agents. An
+// empty userAgent leaves the crawler default in place.
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	if userAgent == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, userAgentKey{}, userAgent)
+}
+
+func userAgentFromContext(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey{}).(string)
+	return userAgent
+}
+
+// userAgent returns the context override, falling back to the configured agent
+func (c *Crawler) userAgent(ctx context.Context) string {
+	if userAgent := userAgentFromContext(ctx); userAgent != "" {
+		return userAgent
+	}
+	return c.config.UserAgent
+}
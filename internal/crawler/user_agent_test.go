@@ -0,0 +1,66 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWarmURLUserAgentOverride(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		agents []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agents = append(agents, r.UserAgent())
+		mu.Unlock()
+		w.Header().Set("CF-Cache-Status", "HIT")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+
+	if _, err := c.WarmURL(context.Background(), ts.URL, false); err != nil {
+		t.Fatalf("Expected default warm to succeed, got %v", err)
+	}
+	ctx := WithUserAgent(context.Background(), "ClientBot/2.0")
+	if _, err := c.WarmURL(ctx, ts.URL, false); err != nil {
+		t.Fatalf("Expected override warm to succeed, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(agents) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(agents))
+	}
+	if !strings.HasPrefix(agents[0], c.GetUserAgent()) {
+		t.Errorf("Expected default user agent, got %q", agents[0])
+	}
+	if agents[1] != "ClientBot/2.0" {
+		t.Errorf("Expected override user agent, got %q", agents[1])
+	}
+}
+
+func TestFetchSitemapUserAgentOverride(t *testing.T) {
+	var agent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agent = r.UserAgent()
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>` + "http://" + r.Host + `/</loc></url></urlset>`))
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+	ctx := WithUserAgent(context.Background(), "ClientBot/2.0")
+	if _, err := c.fetchSitemap(ctx, ts.URL+"/sitemap.xml", Validators{}); err != nil {
+		t.Fatalf("Expected sitemap fetch to succeed, got %v", err)
+	}
+	if agent != "ClientBot/2.0" {
+		t.Errorf("Expected override user agent on sitemap fetch, got %q", agent)
+	}
+}
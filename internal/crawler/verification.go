@@ -0,0 +1,82 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VerificationFilePath is where a site publishes its domain verification token
+const VerificationFilePath = "/.well-known/bluebandedbee-verification.txt"
+
+// maxVerificationFileSize caps how much of a verification file is read; the
+// token is a short hex string
+const maxVerificationFileSize = 4 * 1024
+
+// verificationFileTimeout bounds the whole verification file fetch
+const verificationFileTimeout = 15 * time.Second
+
+// VerificationFileURL returns the URL of a domain's verification file. A full
+// URL is used as the base as-is, otherwise the domain is fetched over HTTPS.
+func VerificationFileURL(domain string) string {
+	if strings.HasPrefix(domain, "http://") || strings.HasPrefix(domain, "https://") {
+		return strings.TrimSuffix(domain, "/") + VerificationFilePath
+	}
+	return "https://" + domain + VerificationFilePath
+}
+
+// FetchVerificationFile fetches a domain's verification file and returns its
+// trimmed contents. Redirects may move between the apex and www but never off
+// the domain, so an open redirect elsewhere can't vouch for it.
+func (c *Crawler) FetchVerificationFile(ctx context.Context, domain string) (string, error) {
+	fileURL := VerificationFileURL(domain)
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid verification file URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	client := &http.Client{
+		Timeout:       verificationFileTimeout,
+		Transport:     newTransport(c.config),
+		CheckRedirect: sameSiteRedirect(parsed.Hostname(), c.maxRedirects()),
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch verification file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("verification file returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxVerificationFileSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read verification file: %w", err)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// sameSiteRedirect allows redirects that stay on host, ignoring a www prefix
+func sameSiteRedirect(host string, maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	site := strings.TrimPrefix(host, "www.")
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("%w: stopped after %d redirects", ErrTooManyRedirects, maxRedirects)
+		}
+		if target := req.URL.Hostname(); strings.TrimPrefix(target, "www.") != site {
+			return fmt.Errorf("verification file redirected off %s to %s", host, target)
+		}
+		return nil
+	}
+}
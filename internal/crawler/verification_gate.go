@@ -0,0 +1,21 @@
+package crawler
+
+import "context"
+
+// VerificationGate is called once before the cache verification phase starts.
+// It may block until a verification slot is available and returns a function
+// that frees the slot when verification finishes.
+type VerificationGate func(ctx context.Context) (release func(), err error)
+
+type verificationGateKey struct{}
+
+// WithVerificationGate attaches a gate that WarmURL calls before verifying a
+// cache MISS. A nil gate removes any gate already on the context.
+func WithVerificationGate(ctx context.Context, gate VerificationGate) context.Context {
+	return context.WithValue(ctx, verificationGateKey{}, gate)
+}
+
+func verificationGateFromContext(ctx context.Context) VerificationGate {
+	gate, _ := ctx.Value(verificationGateKey{}).(VerificationGate)
+	return gate
+}
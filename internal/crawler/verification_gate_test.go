@@ -0,0 +1,64 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmURLCallsVerificationGateOnce(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("CF-Cache-Status", "MISS")
+		} else {
+			w.Header().Set("CF-Cache-Status", "HIT")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var gateCalls, releases int
+	ctx := WithVerificationGate(context.Background(), func(context.Context) (func(), error) {
+		gateCalls++
+		return func() { releases++ }, nil
+	})
+
+	if _, err := New(testConfig()).WarmURL(ctx, ts.URL, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gateCalls != 1 {
+		t.Errorf("Expected gate to be called once, got %d", gateCalls)
+	}
+	if releases != 1 {
+		t.Errorf("Expected slot to be released once, got %d", releases)
+	}
+}
+
+func TestWarmURLSkipsVerificationWhenGateFails(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("CF-Cache-Status", "MISS")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	ctx := WithVerificationGate(context.Background(), func(context.Context) (func(), error) {
+		return nil, errors.New("no slot")
+	})
+
+	result, err := New(testConfig()).WarmURL(ctx, ts.URL, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.CacheStatus != "MISS" {
+		t.Errorf("Expected cache status MISS, got %s", result.CacheStatus)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected only the initial request, got %d", got)
+	}
+}
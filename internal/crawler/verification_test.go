@@ -0,0 +1,69 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationFileURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/.well-known/bluebandedbee-verification.txt", VerificationFileURL("example.com"))
+	assert.Equal(t, "http://127.0.0.1:8080/.well-known/bluebandedbee-verification.txt", VerificationFileURL("http://127.0.0.1:8080/"))
+}
+
+func TestFetchVerificationFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != VerificationFilePath {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("  abc123\n"))
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+	content, err := c.FetchVerificationFile(context.Background(), ts.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", content)
+}
+
+func TestFetchVerificationFileMissing(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	c := New(testConfig())
+	_, err := c.FetchVerificationFile(context.Background(), ts.URL)
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func TestFetchVerificationFileRejectsOffSiteRedirect(t *testing.T) {
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("abc123"))
+	}))
+	defer elsewhere.Close()
+
+	// localhost and 127.0.0.1 are different hosts as far as the check goes
+	offsite := strings.Replace(elsewhere.URL, "127.0.0.1", "localhost", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, offsite+VerificationFilePath, http.StatusFound)
+	}))
+	defer ts.Close()
+
+	c := New(testConfig())
+	_, err := c.FetchVerificationFile(context.Background(), ts.URL)
+	assert.ErrorContains(t, err, "redirected off")
+}
+
+func TestSameSiteRedirectAllowsWWW(t *testing.T) {
+	check := sameSiteRedirect("example.com", DefaultMaxRedirects)
+	req := httptest.NewRequest(http.MethodGet, "https://www.example.com"+VerificationFilePath, nil)
+	assert.NoError(t, check(req, nil))
+
+	req = httptest.NewRequest(http.MethodGet, "https://example.org"+VerificationFilePath, nil)
+	assert.Error(t, check(req, nil))
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
 )
 
 var (
@@ -31,40 +33,91 @@ var (
 	ShutdownRetryDelay = 500 * time.Millisecond
 )
 
-func init() {
-	if val := strings.TrimSpace(os.Getenv("BBB_BATCH_CHANNEL_SIZE")); val != "" {
-		parsed, err := strconv.Atoi(val)
-		if err != nil {
-			log.Warn().Str("value", val).Msg("Failed to parse BBB_BATCH_CHANNEL_SIZE override")
-		} else {
-			if parsed < 500 {
-				log.Warn().Int("requested", parsed).Msg("BBB_BATCH_CHANNEL_SIZE below minimum, using 500")
-				parsed = 500
-			} else if parsed > 20000 {
-				log.Warn().Int("requested", parsed).Msg("BBB_BATCH_CHANNEL_SIZE above maximum, using 20000")
-				parsed = 20000
-			}
-			BatchChannelSize = parsed
-			log.Info().Int("channel_size", parsed).Msg("BBB_BATCH_CHANNEL_SIZE override applied")
-		}
+// Bounds for the batch settings that can be overridden from the environment
+const (
+	minBatchSize          = 10
+	maxBatchSize          = 1000
+	minBatchIntervalMS    = 100
+	maxBatchIntervalMS    = 10000
+	minBatchChannelSize   = 500
+	maxBatchChannelSize   = 20000
+	batchDepthSampleEvery = 5 * time.Second
+)
+
+// BatchConfig controls how task updates are buffered and flushed. Larger
+// batches mean fewer, bigger UPDATEs (less contention) at the cost of status
+// lag; shorter intervals do the opposite.
+type BatchConfig struct {
+	// MaxBatchSize is the number of updates that forces a flush
+	MaxBatchSize int
+	// MaxInterval is the longest an update waits before being flushed
+	MaxInterval time.Duration
+	// MaxBufferedUpdates is how many updates can queue before callers block
+	MaxBufferedUpdates int
+}
+
+// DefaultBatchConfig returns the package-level batch defaults
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		MaxBatchSize:       MaxBatchSize,
+		MaxInterval:        MaxBatchInterval,
+		MaxBufferedUpdates: BatchChannelSize,
 	}
+}
 
-	if val := strings.TrimSpace(os.Getenv("BBB_BATCH_MAX_INTERVAL_MS")); val != "" {
-		parsed, err := strconv.Atoi(val)
-		if err != nil {
-			log.Warn().Str("value", val).Msg("Failed to parse BBB_BATCH_MAX_INTERVAL_MS override")
-		} else {
-			if parsed < 100 {
-				log.Warn().Int("requested", parsed).Msg("BBB_BATCH_MAX_INTERVAL_MS below minimum, using 100ms")
-				parsed = 100
-			} else if parsed > 10000 {
-				log.Warn().Int("requested", parsed).Msg("BBB_BATCH_MAX_INTERVAL_MS above maximum, using 10s")
-				parsed = 10000
-			}
-			MaxBatchInterval = time.Duration(parsed) * time.Millisecond
-			log.Info().Int("interval_ms", parsed).Msg("BBB_BATCH_MAX_INTERVAL_MS override applied")
-		}
+// BatchConfigFromEnv returns the default batch config with any
+// BBB_BATCH_MAX_SIZE, BBB_BATCH_MAX_INTERVAL_MS and BBB_BATCH_CHANNEL_SIZE
+// overrides applied. Out-of-range values are clamped.
+func BatchConfigFromEnv() BatchConfig {
+	cfg := DefaultBatchConfig()
+	if parsed, ok := batchEnvInt("BBB_BATCH_MAX_SIZE", minBatchSize, maxBatchSize); ok {
+		cfg.MaxBatchSize = parsed
+	}
+	if parsed, ok := batchEnvInt("BBB_BATCH_MAX_INTERVAL_MS", minBatchIntervalMS, maxBatchIntervalMS); ok {
+		cfg.MaxInterval = time.Duration(parsed) * time.Millisecond
+	}
+	if parsed, ok := batchEnvInt("BBB_BATCH_CHANNEL_SIZE", minBatchChannelSize, maxBatchChannelSize); ok {
+		cfg.MaxBufferedUpdates = parsed
+	}
+	return cfg
+}
+
+// batchEnvInt reads an integer override clamped to [lower, upper]
+func batchEnvInt(name string, lower, upper int) (int, bool) {
+	val := strings.TrimSpace(os.Getenv(name))
+	if val == "" {
+		return 0, false
 	}
+
+	parsed, err := strconv.Atoi(val)
+	if err != nil {
+		log.Warn().Str("setting", name).Str("value", val).Msg("Failed to parse batch setting override")
+		return 0, false
+	}
+	if parsed < lower {
+		log.Warn().Str("setting", name).Int("requested", parsed).Int("minimum", lower).Msg("Batch setting below minimum, clamping")
+		parsed = lower
+	} else if parsed > upper {
+		log.Warn().Str("setting", name).Int("requested", parsed).Int("maximum", upper).Msg("Batch setting above maximum, clamping")
+		parsed = upper
+	}
+	log.Info().Str("setting", name).Int("value", parsed).Msg("Batch setting override applied")
+	return parsed, true
+}
+
+// withDefaults fills unset or invalid fields from DefaultBatchConfig
+func (cfg BatchConfig) withDefaults() BatchConfig {
+	defaults := DefaultBatchConfig()
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = defaults.MaxBatchSize
+	}
+	if cfg.MaxInterval <= 0 {
+		cfg.MaxInterval = defaults.MaxInterval
+	}
+	if cfg.MaxBufferedUpdates <= 0 {
+		cfg.MaxBufferedUpdates = defaults.MaxBufferedUpdates
+	}
+	return cfg
 }
 
 // isRetryableError determines if an error is infrastructure-related (should retry)
@@ -153,6 +206,7 @@ type QueueExecutor interface {
 // BatchManager coordinates batching of database operations
 type BatchManager struct {
 	queue            QueueExecutor
+	config           BatchConfig
 	updates          chan *TaskUpdate
 	stopCh           chan struct{}
 	wg               sync.WaitGroup
@@ -160,11 +214,19 @@ type BatchManager struct {
 	mu               sync.Mutex
 }
 
-// NewBatchManager creates a new batch manager
+// NewBatchManager creates a new batch manager with the default config
 func NewBatchManager(queue QueueExecutor) *BatchManager {
+	return NewBatchManagerWithConfig(queue, DefaultBatchConfig())
+}
+
+// NewBatchManagerWithConfig creates a new batch manager. Zero config fields
+// fall back to the defaults.
+func NewBatchManagerWithConfig(queue QueueExecutor, cfg BatchConfig) *BatchManager {
+	cfg = cfg.withDefaults()
 	bm := &BatchManager{
 		queue:   queue,
-		updates: make(chan *TaskUpdate, BatchChannelSize),
+		config:  cfg,
+		updates: make(chan *TaskUpdate, cfg.MaxBufferedUpdates),
 		stopCh:  make(chan struct{}),
 	}
 
@@ -173,14 +235,20 @@ func NewBatchManager(queue QueueExecutor) *BatchManager {
 	go bm.processUpdateBatches()
 
 	log.Info().
-		Int("max_batch_size", MaxBatchSize).
-		Dur("max_batch_interval", MaxBatchInterval).
-		Int("channel_size", BatchChannelSize).
+		Int("max_batch_size", cfg.MaxBatchSize).
+		Dur("max_batch_interval", cfg.MaxInterval).
+		Int("channel_size", cfg.MaxBufferedUpdates).
 		Msg("Batch manager started")
 
 	return bm
 }
 
+// QueueDepth returns the number of updates waiting in the channel, excluding
+// the batch currently being accumulated
+func (bm *BatchManager) QueueDepth() int {
+	return len(bm.updates)
+}
+
 // QueueTaskUpdate adds a task update to the batch queue
 func (bm *BatchManager) QueueTaskUpdate(task *Task) {
 	update := &TaskUpdate{
@@ -195,7 +263,7 @@ func (bm *BatchManager) QueueTaskUpdate(task *Task) {
 		// Channel full - this is critical, log and block
 		log.Error().
 			Str("task_id", task.ID).
-			Int("channel_size", BatchChannelSize).
+			Int("channel_size", bm.config.MaxBufferedUpdates).
 			Msg("Update batch channel full, blocking until space available")
 		bm.updates <- update // Block until space available
 	}
@@ -205,10 +273,12 @@ func (bm *BatchManager) QueueTaskUpdate(task *Task) {
 func (bm *BatchManager) processUpdateBatches() {
 	defer bm.wg.Done()
 
-	ticker := time.NewTicker(MaxBatchInterval)
+	ticker := time.NewTicker(bm.config.MaxInterval)
 	defer ticker.Stop()
+	depthTicker := time.NewTicker(batchDepthSampleEvery)
+	defer depthTicker.Stop()
 
-	batch := make([]*TaskUpdate, 0, MaxBatchSize)
+	batch := make([]*TaskUpdate, 0, bm.config.MaxBatchSize)
 
 	flush := func() {
 		if len(batch) == 0 {
@@ -289,14 +359,18 @@ func (bm *BatchManager) processUpdateBatches() {
 			batch = append(batch, update)
 
 			// Flush if batch is full
-			if len(batch) >= MaxBatchSize {
+			if len(batch) >= bm.config.MaxBatchSize {
 				flush()
-				ticker.Reset(MaxBatchInterval)
+				ticker.Reset(bm.config.MaxInterval)
 			}
 
 		case <-ticker.C:
 			flush()
 
+		case <-depthTicker.C:
+			// Includes updates held back by a failed flush
+			observability.RecordBatchQueueDepth(context.Background(), len(bm.updates)+len(batch), bm.config.MaxBufferedUpdates)
+
 		case <-bm.stopCh:
 			// Drain remaining updates
 			draining := true
@@ -671,6 +745,11 @@ func (bm *BatchManager) batchUpdateCompleted(ctx context.Context, tx *sql.Tx, ta
 	secondContentTransferTimes := make([]int64, len(tasks))
 	retryCounts := make([]int, len(tasks))
 	cacheCheckAttempts := make([]string, len(tasks))
+	originCacheStatuses := make([]string, len(tasks))
+	notModified := make([]bool, len(tasks))
+	soft404 := make([]bool, len(tasks))
+	contentHashes := make([]string, len(tasks))
+	contentChanged := make([]sql.NullBool, len(tasks))
 
 	for i, task := range tasks {
 		ids[i] = task.ID
@@ -678,6 +757,11 @@ func (bm *BatchManager) batchUpdateCompleted(ctx context.Context, tx *sql.Tx, ta
 		statusCodes[i] = task.StatusCode
 		responseTimes[i] = task.ResponseTime
 		cacheStatuses[i] = task.CacheStatus
+		originCacheStatuses[i] = task.OriginCacheStatus
+		notModified[i] = task.NotModified
+		soft404[i] = task.Soft404
+		contentHashes[i] = task.ContentHash
+		contentChanged[i] = task.ContentChanged
 		contentTypes[i] = task.ContentType
 		contentLengths[i] = task.ContentLength
 
@@ -746,7 +830,12 @@ func (bm *BatchManager) batchUpdateCompleted(ctx context.Context, tx *sql.Tx, ta
 			second_ttfb = updates.second_ttfb,
 			second_content_transfer_time = updates.second_content_transfer_time,
 			retry_count = updates.retry_count,
-			cache_check_attempts = updates.cache_check_attempts::jsonb
+			cache_check_attempts = updates.cache_check_attempts::jsonb,
+			origin_cache_status = updates.origin_cache_status,
+			not_modified = updates.not_modified,
+			soft_404 = updates.soft_404,
+			content_hash = NULLIF(updates.content_hash, ''),
+			content_changed = updates.content_changed
 		FROM (
 			SELECT
 				unnest($1::text[]) AS id,
@@ -773,7 +862,12 @@ func (bm *BatchManager) batchUpdateCompleted(ctx context.Context, tx *sql.Tx, ta
 				unnest($22::bigint[]) AS second_ttfb,
 				unnest($23::bigint[]) AS second_content_transfer_time,
 				unnest($24::integer[]) AS retry_count,
-				unnest($25::text[]) AS cache_check_attempts
+				unnest($25::text[]) AS cache_check_attempts,
+				unnest($26::text[]) AS origin_cache_status,
+				unnest($27::boolean[]) AS not_modified,
+				unnest($28::boolean[]) AS soft_404,
+				unnest($29::text[]) AS content_hash,
+				unnest($30::boolean[]) AS content_changed
 		) AS updates
 		WHERE tasks.id = updates.id
 	`
@@ -804,6 +898,11 @@ func (bm *BatchManager) batchUpdateCompleted(ctx context.Context, tx *sql.Tx, ta
 		pq.Array(secondContentTransferTimes),
 		pq.Array(retryCounts),
 		pq.Array(cacheCheckAttempts),
+		pq.Array(originCacheStatuses),
+		pq.Array(notModified),
+		pq.Array(soft404),
+		pq.Array(contentHashes),
+		pq.Array(contentChanged),
 	)
 
 	if err != nil {
@@ -880,17 +979,25 @@ func (bm *BatchManager) batchUpdateSkipped(ctx context.Context, tx *sql.Tx, task
 	}
 
 	ids := make([]string, len(tasks))
+	skipReasons := make([]string, len(tasks))
 	for i, task := range tasks {
 		ids[i] = task.ID
+		skipReasons[i] = task.SkipReason
 	}
 
 	query := `
 		UPDATE tasks
-		SET status = 'skipped'
-		WHERE id = ANY($1::text[])
+		SET status = 'skipped',
+		    skip_reason = NULLIF(updates.skip_reason, '')
+		FROM (
+			SELECT
+				unnest($1::text[]) AS id,
+				unnest($2::text[]) AS skip_reason
+		) AS updates
+		WHERE tasks.id = updates.id
 	`
 
-	_, err := tx.ExecContext(ctx, query, pq.Array(ids))
+	_, err := tx.ExecContext(ctx, query, pq.Array(ids), pq.Array(skipReasons))
 	if err != nil {
 		return err
 	}
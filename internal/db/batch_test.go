@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingExecutor struct {
+	calls atomic.Int32
+}
+
+func (e *countingExecutor) Execute(ctx context.Context, fn func(*sql.Tx) error) error {
+	e.calls.Add(1)
+	return nil
+}
+
+func (e *countingExecutor) ExecuteWithContext(ctx context.Context, fn func(context.Context, *sql.Tx) error) error {
+	e.calls.Add(1)
+	return nil
+}
+
+func TestBatchConfigFromEnv(t *testing.T) {
+	t.Setenv("BBB_BATCH_MAX_SIZE", "250")
+	t.Setenv("BBB_BATCH_MAX_INTERVAL_MS", "50")
+	t.Setenv("BBB_BATCH_CHANNEL_SIZE", "not-a-number")
+
+	cfg := BatchConfigFromEnv()
+	assert.Equal(t, 250, cfg.MaxBatchSize)
+	assert.Equal(t, minBatchIntervalMS*time.Millisecond, cfg.MaxInterval, "interval should clamp to the minimum")
+	assert.Equal(t, BatchChannelSize, cfg.MaxBufferedUpdates, "invalid values should keep the default")
+}
+
+func TestBatchConfigWithDefaults(t *testing.T) {
+	cfg := BatchConfig{MaxBatchSize: 5}.withDefaults()
+	assert.Equal(t, 5, cfg.MaxBatchSize)
+	assert.Equal(t, MaxBatchInterval, cfg.MaxInterval)
+	assert.Equal(t, BatchChannelSize, cfg.MaxBufferedUpdates)
+}
+
+func TestBatchManagerStopDrainsQueue(t *testing.T) {
+	executor := &countingExecutor{}
+	bm := NewBatchManagerWithConfig(executor, BatchConfig{
+		MaxBatchSize:       1000,
+		MaxInterval:        time.Hour,
+		MaxBufferedUpdates: 50,
+	})
+
+	for range 25 {
+		bm.QueueTaskUpdate(&Task{ID: "task", Status: "waiting"})
+	}
+	bm.Stop()
+
+	assert.Zero(t, bm.QueueDepth())
+	assert.Equal(t, int32(1), executor.calls.Load(), "remaining updates should flush once on shutdown")
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrCDNPurgeConnectionNotFound is returned when an organisation has no CDN purge connection
+var ErrCDNPurgeConnectionNotFound = errors.New("cdn purge connection not found")
+
+// CDNPurgeConnection represents an organisation's CDN purge configuration
+type CDNPurgeConnection struct {
+	ID              string
+	OrganisationID  string
+	Provider        string // "cloudflare" or "fastly"
+	ZoneID          string // Cloudflare zone ID or Fastly service ID
+	SoftPurge       bool
+	Enabled         bool
+	VaultSecretName string // Name of the secret in Supabase Vault
+	CreatedByUserID *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// UpsertCDNPurgeConnection creates or replaces the CDN purge connection for an organisation
+// Note: Use StoreCDNPurgeToken after saving the connection to store the API token in Vault
+func (db *DB) UpsertCDNPurgeConnection(ctx context.Context, conn *CDNPurgeConnection) error {
+	query := `
+		INSERT INTO cdn_purge_connections (
+			organisation_id, provider, zone_id, soft_purge, enabled, created_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (organisation_id)
+		DO UPDATE SET
+			provider = EXCLUDED.provider,
+			zone_id = EXCLUDED.zone_id,
+			soft_purge = EXCLUDED.soft_purge,
+			enabled = EXCLUDED.enabled
+		RETURNING id, created_at, updated_at
+	`
+
+	err := db.client.QueryRowContext(ctx, query,
+		conn.OrganisationID, conn.Provider, conn.ZoneID, conn.SoftPurge, conn.Enabled, conn.CreatedByUserID,
+	).Scan(&conn.ID, &conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		log.Error().Err(err).Str("organisation_id", conn.OrganisationID).Str("provider", conn.Provider).Msg("Failed to save cdn purge connection")
+		return fmt.Errorf("failed to save cdn purge connection: %w", err)
+	}
+
+	return nil
+}
+
+// StoreCDNPurgeToken stores a CDN API token in Supabase Vault
+func (db *DB) StoreCDNPurgeToken(ctx context.Context, connectionID, token string) error {
+	query := `SELECT store_cdn_purge_token($1::uuid, $2)`
+
+	// Function returns secret name but we don't need it - just scan to consume the result
+	if err := db.client.QueryRowContext(ctx, query, connectionID, token).Scan(new(string)); err != nil {
+		log.Error().Err(err).Str("connection_id", connectionID).Msg("Failed to store cdn purge token in vault")
+		return fmt.Errorf("failed to store cdn purge token: %w", err)
+	}
+
+	return nil
+}
+
+// GetCDNPurgeConnection retrieves the CDN purge connection for an organisation
+func (db *DB) GetCDNPurgeConnection(ctx context.Context, organisationID string) (*CDNPurgeConnection, error) {
+	conn := &CDNPurgeConnection{}
+	var vaultSecretName, createdByUserID sql.NullString
+
+	query := `
+		SELECT id, organisation_id, provider, zone_id, soft_purge, enabled,
+		       vault_secret_name, created_by_user_id, created_at, updated_at
+		FROM cdn_purge_connections
+		WHERE organisation_id = $1
+	`
+
+	err := db.client.QueryRowContext(ctx, query, organisationID).Scan(
+		&conn.ID, &conn.OrganisationID, &conn.Provider, &conn.ZoneID, &conn.SoftPurge, &conn.Enabled,
+		&vaultSecretName, &createdByUserID, &conn.CreatedAt, &conn.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCDNPurgeConnectionNotFound
+		}
+		log.Error().Err(err).Str("organisation_id", organisationID).Msg("Failed to get cdn purge connection")
+		return nil, fmt.Errorf("failed to get cdn purge connection: %w", err)
+	}
+
+	if vaultSecretName.Valid {
+		conn.VaultSecretName = vaultSecretName.String
+	}
+	if createdByUserID.Valid {
+		conn.CreatedByUserID = &createdByUserID.String
+	}
+
+	return conn, nil
+}
+
+// DeleteCDNPurgeConnection removes an organisation's CDN purge connection.
+// The Vault secret is removed by the delete trigger.
+func (db *DB) DeleteCDNPurgeConnection(ctx context.Context, organisationID string) error {
+	result, err := db.client.ExecContext(ctx, `
+		DELETE FROM cdn_purge_connections
+		WHERE organisation_id = $1
+	`, organisationID)
+	if err != nil {
+		log.Error().Err(err).Str("organisation_id", organisationID).Msg("Failed to delete cdn purge connection")
+		return fmt.Errorf("failed to delete cdn purge connection: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrCDNPurgeConnectionNotFound
+	}
+
+	return nil
+}
@@ -203,6 +203,7 @@ type JobListItem struct {
 	Domain                *string  `json:"domains,omitempty"` // For compatibility with frontend
 	DurationSeconds       *int     `json:"duration_seconds,omitempty"`
 	AvgTimePerTaskSeconds *float64 `json:"avg_time_per_task_seconds,omitempty"`
+	Tags                  []string `json:"tags,omitempty"`
 }
 
 // Domain represents the domain information for jobs
@@ -329,107 +330,6 @@ func (db *DB) ListJobs(organisationID string, limit, offset int, status, dateRan
 	return jobs, total, nil
 }
 
-// ListJobsWithOffset lists jobs for an organisation with timezone offset-based date filtering
-func (db *DB) ListJobsWithOffset(organisationID string, limit, offset int, status, dateRange string, tzOffsetMinutes int) ([]JobWithDomain, int, error) {
-	// Build the base query
-	baseQuery := `
-		FROM jobs j
-		LEFT JOIN domains d ON j.domain_id = d.id
-		WHERE j.organisation_id = $1`
-
-	args := []any{organisationID}
-	argCount := 1
-
-	// Add status filter if provided
-	if status != "" {
-		argCount++
-		baseQuery += fmt.Sprintf(" AND j.status = $%d", argCount)
-		args = append(args, status)
-	}
-
-	// Add date range filter if provided
-	if dateRange != "" {
-		startDate, endDate := calculateDateRangeWithOffset(dateRange, tzOffsetMinutes)
-		if startDate != nil {
-			argCount++
-			baseQuery += fmt.Sprintf(" AND j.created_at >= $%d", argCount)
-			args = append(args, *startDate)
-		}
-		if endDate != nil {
-			argCount++
-			baseQuery += fmt.Sprintf(" AND j.created_at <= $%d", argCount)
-			args = append(args, *endDate)
-		}
-	}
-
-	// Get total count
-	countQuery := "SELECT COUNT(*) " + baseQuery
-	var total int
-	err := db.client.QueryRow(countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
-	}
-
-	// Get jobs with pagination
-	// #nosec G202
-	selectQuery := `
-	SELECT
-		j.id, j.status, j.progress, j.total_tasks, j.completed_tasks,
-		j.failed_tasks, j.sitemap_tasks, j.found_tasks, j.created_at,
-		j.started_at, j.completed_at, d.name as domain_name,
-		j.duration_seconds,
-		CASE
-			WHEN j.completed_tasks > 0 AND j.duration_seconds IS NOT NULL THEN j.duration_seconds::double precision / NULLIF(j.completed_tasks, 0)
-			ELSE NULL
-		END AS avg_time_per_task_seconds
-	` + baseQuery
-	// #nosec G202
-	selectQuery += fmt.Sprintf(" ORDER BY j.created_at DESC LIMIT %d OFFSET %d", limit, offset)
-
-	rows, err := db.client.Query(selectQuery, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query jobs: %w", err)
-	}
-	defer rows.Close()
-
-	var jobs []JobWithDomain
-	for rows.Next() {
-		var job JobWithDomain
-		var startedAt, completedAt sql.NullString
-		var domainName sql.NullString
-
-		err := rows.Scan(
-			&job.ID, &job.Status, &job.Progress, &job.TotalTasks, &job.CompletedTasks,
-			&job.FailedTasks, &job.SitemapTasks, &job.FoundTasks, &job.CreatedAt,
-			&startedAt, &completedAt, &domainName,
-			&job.DurationSeconds, &job.AvgTimePerTaskSeconds,
-		)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to scan job row")
-			continue
-		}
-
-		// Handle nullable fields
-		if startedAt.Valid {
-			job.StartedAt = &startedAt.String
-		}
-		if completedAt.Valid {
-			job.CompletedAt = &completedAt.String
-		}
-		if domainName.Valid {
-			job.Domains = &Domain{Name: domainName.String}
-		}
-
-		jobs = append(jobs, job)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating job rows: %w", err)
-	}
-
-	return jobs, total, nil
-}
-
 // calculateDateRangeWithOffset calculates date range using UTC offset in minutes
 // offsetMinutes: negative for ahead of UTC (e.g., -660 for AEDT/UTC+11), positive for behind
 func calculateDateRangeWithOffset(dateRange string, offsetMinutes int) (*time.Time, *time.Time) {
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DomainStats aggregates crawl results for one domain across an organisation's jobs
+type DomainStats struct {
+	Domain         string     `json:"domain"`
+	Since          *time.Time `json:"since,omitempty"`
+	JobCount       int        `json:"job_count"`
+	PagesWarmed    int        `json:"pages_warmed"`
+	TasksCompleted int        `json:"tasks_completed"`
+	AvgTTFBMs      float64    `json:"avg_ttfb_ms"`
+	CacheHitRatio  float64    `json:"cache_hit_ratio"` // Share of first requests served from cache, 0-1
+	ClientErrors   int        `json:"client_errors"`   // 4xx responses
+	ServerErrors   int        `json:"server_errors"`   // 5xx responses
+}
+
+// GetDomainStats aggregates task results for a domain across all of an
+// organisation's jobs. A nil since covers all history.
+func (db *DB) GetDomainStats(ctx context.Context, organisationID, domain string, since *time.Time) (*DomainStats, error) {
+	query := `
+		SELECT
+			COUNT(DISTINCT t.job_id),
+			COUNT(DISTINCT t.page_id) FILTER (WHERE t.status = 'completed'),
+			COUNT(*) FILTER (WHERE t.status = 'completed'),
+			AVG(t.ttfb) FILTER (WHERE t.status = 'completed' AND t.ttfb > 0),
+			COUNT(*) FILTER (WHERE t.status = 'completed' AND COALESCE(t.cache_status, '') <> ''),
+			COUNT(*) FILTER (WHERE t.status = 'completed' AND UPPER(t.cache_status) = 'HIT'),
+			COUNT(*) FILTER (WHERE t.status_code BETWEEN 400 AND 499),
+			COUNT(*) FILTER (WHERE t.status_code BETWEEN 500 AND 599)
+		FROM tasks t
+		JOIN jobs j ON t.job_id = j.id
+		JOIN pages p ON t.page_id = p.id
+		JOIN domains d ON p.domain_id = d.id
+		WHERE j.organisation_id = $1
+			AND d.name = $2
+			AND ($3::timestamp IS NULL OR t.completed_at >= $3)
+	`
+
+	stats := &DomainStats{Domain: domain, Since: since}
+	var avgTTFB sql.NullFloat64
+	var cacheChecked, cacheHits int
+
+	err := db.client.QueryRowContext(ctx, query, organisationID, domain, since).Scan(
+		&stats.JobCount,
+		&stats.PagesWarmed,
+		&stats.TasksCompleted,
+		&avgTTFB,
+		&cacheChecked,
+		&cacheHits,
+		&stats.ClientErrors,
+		&stats.ServerErrors,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain stats: %w", err)
+	}
+
+	if avgTTFB.Valid {
+		stats.AvgTTFBMs = avgTTFB.Float64
+	}
+	if cacheChecked > 0 {
+		stats.CacheHitRatio = float64(cacheHits) / float64(cacheChecked)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DomainTechnologies is the tech stack last detected for a domain. Detection
+// runs on the first successful page a worker warms for the domain, so
+// DetectedAt is nil until the domain has been warmed.
+type DomainTechnologies struct {
+	Domain       string              `json:"domain"`
+	Technologies map[string][]string `json:"technologies"` // Name to categories, e.g. {"WordPress": ["CMS"]}
+	Headers      map[string][]string `json:"headers"`      // Response headers of the page detection ran on
+	HTMLPath     string              `json:"html_path,omitempty"`
+	DetectedAt   *time.Time          `json:"detected_at"`
+}
+
+// GetDomainTechnologies reads the stored technology detection for a domain
+func (db *DB) GetDomainTechnologies(ctx context.Context, domainID int) (*DomainTechnologies, error) {
+	var technologies, headers []byte
+	var htmlPath sql.NullString
+	var detectedAt sql.NullTime
+
+	tech := &DomainTechnologies{
+		Technologies: map[string][]string{},
+		Headers:      map[string][]string{},
+	}
+	err := db.client.QueryRowContext(ctx, `
+		SELECT name, technologies, tech_headers, tech_html_path, tech_detected_at
+		FROM domains
+		WHERE id = $1
+	`, domainID).Scan(&tech.Domain, &technologies, &headers, &htmlPath, &detectedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain technologies: %w", err)
+	}
+
+	if len(technologies) > 0 {
+		if err := json.Unmarshal(technologies, &tech.Technologies); err != nil {
+			return nil, fmt.Errorf("failed to decode domain technologies: %w", err)
+		}
+	}
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &tech.Headers); err != nil {
+			return nil, fmt.Errorf("failed to decode domain tech headers: %w", err)
+		}
+	}
+	tech.HTMLPath = htmlPath.String
+	if detectedAt.Valid {
+		tech.DetectedAt = &detectedAt.Time
+	}
+
+	return tech, nil
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPendingGASessionNotFound is returned when a pending Google Analytics
+// session is missing or has expired
+var ErrPendingGASessionNotFound = errors.New("pending google analytics session not found")
+
+// StorePendingGASession saves or replaces a pending Google Analytics OAuth
+// session. data is the JSON-encoded session payload.
+func (db *DB) StorePendingGASession(ctx context.Context, sessionID string, data []byte, expiresAt time.Time) error {
+	query := `
+		INSERT INTO google_analytics_pending_sessions (id, data, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET
+			data = EXCLUDED.data,
+			expires_at = EXCLUDED.expires_at
+	`
+	if _, err := db.client.ExecContext(ctx, query, sessionID, data, expiresAt); err != nil {
+		return fmt.Errorf("failed to store pending GA session: %w", err)
+	}
+	return nil
+}
+
+// StorePendingGATokens saves a pending session's OAuth tokens in Vault. The
+// session row must already exist. tokens is the JSON-encoded token pair.
+func (db *DB) StorePendingGATokens(ctx context.Context, sessionID string, tokens []byte) error {
+	query := `SELECT store_ga_pending_tokens($1::uuid, $2)`
+	var secretName string
+	if err := db.client.QueryRowContext(ctx, query, sessionID, string(tokens)).Scan(&secretName); err != nil {
+		return fmt.Errorf("failed to store pending GA tokens: %w", err)
+	}
+	return nil
+}
+
+// GetPendingGASession returns the payload, Vault-held tokens and expiry of an
+// unexpired pending session, or ErrPendingGASessionNotFound. tokens is nil
+// when none were stored.
+func (db *DB) GetPendingGASession(ctx context.Context, sessionID string) (data, tokens []byte, expiresAt time.Time, err error) {
+	query := `
+		SELECT data,
+		       CASE WHEN vault_secret_name IS NOT NULL THEN get_ga_pending_tokens(id) END,
+		       expires_at
+		FROM google_analytics_pending_sessions
+		WHERE id = $1 AND expires_at > NOW()
+	`
+	var rawTokens sql.NullString
+	err = db.client.QueryRowContext(ctx, query, sessionID).Scan(&data, &rawTokens, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, time.Time{}, ErrPendingGASessionNotFound
+	}
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("failed to get pending GA session: %w", err)
+	}
+	if rawTokens.Valid {
+		tokens = []byte(rawTokens.String)
+	}
+	return data, tokens, expiresAt, nil
+}
+
+// DeletePendingGASession removes a pending session and, via trigger, its Vault
+// secret; a missing session is not an error
+func (db *DB) DeletePendingGASession(ctx context.Context, sessionID string) error {
+	query := `DELETE FROM google_analytics_pending_sessions WHERE id = $1`
+	if _, err := db.client.ExecContext(ctx, query, sessionID); err != nil {
+		return fmt.Errorf("failed to delete pending GA session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredPendingGASessions removes expired pending sessions and their
+// Vault secrets, returning how many were deleted
+func (db *DB) DeleteExpiredPendingGASessions(ctx context.Context) (int64, error) {
+	query := `DELETE FROM google_analytics_pending_sessions WHERE expires_at <= NOW()`
+	result, err := db.client.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired pending GA sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrHealthWarningNotFound is returned when a health warning doesn't exist
+var ErrHealthWarningNotFound = errors.New("health warning not found")
+
+// Health warning types raised by the health monitor
+const (
+	HealthWarningStuckJobs      = "stuck_jobs"
+	HealthWarningStuckTasks     = "stuck_tasks"
+	HealthWarningCounterLeak    = "counter_leak"
+	HealthWarningCleanupBacklog = "cleanup_backlog"
+)
+
+// HealthWarning is an operational issue detected by the health monitor.
+// Each warning type has at most one open (unresolved) row at a time.
+type HealthWarning struct {
+	ID             int64           `json:"id"`
+	Type           string          `json:"type"`
+	Message        string          `json:"message"`
+	AffectedCount  int             `json:"affected_count"`
+	Details        json.RawMessage `json:"details"`
+	Occurrences    int             `json:"occurrences"`
+	FirstSeenAt    time.Time       `json:"first_seen_at"`
+	LastSeenAt     time.Time       `json:"last_seen_at"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *string         `json:"acknowledged_by,omitempty"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+}
+
+// RecordHealthWarning opens a warning of the given type, or refreshes the
+// open one. Acknowledgement is kept so known issues stay quiet while ongoing.
+func (db *DB) RecordHealthWarning(ctx context.Context, warningType, message string, affectedCount int, details map[string]any) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal health warning details: %w", err)
+	}
+
+	_, err = db.client.ExecContext(ctx, `
+		INSERT INTO health_warnings (warning_type, message, affected_count, details)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (warning_type) WHERE resolved_at IS NULL
+		DO UPDATE SET
+			message = EXCLUDED.message,
+			affected_count = EXCLUDED.affected_count,
+			details = EXCLUDED.details,
+			occurrences = health_warnings.occurrences + 1,
+			last_seen_at = NOW()
+	`, warningType, message, affectedCount, detailsJSON)
+	if err != nil {
+		log.Error().Err(err).Str("warning_type", warningType).Msg("Failed to record health warning")
+		return fmt.Errorf("failed to record health warning: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveHealthWarning closes the open warning of the given type, if any.
+// A later recurrence opens a fresh, unacknowledged warning.
+func (db *DB) ResolveHealthWarning(ctx context.Context, warningType string) error {
+	_, err := db.client.ExecContext(ctx, `
+		UPDATE health_warnings
+		SET resolved_at = NOW()
+		WHERE warning_type = $1 AND resolved_at IS NULL
+	`, warningType)
+	if err != nil {
+		log.Error().Err(err).Str("warning_type", warningType).Msg("Failed to resolve health warning")
+		return fmt.Errorf("failed to resolve health warning: %w", err)
+	}
+
+	return nil
+}
+
+// ListHealthWarnings returns open warnings, most recently seen first.
+// includeResolved adds resolved warnings up to limit.
+func (db *DB) ListHealthWarnings(ctx context.Context, includeResolved bool, limit int) ([]*HealthWarning, error) {
+	rows, err := db.client.QueryContext(ctx, `
+		SELECT id, warning_type, message, affected_count, details, occurrences,
+		       first_seen_at, last_seen_at, acknowledged_at, acknowledged_by, resolved_at
+		FROM health_warnings
+		WHERE resolved_at IS NULL OR $1
+		ORDER BY resolved_at IS NOT NULL, last_seen_at DESC
+		LIMIT $2
+	`, includeResolved, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list health warnings")
+		return nil, fmt.Errorf("failed to list health warnings: %w", err)
+	}
+	defer rows.Close()
+
+	var warnings []*HealthWarning
+	for rows.Next() {
+		warning, err := scanHealthWarning(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan health warning: %w", err)
+		}
+		warnings = append(warnings, warning)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating health warnings: %w", err)
+	}
+
+	return warnings, nil
+}
+
+// AcknowledgeHealthWarning marks a warning as acknowledged by userID.
+// Re-acknowledging keeps the original acknowledgement.
+func (db *DB) AcknowledgeHealthWarning(ctx context.Context, warningID int64, userID string) (*HealthWarning, error) {
+	row := db.client.QueryRowContext(ctx, `
+		UPDATE health_warnings
+		SET acknowledged_at = COALESCE(acknowledged_at, NOW()),
+		    acknowledged_by = COALESCE(acknowledged_by, $2::uuid)
+		WHERE id = $1
+		RETURNING id, warning_type, message, affected_count, details, occurrences,
+		          first_seen_at, last_seen_at, acknowledged_at, acknowledged_by, resolved_at
+	`, warningID, userID)
+
+	warning, err := scanHealthWarning(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrHealthWarningNotFound
+		}
+		log.Error().Err(err).Int64("warning_id", warningID).Msg("Failed to acknowledge health warning")
+		return nil, fmt.Errorf("failed to acknowledge health warning: %w", err)
+	}
+
+	return warning, nil
+}
+
+func scanHealthWarning(scanner interface{ Scan(...any) error }) (*HealthWarning, error) {
+	warning := &HealthWarning{}
+	var details []byte
+	var acknowledgedAt, resolvedAt sql.NullTime
+	var acknowledgedBy sql.NullString
+
+	if err := scanner.Scan(
+		&warning.ID, &warning.Type, &warning.Message, &warning.AffectedCount, &details, &warning.Occurrences,
+		&warning.FirstSeenAt, &warning.LastSeenAt, &acknowledgedAt, &acknowledgedBy, &resolvedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	warning.Details = json.RawMessage(details)
+	if acknowledgedAt.Valid {
+		warning.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if acknowledgedBy.Valid {
+		warning.AcknowledgedBy = &acknowledgedBy.String
+	}
+	if resolvedAt.Valid {
+		warning.ResolvedAt = &resolvedAt.Time
+	}
+
+	return warning, nil
+}
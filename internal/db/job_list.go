@@ -0,0 +1,217 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Job list sort columns
+const (
+	JobSortCreatedAt   = "created_at"
+	JobSortCompletedAt = "completed_at"
+)
+
+// ErrInvalidJobCursor is returned when a job list cursor can't be decoded
+var ErrInvalidJobCursor = errors.New("invalid cursor")
+
+// JobListOptions filters, sorts and pages an organisation's jobs
+type JobListOptions struct {
+	Limit           int
+	Offset          int    // Ignored when Cursor is set
+	Cursor          string // Opaque keyset position from a previous page's NextCursor
+	Status          string
+	Domain          string
+	Tag             string // Only jobs carrying this tag
+	DateRange       string
+	TZOffsetMinutes int
+	SortBy          string // created_at (default) or completed_at
+	Ascending       bool
+}
+
+// JobListPage is one page of a job listing
+type JobListPage struct {
+	Jobs       []JobWithDomain
+	Total      int    // Jobs matching the filters, across all pages
+	HasNext    bool   // More jobs follow this page
+	NextCursor string // Pass as Cursor to fetch the next page; empty on the last page
+}
+
+// jobListCursor is the sort value and id of the last job on a page. An empty
+// value means the sort column was NULL (unfinished jobs sorted by completed_at).
+type jobListCursor struct {
+	value string
+	id    string
+}
+
+func encodeJobListCursor(c jobListCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.value + "|" + c.id))
+}
+
+func decodeJobListCursor(s string) (jobListCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return jobListCursor{}, ErrInvalidJobCursor
+	}
+	value, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return jobListCursor{}, ErrInvalidJobCursor
+	}
+	return jobListCursor{value: value, id: id}, nil
+}
+
+// ListJobsPage lists an organisation's jobs. Cursor paging is keyset-based on
+// (sort column, id) so deep pages stay fast; offset paging remains for the
+// dashboard's numbered pages.
+func (db *DB) ListJobsPage(organisationID string, opts JobListOptions) (*JobListPage, error) {
+	sortColumn := "j.created_at"
+	if opts.SortBy == JobSortCompletedAt {
+		sortColumn = "j.completed_at"
+	}
+	direction, comparison := "DESC", "<"
+	if opts.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	// Build the filters shared by the count and page queries
+	baseQuery := `
+		FROM jobs j
+		LEFT JOIN domains d ON j.domain_id = d.id
+		WHERE j.organisation_id = $1`
+
+	args := []any{organisationID}
+
+	if opts.Status != "" {
+		args = append(args, opts.Status)
+		baseQuery += fmt.Sprintf(" AND j.status = $%d", len(args))
+	}
+
+	if opts.Domain != "" {
+		args = append(args, opts.Domain)
+		baseQuery += fmt.Sprintf(" AND d.name = $%d", len(args))
+	}
+
+	if opts.Tag != "" {
+		args = append(args, opts.Tag)
+		baseQuery += fmt.Sprintf(" AND j.tags ? $%d", len(args))
+	}
+
+	if opts.DateRange != "" {
+		startDate, endDate := calculateDateRangeWithOffset(opts.DateRange, opts.TZOffsetMinutes)
+		if startDate != nil {
+			args = append(args, *startDate)
+			baseQuery += fmt.Sprintf(" AND j.created_at >= $%d", len(args))
+		}
+		if endDate != nil {
+			args = append(args, *endDate)
+			baseQuery += fmt.Sprintf(" AND j.created_at <= $%d", len(args))
+		}
+	}
+
+	page := &JobListPage{}
+	if err := db.client.QueryRow("SELECT COUNT(*) "+baseQuery, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	pageQuery := baseQuery
+	pageArgs := args
+	offset := opts.Offset
+	if opts.Cursor != "" {
+		cursor, err := decodeJobListCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		offset = 0
+
+		// NULLs sort last in both directions, so a NULL cursor only has
+		// NULL rows after it and a non-NULL cursor is followed by them all
+		if cursor.value == "" {
+			pageArgs = append(pageArgs, cursor.id)
+			pageQuery += fmt.Sprintf(" AND %s IS NULL AND j.id %s $%d", sortColumn, comparison, len(pageArgs))
+		} else {
+			pageArgs = append(pageArgs, cursor.value, cursor.id)
+			pageQuery += fmt.Sprintf(" AND ((%s, j.id) %s ($%d::timestamptz, $%d) OR %s IS NULL)",
+				sortColumn, comparison, len(pageArgs)-1, len(pageArgs), sortColumn)
+		}
+	}
+
+	// Fetch one extra row to tell whether another page follows
+	// #nosec G202 -- sort column and direction come from fixed values above
+	selectQuery := `
+	SELECT
+		j.id, j.status, j.progress, j.total_tasks, j.completed_tasks,
+		j.failed_tasks, j.sitemap_tasks, j.found_tasks, j.created_at,
+		j.started_at, j.completed_at, d.name as domain_name,
+		j.duration_seconds, j.tags,
+		CASE
+			WHEN j.completed_tasks > 0 AND j.duration_seconds IS NOT NULL THEN j.duration_seconds::double precision / NULLIF(j.completed_tasks, 0)
+			ELSE NULL
+		END AS avg_time_per_task_seconds
+	` + pageQuery + fmt.Sprintf(" ORDER BY %s %s NULLS LAST, j.id %s LIMIT %d OFFSET %d",
+		sortColumn, direction, direction, opts.Limit+1, offset)
+
+	rows, err := db.client.Query(selectQuery, pageArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var job JobWithDomain
+		var startedAt, completedAt sql.NullString
+		var domainName sql.NullString
+		var tags []byte
+
+		err := rows.Scan(
+			&job.ID, &job.Status, &job.Progress, &job.TotalTasks, &job.CompletedTasks,
+			&job.FailedTasks, &job.SitemapTasks, &job.FoundTasks, &job.CreatedAt,
+			&startedAt, &completedAt, &domainName,
+			&job.DurationSeconds, &tags, &job.AvgTimePerTaskSeconds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job row: %w", err)
+		}
+
+		// Handle nullable fields
+		if startedAt.Valid {
+			job.StartedAt = &startedAt.String
+		}
+		if completedAt.Valid {
+			job.CompletedAt = &completedAt.String
+		}
+		if domainName.Valid {
+			job.Domains = &Domain{Name: domainName.String}
+		}
+		if len(tags) > 0 {
+			if err := json.Unmarshal(tags, &job.Tags); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal job tags: %w", err)
+			}
+		}
+
+		page.Jobs = append(page.Jobs, job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job rows: %w", err)
+	}
+
+	if len(page.Jobs) > opts.Limit {
+		page.Jobs = page.Jobs[:opts.Limit]
+		page.HasNext = true
+
+		last := page.Jobs[len(page.Jobs)-1]
+		cursor := jobListCursor{value: last.CreatedAt, id: last.ID}
+		if opts.SortBy == JobSortCompletedAt {
+			cursor.value = ""
+			if last.CompletedAt != nil {
+				cursor.value = *last.CompletedAt
+			}
+		}
+		page.NextCursor = encodeJobListCursor(cursor)
+	}
+
+	return page, nil
+}
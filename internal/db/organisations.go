@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // OrganisationMember represents a user membership within an organisation.
@@ -424,6 +426,55 @@ func (db *DB) GetOrganisationPlanID(ctx context.Context, organisationID string)
 	return planID, nil
 }
 
+// GetOrganisationCrawlDenyHosts returns the host patterns an organisation
+// never crawls.
+func (db *DB) GetOrganisationCrawlDenyHosts(ctx context.Context, organisationID string) ([]string, error) {
+	query := `
+		SELECT crawl_deny_hosts
+		FROM organisations
+		WHERE id = $1
+	`
+
+	var hosts []string
+	if err := db.client.QueryRowContext(ctx, query, organisationID).Scan(pq.Array(&hosts)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("organisation not found")
+		}
+		return nil, fmt.Errorf("failed to fetch crawl deny list: %w", err)
+	}
+
+	return hosts, nil
+}
+
+// SetOrganisationCrawlDenyHosts replaces an organisation's crawl deny list.
+// Running jobs keep the list they loaded when they started.
+func (db *DB) SetOrganisationCrawlDenyHosts(ctx context.Context, organisationID string, hosts []string) error {
+	if hosts == nil {
+		hosts = []string{}
+	}
+
+	query := `
+		UPDATE organisations
+		SET crawl_deny_hosts = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := db.client.ExecContext(ctx, query, organisationID, pq.Array(hosts))
+	if err != nil {
+		return fmt.Errorf("failed to update crawl deny list: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("organisation not found")
+	}
+
+	return nil
+}
+
 // ListDailyUsage returns daily usage rows for an organisation within a date range.
 func (db *DB) ListDailyUsage(ctx context.Context, organisationID string, startDate, endDate time.Time) ([]DailyUsageEntry, error) {
 	query := `
@@ -18,6 +18,7 @@ type Page struct {
 	ID       int
 	Path     string
 	Priority float64
+	Depth    int // Links followed to reach the page; 0 for root, sitemap and seed URLs
 }
 
 // TransactionExecutor interface for types that can execute transactions
@@ -60,7 +61,7 @@ func CreatePageRecords(ctx context.Context, q TransactionExecutor, domainID int,
 	}
 
 	for _, u := range urls {
-		path, err := normaliseURLPath(u, domain)
+		path, err := NormaliseURLPath(u, domain)
 		if err != nil {
 			log.Warn().Err(err).Str("url", u).Msg("Skipping invalid URL")
 			continue
@@ -140,7 +141,11 @@ func ensurePageBatch(ctx context.Context, q TransactionExecutor, domainID int, b
 	})
 }
 
-func normaliseURLPath(u string, domain string) (string, error) {
+// NormaliseURLPath resolves a URL against the domain and returns the path used
+// as the page key. The query string and fragment are dropped, so faceted
+// variants such as ?sort= or ?page= collapse onto a single page (and, through
+// the tasks (job_id, page_id) unique index, a single task per job).
+func NormaliseURLPath(u string, domain string) (string, error) {
 	parsedURL, err := url.Parse(u)
 	if err != nil {
 		return "", err
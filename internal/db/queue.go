@@ -52,6 +52,25 @@ type DbQueue struct {
 // a connection before the caller's context expires.
 var ErrPoolSaturated = errors.New("database connection pool saturated")
 
+// PoolBusyRetryAfter is how long clients are asked to wait after a write is
+// shed because the pool is saturated
+const PoolBusyRetryAfter = 3 * time.Second
+
+// PoolBusyError rejects a write up front while the connection pool is
+// saturated, rather than letting it queue behind other work until the
+// caller times out. It matches ErrPoolSaturated with errors.Is.
+type PoolBusyError struct {
+	RetryAfter time.Duration
+}
+
+func (e *PoolBusyError) Error() string {
+	return fmt.Sprintf("database busy, retry after %s", e.RetryAfter)
+}
+
+func (e *PoolBusyError) Unwrap() error {
+	return ErrPoolSaturated
+}
+
 // ErrConcurrencyBlocked is returned when pending tasks exist but all are blocked
 // by job concurrency limits. Workers should back off when receiving this error.
 var ErrConcurrencyBlocked = errors.New("tasks exist but blocked by concurrency limits")
@@ -735,6 +754,37 @@ func (q *DbQueue) ExecuteMaintenance(ctx context.Context, fn func(*sql.Tx) error
 	return nil
 }
 
+// CheckWriteCapacity returns a PoolBusyError when connection usage is at the
+// reject threshold, so request-driven writes can shed load instead of adding
+// to the queue. Workers keep using Execute, which waits for a connection.
+func (q *DbQueue) CheckWriteCapacity() error {
+	if q == nil || q.db == nil || q.db.client == nil {
+		return nil
+	}
+
+	stats := q.db.client.Stats()
+	maxOpen := stats.MaxOpenConnections
+	if maxOpen == 0 && q.db.config != nil {
+		maxOpen = q.db.config.MaxOpenConns
+	}
+	if maxOpen <= 0 {
+		return nil
+	}
+
+	usage := float64(stats.InUse) / float64(maxOpen)
+	if usage < q.poolRejectThreshold {
+		return nil
+	}
+
+	log.Debug().
+		Int("in_use", stats.InUse).
+		Int("max_open", maxOpen).
+		Float64("usage", usage).
+		Msg("Shedding write while DB pool is saturated")
+	observability.RecordDBPoolRejection(context.Background())
+	return &PoolBusyError{RetryAfter: PoolBusyRetryAfter}
+}
+
 func (q *DbQueue) ensurePoolCapacity(ctx context.Context) (func(), error) {
 	noop := func() {}
 	if q == nil || q.db == nil || q.db.client == nil {
@@ -834,13 +884,20 @@ type Task struct {
 	CompletedAt time.Time
 	RetryCount  int
 	Error       string
+	SkipReason  string // Why a skipped task wasn't warmed, e.g. "unchanged"
 	SourceType  string
 	SourceURL   string
+	Depth       int // Links followed from a root, sitemap or seed URL
 
 	// Result data
 	StatusCode          int
 	ResponseTime        int64
 	CacheStatus         string
+	OriginCacheStatus   string       // Origin/shield cache tier behind the edge, if reported
+	NotModified         bool         // Conditional warm answered 304 Not Modified
+	Soft404             bool         // 200 response whose body looks like a "not found" page
+	ContentHash         string       // SHA-256 of the normalised body, for jobs that hash content
+	ContentChanged      sql.NullBool // Whether ContentHash differs from the page's previous warm; unset when there's none
 	ContentType         string
 	ContentLength       int64
 	Headers             []byte // Stored as JSONB
@@ -865,6 +922,10 @@ type Task struct {
 
 	// Priority
 	PriorityScore float64
+
+	// Time the job was concurrency-blocked while this task waited (set on claim)
+	ConcurrencyBlockCount int
+	ConcurrencyWaitMs     int64
 }
 
 // GetNextTask gets a pending task using row-level locking
@@ -915,25 +976,32 @@ func (q *DbQueue) GetNextTask(ctx context.Context, jobID string) (*Task, error)
 			),
 			job_update AS (
 				UPDATE jobs j
-				SET running_tasks = running_tasks + 1
+				SET running_tasks = running_tasks + 1,
+				    -- A successful claim ends any concurrency-blocked period
+				    concurrency_blocked_ms = j.concurrency_blocked_ms + COALESCE(
+				        (EXTRACT(EPOCH FROM ($1::timestamptz - j.concurrency_blocked_since)) * 1000)::bigint, 0),
+				    concurrency_blocked_since = NULL
 				FROM next_task nt
 				WHERE j.id = nt.job_id
 				  AND (j.concurrency IS NULL OR j.concurrency = 0 OR j.running_tasks < j.concurrency)
-				RETURNING j.id, j.running_tasks, j.concurrency
+				RETURNING j.id, j.running_tasks, j.concurrency, j.concurrency_blocks, j.concurrency_blocked_ms
 			),
 			task_update AS (
 				UPDATE tasks
-				SET status = 'running', started_at = $1
+				SET status = 'running', started_at = $1,
+				    concurrency_block_count = GREATEST(ju.concurrency_blocks - tasks.concurrency_blocks_mark, 0),
+				    concurrency_wait_ms = GREATEST(ju.concurrency_blocked_ms - tasks.concurrency_blocked_ms_mark, 0)
 				FROM next_task nt
 				JOIN job_update ju ON ju.id = nt.job_id
 				WHERE tasks.id = nt.id
 				RETURNING tasks.id, tasks.job_id, tasks.page_id, tasks.path,
 				          tasks.created_at, tasks.retry_count, tasks.source_type,
 				          tasks.source_url, tasks.priority_score,
-				          ju.running_tasks, ju.concurrency
+				          tasks.concurrency_block_count, tasks.concurrency_wait_ms,
+				          ju.running_tasks, ju.concurrency, tasks.depth
 			)
 			SELECT id, job_id, page_id, path, created_at, retry_count, source_type, source_url, priority_score,
-			       running_tasks, concurrency
+			       concurrency_block_count, concurrency_wait_ms, running_tasks, concurrency, depth
 			FROM task_update
 		`
 
@@ -945,7 +1013,8 @@ func (q *DbQueue) GetNextTask(ctx context.Context, jobID string) (*Task, error)
 		err := row.Scan(
 			&task.ID, &task.JobID, &task.PageID, &task.Path,
 			&task.CreatedAt, &task.RetryCount, &task.SourceType, &task.SourceURL,
-			&task.PriorityScore, &jobRunningTasks, &jobConcurrency,
+			&task.PriorityScore, &task.ConcurrencyBlockCount, &task.ConcurrencyWaitMs,
+			&jobRunningTasks, &jobConcurrency, &task.Depth,
 		)
 		elapsed := time.Since(queryStart)
 
@@ -1024,6 +1093,8 @@ func (q *DbQueue) GetNextTask(ctx context.Context, jobID string) (*Task, error)
 			Int64("job_running_tasks", runningValue).
 			Int64("job_concurrency_limit", concurrencyValue).
 			Bool("job_concurrency_unlimited", unlimited).
+			Int("concurrency_block_count", task.ConcurrencyBlockCount).
+			Int64("concurrency_wait_ms", task.ConcurrencyWaitMs).
 			Msg("Claimed next task")
 
 		observability.RecordTaskClaimAttempt(ctx, task.JobID, elapsed, "claimed")
@@ -1051,7 +1122,8 @@ func (q *DbQueue) GetNextTask(ctx context.Context, jobID string) (*Task, error)
 		return nil, nil // No tasks available
 	}
 	if errors.Is(err, ErrConcurrencyBlocked) {
-		// Tasks exist but blocked by concurrency - return sentinel for backoff
+		// Tasks exist but blocked by concurrency - return sentinel for backoff.
+		// The caller counts the block against the job.
 		return nil, err
 	}
 	if err != nil {
@@ -1070,18 +1142,21 @@ func (q *DbQueue) GetNextTask(ctx context.Context, jobID string) (*Task, error)
 
 // enqueueJobConfig holds configuration fetched from the database for task enqueueing
 type enqueueJobConfig struct {
-	maxPages         int
-	concurrency      sql.NullInt64
-	runningTasks     int
-	pendingTaskCount int
-	domainID         sql.NullInt64
-	domainName       sql.NullString
-	orgID            sql.NullString
-	quotaRemaining   sql.NullInt64
-	currentTaskCount int
+	maxPages           int
+	concurrency        sql.NullInt64
+	runningTasks       int
+	pendingTaskCount   int
+	domainID           sql.NullInt64
+	domainName         sql.NullString
+	orgID              sql.NullString
+	quotaRemaining     sql.NullInt64
+	currentTaskCount   int
+	dryRun             bool
+	prioritiseBySearch bool
 }
 
-// deduplicatePages removes duplicate pages, keeping highest priority for each page ID
+// deduplicatePages removes duplicate pages, keeping the highest priority and
+// shallowest depth for each page ID
 func deduplicatePages(pages []Page) []Page {
 	uniquePages := make([]Page, 0, len(pages))
 	seen := make(map[int]int, len(pages))
@@ -1093,6 +1168,7 @@ func deduplicatePages(pages []Page) []Page {
 			if page.Priority > uniquePages[idx].Priority {
 				uniquePages[idx].Priority = page.Priority
 			}
+			uniquePages[idx].Depth = min(uniquePages[idx].Depth, page.Depth)
 			continue
 		}
 		seen[page.ID] = len(uniquePages)
@@ -1181,13 +1257,15 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 				   CASE WHEN j.organisation_id IS NOT NULL
 				        THEN get_daily_quota_remaining(j.organisation_id)
 				        ELSE NULL
-				   END
+				   END,
+				   j.dry_run, j.prioritise_by_search
 			FROM jobs j
 			LEFT JOIN domains d ON j.domain_id = d.id
 			WHERE j.id = $1
 			FOR UPDATE OF j
 		`, jobID).Scan(&cfg.maxPages, &cfg.concurrency, &cfg.runningTasks, &cfg.pendingTaskCount,
-			&cfg.domainID, &cfg.domainName, &cfg.currentTaskCount, &cfg.orgID, &cfg.quotaRemaining)
+			&cfg.domainID, &cfg.domainName, &cfg.currentTaskCount, &cfg.orgID, &cfg.quotaRemaining,
+			&cfg.dryRun, &cfg.prioritiseBySearch)
 		if err != nil {
 			return fmt.Errorf("failed to get job configuration and task count: %w", err)
 		}
@@ -1222,7 +1300,8 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 		insertQuery := `
 			INSERT INTO tasks (
 				id, job_id, page_id, path, status, created_at, retry_count,
-				source_type, source_url, priority_score
+				source_type, source_url, priority_score,
+				concurrency_blocks_mark, concurrency_blocked_ms_mark, depth
 			)
 			SELECT
 				unnest_ids,
@@ -1234,7 +1313,11 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 				unnest_retry_counts,
 				unnest_source_types,
 				unnest_source_urls,
-				unnest_priorities
+				unnest_priorities,
+				-- Snapshot the job's block totals so the claim can report the delta
+				j.concurrency_blocks,
+				j.concurrency_blocked_ms,
+				unnest_depths
 			FROM UNNEST(
 				$1::uuid[],
 				$2::uuid[],
@@ -1245,7 +1328,8 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 				$7::int[],
 				$8::text[],
 				$9::text[],
-				$10::double precision[]
+				$10::double precision[],
+				$11::int[]
 			) AS t(
 				unnest_ids,
 				unnest_job_ids,
@@ -1256,8 +1340,10 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 				unnest_retry_counts,
 				unnest_source_types,
 				unnest_source_urls,
-				unnest_priorities
+				unnest_priorities,
+				unnest_depths
 			)
+			JOIN jobs j ON j.id = unnest_job_ids::text
 			ON CONFLICT (job_id, page_id) DO UPDATE
 			SET status = EXCLUDED.status,
 				created_at = EXCLUDED.created_at,
@@ -1265,6 +1351,10 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 				source_type = EXCLUDED.source_type,
 				source_url = EXCLUDED.source_url,
 				priority_score = GREATEST(tasks.priority_score, EXCLUDED.priority_score),
+				-- A page found again by a shorter path keeps the shallower depth
+				depth = LEAST(tasks.depth, EXCLUDED.depth),
+				concurrency_blocks_mark = EXCLUDED.concurrency_blocks_mark,
+				concurrency_blocked_ms_mark = EXCLUDED.concurrency_blocked_ms_mark,
 				started_at = NULL,
 				completed_at = NULL,
 				error = NULL
@@ -1274,6 +1364,7 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 		now := time.Now().UTC()
 		processedPending := 0
 		processedWaiting := 0
+		processedDiscovered := 0
 
 		var (
 			taskIDs     []string
@@ -1286,6 +1377,7 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 			sourceTypes []string
 			sourceURLs  []string
 			priorities  []float64
+			depths      []int
 		)
 
 		for _, page := range uniquePages {
@@ -1294,8 +1386,12 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 			}
 
 			var status string
-			if cfg.maxPages == 0 || cfg.currentTaskCount+processedPending+processedWaiting < cfg.maxPages {
-				if processedPending < availableSlots {
+			if cfg.maxPages == 0 || cfg.currentTaskCount+processedPending+processedWaiting+processedDiscovered < cfg.maxPages {
+				if cfg.dryRun {
+					// Dry runs only list URLs; workers never claim discovered tasks
+					status = "discovered"
+					processedDiscovered++
+				} else if processedPending < availableSlots {
 					status = "pending"
 					processedPending++
 				} else {
@@ -1321,6 +1417,7 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 				sourceURLs = append(sourceURLs, "")
 			}
 			priorities = append(priorities, page.Priority)
+			depths = append(depths, page.Depth)
 		}
 
 		if len(taskIDs) == 0 {
@@ -1338,6 +1435,7 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 			pq.Array(sourceTypes),
 			pq.Array(sourceURLs),
 			pq.Array(priorities),
+			pq.Array(depths),
 		)
 
 		if err != nil {
@@ -1345,20 +1443,27 @@ func (q *DbQueue) EnqueueURLs(ctx context.Context, jobID string, pages []Page, s
 		}
 
 		// Apply traffic scores from page_analytics using GREATEST
-		// This ensures high-traffic pages get prioritised even if structural priority is low
+		// This ensures high-traffic pages get prioritised even if structural priority is low.
+		// Jobs that prioritise by search also take the page's Search Console score.
 		if cfg.orgID.Valid && cfg.domainID.Valid {
 			_, err = tx.ExecContext(ctx, `
 				UPDATE tasks t
-				SET priority_score = GREATEST(t.priority_score, COALESCE(pa.traffic_score, 0))
+				SET priority_score = GREATEST(t.priority_score, s.score)
 				FROM pages p
 				JOIN page_analytics pa ON pa.organisation_id = $1
 					AND pa.domain_id = $2
 					AND pa.path = p.path
+				CROSS JOIN LATERAL (
+					SELECT GREATEST(
+						COALESCE(pa.traffic_score, 0),
+						CASE WHEN $4 THEN COALESCE(pa.search_score, 0) ELSE 0 END
+					) AS score
+				) s
 				WHERE t.page_id = p.id
 				AND t.job_id = $3
 				AND t.status IN ('pending', 'waiting')
-				AND COALESCE(pa.traffic_score, 0) > t.priority_score
-			`, cfg.orgID.String, cfg.domainID.Int64, jobID)
+				AND s.score > t.priority_score
+			`, cfg.orgID.String, cfg.domainID.Int64, jobID, cfg.prioritiseBySearch)
 			if err != nil {
 				// Log but don't fail - traffic scores are an optimisation
 				log.Warn().
@@ -1524,8 +1629,10 @@ func (q *DbQueue) UpdateTaskStatus(ctx context.Context, task *Task) error {
 					second_dns_lookup_time = $19, second_tcp_connection_time = $20,
 					second_tls_handshake_time = $21, second_ttfb = $22,
 					second_content_transfer_time = $23,
-					retry_count = $24, cache_check_attempts = $25::jsonb
-				WHERE id = $26
+					retry_count = $24, cache_check_attempts = $25::jsonb,
+					origin_cache_status = $26, not_modified = $27, soft_404 = $28,
+					content_hash = NULLIF($29, ''), content_changed = $30
+				WHERE id = $31
 				RETURNING job_id
 			`, task.Status, task.CompletedAt, task.StatusCode,
 				task.ResponseTime, task.CacheStatus, task.ContentType,
@@ -1537,7 +1644,9 @@ func (q *DbQueue) UpdateTaskStatus(ctx context.Context, task *Task) error {
 				task.SecondDNSLookupTime, task.SecondTCPConnectionTime,
 				task.SecondTLSHandshakeTime, task.SecondTTFB,
 				task.SecondContentTransferTime,
-				task.RetryCount, string(cacheCheckAttempts), task.ID).Scan(&jobID)
+				task.RetryCount, string(cacheCheckAttempts),
+				task.OriginCacheStatus, task.NotModified, task.Soft404,
+				task.ContentHash, task.ContentChanged, task.ID).Scan(&jobID)
 
 		case "failed":
 			// Update task fields only (running_tasks decremented separately via DecrementRunningTasks)
@@ -1552,10 +1661,10 @@ func (q *DbQueue) UpdateTaskStatus(ctx context.Context, task *Task) error {
 			// Update task fields only (running_tasks decremented separately via DecrementRunningTasks)
 			err = tx.QueryRowContext(ctx, `
 				UPDATE tasks
-				SET status = $1
-				WHERE id = $2
+				SET status = $1, skip_reason = NULLIF($2, '')
+				WHERE id = $3
 				RETURNING job_id
-			`, task.Status, task.ID).Scan(&jobID)
+			`, task.Status, task.SkipReason, task.ID).Scan(&jobID)
 
 		case "pending":
 			// Update task fields only (running_tasks decremented separately via DecrementRunningTasks)
@@ -1672,6 +1781,7 @@ func (q *DbQueue) promoteWaitingTask(ctx context.Context, jobID string) error {
 		return err
 	})
 }
+
 func (q *DbQueue) hasAnyConcurrencyBlockedTasks(ctx context.Context, tx *sql.Tx) bool {
 	query := `
 		SELECT EXISTS (
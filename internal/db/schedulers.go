@@ -20,7 +20,9 @@ type Scheduler struct {
 	ID                    string
 	DomainID              int
 	OrganisationID        string
-	ScheduleIntervalHours int
+	ScheduleIntervalHours int    // 0 when CronExpression is set
+	CronExpression        string // Five-field cron spec; empty for interval schedules
+	Timezone              string // IANA timezone the cron spec is evaluated in
 	NextRunAt             time.Time
 	IsEnabled             bool
 	Concurrency           int
@@ -33,19 +35,79 @@ type Scheduler struct {
 	UpdatedAt             time.Time
 }
 
+// schedulerColumns is the column list read by scanScheduler
+const schedulerColumns = `id, domain_id, organisation_id, schedule_interval_hours, cron_expression,
+		       timezone, next_run_at, is_enabled, concurrency, find_links, max_pages,
+		       include_paths, exclude_paths, required_workers, created_at, updated_at`
+
+// scanScheduler reads a row selected with schedulerColumns
+func scanScheduler(row interface{ Scan(dest ...any) error }) (*Scheduler, error) {
+	scheduler := &Scheduler{}
+	var intervalHours sql.NullInt64
+	var cronExpression, includePaths, excludePaths sql.NullString
+
+	err := row.Scan(
+		&scheduler.ID, &scheduler.DomainID, &scheduler.OrganisationID,
+		&intervalHours, &cronExpression, &scheduler.Timezone,
+		&scheduler.NextRunAt, &scheduler.IsEnabled,
+		&scheduler.Concurrency, &scheduler.FindLinks, &scheduler.MaxPages,
+		&includePaths, &excludePaths, &scheduler.RequiredWorkers,
+		&scheduler.CreatedAt, &scheduler.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler.ScheduleIntervalHours = int(intervalHours.Int64)
+	scheduler.CronExpression = cronExpression.String
+
+	if includePaths.Valid && includePaths.String != "" {
+		if err := json.Unmarshal([]byte(includePaths.String), &scheduler.IncludePaths); err != nil {
+			log.Warn().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to deserialise include_paths")
+			scheduler.IncludePaths = []string{}
+		}
+	} else {
+		scheduler.IncludePaths = []string{}
+	}
+	if excludePaths.Valid && excludePaths.String != "" {
+		if err := json.Unmarshal([]byte(excludePaths.String), &scheduler.ExcludePaths); err != nil {
+			log.Warn().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to deserialise exclude_paths")
+			scheduler.ExcludePaths = []string{}
+		}
+	} else {
+		scheduler.ExcludePaths = []string{}
+	}
+
+	return scheduler, nil
+}
+
+// scheduleTiming maps a scheduler's interval/cron pair to nullable columns so
+// exactly one of them is set
+func scheduleTiming(scheduler *Scheduler) (sql.NullInt64, sql.NullString, string) {
+	timezone := scheduler.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if scheduler.CronExpression != "" {
+		return sql.NullInt64{}, sql.NullString{String: scheduler.CronExpression, Valid: true}, timezone
+	}
+	return sql.NullInt64{Int64: int64(scheduler.ScheduleIntervalHours), Valid: true}, sql.NullString{}, timezone
+}
+
 // CreateScheduler creates a new scheduler
 func (db *DB) CreateScheduler(ctx context.Context, scheduler *Scheduler) error {
 	query := `
 		INSERT INTO schedulers (
-			id, domain_id, organisation_id, schedule_interval_hours, next_run_at,
-			is_enabled, concurrency, find_links, max_pages, include_paths,
-			exclude_paths, required_workers, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			id, domain_id, organisation_id, schedule_interval_hours, cron_expression,
+			timezone, next_run_at, is_enabled, concurrency, find_links, max_pages,
+			include_paths, exclude_paths, required_workers, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
+	intervalHours, cronExpression, timezone := scheduleTiming(scheduler)
 	_, err := db.client.ExecContext(ctx, query,
 		scheduler.ID, scheduler.DomainID, scheduler.OrganisationID,
-		scheduler.ScheduleIntervalHours, scheduler.NextRunAt, scheduler.IsEnabled,
+		intervalHours, cronExpression, timezone, scheduler.NextRunAt, scheduler.IsEnabled,
 		scheduler.Concurrency, scheduler.FindLinks, scheduler.MaxPages,
 		Serialise(scheduler.IncludePaths), Serialise(scheduler.ExcludePaths),
 		scheduler.RequiredWorkers, scheduler.CreatedAt, scheduler.UpdatedAt,
@@ -60,24 +122,13 @@ func (db *DB) CreateScheduler(ctx context.Context, scheduler *Scheduler) error {
 
 // GetScheduler retrieves a scheduler by ID
 func (db *DB) GetScheduler(ctx context.Context, schedulerID string) (*Scheduler, error) {
-	scheduler := &Scheduler{}
-	var includePaths, excludePaths sql.NullString
-
 	query := `
-		SELECT id, domain_id, organisation_id, schedule_interval_hours, next_run_at,
-		       is_enabled, concurrency, find_links, max_pages, include_paths,
-		       exclude_paths, required_workers, created_at, updated_at
+		SELECT ` + schedulerColumns + `
 		FROM schedulers
 		WHERE id = $1
 	`
 
-	err := db.client.QueryRowContext(ctx, query, schedulerID).Scan(
-		&scheduler.ID, &scheduler.DomainID, &scheduler.OrganisationID,
-		&scheduler.ScheduleIntervalHours, &scheduler.NextRunAt, &scheduler.IsEnabled,
-		&scheduler.Concurrency, &scheduler.FindLinks, &scheduler.MaxPages,
-		&includePaths, &excludePaths, &scheduler.RequiredWorkers,
-		&scheduler.CreatedAt, &scheduler.UpdatedAt,
-	)
+	scheduler, err := scanScheduler(db.client.QueryRowContext(ctx, query, schedulerID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrSchedulerNotFound
@@ -86,32 +137,13 @@ func (db *DB) GetScheduler(ctx context.Context, schedulerID string) (*Scheduler,
 		return nil, fmt.Errorf("failed to get scheduler: %w", err)
 	}
 
-	if includePaths.Valid && includePaths.String != "" {
-		if err := json.Unmarshal([]byte(includePaths.String), &scheduler.IncludePaths); err != nil {
-			log.Warn().Err(err).Str("scheduler_id", schedulerID).Msg("Failed to deserialise include_paths")
-			scheduler.IncludePaths = []string{}
-		}
-	} else {
-		scheduler.IncludePaths = []string{}
-	}
-	if excludePaths.Valid && excludePaths.String != "" {
-		if err := json.Unmarshal([]byte(excludePaths.String), &scheduler.ExcludePaths); err != nil {
-			log.Warn().Err(err).Str("scheduler_id", schedulerID).Msg("Failed to deserialise exclude_paths")
-			scheduler.ExcludePaths = []string{}
-		}
-	} else {
-		scheduler.ExcludePaths = []string{}
-	}
-
 	return scheduler, nil
 }
 
 // ListSchedulers retrieves all schedulers for an organisation
 func (db *DB) ListSchedulers(ctx context.Context, organisationID string) ([]*Scheduler, error) {
 	query := `
-		SELECT id, domain_id, organisation_id, schedule_interval_hours, next_run_at,
-		       is_enabled, concurrency, find_links, max_pages, include_paths,
-		       exclude_paths, required_workers, created_at, updated_at
+		SELECT ` + schedulerColumns + `
 		FROM schedulers
 		WHERE organisation_id = $1
 		ORDER BY created_at DESC
@@ -127,38 +159,12 @@ func (db *DB) ListSchedulers(ctx context.Context, organisationID string) ([]*Sch
 	// Initialize slice to return empty array instead of null in JSON
 	schedulers := make([]*Scheduler, 0)
 	for rows.Next() {
-		scheduler := &Scheduler{}
-		var includePaths, excludePaths sql.NullString
-
-		err := rows.Scan(
-			&scheduler.ID, &scheduler.DomainID, &scheduler.OrganisationID,
-			&scheduler.ScheduleIntervalHours, &scheduler.NextRunAt, &scheduler.IsEnabled,
-			&scheduler.Concurrency, &scheduler.FindLinks, &scheduler.MaxPages,
-			&includePaths, &excludePaths, &scheduler.RequiredWorkers,
-			&scheduler.CreatedAt, &scheduler.UpdatedAt,
-		)
+		scheduler, err := scanScheduler(rows)
 		if err != nil {
 			log.Error().Err(err).Str("organisation_id", organisationID).Msg("Failed to scan scheduler row")
 			return nil, fmt.Errorf("failed to scan scheduler: %w", err)
 		}
 
-		if includePaths.Valid && includePaths.String != "" {
-			if err := json.Unmarshal([]byte(includePaths.String), &scheduler.IncludePaths); err != nil {
-				log.Warn().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to deserialise include_paths")
-				scheduler.IncludePaths = []string{}
-			}
-		} else {
-			scheduler.IncludePaths = []string{}
-		}
-		if excludePaths.Valid && excludePaths.String != "" {
-			if err := json.Unmarshal([]byte(excludePaths.String), &scheduler.ExcludePaths); err != nil {
-				log.Warn().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to deserialise exclude_paths")
-				scheduler.ExcludePaths = []string{}
-			}
-		} else {
-			scheduler.ExcludePaths = []string{}
-		}
-
 		schedulers = append(schedulers, scheduler)
 	}
 
@@ -172,31 +178,35 @@ func (db *DB) UpdateScheduler(ctx context.Context, schedulerID string, updates *
 	query := `
 		UPDATE schedulers
 		SET schedule_interval_hours = $1,
-		    next_run_at = $2,
-		    is_enabled = $3,
-		    concurrency = $4,
-		    find_links = $5,
-		    max_pages = $6,
-		    include_paths = $7,
-		    exclude_paths = $8,
-		    required_workers = $9,
-		    updated_at = $10
-		WHERE id = $11
+		    cron_expression = $2,
+		    timezone = $3,
+		    next_run_at = $4,
+		    is_enabled = $5,
+		    concurrency = $6,
+		    find_links = $7,
+		    max_pages = $8,
+		    include_paths = $9,
+		    exclude_paths = $10,
+		    required_workers = $11,
+		    updated_at = $12
+		WHERE id = $13
 	`
 
+	intervalHours, cronExpression, timezone := scheduleTiming(updates)
+
 	var result sql.Result
 	var err error
 	if expectedIsEnabled != nil {
-		query = query + " AND is_enabled = $12"
+		query = query + " AND is_enabled = $14"
 		result, err = db.client.ExecContext(ctx, query,
-			updates.ScheduleIntervalHours, updates.NextRunAt, updates.IsEnabled,
+			intervalHours, cronExpression, timezone, updates.NextRunAt, updates.IsEnabled,
 			updates.Concurrency, updates.FindLinks, updates.MaxPages,
 			Serialise(updates.IncludePaths), Serialise(updates.ExcludePaths),
 			updates.RequiredWorkers, time.Now().UTC(), schedulerID, *expectedIsEnabled,
 		)
 	} else {
 		result, err = db.client.ExecContext(ctx, query,
-			updates.ScheduleIntervalHours, updates.NextRunAt, updates.IsEnabled,
+			intervalHours, cronExpression, timezone, updates.NextRunAt, updates.IsEnabled,
 			updates.Concurrency, updates.FindLinks, updates.MaxPages,
 			Serialise(updates.IncludePaths), Serialise(updates.ExcludePaths),
 			updates.RequiredWorkers, time.Now().UTC(), schedulerID,
@@ -259,9 +269,7 @@ func (db *DB) DeleteScheduler(ctx context.Context, schedulerID string) error {
 // GetSchedulersReadyToRun retrieves schedulers that are ready to run
 func (db *DB) GetSchedulersReadyToRun(ctx context.Context, limit int) ([]*Scheduler, error) {
 	query := `
-		SELECT id, domain_id, organisation_id, schedule_interval_hours, next_run_at,
-		       is_enabled, concurrency, find_links, max_pages, include_paths,
-		       exclude_paths, required_workers, created_at, updated_at
+		SELECT ` + schedulerColumns + `
 		FROM schedulers
 		WHERE is_enabled = TRUE
 		  AND next_run_at <= NOW()
@@ -279,38 +287,12 @@ func (db *DB) GetSchedulersReadyToRun(ctx context.Context, limit int) ([]*Schedu
 	// Initialize slice to return empty array instead of null in JSON
 	schedulers := make([]*Scheduler, 0)
 	for rows.Next() {
-		scheduler := &Scheduler{}
-		var includePaths, excludePaths sql.NullString
-
-		err := rows.Scan(
-			&scheduler.ID, &scheduler.DomainID, &scheduler.OrganisationID,
-			&scheduler.ScheduleIntervalHours, &scheduler.NextRunAt, &scheduler.IsEnabled,
-			&scheduler.Concurrency, &scheduler.FindLinks, &scheduler.MaxPages,
-			&includePaths, &excludePaths, &scheduler.RequiredWorkers,
-			&scheduler.CreatedAt, &scheduler.UpdatedAt,
-		)
+		scheduler, err := scanScheduler(rows)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to scan scheduler row in ready to run query")
 			return nil, fmt.Errorf("failed to scan scheduler: %w", err)
 		}
 
-		if includePaths.Valid && includePaths.String != "" {
-			if err := json.Unmarshal([]byte(includePaths.String), &scheduler.IncludePaths); err != nil {
-				log.Warn().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to deserialise include_paths")
-				scheduler.IncludePaths = []string{}
-			}
-		} else {
-			scheduler.IncludePaths = []string{}
-		}
-		if excludePaths.Valid && excludePaths.String != "" {
-			if err := json.Unmarshal([]byte(excludePaths.String), &scheduler.ExcludePaths); err != nil {
-				log.Warn().Err(err).Str("scheduler_id", scheduler.ID).Msg("Failed to deserialise exclude_paths")
-				scheduler.ExcludePaths = []string{}
-			}
-		} else {
-			scheduler.ExcludePaths = []string{}
-		}
-
 		schedulers = append(schedulers, scheduler)
 	}
 
@@ -347,6 +329,28 @@ func (db *DB) GetLastJobStartTimeForScheduler(ctx context.Context, schedulerID s
 	return &startedAt.Time, nil
 }
 
+// HasActiveJobForScheduler reports whether a job created by the scheduler is
+// still pending or running, so the next run doesn't overlap it
+func (db *DB) HasActiveJobForScheduler(ctx context.Context, schedulerID string) (bool, error) {
+	var active bool
+
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM jobs
+			WHERE scheduler_id = $1
+			  AND status IN ('pending', 'initializing', 'running', 'paused')
+		)
+	`
+
+	if err := db.client.QueryRowContext(ctx, query, schedulerID).Scan(&active); err != nil {
+		log.Error().Err(err).Str("scheduler_id", schedulerID).Msg("Failed to check for active scheduler job")
+		return false, fmt.Errorf("failed to check for active scheduler job: %w", err)
+	}
+
+	return active, nil
+}
+
 // UpdateSchedulerNextRun updates only the next_run_at timestamp
 func (db *DB) UpdateSchedulerNextRun(ctx context.Context, schedulerID string, nextRun time.Time) error {
 	query := `
@@ -0,0 +1,367 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrSearchConsoleConnectionNotFound is returned when a Search Console connection is not found
+var ErrSearchConsoleConnectionNotFound = errors.New("search console connection not found")
+
+// SearchConsoleConnection represents an organisation's connection to a Search Console site
+type SearchConsoleConnection struct {
+	ID               string
+	OrganisationID   string
+	SiteURL          string // e.g. "sc-domain:example.com" or "https://www.example.com/"
+	DomainID         *int   // Domain the site maps to, nil when it matches none
+	GoogleUserID     string // Google user ID who authorised
+	GoogleEmail      string // Google email for display
+	VaultSecretName  string // Name of the secret in Supabase Vault
+	InstallingUserID string // Our user who installed
+	Status           string // "active" or "inactive"
+	LastSyncedAt     time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// PageSearchStats is one page's Search Console performance over 28 days
+type PageSearchStats struct {
+	Path        string
+	Clicks      int64
+	Impressions int64
+}
+
+const searchConsoleConnectionColumns = `
+	id, organisation_id, site_url, domain_id, google_user_id, google_email,
+	vault_secret_name, installing_user_id, status, last_synced_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSearchConsoleConnection(row rowScanner) (*SearchConsoleConnection, error) {
+	conn := &SearchConsoleConnection{}
+	var domainID sql.NullInt64
+	var googleUserID, googleEmail, vaultSecretName, installingUserID sql.NullString
+	var lastSyncedAt sql.NullTime
+
+	if err := row.Scan(
+		&conn.ID, &conn.OrganisationID, &conn.SiteURL, &domainID, &googleUserID, &googleEmail,
+		&vaultSecretName, &installingUserID, &conn.Status, &lastSyncedAt, &conn.CreatedAt, &conn.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if domainID.Valid {
+		id := int(domainID.Int64)
+		conn.DomainID = &id
+	}
+	conn.GoogleUserID = googleUserID.String
+	conn.GoogleEmail = googleEmail.String
+	conn.VaultSecretName = vaultSecretName.String
+	conn.InstallingUserID = installingUserID.String
+	if lastSyncedAt.Valid {
+		conn.LastSyncedAt = lastSyncedAt.Time
+	}
+	return conn, nil
+}
+
+// UpsertSearchConsoleConnection creates or refreshes the connection for an
+// organisation's site and sets conn.ID. Reconnecting reactivates the site.
+// Note: Use StoreSearchConsoleToken afterwards to store the refresh token in Vault
+func (db *DB) UpsertSearchConsoleConnection(ctx context.Context, conn *SearchConsoleConnection) error {
+	query := `
+		INSERT INTO search_console_connections (
+			organisation_id, site_url, domain_id, google_user_id, google_email,
+			installing_user_id, status
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, 'active')
+		ON CONFLICT (organisation_id, site_url)
+		DO UPDATE SET
+			domain_id = EXCLUDED.domain_id,
+			google_user_id = EXCLUDED.google_user_id,
+			google_email = EXCLUDED.google_email,
+			installing_user_id = EXCLUDED.installing_user_id,
+			status = 'active',
+			updated_at = NOW()
+		RETURNING id
+	`
+
+	var domainID any
+	if conn.DomainID != nil {
+		domainID = *conn.DomainID
+	}
+
+	err := db.client.QueryRowContext(ctx, query,
+		conn.OrganisationID, conn.SiteURL, domainID, conn.GoogleUserID, conn.GoogleEmail,
+		conn.InstallingUserID,
+	).Scan(&conn.ID)
+	if err != nil {
+		log.Error().Err(err).Str("organisation_id", conn.OrganisationID).Msg("Failed to upsert Search Console connection")
+		return fmt.Errorf("failed to upsert Search Console connection: %w", err)
+	}
+	conn.Status = "active"
+	return nil
+}
+
+// StoreSearchConsoleToken stores a Search Console refresh token in Supabase Vault
+func (db *DB) StoreSearchConsoleToken(ctx context.Context, connectionID, refreshToken string) error {
+	query := `SELECT store_gsc_token($1::uuid, $2)`
+
+	if err := db.client.QueryRowContext(ctx, query, connectionID, refreshToken).Scan(new(string)); err != nil {
+		log.Error().Err(err).Str("connection_id", connectionID).Msg("Failed to store Search Console token in vault")
+		return fmt.Errorf("failed to store Search Console token: %w", err)
+	}
+	return nil
+}
+
+// GetSearchConsoleToken retrieves a Search Console refresh token from Supabase Vault
+func (db *DB) GetSearchConsoleToken(ctx context.Context, connectionID string) (string, error) {
+	query := `SELECT get_gsc_token($1::uuid)`
+
+	var token sql.NullString
+	if err := db.client.QueryRowContext(ctx, query, connectionID).Scan(&token); err != nil {
+		log.Error().Err(err).Str("connection_id", connectionID).Msg("Failed to get Search Console token from vault")
+		return "", fmt.Errorf("failed to get Search Console token: %w", err)
+	}
+	if !token.Valid {
+		return "", ErrGoogleTokenNotFound
+	}
+	return token.String, nil
+}
+
+// ListSearchConsoleConnections lists all Search Console connections for an organisation
+func (db *DB) ListSearchConsoleConnections(ctx context.Context, organisationID string) ([]*SearchConsoleConnection, error) {
+	query := `SELECT ` + searchConsoleConnectionColumns + `
+		FROM search_console_connections
+		WHERE organisation_id = $1
+		ORDER BY status ASC, site_url ASC
+	`
+
+	rows, err := db.client.QueryContext(ctx, query, organisationID)
+	if err != nil {
+		log.Error().Err(err).Str("organisation_id", organisationID).Msg("Failed to list Search Console connections")
+		return nil, fmt.Errorf("failed to list Search Console connections: %w", err)
+	}
+	defer rows.Close()
+
+	var connections []*SearchConsoleConnection
+	for rows.Next() {
+		conn, err := scanSearchConsoleConnection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan Search Console connection: %w", err)
+		}
+		connections = append(connections, conn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating Search Console connections: %w", err)
+	}
+	return connections, nil
+}
+
+// GetActiveSearchConsoleConnectionForDomain returns the organisation's active
+// Search Console connection for a domain, or nil when the site isn't connected
+func (db *DB) GetActiveSearchConsoleConnectionForDomain(ctx context.Context, organisationID string, domainID int) (*SearchConsoleConnection, error) {
+	// Prefer a domain property over URL-prefix properties, which may only
+	// cover part of the site
+	query := `SELECT ` + searchConsoleConnectionColumns + `
+		FROM search_console_connections
+		WHERE organisation_id = $1 AND domain_id = $2 AND status = 'active'
+		ORDER BY (site_url LIKE 'sc-domain:%') DESC, last_synced_at DESC NULLS LAST, id ASC
+		LIMIT 1
+	`
+
+	conn, err := scanSearchConsoleConnection(db.client.QueryRowContext(ctx, query, organisationID, domainID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Str("organisation_id", organisationID).Int("domain_id", domainID).Msg("Failed to get active Search Console connection")
+		return nil, fmt.Errorf("failed to get active Search Console connection: %w", err)
+	}
+	return conn, nil
+}
+
+// DeleteSearchConsoleConnection deletes a Search Console connection and its vault token
+func (db *DB) DeleteSearchConsoleConnection(ctx context.Context, connectionID, organisationID string) error {
+	tx, err := db.client.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	var found bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT TRUE FROM search_console_connections
+		WHERE id = $1 AND organisation_id = $2
+		FOR UPDATE
+	`, connectionID, organisationID).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSearchConsoleConnectionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find Search Console connection: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT delete_gsc_token($1::uuid)`, connectionID); err != nil {
+		return fmt.Errorf("failed to delete Search Console token: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM search_console_connections WHERE id = $1`, connectionID); err != nil {
+		log.Error().Err(err).Str("connection_id", connectionID).Msg("Failed to delete Search Console connection")
+		return fmt.Errorf("failed to delete Search Console connection: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// MarkSearchConsoleConnectionInactive deactivates a connection whose token no
+// longer works, so jobs stop trying it until the site is reconnected
+func (db *DB) MarkSearchConsoleConnectionInactive(ctx context.Context, connectionID, reason string) error {
+	_, err := db.client.ExecContext(ctx, `
+		UPDATE search_console_connections
+		SET status = 'inactive', updated_at = NOW()
+		WHERE id = $1
+	`, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to mark Search Console connection inactive: %w", err)
+	}
+	log.Warn().Str("connection_id", connectionID).Str("reason", reason).Msg("Marked Search Console connection inactive")
+	return nil
+}
+
+// UpdateSearchConsoleLastSync records a successful Search Console fetch
+func (db *DB) UpdateSearchConsoleLastSync(ctx context.Context, connectionID string) error {
+	_, err := db.client.ExecContext(ctx, `
+		UPDATE search_console_connections
+		SET last_synced_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to update Search Console last sync: %w", err)
+	}
+	return nil
+}
+
+// UpsertPageSearchStats stores 28-day Search Console clicks and impressions
+// for a domain's pages in page_analytics, leaving GA4 page views untouched
+func (db *DB) UpsertPageSearchStats(ctx context.Context, organisationID string, domainID int, connectionID string, stats []PageSearchStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	paths := make([]string, len(stats))
+	clicks := make([]int64, len(stats))
+	impressions := make([]int64, len(stats))
+	for i, s := range stats {
+		paths[i] = s.Path
+		clicks[i] = s.Clicks
+		impressions[i] = s.Impressions
+	}
+
+	query := `
+		INSERT INTO page_analytics (
+			organisation_id, domain_id, path,
+			search_clicks_28d, search_impressions_28d, gsc_connection_id, search_fetched_at
+		)
+		SELECT $1, $2, s.path, s.clicks, s.impressions, $3, NOW()
+		FROM unnest($4::text[], $5::bigint[], $6::bigint[]) AS s(path, clicks, impressions)
+		ON CONFLICT (organisation_id, domain_id, path)
+		DO UPDATE SET
+			search_clicks_28d = EXCLUDED.search_clicks_28d,
+			search_impressions_28d = EXCLUDED.search_impressions_28d,
+			gsc_connection_id = EXCLUDED.gsc_connection_id,
+			search_fetched_at = NOW(),
+			updated_at = NOW()
+	`
+
+	_, err := db.client.ExecContext(ctx, query, organisationID, domainID, connectionID,
+		pq.Array(paths), pq.Array(clicks), pq.Array(impressions))
+	if err != nil {
+		return fmt.Errorf("failed to upsert page search stats: %w", err)
+	}
+	return nil
+}
+
+// CalculateSearchScores scores a domain's pages by 28-day search impressions
+// on the same log-scaled curve as CalculateTrafficScores.
+// Score range: 0 (0-1 impressions) then 0.10 (floor) to 0.99 (ceiling).
+func (db *DB) CalculateSearchScores(ctx context.Context, organisationID string, domainID int) error {
+	query := `
+		WITH stats AS (
+			SELECT
+				id,
+				search_impressions_28d AS impressions,
+				MIN(search_impressions_28d) OVER () AS min_impressions,
+				MAX(search_impressions_28d) OVER () AS max_impressions
+			FROM page_analytics
+			WHERE organisation_id = $1 AND domain_id = $2
+		)
+		UPDATE page_analytics pa
+		SET search_score = CASE
+			WHEN s.impressions <= 1 THEN 0
+			WHEN s.max_impressions = s.min_impressions THEN 0.10
+			ELSE 0.10 + 0.89 * (
+				LN(s.impressions + 1) - LN(s.min_impressions + 1)
+			) / NULLIF(
+				LN(s.max_impressions + 1) - LN(s.min_impressions + 1),
+				0
+			)
+		END,
+		updated_at = NOW()
+		FROM stats s
+		WHERE pa.id = s.id
+	`
+
+	result, err := db.client.ExecContext(ctx, query, organisationID, domainID)
+	if err != nil {
+		return fmt.Errorf("failed to calculate search scores: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	log.Info().
+		Str("organisation_id", organisationID).
+		Int("domain_id", domainID).
+		Int64("pages_updated", rowsAffected).
+		Msg("Calculated search scores for domain")
+
+	return nil
+}
+
+// ApplySearchScoresToTasks raises pending tasks to their page's search score,
+// for the organisation's jobs on the domain that prioritise by search
+func (db *DB) ApplySearchScoresToTasks(ctx context.Context, organisationID string, domainID int) error {
+	query := `
+		UPDATE tasks t
+		SET priority_score = GREATEST(t.priority_score, pa.search_score)
+		FROM pages p
+		JOIN jobs j ON j.organisation_id = $1 AND j.prioritise_by_search
+		JOIN page_analytics pa ON pa.organisation_id = j.organisation_id
+			AND pa.domain_id = p.domain_id
+			AND pa.path = p.path
+		WHERE t.job_id = j.id
+		AND t.page_id = p.id
+		AND p.domain_id = $2
+		AND t.status IN ('pending', 'waiting')
+		AND pa.search_score > t.priority_score
+	`
+
+	result, err := db.client.ExecContext(ctx, query, organisationID, domainID)
+	if err != nil {
+		return fmt.Errorf("failed to apply search scores to tasks: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected > 0 {
+		log.Info().
+			Str("organisation_id", organisationID).
+			Int("domain_id", domainID).
+			Int64("tasks_updated", rowsAffected).
+			Msg("Applied search scores to pending tasks")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// GetOrganisationSlackWebhookURL returns the Slack incoming webhook job
+// failures are posted to, or "" when none is set. The URL is kept in Vault.
+func (db *DB) GetOrganisationSlackWebhookURL(ctx context.Context, organisationID string) (string, error) {
+	var url sql.NullString
+	err := db.client.QueryRowContext(ctx, `
+		SELECT get_organisation_slack_webhook_url($1)
+	`, organisationID).Scan(&url)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Slack webhook URL: %w", err)
+	}
+	return url.String, nil
+}
+
+// SetOrganisationSlackWebhookURL sets or, with "", clears an organisation's
+// Slack incoming webhook
+func (db *DB) SetOrganisationSlackWebhookURL(ctx context.Context, organisationID, url string) error {
+	var configured bool
+	err := db.client.QueryRowContext(ctx, `
+		SELECT set_organisation_slack_webhook_url(id, $2)
+		FROM organisations
+		WHERE id = $1
+	`, organisationID, sql.NullString{String: url, Valid: url != ""}).Scan(&configured)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("organisation not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Slack webhook URL: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetOrganisationWebhookSecret returns the key an organisation's job
+// completion webhooks are signed with. It's kept in Vault and created on first
+// use.
+func (db *DB) GetOrganisationWebhookSecret(ctx context.Context, organisationID string) (string, error) {
+	var secret string
+	err := db.client.QueryRowContext(ctx, `
+		SELECT get_organisation_webhook_secret($1)
+	`, organisationID).Scan(&secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get webhook secret: %w", err)
+	}
+	return secret, nil
+}
+
+// RotateOrganisationWebhookSecret replaces an organisation's webhook signing
+// key and returns the new one. Deliveries already in flight keep the old key.
+func (db *DB) RotateOrganisationWebhookSecret(ctx context.Context, organisationID string) (string, error) {
+	var secret string
+	err := db.client.QueryRowContext(ctx, `
+		SELECT rotate_organisation_webhook_secret($1)
+	`, organisationID).Scan(&secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,225 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// autoConcurrencyStart is where auto-tuned jobs begin before ramping up
+	autoConcurrencyStart = 2
+	// autoLatencyWindowSize caps how many response times a window's p95 covers
+	autoLatencyWindowSize = 50
+	// autoMinSamples is how many requests a window needs before the limit is
+	// judged
+	autoMinSamples = 10
+	// autoAdjustInterval is the shortest window, so each change has time to
+	// show up in latency and errors
+	autoAdjustInterval = 10 * time.Second
+	// autoReprobeInterval is how long a settled limit below the job's
+	// concurrency must stay healthy before one step above it is tried again
+	autoReprobeInterval = 5 * time.Minute
+)
+
+// autoConcurrencyState tunes a job's concurrency AIMD-style: one step up per
+// interval while the origin stays healthy, halving when it degrades. The limit
+// that degraded the origin becomes a ceiling, so the job settles just below it
+// rather than sawtoothing. Guarded by perfMutex.
+type autoConcurrencyState struct {
+	limit       int       // Current concurrency limit
+	ceiling     int       // Highest limit to ramp to; 0 for the job's concurrency
+	baseline    float64   // EWMA of healthy p95 response times (ms)
+	latencies   []int64   // Successful response times in the current window
+	requests    int       // Requests in the current window
+	distress    int       // Of which rate limits, blocks or server errors
+	lastAdjust  time.Time // When the current window started
+	stableSince time.Time // When the limit settled; zero while still moving
+}
+
+func newAutoConcurrencyState(jobConcurrency int, now time.Time) *autoConcurrencyState {
+	return &autoConcurrencyState{
+		limit:      max(min(autoConcurrencyStart, jobConcurrency), 1),
+		latencies:  make([]int64, 0, autoLatencyWindowSize),
+		lastAdjust: now,
+	}
+}
+
+// recordOutcome counts a request towards the error rate at the current limit
+func (s *autoConcurrencyState) recordOutcome(distress bool) {
+	s.requests++
+	if distress {
+		s.distress++
+	}
+}
+
+// recordLatency adds a successful response time to the p95 window. Must be
+// called with perfMutex held.
+func (s *autoConcurrencyState) recordLatency(responseTime int64) {
+	if len(s.latencies) == autoLatencyWindowSize {
+		s.latencies = s.latencies[1:]
+	}
+	s.latencies = append(s.latencies, responseTime)
+}
+
+// p95 returns the 95th percentile of the recorded response times
+func (s *autoConcurrencyState) p95() int64 {
+	sorted := slices.Clone(s.latencies)
+	slices.Sort(sorted)
+	idx := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	return sorted[max(idx, 0)]
+}
+
+func (s *autoConcurrencyState) errorRate() float64 {
+	if s.requests == 0 {
+		return 0
+	}
+	return float64(s.distress) / float64(s.requests)
+}
+
+// startWindow starts measuring afresh after a judgement
+func (s *autoConcurrencyState) startWindow(now time.Time) {
+	s.latencies = s.latencies[:0]
+	s.requests = 0
+	s.distress = 0
+	s.lastAdjust = now
+}
+
+// autoConcurrencyDecision is a change to an auto-tuned job's limit or state
+type autoConcurrencyDecision struct {
+	limit     int
+	stable    bool
+	degraded  bool
+	p95       int64
+	errorRate float64
+}
+
+// nextAutoConcurrency records a request outcome and decides whether the
+// job's limit should change. p95 latency at multiplier times its healthy
+// baseline, or an error rate at the threshold, halves the limit; latency near
+// baseline with few errors steps it up until the ceiling, where it settles.
+// Anything in between holds. Must be called with perfMutex held.
+func nextAutoConcurrency(s *autoConcurrencyState, distress bool, jobConcurrency int, multiplier, errorThreshold float64, now time.Time) (autoConcurrencyDecision, bool) {
+	s.recordOutcome(distress)
+	if s.requests < autoMinSamples || now.Sub(s.lastAdjust) < autoAdjustInterval {
+		return autoConcurrencyDecision{}, false
+	}
+
+	var p95 float64
+	if len(s.latencies) > 0 {
+		p95 = float64(s.p95())
+		if s.baseline == 0 {
+			// The conservative starting limit sets what healthy looks like
+			s.baseline = p95
+		}
+	}
+	errorRate := s.errorRate()
+	degraded := errorRate >= errorThreshold || (p95 > 0 && p95 >= s.baseline*multiplier)
+	healthy := errorRate <= errorThreshold*errorRecoveryFactor && p95 > 0 && p95 <= s.baseline*latencyRecoveryFactor
+
+	if healthy {
+		// Only learn from healthy periods so a struggling origin can't become the baseline
+		s.baseline = s.baseline*(1-latencyBaselineWeight) + p95*latencyBaselineWeight
+	}
+
+	ceiling := jobConcurrency
+	if s.ceiling > 0 {
+		ceiling = min(s.ceiling, jobConcurrency)
+	}
+
+	next := s.limit
+	settled := false
+	switch {
+	case degraded:
+		s.ceiling = max(s.limit-1, 1)
+		next = max(s.limit/2, 1)
+	case !healthy:
+		// Between the thresholds: hold and judge again next window
+	case s.limit < ceiling:
+		next = s.limit + 1
+	case s.stableSince.IsZero():
+		s.stableSince = now
+		settled = true
+	case ceiling < jobConcurrency && now.Sub(s.stableSince) >= autoReprobeInterval:
+		s.ceiling = ceiling + 1
+		next = s.limit + 1
+	}
+	s.startWindow(now)
+
+	if next == s.limit && !settled {
+		return autoConcurrencyDecision{}, false
+	}
+	if next != s.limit {
+		s.limit = next
+		s.stableSince = time.Time{}
+	}
+	return autoConcurrencyDecision{
+		limit:     s.limit,
+		stable:    !s.stableSince.IsZero(),
+		degraded:  degraded,
+		p95:       int64(p95),
+		errorRate: errorRate,
+	}, true
+}
+
+// startAutoConcurrency caps a newly added auto-tuned job at its starting limit
+func (wp *WorkerPool) startAutoConcurrency(jobID string, info *JobInfo) {
+	state := newAutoConcurrencyState(info.Concurrency, time.Now())
+
+	wp.perfMutex.Lock()
+	perf, exists := wp.jobPerformance[jobID]
+	if exists {
+		perf.Auto = state
+	}
+	wp.perfMutex.Unlock()
+	if !exists {
+		return
+	}
+
+	wp.ensureDomainLimiter().SetAutoCap(jobID, info.limiterDomain(), state.limit)
+	wp.persistAutoConcurrency(jobID, state.limit, false)
+}
+
+// applyAutoConcurrency pushes an auto-tuned job's new limit to the domain
+// limiter and records it on the job
+func (wp *WorkerPool) applyAutoConcurrency(jobID string, domain string, decision autoConcurrencyDecision) {
+	wp.ensureDomainLimiter().SetAutoCap(jobID, domain, decision.limit)
+	wp.persistAutoConcurrency(jobID, decision.limit, decision.stable)
+
+	event := log.Info()
+	if decision.degraded {
+		event = log.Warn()
+	}
+	event.
+		Str("job_id", jobID).
+		Str("domain", domain).
+		Int64("p95_response_time", decision.p95).
+		Float64("error_rate", decision.errorRate).
+		Int("concurrency_limit", decision.limit).
+		Bool("stable", decision.stable).
+		Msg("Auto-tuned job concurrency")
+}
+
+func (wp *WorkerPool) persistAutoConcurrency(jobID string, limit int, stable bool) {
+	if wp.dbQueue == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET auto_concurrency_limit = $1, auto_concurrency_stable = $2
+			WHERE id = $3
+		`, limit, stable, jobID)
+		return err
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("job_id", jobID).Msg("Failed to record auto-tuned concurrency")
+	}
+}
@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// judgeWindow feeds one window of requests, errors of them failing, and
+// returns any change judged along the way
+func judgeWindow(s *autoConcurrencyState, latency int64, errors int, jobConcurrency int, now time.Time) (autoConcurrencyDecision, bool) {
+	for i := range autoMinSamples {
+		if i >= errors {
+			s.recordLatency(latency)
+		}
+		if decision, changed := nextAutoConcurrency(s, i < errors, jobConcurrency, 3.0, 0.2, now); changed {
+			return decision, true
+		}
+	}
+	return autoConcurrencyDecision{}, false
+}
+
+func TestNextAutoConcurrencyRampsAndSettles(t *testing.T) {
+	now := time.Now()
+	s := newAutoConcurrencyState(5, now)
+	assert.Equal(t, autoConcurrencyStart, s.limit)
+
+	// Nothing is judged before the window is long enough
+	_, changed := judgeWindow(s, 100, 0, 5, now.Add(time.Second))
+	assert.False(t, changed)
+	s.startWindow(now) // Judge the next windows on their own requests
+
+	for _, want := range []int{3, 4, 5} {
+		now = now.Add(autoAdjustInterval)
+		decision, changed := judgeWindow(s, 100, 0, 5, now)
+		require.True(t, changed)
+		assert.Equal(t, want, decision.limit)
+		assert.False(t, decision.stable)
+	}
+
+	// At the job's concurrency the limit settles and stays put
+	now = now.Add(autoAdjustInterval)
+	decision, changed := judgeWindow(s, 100, 0, 5, now)
+	require.True(t, changed)
+	assert.Equal(t, 5, decision.limit)
+	assert.True(t, decision.stable)
+
+	now = now.Add(autoReprobeInterval)
+	_, changed = judgeWindow(s, 100, 0, 5, now)
+	assert.False(t, changed)
+}
+
+func TestNextAutoConcurrencyBacksOffBelowDegradingLimit(t *testing.T) {
+	now := time.Now()
+	s := newAutoConcurrencyState(10, now)
+
+	for range 4 {
+		now = now.Add(autoAdjustInterval)
+		judgeWindow(s, 100, 0, 10, now)
+	}
+	require.Equal(t, 6, s.limit)
+
+	// p95 at three times the baseline halves the limit
+	now = now.Add(autoAdjustInterval)
+	decision, changed := judgeWindow(s, 400, 0, 10, now)
+	require.True(t, changed)
+	assert.True(t, decision.degraded)
+	assert.Equal(t, 3, decision.limit)
+
+	// It climbs back to just below the limit that degraded and settles there
+	for _, want := range []int{4, 5} {
+		now = now.Add(autoAdjustInterval)
+		decision, changed = judgeWindow(s, 100, 0, 10, now)
+		require.True(t, changed)
+		assert.Equal(t, want, decision.limit)
+	}
+	now = now.Add(autoAdjustInterval)
+	decision, changed = judgeWindow(s, 100, 0, 10, now)
+	require.True(t, changed)
+	assert.True(t, decision.stable)
+	assert.Equal(t, 5, decision.limit)
+
+	now = now.Add(autoAdjustInterval)
+	_, changed = judgeWindow(s, 100, 0, 10, now)
+	assert.False(t, changed)
+
+	// A long healthy spell earns one more probe
+	now = now.Add(autoReprobeInterval)
+	decision, changed = judgeWindow(s, 100, 0, 10, now)
+	require.True(t, changed)
+	assert.False(t, decision.stable)
+	assert.Equal(t, 6, decision.limit)
+}
+
+func TestNextAutoConcurrencyReactsToErrors(t *testing.T) {
+	now := time.Now()
+	s := newAutoConcurrencyState(8, now)
+	s.limit = 4
+	s.baseline = 100
+
+	// 10% errors is under the threshold but too many to ramp up
+	now = now.Add(autoAdjustInterval)
+	_, changed := judgeWindow(s, 100, 1, 8, now)
+	assert.False(t, changed)
+	assert.Equal(t, 4, s.limit)
+
+	now = now.Add(autoAdjustInterval)
+	decision, changed := judgeWindow(s, 100, 2, 8, now)
+	require.True(t, changed)
+	assert.True(t, decision.degraded)
+	assert.Equal(t, 2, decision.limit)
+
+	// Every request failing still backs off, with no latency to judge
+	now = now.Add(autoAdjustInterval)
+	decision, changed = judgeWindow(s, 0, autoMinSamples, 8, now)
+	require.True(t, changed)
+	assert.Equal(t, 1, decision.limit)
+}
+
+func TestStartAutoConcurrencyCapsJob(t *testing.T) {
+	wp := &WorkerPool{
+		jobPerformance: map[string]*JobPerformance{"job-1": {}},
+		domainLimiter:  newDomainLimiter(nil),
+	}
+	wp.domainLimiter.cfg.BaseDelay = 0
+
+	wp.startAutoConcurrency("job-1", &JobInfo{DomainName: "example.com", Concurrency: 8})
+	require.NotNil(t, wp.jobPerformance["job-1"].Auto)
+
+	permit, err := wp.domainLimiter.Acquire(context.Background(), DomainRequest{Domain: "example.com", JobID: "job-1", JobConcurrency: 8})
+	require.NoError(t, err)
+	defer permit.Release(true, false)
+	assert.Equal(t, autoConcurrencyStart, wp.domainLimiter.GetEffectiveConcurrency("job-1", "example.com"))
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
+	"github.com/rs/zerolog/log"
+)
+
+// backlogMetricJobLimit bounds the per-job backlog gauges to the largest
+// backlogs, keeping metric cardinality flat however many jobs are running
+const backlogMetricJobLimit = 10
+
+type jobBacklog struct {
+	id      string
+	pending int
+	waiting int
+	running int
+}
+
+const jobBacklogQuery = `
+	SELECT id, pending_tasks, waiting_tasks, running_tasks
+	FROM jobs
+	WHERE status = 'running'
+	ORDER BY pending_tasks + waiting_tasks + running_tasks DESC
+	LIMIT $1
+`
+
+// topJobBacklogs returns the running jobs with the most outstanding tasks
+func (wp *WorkerPool) topJobBacklogs(ctx context.Context) ([]jobBacklog, error) {
+	rows, err := wp.db.QueryContext(ctx, jobBacklogQuery, backlogMetricJobLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job backlogs: %w", err)
+	}
+	defer rows.Close()
+
+	var backlogs []jobBacklog
+	for rows.Next() {
+		var b jobBacklog
+		if err := rows.Scan(&b.id, &b.pending, &b.waiting, &b.running); err != nil {
+			return nil, fmt.Errorf("failed to scan job backlog row: %w", err)
+		}
+		backlogs = append(backlogs, b)
+	}
+	return backlogs, rows.Err()
+}
+
+// recordTaskBacklog exports the system task counts and the largest per-job
+// backlogs as gauges, so a steadily climbing waiting count can be alerted on
+func (wp *WorkerPool) recordTaskBacklog(ctx context.Context, counts SystemTaskCounts) {
+	observability.RecordTaskBacklog(ctx, counts.Pending, counts.Waiting, counts.Running)
+
+	backlogs, err := wp.topJobBacklogs(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to record job task backlogs")
+		return
+	}
+
+	reported := make(map[string]struct{}, len(backlogs))
+	for _, b := range backlogs {
+		observability.RecordJobTaskBacklog(ctx, b.id, b.pending, b.waiting, b.running)
+		reported[b.id] = struct{}{}
+	}
+
+	// Zero jobs that dropped out of the top N so their last backlog doesn't
+	// linger in the gauge after they finish
+	for id := range wp.backlogJobs {
+		if _, ok := reported[id]; !ok {
+			observability.RecordJobTaskBacklog(ctx, id, 0, 0, 0)
+		}
+	}
+	wp.backlogJobs = reported
+}
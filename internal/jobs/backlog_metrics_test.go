@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordTaskBacklogTracksReportedJobs(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB, backlogJobs: map[string]struct{}{"job-old": {}}}
+	counts := SystemTaskCounts{ActiveJobs: 2, Pending: 30, Waiting: 900, Running: 40}
+
+	mock.ExpectQuery("SELECT id, pending_tasks, waiting_tasks, running_tasks").
+		WithArgs(backlogMetricJobLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pending_tasks", "waiting_tasks", "running_tasks"}).
+			AddRow("job-1", 20, 800, 30).
+			AddRow("job-2", 10, 100, 10))
+
+	wp.recordTaskBacklog(context.Background(), counts)
+
+	assert.Equal(t, map[string]struct{}{"job-1": {}, "job-2": {}}, wp.backlogJobs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordTaskBacklogKeepsReportedJobsOnQueryError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB, backlogJobs: map[string]struct{}{"job-1": {}}}
+
+	mock.ExpectQuery("SELECT id, pending_tasks, waiting_tasks, running_tasks").
+		WillReturnError(assert.AnError)
+
+	wp.recordTaskBacklog(context.Background(), SystemTaskCounts{})
+
+	assert.Equal(t, map[string]struct{}{"job-1": {}}, wp.backlogJobs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
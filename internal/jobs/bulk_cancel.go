@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrJobNotCancellable is returned when cancelling a job that has already finished
+var ErrJobNotCancellable = errors.New("job cannot be canceled")
+
+// CancelActiveJobs cancels every pending, initialising, running or paused job
+// the organisation has for a domain, or across all its domains when domain is
+// empty. Jobs that finish before they're reached are skipped, so repeated
+// calls are safe. It returns how many jobs were cancelled; a failure on one
+// job doesn't stop the rest.
+func (jm *JobManager) CancelActiveJobs(ctx context.Context, organisationID, domain string) (int, error) {
+	span := sentry.StartSpan(ctx, "manager.cancel_active_jobs")
+	defer span.Finish()
+
+	span.SetTag("organisation_id", organisationID)
+	span.SetTag("domain", domain)
+
+	jobIDs, err := jm.activeJobIDs(ctx, organisationID, domain)
+	if err != nil {
+		span.SetTag("error", "true")
+		span.SetData("error.message", err.Error())
+		return 0, err
+	}
+
+	cancelled := 0
+	var errs []error
+	for _, jobID := range jobIDs {
+		err := jm.CancelJob(ctx, jobID)
+		switch {
+		case err == nil:
+			cancelled++
+		case errors.Is(err, ErrJobNotCancellable):
+			// Finished since we listed it
+		default:
+			errs = append(errs, fmt.Errorf("job %s: %w", jobID, err))
+		}
+	}
+
+	log.Info().
+		Str("organisation_id", organisationID).
+		Str("domain", domain).
+		Int("active_jobs", len(jobIDs)).
+		Int("cancelled", cancelled).
+		Int("failed", len(errs)).
+		Msg("Cancelled active jobs")
+
+	if len(errs) > 0 {
+		span.SetTag("error", "true")
+		return cancelled, errors.Join(errs...)
+	}
+	return cancelled, nil
+}
+
+// activeJobIDs lists the organisation's unfinished jobs, oldest first
+func (jm *JobManager) activeJobIDs(ctx context.Context, organisationID, domain string) ([]string, error) {
+	var jobIDs []string
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT j.id
+			FROM jobs j
+			JOIN domains d ON j.domain_id = d.id
+			WHERE j.organisation_id = $1
+			  AND ($2 = '' OR d.name = $2)
+			  AND j.status IN ($3, $4, $5, $6)
+			ORDER BY j.created_at
+		`, organisationID, domain, JobStatusPending, JobStatusInitialising, JobStatusRunning, JobStatusPaused)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var jobID string
+			if err := rows.Scan(&jobID); err != nil {
+				return err
+			}
+			jobIDs = append(jobIDs, jobID)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active jobs: %w", err)
+	}
+	return jobIDs, nil
+}
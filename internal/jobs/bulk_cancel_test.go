@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveJobIDs(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id").
+		WithArgs("org-1", "example.com", "pending", "initializing", "running", "paused").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("job-1").AddRow("job-2"))
+	mock.ExpectCommit()
+
+	jobIDs, err := jm.activeJobIDs(context.Background(), "org-1", "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-1", "job-2"}, jobIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelActiveJobsNoneActive(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id").
+		WithArgs("org-1", "", "pending", "initializing", "running", "paused").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	cancelled, err := jm.CancelActiveJobs(context.Background(), "org-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, cancelled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelActiveJobsListError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id").WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	_, err = jm.CancelActiveJobs(context.Background(), "org-1", "example.com")
+	assert.ErrorContains(t, err, "failed to list active jobs")
+}
@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/cdnpurge"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	cdnPurgeTimeout = 10 * time.Minute
+	// cdnPurgeMaxURLs caps a single job's purge so very large crawls don't
+	// exhaust CDN API rate limits
+	cdnPurgeMaxURLs = 10000
+)
+
+// purgeCDNForJob runs the organisation's post-completion CDN purge hook, if
+// configured, for URLs confirmed freshly cached by the job. Jobs that purged
+// before warming are skipped, as purging now would discard what they warmed.
+func (wp *WorkerPool) purgeCDNForJob(jobID string) {
+	if wp == nil || wp.dbQueue == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cdnPurgeTimeout)
+		defer cancel()
+
+		if err := wp.runCDNPurge(ctx, jobID); err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Msg("CDN purge after job completion failed")
+		}
+	}()
+}
+
+func (wp *WorkerPool) runCDNPurge(ctx context.Context, jobID string) error {
+	var purgedBeforeWarm bool
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			SELECT purge_before_warm FROM jobs WHERE id = $1
+		`, jobID).Scan(&purgedBeforeWarm)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load job purge settings: %w", err)
+	}
+	if purgedBeforeWarm {
+		log.Debug().Str("job_id", jobID).Msg("Job purged before warming; skipping post-completion CDN purge")
+		return nil
+	}
+
+	cfg, err := loadCDNPurgeConfig(ctx, wp.dbQueue, jobID)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return nil // No purge hook configured for this organisation
+	}
+
+	urls, err := wp.freshlyCachedURLs(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		log.Debug().Str("job_id", jobID).Msg("No freshly cached URLs to purge")
+		return nil
+	}
+
+	purger, err := cdnpurge.New(*cfg)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err := purger.Purge(ctx, urls); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("job_id", jobID).
+		Str("provider", purger.Provider()).
+		Int("urls_purged", len(urls)).
+		Bool("soft_purge", cfg.SoftPurge).
+		Dur("duration", time.Since(start)).
+		Msg("Purged stale CDN content after job completion")
+
+	return nil
+}
+
+// txExecutor runs a function in a database transaction; both the worker
+// pool's queue and the job manager's satisfy it
+type txExecutor interface {
+	Execute(ctx context.Context, fn func(*sql.Tx) error) error
+}
+
+// loadCDNPurgeConfig returns the enabled CDN purge connection, with its token,
+// for a job's organisation. Returns nil when none is configured.
+func loadCDNPurgeConfig(ctx context.Context, q txExecutor, jobID string) (*cdnpurge.Config, error) {
+	var cfg cdnpurge.Config
+	var connectionID string
+	var token sql.NullString
+
+	err := q.Execute(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			SELECT c.id, c.provider, c.zone_id, c.soft_purge
+			FROM jobs j
+			JOIN cdn_purge_connections c ON c.organisation_id = j.organisation_id
+			WHERE j.id = $1 AND c.enabled = TRUE
+		`, jobID).Scan(&connectionID, &cfg.Provider, &cfg.ZoneID, &cfg.SoftPurge)
+		if err != nil {
+			return err
+		}
+		return tx.QueryRowContext(ctx, `SELECT get_cdn_purge_token($1::uuid)`, connectionID).Scan(&token)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cdn purge connection: %w", err)
+	}
+	if !token.Valid || token.String == "" {
+		return nil, fmt.Errorf("cdn purge token missing for connection %s", connectionID)
+	}
+	cfg.APIToken = token.String
+
+	return &cfg, nil
+}
+
+// freshlyCachedURLs returns URLs whose cache was confirmed warm by the job,
+// either on the first request or after the cache validation loop. URLs use
+// the job's url_scheme, matching the URLs that were warmed.
+func (wp *WorkerPool) freshlyCachedURLs(ctx context.Context, jobID string) ([]string, error) {
+	var urls []string
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT d.name, p.path, j.url_scheme
+			FROM tasks t
+			JOIN jobs j ON t.job_id = j.id
+			JOIN pages p ON t.page_id = p.id
+			JOIN domains d ON p.domain_id = d.id
+			WHERE t.job_id = $1
+			  AND t.status = $2
+			  AND (UPPER(t.second_cache_status) = 'HIT' OR UPPER(t.cache_status) = 'HIT')
+			ORDER BY t.priority_score DESC
+			LIMIT $3
+		`, jobID, TaskStatusCompleted, cdnPurgeMaxURLs)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var domainName, path, scheme string
+			if err := rows.Scan(&domainName, &path, &scheme); err != nil {
+				return err
+			}
+			urls = append(urls, constructTaskURL(path, domainName, URLScheme(scheme)))
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load freshly cached URLs: %w", err)
+	}
+	return urls, nil
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreshlyCachedURLsUseJobScheme(t *testing.T) {
+	tests := []struct {
+		scheme   string
+		expected string
+	}{
+		{scheme: "https", expected: "https://example.com/about"},
+		{scheme: "http", expected: "http://example.com/about"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			queue := &mockDbQueueWrapper{mockDB: mockDB}
+			wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT d.name, p.path, j.url_scheme").
+				WithArgs("job-1", TaskStatusCompleted, cdnPurgeMaxURLs).
+				WillReturnRows(sqlmock.NewRows([]string{"name", "path", "url_scheme"}).
+					AddRow("example.com", "/about", tt.scheme))
+			mock.ExpectCommit()
+
+			urls, err := wp.freshlyCachedURLs(context.Background(), "job-1")
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expected}, urls)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCDNPurgeSkipsJobsPurgedBeforeWarm(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT purge_before_warm FROM jobs").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"purge_before_warm"}).AddRow(true))
+	mock.ExpectCommit()
+
+	require.NoError(t, wp.runCDNPurge(context.Background(), "job-1"))
+	assert.NoError(t, mock.ExpectationsWereMet(), "no purge connection should be loaded")
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/rs/zerolog/log"
+)
+
+// SkipReasonUnchanged marks tasks skipped by changed-only jobs because the
+// page's ETag/Last-Modified matched the previous job
+const SkipReasonUnchanged = "unchanged"
+
+// errTaskUnchanged tells processNextTask the page was skipped as unchanged
+var errTaskUnchanged = errors.New("page unchanged since previous job")
+
+// previousValidators returns the ETag and Last-Modified the page returned the
+// last time another job for the same organisation warmed it
+func (wp *WorkerPool) previousValidators(ctx context.Context, task *Task) (crawler.Validators, error) {
+	var etag, lastModified sql.NullString
+	err := wp.db.QueryRowContext(ctx, `
+		SELECT t.headers->'Etag'->>0, t.headers->'Last-Modified'->>0
+		FROM tasks t
+		JOIN jobs j ON j.id = t.job_id
+		WHERE t.page_id = $1
+		  AND t.job_id <> $2
+		  AND t.status = 'completed'
+		  AND j.organisation_id IS NOT DISTINCT FROM (SELECT organisation_id FROM jobs WHERE id = $2)
+		ORDER BY t.completed_at DESC
+		LIMIT 1
+	`, task.PageID, task.JobID).Scan(&etag, &lastModified)
+	if errors.Is(err, sql.ErrNoRows) {
+		return crawler.Validators{}, nil
+	}
+	if err != nil {
+		return crawler.Validators{}, err
+	}
+	return crawler.Validators{ETag: etag.String, LastModified: lastModified.String}, nil
+}
+
+// pageUnchanged reports whether a changed-only task can skip its full warm.
+// Lookup or request failures fall back to warming the page.
+func (wp *WorkerPool) pageUnchanged(ctx context.Context, task *Task, url string) bool {
+	previous, err := wp.previousValidators(ctx, task)
+	if err != nil {
+		log.Warn().Err(err).Str("task_id", task.ID).Msg("Failed to load previous validators, warming page")
+		return false
+	}
+	if previous.IsZero() {
+		return false
+	}
+
+	unchanged, err := wp.crawler.CheckUnchanged(ctx, url, previous)
+	if err != nil {
+		log.Debug().Err(err).Str("task_id", task.ID).Msg("Conditional check failed, warming page")
+		return false
+	}
+	return unchanged
+}
+
+// handleTaskUnchanged records a changed-only task as skipped-unchanged
+func (wp *WorkerPool) handleTaskUnchanged(ctx context.Context, task *db.Task) error {
+	wp.resetJobFailureStreak(task.JobID)
+
+	task.Status = string(TaskStatusSkipped)
+	task.SkipReason = SkipReasonUnchanged
+	task.CompletedAt = time.Now().UTC()
+
+	log.Debug().
+		Str("task_id", task.ID).
+		Str("job_id", task.JobID).
+		Msg("Skipped unchanged page")
+
+	// Free the concurrency slot straight away, as for completed tasks
+	if err := wp.releaseRunningTaskSlot(task.JobID); err != nil {
+		log.Error().Err(err).Str("job_id", task.JobID).Str("task_id", task.ID).
+			Msg("Failed to decrement running_tasks counter")
+	}
+
+	wp.batchManager.QueueTaskUpdate(task)
+	return nil
+}
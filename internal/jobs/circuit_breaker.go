@@ -0,0 +1,192 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultBreakerThreshold is the error rate over a full window of recent
+	// requests that opens a domain's circuit
+	defaultBreakerThreshold = 0.9
+	defaultBreakerCooldown  = 2 * time.Minute
+	// breakerProbeWait keeps claims off a half-open domain while its probe
+	// request is in flight
+	breakerProbeWait = time.Second
+)
+
+const waitingReasonCircuitOpen WaitingReason = "circuit_open"
+
+// errCircuitOpen is returned by DomainLimiter.Acquire while a domain's
+// circuit is open, or half-open with its probe already in flight
+var errCircuitOpen = errors.New("domain circuit breaker open")
+
+// breakerState is where a domain's circuit breaker is in its cycle
+type breakerState string
+
+const (
+	breakerClosed   breakerState = "closed"
+	breakerOpen     breakerState = "open"
+	breakerHalfOpen breakerState = "half_open"
+)
+
+// breakerTransition is a state change to log and count
+type breakerTransition struct {
+	from, to  breakerState
+	errorRate float64
+}
+
+// domainBreaker stops requests to a domain failing on nearly every request.
+// Once the error rate over the last errorWindowSize requests reaches the
+// threshold the circuit opens for a cooldown, then a single probe request
+// decides whether it closes again or reopens. Guarded by domainState.mu.
+type domainBreaker struct {
+	state        breakerState
+	window       errorWindow
+	openUntil    time.Time
+	probeStarted time.Time // Zero unless a half-open probe is in flight
+}
+
+func breakerConfigFromEnv(cfg *DomainLimiterConfig) {
+	if raw := strings.TrimSpace(os.Getenv("BBB_CIRCUIT_BREAKER_THRESHOLD")); raw != "" {
+		// 0 turns the breaker off
+		if parsed, err := strconv.ParseFloat(raw, 64); err == nil && parsed >= 0 && parsed <= 1 {
+			cfg.BreakerThreshold = parsed
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("BBB_CIRCUIT_BREAKER_COOLDOWN_SECONDS")); raw != "" {
+		if sec, err := strconv.Atoi(raw); err == nil && sec > 0 {
+			cfg.BreakerCooldown = time.Duration(sec) * time.Second
+		}
+	}
+}
+
+// allow reports whether a request may go ahead, moving an open circuit whose
+// cooldown has passed to half-open and letting one probe through
+func (b *domainBreaker) allow(cfg DomainLimiterConfig, now time.Time) (bool, *breakerTransition) {
+	var transition *breakerTransition
+	switch b.state {
+	case breakerOpen:
+		if now.Before(b.openUntil) {
+			return false, nil
+		}
+		b.state = breakerHalfOpen
+		b.probeStarted = time.Time{}
+		transition = &breakerTransition{from: breakerOpen, to: breakerHalfOpen}
+		fallthrough
+	case breakerHalfOpen:
+		// A probe that never reported back, e.g. because it was skipped,
+		// expires after a cooldown so the circuit can't stay stuck
+		if !b.probeStarted.IsZero() && now.Sub(b.probeStarted) < cfg.BreakerCooldown {
+			return false, transition
+		}
+		b.probeStarted = now
+		return true, transition
+	default:
+		return true, nil
+	}
+}
+
+// record adds a request outcome, opening the circuit once the window's error
+// rate reaches the threshold. A half-open probe closes or reopens it.
+func (b *domainBreaker) record(cfg DomainLimiterConfig, failure bool, now time.Time) *breakerTransition {
+	if cfg.BreakerThreshold <= 0 {
+		return nil
+	}
+
+	switch b.state {
+	case breakerOpen:
+		// Requests that were already in flight when the circuit opened
+		return nil
+	case breakerHalfOpen:
+		b.probeStarted = time.Time{}
+		if failure {
+			b.state = breakerOpen
+			b.openUntil = now.Add(cfg.BreakerCooldown)
+			return &breakerTransition{from: breakerHalfOpen, to: breakerOpen, errorRate: 1}
+		}
+		b.state = breakerClosed
+		b.window = errorWindow{}
+		return &breakerTransition{from: breakerHalfOpen, to: breakerClosed}
+	default:
+		b.window.add(failure)
+		if b.window.count < errorWindowSize || b.window.rate() < cfg.BreakerThreshold {
+			return nil
+		}
+		rate := b.window.rate()
+		b.state = breakerOpen
+		b.openUntil = now.Add(cfg.BreakerCooldown)
+		b.window = errorWindow{}
+		return &breakerTransition{from: breakerClosed, to: breakerOpen, errorRate: rate}
+	}
+}
+
+// wait is how long claims for the domain should hold off
+func (b *domainBreaker) wait(cfg DomainLimiterConfig, now time.Time) time.Duration {
+	switch b.state {
+	case breakerOpen:
+		return max(b.openUntil.Sub(now), 0)
+	case breakerHalfOpen:
+		if !b.probeStarted.IsZero() && now.Sub(b.probeStarted) < cfg.BreakerCooldown {
+			return breakerProbeWait
+		}
+	}
+	return 0
+}
+
+// RecordBreakerOutcome feeds a request outcome into the domain's circuit
+// breaker. Failures are server errors, rate limits, blocks and requests that
+// got no response at all.
+func (dl *DomainLimiter) RecordBreakerOutcome(domain string, failure bool) {
+	if domain == "" || dl.cfg.BreakerThreshold <= 0 {
+		return
+	}
+
+	state := dl.getOrCreateState(domain)
+	state.mu.Lock()
+	transition := state.breaker.record(dl.cfg, failure, dl.now())
+	state.mu.Unlock()
+
+	logBreakerTransition(domain, transition, dl.cfg.BreakerCooldown)
+}
+
+func logBreakerTransition(domain string, transition *breakerTransition, cooldown time.Duration) {
+	if transition == nil {
+		return
+	}
+
+	event := log.Info()
+	if transition.to == breakerOpen {
+		event = log.Warn().Float64("error_rate", transition.errorRate).Dur("cooldown", cooldown)
+	}
+	event.
+		Str("domain", domain).
+		Str("from", string(transition.from)).
+		Str("to", string(transition.to)).
+		Msg("Domain circuit breaker changed state")
+	observability.RecordCircuitBreakerTransition(context.Background(), domain, string(transition.to))
+}
+
+// handleTaskCircuitOpen puts a task claimed for a domain whose circuit is open
+// back to waiting without spending a retry; claims resume after the cooldown
+func (wp *WorkerPool) handleTaskCircuitOpen(ctx context.Context, task *db.Task) error {
+	task.Status = string(TaskStatusWaiting)
+	task.StartedAt = time.Time{}
+	wp.recordWaitingTask(ctx, task, waitingReasonCircuitOpen)
+
+	if err := wp.releaseRunningTaskSlot(task.JobID); err != nil {
+		log.Error().Err(err).Str("job_id", task.JobID).Str("task_id", task.ID).
+			Msg("Failed to decrement running_tasks counter")
+	}
+
+	wp.batchManager.QueueTaskUpdate(task)
+	return nil
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openBreaker(dl *DomainLimiter, domain string) {
+	for range errorWindowSize {
+		dl.RecordBreakerOutcome(domain, true)
+	}
+}
+
+func TestCircuitBreakerOpensOnSustainedErrors(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	now := time.Now()
+	dl.now = func() time.Time { return now }
+
+	// A partial window never opens the circuit, however bad
+	for range errorWindowSize - 1 {
+		dl.RecordBreakerOutcome("example.com", true)
+	}
+	assert.Equal(t, time.Duration(0), dl.EstimatedWait("example.com"))
+
+	dl.RecordBreakerOutcome("example.com", true)
+	assert.Equal(t, dl.cfg.BreakerCooldown, dl.EstimatedWait("example.com"))
+
+	_, err := dl.Acquire(context.Background(), DomainRequest{Domain: "example.com", JobID: "job-1"})
+	assert.ErrorIs(t, err, errCircuitOpen)
+
+	// Other domains are unaffected
+	assert.Equal(t, time.Duration(0), dl.EstimatedWait("other.com"))
+}
+
+func TestCircuitBreakerStaysClosedBelowThreshold(t *testing.T) {
+	dl := newDomainLimiter(nil)
+
+	for i := range errorWindowSize * 2 {
+		dl.RecordBreakerOutcome("example.com", i%4 != 0)
+	}
+	assert.Equal(t, time.Duration(0), dl.EstimatedWait("example.com"))
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	dl.cfg.BaseDelay = 0
+	now := time.Now()
+	dl.now = func() time.Time { return now }
+	ctx := context.Background()
+	req := DomainRequest{Domain: "example.com", JobID: "job-1"}
+
+	openBreaker(dl, "example.com")
+	now = now.Add(dl.cfg.BreakerCooldown)
+
+	// First claim after the cooldown is the probe; others wait on it
+	permit, err := dl.Acquire(ctx, req)
+	require.NoError(t, err)
+	permit.Release(false, false)
+	_, err = dl.Acquire(ctx, req)
+	assert.ErrorIs(t, err, errCircuitOpen)
+	assert.Equal(t, breakerProbeWait, dl.EstimatedWait("example.com"))
+
+	// A failed probe reopens for another cooldown
+	dl.RecordBreakerOutcome("example.com", true)
+	assert.Equal(t, dl.cfg.BreakerCooldown, dl.EstimatedWait("example.com"))
+
+	// A successful probe closes it again
+	now = now.Add(dl.cfg.BreakerCooldown)
+	permit, err = dl.Acquire(ctx, req)
+	require.NoError(t, err)
+	permit.Release(true, false)
+	dl.RecordBreakerOutcome("example.com", false)
+	assert.Equal(t, time.Duration(0), dl.EstimatedWait("example.com"))
+
+	permit, err = dl.Acquire(ctx, req)
+	require.NoError(t, err)
+	permit.Release(true, false)
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	t.Setenv("BBB_CIRCUIT_BREAKER_THRESHOLD", "0")
+	dl := newDomainLimiter(nil)
+
+	openBreaker(dl, "example.com")
+	assert.Equal(t, time.Duration(0), dl.EstimatedWait("example.com"))
+}
@@ -0,0 +1,107 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// concurrencyBlockFlushInterval is how often counted concurrency blocks are
+// written to jobs. Claims are rejected many times a second on a saturated job,
+// so they're counted in memory rather than with an UPDATE each.
+const concurrencyBlockFlushInterval = 5 * time.Second
+
+// pendingConcurrencyBlocks is a job's blocked claims since the last flush
+type pendingConcurrencyBlocks struct {
+	count int64
+	since time.Time // First block since the last flush
+}
+
+// countConcurrencyBlock notes a claim rejected because jobID was at its
+// concurrency limit with work queued
+func (wp *WorkerPool) countConcurrencyBlock(jobID string, now time.Time) {
+	if jobID == "" {
+		return
+	}
+	wp.concurrencyBlockMu.Lock()
+	defer wp.concurrencyBlockMu.Unlock()
+	if wp.concurrencyBlockPending == nil {
+		wp.concurrencyBlockPending = make(map[string]*pendingConcurrencyBlocks)
+	}
+	pending, ok := wp.concurrencyBlockPending[jobID]
+	if !ok {
+		pending = &pendingConcurrencyBlocks{since: now}
+		wp.concurrencyBlockPending[jobID] = pending
+	}
+	pending.count++
+}
+
+// startConcurrencyBlockFlushLoop periodically writes counted concurrency
+// blocks to their jobs
+func (wp *WorkerPool) startConcurrencyBlockFlushLoop(ctx context.Context) {
+	wp.wg.Go(func() {
+		ticker := time.NewTicker(concurrencyBlockFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-wp.stopCh:
+				return
+			case <-ticker.C:
+				wp.flushConcurrencyBlocks(ctx)
+			}
+		}
+	})
+}
+
+// flushConcurrencyBlocks adds the counted blocks to each job in one UPDATE and
+// opens its blocked period if it's still saturated; a claim since then will
+// have closed it. Failures only lose a data point.
+func (wp *WorkerPool) flushConcurrencyBlocks(ctx context.Context) {
+	wp.concurrencyBlockMu.Lock()
+	pending := wp.concurrencyBlockPending
+	wp.concurrencyBlockPending = nil
+	wp.concurrencyBlockMu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	jobIDs := make([]string, 0, len(pending))
+	counts := make([]int64, 0, len(pending))
+	since := make([]time.Time, 0, len(pending))
+	for jobID, blocks := range pending {
+		jobIDs = append(jobIDs, jobID)
+		counts = append(counts, blocks.count)
+		since = append(since, blocks.since)
+	}
+
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	flushCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := wp.dbQueue.Execute(flushCtx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(flushCtx, `
+			UPDATE jobs j
+			SET concurrency_blocks = j.concurrency_blocks + b.blocks,
+			    concurrency_blocked_since = CASE
+			        WHEN j.concurrency > 0 AND j.running_tasks >= j.concurrency AND j.pending_tasks > 0
+			        THEN COALESCE(j.concurrency_blocked_since, b.since)
+			        ELSE j.concurrency_blocked_since
+			    END
+			FROM unnest($1::text[], $2::bigint[], $3::timestamptz[]) AS b(job_id, blocks, since)
+			WHERE j.id = b.job_id
+			  AND j.status = 'running'
+		`, pq.Array(jobIDs), pq.Array(counts), pq.Array(since))
+		return err
+	})
+	if err != nil {
+		log.Warn().Err(err).Int("jobs", len(jobIDs)).Msg("Failed to record concurrency blocks")
+	}
+}
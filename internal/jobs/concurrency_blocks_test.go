@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushConcurrencyBlocksWritesCountsInOneUpdate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}}
+
+	now := time.Now().UTC()
+	for range 3 {
+		wp.countConcurrencyBlock("job-1", now)
+	}
+	wp.countConcurrencyBlock("", now) // Not attributable to a job
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs j\\s+SET concurrency_blocks = j.concurrency_blocks \\+ b.blocks").
+		WithArgs(`{"job-1"}`, "{3}", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	wp.flushConcurrencyBlocks(context.Background())
+
+	// Nothing counted since, so no write
+	wp.flushConcurrencyBlocks(context.Background())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
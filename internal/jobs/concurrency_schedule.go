@@ -0,0 +1,133 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+)
+
+// maxConcurrencyWindows bounds how many windows a schedule can define
+const maxConcurrencyWindows = 24
+
+// ConcurrencyWindow sets a job's concurrency between two local times of day.
+// End earlier than Start wraps past midnight (e.g. 22:00-06:00).
+type ConcurrencyWindow struct {
+	Start       string `json:"start"` // "HH:MM", inclusive
+	End         string `json:"end"`   // "HH:MM", exclusive
+	Concurrency int    `json:"concurrency"`
+
+	startMinute int
+	endMinute   int
+}
+
+// ConcurrencySchedule varies a job's concurrency by time of day in its
+// timezone. Outside every window the job's configured concurrency applies;
+// where windows overlap the first match wins. Call Validate before use.
+type ConcurrencySchedule struct {
+	Timezone string              `json:"timezone,omitempty"` // IANA name, defaults to UTC
+	Windows  []ConcurrencyWindow `json:"windows"`
+
+	location *time.Location
+}
+
+// Validate checks the schedule against the job's concurrency and resolves
+// its timezone and window times. Window levels can't exceed jobConcurrency,
+// which remains the job's in-flight budget.
+func (s *ConcurrencySchedule) Validate(jobConcurrency int) error {
+	if len(s.Windows) == 0 {
+		return fmt.Errorf("concurrency schedule needs at least one window")
+	}
+	if len(s.Windows) > maxConcurrencyWindows {
+		return fmt.Errorf("concurrency schedule supports at most %d windows", maxConcurrencyWindows)
+	}
+
+	location := time.UTC
+	if s.Timezone != "" {
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid schedule timezone %q", s.Timezone)
+		}
+		location = loc
+	}
+
+	for i := range s.Windows {
+		w := &s.Windows[i]
+		start, err := parseClockMinute(w.Start)
+		if err != nil {
+			return fmt.Errorf("window %d: invalid start: %w", i+1, err)
+		}
+		end, err := parseClockMinute(w.End)
+		if err != nil {
+			return fmt.Errorf("window %d: invalid end: %w", i+1, err)
+		}
+		if start == end {
+			return fmt.Errorf("window %d: start and end must differ", i+1)
+		}
+		if w.Concurrency < 1 || (jobConcurrency > 0 && w.Concurrency > jobConcurrency) {
+			return fmt.Errorf("window %d: concurrency must be between 1 and the job concurrency (%d)", i+1, jobConcurrency)
+		}
+		w.startMinute, w.endMinute = start, end
+	}
+
+	s.location = location
+	return nil
+}
+
+// ConcurrencyAt returns the scheduled concurrency at t, or fallback when no
+// window applies
+func (s *ConcurrencySchedule) ConcurrencyAt(t time.Time, fallback int) int {
+	if s == nil || s.location == nil {
+		return fallback
+	}
+
+	local := t.In(s.location)
+	minute := local.Hour()*60 + local.Minute()
+	for _, w := range s.Windows {
+		if w.contains(minute) {
+			return w.Concurrency
+		}
+	}
+	return fallback
+}
+
+func (w ConcurrencyWindow) contains(minute int) bool {
+	if w.startMinute < w.endMinute {
+		return minute >= w.startMinute && minute < w.endMinute
+	}
+	// Wraps past midnight
+	return minute >= w.startMinute || minute < w.endMinute
+}
+
+// parseConcurrencySchedule decodes a stored schedule; empty input means none
+func parseConcurrencySchedule(raw []byte, jobConcurrency int) (*ConcurrencySchedule, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var schedule ConcurrencySchedule
+	if err := json.Unmarshal(raw, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal concurrency schedule: %w", err)
+	}
+	if err := schedule.Validate(jobConcurrency); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// serialiseConcurrencySchedule returns the JSON to store, or nil for NULL
+func serialiseConcurrencySchedule(schedule *ConcurrencySchedule) any {
+	if schedule == nil {
+		return nil
+	}
+	return db.Serialise(schedule)
+}
+
+func parseClockMinute(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
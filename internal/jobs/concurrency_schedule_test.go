@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule ConcurrencySchedule
+		wantErr  bool
+	}{
+		{"valid", ConcurrencySchedule{Timezone: "Australia/Sydney", Windows: []ConcurrencyWindow{{Start: "09:00", End: "17:00", Concurrency: 2}}}, false},
+		{"defaults to utc", ConcurrencySchedule{Windows: []ConcurrencyWindow{{Start: "22:00", End: "06:00", Concurrency: 5}}}, false},
+		{"no windows", ConcurrencySchedule{}, true},
+		{"bad timezone", ConcurrencySchedule{Timezone: "Mars/Olympus", Windows: []ConcurrencyWindow{{Start: "09:00", End: "17:00", Concurrency: 2}}}, true},
+		{"bad time", ConcurrencySchedule{Windows: []ConcurrencyWindow{{Start: "9am", End: "17:00", Concurrency: 2}}}, true},
+		{"empty window", ConcurrencySchedule{Windows: []ConcurrencyWindow{{Start: "09:00", End: "09:00", Concurrency: 2}}}, true},
+		{"above job concurrency", ConcurrencySchedule{Windows: []ConcurrencyWindow{{Start: "09:00", End: "17:00", Concurrency: 11}}}, true},
+		{"zero concurrency", ConcurrencySchedule{Windows: []ConcurrencyWindow{{Start: "09:00", End: "17:00", Concurrency: 0}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate(10)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConcurrencyScheduleConcurrencyAt(t *testing.T) {
+	schedule := &ConcurrencySchedule{
+		Timezone: "Australia/Sydney",
+		Windows: []ConcurrencyWindow{
+			{Start: "09:00", End: "17:00", Concurrency: 2},
+			{Start: "22:00", End: "02:00", Concurrency: 8},
+		},
+	}
+	require.NoError(t, schedule.Validate(10))
+
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 16, hour, minute, 0, 0, sydney).UTC()
+	}
+
+	assert.Equal(t, 2, schedule.ConcurrencyAt(at(9, 0), 10))
+	assert.Equal(t, 2, schedule.ConcurrencyAt(at(16, 59), 10))
+	assert.Equal(t, 10, schedule.ConcurrencyAt(at(17, 0), 10))
+	assert.Equal(t, 8, schedule.ConcurrencyAt(at(23, 30), 10))
+	assert.Equal(t, 8, schedule.ConcurrencyAt(at(1, 0), 10))
+	assert.Equal(t, 10, schedule.ConcurrencyAt(at(3, 0), 10))
+
+	var none *ConcurrencySchedule
+	assert.Equal(t, 10, none.ConcurrencyAt(at(12, 0), 10))
+}
+
+func TestDomainLimiterAppliesConcurrencySchedule(t *testing.T) {
+	schedule := &ConcurrencySchedule{Windows: []ConcurrencyWindow{{Start: "09:00", End: "17:00", Concurrency: 2}}}
+	require.NoError(t, schedule.Validate(10))
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	dl := newDomainLimiter(nil)
+	dl.cfg.BaseDelay = 0
+	dl.now = func() time.Time { return now }
+
+	req := DomainRequest{Domain: "example.com", JobID: "job-1", JobConcurrency: 10, Schedule: schedule}
+
+	permit, err := dl.Acquire(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, dl.GetEffectiveConcurrency("job-1", "example.com"))
+	permit.Release(false, false)
+
+	// Outside the window the job's own concurrency applies again
+	now = time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	permit, err = dl.Acquire(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 10, dl.GetEffectiveConcurrency("job-1", "example.com"))
+	permit.Release(false, false)
+}
@@ -0,0 +1,99 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"regexp"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/rs/zerolog/log"
+)
+
+// htmlCommentPattern matches HTML comments, where caching plugins and CMSes
+// often stamp render times that change on every response
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// interTagWhitespacePattern matches indentation between tags
+var interTagWhitespacePattern = regexp.MustCompile(`>\s+<`)
+
+// whitespacePattern matches runs of whitespace, which templates vary freely
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// hashContent returns the SHA-256 of a body with comments and indentation
+// removed and whitespace collapsed, so cosmetic differences don't count as
+// changes
+func hashContent(body []byte) string {
+	normalised := htmlCommentPattern.ReplaceAll(body, nil)
+	normalised = interTagWhitespacePattern.ReplaceAll(normalised, []byte("><"))
+	normalised = whitespacePattern.ReplaceAll(normalised, []byte(" "))
+	sum := sha256.Sum256(bytes.TrimSpace(normalised))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashesContent reports whether a job opted in to content hashing
+func (wp *WorkerPool) hashesContent(jobID string) bool {
+	wp.jobInfoMutex.RLock()
+	defer wp.jobInfoMutex.RUnlock()
+
+	info, exists := wp.jobInfoCache[jobID]
+	return exists && info.HashContent
+}
+
+// previousContentHash returns the content hash recorded the last time another
+// job for the same organisation warmed the page, or "" if there isn't one
+func (wp *WorkerPool) previousContentHash(ctx context.Context, task *db.Task) (string, error) {
+	if wp.db == nil {
+		return "", nil
+	}
+
+	var hash string
+	err := wp.db.QueryRowContext(ctx, `
+		SELECT t.content_hash
+		FROM tasks t
+		JOIN jobs j ON j.id = t.job_id
+		WHERE t.page_id = $1
+		  AND t.job_id <> $2
+		  AND t.status = 'completed'
+		  AND t.content_hash IS NOT NULL
+		  AND j.organisation_id IS NOT DISTINCT FROM (SELECT organisation_id FROM jobs WHERE id = $2)
+		ORDER BY t.completed_at DESC
+		LIMIT 1
+	`, task.PageID, task.JobID).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return hash, err
+}
+
+// recordContentHash hashes the response body and compares it with the page's
+// previous hash. A 304 carries the previous hash forward as unchanged; HEAD
+// warms have no body and aren't hashed. ContentChanged stays unset when
+// there's nothing to compare against.
+func (wp *WorkerPool) recordContentHash(ctx context.Context, task *db.Task, result *crawler.CrawlResult) {
+	if len(result.Body) == 0 && !result.NotModified {
+		return
+	}
+
+	previous, err := wp.previousContentHash(ctx, task)
+	if err != nil {
+		log.Warn().Err(err).Str("task_id", task.ID).Msg("Failed to load previous content hash")
+	}
+
+	if result.NotModified {
+		if previous != "" {
+			task.ContentHash = previous
+			task.ContentChanged = sql.NullBool{Bool: false, Valid: true}
+		}
+		return
+	}
+
+	task.ContentHash = hashContent(result.Body)
+	if previous != "" {
+		task.ContentChanged = sql.NullBool{Bool: task.ContentHash != previous, Valid: true}
+	}
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashContentIgnoresCosmeticDifferences(t *testing.T) {
+	base := hashContent([]byte("<html><body><h1>Launch</h1></body></html>"))
+
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, hashContent([]byte("<html>\n  <body>\n    <h1>Launch</h1>\n  </body>\n</html>\n")))
+	assert.Equal(t, base, hashContent([]byte("<html><body><h1>Launch</h1></body></html><!-- Page generated in 0.42 seconds -->")))
+	assert.NotEqual(t, base, hashContent([]byte("<html><body><h1>Launch day</h1></body></html>")))
+}
+
+func TestRecordContentHash(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB}
+	body := []byte("<html><body>Launch</body></html>")
+	previousRows := func(hash string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"content_hash"}).AddRow(hash)
+	}
+
+	// First warm of the page: hashed, nothing to compare against
+	mock.ExpectQuery("SELECT t.content_hash").WithArgs(7, "job-1").WillReturnError(sql.ErrNoRows)
+	task := &db.Task{ID: "t1", JobID: "job-1", PageID: 7}
+	wp.recordContentHash(context.Background(), task, &crawler.CrawlResult{Body: body})
+	assert.Equal(t, hashContent(body), task.ContentHash)
+	assert.False(t, task.ContentChanged.Valid)
+
+	// Same body as last time
+	mock.ExpectQuery("SELECT t.content_hash").WithArgs(7, "job-1").WillReturnRows(previousRows(hashContent(body)))
+	task = &db.Task{ID: "t2", JobID: "job-1", PageID: 7}
+	wp.recordContentHash(context.Background(), task, &crawler.CrawlResult{Body: body})
+	assert.Equal(t, sql.NullBool{Bool: false, Valid: true}, task.ContentChanged)
+
+	// Different body
+	mock.ExpectQuery("SELECT t.content_hash").WithArgs(7, "job-1").WillReturnRows(previousRows("stale"))
+	task = &db.Task{ID: "t3", JobID: "job-1", PageID: 7}
+	wp.recordContentHash(context.Background(), task, &crawler.CrawlResult{Body: body})
+	assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, task.ContentChanged)
+
+	// 304 carries the previous hash forward
+	mock.ExpectQuery("SELECT t.content_hash").WithArgs(7, "job-1").WillReturnRows(previousRows("stale"))
+	task = &db.Task{ID: "t4", JobID: "job-1", PageID: 7}
+	wp.recordContentHash(context.Background(), task, &crawler.CrawlResult{NotModified: true})
+	assert.Equal(t, "stale", task.ContentHash)
+	assert.Equal(t, sql.NullBool{Bool: false, Valid: true}, task.ContentChanged)
+
+	// HEAD warms have no body to hash
+	task = &db.Task{ID: "t5", JobID: "job-1", PageID: 7}
+	wp.recordContentHash(context.Background(), task, &crawler.CrawlResult{})
+	assert.Empty(t, task.ContentHash)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
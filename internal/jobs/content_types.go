@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/rs/zerolog/log"
+)
+
+// SkipReasonContentType marks tasks skipped because the response's
+// Content-Type wasn't on the job's allow list, e.g. PDFs and images
+const SkipReasonContentType = "content_type"
+
+// MaxContentTypes caps a job's content type allow list
+const MaxContentTypes = 20
+
+// DefaultContentTypes is the allow list for jobs that don't set one, so warm
+// jobs stay focused on pages
+var DefaultContentTypes = []string{"text/html", "application/xhtml+xml"}
+
+// ListedURLContentTypes is the default for jobs given their URLs up front
+// (warm_urls, seed_urls or a HAR import). Those lists name assets on
+// purpose, so nothing in them is skipped for its type.
+var ListedURLContentTypes = []string{"*/*"}
+
+// errTaskContentType tells processNextTask the response wasn't an allowed type
+var errTaskContentType = errors.New("response content type not allowed")
+
+// NormaliseContentTypes validates and normalises a content type allow list.
+// Entries are media types such as "text/html", "image/*" for a whole family,
+// or "*/*" to warm everything. Parameters are dropped, duplicates and blank
+// entries are removed, and an empty list gets DefaultContentTypes.
+func NormaliseContentTypes(types []string) ([]string, error) {
+	if len(types) > MaxContentTypes {
+		return nil, fmt.Errorf("content_types supports at most %d types", MaxContentTypes)
+	}
+
+	normalised := make([]string, 0, len(types))
+	for _, raw := range types {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		mediaType, _, err := mime.ParseMediaType(raw)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return nil, fmt.Errorf("invalid content type %q: use a media type like text/html or image/*", raw)
+		}
+		if family, _, _ := strings.Cut(mediaType, "/"); family == "*" && mediaType != "*/*" {
+			return nil, fmt.Errorf("invalid content type %q: use a media type like text/html or image/*", raw)
+		}
+		if !slices.Contains(normalised, mediaType) {
+			normalised = append(normalised, mediaType)
+		}
+	}
+
+	if len(normalised) == 0 {
+		return slices.Clone(DefaultContentTypes), nil
+	}
+	return normalised, nil
+}
+
+// jobContentTypes returns the job's normalised allow list, defaulting to
+// ListedURLContentTypes when the job warms an explicit URL list
+func jobContentTypes(options *JobOptions) ([]string, error) {
+	if len(options.ContentTypes) == 0 && (len(options.WarmURLs) > 0 || len(options.SeedURLs) > 0) {
+		return slices.Clone(ListedURLContentTypes), nil
+	}
+	return NormaliseContentTypes(options.ContentTypes)
+}
+
+// handleTaskContentType records a task whose response type wasn't allowed as
+// skipped. The body was never downloaded, so there's nothing else to store.
+func (wp *WorkerPool) handleTaskContentType(ctx context.Context, task *db.Task, result *crawler.CrawlResult) error {
+	wp.resetJobFailureStreak(task.JobID)
+
+	task.Status = string(TaskStatusSkipped)
+	task.SkipReason = SkipReasonContentType
+	task.CompletedAt = time.Now().UTC()
+
+	contentType := ""
+	if result != nil {
+		contentType = result.ContentType
+	}
+	log.Debug().
+		Str("task_id", task.ID).
+		Str("job_id", task.JobID).
+		Str("content_type", contentType).
+		Msg("Skipped response with disallowed content type")
+
+	// Free the concurrency slot straight away, as for completed tasks
+	if err := wp.releaseRunningTaskSlot(task.JobID); err != nil {
+		log.Error().Err(err).Str("job_id", task.JobID).Str("task_id", task.ID).
+			Msg("Failed to decrement running_tasks counter")
+	}
+
+	wp.batchManager.QueueTaskUpdate(task)
+	return nil
+}
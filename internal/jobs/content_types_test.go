@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormaliseContentTypes(t *testing.T) {
+	types, err := NormaliseContentTypes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultContentTypes, types)
+
+	types, err = NormaliseContentTypes([]string{" Text/HTML; charset=utf-8", "image/*", "", "text/html", "*/*"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"text/html", "image/*", "*/*"}, types)
+
+	for _, invalid := range []string{"html", "text/", "*/html", "text/html;;"} {
+		_, err := NormaliseContentTypes([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+
+	_, err = NormaliseContentTypes(make([]string, MaxContentTypes+1))
+	assert.Error(t, err)
+}
+
+func TestJobContentTypes(t *testing.T) {
+	types, err := jobContentTypes(&JobOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultContentTypes, types)
+
+	// HAR imports and other explicit lists warm their assets too
+	types, err = jobContentTypes(&JobOptions{WarmURLs: []string{"/", "/app.js", "/font.woff2"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*/*"}, types)
+
+	types, err = jobContentTypes(&JobOptions{SeedURLs: []string{"/"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*/*"}, types)
+
+	// An explicit allow list still wins
+	types, err = jobContentTypes(&JobOptions{WarmURLs: []string{"/"}, ContentTypes: []string{"text/html"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"text/html"}, types)
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import "fmt"
+
+// MaxCrawlDelayBoundSeconds is the largest per-job crawl delay floor or ceiling
+const MaxCrawlDelayBoundSeconds = 300
+
+// ValidateCrawlDelayBounds checks a job's crawl delay floor and ceiling; 0
+// leaves that side unbounded
+func ValidateCrawlDelayBounds(minSeconds, maxSeconds int) error {
+	if minSeconds < 0 || minSeconds > MaxCrawlDelayBoundSeconds {
+		return fmt.Errorf("min_crawl_delay_seconds must be between 0 and %d", MaxCrawlDelayBoundSeconds)
+	}
+	if maxSeconds < 0 || maxSeconds > MaxCrawlDelayBoundSeconds {
+		return fmt.Errorf("max_crawl_delay_seconds must be between 0 and %d", MaxCrawlDelayBoundSeconds)
+	}
+	if maxSeconds > 0 && minSeconds > maxSeconds {
+		return fmt.Errorf("min_crawl_delay_seconds (%d) cannot exceed max_crawl_delay_seconds (%d)", minSeconds, maxSeconds)
+	}
+	return nil
+}
+
+// clampCrawlDelay bounds a robots.txt Crawl-delay by the job's floor and
+// ceiling. The ceiling stops an absurd directive stalling the job; the floor
+// applies even when robots.txt sets no delay.
+func clampCrawlDelay(robotsSeconds, minSeconds, maxSeconds int) int {
+	delay := max(robotsSeconds, 0)
+	if maxSeconds > 0 {
+		delay = min(delay, maxSeconds)
+	}
+	return max(delay, minSeconds)
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCrawlDelayBounds(t *testing.T) {
+	assert.NoError(t, ValidateCrawlDelayBounds(0, 0))
+	assert.NoError(t, ValidateCrawlDelayBounds(5, 30))
+	assert.NoError(t, ValidateCrawlDelayBounds(10, 0), "a floor without a ceiling")
+	assert.Error(t, ValidateCrawlDelayBounds(-1, 0))
+	assert.Error(t, ValidateCrawlDelayBounds(0, MaxCrawlDelayBoundSeconds+1))
+	assert.Error(t, ValidateCrawlDelayBounds(30, 5))
+}
+
+func TestClampCrawlDelay(t *testing.T) {
+	tests := []struct {
+		name             string
+		robots, min, max int
+		want             int
+	}{
+		{"unbounded", 10, 0, 0, 10},
+		{"floor without robots delay", 0, 5, 0, 5},
+		{"floor below robots delay", 10, 5, 0, 10},
+		{"ceiling on absurd delay", 7200, 0, 30, 30},
+		{"both bounds", 7200, 5, 30, 30},
+		{"floor over small delay", 1, 5, 30, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clampCrawlDelay(tt.robots, tt.min, tt.max))
+		})
+	}
+}
+
+func TestDomainLimiterMinDelay(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	dl.cfg.BaseDelay = 0
+	now := time.Now()
+	dl.now = func() time.Time { return now }
+
+	// The robots multiplier halves the delay, but the job's floor still holds
+	permit, err := dl.Acquire(context.Background(), DomainRequest{
+		Domain:         "example.com",
+		JobID:          "job-1",
+		RobotsDelay:    4 * time.Second,
+		MinDelay:       4 * time.Second,
+		JobConcurrency: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Second, permit.delay)
+	permit.Release(true, false)
+
+	// The floor doesn't raise the shared base delay for other jobs
+	state := dl.getOrCreateState("example.com")
+	state.mu.Lock()
+	assert.Equal(t, 2*time.Second, state.baseDelay)
+	state.mu.Unlock()
+}
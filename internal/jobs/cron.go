@@ -0,0 +1,295 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+)
+
+// cronSearchYears bounds the search for the next run so impossible schedules
+// (e.g. 30 February) fail rather than loop forever
+const cronSearchYears = 5
+
+// ErrCronNeverRuns is returned for schedules that never match a real time
+var ErrCronNeverRuns = errors.New("cron expression never runs")
+
+// MinCronInterval is the shortest gap allowed between a cron schedule's runs,
+// matching the shortest fixed schedule_interval_hours
+const MinCronInterval = 6 * time.Hour
+
+// ErrCronTooFrequent is returned for schedules with runs closer together than
+// MinCronInterval
+var ErrCronTooFrequent = fmt.Errorf("cron expression must not run more often than every %d hours", int(MinCronInterval/time.Hour))
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronDayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// CronSchedule is a parsed five-field cron expression (minute hour
+// day-of-month month day-of-week) evaluated in a fixed location
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // Bit n set when value n matches
+	domAny, dowAny                bool
+	location                      *time.Location
+}
+
+// ParseCronSchedule parses a standard five-field cron expression. Times are
+// evaluated in timezone (an IANA name, UTC when empty); a CRON_TZ= or TZ=
+// prefix on the expression takes precedence.
+func ParseCronSchedule(expr, timezone string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if prefix, rest, ok := strings.Cut(expr, " "); ok {
+		if tz, found := strings.CutPrefix(prefix, "CRON_TZ="); found {
+			timezone, expr = tz, strings.TrimSpace(rest)
+		} else if tz, found := strings.CutPrefix(prefix, "TZ="); found {
+			timezone, expr = tz, strings.TrimSpace(rest)
+		}
+	}
+
+	location, err := LoadScheduleLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	s := &CronSchedule{location: location}
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	// 7 is accepted as Sunday alongside 0
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = strings.HasPrefix(fields[2], "*")
+	s.dowAny = strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+// LoadScheduleLocation resolves a schedule's IANA timezone, defaulting to UTC
+func LoadScheduleLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	return location, nil
+}
+
+func parseCronField(field string, lo, hi int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		start, end := lo, hi
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(first, lo, hi, names); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseCronValue(last, lo, hi, names); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, fmt.Errorf("invalid range %q", rangePart)
+				}
+			} else if hasStep {
+				end = hi // "5/15" means every 15 starting at 5
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, lo, hi int, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if n < lo || n > hi {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, lo, hi)
+	}
+	return n, nil
+}
+
+// Location is the timezone the schedule is evaluated in
+func (s *CronSchedule) Location() *time.Location {
+	return s.location
+}
+
+// Next returns the first matching time strictly after the given time, or the
+// zero time if the schedule never matches
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			// Built from wall-clock fields so DST changes don't skip hours
+			want := t.Hour() + 1
+			next := time.Date(t.Year(), t.Month(), t.Day(), want, 0, 0, 0, s.location)
+			if want < 24 && next.Hour() != want && s.hour&(1<<uint(want)) != 0 {
+				// The wanted hour doesn't exist today (clocks sprang
+				// forward), so run as soon as the clock resumes
+				return next
+			}
+			t = next
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's rule that when both day fields are restricted a
+// day matching either one fires
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// shortestGap returns the shortest gap between consecutive runs over the
+// search window, stopping at the first gap below floor. Gaps are measured on
+// the wall clock so a DST change doesn't make a six-hourly schedule look
+// shorter.
+func (s *CronSchedule) shortestGap(from time.Time, floor time.Duration) time.Duration {
+	wallClock := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	}
+
+	prev := s.Next(from)
+	if prev.IsZero() {
+		return 0
+	}
+	limit := prev.AddDate(cronSearchYears, 0, 0)
+	shortest := time.Duration(0)
+	for {
+		next := s.Next(prev)
+		if next.IsZero() || next.After(limit) {
+			return shortest
+		}
+		gap := wallClock(next).Sub(wallClock(prev))
+		if shortest == 0 || gap < shortest {
+			shortest = gap
+			if shortest < floor {
+				return shortest
+			}
+		}
+		prev = next
+	}
+}
+
+// ValidateCronSchedule checks a scheduler's cron expression and timezone, and
+// that its runs are at least MinCronInterval apart
+func ValidateCronSchedule(expr, timezone string) error {
+	schedule, err := ParseCronSchedule(expr, timezone)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if schedule.Next(now).IsZero() {
+		return ErrCronNeverRuns
+	}
+	if gap := schedule.shortestGap(now, MinCronInterval); gap > 0 && gap < MinCronInterval {
+		return ErrCronTooFrequent
+	}
+	return nil
+}
+
+// NextScheduledRun returns when a scheduler should next create a job after the
+// given time, from its cron expression or else its fixed interval
+func NextScheduledRun(scheduler *db.Scheduler, after time.Time) (time.Time, error) {
+	if scheduler.CronExpression == "" {
+		if scheduler.ScheduleIntervalHours <= 0 {
+			return time.Time{}, errors.New("scheduler has no interval or cron expression")
+		}
+		return after.UTC().Add(time.Duration(scheduler.ScheduleIntervalHours) * time.Hour), nil
+	}
+
+	schedule, err := ParseCronSchedule(scheduler.CronExpression, scheduler.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := schedule.Next(after)
+	if next.IsZero() {
+		return time.Time{}, ErrCronNeverRuns
+	}
+	return next.UTC(), nil
+}
@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	after := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC) // Friday
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, 10, 16, 10, 8, 0, 0, time.UTC)},
+		{"nightly", "0 2 * * *", time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)},
+		{"range and list", "30 9-17 * * 1,3", time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)},
+		{"day names", "0 0 * * SUN", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"seven is sunday", "0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"month rollover", "0 0 1 JAN *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"dom or dow", "0 0 20 * MON", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"macro", "@daily", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expr, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(after).UTC())
+		})
+	}
+}
+
+func TestCronScheduleTimezone(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+
+	// 2am Sydney is 3pm UTC the day before while daylight saving applies
+	schedule, err := ParseCronSchedule("0 2 * * *", "Australia/Sydney")
+	require.NoError(t, err)
+	next := schedule.Next(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 17, 2, 0, 0, 0, sydney), next)
+	assert.Equal(t, time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), next.UTC())
+
+	// A CRON_TZ prefix overrides the timezone argument
+	prefixed, err := ParseCronSchedule("CRON_TZ=Australia/Sydney 0 2 * * *", "UTC")
+	require.NoError(t, err)
+	assert.Equal(t, sydney, prefixed.Location())
+
+	// 2am doesn't exist the night Sydney springs forward; the run moves to 3am
+	dst := schedule.Next(time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 4, 3, 0, 0, 0, sydney), dst)
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"abc * * * *",
+	} {
+		_, err := ParseCronSchedule(expr, "")
+		assert.Error(t, err, expr)
+	}
+
+	_, err := ParseCronSchedule("* * * * *", "Mars/Olympus_Mons")
+	assert.Error(t, err)
+
+	assert.ErrorIs(t, ValidateCronSchedule("0 0 30 2 *", "UTC"), ErrCronNeverRuns)
+	assert.NoError(t, ValidateCronSchedule("0 2 * * *", "Australia/Sydney"))
+}
+
+func TestValidateCronScheduleMinimumInterval(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"@hourly",
+		"0 */4 * * *",
+		"0 0,5 * * *",
+		"0 1,23 * * *", // 23:00 to 01:00 the next day
+	} {
+		assert.ErrorIs(t, ValidateCronSchedule(expr, "UTC"), ErrCronTooFrequent, expr)
+	}
+
+	for _, expr := range []string{
+		"0 */6 * * *",
+		"0 2,14 * * *",
+		"0 22 * * 1,3",
+		"@daily",
+		"@weekly",
+	} {
+		assert.NoError(t, ValidateCronSchedule(expr, "UTC"), expr)
+	}
+
+	// Six-hourly in a DST zone is six hours apart on the wall clock
+	assert.NoError(t, ValidateCronSchedule("0 */6 * * *", "Europe/London"))
+}
+
+func TestNextScheduledRun(t *testing.T) {
+	after := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	next, err := NextScheduledRun(&db.Scheduler{ScheduleIntervalHours: 12}, after)
+	require.NoError(t, err)
+	assert.Equal(t, after.Add(12*time.Hour), next)
+
+	next, err = NextScheduledRun(&db.Scheduler{CronExpression: "0 2 * * *", Timezone: "Australia/Sydney"}, after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), next)
+
+	_, err = NextScheduledRun(&db.Scheduler{}, after)
+	assert.Error(t, err)
+}
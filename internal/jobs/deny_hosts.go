@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
+	"github.com/rs/zerolog/log"
+)
+
+// SkipReasonDeniedHost marks tasks skipped because their host is on the
+// organisation's crawl deny list
+const SkipReasonDeniedHost = "denied_host"
+
+// MaxDenyHostPatterns caps an organisation's crawl deny list
+const MaxDenyHostPatterns = 200
+
+// Stages at which a denied host is caught, used as the metric attribute
+const (
+	denyStageDiscovery = "discovery"
+	denyStageClaim     = "claim"
+)
+
+// errTaskHostDenied tells processNextTask the task's host is on the deny list
+var errTaskHostDenied = errors.New("task host is on the organisation deny list")
+
+// NormaliseDenyHostPatterns validates and normalises deny list patterns. A
+// pattern is a hostname such as "cdn.example.com", which also covers its
+// subdomains, or "*.example.com", which covers only subdomains. Duplicates
+// and blank entries are dropped.
+func NormaliseDenyHostPatterns(patterns []string) ([]string, error) {
+	if len(patterns) > MaxDenyHostPatterns {
+		return nil, fmt.Errorf("at most %d deny list patterns are allowed", MaxDenyHostPatterns)
+	}
+
+	normalised := make([]string, 0, len(patterns))
+	for _, raw := range patterns {
+		pattern := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+		if pattern == "" {
+			continue
+		}
+
+		host := strings.TrimPrefix(pattern, "*.")
+		if host == "" || strings.ContainsAny(host, "/:*@?# ") || !strings.Contains(host, ".") {
+			return nil, fmt.Errorf("invalid host pattern %q: use a hostname like cdn.example.com or *.example.com", raw)
+		}
+		if !slices.Contains(normalised, pattern) {
+			normalised = append(normalised, pattern)
+		}
+	}
+	return normalised, nil
+}
+
+// matchDeniedHost returns the first pattern that covers host
+func matchDeniedHost(host string, patterns []string) (string, bool) {
+	if host == "" || len(patterns) == 0 {
+		return "", false
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	for _, pattern := range patterns {
+		if suffix, wildcard := strings.CutPrefix(pattern, "*."); wildcard {
+			if strings.HasSuffix(host, "."+suffix) {
+				return pattern, true
+			}
+			continue
+		}
+		if host == pattern || strings.HasSuffix(host, "."+pattern) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// taskURLHost returns the host of a constructed task URL, or "" if it can't
+// be parsed
+func taskURLHost(taskURL string) string {
+	parsed, err := url.Parse(taskURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// handleTaskHostDenied records a task whose host is on the deny list as skipped
+func (wp *WorkerPool) handleTaskHostDenied(ctx context.Context, task *db.Task) error {
+	task.Status = string(TaskStatusSkipped)
+	task.SkipReason = SkipReasonDeniedHost
+	task.CompletedAt = time.Now().UTC()
+
+	// Free the concurrency slot straight away, as for completed tasks
+	if err := wp.releaseRunningTaskSlot(task.JobID); err != nil {
+		log.Error().Err(err).Str("job_id", task.JobID).Str("task_id", task.ID).
+			Msg("Failed to decrement running_tasks counter")
+	}
+
+	wp.batchManager.QueueTaskUpdate(task)
+	return nil
+}
+
+// recordDeniedURLs logs and counts URLs dropped by the deny list
+func recordDeniedURLs(ctx context.Context, jobID, stage string, count int, patterns []string) {
+	if count == 0 {
+		return
+	}
+
+	log.Info().
+		Str("job_id", jobID).
+		Str("stage", stage).
+		Int("denied_count", count).
+		Strs("patterns", patterns).
+		Msg("Dropped URLs on the organisation deny list")
+	observability.RecordDeniedURLs(ctx, jobID, stage, count)
+}
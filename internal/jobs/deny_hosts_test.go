@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormaliseDenyHostPatterns(t *testing.T) {
+	hosts, err := NormaliseDenyHostPatterns([]string{" CDN.Example.com. ", "*.tracker.io", "", "cdn.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdn.example.com", "*.tracker.io"}, hosts)
+
+	for _, invalid := range []string{"https://cdn.example.com", "example.com/path", "*", "localhost", "cdn.*.com"} {
+		_, err := NormaliseDenyHostPatterns([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+
+	_, err = NormaliseDenyHostPatterns(make([]string, MaxDenyHostPatterns+1))
+	assert.Error(t, err)
+}
+
+func TestMatchDeniedHost(t *testing.T) {
+	patterns := []string{"cdn.example.com", "*.widgets.example.com"}
+
+	tests := map[string]bool{
+		"cdn.example.com":           true,
+		"img.cdn.example.com":       true,
+		"CDN.Example.com":           true,
+		"a.widgets.example.com":     true,
+		"widgets.example.com":       false,
+		"example.com":               false,
+		"notcdn.example.com":        false,
+		"www.example.com":           false,
+		"cdn.example.com.evil.test": false,
+	}
+	for host, want := range tests {
+		_, denied := matchDeniedHost(host, patterns)
+		assert.Equal(t, want, denied, host)
+	}
+
+	_, denied := matchDeniedHost("cdn.example.com", nil)
+	assert.False(t, denied)
+}
+
+func TestProcessDiscoveredLinksDropsDeniedHosts(t *testing.T) {
+	tests := []struct {
+		name        string
+		links       []string
+		wantPersist bool
+	}{
+		{
+			name:        "denied subdomain is dropped",
+			links:       []string{"https://cdn.example.com/widget.js"},
+			wantPersist: false,
+		},
+		{
+			name:        "other links are kept",
+			links:       []string{"https://cdn.example.com/widget.js", "https://example.com/about"},
+			wantPersist: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			persistCalls := 0
+			wp := &WorkerPool{
+				dbQueue: &MockDbQueue{
+					ExecuteFunc: func(ctx context.Context, fn func(*sql.Tx) error) error {
+						persistCalls++
+						return errors.New("stop before persisting")
+					},
+				},
+				crawler: &pathFilterCrawler{real: crawler.New(crawler.DefaultConfig())},
+				jobInfoCache: map[string]*JobInfo{
+					"job-1": {
+						DomainID:   1,
+						DomainName: "example.com",
+						DenyHosts:  []string{"cdn.example.com"},
+					},
+				},
+			}
+
+			task := &Task{
+				ID:            "task-1",
+				JobID:         "job-1",
+				DomainID:      1,
+				DomainName:    "example.com",
+				Path:          "/blog",
+				PriorityScore: 0.5,
+				FindLinks:     true,
+			}
+			result := &crawler.CrawlResult{Links: map[string][]string{"body": tt.links}}
+
+			wp.processDiscoveredLinks(context.Background(), task, result, "https://example.com/blog")
+
+			assert.Equal(t, tt.wantPersist, persistCalls > 0)
+		})
+	}
+}
+
+func TestProcessTaskSkipsDeniedHost(t *testing.T) {
+	wp := &WorkerPool{crawler: &MockCrawler{}}
+	task := &Task{
+		ID:         "task-1",
+		JobID:      "job-1",
+		DomainName: "example.com",
+		Path:       "https://cdn.example.com/widget.js",
+		DenyHosts:  []string{"cdn.example.com"},
+	}
+
+	_, err := wp.processTask(context.Background(), task)
+	assert.ErrorIs(t, err, errTaskHostDenied)
+}
@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+)
+
+// pathFilterCrawler uses the real crawler's FilterURLs so discovered links
+// are matched exactly as sitemap URLs are
+type pathFilterCrawler struct {
+	MockCrawler
+	real *crawler.Crawler
+}
+
+func (c *pathFilterCrawler) FilterURLs(urls []string, includePaths, excludePaths []string) []string {
+	return c.real.FilterURLs(urls, includePaths, excludePaths)
+}
+
+func TestProcessDiscoveredLinksAppliesPathFilters(t *testing.T) {
+	tests := []struct {
+		name         string
+		includePaths []string
+		excludePaths []string
+		links        []string
+		wantPersist  bool
+	}{
+		{
+			name:         "link outside include paths is dropped",
+			includePaths: []string{"/blog"},
+			links:        []string{"https://example.com/about", "https://example.com/shop/item"},
+			wantPersist:  false,
+		},
+		{
+			name:         "link inside include paths is kept",
+			includePaths: []string{"/blog"},
+			links:        []string{"https://example.com/about", "https://example.com/blog/post"},
+			wantPersist:  true,
+		},
+		{
+			name:         "excluded link is dropped",
+			excludePaths: []string{"/private"},
+			links:        []string{"https://example.com/private/page"},
+			wantPersist:  false,
+		},
+		{
+			name:        "no patterns keeps every link",
+			links:       []string{"https://example.com/about"},
+			wantPersist: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			persistCalls := 0
+			wp := &WorkerPool{
+				dbQueue: &MockDbQueue{
+					ExecuteFunc: func(ctx context.Context, fn func(*sql.Tx) error) error {
+						persistCalls++
+						return errors.New("stop before persisting")
+					},
+				},
+				crawler: &pathFilterCrawler{real: crawler.New(crawler.DefaultConfig())},
+				jobInfoCache: map[string]*JobInfo{
+					"job-1": {
+						DomainID:     1,
+						DomainName:   "example.com",
+						IncludePaths: tt.includePaths,
+						ExcludePaths: tt.excludePaths,
+					},
+				},
+			}
+
+			task := &Task{
+				ID:            "task-1",
+				JobID:         "job-1",
+				DomainID:      1,
+				DomainName:    "example.com",
+				Path:          "/blog",
+				PriorityScore: 0.5,
+				FindLinks:     true,
+			}
+			result := &crawler.CrawlResult{Links: map[string][]string{"body": tt.links}}
+
+			wp.processDiscoveredLinks(context.Background(), task, result, "https://example.com/blog")
+
+			assert.Equal(t, tt.wantPersist, persistCalls > 0)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+// discoveryPacer spaces a job's robots.txt and sitemap requests through the
+// domain limiter, so discovery honours the same crawl delay and rate-limit
+// backoff as the warm that follows it
+type discoveryPacer struct {
+	limiter     *DomainLimiter
+	domain      string // Limiter key, i.e. after subdomain grouping
+	host        string // The job's own domain
+	jobID       string
+	minDelay    time.Duration
+	robotsDelay atomic.Int64 // Nanoseconds, set once robots.txt is parsed
+}
+
+// withDiscoveryPacer attaches a pacer for the job's domain to ctx. Without a
+// worker pool, e.g. in tests, discovery runs unpaced.
+func (jm *JobManager) withDiscoveryPacer(ctx context.Context, job *Job, domain string) context.Context {
+	if jm.workerPool == nil {
+		return ctx
+	}
+	return crawler.WithDiscoveryPacer(ctx, &discoveryPacer{
+		limiter:  jm.workerPool.ensureDomainLimiter(),
+		domain:   limiterDomain(domain, job.GroupSubdomains),
+		host:     domain,
+		jobID:    job.ID,
+		minDelay: time.Duration(job.MinCrawlDelaySeconds) * time.Second,
+	})
+}
+
+func (p *discoveryPacer) Wait(ctx context.Context) (func(statusCode int, retryAfter time.Duration), error) {
+	permit, err := p.limiter.Acquire(ctx, DomainRequest{
+		Domain:         p.domain,
+		Host:           p.host,
+		JobID:          p.jobID,
+		RobotsDelay:    time.Duration(p.robotsDelay.Load()),
+		MinDelay:       p.minDelay,
+		JobConcurrency: 1,
+	})
+	if errors.Is(err, errCircuitOpen) {
+		// Try anyway: failing discovery here would drop the job to its
+		// root URL on the strength of other jobs' errors
+		return func(int, time.Duration) {}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return func(statusCode int, retryAfter time.Duration) {
+		rateLimited := statusCode == http.StatusTooManyRequests ||
+			statusCode == http.StatusForbidden || statusCode == http.StatusServiceUnavailable
+		failure := statusCode == 0 || isOriginDistress(statusCode, rateLimited)
+		permit.Release(!failure, rateLimited)
+		// Reports back on the half-open probe if discovery claimed it
+		p.limiter.RecordBreakerOutcome(p.domain, failure)
+		p.limiter.ApplyRetryAfter(p.domain, retryAfter)
+	}, nil
+}
+
+func (p *discoveryPacer) SetCrawlDelay(seconds int) {
+	p.robotsDelay.Store(int64(time.Duration(seconds) * time.Second))
+}
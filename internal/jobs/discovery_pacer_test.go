@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoveryPacerSpacesRequestsByCrawlDelay(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	dl.cfg.BaseDelay = 0
+	dl.cfg.RobotsDelayMultiplier = 1
+	pacer := &discoveryPacer{limiter: dl, domain: "example.com", jobID: "job-1"}
+	ctx := context.Background()
+
+	done, err := pacer.Wait(ctx)
+	require.NoError(t, err)
+	done(http.StatusOK, 0)
+
+	// robots.txt's Crawl-delay applies to the sitemap requests after it
+	pacer.SetCrawlDelay(1)
+	done, err = pacer.Wait(ctx)
+	require.NoError(t, err)
+	done(http.StatusOK, 0)
+
+	start := time.Now()
+	done, err = pacer.Wait(ctx)
+	require.NoError(t, err)
+	done(http.StatusOK, 0)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestDiscoveryPacerBacksOffOnRateLimit(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	now := time.Now()
+	dl.now = func() time.Time { return now }
+	pacer := &discoveryPacer{limiter: dl, domain: "example.com", jobID: "job-1"}
+
+	done, err := pacer.Wait(context.Background())
+	require.NoError(t, err)
+	done(http.StatusTooManyRequests, 30*time.Second)
+
+	// The warm that follows inherits the backoff
+	assert.GreaterOrEqual(t, dl.EstimatedWait("example.com"), 30*time.Second)
+}
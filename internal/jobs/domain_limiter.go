@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,6 +26,9 @@ type DomainLimiterConfig struct {
 	CancelStreakThreshold int
 	CancelDelayThreshold  time.Duration
 	RobotsDelayMultiplier float64
+	MaxRetryAfter         time.Duration // Upper bound on a server-requested Retry-After pause
+	BreakerThreshold      float64       // Error rate over the last 50 requests that opens the circuit; 0 disables it
+	BreakerCooldown       time.Duration // How long an open circuit short-circuits claims before probing
 }
 
 func defaultDomainLimiterConfig() DomainLimiterConfig {
@@ -40,6 +44,9 @@ func defaultDomainLimiterConfig() DomainLimiterConfig {
 		CancelStreakThreshold: 20,
 		CancelDelayThreshold:  60 * time.Second,
 		RobotsDelayMultiplier: 0.5,
+		MaxRetryAfter:         10 * time.Minute,
+		BreakerThreshold:      defaultBreakerThreshold,
+		BreakerCooldown:       defaultBreakerCooldown,
 	}
 
 	if v, ok := os.LookupEnv("BBB_RATE_LIMIT_BASE_DELAY_MS"); ok {
@@ -80,11 +87,17 @@ func defaultDomainLimiterConfig() DomainLimiterConfig {
 	if v, ok := os.LookupEnv("BBB_RATE_LIMIT_CANCEL_ENABLED"); ok {
 		cfg.CancelRateLimitJobs = v == "1" || v == "true" || v == "TRUE"
 	}
+	if v, ok := os.LookupEnv("BBB_RATE_LIMIT_MAX_RETRY_AFTER_SECONDS"); ok {
+		if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+			cfg.MaxRetryAfter = time.Duration(sec) * time.Second
+		}
+	}
 	if v, ok := os.LookupEnv("BBB_ROBOTS_DELAY_MULTIPLIER"); ok {
 		if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 && f <= 1.0 {
 			cfg.RobotsDelayMultiplier = f
 		}
 	}
+	breakerConfigFromEnv(&cfg)
 
 	return cfg
 }
@@ -103,19 +116,33 @@ type DomainLimiter struct {
 // DomainRequest describes a request against a domain that needs throttling.
 type DomainRequest struct {
 	Domain         string
+	Host           string // Domain the adaptive delay is saved against when Domain groups subdomains; defaults to Domain
 	JobID          string
 	RobotsDelay    time.Duration
+	MinDelay       time.Duration // Job's crawl delay floor, not scaled by RobotsDelayMultiplier
 	JobConcurrency int
+	Schedule       *ConcurrencySchedule // Optional time-of-day concurrency, capped by JobConcurrency
 }
 
 // DomainPermit is returned by Acquire and must be released after the request completes.
 type DomainPermit struct {
 	limiter *DomainLimiter
 	domain  string
+	host    string
 	jobID   string
 	delay   time.Duration
 }
 
+// limiterDomain is the key a job's requests are paced under: its own host, or
+// the registrable domain when subdomains are grouped so www.example.com and
+// shop.example.com share one origin's politeness budget
+func limiterDomain(domain string, groupSubdomains bool) string {
+	if !groupSubdomains || domain == "" {
+		return domain
+	}
+	return util.RegistrableDomain(domain)
+}
+
 func newDomainLimiter(dbQueue DbQueueInterface) *DomainLimiter {
 	cfg := defaultDomainLimiterConfig()
 	log.Info().
@@ -152,14 +179,29 @@ func (dl *DomainLimiter) Acquire(ctx context.Context, req DomainRequest) (*Domai
 	}
 
 	state := dl.getOrCreateState(req.Domain)
+
+	state.mu.Lock()
+	allowed, transition := state.breaker.allow(dl.cfg, dl.now())
+	state.mu.Unlock()
+	logBreakerTransition(req.Domain, transition, dl.cfg.BreakerCooldown)
+	if !allowed {
+		return nil, errCircuitOpen
+	}
+
 	delay, err := state.acquire(ctx, dl.cfg, dl.now, req)
 	if err != nil {
 		return nil, err
 	}
 
+	host := req.Host
+	if host == "" {
+		host = req.Domain
+	}
+
 	return &DomainPermit{
 		limiter: dl,
 		domain:  req.Domain,
+		host:    host,
 		jobID:   req.JobID,
 		delay:   delay,
 	}, nil
@@ -170,7 +212,7 @@ func (p *DomainPermit) Release(success bool, rateLimited bool) {
 	if p == nil || p.limiter == nil || p.domain == "" {
 		return
 	}
-	p.limiter.release(p.domain, p.jobID, success, rateLimited)
+	p.limiter.release(p.domain, p.host, p.jobID, success, rateLimited)
 }
 
 // UpdateRobotsDelay allows adjusting the base delay when robots.txt changes.
@@ -186,6 +228,97 @@ func (dl *DomainLimiter) UpdateRobotsDelay(domain string, delaySeconds int) {
 	}
 }
 
+// ApplyRetryAfter pauses all requests to a domain for at least wait, as asked
+// by a Retry-After header. The pause is capped at MaxRetryAfter so one bad
+// header can't stall jobs indefinitely, and never shortens an existing backoff.
+// Returns the pause actually applied.
+func (dl *DomainLimiter) ApplyRetryAfter(domain string, wait time.Duration) time.Duration {
+	if domain == "" || wait <= 0 || dl.cfg.MaxRetryAfter <= 0 {
+		return 0
+	}
+	wait = min(wait, dl.cfg.MaxRetryAfter)
+
+	state := dl.getOrCreateState(domain)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	until := dl.now().Add(wait)
+	if until.After(state.backoffUntil) {
+		state.backoffUntil = until
+	}
+	return wait
+}
+
+// SetLatencyCap limits a job's concurrency on a domain while its origin is
+// slow. A cap of 0 removes the limit.
+func (dl *DomainLimiter) SetLatencyCap(jobID string, domain string, limit int) {
+	if domain == "" {
+		return
+	}
+
+	state := dl.getOrCreateState(domain)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	js, ok := state.jobStates[jobID]
+	if !ok {
+		js = &jobDomainState{}
+		state.jobStates[jobID] = js
+	}
+	js.latencyCap = max(limit, 0)
+	if js.latencyCap > 0 && js.allowed > js.latencyCap {
+		js.allowed = js.latencyCap
+	}
+	// Wake waiters so a lifted cap takes effect straight away
+	state.cond.Broadcast()
+}
+
+// SetErrorCap limits a job's concurrency on a domain while the origin is
+// returning errors or blocks. A cap of 0 removes the limit.
+func (dl *DomainLimiter) SetErrorCap(jobID string, domain string, limit int) {
+	if domain == "" {
+		return
+	}
+
+	state := dl.getOrCreateState(domain)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	js, ok := state.jobStates[jobID]
+	if !ok {
+		js = &jobDomainState{}
+		state.jobStates[jobID] = js
+	}
+	js.errorCap = max(limit, 0)
+	if js.errorCap > 0 && js.allowed > js.errorCap {
+		js.allowed = js.errorCap
+	}
+	state.cond.Broadcast()
+}
+
+// SetAutoCap sets an auto-tuned job's concurrency limit on a domain. A cap of
+// 0 removes the limit.
+func (dl *DomainLimiter) SetAutoCap(jobID string, domain string, limit int) {
+	if domain == "" {
+		return
+	}
+
+	state := dl.getOrCreateState(domain)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	js, ok := state.jobStates[jobID]
+	if !ok {
+		js = &jobDomainState{}
+		state.jobStates[jobID] = js
+	}
+	js.autoCap = max(limit, 0)
+	if js.autoCap > 0 && js.allowed > js.autoCap {
+		js.allowed = js.autoCap
+	}
+	state.cond.Broadcast()
+}
+
 // EstimatedWait returns the estimated time until the domain is available for requests.
 // Returns 0 if the domain is available immediately or unknown.
 func (dl *DomainLimiter) EstimatedWait(domain string) time.Duration {
@@ -210,10 +343,11 @@ func (dl *DomainLimiter) EstimatedWait(domain string) time.Duration {
 		waitUntil = state.backoffUntil
 	}
 
+	wait := state.breaker.wait(dl.cfg, now)
 	if waitUntil.After(now) {
-		return waitUntil.Sub(now)
+		wait = max(wait, waitUntil.Sub(now))
 	}
-	return 0
+	return wait
 }
 
 // Domain state ---------------------------------------------------------------------------------
@@ -238,13 +372,18 @@ type domainState struct {
 	probePrevious time.Duration
 	probeTarget   time.Duration
 
+	breaker domainBreaker
+
 	jobStates map[string]*jobDomainState
 }
 
 type jobDomainState struct {
-	original int
-	allowed  int
-	active   int
+	original   int
+	allowed    int
+	active     int
+	latencyCap int // 0 when the job isn't backing off for origin latency
+	errorCap   int // 0 when the job isn't backing off for origin errors
+	autoCap    int // 0 unless the job's concurrency is auto-tuned
 }
 
 func newDomainState(base time.Duration) *domainState {
@@ -308,6 +447,10 @@ func (ds *domainState) acquire(ctx context.Context, cfg DomainLimiterConfig, now
 
 	for {
 		now := nowFn()
+		jobConcurrency := req.JobConcurrency
+		if req.Schedule != nil {
+			jobConcurrency = min(req.Schedule.ConcurrencyAt(now, jobConcurrency), jobConcurrency)
+		}
 		if req.RobotsDelay > 0 {
 			robots := req.RobotsDelay
 			multiplierActive := cfg.RobotsDelayMultiplier > 0 && cfg.RobotsDelayMultiplier < 1.0
@@ -344,22 +487,35 @@ func (ds *domainState) acquire(ctx context.Context, cfg DomainLimiterConfig, now
 			continue
 		}
 
-		js := ds.ensureJobState(req.JobID, req.JobConcurrency)
-		js.allowed = ds.computeAllowedConcurrency(cfg, req.JobConcurrency)
+		js := ds.ensureJobState(req.JobID, jobConcurrency)
+		js.allowed = ds.computeAllowedConcurrency(cfg, jobConcurrency)
+		if js.latencyCap > 0 {
+			js.allowed = min(js.allowed, js.latencyCap)
+		}
+		if js.errorCap > 0 {
+			js.allowed = min(js.allowed, js.errorCap)
+		}
+		if js.autoCap > 0 {
+			js.allowed = min(js.allowed, js.autoCap)
+		}
 		if js.active >= js.allowed {
 			ds.cond.Wait()
 			continue
 		}
 
 		js.active++
-		delay := ds.effectiveDelay(cfg)
+		// A job's floor spaces the next request without raising the shared
+		// base delay, so other jobs on the domain keep their own pacing
+		delay := max(ds.effectiveDelay(cfg), req.MinDelay)
 		ds.nextAvailable = now.Add(delay)
 		ds.mu.Unlock()
 		return delay, nil
 	}
 }
 
-func (dl *DomainLimiter) release(domain string, jobID string, success bool, rateLimited bool) {
+// release updates the domain's pacing after a request. Adaptive delay changes
+// are saved against host, the real domain row, as a grouped domain has none.
+func (dl *DomainLimiter) release(domain string, host string, jobID string, success bool, rateLimited bool) {
 	state := dl.getOrCreateState(domain)
 
 	state.mu.Lock()
@@ -452,8 +608,8 @@ func (dl *DomainLimiter) release(domain string, jobID string, success bool, rate
 	if shouldPersist {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
-		if err := dl.persistDomain(ctx, domain, adaptiveSeconds, floorSeconds); err != nil {
-			log.Warn().Err(err).Str("domain", domain).Msg("Failed to persist adaptive delay")
+		if err := dl.persistDomain(ctx, host, adaptiveSeconds, floorSeconds); err != nil {
+			log.Warn().Err(err).Str("domain", host).Msg("Failed to persist adaptive delay")
 		}
 	}
 }
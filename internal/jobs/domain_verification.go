@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog/log"
+)
+
+// Ways an organisation can prove it owns a domain
+const (
+	DomainVerificationDNS  = "dns"
+	DomainVerificationFile = "file"
+)
+
+// verificationRecordPrefix is prepended to the domain to name the TXT record
+const verificationRecordPrefix = "_bluebandedbee."
+
+// verificationValuePrefix precedes the token in the TXT record
+const verificationValuePrefix = "bluebandedbee-verification="
+
+// ErrDomainNotVerified is returned when an organisation creates a job for a
+// domain it hasn't proven it owns
+var ErrDomainNotVerified = errors.New("domain has not been verified for this organisation; verify it with POST /v1/domains/{domain}/verify")
+
+// lookupTXT resolves TXT records; tests swap it out
+var lookupTXT = net.DefaultResolver.LookupTXT
+
+// verificationFileFetcher is implemented by crawlers that can fetch a
+// domain's well-known verification file
+type verificationFileFetcher interface {
+	FetchVerificationFile(ctx context.Context, domain string) (string, error)
+}
+
+// DomainVerification is an organisation's verification state for a domain,
+// with the instructions for proving ownership
+type DomainVerification struct {
+	Domain         string     `json:"domain"`
+	Verified       bool       `json:"verified"`
+	Method         string     `json:"method,omitempty"`
+	VerifiedAt     *time.Time `json:"verified_at,omitempty"`
+	Token          string     `json:"token"`
+	DNSRecordName  string     `json:"dns_record_name"`
+	DNSRecordValue string     `json:"dns_record_value"`
+	FileURL        string     `json:"file_url"`
+	FileContent    string     `json:"file_content"`
+}
+
+func newDomainVerification(domain, token string) *DomainVerification {
+	return &DomainVerification{
+		Domain:         domain,
+		Token:          token,
+		DNSRecordName:  verificationRecordPrefix + domain,
+		DNSRecordValue: verificationValuePrefix + token,
+		FileURL:        crawler.VerificationFileURL(domain),
+		FileContent:    token,
+	}
+}
+
+func generateVerificationToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// checkDomainVerified fails with ErrDomainNotVerified unless the organisation
+// is trusted or has verified the domain. Jobs without an organisation, such as
+// those created by internal tooling, aren't checked.
+func (jm *JobManager) checkDomainVerified(ctx context.Context, organisationID *string, domain string) error {
+	if organisationID == nil || *organisationID == "" {
+		return nil
+	}
+
+	var allowed bool
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM trusted_organisations WHERE organisation_id = $1
+			) OR EXISTS (
+				SELECT 1
+				FROM organisation_domains od
+				JOIN domains d ON d.id = od.domain_id
+				WHERE od.organisation_id = $1
+				AND d.name = $2
+				AND od.verified_at IS NOT NULL
+			)
+		`, *organisationID, domain).Scan(&allowed)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check domain verification: %w", err)
+	}
+	if !allowed {
+		return ErrDomainNotVerified
+	}
+	return nil
+}
+
+// VerifyDomain checks whether the organisation has published its verification
+// token for the domain, as a TXT record or a well-known file, and records the
+// domain as verified if so. The first call issues the token; the returned
+// state carries the instructions either way.
+func (jm *JobManager) VerifyDomain(ctx context.Context, organisationID, domain string) (*DomainVerification, error) {
+	span := sentry.StartSpan(ctx, "manager.verify_domain")
+	defer span.Finish()
+
+	span.SetTag("domain", domain)
+
+	if err := jm.checkWriteCapacity(); err != nil {
+		return nil, err
+	}
+
+	newToken, err := generateVerificationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	var domainID int
+	var token string
+	var verifiedAt sql.NullTime
+	var method sql.NullString
+	err = jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO domains (name, created_at)
+			VALUES ($1, NOW())
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		`, domain).Scan(&domainID); err != nil {
+			return err
+		}
+
+		// Keep an issued token so instructions already followed stay valid
+		return tx.QueryRowContext(ctx, `
+			INSERT INTO organisation_domains (organisation_id, domain_id, verification_token, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (organisation_id, domain_id) DO UPDATE
+			SET verification_token = COALESCE(organisation_domains.verification_token, EXCLUDED.verification_token)
+			RETURNING verification_token, verified_at, verification_method
+		`, organisationID, domainID, newToken).Scan(&token, &verifiedAt, &method)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domain verification: %w", err)
+	}
+
+	verification := newDomainVerification(domain, token)
+	if verifiedAt.Valid {
+		verification.Verified = true
+		verification.Method = method.String
+		verification.VerifiedAt = &verifiedAt.Time
+		return verification, nil
+	}
+
+	found := jm.findVerificationToken(ctx, domain, token)
+	if found == "" {
+		log.Info().
+			Str("organisation_id", organisationID).
+			Str("domain", domain).
+			Msg("Domain verification token not found")
+		return verification, nil
+	}
+
+	err = jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			UPDATE organisation_domains
+			SET verified_at = NOW(), verification_method = $1
+			WHERE organisation_id = $2 AND domain_id = $3
+			RETURNING verified_at
+		`, found, organisationID, domainID).Scan(&verifiedAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record domain verification: %w", err)
+	}
+
+	verification.Verified = true
+	verification.Method = found
+	verification.VerifiedAt = &verifiedAt.Time
+
+	log.Info().
+		Str("organisation_id", organisationID).
+		Str("domain", domain).
+		Str("method", found).
+		Msg("Domain verified")
+
+	return verification, nil
+}
+
+// findVerificationToken looks for the token in the domain's TXT record, then
+// its well-known file, returning the method that found it or ""
+func (jm *JobManager) findVerificationToken(ctx context.Context, domain, token string) string {
+	records, err := lookupTXT(ctx, verificationRecordPrefix+domain)
+	if err != nil {
+		log.Debug().Err(err).Str("domain", domain).Msg("Domain verification TXT lookup failed")
+	}
+	for _, record := range records {
+		if strings.TrimSpace(record) == verificationValuePrefix+token {
+			return DomainVerificationDNS
+		}
+	}
+
+	fetcher, ok := jm.crawler.(verificationFileFetcher)
+	if !ok {
+		return ""
+	}
+	content, err := fetcher.FetchVerificationFile(ctx, domain)
+	if err != nil {
+		log.Debug().Err(err).Str("domain", domain).Msg("Domain verification file fetch failed")
+		return ""
+	}
+	if content == token {
+		return DomainVerificationFile
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verificationFileCrawler serves a canned verification file
+type verificationFileCrawler struct {
+	MockCrawler
+	content string
+}
+
+func (c *verificationFileCrawler) FetchVerificationFile(_ context.Context, _ string) (string, error) {
+	if c.content == "" {
+		return "", errors.New("verification file returned status 404")
+	}
+	return c.content, nil
+}
+
+// stubLookupTXT replaces the TXT resolver for the duration of a test
+func stubLookupTXT(t *testing.T, records []string) {
+	t.Helper()
+	original := lookupTXT
+	lookupTXT = func(_ context.Context, _ string) ([]string, error) {
+		if records == nil {
+			return nil, errors.New("no such host")
+		}
+		return records, nil
+	}
+	t.Cleanup(func() { lookupTXT = original })
+}
+
+func TestCheckDomainVerifiedSkipsJobsWithoutOrganisation(t *testing.T) {
+	jm := &JobManager{}
+	assert.NoError(t, jm.checkDomainVerified(context.Background(), nil, "example.com"))
+}
+
+func TestCheckDomainVerified(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed bool
+		wantErr error
+	}{
+		{name: "verified or trusted", allowed: true},
+		{name: "unverified", allowed: false, wantErr: ErrDomainNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer mockDB.Close()
+
+			jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+			orgID := "org-1"
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("FROM trusted_organisations").
+				WithArgs(orgID, "example.com").
+				WillReturnRows(sqlmock.NewRows([]string{"allowed"}).AddRow(tt.allowed))
+			mock.ExpectCommit()
+
+			err = jm.checkDomainVerified(context.Background(), &orgID, "example.com")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFindVerificationToken(t *testing.T) {
+	t.Run("dns record", func(t *testing.T) {
+		stubLookupTXT(t, []string{"v=spf1 -all", "bluebandedbee-verification=tok"})
+		jm := &JobManager{crawler: &verificationFileCrawler{}}
+		assert.Equal(t, DomainVerificationDNS, jm.findVerificationToken(context.Background(), "example.com", "tok"))
+	})
+
+	t.Run("well-known file", func(t *testing.T) {
+		stubLookupTXT(t, nil)
+		jm := &JobManager{crawler: &verificationFileCrawler{content: "tok"}}
+		assert.Equal(t, DomainVerificationFile, jm.findVerificationToken(context.Background(), "example.com", "tok"))
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		stubLookupTXT(t, []string{"bluebandedbee-verification=other"})
+		jm := &JobManager{crawler: &verificationFileCrawler{content: "other"}}
+		assert.Empty(t, jm.findVerificationToken(context.Background(), "example.com", "tok"))
+	})
+}
+
+func TestVerifyDomainRecordsVerification(t *testing.T) {
+	stubLookupTXT(t, []string{"bluebandedbee-verification=tok"})
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	verifiedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO domains").
+		WithArgs("example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery("INSERT INTO organisation_domains").
+		WithArgs("org-1", 7, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"verification_token", "verified_at", "verification_method"}).AddRow("tok", nil, nil))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE organisation_domains").
+		WithArgs(DomainVerificationDNS, "org-1", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"verified_at"}).AddRow(verifiedAt))
+	mock.ExpectCommit()
+
+	verification, err := jm.VerifyDomain(context.Background(), "org-1", "example.com")
+	require.NoError(t, err)
+	assert.True(t, verification.Verified)
+	assert.Equal(t, DomainVerificationDNS, verification.Method)
+	assert.Equal(t, "_bluebandedbee.example.com", verification.DNSRecordName)
+	assert.Equal(t, "bluebandedbee-verification=tok", verification.DNSRecordValue)
+	assert.Equal(t, verifiedAt, *verification.VerifiedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVerifyDomainReturnsInstructionsWhenTokenMissing(t *testing.T) {
+	stubLookupTXT(t, nil)
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO domains").
+		WithArgs("example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery("INSERT INTO organisation_domains").
+		WithArgs("org-1", 7, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"verification_token", "verified_at", "verification_method"}).AddRow("tok", nil, nil))
+	mock.ExpectCommit()
+
+	verification, err := jm.VerifyDomain(context.Background(), "org-1", "example.com")
+	require.NoError(t, err)
+	assert.False(t, verification.Verified)
+	assert.Equal(t, "tok", verification.FileContent)
+	assert.Equal(t, "https://example.com/.well-known/bluebandedbee-verification.txt", verification.FileURL)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startFakeWorker mimics a worker that stops claiming on drain but finishes
+// its in-flight task first
+func startFakeWorker(wp *WorkerPool, taskDuration time.Duration, finished *atomic.Bool) {
+	wp.workersWG.Add(1)
+	wp.wg.Go(func() {
+		defer wp.workersWG.Done()
+		select {
+		case <-wp.drainCh:
+		case <-wp.stopCh:
+		}
+		time.Sleep(taskDuration)
+		finished.Store(true)
+	})
+}
+
+func TestWorkerPoolDrainWaitsForInFlightTasks(t *testing.T) {
+	wp := &WorkerPool{stopCh: make(chan struct{}), drainCh: make(chan struct{})}
+	var finished atomic.Bool
+	startFakeWorker(wp, 50*time.Millisecond, &finished)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	wp.Drain(ctx)
+
+	assert.True(t, finished.Load(), "in-flight task should finish before Drain returns")
+	assert.True(t, wp.draining.Load())
+	assert.True(t, wp.stopping.Load(), "Drain should stop the pool once drained")
+
+	// A second drain after stopping is a no-op
+	wp.Drain(ctx)
+}
+
+func TestWorkerPoolDrainDeadlineFallsBackToStop(t *testing.T) {
+	wp := &WorkerPool{stopCh: make(chan struct{}), drainCh: make(chan struct{})}
+	var finished atomic.Bool
+	startFakeWorker(wp, 200*time.Millisecond, &finished)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	wp.Drain(ctx)
+
+	assert.True(t, wp.stopping.Load())
+	select {
+	case <-wp.stopCh:
+	default:
+		t.Fatal("stop channel should be closed after the forced stop")
+	}
+}
+
+func TestScaleWorkersSkippedWhileDraining(t *testing.T) {
+	wp := &WorkerPool{currentWorkers: 2, maxWorkers: 10}
+	wp.draining.Store(true)
+
+	wp.scaleWorkers(context.Background(), 5)
+
+	assert.Equal(t, 2, wp.currentWorkers)
+}
+
+func TestCollectQueuedRunningTaskReleases(t *testing.T) {
+	wp := &WorkerPool{
+		runningTaskReleaseCh:      make(chan string, 4),
+		runningTaskReleasePending: map[string]int{"job-1": 1},
+	}
+	wp.runningTaskReleaseCh <- "job-1"
+	wp.runningTaskReleaseCh <- "job-2"
+	wp.runningTaskReleaseCh <- ""
+
+	wp.collectQueuedRunningTaskReleases()
+
+	assert.Equal(t, map[string]int{"job-1": 2, "job-2": 1}, wp.runningTaskReleasePending)
+	assert.Empty(t, wp.runningTaskReleaseCh)
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/events"
+	"github.com/rs/zerolog/log"
+)
+
+// completeDryRun finishes a dry-run job once discovery has queued its URLs as
+// discovered tasks. A job that discovered nothing is left pending so
+// CleanupStuckJobs fails it with the usual "no tasks created" message.
+func (jm *JobManager) completeDryRun(ctx context.Context, jobID string) {
+	var completed bool
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET status = $1,
+				started_at = COALESCE(started_at, created_at),
+				completed_at = NOW(),
+				progress = 100.0
+			WHERE id = $2
+			  AND status IN ($3, $4, $5)
+			  AND EXISTS (SELECT 1 FROM tasks WHERE job_id = $2)
+		`, JobStatusCompleted, jobID, JobStatusPending, JobStatusInitialising, JobStatusRunning)
+		if err != nil {
+			return err
+		}
+		rows, _ := result.RowsAffected()
+		completed = rows > 0
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("job_id", jobID).Msg("Failed to complete dry-run job")
+		return
+	}
+	if !completed {
+		log.Warn().Str("job_id", jobID).Msg("Dry-run job discovered no URLs or is no longer active")
+		return
+	}
+
+	log.Info().Str("job_id", jobID).Msg("Dry-run discovery complete")
+
+	jm.clearProcessedPages(jobID)
+	jm.workerPool.publishJobEvent(jobID, events.JobCompleted)
+	jm.workerPool.notifyJobWebhook(jobID)
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteDryRun(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{
+		db:             mockDB,
+		dbQueue:        &mockDbQueueWrapper{mockDB: mockDB},
+		processedPages: map[string]struct{}{"job-1_7": {}, "job-2_7": {}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs").
+		WithArgs(JobStatusCompleted, "job-1", JobStatusPending, JobStatusInitialising, JobStatusRunning).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	jm.completeDryRun(context.Background(), "job-1")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.False(t, jm.isPageProcessed("job-1", 7))
+	assert.True(t, jm.isPageProcessed("job-2", 7))
+
+	// Nothing discovered: the job is left for stuck-job cleanup to fail
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs").
+		WithArgs(JobStatusCompleted, "job-2", JobStatusPending, JobStatusInitialising, JobStatusRunning).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	jm.completeDryRun(context.Background(), "job-2")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, jm.isPageProcessed("job-2", 7))
+}
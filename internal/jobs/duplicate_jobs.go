@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// DuplicatePolicy is what job creation does when the domain already has an
+// active job for the same organisation or user
+type DuplicatePolicy string
+
+const (
+	// DuplicateCancel cancels the active job and creates a new one (default)
+	DuplicateCancel DuplicatePolicy = "cancel"
+	// DuplicateReuse returns the active job instead of creating a new one
+	DuplicateReuse DuplicatePolicy = "reuse"
+	// DuplicateError refuses to create the job with ErrDuplicateJob
+	DuplicateError DuplicatePolicy = "error"
+)
+
+// ErrDuplicateJob is returned under DuplicateError when the domain already
+// has an active job
+var ErrDuplicateJob = errors.New("an active job already exists for this domain")
+
+// ParseDuplicatePolicy validates a policy name; empty means cancel
+func ParseDuplicatePolicy(raw string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(strings.ToLower(strings.TrimSpace(raw))); policy {
+	case "":
+		return DuplicateCancel, nil
+	case DuplicateCancel, DuplicateReuse, DuplicateError:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("on_duplicate must be cancel, reuse or error, got %q", raw)
+	}
+}
+
+// activeJobIndexes are the partial unique indexes allowing one active job per
+// domain for an organisation or, without one, a user
+var activeJobIndexes = map[string]bool{
+	"idx_jobs_active_org_domain":  true,
+	"idx_jobs_active_user_domain": true,
+}
+
+// isActiveJobConflict reports whether a job insert lost a race with another
+// request creating an active job for the same domain
+func isActiveJobConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && activeJobIndexes[pqErr.Constraint]
+}
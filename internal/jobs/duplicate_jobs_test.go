@@ -0,0 +1,124 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	policy, err := ParseDuplicatePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, DuplicateCancel, policy)
+
+	policy, err = ParseDuplicatePolicy(" Reuse ")
+	require.NoError(t, err)
+	assert.Equal(t, DuplicateReuse, policy)
+
+	_, err = ParseDuplicatePolicy("replace")
+	assert.Error(t, err)
+}
+
+func TestHandleExistingJobsErrorPolicy(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	orgID := "org-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id, j.status, j.organisation_id, j.user_id").
+		WithArgs("example.com", orgID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "organisation_id", "user_id"}).
+			AddRow("job-1", "running", orgID, nil))
+	mock.ExpectCommit()
+
+	existing, err := jm.handleExistingJobs(context.Background(), "example.com", &JobOptions{
+		OrganisationID: &orgID,
+		OnDuplicate:    DuplicateError,
+	})
+	assert.Nil(t, existing)
+	assert.ErrorIs(t, err, ErrDuplicateJob)
+	assert.ErrorContains(t, err, "job-1")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandleExistingJobsWithoutActiveJob(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	orgID := "org-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id, j.status, j.organisation_id, j.user_id").
+		WithArgs("example.com", orgID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "organisation_id", "user_id"}))
+	mock.ExpectRollback()
+
+	existing, err := jm.handleExistingJobs(context.Background(), "example.com", &JobOptions{
+		OrganisationID: &orgID,
+		OnDuplicate:    DuplicateReuse,
+	})
+	assert.Nil(t, existing)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateJobConcurrentDuplicateAppliesPolicyAgain(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	orgID := "org-1"
+	activeJobColumns := []string{"id", "status", "organisation_id", "user_id"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(orgID, "example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectCommit()
+
+	// No active job yet, but another request inserts one before we do
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id, j.status, j.organisation_id, j.user_id").
+		WithArgs("example.com", orgID).
+		WillReturnRows(sqlmock.NewRows(activeJobColumns))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO domains").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO jobs").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_jobs_active_org_domain"})
+	mock.ExpectRollback()
+
+	// The retry finds the job that won the race
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT j.id, j.status, j.organisation_id, j.user_id").
+		WithArgs("example.com", orgID).
+		WillReturnRows(sqlmock.NewRows(activeJobColumns).AddRow("job-2", "pending", orgID, nil))
+	mock.ExpectCommit()
+
+	_, err = jm.CreateJob(context.Background(), &JobOptions{
+		Domain:         "example.com",
+		OrganisationID: &orgID,
+		Concurrency:    5,
+		OnDuplicate:    DuplicateError,
+	})
+	assert.ErrorIs(t, err, ErrDuplicateJob)
+	assert.ErrorContains(t, err, "job-2")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsActiveJobConflict(t *testing.T) {
+	assert.True(t, isActiveJobConflict(&pq.Error{Code: "23505", Constraint: "idx_jobs_active_user_domain"}))
+	assert.False(t, isActiveJobConflict(&pq.Error{Code: "23505", Constraint: "jobs_pkey"}))
+	assert.False(t, isActiveJobConflict(nil))
+}
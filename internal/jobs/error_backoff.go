@@ -0,0 +1,169 @@
+package jobs
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultErrorBackoffThreshold = 0.2
+	// errorRecoveryFactor is the share of the threshold the error rate must
+	// fall under before concurrency is stepped back up
+	errorRecoveryFactor = 0.25
+	// errorWindowSize is how many recent requests the error rate covers
+	errorWindowSize = 50
+	// errorMinSamples avoids reacting to the first few requests of a job
+	errorMinSamples = 20
+	// errorAdjustInterval spaces out cap changes so each one has time to
+	// show up in the window
+	errorAdjustInterval = 10 * time.Second
+)
+
+func errorBackoffThresholdFromEnv() float64 {
+	if raw := strings.TrimSpace(os.Getenv("BBB_ERROR_BACKOFF_THRESHOLD")); raw != "" {
+		if parsed, err := strconv.ParseFloat(raw, 64); err == nil && parsed > 0 && parsed <= 1 {
+			return parsed
+		}
+	}
+	return defaultErrorBackoffThreshold
+}
+
+// isOriginDistress reports whether a request's outcome suggests the origin
+// is struggling or pushing back: a rate limit, a block or a server error
+func isOriginDistress(statusCode int, rateLimited bool) bool {
+	return rateLimited || statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusForbidden || statusCode >= 500
+}
+
+// resultStatusCode returns the response status, or 0 when there was no response
+func resultStatusCode(result *crawler.CrawlResult) int {
+	if result == nil {
+		return 0
+	}
+	return result.StatusCode
+}
+
+// errorWindow is a ring of the most recent request outcomes for a job
+type errorWindow struct {
+	outcomes [errorWindowSize]bool // true for a distress signal
+	next     int
+	count    int
+	errors   int
+}
+
+func (ew *errorWindow) add(distress bool) {
+	if ew.count == errorWindowSize {
+		if ew.outcomes[ew.next] {
+			ew.errors--
+		}
+	} else {
+		ew.count++
+	}
+	ew.outcomes[ew.next] = distress
+	if distress {
+		ew.errors++
+	}
+	ew.next = (ew.next + 1) % errorWindowSize
+}
+
+func (ew *errorWindow) rate() float64 {
+	if ew.count == 0 {
+		return 0
+	}
+	return float64(ew.errors) / float64(ew.count)
+}
+
+// errorCapDecision is a concurrency cap change driven by origin errors
+type errorCapDecision struct {
+	limit     int // 0 removes the cap
+	errorRate float64
+	rising    bool
+}
+
+// nextErrorCap records a request outcome and decides whether the job's
+// error-driven concurrency cap should change. A rate at or above the
+// threshold halves the cap; once it falls well below, the cap grows by one
+// per interval until it's lifted. Must be called with perfMutex held.
+func nextErrorCap(perf *JobPerformance, distress bool, jobConcurrency int, threshold float64, now time.Time) (errorCapDecision, bool) {
+	perf.Errors.add(distress)
+	if perf.Errors.count < errorMinSamples {
+		return errorCapDecision{}, false
+	}
+	if !perf.LastErrorAdjust.IsZero() && now.Sub(perf.LastErrorAdjust) < errorAdjustInterval {
+		return errorCapDecision{}, false
+	}
+
+	rate := perf.Errors.rate()
+	current := perf.ErrorCap
+	next := current
+	switch {
+	case rate >= threshold:
+		if current == 0 {
+			current = max(jobConcurrency, 1)
+		}
+		next = max(current/2, 1)
+	case current > 0 && rate <= threshold*errorRecoveryFactor:
+		next = current + 1
+		if next >= jobConcurrency {
+			next = 0
+		}
+	}
+
+	if next == perf.ErrorCap {
+		return errorCapDecision{}, false
+	}
+
+	perf.ErrorCap = next
+	perf.LastErrorAdjust = now
+	return errorCapDecision{limit: next, errorRate: rate, rising: rate >= threshold}, true
+}
+
+// recordOriginOutcome feeds a request outcome into the job's rolling error
+// rate and pushes any cap change to the domain limiter. This reacts to the
+// origin's distress, unlike recordConcurrencyBlock which reacts to our own cap.
+func (wp *WorkerPool) recordOriginOutcome(task *Task, statusCode int, rateLimited bool) {
+	distress := isOriginDistress(statusCode, rateLimited)
+	jobConcurrency := max(task.JobConcurrency, 1)
+
+	wp.perfMutex.Lock()
+	perf, exists := wp.jobPerformance[task.JobID]
+	if !exists {
+		wp.perfMutex.Unlock()
+		return // Job not tracked
+	}
+	if perf.Auto != nil {
+		// Auto-tuned jobs react to errors through their own limit
+		decision, changed := nextAutoConcurrency(perf.Auto, distress, jobConcurrency, wp.latencySpikeMultiplier, wp.errorBackoffThreshold, time.Now())
+		wp.perfMutex.Unlock()
+		if changed {
+			wp.applyAutoConcurrency(task.JobID, limiterDomain(task.DomainName, task.GroupSubdomains), decision)
+		}
+		return
+	}
+	decision, changed := nextErrorCap(perf, distress, jobConcurrency, wp.errorBackoffThreshold, time.Now())
+	wp.perfMutex.Unlock()
+
+	if !changed {
+		return
+	}
+
+	domain := limiterDomain(task.DomainName, task.GroupSubdomains)
+	wp.ensureDomainLimiter().SetErrorCap(task.JobID, domain, decision.limit)
+
+	event := log.Info()
+	if decision.rising {
+		event = log.Warn()
+	}
+	event.
+		Str("job_id", task.JobID).
+		Str("domain", domain).
+		Float64("error_rate", decision.errorRate).
+		Int("concurrency_cap", decision.limit).
+		Msg("Adjusted job concurrency for origin errors")
+}
@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOriginDistress(t *testing.T) {
+	assert.True(t, isOriginDistress(503, false))
+	assert.True(t, isOriginDistress(500, false))
+	assert.True(t, isOriginDistress(429, false))
+	assert.True(t, isOriginDistress(403, false))
+	assert.True(t, isOriginDistress(0, true))
+	assert.False(t, isOriginDistress(404, false))
+	assert.False(t, isOriginDistress(200, false))
+	assert.False(t, isOriginDistress(0, false), "network errors aren't an origin signal")
+}
+
+func TestErrorWindowRolls(t *testing.T) {
+	var ew errorWindow
+	for range errorWindowSize {
+		ew.add(true)
+	}
+	assert.Equal(t, 1.0, ew.rate())
+
+	for range errorWindowSize / 2 {
+		ew.add(false)
+	}
+	assert.Equal(t, 0.5, ew.rate())
+	assert.Equal(t, errorWindowSize, ew.count)
+}
+
+func TestNextErrorCap(t *testing.T) {
+	perf := &JobPerformance{}
+	now := time.Now()
+
+	// Too few samples to judge, even if they're all errors
+	for range errorMinSamples - 1 {
+		_, changed := nextErrorCap(perf, true, 8, 0.2, now)
+		assert.False(t, changed)
+	}
+
+	// Rate over the threshold halves the job's concurrency
+	decision, changed := nextErrorCap(perf, true, 8, 0.2, now)
+	require.True(t, changed)
+	assert.True(t, decision.rising)
+	assert.Equal(t, 4, decision.limit)
+
+	// Further cuts wait for the adjust interval
+	_, changed = nextErrorCap(perf, true, 8, 0.2, now.Add(time.Second))
+	assert.False(t, changed)
+
+	now = now.Add(errorAdjustInterval)
+	decision, changed = nextErrorCap(perf, true, 8, 0.2, now)
+	require.True(t, changed)
+	assert.Equal(t, 2, decision.limit)
+
+	// Healthy responses push the errors out of the window
+	for range errorWindowSize {
+		nextErrorCap(perf, false, 8, 0.2, now)
+	}
+
+	// Recovery steps the cap back up one at a time until it's lifted
+	for _, want := range []int{3, 4, 5, 6, 7, 0} {
+		now = now.Add(errorAdjustInterval)
+		decision, changed = nextErrorCap(perf, false, 8, 0.2, now)
+		require.True(t, changed)
+		assert.False(t, decision.rising)
+		assert.Equal(t, want, decision.limit)
+	}
+	assert.Zero(t, perf.ErrorCap)
+}
+
+func TestNextErrorCapHoldsBetweenThresholds(t *testing.T) {
+	perf := &JobPerformance{ErrorCap: 2}
+	now := time.Now()
+
+	// 10% errors is under the threshold but not low enough to recover
+	for i := range errorWindowSize {
+		_, changed := nextErrorCap(perf, i%10 == 0, 8, 0.2, now)
+		assert.False(t, changed)
+	}
+	assert.Equal(t, 2, perf.ErrorCap)
+}
+
+func TestDomainLimiterErrorCap(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	dl.cfg.BaseDelay = 0
+	dl.SetErrorCap("job-1", "example.com", 1)
+
+	req := DomainRequest{Domain: "example.com", JobID: "job-1", JobConcurrency: 4}
+	permit, err := dl.Acquire(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dl.GetEffectiveConcurrency("job-1", "example.com"))
+
+	// Other jobs on the domain keep their own limit
+	other, err := dl.Acquire(context.Background(), DomainRequest{Domain: "example.com", JobID: "job-2", JobConcurrency: 4})
+	require.NoError(t, err)
+	other.Release(true, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	acquired := make(chan error, 1)
+	go func() {
+		second, err := dl.Acquire(ctx, req)
+		if err == nil {
+			second.Release(true, false)
+		}
+		acquired <- err
+	}()
+
+	dl.SetErrorCap("job-1", "example.com", 0)
+	require.NoError(t, <-acquired)
+	permit.Release(true, false)
+}
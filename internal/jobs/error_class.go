@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+// ErrorClass is the kind of failure behind a task error. Task errors cross
+// WarmURL as text, so they are classified by message.
+type ErrorClass string
+
+const (
+	ErrorClassBlocked ErrorClass = "blocked"      // 403/429/503 - usually a WAF or rate limit
+	ErrorClassTimeout ErrorClass = "timeout"      // Request deadlines and gateway timeouts
+	ErrorClassServer  ErrorClass = "server_error" // 5xx responses other than 503/504
+	ErrorClassNetwork ErrorClass = "network"      // Connection failures before a usable response
+	ErrorClassClient  ErrorClass = "client_error" // 4xx responses and redirects that won't change on retry
+	ErrorClassOther   ErrorClass = "other"
+)
+
+// Retryable reports whether errors of this class are worth retrying
+func (c ErrorClass) Retryable() bool {
+	return c == ErrorClassTimeout || c == ErrorClassServer || c == ErrorClassNetwork
+}
+
+var statusCodePattern = regexp.MustCompile(`\b(\d{3})\b`)
+
+// ClassifyError buckets a task error. Blocking wins over everything else so
+// rate limits get backoff rather than ordinary retries; nil errors return "".
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+	return ClassifyErrorMessage(err.Error())
+}
+
+// ClassifyErrorMessage buckets a task error message, such as tasks.error
+func ClassifyErrorMessage(message string) ErrorClass {
+	lower := strings.ToLower(message)
+
+	// Redirect loops won't change on retry, and the redirect chain in the
+	// message can contain URLs that look like the keywords below
+	if strings.Contains(lower, crawler.ErrTooManyRedirects.Error()) {
+		return ErrorClassClient
+	}
+
+	switch {
+	case containsAny(lower, "403", "forbidden", "429", "too many requests", "rate limit", "503", "service unavailable"):
+		return ErrorClassBlocked
+	case containsAny(lower, "timeout", "deadline exceeded", "504"):
+		return ErrorClassTimeout
+	case containsAny(lower, "internal server error", "bad gateway", "502", "500"):
+		return ErrorClassServer
+	case containsAny(lower, "connection", "network", "temporary", "reset by peer", "broken pipe", "unexpected eof"):
+		return ErrorClassNetwork
+	case isClientErrorMessage(lower):
+		return ErrorClassClient
+	default:
+		return ErrorClassOther
+	}
+}
+
+// isClientErrorMessage matches crawler errors for missing, protected or
+// redirecting pages, and any 4xx status in the message
+func isClientErrorMessage(lower string) bool {
+	if strings.Contains(lower, "crawler error") && containsAny(lower,
+		"not found", "bad request", "unauthorized", "forbidden", "gone", "method not allowed",
+		"temporary redirect", "permanent redirect", "moved permanently", "see other") {
+		return true
+	}
+	for _, match := range statusCodePattern.FindAllStringSubmatch(lower, -1) {
+		code, err := strconv.Atoi(match[1])
+		if err == nil && code >= 400 && code < 500 {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorBreakdown counts a job's failed tasks by ErrorClass
+type ErrorBreakdown struct {
+	ClientErrors int `json:"client_errors"` // 4xx and redirects
+	ServerErrors int `json:"server_errors"` // 5xx other than 503/504
+	Timeouts     int `json:"timeouts"`
+	Blocked      int `json:"blocked"` // 403/429/503
+	Network      int `json:"network"`
+	Other        int `json:"other"`
+}
+
+// Add counts n failed tasks whose error has the given class
+func (b *ErrorBreakdown) Add(class ErrorClass, n int) {
+	switch class {
+	case ErrorClassClient:
+		b.ClientErrors += n
+	case ErrorClassServer:
+		b.ServerErrors += n
+	case ErrorClassTimeout:
+		b.Timeouts += n
+	case ErrorClassBlocked:
+		b.Blocked += n
+	case ErrorClassNetwork:
+		b.Network += n
+	default:
+		b.Other += n
+	}
+}
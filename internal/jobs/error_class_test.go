@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err      string
+		expected ErrorClass
+	}{
+		{"non-success status code: 403 Forbidden", ErrorClassBlocked},
+		{"non-success status code: 429 Too Many Requests", ErrorClassBlocked},
+		{"non-success status code: 503 Service Unavailable", ErrorClassBlocked},
+		{"context deadline exceeded", ErrorClassTimeout},
+		{"non-success status code: 504 Gateway Timeout", ErrorClassTimeout},
+		{"non-success status code: 500 Internal Server Error", ErrorClassServer},
+		{"non-success status code: 502 Bad Gateway", ErrorClassServer},
+		{"read tcp: connection reset by peer", ErrorClassNetwork},
+		{"unexpected EOF", ErrorClassNetwork},
+		{"crawler error: 404 Not Found", ErrorClassClient},
+		{"crawler error: 410 Gone", ErrorClassClient},
+		{`crawler error: Get "https://example.com/503": too many redirects: https://example.com/ → https://example.com/503 → https://example.com/ (loop)`, ErrorClassClient},
+		{"invalid URL", ErrorClassOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClassifyError(errors.New(tt.err)))
+		})
+	}
+
+	assert.Equal(t, ErrorClass(""), ClassifyError(nil))
+	assert.False(t, ClassifyError(nil).Retryable())
+}
+
+func TestErrorBreakdownAdd(t *testing.T) {
+	var breakdown ErrorBreakdown
+	for _, message := range []string{"403 Forbidden", "429 Too Many Requests", "crawler error: 404 Not Found", "context deadline exceeded", "", "connection refused"} {
+		breakdown.Add(ClassifyErrorMessage(message), 1)
+	}
+	breakdown.Add(ErrorClassServer, 3)
+
+	assert.Equal(t, ErrorBreakdown{ClientErrors: 1, ServerErrors: 3, Timeouts: 1, Blocked: 2, Network: 1, Other: 1}, breakdown)
+}
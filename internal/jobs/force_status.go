@@ -0,0 +1,144 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/events"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrInvalidForceStatus is returned when a forced status isn't terminal
+var ErrInvalidForceStatus = errors.New("target status must be completed, failed or cancelled")
+
+// ForceStatusResult summarises a manual status override
+type ForceStatusResult struct {
+	JobID          string    `json:"job_id"`
+	PreviousStatus JobStatus `json:"previous_status"`
+	Status         JobStatus `json:"status"`
+	TasksSkipped   int64     `json:"tasks_skipped"`
+	TasksFailed    int64     `json:"tasks_failed"`
+}
+
+// IsTerminalJobStatus reports whether a job status is final
+func IsTerminalJobStatus(status JobStatus) bool {
+	switch status {
+	case JobStatusCompleted, JobStatusFailed, JobStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ForceJobStatus forcibly moves a wedged job into a terminal status. Queued
+// (pending/waiting) tasks are skipped, in-flight tasks are failed, the job is
+// removed from the worker pool and its counters are reconciled from tasks.
+// Intended as an operator escape hatch - it bypasses status transition checks.
+func (jm *JobManager) ForceJobStatus(ctx context.Context, jobID string, status JobStatus, reason string) (*ForceStatusResult, error) {
+	if !IsTerminalJobStatus(status) {
+		return nil, ErrInvalidForceStatus
+	}
+
+	result := &ForceStatusResult{JobID: jobID, Status: status}
+	now := time.Now().UTC()
+
+	taskError := fmt.Sprintf("Job manually marked as %s by administrator", status)
+	var jobError any
+	if status == JobStatusFailed {
+		msg := taskError
+		if reason != "" {
+			msg += ": " + reason
+		}
+		jobError = msg
+	}
+
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		var previous string
+		if err := tx.QueryRowContext(ctx, `
+			SELECT status FROM jobs WHERE id = $1 FOR UPDATE
+		`, jobID).Scan(&previous); err != nil {
+			return err
+		}
+		result.PreviousStatus = JobStatus(previous)
+
+		// Task updates fire the progress trigger, so they must run before the
+		// final job status is written
+		res, err := tx.ExecContext(ctx, `
+			UPDATE tasks
+			SET status = $1
+			WHERE job_id = $2 AND status IN ($3, $4)
+		`, TaskStatusSkipped, jobID, TaskStatusPending, TaskStatusWaiting)
+		if err != nil {
+			return fmt.Errorf("failed to skip queued tasks: %w", err)
+		}
+		result.TasksSkipped, _ = res.RowsAffected()
+
+		res, err = tx.ExecContext(ctx, `
+			UPDATE tasks
+			SET status = $1, error = $2, completed_at = $3
+			WHERE job_id = $4 AND status = $5
+		`, TaskStatusFailed, taskError, now, jobID, TaskStatusRunning)
+		if err != nil {
+			return fmt.Errorf("failed to fail running tasks: %w", err)
+		}
+		result.TasksFailed, _ = res.RowsAffected()
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE jobs j
+			SET status = $1,
+				completed_at = $2,
+				error_message = COALESCE($3, j.error_message),
+				completed_tasks = c.completed,
+				failed_tasks = c.failed,
+				skipped_tasks = c.skipped,
+				running_tasks = 0,
+				pending_tasks = 0,
+				waiting_tasks = 0
+			FROM (
+				SELECT
+					COUNT(*) FILTER (WHERE status = 'completed') AS completed,
+					COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+					COUNT(*) FILTER (WHERE status = 'skipped') AS skipped
+				FROM tasks
+				WHERE job_id = $4
+			) c
+			WHERE j.id = $4
+		`, string(status), now, jobError, jobID)
+		if err != nil {
+			return fmt.Errorf("failed to update job status: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("job %s not found: %w", jobID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to force job status: %w", err)
+	}
+
+	if jm.workerPool != nil {
+		jm.workerPool.RemoveJob(jobID)
+		switch status {
+		case JobStatusCompleted:
+			jm.workerPool.publishJobEvent(jobID, events.JobCompleted)
+		case JobStatusFailed:
+			jm.workerPool.publishJobEvent(jobID, events.JobFailed)
+		}
+		jm.workerPool.notifyJobWebhook(jobID)
+	}
+	jm.clearProcessedPages(jobID)
+
+	log.Warn().
+		Str("job_id", jobID).
+		Str("previous_status", string(result.PreviousStatus)).
+		Str("status", string(status)).
+		Int64("tasks_skipped", result.TasksSkipped).
+		Int64("tasks_failed", result.TasksFailed).
+		Str("reason", reason).
+		Msg("Job status forced manually")
+
+	return result, nil
+}
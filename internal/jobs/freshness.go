@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+)
+
+const (
+	// defaultSitemapPriority is the initial priority of sitemap pages
+	defaultSitemapPriority = 0.1
+	// maxFreshnessPriority is the priority of a page modified just now. It stays
+	// below the homepage and its direct links so structure still leads.
+	maxFreshnessPriority = 0.5
+	// DefaultFreshnessWindowDays applies when a job doesn't set a window
+	DefaultFreshnessWindowDays = 7
+	// MaxFreshnessWindowDays bounds the freshness window
+	MaxFreshnessWindowDays = 365
+)
+
+// ValidateFreshnessWindowDays checks a per-job freshness window; 0 disables the boost
+func ValidateFreshnessWindowDays(days int) error {
+	if days < 0 || days > MaxFreshnessWindowDays {
+		return fmt.Errorf("freshness_window_days must be between 0 and %d", MaxFreshnessWindowDays)
+	}
+	return nil
+}
+
+// effectiveFreshnessWindowDays returns the job's freshness window, or the default
+func (o *JobOptions) effectiveFreshnessWindowDays() int {
+	if o == nil || o.FreshnessWindowDays == nil {
+		return DefaultFreshnessWindowDays
+	}
+	return *o.FreshnessWindowDays
+}
+
+// sitemapFreshness sets initial task priority from sitemap <lastmod> dates
+type sitemapFreshness struct {
+	lastMods   map[string]time.Time // Keyed by normalised page path
+	windowDays int
+	now        time.Time
+}
+
+// newSitemapFreshness indexes sitemap entries with a lastmod by page path.
+// Returns nil when the boost is disabled or no entry has a lastmod.
+func newSitemapFreshness(entries []crawler.SitemapURL, domain string, windowDays int, now time.Time) *sitemapFreshness {
+	if windowDays <= 0 {
+		return nil
+	}
+
+	lastMods := make(map[string]time.Time)
+	for _, entry := range entries {
+		if entry.LastMod.IsZero() {
+			continue
+		}
+		path, err := db.NormaliseURLPath(entry.URL, domain)
+		if err != nil {
+			continue
+		}
+		// A page listed twice keeps its most recent lastmod
+		if existing, ok := lastMods[path]; !ok || entry.LastMod.After(existing) {
+			lastMods[path] = entry.LastMod
+		}
+	}
+	if len(lastMods) == 0 {
+		return nil
+	}
+
+	return &sitemapFreshness{lastMods: lastMods, windowDays: windowDays, now: now}
+}
+
+// priority scales from maxFreshnessPriority for a page modified now down to
+// the default at the edge of the window. Pages without a lastmod, or modified
+// before the window, keep the default.
+func (f *sitemapFreshness) priority(path string) float64 {
+	if f == nil {
+		return defaultSitemapPriority
+	}
+	lastMod, ok := f.lastMods[path]
+	if !ok {
+		return defaultSitemapPriority
+	}
+
+	window := time.Duration(f.windowDays) * 24 * time.Hour
+	age := max(f.now.Sub(lastMod), 0) // Future dates count as modified now
+	if age >= window {
+		return defaultSitemapPriority
+	}
+
+	recency := 1 - float64(age)/float64(window)
+	score := defaultSitemapPriority + (maxFreshnessPriority-defaultSitemapPriority)*recency
+	// priority_score is NUMERIC(4,3)
+	return math.Round(score*1000) / 1000
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+func TestSitemapFreshnessPriority(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	entries := []crawler.SitemapURL{
+		{URL: "https://example.com/just-now", LastMod: now},
+		{URL: "https://example.com/half-window", LastMod: now.Add(-84 * time.Hour)},
+		{URL: "https://example.com/stale", LastMod: now.Add(-30 * 24 * time.Hour)},
+		{URL: "https://example.com/future", LastMod: now.Add(48 * time.Hour)},
+		{URL: "https://example.com/undated"},
+		{URL: "https://example.com/dup", LastMod: now.Add(-6 * 24 * time.Hour)},
+		{URL: "https://example.com/dup", LastMod: now},
+	}
+
+	freshness := newSitemapFreshness(entries, "example.com", 7, now)
+	require.NotNil(t, freshness)
+
+	assert.Equal(t, 0.5, freshness.priority("/just-now"))
+	assert.Equal(t, 0.3, freshness.priority("/half-window"))
+	assert.Equal(t, defaultSitemapPriority, freshness.priority("/stale"))
+	assert.Equal(t, 0.5, freshness.priority("/future"), "future dates count as modified now")
+	assert.Equal(t, defaultSitemapPriority, freshness.priority("/undated"))
+	assert.Equal(t, 0.5, freshness.priority("/dup"), "duplicates keep the most recent lastmod")
+	assert.Equal(t, defaultSitemapPriority, freshness.priority("/not-in-sitemap"))
+}
+
+func TestNewSitemapFreshnessDisabled(t *testing.T) {
+	now := time.Now().UTC()
+	entries := []crawler.SitemapURL{{URL: "https://example.com/page", LastMod: now}}
+
+	assert.Nil(t, newSitemapFreshness(entries, "example.com", 0, now), "a zero window disables the boost")
+	assert.Nil(t, newSitemapFreshness([]crawler.SitemapURL{{URL: "https://example.com/page"}}, "example.com", 7, now))
+
+	var freshness *sitemapFreshness
+	assert.Equal(t, defaultSitemapPriority, freshness.priority("/page"))
+}
+
+func TestEffectiveFreshnessWindowDays(t *testing.T) {
+	zero, thirty := 0, 30
+	assert.Equal(t, DefaultFreshnessWindowDays, (&JobOptions{}).effectiveFreshnessWindowDays())
+	assert.Equal(t, 0, (&JobOptions{FreshnessWindowDays: &zero}).effectiveFreshnessWindowDays())
+	assert.Equal(t, 30, (&JobOptions{FreshnessWindowDays: &thirty}).effectiveFreshnessWindowDays())
+
+	assert.NoError(t, ValidateFreshnessWindowDays(0))
+	assert.NoError(t, ValidateFreshnessWindowDays(MaxFreshnessWindowDays))
+	assert.Error(t, ValidateFreshnessWindowDays(-1))
+	assert.Error(t, ValidateFreshnessWindowDays(MaxFreshnessWindowDays+1))
+}
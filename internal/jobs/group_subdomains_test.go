@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterDomain(t *testing.T) {
+	assert.Equal(t, "www.example.com", limiterDomain("www.example.com", false))
+	assert.Equal(t, "example.com", limiterDomain("www.example.com", true))
+	assert.Equal(t, "example.co.uk", limiterDomain("shop.example.co.uk", true))
+	assert.Equal(t, "example.com", limiterDomain("example.com", true))
+	assert.Equal(t, "", limiterDomain("", true))
+}
+
+func TestGroupedSubdomainsShareLimiterState(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	dl := newDomainLimiter(nil)
+	dl.now = func() time.Time { return now }
+	dl.cfg.MaxRetryAfter = 5 * time.Minute
+
+	www := &JobInfo{DomainName: "www.example.com", GroupSubdomains: true}
+	shop := &JobInfo{DomainName: "shop.example.com", GroupSubdomains: true}
+	other := &JobInfo{DomainName: "blog.example.com"}
+
+	dl.ApplyRetryAfter(www.limiterDomain(), time.Minute)
+	assert.Equal(t, time.Minute, dl.EstimatedWait(shop.limiterDomain()))
+	assert.Zero(t, dl.EstimatedWait(other.limiterDomain()))
+}
+
+func TestGroupedSubdomainsPersistDelayToOwnDomain(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	dl := newDomainLimiter(&MockDbQueue{ExecuteFunc: queue.Execute})
+
+	shop := &JobInfo{DomainName: "shop.example.com", GroupSubdomains: true}
+	permit, err := dl.Acquire(context.Background(), DomainRequest{
+		Domain:         shop.limiterDomain(),
+		Host:           shop.DomainName,
+		JobID:          "job-1",
+		JobConcurrency: 1,
+	})
+	require.NoError(t, err)
+
+	// The grouped key has no domains row; the delay belongs to the task's host
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE domains").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "shop.example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	permit.Release(false, true)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
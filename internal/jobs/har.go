@@ -0,0 +1,145 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+)
+
+// MaxWarmURLs caps how many URLs a job's explicit warm list can hold
+const MaxWarmURLs = 1000
+
+// ErrNoHARURLs is returned when a HAR has no usable same-domain GET requests
+var ErrNoHARURLs = errors.New("HAR contains no same-domain GET requests")
+
+// harFile is the subset of the HAR 1.2 format needed to build a warm list
+type harFile struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Response struct {
+		Content struct {
+			MimeType string `json:"mimeType"`
+		} `json:"content"`
+	} `json:"response"`
+}
+
+// HARWarmList is the warm list extracted from a HAR session
+type HARWarmList struct {
+	Domain  string   // Normalised domain the paths belong to
+	Paths   []string // Unique paths in first-request order
+	Skipped int      // Entries dropped as non-GET, other domains or duplicates
+}
+
+// ParseHARWarmList extracts same-domain GET request paths from a HAR file.
+// When domain is empty it's taken from the first HTML document request, or
+// the first GET request. Query strings and fragments are dropped since tasks
+// are keyed by path.
+func ParseHARWarmList(data []byte, domain string) (*HARWarmList, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %w", err)
+	}
+	if len(har.Log.Entries) == 0 {
+		return nil, fmt.Errorf("invalid HAR file: no log entries")
+	}
+
+	domain = util.NormaliseDomain(strings.ToLower(strings.TrimSpace(domain)))
+	if domain == "" {
+		domain = harDocumentDomain(har.Log.Entries)
+	}
+	if domain == "" {
+		return nil, ErrNoHARURLs
+	}
+
+	list := &HARWarmList{Domain: domain}
+	seen := make(map[string]struct{}, len(har.Log.Entries))
+	for _, entry := range har.Log.Entries {
+		path, ok := harEntryPath(entry, domain)
+		if !ok {
+			list.Skipped++
+			continue
+		}
+		if _, dup := seen[path]; dup {
+			list.Skipped++
+			continue
+		}
+		seen[path] = struct{}{}
+		list.Paths = append(list.Paths, path)
+	}
+
+	if len(list.Paths) == 0 {
+		return nil, ErrNoHARURLs
+	}
+	if err := ValidateWarmURLs(list.Paths); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ValidateWarmURLs checks an explicit warm list is within MaxWarmURLs
+func ValidateWarmURLs(urls []string) error {
+	if len(urls) > MaxWarmURLs {
+		return fmt.Errorf("warm list has %d unique URLs; the maximum is %d", len(urls), MaxWarmURLs)
+	}
+	return nil
+}
+
+// harDocumentDomain picks the domain of the page the session loaded
+func harDocumentDomain(entries []harEntry) string {
+	fallback := ""
+	for _, entry := range entries {
+		if !strings.EqualFold(entry.Request.Method, http.MethodGet) {
+			continue
+		}
+		parsed, err := url.Parse(entry.Request.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+			continue
+		}
+		host := util.NormaliseDomain(strings.ToLower(parsed.Hostname()))
+		if strings.HasPrefix(entry.Response.Content.MimeType, "text/html") {
+			return host
+		}
+		if fallback == "" {
+			fallback = host
+		}
+	}
+	return fallback
+}
+
+// harEntryPath returns the normalised path of a same-domain GET request
+func harEntryPath(entry harEntry, domain string) (string, bool) {
+	if !strings.EqualFold(entry.Request.Method, http.MethodGet) {
+		return "", false
+	}
+
+	parsed, err := url.Parse(entry.Request.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", false
+	}
+	if util.NormaliseDomain(strings.ToLower(parsed.Hostname())) != domain {
+		return "", false
+	}
+
+	path := parsed.Path
+	if path == "" {
+		path = "/"
+	}
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path, true
+}
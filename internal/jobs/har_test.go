@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func harWithEntries(entries ...string) []byte {
+	return []byte(`{"log":{"version":"1.2","entries":[` + strings.Join(entries, ",") + `]}}`)
+}
+
+func harEntryJSON(method, rawURL, mimeType string) string {
+	return fmt.Sprintf(`{"request":{"method":%q,"url":%q},"response":{"status":200,"content":{"mimeType":%q}}}`, method, rawURL, mimeType)
+}
+
+func TestParseHARWarmList(t *testing.T) {
+	data := harWithEntries(
+		harEntryJSON("GET", "https://cdn.other.com/lib.js", "application/javascript"),
+		harEntryJSON("GET", "https://www.example.com/", "text/html; charset=utf-8"),
+		harEntryJSON("GET", "https://example.com/fonts/inter.woff2", "font/woff2"),
+		harEntryJSON("GET", "https://example.com/api/menu?locale=en", "application/json"),
+		harEntryJSON("GET", "https://example.com/api/menu?locale=fr", "application/json"),
+		harEntryJSON("POST", "https://example.com/api/track", "application/json"),
+		harEntryJSON("GET", "https://example.com/about/", "text/html"),
+		harEntryJSON("GET", "data:image/png;base64,AAAA", "image/png"),
+	)
+
+	list, err := ParseHARWarmList(data, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", list.Domain, "domain comes from the HTML document, not the first entry")
+	assert.Equal(t, []string{"/", "/fonts/inter.woff2", "/api/menu", "/about"}, list.Paths)
+	assert.Equal(t, 4, list.Skipped, "other domain, duplicate path, POST and data URL")
+}
+
+func TestParseHARWarmListExplicitDomain(t *testing.T) {
+	data := harWithEntries(
+		harEntryJSON("GET", "https://example.com/", "text/html"),
+		harEntryJSON("GET", "https://shop.example.com/cart", "text/html"),
+	)
+
+	list, err := ParseHARWarmList(data, "https://shop.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, "shop.example.com", list.Domain)
+	assert.Equal(t, []string{"/cart"}, list.Paths)
+}
+
+func TestParseHARWarmListErrors(t *testing.T) {
+	_, err := ParseHARWarmList([]byte("not json"), "")
+	assert.ErrorContains(t, err, "invalid HAR file")
+
+	_, err = ParseHARWarmList(harWithEntries(), "")
+	assert.ErrorContains(t, err, "no log entries")
+
+	_, err = ParseHARWarmList(harWithEntries(harEntryJSON("POST", "https://example.com/form", "text/html")), "")
+	assert.ErrorIs(t, err, ErrNoHARURLs)
+
+	entries := make([]string, 0, MaxWarmURLs+1)
+	for i := 0; i <= MaxWarmURLs; i++ {
+		entries = append(entries, harEntryJSON("GET", fmt.Sprintf("https://example.com/p/%d", i), "text/html"))
+	}
+	_, err = ParseHARWarmList(harWithEntries(entries...), "")
+	assert.ErrorContains(t, err, "maximum is 1000")
+}
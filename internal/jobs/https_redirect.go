@@ -0,0 +1,162 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// httpsRedirectChecksPerJob caps the checks recorded per job; redirect
+	// rules are usually site-wide so a sample of the highest-priority pages is
+	// enough
+	httpsRedirectChecksPerJob = 50
+	httpsRedirectCheckTimeout = 15 * time.Second
+)
+
+// httpsRedirectChecker is implemented by crawlers that can probe the HTTP
+// variant of a URL
+type httpsRedirectChecker interface {
+	CheckHTTPSRedirect(ctx context.Context, targetURL string) (*crawler.HTTPSRedirectCheck, error)
+}
+
+// reserveHTTPSRedirectCheck claims one of the job's HTTP probe slots
+func (wp *WorkerPool) reserveHTTPSRedirectCheck(jobID string) bool {
+	wp.httpsCheckMutex.Lock()
+	defer wp.httpsCheckMutex.Unlock()
+
+	if wp.httpsCheckCounts == nil {
+		wp.httpsCheckCounts = make(map[string]int)
+	}
+	if wp.httpsCheckCounts[jobID] >= httpsRedirectChecksPerJob {
+		return false
+	}
+	wp.httpsCheckCounts[jobID]++
+	return true
+}
+
+// checkHTTPSRedirect records whether the HTTP variant of a warmed URL
+// redirects to HTTPS for the job's issues report. Pages warmed over HTTP
+// answer it from the crawl's own redirect chain; HTTPS pages need a probe of
+// the HTTP variant. Runs in the background so it never delays task completion.
+func (wp *WorkerPool) checkHTTPSRedirect(jobID string, result *crawler.CrawlResult) {
+	if wp.dbQueue == nil || result == nil {
+		return
+	}
+
+	if check := crawler.HTTPSRedirectFromResult(result); check != nil {
+		if !wp.reserveHTTPSRedirectCheck(jobID) {
+			return
+		}
+		go wp.saveHTTPSRedirectCheck(jobID, check)
+		return
+	}
+
+	checker, ok := wp.crawler.(httpsRedirectChecker)
+	if !ok || !strings.HasPrefix(result.URL, "https://") {
+		return
+	}
+	wp.jobInfoMutex.RLock()
+	info := wp.jobInfoCache[jobID]
+	wp.jobInfoMutex.RUnlock()
+	// Probing a protected site would mean sending its credentials over plain
+	// HTTP, so those jobs only get the passive check
+	if info == nil || !info.Credentials.IsZero() {
+		return
+	}
+	if !wp.reserveHTTPSRedirectCheck(jobID) {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), httpsRedirectCheckTimeout)
+		defer cancel()
+
+		check, err := wp.probeHTTPSRedirect(ctx, checker, jobID, info, result.URL)
+		if err != nil {
+			// HTTP not being served at all is fine - nothing to report
+			log.Debug().Err(err).Str("job_id", jobID).Str("url", result.URL).Msg("HTTP variant unreachable for HTTPS redirect check")
+			return
+		}
+		wp.saveHTTPSRedirectCheck(jobID, check)
+	}()
+}
+
+// probeHTTPSRedirect requests the HTTP variant of targetURL like any other of
+// the job's requests: paced by the domain limiter under the job's crawl delay
+// and concurrency, with its user agent and custom headers. The HTTPS page was
+// warmed, so robots.txt already allows its path.
+func (wp *WorkerPool) probeHTTPSRedirect(ctx context.Context, checker httpsRedirectChecker, jobID string, info *JobInfo, targetURL string) (*crawler.HTTPSRedirectCheck, error) {
+	permit, err := wp.ensureDomainLimiter().Acquire(ctx, DomainRequest{
+		Domain:         info.limiterDomain(),
+		Host:           info.DomainName,
+		JobID:          jobID,
+		RobotsDelay:    time.Duration(info.CrawlDelay) * time.Second,
+		MinDelay:       time.Duration(info.MinCrawlDelay) * time.Second,
+		JobConcurrency: max(info.Concurrency, 1),
+		Schedule:       info.Schedule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if info.UserAgent != "" {
+		ctx = crawler.WithUserAgent(ctx, info.UserAgent)
+	}
+	ctx = crawler.WithCustomHeaders(ctx, info.CustomHeaders)
+
+	check, err := checker.CheckHTTPSRedirect(ctx, targetURL)
+	rateLimited := check != nil && (check.StatusCode == http.StatusTooManyRequests || check.StatusCode == http.StatusServiceUnavailable)
+	permit.Release(err == nil && !rateLimited, rateLimited)
+	return check, err
+}
+
+// saveHTTPSRedirectCheck stores a check and logs HTTP URLs that don't upgrade
+func (wp *WorkerPool) saveHTTPSRedirectCheck(jobID string, check *crawler.HTTPSRedirectCheck) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpsRedirectCheckTimeout)
+	defer cancel()
+
+	if err := wp.recordHTTPSRedirectCheck(ctx, jobID, check); err != nil {
+		log.Warn().Err(err).Str("job_id", jobID).Str("http_url", check.HTTPURL).Msg("Failed to record HTTPS redirect check")
+		return
+	}
+
+	if check.Status != crawler.HTTPSRedirectOK {
+		log.Info().
+			Str("job_id", jobID).
+			Str("http_url", check.HTTPURL).
+			Str("status", check.Status).
+			Int("status_code", check.StatusCode).
+			Msg("HTTP URL does not redirect to HTTPS")
+	}
+}
+
+func (wp *WorkerPool) recordHTTPSRedirectCheck(ctx context.Context, jobID string, check *crawler.HTTPSRedirectCheck) error {
+	chain, err := json.Marshal(check.RedirectChain)
+	if err != nil {
+		return err
+	}
+	if check.RedirectChain == nil {
+		chain = []byte("[]")
+	}
+
+	return wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO job_https_redirect_checks (job_id, http_url, status, status_code, final_url, redirect_chain)
+			VALUES ($1, $2, $3, $4, $5, $6::jsonb)
+			ON CONFLICT (job_id, http_url) DO UPDATE SET
+				status = EXCLUDED.status,
+				status_code = EXCLUDED.status_code,
+				final_url = EXCLUDED.final_url,
+				redirect_chain = EXCLUDED.redirect_chain,
+				checked_at = NOW()
+		`, jobID, check.HTTPURL, check.Status, check.StatusCode, check.FinalURL, string(chain))
+		return err
+	})
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleScalingFromEnv(t *testing.T) {
+	t.Setenv("BBB_WORKER_IDLE_THRESHOLD", "")
+	t.Setenv("BBB_WORKER_SCALE_COOLDOWN_SECONDS", "")
+	assert.Equal(t, defaultIdleThreshold, idleThresholdFromEnv())
+	assert.Equal(t, defaultScaleCooldown, scaleCooldownFromEnv())
+
+	t.Setenv("BBB_WORKER_IDLE_THRESHOLD", "10")
+	t.Setenv("BBB_WORKER_SCALE_COOLDOWN_SECONDS", "120")
+	assert.Equal(t, 10, idleThresholdFromEnv())
+	assert.Equal(t, 120*time.Second, scaleCooldownFromEnv())
+
+	// 0 still disables idle scale-down; junk falls back to the default
+	t.Setenv("BBB_WORKER_IDLE_THRESHOLD", "0")
+	assert.Equal(t, 0, idleThresholdFromEnv())
+	t.Setenv("BBB_WORKER_IDLE_THRESHOLD", "0.10")
+	assert.Equal(t, defaultIdleThreshold, idleThresholdFromEnv())
+}
+
+func newIdlePool(workers int) *WorkerPool {
+	wp := &WorkerPool{
+		currentWorkers:    workers,
+		maxWorkers:        50,
+		baseWorkerCount:   5,
+		workerConcurrency: 10,
+		idleThreshold:     defaultIdleThreshold,
+		scaleCooldown:     defaultScaleCooldown,
+		jobs:              map[string]bool{},
+		jobInfoCache:      map[string]*JobInfo{},
+		idleWorkers:       map[int]time.Time{},
+		domainLimiter:     newDomainLimiter(nil),
+	}
+	for id := range workers {
+		wp.idleWorkers[id] = time.Now()
+	}
+	return wp
+}
+
+func TestMaybeScaleDownReturnsToBaseWhenIdle(t *testing.T) {
+	wp := newIdlePool(50)
+
+	wp.maybeScaleDown()
+	assert.Equal(t, 5, wp.currentWorkers)
+	assert.Len(t, wp.idleWorkers, 5, "idle entries for exited workers are dropped")
+
+	// Within the cooldown nothing more happens, and nothing is due at base
+	wp.maybeScaleDown()
+	assert.Equal(t, 5, wp.currentWorkers)
+	assert.False(t, wp.idleScaleDownDue())
+}
+
+func TestMaybeScaleDownWaitsForAllWorkersIdle(t *testing.T) {
+	wp := newIdlePool(20)
+	delete(wp.idleWorkers, 7)
+
+	wp.maybeScaleDown()
+	assert.Equal(t, 20, wp.currentWorkers)
+}
+
+func TestIdleScaleDownDue(t *testing.T) {
+	wp := newIdlePool(20)
+	assert.True(t, wp.idleScaleDownDue())
+
+	// An earlier scale-down holds further ones off until the cooldown passes
+	wp.lastScaleDown = time.Now()
+	assert.False(t, wp.idleScaleDownDue())
+	wp.lastScaleDown = time.Now().Add(-defaultScaleCooldown)
+	assert.True(t, wp.idleScaleDownDue())
+
+	// Active jobs leave scaling to maybeScaleDown's own capacity sums
+	wp.jobs["job-1"] = true
+	assert.False(t, wp.idleScaleDownDue())
+}
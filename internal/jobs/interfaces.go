@@ -12,9 +12,11 @@ import (
 type CrawlerInterface interface {
 	WarmURL(ctx context.Context, url string, findLinks bool) (*crawler.CrawlResult, error)
 	DiscoverSitemapsAndRobots(ctx context.Context, domain string) (*crawler.SitemapDiscoveryResult, error)
-	ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error)
+	ParseSitemap(ctx context.Context, sitemapURL string) ([]crawler.SitemapURL, error)
 	FilterURLs(urls []string, includePaths, excludePaths []string) []string
 	GetUserAgent() string
+	CheckUnchanged(ctx context.Context, url string, previous crawler.Validators) (bool, error)
+	CheckCached(ctx context.Context, url string) (bool, error)
 }
 
 // DbQueueInterface defines the database queue operations needed by WorkerPool
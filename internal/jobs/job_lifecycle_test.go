@@ -268,6 +268,18 @@ func TestJobLifecycleStatusTransitions(t *testing.T) {
 			toStatus:   JobStatusCancelled,
 			isValid:    true,
 		},
+		{
+			name:       "running_to_paused",
+			fromStatus: JobStatusRunning,
+			toStatus:   JobStatusPaused,
+			isValid:    true,
+		},
+		{
+			name:       "paused_to_running_resume",
+			fromStatus: JobStatusPaused,
+			toStatus:   JobStatusRunning,
+			isValid:    true,
+		},
 		{
 			name:       "pending_to_cancelled",
 			fromStatus: JobStatusPending,
@@ -408,3 +420,121 @@ func TestJobManagerUpdateJobStatus(t *testing.T) {
 		})
 	}
 }
+
+// TestJobManagerForceJobStatus tests the operator override for wedged jobs
+func TestJobManagerForceJobStatus(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	ctx := context.Background()
+	jm := &JobManager{
+		db:             mockDB,
+		dbQueue:        &mockDbQueueWrapper{mockDB: mockDB},
+		processedPages: make(map[string]struct{}),
+	}
+
+	t.Run("rejects_non_terminal_status", func(t *testing.T) {
+		_, err := jm.ForceJobStatus(ctx, "job-1", JobStatusRunning, "")
+		assert.ErrorIs(t, err, ErrInvalidForceStatus)
+	})
+
+	t.Run("fails_wedged_job", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT status FROM jobs WHERE id = \\$1 FOR UPDATE").
+			WithArgs("job-1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("running"))
+		mock.ExpectExec("UPDATE tasks").
+			WithArgs(TaskStatusSkipped, "job-1", TaskStatusPending, TaskStatusWaiting).
+			WillReturnResult(sqlmock.NewResult(0, 12))
+		mock.ExpectExec("UPDATE tasks").
+			WithArgs(TaskStatusFailed, sqlmock.AnyArg(), sqlmock.AnyArg(), "job-1", TaskStatusRunning).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("UPDATE jobs j").
+			WithArgs(string(JobStatusFailed), sqlmock.AnyArg(), "Job manually marked as failed by administrator: stuck", "job-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		result, err := jm.ForceJobStatus(ctx, "job-1", JobStatusFailed, "stuck")
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusRunning, result.PreviousStatus)
+		assert.Equal(t, JobStatusFailed, result.Status)
+		assert.Equal(t, int64(12), result.TasksSkipped)
+		assert.Equal(t, int64(3), result.TasksFailed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("job_not_found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT status FROM jobs").
+			WithArgs("missing").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := jm.ForceJobStatus(ctx, "missing", JobStatusCompleted, "")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestMarkJobCompletedReportsTransition(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs\\s+SET status = \\$1").
+		WithArgs(JobStatusCompleted, sqlmock.AnyArg(), "job-1", JobStatusCancelled, JobStatusFailed).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	completed, err := wp.markJobCompleted(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.True(t, completed)
+
+	// Already completed, or cancelled or failed first: no row changes
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs\\s+SET status = \\$1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	completed, err = wp.markJobCompleted(context.Background(), "job-2")
+	require.NoError(t, err)
+	assert.False(t, completed)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimJobCompletionHooksOnlyOnce(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE jobs\\s+SET completion_hooks_at = NOW\\(\\)").
+		WithArgs("job-1", JobStatusCompleted).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("job-1"))
+	mock.ExpectCommit()
+
+	claimed, err := wp.claimJobCompletionHooks(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Another instance already claimed them
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE jobs\\s+SET completion_hooks_at = NOW\\(\\)").
+		WithArgs("job-1", JobStatusCompleted).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	claimed, err = wp.claimJobCompletionHooks(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/lib/pq"
+)
+
+// Where a job's reported robots.txt rules came from
+const (
+	RobotsRulesActive  = "active"  // Rules the worker pool is enforcing for the running job
+	RobotsRulesFetched = "fetched" // Job isn't loaded in the pool, so robots.txt was read again
+)
+
+// JobRobots is the robots.txt rules a job enforces and the sitemaps its
+// discovery found
+type JobRobots struct {
+	JobID              string   `json:"job_id"`
+	Domain             string   `json:"domain"`
+	Source             string   `json:"source"`
+	CrawlDelay         int      `json:"crawl_delay"`
+	DisallowPatterns   []string `json:"disallow_patterns"`
+	AllowPatterns      []string `json:"allow_patterns"`
+	Sitemaps           []string `json:"sitemaps"`                  // Sitemap lines in robots.txt
+	DiscoveredSitemaps []string `json:"discovered_sitemaps"`       // Empty for jobs that don't use sitemaps
+	FailedSitemaps     []string `json:"failed_sitemaps,omitempty"` // Sitemaps still unreachable after retries
+}
+
+// GetJobRobots reports the robots.txt rules applied to a job. A running job
+// reports the rules cached in the worker pool; any other job has robots.txt
+// read again with its user agent and credentials.
+func (jm *JobManager) GetJobRobots(ctx context.Context, jobID string) (*JobRobots, error) {
+	if jm.workerPool == nil {
+		return nil, errors.New("worker pool not configured")
+	}
+
+	robots := &JobRobots{JobID: jobID}
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			SELECT d.name, COALESCE(j.discovered_sitemaps, '{}'), j.failed_sitemaps
+			FROM jobs j
+			JOIN domains d ON j.domain_id = d.id
+			WHERE j.id = $1
+		`, jobID).Scan(&robots.Domain, pq.Array(&robots.DiscoveredSitemaps), pq.Array(&robots.FailedSitemaps))
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("job %s not found: %w", jobID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job %s: %w", jobID, err)
+	}
+
+	rules, source, err := jm.workerPool.jobRobotsRules(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	robots.Source = source
+	robots.CrawlDelay = rules.CrawlDelay
+	robots.DisallowPatterns = nonNilStrings(rules.DisallowPatterns)
+	robots.AllowPatterns = nonNilStrings(rules.AllowPatterns)
+	robots.Sitemaps = nonNilStrings(rules.Sitemaps)
+	robots.DiscoveredSitemaps = nonNilStrings(robots.DiscoveredSitemaps)
+	return robots, nil
+}
+
+// jobRobotsRules returns the robots.txt rules cached for a job the pool is
+// running, else fetches them the way the pool would when loading the job
+func (wp *WorkerPool) jobRobotsRules(ctx context.Context, jobID string) (*crawler.RobotsRules, string, error) {
+	wp.jobInfoMutex.RLock()
+	info, ok := wp.jobInfoCache[jobID]
+	wp.jobInfoMutex.RUnlock()
+	if ok && info != nil && info.RobotsRules != nil {
+		return info.RobotsRules, RobotsRulesActive, nil
+	}
+
+	info, err := wp.fetchJobInfoFromDB(ctx, jobID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load job %s: %w", jobID, err)
+	}
+	robotsCtx := crawler.WithCredentials(ctx, info.Credentials)
+	rules := wp.fetchRobotsRules(robotsCtx, info.DomainName, info.UserAgent)
+	if rules == nil {
+		return nil, "", fmt.Errorf("failed to fetch robots.txt for %s", info.DomainName)
+	}
+	return rules, RobotsRulesFetched, nil
+}
+
+// nonNilStrings keeps empty lists encoding as [] rather than null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
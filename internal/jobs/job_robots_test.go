@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobRobotsReportsActiveRules(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{jobInfoCache: map[string]*JobInfo{
+		"job-1": {DomainName: "example.com", RobotsRules: &crawler.RobotsRules{
+			CrawlDelay:       2,
+			DisallowPatterns: []string{"/admin/"},
+			Sitemaps:         []string{"https://example.com/sitemap.xml"},
+		}},
+	}}
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}, workerPool: wp}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.name, COALESCE\\(j.discovered_sitemaps").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "discovered_sitemaps", "failed_sitemaps"}).
+			AddRow("example.com", "{https://example.com/sitemap.xml,https://example.com/sitemap_index.xml}", "{https://example.com/sitemap_index.xml}"))
+	mock.ExpectCommit()
+
+	robots, err := jm.GetJobRobots(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, RobotsRulesActive, robots.Source)
+	assert.Equal(t, "example.com", robots.Domain)
+	assert.Equal(t, 2, robots.CrawlDelay)
+	assert.Equal(t, []string{"/admin/"}, robots.DisallowPatterns)
+	assert.Equal(t, []string{}, robots.AllowPatterns)
+	assert.Equal(t, []string{"https://example.com/sitemap.xml"}, robots.Sitemaps)
+	assert.Len(t, robots.DiscoveredSitemaps, 2)
+	assert.Equal(t, []string{"https://example.com/sitemap_index.xml"}, robots.FailedSitemaps)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetJobRobotsUnknownJob(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}, workerPool: &WorkerPool{}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.name").
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "discovered_sitemaps", "failed_sitemaps"}))
+	mock.ExpectRollback()
+
+	_, err = jm.GetJobRobots(context.Background(), "missing")
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// jobSettings is a job's row as stored, before it's turned into a JobInfo
+type jobSettings struct {
+	DomainID      int
+	DomainName    string
+	CrawlDelay    sql.NullInt64
+	AdaptiveDelay sql.NullInt64
+	AdaptiveFloor sql.NullInt64
+	FindLinks     bool
+	Concurrency   int // Warming concurrency, excluding the verification share
+	VerifyConc    int
+	MaxRetries    int
+	RetryBudget   int
+	Schedule      []byte
+	Cacheable     []int64
+	ChangedOnly   bool
+	SkipCached    bool
+	ContentTypes  []string
+	URLScheme     string
+	PriorityTier  string
+	SlowOrigin    string
+	AutoConc      bool
+	UserAgent     string
+	CustomHeaders []byte
+	Conditional   bool
+	DetectSoft404 bool
+	HashContent   bool
+	FullBody      bool
+	TaskTimeout   int
+	IncludePaths  []byte
+	ExcludePaths  []byte
+	Credentials   sql.NullString
+	Method        string
+	GroupSubs     bool
+	SecondRequest bool
+	MinCrawlDelay int
+	MaxCrawlDelay int
+	DenyHosts     []string
+	MaxDepth      int
+}
+
+// jobSettingsColumn pairs a selected expression with where it's scanned, so
+// the column list and scan targets can't drift out of order
+type jobSettingsColumn struct {
+	expr string
+	dest any
+}
+
+func (s *jobSettings) columns() []jobSettingsColumn {
+	return []jobSettingsColumn{
+		{"d.id", &s.DomainID},
+		{"d.name", &s.DomainName},
+		{"d.crawl_delay_seconds", &s.CrawlDelay},
+		{"d.adaptive_delay_seconds", &s.AdaptiveDelay},
+		{"d.adaptive_delay_floor_seconds", &s.AdaptiveFloor},
+		{"j.find_links", &s.FindLinks},
+		{"j.concurrency - j.verify_concurrency", &s.Concurrency},
+		{"j.verify_concurrency", &s.VerifyConc},
+		{"j.max_retries", &s.MaxRetries},
+		{"j.retry_budget", &s.RetryBudget},
+		{"j.concurrency_schedule", &s.Schedule},
+		{"j.cacheable_status_codes", pq.Array(&s.Cacheable)},
+		{"j.changed_only", &s.ChangedOnly},
+		{"j.skip_cached_urls", &s.SkipCached},
+		{"j.content_types", pq.Array(&s.ContentTypes)},
+		{"j.url_scheme", &s.URLScheme},
+		{"j.priority_tier", &s.PriorityTier},
+		{"j.slow_origin_policy", &s.SlowOrigin},
+		{"j.auto_concurrency", &s.AutoConc},
+		{"COALESCE(j.user_agent, '')", &s.UserAgent},
+		{"j.custom_headers", &s.CustomHeaders},
+		{"j.conditional_warm", &s.Conditional},
+		{"j.detect_soft_404", &s.DetectSoft404},
+		{"j.hash_content", &s.HashContent},
+		{"j.full_body_detection", &s.FullBody},
+		{"j.task_timeout_seconds", &s.TaskTimeout},
+		{"j.include_paths", &s.IncludePaths},
+		{"j.exclude_paths", &s.ExcludePaths},
+		{"CASE WHEN j.credentials_secret_name IS NOT NULL THEN get_job_credentials(j.id) END", &s.Credentials},
+		{"j.method", &s.Method},
+		{"j.group_subdomains", &s.GroupSubs},
+		{"j.second_request", &s.SecondRequest},
+		{"j.min_crawl_delay_seconds", &s.MinCrawlDelay},
+		{"j.max_crawl_delay_seconds", &s.MaxCrawlDelay},
+		{"COALESCE(o.crawl_deny_hosts, '{}')", pq.Array(&s.DenyHosts)},
+		{"j.max_depth", &s.MaxDepth},
+	}
+}
+
+// scanJobSettings loads a job's settings along with its domain's delays and
+// its organisation's deny list
+func scanJobSettings(ctx context.Context, tx *sql.Tx, jobID string) (*jobSettings, error) {
+	settings := &jobSettings{}
+	columns := settings.columns()
+	exprs := make([]string, len(columns))
+	dests := make([]any, len(columns))
+	for i, column := range columns {
+		exprs[i] = column.expr
+		dests[i] = column.dest
+	}
+
+	query := `
+		SELECT ` + strings.Join(exprs, ", ") + `
+		FROM domains d
+		JOIN jobs j ON j.domain_id = d.id
+		LEFT JOIN organisations o ON o.id = j.organisation_id
+		WHERE j.id = $1
+	`
+	if err := tx.QueryRowContext(ctx, query, jobID).Scan(dests...); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// jobInfo decodes the stored settings into the JobInfo workers run the job with
+func (s *jobSettings) jobInfo(jobID string) (*JobInfo, error) {
+	info := &JobInfo{
+		DomainID:          s.DomainID,
+		DomainName:        s.DomainName,
+		FindLinks:         s.FindLinks,
+		Concurrency:       s.Concurrency,
+		VerifyConcurrency: s.VerifyConc,
+		CacheableStatuses: statusCodesFromInt64(s.Cacheable),
+		ChangedOnly:       s.ChangedOnly,
+		SkipCachedURLs:    s.SkipCached,
+		ContentTypes:      s.ContentTypes,
+		URLScheme:         URLScheme(s.URLScheme),
+		PriorityTier:      PriorityTier(s.PriorityTier),
+		SlowOriginPolicy:  SlowOriginPolicy(s.SlowOrigin),
+		UserAgent:         s.UserAgent,
+		ConditionalWarm:   s.Conditional,
+		DetectSoft404:     s.DetectSoft404,
+		HashContent:       s.HashContent,
+		FullBodyDetection: s.FullBody,
+		MaxRetries:        s.MaxRetries,
+		RetryBudget:       s.RetryBudget,
+		TaskTimeout:       time.Duration(ClampTaskTimeoutSeconds(s.TaskTimeout)) * time.Second,
+		Method:            WarmMethod(s.Method),
+		GroupSubdomains:   s.GroupSubs,
+		SecondRequest:     s.SecondRequest,
+		MinCrawlDelay:     s.MinCrawlDelay,
+		DenyHosts:         s.DenyHosts,
+		MaxDepth:          s.MaxDepth,
+		AutoConcurrency:   s.AutoConc,
+	}
+	if s.CrawlDelay.Valid {
+		info.CrawlDelay = int(s.CrawlDelay.Int64)
+	}
+	info.CrawlDelay = clampCrawlDelay(info.CrawlDelay, s.MinCrawlDelay, s.MaxCrawlDelay)
+	if s.AdaptiveDelay.Valid {
+		info.AdaptiveDelay = int(s.AdaptiveDelay.Int64)
+	}
+	if s.AdaptiveFloor.Valid {
+		info.AdaptiveDelayFloor = int(s.AdaptiveFloor.Int64)
+	}
+
+	var err error
+	if info.Schedule, err = parseConcurrencySchedule(s.Schedule, s.Concurrency); err != nil {
+		// A bad schedule shouldn't stall the job; fall back to fixed concurrency
+		log.Warn().Err(err).Str("job_id", jobID).Msg("Ignoring invalid concurrency schedule")
+		info.Schedule = nil
+	}
+	if info.CustomHeaders, err = parseCustomHeaders(s.CustomHeaders); err != nil {
+		return nil, err
+	}
+	if len(s.IncludePaths) > 0 {
+		if err := json.Unmarshal(s.IncludePaths, &info.IncludePaths); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal include paths: %w", err)
+		}
+	}
+	if len(s.ExcludePaths) > 0 {
+		if err := json.Unmarshal(s.ExcludePaths, &info.ExcludePaths); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal exclude paths: %w", err)
+		}
+	}
+	if s.Credentials.Valid && s.Credentials.String != "" {
+		if err := json.Unmarshal([]byte(s.Credentials.String), &info.Credentials); err != nil {
+			return nil, fmt.Errorf("failed to decode job credentials: %w", err)
+		}
+	}
+
+	return info, nil
+}
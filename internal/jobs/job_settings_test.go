@@ -0,0 +1,102 @@
+package jobs
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchJobInfoFromDB(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}}
+
+	// Keyed by expression so the test follows the column list, not its order
+	values := map[string]driver.Value{
+		"d.id":                                 int64(7),
+		"d.name":                               "example.com",
+		"d.crawl_delay_seconds":                int64(2),
+		"d.adaptive_delay_seconds":             nil,
+		"d.adaptive_delay_floor_seconds":       int64(1),
+		"j.find_links":                         true,
+		"j.concurrency - j.verify_concurrency": int64(4),
+		"j.verify_concurrency":                 int64(1),
+		"j.max_retries":                        int64(3),
+		"j.retry_budget":                       int64(50),
+		"j.concurrency_schedule":               nil,
+		"j.cacheable_status_codes":             "{404}",
+		"j.changed_only":                       false,
+		"j.skip_cached_urls":                   true,
+		"j.content_types":                      "{text/html}",
+		"j.url_scheme":                         "http",
+		"j.priority_tier":                      "high",
+		"j.slow_origin_policy":                 "back_off",
+		"j.auto_concurrency":                   false,
+		"COALESCE(j.user_agent, '')":           "CustomBot/1.0",
+		"j.custom_headers":                     []byte(`{"X-Warm":"1"}`),
+		"j.conditional_warm":                   true,
+		"j.detect_soft_404":                    false,
+		"j.hash_content":                       true,
+		"j.full_body_detection":                false,
+		"j.task_timeout_seconds":               int64(45),
+		"j.include_paths":                      []byte(`["/blog/*"]`),
+		"j.exclude_paths":                      nil,
+		"j.method":                             "HEAD",
+		"j.group_subdomains":                   false,
+		"j.second_request":                     true,
+		"j.min_crawl_delay_seconds":            int64(0),
+		"j.max_crawl_delay_seconds":            int64(10),
+		"COALESCE(o.crawl_deny_hosts, '{}')":   "{admin.example.com}",
+		"j.max_depth":                          int64(5),
+		"CASE WHEN j.credentials_secret_name IS NOT NULL THEN get_job_credentials(j.id) END": nil,
+	}
+	columns := (&jobSettings{}).columns()
+	names := make([]string, len(columns))
+	row := make([]driver.Value, len(columns))
+	for i, column := range columns {
+		value, ok := values[column.expr]
+		require.True(t, ok, "no test value for %s", column.expr)
+		names[i] = column.expr
+		row[i] = value
+	}
+	require.Len(t, values, len(columns))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.id, d.name").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows(names).AddRow(row...))
+	mock.ExpectCommit()
+
+	info, err := wp.fetchJobInfoFromDB(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, 7, info.DomainID)
+	assert.Equal(t, "example.com", info.DomainName)
+	assert.Equal(t, 2, info.CrawlDelay)
+	assert.Zero(t, info.AdaptiveDelay)
+	assert.Equal(t, 1, info.AdaptiveDelayFloor)
+	assert.Equal(t, 4, info.Concurrency)
+	assert.Equal(t, 1, info.VerifyConcurrency)
+	assert.Equal(t, 50, info.RetryBudget)
+	assert.Equal(t, []int{404}, info.CacheableStatuses)
+	assert.Equal(t, []string{"text/html"}, info.ContentTypes)
+	assert.Equal(t, URLScheme("http"), info.URLScheme)
+	assert.Equal(t, "CustomBot/1.0", info.UserAgent)
+	assert.Equal(t, map[string]string{"X-Warm": "1"}, info.CustomHeaders)
+	assert.Equal(t, 45*time.Second, info.TaskTimeout)
+	assert.Equal(t, []string{"/blog/*"}, info.IncludePaths)
+	assert.Empty(t, info.ExcludePaths)
+	assert.Equal(t, WarmMethod("HEAD"), info.Method)
+	assert.Equal(t, []string{"admin.example.com"}, info.DenyHosts)
+	assert.Equal(t, 5, info.MaxDepth)
+	assert.Nil(t, info.Schedule)
+}
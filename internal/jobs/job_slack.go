@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/webhook"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+// slackWebhookHost is the only host Slack issues incoming webhooks on
+const slackWebhookHost = "hooks.slack.com"
+
+// maxSlackReasonLength keeps failure messages to a line or two in the channel
+const maxSlackReasonLength = 300
+
+// newSlackNotifier allows each webhook a burst of 5 failure alerts, then one
+// every 30 seconds, so a mass failure doesn't flood the channel
+func newSlackNotifier() *webhook.Notifier {
+	return webhook.NewNotifier(webhook.NotifierConfig{
+		Name:        "slack_job_failures",
+		Concurrency: 8,
+		Timeout:     30 * time.Second,
+		RateLimit:   rate.Every(30 * time.Second),
+		Burst:       5,
+	})
+}
+
+// slackJobFailure is what a job failure alert reports
+type slackJobFailure struct {
+	JobID          string
+	Domain         string
+	Reason         string
+	TotalTasks     int
+	CompletedTasks int
+	FailedTasks    int
+	SkippedTasks   int
+}
+
+// slackMessage is an incoming webhook payload
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// ValidateSlackWebhookURL checks an organisation's Slack incoming webhook URL;
+// empty clears it
+func ValidateSlackWebhookURL(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	if err := webhook.ValidateURL(rawURL); err != nil {
+		return fmt.Errorf("slack_webhook_url: %w", err)
+	}
+	parsed, _ := url.Parse(rawURL)
+	if !strings.EqualFold(parsed.Hostname(), slackWebhookHost) || !strings.HasPrefix(parsed.Path, "/services/") {
+		return fmt.Errorf("slack_webhook_url must be a Slack incoming webhook (https://%s/services/...)", slackWebhookHost)
+	}
+	return nil
+}
+
+// notifyJobFailedSlack posts a failure alert to the job organisation's Slack
+// webhook, if one is set, without blocking the caller
+func (wp *WorkerPool) notifyJobFailedSlack(jobID string) {
+	if wp == nil || wp.dbQueue == nil || wp.webhookSender == nil || wp.slackNotifier == nil {
+		return
+	}
+
+	wp.slackNotifier.Notify("", func(ctx context.Context) error {
+		if err := wp.sendJobFailedSlack(ctx, jobID); err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Msg("Slack job failure alert failed")
+		}
+		return nil
+	})
+}
+
+func (wp *WorkerPool) sendJobFailedSlack(ctx context.Context, jobID string) error {
+	var endpoint, reason sql.NullString
+	failure := slackJobFailure{JobID: jobID}
+
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			SELECT get_organisation_slack_webhook_url(j.organisation_id), d.name, j.error_message,
+			       j.total_tasks, j.completed_tasks, j.failed_tasks, j.skipped_tasks
+			FROM jobs j
+			JOIN domains d ON d.id = j.domain_id
+			WHERE j.id = $1
+			  AND j.status = $2
+			  AND j.organisation_id IS NOT NULL
+		`, jobID, JobStatusFailed).Scan(
+			&endpoint, &failure.Domain, &reason,
+			&failure.TotalTasks, &failure.CompletedTasks, &failure.FailedTasks, &failure.SkippedTasks,
+		)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // Not failed, or no organisation
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load job for Slack alert: %w", err)
+	}
+	if !endpoint.Valid || endpoint.String == "" {
+		return nil // No Slack webhook for this organisation
+	}
+	failure.Reason = reason.String
+
+	// Limit per webhook so one organisation's mass failure stays readable
+	if !wp.slackNotifier.Allow(endpoint.String) {
+		return nil
+	}
+
+	if _, err := wp.webhookSender.SendUnsigned(ctx, endpoint.String, slackMessage{Text: formatJobFailureSlack(failure)}); err != nil {
+		return fmt.Errorf("failed to post Slack job failure alert: %w", err)
+	}
+
+	log.Info().Str("job_id", jobID).Msg("Posted job failure to Slack")
+	return nil
+}
+
+// formatJobFailureSlack renders a short mrkdwn alert
+func formatJobFailureSlack(f slackJobFailure) string {
+	reason := strings.TrimSpace(f.Reason)
+	if reason == "" {
+		reason = "No reason recorded"
+	}
+	if runes := []rune(reason); len(runes) > maxSlackReasonLength {
+		reason = string(runes[:maxSlackReasonLength]) + "…"
+	}
+
+	return fmt.Sprintf(":x: *Job failed* for *%s*\nJob `%s`: %s\nTasks: %d completed, %d failed, %d skipped of %d",
+		f.Domain, f.JobID, reason, f.CompletedTasks, f.FailedTasks, f.SkippedTasks, f.TotalTasks)
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendJobFailedSlack(t *testing.T) {
+	var message slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{
+		dbQueue:       &MockDbQueue{ExecuteFunc: queue.Execute},
+		webhookSender: webhook.NewSenderWithClient(server.Client()),
+		slackNotifier: newSlackNotifier(),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT get_organisation_slack_webhook_url").
+		WithArgs("job-1", JobStatusFailed).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"slack_webhook_url", "name", "error_message",
+			"total_tasks", "completed_tasks", "failed_tasks", "skipped_tasks",
+		}).AddRow(server.URL, "example.com", "Job timed out: no task progress for 30 minutes", 50, 20, 25, 5))
+	mock.ExpectCommit()
+
+	require.NoError(t, wp.sendJobFailedSlack(context.Background(), "job-1"))
+
+	assert.Contains(t, message.Text, "*example.com*")
+	assert.Contains(t, message.Text, "`job-1`: Job timed out")
+	assert.Contains(t, message.Text, "20 completed, 25 failed, 5 skipped of 50")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendJobFailedSlackSkipsOrganisationsWithoutWebhook(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{
+		dbQueue:       &MockDbQueue{ExecuteFunc: queue.Execute},
+		webhookSender: webhook.NewSender(),
+		slackNotifier: newSlackNotifier(),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT get_organisation_slack_webhook_url").
+		WithArgs("job-1", JobStatusFailed).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"slack_webhook_url", "name", "error_message",
+			"total_tasks", "completed_tasks", "failed_tasks", "skipped_tasks",
+		}).AddRow(nil, "example.com", nil, 1, 0, 1, 0))
+	mock.ExpectCommit()
+
+	require.NoError(t, wp.sendJobFailedSlack(context.Background(), "job-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFormatJobFailureSlackTruncatesReason(t *testing.T) {
+	text := formatJobFailureSlack(slackJobFailure{JobID: "job-1", Domain: "example.com", Reason: strings.Repeat("x", 1000)})
+	assert.Less(t, len(text), 500)
+
+	text = formatJobFailureSlack(slackJobFailure{JobID: "job-1", Domain: "example.com"})
+	assert.Contains(t, text, "No reason recorded")
+}
+
+func TestValidateSlackWebhookURL(t *testing.T) {
+	assert.NoError(t, ValidateSlackWebhookURL(""))
+	assert.NoError(t, ValidateSlackWebhookURL("https://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.Error(t, ValidateSlackWebhookURL("http://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.Error(t, ValidateSlackWebhookURL("https://example.com/services/T000"))
+	assert.Error(t, ValidateSlackWebhookURL("https://hooks.slack.com/other"))
+}
@@ -0,0 +1,158 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+// jobWebhookTimeout covers every retry of a single delivery
+const jobWebhookTimeout = 2 * time.Minute
+
+// maxConcurrentJobWebhooks bounds deliveries in flight. A delivery dropped at
+// capacity is never claimed, so its notify_webhook_status stays NULL.
+const maxConcurrentJobWebhooks = 200
+
+func newJobWebhookNotifier() *webhook.Notifier {
+	return webhook.NewNotifier(webhook.NotifierConfig{
+		Name:        "job_webhooks",
+		Concurrency: maxConcurrentJobWebhooks,
+		Timeout:     jobWebhookTimeout,
+	})
+}
+
+// Webhook delivery states recorded in jobs.notify_webhook_status
+const (
+	webhookStatusSending   = "sending"
+	webhookStatusDelivered = "delivered"
+	webhookStatusFailed    = "failed"
+)
+
+// JobWebhookPayload is POSTed to a job's notify_webhook_url when it reaches
+// a terminal status
+type JobWebhookPayload struct {
+	Event           string    `json:"event"` // job.completed, job.failed or job.cancelled
+	JobID           string    `json:"job_id"`
+	Domain          string    `json:"domain"`
+	Status          string    `json:"status"`
+	TotalTasks      int       `json:"total_tasks"`
+	CompletedTasks  int       `json:"completed_tasks"`
+	FailedTasks     int       `json:"failed_tasks"`
+	SkippedTasks    int       `json:"skipped_tasks"`
+	DurationSeconds *int      `json:"duration_seconds,omitempty"`
+	ErrorMessage    string    `json:"error_message,omitempty"`
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// notifyJobWebhook delivers the job's completion webhook, if one is set, in
+// the background. Each job is delivered at most once however many terminal
+// paths observe it.
+func (wp *WorkerPool) notifyJobWebhook(jobID string) {
+	if wp == nil || wp.dbQueue == nil || wp.webhookSender == nil || wp.webhookNotifier == nil {
+		return
+	}
+
+	wp.webhookNotifier.Notify("", func(ctx context.Context) error {
+		if err := wp.sendJobWebhook(ctx, jobID); err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Msg("Job completion webhook failed")
+		}
+		return nil
+	})
+}
+
+func (wp *WorkerPool) sendJobWebhook(ctx context.Context, jobID string) error {
+	var endpoint, secret string
+	var duration sql.NullInt64
+	var errorMessage sql.NullString
+	payload := JobWebhookPayload{JobID: jobID}
+
+	// Claiming the delivery in the same statement that reads it stops two
+	// terminal paths (or two instances) sending it twice
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			UPDATE jobs j
+			SET notify_webhook_status = $2
+			FROM domains d, organisations o
+			WHERE j.id = $1
+			  AND d.id = j.domain_id
+			  AND o.id = j.organisation_id
+			  AND j.notify_webhook_url IS NOT NULL
+			  AND j.notify_webhook_status IS NULL
+			  AND j.status IN ($3, $4, $5)
+			RETURNING j.notify_webhook_url, get_organisation_webhook_secret(o.id), d.name, j.status,
+			          j.total_tasks, j.completed_tasks, j.failed_tasks, j.skipped_tasks,
+			          EXTRACT(EPOCH FROM (j.completed_at - j.started_at))::INTEGER, j.error_message
+		`, jobID, webhookStatusSending, JobStatusCompleted, JobStatusFailed, JobStatusCancelled).Scan(
+			&endpoint, &secret, &payload.Domain, &payload.Status,
+			&payload.TotalTasks, &payload.CompletedTasks, &payload.FailedTasks, &payload.SkippedTasks,
+			&duration, &errorMessage,
+		)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // No webhook, not terminal yet, or already claimed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim job webhook: %w", err)
+	}
+
+	payload.Event = "job." + payload.Status
+	payload.ErrorMessage = errorMessage.String
+	payload.OccurredAt = time.Now().UTC()
+	if duration.Valid {
+		seconds := int(duration.Int64)
+		payload.DurationSeconds = &seconds
+	}
+
+	delivery, sendErr := wp.webhookSender.Send(ctx, endpoint, secret, payload)
+
+	status := webhookStatusDelivered
+	var lastError sql.NullString
+	if sendErr != nil {
+		status = webhookStatusFailed
+		lastError = sql.NullString{String: sendErr.Error(), Valid: true}
+	}
+
+	// Record the outcome even if the delivery context has run out
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := wp.dbQueue.Execute(recordCtx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(recordCtx, `
+			UPDATE jobs
+			SET notify_webhook_status = $2,
+				notify_webhook_attempts = $3,
+				notify_webhook_error = $4,
+				notify_webhook_sent_at = $5
+			WHERE id = $1
+		`, jobID, status, delivery.Attempts, lastError, time.Now().UTC())
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to record job webhook delivery: %w", err)
+	}
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	log.Info().
+		Str("job_id", jobID).
+		Str("status", payload.Status).
+		Int("attempts", delivery.Attempts).
+		Msg("Delivered job completion webhook")
+	return nil
+}
+
+// ValidateNotifyWebhookURL checks a job's completion webhook URL; empty disables it
+func ValidateNotifyWebhookURL(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	if err := webhook.ValidateURL(rawURL); err != nil {
+		return fmt.Errorf("notify_webhook_url: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendJobWebhook(t *testing.T) {
+	var payload JobWebhookPayload
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.SignatureHeader)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}, webhookSender: webhook.NewSenderWithClient(server.Client())}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE jobs j\\s+SET notify_webhook_status = \\$2").
+		WithArgs("job-1", webhookStatusSending, JobStatusCompleted, JobStatusFailed, JobStatusCancelled).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"notify_webhook_url", "webhook_secret", "name", "status",
+			"total_tasks", "completed_tasks", "failed_tasks", "skipped_tasks", "duration", "error_message",
+		}).AddRow(server.URL, "secret", "example.com", "completed", 10, 8, 1, 1, 42, nil))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs\\s+SET notify_webhook_status = \\$2").
+		WithArgs("job-1", webhookStatusDelivered, 1, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, wp.sendJobWebhook(context.Background(), "job-1"))
+
+	assert.Equal(t, "job.completed", payload.Event)
+	assert.Equal(t, "job-1", payload.JobID)
+	assert.Equal(t, "example.com", payload.Domain)
+	assert.Equal(t, 10, payload.TotalTasks)
+	assert.Equal(t, 8, payload.CompletedTasks)
+	assert.Equal(t, 1, payload.FailedTasks)
+	require.NotNil(t, payload.DurationSeconds)
+	assert.Equal(t, 42, *payload.DurationSeconds)
+	assert.NotEmpty(t, signature)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendJobWebhookSkipsUnclaimedJobs(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	queue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: queue.Execute}, webhookSender: webhook.NewSender()}
+
+	// No webhook configured, job still running, or another path already sent it
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE jobs j").
+		WillReturnRows(sqlmock.NewRows([]string{"notify_webhook_url"}))
+	mock.ExpectRollback()
+
+	assert.NoError(t, wp.sendJobWebhook(context.Background(), "job-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestValidateNotifyWebhookURL(t *testing.T) {
+	assert.NoError(t, ValidateNotifyWebhookURL(""))
+	assert.NoError(t, ValidateNotifyWebhookURL("https://hooks.example.com/bbb"))
+	assert.Error(t, ValidateNotifyWebhookURL("http://hooks.example.com/bbb"))
+}
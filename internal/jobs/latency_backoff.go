@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SlowOriginPolicy decides how the worker pool reacts when a job's origin slows down
+type SlowOriginPolicy string
+
+const (
+	// SlowOriginPolicyBoost adds workers as response times rise (default)
+	SlowOriginPolicyBoost SlowOriginPolicy = "boost"
+	// SlowOriginPolicyBackOff cuts the job's concurrency when latency spikes well
+	// above its baseline, so we don't pile onto a struggling origin
+	SlowOriginPolicyBackOff SlowOriginPolicy = "back_off"
+)
+
+const (
+	defaultLatencySpikeMultiplier = 3.0
+	// latencyRecoveryFactor is how close to baseline latency must fall
+	// before concurrency is stepped back up
+	latencyRecoveryFactor = 1.5
+	// latencyBaselineWeight is the EWMA weight of each healthy sample
+	latencyBaselineWeight = 0.1
+	// latencyAdjustInterval spaces out cap changes so each one has time to
+	// show up in the recent response window
+	latencyAdjustInterval = 10 * time.Second
+)
+
+// ParseSlowOriginPolicy validates a policy name; empty means boost
+func ParseSlowOriginPolicy(raw string) (SlowOriginPolicy, error) {
+	switch policy := SlowOriginPolicy(strings.ToLower(strings.TrimSpace(raw))); policy {
+	case "":
+		return SlowOriginPolicyBoost, nil
+	case SlowOriginPolicyBoost, SlowOriginPolicyBackOff:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("slow_origin_policy must be boost or back_off, got %q", raw)
+	}
+}
+
+func latencySpikeMultiplierFromEnv() float64 {
+	if raw := strings.TrimSpace(os.Getenv("BBB_LATENCY_SPIKE_MULTIPLIER")); raw != "" {
+		if parsed, err := strconv.ParseFloat(raw, 64); err == nil && parsed > latencyRecoveryFactor {
+			return parsed
+		}
+	}
+	return defaultLatencySpikeMultiplier
+}
+
+// latencyCapDecision is a concurrency cap change for a back-off job
+type latencyCapDecision struct {
+	limit    int // 0 removes the cap
+	baseline float64
+	spiking  bool
+}
+
+// nextLatencyCap updates the job's latency baseline and decides whether its
+// concurrency cap should change. Spikes halve the cap; once latency is back
+// near baseline the cap grows by one per interval until it's lifted. Must be
+// called with perfMutex held.
+func nextLatencyCap(perf *JobPerformance, avgResponseTime int64, jobConcurrency int, multiplier float64, now time.Time) (latencyCapDecision, bool) {
+	avg := float64(avgResponseTime)
+	if perf.BaselineResponseTime == 0 {
+		perf.BaselineResponseTime = avg
+		return latencyCapDecision{}, false
+	}
+
+	baseline := perf.BaselineResponseTime
+	spiking := avg >= baseline*multiplier
+	if !spiking && perf.LatencyCap == 0 {
+		// Only learn from healthy periods so a slow origin can't become the baseline
+		perf.BaselineResponseTime = baseline*(1-latencyBaselineWeight) + avg*latencyBaselineWeight
+	}
+
+	if !perf.LastLatencyAdjust.IsZero() && now.Sub(perf.LastLatencyAdjust) < latencyAdjustInterval {
+		return latencyCapDecision{}, false
+	}
+
+	current := perf.LatencyCap
+	next := current
+	switch {
+	case spiking:
+		if current == 0 {
+			current = max(jobConcurrency, 1)
+		}
+		next = max(current/2, 1)
+	case current > 0 && avg <= baseline*latencyRecoveryFactor:
+		next = current + 1
+		if next >= jobConcurrency {
+			next = 0
+		}
+	}
+
+	if next == perf.LatencyCap {
+		return latencyCapDecision{}, false
+	}
+
+	perf.LatencyCap = next
+	perf.LastLatencyAdjust = now
+	return latencyCapDecision{limit: next, baseline: baseline, spiking: spiking}, true
+}
+
+// applyLatencyCap pushes a back-off job's new concurrency cap to the domain limiter
+func (wp *WorkerPool) applyLatencyCap(jobID string, domain string, avgResponseTime int64, decision latencyCapDecision) {
+	wp.ensureDomainLimiter().SetLatencyCap(jobID, domain, decision.limit)
+
+	event := log.Info()
+	if decision.spiking {
+		event = log.Warn()
+	}
+	event.
+		Str("job_id", jobID).
+		Str("domain", domain).
+		Int64("avg_response_time", avgResponseTime).
+		Float64("baseline_response_time", decision.baseline).
+		Int("concurrency_cap", decision.limit).
+		Bool("latency_spike", decision.spiking).
+		Msg("Adjusted job concurrency for origin latency")
+}
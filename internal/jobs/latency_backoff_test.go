@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlowOriginPolicy(t *testing.T) {
+	policy, err := ParseSlowOriginPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SlowOriginPolicyBoost, policy)
+
+	policy, err = ParseSlowOriginPolicy(" Back_Off ")
+	require.NoError(t, err)
+	assert.Equal(t, SlowOriginPolicyBackOff, policy)
+
+	_, err = ParseSlowOriginPolicy("throttle")
+	assert.Error(t, err)
+}
+
+func TestNextLatencyCap(t *testing.T) {
+	perf := &JobPerformance{}
+	now := time.Now()
+
+	// First sample seeds the baseline
+	_, changed := nextLatencyCap(perf, 200, 8, 3, now)
+	assert.False(t, changed)
+	assert.Equal(t, 200.0, perf.BaselineResponseTime)
+
+	// Spike halves the job's concurrency
+	decision, changed := nextLatencyCap(perf, 700, 8, 3, now)
+	require.True(t, changed)
+	assert.True(t, decision.spiking)
+	assert.Equal(t, 4, decision.limit)
+	assert.Equal(t, 200.0, perf.BaselineResponseTime, "spikes don't move the baseline")
+
+	// Further spikes wait for the adjust interval
+	_, changed = nextLatencyCap(perf, 900, 8, 3, now.Add(time.Second))
+	assert.False(t, changed)
+
+	now = now.Add(latencyAdjustInterval)
+	decision, changed = nextLatencyCap(perf, 900, 8, 3, now)
+	require.True(t, changed)
+	assert.Equal(t, 2, decision.limit)
+
+	// Recovery steps the cap back up one at a time until it's lifted
+	for _, want := range []int{3, 4, 5, 6, 7, 0} {
+		now = now.Add(latencyAdjustInterval)
+		decision, changed = nextLatencyCap(perf, 250, 8, 3, now)
+		require.True(t, changed)
+		assert.False(t, decision.spiking)
+		assert.Equal(t, want, decision.limit)
+	}
+	assert.Zero(t, perf.LatencyCap)
+}
+
+func TestDomainLimiterLatencyCap(t *testing.T) {
+	dl := newDomainLimiter(nil)
+	dl.cfg.BaseDelay = 0
+	dl.SetLatencyCap("job-1", "example.com", 1)
+
+	req := DomainRequest{Domain: "example.com", JobID: "job-1", JobConcurrency: 4}
+	permit, err := dl.Acquire(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dl.GetEffectiveConcurrency("job-1", "example.com"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	acquired := make(chan error, 1)
+	go func() {
+		second, err := dl.Acquire(ctx, req)
+		if err == nil {
+			second.Release(true, false)
+		}
+		acquired <- err
+	}()
+
+	// Lifting the cap lets the waiting request through without a release
+	dl.SetLatencyCap("job-1", "example.com", 0)
+	require.NoError(t, <-acquired)
+	permit.Release(true, false)
+}
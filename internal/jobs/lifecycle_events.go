@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/events"
+	"github.com/rs/zerolog/log"
+)
+
+const lifecycleEventTimeout = 15 * time.Second
+
+// publishJobEvent emits a lifecycle event for the job to the configured queue.
+// Publishing runs in the background and failures are logged rather than
+// surfaced, so a queue outage never affects job processing.
+func (wp *WorkerPool) publishJobEvent(jobID string, eventType events.Type) {
+	if wp == nil || wp.eventPublisher == nil {
+		return
+	}
+
+	occurredAt := time.Now().UTC()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), lifecycleEventTimeout)
+		defer cancel()
+
+		event, err := wp.loadJobEvent(ctx, jobID, eventType)
+		if err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Str("event_type", string(eventType)).Msg("Failed to load job for lifecycle event")
+			return
+		}
+		event.OccurredAt = occurredAt
+
+		if err := wp.eventPublisher.Publish(ctx, *event); err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Str("event_type", string(eventType)).Msg("Failed to publish job lifecycle event")
+			return
+		}
+
+		log.Debug().Str("job_id", jobID).Str("event_type", string(eventType)).Msg("Published job lifecycle event")
+	}()
+}
+
+func (wp *WorkerPool) loadJobEvent(ctx context.Context, jobID string, eventType events.Type) (*events.Event, error) {
+	event := &events.Event{Type: eventType, JobID: jobID}
+	var organisationID, errorMessage sql.NullString
+
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			SELECT d.name, j.organisation_id, j.status, j.total_tasks, j.completed_tasks, j.failed_tasks, j.error_message
+			FROM jobs j
+			JOIN domains d ON j.domain_id = d.id
+			WHERE j.id = $1
+		`, jobID).Scan(
+			&event.Domain, &organisationID, &event.Status, &event.TotalTasks,
+			&event.CompletedTasks, &event.FailedTasks, &errorMessage,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event.OrganisationID = organisationID.String
+	event.ErrorMessage = errorMessage.String
+	return event, nil
+}
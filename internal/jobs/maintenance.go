@@ -0,0 +1,49 @@
+package jobs
+
+import "context"
+
+// ReconciledCounter is one job whose running_tasks counter was corrected
+type ReconciledCounter struct {
+	JobID       string `json:"job_id"`
+	OldValue    int    `json:"old_value"`
+	NewValue    int    `json:"new_value"`
+	LeakedTasks int    `json:"leaked_tasks"`
+}
+
+// ReconcileResult reports what a running_tasks reconciliation fixed
+type ReconcileResult struct {
+	JobsFixed   int                 `json:"jobs_fixed"`
+	LeakedTasks int                 `json:"leaked_tasks"`
+	Jobs        []ReconciledCounter `json:"jobs"`
+}
+
+// RebalancedQueue is one job whose excess pending tasks were demoted
+type RebalancedQueue struct {
+	JobID        string `json:"job_id"`
+	DemotedTasks int    `json:"demoted_tasks"`
+}
+
+// RebalanceResult reports what a pending queue rebalance demoted
+type RebalanceResult struct {
+	JobsRebalanced int               `json:"jobs_rebalanced"`
+	DemotedTasks   int               `json:"demoted_tasks"`
+	Jobs           []RebalancedQueue `json:"jobs"`
+}
+
+// ReconcileRunningTaskCounters runs the worker pool's running_tasks
+// reconciliation now rather than waiting for a restart or recovery pass
+func (jm *JobManager) ReconcileRunningTaskCounters(ctx context.Context) (*ReconcileResult, error) {
+	if jm.workerPool == nil {
+		return nil, ErrWorkerPoolUnavailable
+	}
+	return jm.workerPool.reconcileRunningTaskCounters(ctx)
+}
+
+// RebalancePendingQueues runs the worker pool's pending queue rebalance now
+// rather than waiting for the next tick
+func (jm *JobManager) RebalancePendingQueues(ctx context.Context) (*RebalanceResult, error) {
+	if jm.workerPool == nil {
+		return nil, ErrWorkerPoolUnavailable
+	}
+	return jm.workerPool.rebalancePendingQueues(ctx)
+}
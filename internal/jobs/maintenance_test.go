@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileRunningTaskCountersReportsFixes(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	mock.ExpectQuery("WITH actual_counts AS").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "old_value", "new_value", "leaked_tasks"}).
+			AddRow("job-a", 7, 2, 5).
+			AddRow("job-b", 1, 0, 1))
+
+	jm := &JobManager{workerPool: &WorkerPool{db: mockDB}}
+	result, err := jm.ReconcileRunningTaskCounters(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.JobsFixed)
+	assert.Equal(t, 6, result.LeakedTasks)
+	assert.Equal(t, []ReconciledCounter{
+		{JobID: "job-a", OldValue: 7, NewValue: 2, LeakedTasks: 5},
+		{JobID: "job-b", OldValue: 1, NewValue: 0, LeakedTasks: 1},
+	}, result.Jobs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRebalancePendingQueuesReportsDemotions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	// Task counts only feed logs and metrics; a failure doesn't stop the rebalance
+	mock.ExpectQuery("COUNT\\(\\*\\) AS active_jobs").WillReturnError(errors.New("counts unavailable"))
+	mock.ExpectQuery("SELECT\\s+id,").
+		WithArgs(pendingRebalanceJobLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cap", "pending"}).
+			AddRow("job-a", 5, 40).
+			AddRow("job-b", 5, 6))
+	mock.ExpectExec("UPDATE tasks").WithArgs("job-a", 5).WillReturnResult(sqlmock.NewResult(0, 35))
+	mock.ExpectExec("UPDATE tasks").WithArgs("job-b", 5).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	jm := &JobManager{workerPool: &WorkerPool{db: mockDB}}
+	result, err := jm.RebalancePendingQueues(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.JobsRebalanced)
+	assert.Equal(t, 35, result.DemotedTasks)
+	assert.Equal(t, []RebalancedQueue{{JobID: "job-a", DemotedTasks: 35}}, result.Jobs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintenanceWithoutWorkerPool(t *testing.T) {
+	jm := &JobManager{}
+
+	_, err := jm.ReconcileRunningTaskCounters(context.Background())
+	assert.ErrorIs(t, err, ErrWorkerPoolUnavailable)
+
+	_, err = jm.RebalancePendingQueues(context.Background())
+	assert.ErrorIs(t, err, ErrWorkerPoolUnavailable)
+}
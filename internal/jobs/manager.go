@@ -6,19 +6,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
 	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/events"
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
 	"github.com/getsentry/sentry-go"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
+// defaultMaxSitemapDiscoveries bounds how many background sitemap discoveries
+// may run at once across all jobs on this instance.
+const defaultMaxSitemapDiscoveries = 10
+
+// sitemapEnqueueBatchSize keeps each sitemap enqueue transaction short on
+// large sitemaps.
+const sitemapEnqueueBatchSize = 1000
+
+// sitemapDiscoverySem is shared by every JobManager so bursts of job creation
+// queue their sitemap discovery rather than fetching all sitemaps at once.
+var sitemapDiscoverySem = make(chan struct{}, maxSitemapDiscoveriesFromEnv())
+
+func maxSitemapDiscoveriesFromEnv() int {
+	if raw := strings.TrimSpace(os.Getenv("BBB_MAX_CONCURRENT_SITEMAP_DISCOVERIES")); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultMaxSitemapDiscoveries
+}
+
+// acquireSitemapDiscoverySlot blocks until a discovery slot is free or ctx is done
+func acquireSitemapDiscoverySlot(ctx context.Context) error {
+	select {
+	case sitemapDiscoverySem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func releaseSitemapDiscoverySlot() {
+	<-sitemapDiscoverySem
+}
+
 // DbQueueProvider defines the interface for database operations
 type DbQueueProvider interface {
 	Execute(ctx context.Context, fn func(*sql.Tx) error) error
@@ -26,22 +65,53 @@ type DbQueueProvider interface {
 	CleanupStuckJobs(ctx context.Context) error
 }
 
+// writeCapacityChecker is implemented by queues that can shed request-driven
+// writes while the connection pool is saturated
+type writeCapacityChecker interface {
+	CheckWriteCapacity() error
+}
+
 // JobManagerInterface defines the interface for job management operations
 type JobManagerInterface interface {
 	// Core job operations used by API layer
 	CreateJob(ctx context.Context, options *JobOptions) (*Job, error)
+	CreateRewarmJob(ctx context.Context, sourceJobID string, statuses []int, cacheStatuses []string) (*Job, error)
 	CancelJob(ctx context.Context, jobID string) error
+	CancelActiveJobs(ctx context.Context, organisationID, domain string) (int, error)
+	PauseJob(ctx context.Context, jobID string) error
+	ResumeJob(ctx context.Context, jobID string) error
 	GetJobStatus(ctx context.Context, jobID string) (*Job, error)
 
 	// Additional job operations
 	GetJob(ctx context.Context, jobID string) (*Job, error)
 	EnqueueJobURLs(ctx context.Context, jobID string, pages []db.Page, sourceType string, sourceURL string) error
+	PrioritiseTasks(ctx context.Context, jobID string, paths []string, priority float64) (int, error)
+	GetJobRobots(ctx context.Context, jobID string) (*JobRobots, error)
 
 	// Job utility methods
 	IsJobComplete(job *Job) bool
 	CalculateJobProgress(job *Job) float64
 	ValidateStatusTransition(from, to JobStatus) error
 	UpdateJobStatus(ctx context.Context, jobID string, status JobStatus) error
+
+	// Operator escape hatch for wedged jobs
+	ForceJobStatus(ctx context.Context, jobID string, status JobStatus, reason string) (*ForceStatusResult, error)
+
+	// Health reporting
+	NotificationListenerStatus() NotificationListenerStatus
+	WorkerPoolStarted() bool
+	SystemThroughput(ctx context.Context) (*SystemThroughput, error)
+	PoolSnapshot() (*PoolSnapshot, error)
+
+	// On-demand maintenance normally run on timers
+	ReconcileRunningTaskCounters(ctx context.Context) (*ReconcileResult, error)
+	RebalancePendingQueues(ctx context.Context) (*RebalanceResult, error)
+
+	// Pre-flight checks
+	PreviewRobots(ctx context.Context, domain string, includePaths, excludePaths []string) (*RobotsPreview, error)
+
+	// Domain ownership
+	VerifyDomain(ctx context.Context, organisationID, domain string) (*DomainVerification, error)
 }
 
 // JobManager handles job creation and lifecycle management
@@ -68,11 +138,29 @@ func NewJobManager(db *sql.DB, dbQueue DbQueueProvider, crawler CrawlerInterface
 	}
 }
 
-// handleExistingJobs checks for existing active jobs and cancels them if found
-func (jm *JobManager) handleExistingJobs(ctx context.Context, domain string, userID *string, organisationID *string) error {
+// checkWriteCapacity fails fast with a db.PoolBusyError while the connection
+// pool is saturated, so API writes don't block until the client times out
+func (jm *JobManager) checkWriteCapacity() error {
+	if checker, ok := jm.dbQueue.(writeCapacityChecker); ok {
+		return checker.CheckWriteCapacity()
+	}
+	return nil
+}
+
+// handleExistingJobs applies the job's duplicate policy to any existing active
+// job for the same domain and user/organisation. It returns the existing job
+// when the policy reuses it, and nil to continue creating the new job.
+func (jm *JobManager) handleExistingJobs(ctx context.Context, domain string, options *JobOptions) (*Job, error) {
+	policy, err := ParseDuplicatePolicy(string(options.OnDuplicate))
+	if err != nil {
+		return nil, err
+	}
+
+	userID, organisationID := options.UserID, options.OrganisationID
+
 	// Need either user_id or organisation_id to check for duplicates
 	if (userID == nil || *userID == "") && (organisationID == nil || *organisationID == "") {
-		return nil // Skip check if neither ID is provided
+		return nil, nil // Skip check if neither ID is provided
 	}
 
 	var existingJobID string
@@ -112,76 +200,126 @@ func (jm *JobManager) handleExistingJobs(ctx context.Context, domain string, use
 		args = []any{domain, *userID}
 	}
 
-	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+	err = jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx, query, args...).Scan(
 			&existingJobID, &existingJobStatus, &existingOrgID, &existingUserID)
 	})
 
-	if err == nil && existingJobID != "" {
-		// Found an existing active job for the same domain and user/organisation
-		logEvent := log.Info().
-			Str("existing_job_id", existingJobID).
-			Str("existing_job_status", existingJobStatus).
-			Str("domain", domain)
-
-		if existingOrgID.Valid {
-			logEvent = logEvent.Str("organisation_id", existingOrgID.String)
-		}
-		if existingUserID.Valid {
-			logEvent = logEvent.Str("user_id", existingUserID.String)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			// Log query error but continue with job creation
+			log.Warn().
+				Err(err).
+				Str("domain", domain).
+				Msg("Error checking for existing jobs")
 		}
+		return nil, nil
+	}
 
-		logEvent.Msg("Found existing active job for domain, cancelling it")
+	// Found an existing active job for the same domain and user/organisation
+	logEvent := log.Info().
+		Str("existing_job_id", existingJobID).
+		Str("existing_job_status", existingJobStatus).
+		Str("domain", domain).
+		Str("on_duplicate", string(policy))
 
-		if err := jm.CancelJob(ctx, existingJobID); err != nil {
-			log.Error().
-				Err(err).
-				Str("job_id", existingJobID).
-				Msg("Failed to cancel existing job")
-			// Continue with new job creation even if cancellation fails
+	if existingOrgID.Valid {
+		logEvent = logEvent.Str("organisation_id", existingOrgID.String)
+	}
+	if existingUserID.Valid {
+		logEvent = logEvent.Str("user_id", existingUserID.String)
+	}
+
+	switch policy {
+	case DuplicateReuse:
+		logEvent.Msg("Found existing active job for domain, reusing it")
+		existing, err := jm.GetJob(ctx, existingJobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load existing job: %w", err)
 		}
-	} else if err != nil && err != sql.ErrNoRows {
-		// Log query error but continue with job creation
-		log.Warn().
-			Err(err).
-			Str("domain", domain).
-			Msg("Error checking for existing jobs")
+		existing.Reused = true
+		return existing, nil
+	case DuplicateError:
+		logEvent.Msg("Found existing active job for domain, refusing new job")
+		return nil, fmt.Errorf("%w (job %s is %s)", ErrDuplicateJob, existingJobID, existingJobStatus)
 	}
 
-	return nil // Always return nil to continue with job creation
+	logEvent.Msg("Found existing active job for domain, cancelling it")
+
+	if err := jm.CancelJob(ctx, existingJobID); err != nil {
+		log.Error().
+			Err(err).
+			Str("job_id", existingJobID).
+			Msg("Failed to cancel existing job")
+		// Continue with new job creation even if cancellation fails
+	}
+	return nil, nil
 }
 
 // createJobObject creates a new Job instance with the given options and normalized domain
 func createJobObject(options *JobOptions, normalisedDomain string) *Job {
 	return &Job{
-		ID:              uuid.New().String(),
-		Domain:          normalisedDomain,
-		UserID:          options.UserID,
-		OrganisationID:  options.OrganisationID,
-		Status:          JobStatusPending,
-		Progress:        0,
-		TotalTasks:      0,
-		CompletedTasks:  0,
-		FoundTasks:      0,
-		SitemapTasks:    0,
-		FailedTasks:     0,
-		CreatedAt:       time.Now().UTC(),
-		Concurrency:     options.Concurrency,
-		FindLinks:       options.FindLinks,
-		MaxPages:        options.MaxPages,
-		IncludePaths:    options.IncludePaths,
-		ExcludePaths:    options.ExcludePaths,
-		RequiredWorkers: options.RequiredWorkers,
-		SourceType:      options.SourceType,
-		SourceDetail:    options.SourceDetail,
-		SourceInfo:      options.SourceInfo,
-		SchedulerID:     options.SchedulerID,
+		ID:                   uuid.New().String(),
+		Domain:               normalisedDomain,
+		UserID:               options.UserID,
+		OrganisationID:       options.OrganisationID,
+		Status:               JobStatusPending,
+		Progress:             0,
+		TotalTasks:           0,
+		CompletedTasks:       0,
+		FoundTasks:           0,
+		SitemapTasks:         0,
+		FailedTasks:          0,
+		CreatedAt:            time.Now().UTC(),
+		Concurrency:          options.Concurrency,
+		VerifyConcurrency:    options.VerifyConcurrency,
+		ConcurrencySchedule:  options.ConcurrencySchedule,
+		CacheableStatusCodes: options.CacheableStatusCodes,
+		ChangedOnly:          options.ChangedOnly,
+		SkipCachedURLs:       options.SkipCachedURLs,
+		ContentTypes:         options.ContentTypes,
+		URLScheme:            options.URLScheme,
+		Tags:                 options.Tags,
+		PriorityTier:         options.PriorityTier,
+		SlowOriginPolicy:     options.SlowOriginPolicy,
+		AutoConcurrency:      options.AutoConcurrency,
+		UserAgent:            options.UserAgent,
+		CustomHeaders:        options.CustomHeaders,
+		ConditionalWarm:      options.ConditionalWarm,
+		DetectSoft404:        options.DetectSoft404,
+		HashContent:          options.HashContent,
+		FullBodyDetection:    options.FullBodyDetection,
+		NotifyWebhookURL:     options.NotifyWebhookURL,
+		PurgeBeforeWarm:      options.PurgeBeforeWarm,
+		DryRun:               options.DryRun,
+		HasCredentials:       options.Credentials != nil && !options.Credentials.IsZero(),
+		Method:               options.Method,
+		GroupSubdomains:      options.GroupSubdomains,
+		SecondRequest:        options.SecondRequest,
+		MinCrawlDelaySeconds: options.MinCrawlDelaySeconds,
+		MaxCrawlDelaySeconds: options.MaxCrawlDelaySeconds,
+		PrioritiseBySearch:   options.PrioritiseBySearch,
+		MaxRuntimeMinutes:    options.MaxRuntimeMinutes,
+		MaxRuntimeAction:     options.MaxRuntimeAction,
+		FindLinks:            options.FindLinks,
+		MaxPages:             options.MaxPages,
+		MaxDepth:             options.MaxDepth,
+		IncludePaths:         options.IncludePaths,
+		ExcludePaths:         options.ExcludePaths,
+		RequiredWorkers:      options.RequiredWorkers,
+		MaxRetries:           options.effectiveMaxRetries(),
+		RetryBudget:          options.RetryBudget,
+		TaskTimeoutSeconds:   ClampTaskTimeoutSeconds(options.TaskTimeoutSeconds),
+		SourceType:           options.SourceType,
+		SourceDetail:         options.SourceDetail,
+		SourceInfo:           options.SourceInfo,
+		SchedulerID:          options.SchedulerID,
 	}
 }
 
-// setupJobDatabase creates domain and job records in the database
-// Returns the domain ID for use in subsequent operations
-func (jm *JobManager) setupJobDatabase(ctx context.Context, job *Job, normalisedDomain string) (int, error) {
+// setupJobDatabase creates domain and job records in the database, storing
+// any site credentials in Vault. Returns the domain ID for use in subsequent operations
+func (jm *JobManager) setupJobDatabase(ctx context.Context, job *Job, normalisedDomain string, creds *crawler.Credentials) (int, error) {
 	var domainID int
 
 	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
@@ -200,17 +338,48 @@ func (jm *JobManager) setupJobDatabase(ctx context.Context, job *Job, normalised
 				id, domain_id, user_id, organisation_id, status, progress, total_tasks, completed_tasks, failed_tasks, skipped_tasks,
 				created_at, concurrency, find_links, include_paths, exclude_paths,
 				required_workers, max_pages,
-				found_tasks, sitemap_tasks, source_type, source_detail, source_info, scheduler_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
+				found_tasks, sitemap_tasks, source_type, source_detail, source_info, scheduler_id, max_retries,
+				verify_concurrency, concurrency_schedule, cacheable_status_codes, changed_only, priority_tier,
+				slow_origin_policy, user_agent, conditional_warm, notify_webhook_url, purge_before_warm,
+				task_timeout_seconds, dry_run, method, group_subdomains, second_request,
+				min_crawl_delay_seconds, max_crawl_delay_seconds, prioritise_by_search, max_runtime_minutes,
+				max_runtime_action, custom_headers, detect_soft_404, hash_content, max_depth, auto_concurrency,
+				skip_cached_urls, content_types, tags, retry_budget, url_scheme, full_body_detection
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42, $43, $44, $45, $46, $47, $48, $49, $50, $51, $52, $53, $54, $55)`,
 			job.ID, domainID, job.UserID, job.OrganisationID, string(job.Status), job.Progress,
 			job.TotalTasks, job.CompletedTasks, job.FailedTasks, job.SkippedTasks,
-			job.CreatedAt, job.Concurrency, job.FindLinks,
+			// jobs.concurrency is the total in-flight budget shared by both phases
+			job.CreatedAt, job.Concurrency+job.VerifyConcurrency, job.FindLinks,
 			db.Serialise(job.IncludePaths), db.Serialise(job.ExcludePaths),
 			job.RequiredWorkers, job.MaxPages,
 			job.FoundTasks, job.SitemapTasks, job.SourceType, job.SourceDetail, job.SourceInfo,
-			job.SchedulerID,
+			job.SchedulerID, job.MaxRetries,
+			job.VerifyConcurrency, serialiseConcurrencySchedule(job.ConcurrencySchedule),
+			pq.Array(statusCodesToInt64(job.CacheableStatusCodes)), job.ChangedOnly,
+			string(job.PriorityTier), string(job.SlowOriginPolicy),
+			sql.NullString{String: job.UserAgent, Valid: job.UserAgent != ""},
+			job.ConditionalWarm,
+			sql.NullString{String: job.NotifyWebhookURL, Valid: job.NotifyWebhookURL != ""},
+			job.PurgeBeforeWarm, job.TaskTimeoutSeconds, job.DryRun, string(job.Method), job.GroupSubdomains,
+			job.SecondRequest, job.MinCrawlDelaySeconds, job.MaxCrawlDelaySeconds, job.PrioritiseBySearch,
+			job.MaxRuntimeMinutes, string(job.MaxRuntimeAction), serialiseCustomHeaders(job.CustomHeaders),
+			job.DetectSoft404, job.HashContent, job.MaxDepth, job.AutoConcurrency,
+			job.SkipCachedURLs, pq.Array(job.ContentTypes), serialiseTags(job.Tags),
+			job.RetryBudget, string(job.URLScheme), job.FullBodyDetection,
 		)
-		return err
+		if err != nil || !job.HasCredentials {
+			return err
+		}
+
+		payload, err := json.Marshal(creds)
+		if err != nil {
+			return fmt.Errorf("failed to encode credentials: %w", err)
+		}
+		var secretName string
+		if err := tx.QueryRowContext(ctx, `SELECT store_job_credentials($1, $2)`, job.ID, string(payload)).Scan(&secretName); err != nil {
+			return fmt.Errorf("failed to store credentials: %w", err)
+		}
+		return nil
 	})
 
 	if err != nil {
@@ -297,12 +466,16 @@ func (jm *JobManager) createManualRootTask(ctx context.Context, job *Job, domain
 		}
 
 		// Enqueue the root URL with its page ID
+		status := TaskStatusPending
+		if job.DryRun {
+			status = TaskStatusDiscovered
+		}
 		_, err = tx.ExecContext(ctx, `
 			INSERT INTO tasks (
 				id, job_id, page_id, path, status, created_at, retry_count,
 				source_type, source_url
 			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		`, uuid.New().String(), job.ID, pageID, rootPath, "pending", time.Now().UTC(), 0, "manual", "")
+		`, uuid.New().String(), job.ID, pageID, rootPath, string(status), time.Now().UTC(), 0, "manual", "")
 
 		if err != nil {
 			return fmt.Errorf("failed to enqueue task for root path: %w", err)
@@ -325,22 +498,96 @@ func (jm *JobManager) createManualRootTask(ctx context.Context, job *Job, domain
 	return nil
 }
 
-// setupJobURLDiscovery handles URL discovery for the job (sitemap or manual)
+// setupJobURLDiscovery handles URL discovery for the job (warm list, seed list, sitemap or manual)
 func (jm *JobManager) setupJobURLDiscovery(ctx context.Context, job *Job, options *JobOptions, domainID int, normalisedDomain string) error {
+	// Discovery fetches robots.txt and sitemaps with the job's user agent and credentials
+	discoveryCtx := crawler.WithUserAgent(context.Background(), options.UserAgent)
+	if options.Credentials != nil {
+		discoveryCtx = crawler.WithCredentials(discoveryCtx, *options.Credentials)
+	}
+	// Its requests are spaced by the domain limiter, like the warm itself
+	discoveryCtx = jm.withDiscoveryPacer(discoveryCtx, job, normalisedDomain)
+
+	if len(options.WarmURLs) > 0 {
+		// Explicit warm list (e.g. from a HAR import) - enqueue exactly these URLs
+		backgroundCtx, cancel := context.WithTimeout(discoveryCtx, 10*time.Minute)
+		go func() {
+			defer cancel()
+			if err := jm.enqueueURLsForJob(backgroundCtx, job.ID, normalisedDomain, options.WarmURLs, "warm_list", nil); err != nil {
+				log.Error().Err(err).Str("job_id", job.ID).Msg("Failed to enqueue warm list URLs")
+				jm.updateJobWithError(backgroundCtx, job.ID, fmt.Sprintf("Failed to enqueue warm list: %v", err))
+				return
+			}
+
+			if job.DryRun {
+				jm.completeDryRun(backgroundCtx, job.ID)
+				return
+			}
+			if jm.workerPool != nil {
+				jm.workerPool.NotifyNewTasks()
+			}
+		}()
+		return nil
+	}
+
+	if len(options.SeedURLs) > 0 {
+		// Client-supplied seed list - skips sitemap discovery; FindLinks still
+		// follows links from the seeded pages
+		backgroundCtx, cancel := context.WithTimeout(discoveryCtx, 10*time.Minute)
+		go func() {
+			defer cancel()
+			if err := jm.enqueueSeedURLs(backgroundCtx, job, normalisedDomain, options.SeedURLs); err != nil {
+				log.Error().Err(err).Str("job_id", job.ID).Msg("Failed to enqueue seed URLs")
+				jm.updateJobWithError(backgroundCtx, job.ID, fmt.Sprintf("Failed to enqueue seed URLs: %v", err))
+				return
+			}
+
+			if job.DryRun {
+				jm.completeDryRun(backgroundCtx, job.ID)
+				return
+			}
+			if jm.workerPool != nil {
+				jm.workerPool.NotifyNewTasks()
+			}
+		}()
+		return nil
+	}
+
 	if options.UseSitemap {
 		// Fetch and process sitemap in a separate goroutine
 		// Use detached context with timeout for background processing
-		backgroundCtx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		backgroundCtx, cancel := context.WithTimeout(discoveryCtx, 30*time.Minute)
 		go func() {
 			defer cancel()
-			jm.processSitemap(backgroundCtx, job.ID, normalisedDomain, options.IncludePaths, options.ExcludePaths)
+
+			// Queue behind other discoveries so bursts of jobs don't spike memory and network
+			if err := acquireSitemapDiscoverySlot(backgroundCtx); err != nil {
+				log.Error().
+					Err(err).
+					Str("job_id", job.ID).
+					Str("domain", normalisedDomain).
+					Msg("Timed out waiting for sitemap discovery slot")
+				if jm.dbQueue != nil {
+					errCtx, errCancel := context.WithTimeout(context.Background(), 10*time.Second)
+					jm.updateJobWithError(errCtx, job.ID, fmt.Sprintf("Timed out waiting to start sitemap discovery: %v", err))
+					errCancel()
+				}
+				return
+			}
+			defer releaseSitemapDiscoverySlot()
+
+			// A dry run never warms, so there's nothing to purge for
+			jm.processSitemap(backgroundCtx, job.ID, normalisedDomain, options.IncludePaths, options.ExcludePaths, options.effectiveFreshnessWindowDays(), options.PurgeBeforeWarm && !job.DryRun)
+			if job.DryRun {
+				jm.completeDryRun(backgroundCtx, job.ID)
+			}
 		}()
 		return nil
 	}
 
 	// Manual root URL creation - process in background for consistency
 	// Use detached context with timeout for background processing
-	backgroundCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	backgroundCtx, cancel := context.WithTimeout(discoveryCtx, 10*time.Minute)
 	go func() {
 		defer cancel()
 		rootPath := "/"
@@ -356,6 +603,11 @@ func (jm *JobManager) setupJobURLDiscovery(ctx context.Context, job *Job, option
 			return
 		}
 
+		if job.DryRun {
+			jm.completeDryRun(backgroundCtx, job.ID)
+			return
+		}
+
 		// Notify workers immediately that new tasks are available
 		if jm.workerPool != nil {
 			jm.workerPool.NotifyNewTasks()
@@ -374,6 +626,20 @@ func (jm *JobManager) CreateJob(ctx context.Context, options *JobOptions) (*Job,
 
 	normalisedDomain := util.NormaliseDomain(options.Domain)
 
+	if options.MaxRetries != nil {
+		if err := ValidateMaxRetries(*options.MaxRetries); err != nil {
+			return nil, invalidJobOptions(err)
+		}
+	}
+
+	if err := ValidateRetryBudget(options.RetryBudget); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	if options.VerifyConcurrency < 0 {
+		return nil, invalidJobOptions(fmt.Errorf("verify_concurrency must not be negative"))
+	}
+
 	if options.Concurrency <= 0 {
 		defaultConcurrency := fallbackJobConcurrency
 		if jm.workerPool != nil && jm.workerPool.maxWorkers > 0 {
@@ -386,16 +652,144 @@ func (jm *JobManager) CreateJob(ctx context.Context, options *JobOptions) (*Job,
 		options.Concurrency = defaultConcurrency
 	}
 
-	// Handle any existing active jobs for the same domain and user/organisation
-	if err := jm.handleExistingJobs(ctx, normalisedDomain, options.UserID, options.OrganisationID); err != nil {
-		return nil, fmt.Errorf("failed to handle existing jobs: %w", err)
+	if options.ConcurrencySchedule != nil {
+		if err := options.ConcurrencySchedule.Validate(options.Concurrency); err != nil {
+			return nil, invalidJobOptions(err)
+		}
+	}
+
+	if err := ValidateCacheableStatusCodes(options.CacheableStatusCodes); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	if !options.uncappedWarmList {
+		if err := ValidateWarmURLs(options.WarmURLs); err != nil {
+			return nil, invalidJobOptions(err)
+		}
+	}
+
+	contentTypes, err := jobContentTypes(options)
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.ContentTypes = contentTypes
+
+	tags, err := NormaliseTags(options.Tags)
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.Tags = tags
+
+	if len(options.SeedURLs) > 0 {
+		paths, err := SeedURLPaths(options.SeedURLs, normalisedDomain)
+		if err != nil {
+			return nil, invalidJobOptions(err)
+		}
+		options.SeedURLs = paths
+	}
+
+	tier, err := ParsePriorityTier(string(options.PriorityTier))
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.PriorityTier = tier
+
+	policy, err := ParseSlowOriginPolicy(string(options.SlowOriginPolicy))
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.SlowOriginPolicy = policy
+
+	scheme, err := ParseURLScheme(string(options.URLScheme))
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.URLScheme = scheme
+
+	method, err := ParseWarmMethod(string(options.Method))
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.Method = method
+	if method == WarmMethodHead {
+		// HEAD responses have no body to find links in
+		options.FindLinks = false
+	}
+
+	if err := ValidateUserAgent(options.UserAgent); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	if err := ValidateCustomHeaders(options.CustomHeaders); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	if err := ValidateNotifyWebhookURL(options.NotifyWebhookURL); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	if options.Credentials != nil && !options.Credentials.IsZero() {
+		if err := ValidateCredentials(*options.Credentials); err != nil {
+			return nil, invalidJobOptions(err)
+		}
+	}
+
+	if options.FreshnessWindowDays != nil {
+		if err := ValidateFreshnessWindowDays(*options.FreshnessWindowDays); err != nil {
+			return nil, invalidJobOptions(err)
+		}
+	}
+
+	if err := ValidateCrawlDelayBounds(options.MinCrawlDelaySeconds, options.MaxCrawlDelaySeconds); err != nil {
+		return nil, invalidJobOptions(err)
 	}
 
-	// Create a new job object
-	job := createJobObject(options, normalisedDomain)
+	if err := ValidateMaxRuntimeMinutes(options.MaxRuntimeMinutes); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	if err := ValidateMaxDepth(options.MaxDepth); err != nil {
+		return nil, invalidJobOptions(err)
+	}
+
+	runtimeAction, err := ParseMaxRuntimeAction(string(options.MaxRuntimeAction))
+	if err != nil {
+		return nil, invalidJobOptions(err)
+	}
+	options.MaxRuntimeAction = runtimeAction
+
+	if err := jm.checkWriteCapacity(); err != nil {
+		return nil, err
+	}
+
+	if err := jm.checkDomainVerified(ctx, options.OrganisationID, normalisedDomain); err != nil {
+		return nil, err
+	}
 
-	// Setup database records for the job
-	domainID, err := jm.setupJobDatabase(ctx, job, normalisedDomain)
+	var job *Job
+	var domainID int
+	for attempt := 0; ; attempt++ {
+		// Handle any existing active jobs for the same domain and user/organisation
+		if existing, err := jm.handleExistingJobs(ctx, normalisedDomain, options); err != nil || existing != nil {
+			return existing, err
+		}
+
+		// Create a new job object
+		job = createJobObject(options, normalisedDomain)
+
+		// Setup database records for the job. A concurrent request can insert
+		// an active job for the domain between the check and the insert; the
+		// unique index rejects ours, and the duplicate policy is applied once
+		// more against the job that won.
+		domainID, err = jm.setupJobDatabase(ctx, job, normalisedDomain, options.Credentials)
+		if isActiveJobConflict(err) {
+			if attempt == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("%w (created concurrently)", ErrDuplicateJob)
+		}
+		break
+	}
 	if err != nil {
 		span.SetTag("error", "true")
 		span.SetData("error.message", err.Error())
@@ -409,8 +803,11 @@ func (jm *JobManager) CreateJob(ctx context.Context, options *JobOptions) (*Job,
 		Bool("use_sitemap", options.UseSitemap).
 		Bool("find_links", options.FindLinks).
 		Int("max_pages", options.MaxPages).
+		Bool("dry_run", options.DryRun).
 		Msg("Created new job")
 
+	jm.workerPool.publishJobEvent(job.ID, events.JobCreated)
+
 	// Setup URL discovery (sitemap or manual root URL)
 	if err := jm.setupJobURLDiscovery(ctx, job, options, domainID, normalisedDomain); err != nil {
 		span.SetTag("error", "true")
@@ -518,6 +915,10 @@ func (jm *JobManager) CancelJob(ctx context.Context, jobID string) error {
 
 	span.SetTag("job_id", jobID)
 
+	if err := jm.checkWriteCapacity(); err != nil {
+		return err
+	}
+
 	// Get the job using our new method
 	job, err := jm.GetJob(ctx, jobID)
 	if err != nil {
@@ -528,8 +929,8 @@ func (jm *JobManager) CancelJob(ctx context.Context, jobID string) error {
 	}
 
 	// Check if job can be canceled
-	if job.Status != JobStatusRunning && job.Status != JobStatusPending && job.Status != JobStatusPaused {
-		return fmt.Errorf("job cannot be canceled: %s", job.Status)
+	if job.Status != JobStatusRunning && job.Status != JobStatusPending && job.Status != JobStatusInitialising && job.Status != JobStatusPaused {
+		return fmt.Errorf("%w: %s", ErrJobNotCancellable, job.Status)
 	}
 
 	// Update job status to cancelled
@@ -570,6 +971,7 @@ func (jm *JobManager) CancelJob(ctx context.Context, jobID string) error {
 	// Remove job from worker pool
 	if jm.workerPool != nil {
 		jm.workerPool.RemoveJob(job.ID)
+		jm.workerPool.notifyJobWebhook(job.ID)
 	}
 
 	// Clear processed pages for this job
@@ -591,7 +993,8 @@ func (jm *JobManager) GetJob(ctx context.Context, jobID string) (*Job, error) {
 	span.SetTag("job_id", jobID)
 
 	var job Job
-	var includePaths, excludePaths []byte
+	var includePaths, excludePaths, concurrencySchedule, customHeaders, tags []byte
+	var cacheableStatusCodes []int64
 	var startedAt, completedAt sql.NullTime
 	var errorMessage, userID, organisationID sql.NullString
 
@@ -601,10 +1004,18 @@ func (jm *JobManager) GetJob(ctx context.Context, jobID string) (*Job, error) {
 		err := tx.QueryRowContext(ctx, `
 			SELECT
 				j.id, d.name, j.status, j.progress, j.total_tasks, j.completed_tasks, j.failed_tasks, j.skipped_tasks,
-				j.created_at, j.started_at, j.completed_at, j.concurrency, j.find_links,
+				j.created_at, j.started_at, j.completed_at, j.concurrency - j.verify_concurrency, j.find_links,
 				j.include_paths, j.exclude_paths, j.error_message, j.required_workers,
 				j.found_tasks, j.sitemap_tasks, j.duration_seconds, j.avg_time_per_task_seconds,
-				j.user_id, j.organisation_id
+				j.user_id, j.organisation_id, j.max_retries, j.verify_concurrency,
+				j.concurrency_schedule, j.cacheable_status_codes, j.changed_only, j.priority_tier,
+				j.slow_origin_policy, COALESCE(j.user_agent, ''), j.conditional_warm,
+				COALESCE(j.notify_webhook_url, ''), j.purge_before_warm, COALESCE(j.warning_message, ''),
+				j.task_timeout_seconds, j.dry_run, j.credentials_secret_name IS NOT NULL, j.method,
+				j.group_subdomains, j.second_request, j.min_crawl_delay_seconds, j.max_crawl_delay_seconds,
+				j.prioritise_by_search, j.max_runtime_minutes, j.max_runtime_action, j.custom_headers,
+				j.detect_soft_404, j.hash_content, j.max_depth, j.auto_concurrency, j.skip_cached_urls,
+				j.content_types, j.tags, j.retry_budget, j.url_scheme, j.full_body_detection
 			FROM jobs j
 			JOIN domains d ON j.domain_id = d.id
 			WHERE j.id = $1
@@ -613,7 +1024,15 @@ func (jm *JobManager) GetJob(ctx context.Context, jobID string) (*Job, error) {
 			&job.FailedTasks, &job.SkippedTasks, &job.CreatedAt, &startedAt, &completedAt, &job.Concurrency,
 			&job.FindLinks, &includePaths, &excludePaths, &errorMessage, &job.RequiredWorkers,
 			&job.FoundTasks, &job.SitemapTasks, &job.DurationSeconds, &job.AvgTimePerTaskSeconds,
-			&userID, &organisationID,
+			&userID, &organisationID, &job.MaxRetries, &job.VerifyConcurrency,
+			&concurrencySchedule, pq.Array(&cacheableStatusCodes), &job.ChangedOnly, &job.PriorityTier,
+			&job.SlowOriginPolicy, &job.UserAgent, &job.ConditionalWarm,
+			&job.NotifyWebhookURL, &job.PurgeBeforeWarm, &job.WarningMessage,
+			&job.TaskTimeoutSeconds, &job.DryRun, &job.HasCredentials, &job.Method,
+			&job.GroupSubdomains, &job.SecondRequest, &job.MinCrawlDelaySeconds, &job.MaxCrawlDelaySeconds,
+			&job.PrioritiseBySearch, &job.MaxRuntimeMinutes, &job.MaxRuntimeAction, &customHeaders,
+			&job.DetectSoft404, &job.HashContent, &job.MaxDepth, &job.AutoConcurrency, &job.SkipCachedURLs,
+			pq.Array(&job.ContentTypes), &tags, &job.RetryBudget, &job.URLScheme, &job.FullBodyDetection,
 		)
 		return err
 	})
@@ -662,6 +1081,20 @@ func (jm *JobManager) GetJob(ctx context.Context, jobID string) (*Job, error) {
 		}
 	}
 
+	job.ConcurrencySchedule, err = parseConcurrencySchedule(concurrencySchedule, job.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+	job.CacheableStatusCodes = statusCodesFromInt64(cacheableStatusCodes)
+	if job.CustomHeaders, err = parseCustomHeaders(customHeaders); err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		if err := json.Unmarshal(tags, &job.Tags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
+	}
+
 	return &job, nil
 }
 
@@ -688,17 +1121,15 @@ func (jm *JobManager) GetJobStatus(ctx context.Context, jobID string) (*Job, err
 	return job, nil
 }
 
-// discoverAndParseSitemaps discovers and parses all sitemaps for a domain
-func (jm *JobManager) discoverAndParseSitemaps(ctx context.Context, domain string) ([]string, *crawler.RobotsRules, error) {
+// discoverAndParseSitemaps discovers and parses all sitemaps for a domain,
+// reporting which sitemaps failed to load after retries
+func (jm *JobManager) discoverAndParseSitemaps(ctx context.Context, domain string) ([]crawler.SitemapURL, *crawler.RobotsRules, sitemapLoadReport, error) {
 	// Use the injected crawler if available, otherwise create a new one
 	var sitemapCrawler CrawlerInterface
 	if jm.crawler != nil {
 		sitemapCrawler = jm.crawler
 	} else {
-		// Create a crawler config that allows skipping already cached URLs
-		crawlerConfig := crawler.DefaultConfig()
-		crawlerConfig.SkipCachedURLs = false
-		sitemapCrawler = crawler.New(crawlerConfig)
+		sitemapCrawler = crawler.New(crawler.DefaultConfig())
 	}
 
 	// Discover sitemaps and robots.txt rules for the domain
@@ -708,7 +1139,7 @@ func (jm *JobManager) discoverAndParseSitemaps(ctx context.Context, domain strin
 			Err(err).
 			Str("domain", domain).
 			Msg("Failed to discover sitemaps and robots rules")
-		return []string{}, &crawler.RobotsRules{}, err
+		return []crawler.SitemapURL{}, &crawler.RobotsRules{}, sitemapLoadReport{}, err
 	}
 
 	sitemaps := discoveryResult.Sitemaps
@@ -720,31 +1151,18 @@ func (jm *JobManager) discoverAndParseSitemaps(ctx context.Context, domain strin
 		Int("sitemap_count", len(sitemaps)).
 		Msg("Sitemaps discovered")
 
-	// Process each sitemap to extract URLs
-	var urls []string
-	for _, sitemapURL := range sitemaps {
-		log.Info().
-			Str("sitemap_url", sitemapURL).
-			Msg("Processing sitemap")
-
-		sitemapURLs, err := sitemapCrawler.ParseSitemap(ctx, sitemapURL)
-		if err != nil {
-			log.Warn().
-				Err(err).
-				Str("sitemap_url", sitemapURL).
-				Msg("Error parsing sitemap")
-			continue
-		}
-
-		log.Info().
-			Str("sitemap_url", sitemapURL).
-			Int("url_count", len(sitemapURLs)).
-			Msg("Parsed URLs from sitemap")
-
-		urls = append(urls, sitemapURLs...)
+	// Process each sitemap to extract URLs, noting any that fail to load
+	urls, report := parseSitemaps(ctx, sitemapCrawler, sitemaps)
+	report.Found = sitemaps
+	if len(report.Failed) > 0 {
+		log.Warn().
+			Str("domain", domain).
+			Int("failed_sitemaps", len(report.Failed)).
+			Int("total_sitemaps", report.Total()).
+			Msg("Some sitemaps failed to load")
 	}
 
-	return urls, robotsRules, nil
+	return urls, robotsRules, report, nil
 }
 
 // filterURLsAgainstRobots filters URLs against robots.txt rules and path patterns
@@ -785,8 +1203,9 @@ func (jm *JobManager) filterURLsAgainstRobots(urls []string, robotsRules *crawle
 	return filteredURLs
 }
 
-// enqueueURLsForJob creates page records and enqueues URLs for a job
-func (jm *JobManager) enqueueURLsForJob(ctx context.Context, jobID, domain string, urls []string, sourceType string) error {
+// enqueueURLsForJob creates page records and enqueues URLs for a job. A nil
+// priorities gives every non-homepage URL the default sitemap priority.
+func (jm *JobManager) enqueueURLsForJob(ctx context.Context, jobID, domain string, urls []string, sourceType string, priorities *sitemapPriority) error {
 	if len(urls) == 0 {
 		return nil
 	}
@@ -814,7 +1233,7 @@ func (jm *JobManager) enqueueURLsForJob(ctx context.Context, jobID, domain strin
 		pagesWithPriority[i] = db.Page{
 			ID:       pageID,
 			Path:     paths[i],
-			Priority: 0.1, // Default sitemap priority
+			Priority: priorities.priority(paths[i]), // Default sitemap priority unless fresh or hinted
 		}
 		// Set homepage priority to 1.000
 		if paths[i] == "/" {
@@ -911,7 +1330,8 @@ func (jm *JobManager) ValidateStatusTransition(from, to JobStatus) error {
 	// Normal forward transitions
 	validTransitions := map[JobStatus][]JobStatus{
 		JobStatusPending:   {JobStatusRunning, JobStatusCancelled},
-		JobStatusRunning:   {JobStatusCompleted, JobStatusFailed, JobStatusCancelled},
+		JobStatusRunning:   {JobStatusPaused, JobStatusCompleted, JobStatusFailed, JobStatusCancelled},
+		JobStatusPaused:    {JobStatusRunning, JobStatusCancelled},
 		JobStatusCompleted: {JobStatusRunning}, // Restart
 		JobStatusFailed:    {JobStatusRunning}, // Retry
 		JobStatusCancelled: {JobStatusRunning}, // Restart
@@ -977,7 +1397,7 @@ func (jm *JobManager) enqueueFallbackURL(ctx context.Context, jobID, domain stri
 	rootURL := fmt.Sprintf("https://%s/", domain)
 	fallbackURLs := []string{rootURL}
 
-	if err := jm.enqueueURLsForJob(ctx, jobID, domain, fallbackURLs, "fallback"); err != nil {
+	if err := jm.enqueueURLsForJob(ctx, jobID, domain, fallbackURLs, "fallback", nil); err != nil {
 		log.Error().
 			Err(err).
 			Str("job_id", jobID).
@@ -998,7 +1418,7 @@ func (jm *JobManager) enqueueFallbackURL(ctx context.Context, jobID, domain stri
 }
 
 // enqueueSitemapURLs enqueues discovered sitemap URLs for processing
-func (jm *JobManager) enqueueSitemapURLs(ctx context.Context, jobID, domain string, urls []string) error {
+func (jm *JobManager) enqueueSitemapURLs(ctx context.Context, jobID, domain string, urls []string, priorities *sitemapPriority) error {
 	// Log URLs for debugging
 	for i, url := range urls {
 		log.Debug().
@@ -1009,7 +1429,7 @@ func (jm *JobManager) enqueueSitemapURLs(ctx context.Context, jobID, domain stri
 			Msg("URL from sitemap")
 	}
 
-	if err := jm.enqueueURLsForJob(ctx, jobID, domain, urls, "sitemap"); err != nil {
+	if err := jm.enqueueURLsForJob(ctx, jobID, domain, urls, "sitemap", priorities); err != nil {
 		log.Error().
 			Err(err).
 			Str("job_id", jobID).
@@ -1022,7 +1442,7 @@ func (jm *JobManager) enqueueSitemapURLs(ctx context.Context, jobID, domain stri
 }
 
 // processSitemap fetches and processes a sitemap for a domain
-func (jm *JobManager) processSitemap(ctx context.Context, jobID, domain string, includePaths, excludePaths []string) {
+func (jm *JobManager) processSitemap(ctx context.Context, jobID, domain string, includePaths, excludePaths []string, freshnessWindowDays int, purgeFirst bool) {
 	// Guard against nil dependencies (e.g., in test environments)
 	if jm.crawler == nil || jm.dbQueue == nil || jm.db == nil {
 		log.Warn().
@@ -1043,8 +1463,9 @@ func (jm *JobManager) processSitemap(ctx context.Context, jobID, domain string,
 		Str("domain", domain).
 		Msg("Starting sitemap processing")
 
-	// Step 1: Discover and parse sitemaps
-	urls, robotsRules, err := jm.discoverAndParseSitemaps(ctx, domain)
+	// Step 1: Discover and parse sitemaps, reusing any unchanged since the
+	// domain's last job
+	entries, robotsRules, report, err := jm.discoverAndParseSitemaps(jm.withSitemapCache(ctx, domain), domain)
 	if err != nil {
 		span.SetTag("error", "true")
 		span.SetData("error.message", err.Error())
@@ -1058,16 +1479,27 @@ func (jm *JobManager) processSitemap(ctx context.Context, jobID, domain string,
 		return
 	}
 
+	jm.recordSitemapLoadReport(ctx, jobID, report)
+
 	// Step 2: Update domain crawl delay if present
 	jm.updateDomainCrawlDelay(ctx, domain, robotsRules.CrawlDelay)
 
 	// Step 3: Filter URLs against robots.txt and path patterns
-	urls = jm.filterURLsAgainstRobots(urls, robotsRules, includePaths, excludePaths)
+	urls := jm.filterURLsAgainstRobots(crawler.SitemapURLStrings(entries), robotsRules, includePaths, excludePaths)
+	priorities := newSitemapPriority(
+		newSitemapFreshness(entries, domain, freshnessWindowDays, time.Now().UTC()),
+		newSitemapHints(entries, domain),
+	)
+
+	// Step 4: Purge the CDN so warming repopulates it with fresh content
+	if purgeFirst {
+		jm.purgeBeforeWarm(ctx, jobID, urls)
+	}
 
-	// Step 4: Enqueue URLs in batches or create fallback
+	// Step 5: Enqueue URLs in batches or create fallback
 	if len(urls) > 0 {
 		// Process URLs in batches to avoid database timeouts on large sitemaps
-		const batchSize = 1000
+		const batchSize = sitemapEnqueueBatchSize
 		totalBatches := (len(urls) + batchSize - 1) / batchSize
 
 		log.Info().
@@ -1082,7 +1514,7 @@ func (jm *JobManager) processSitemap(ctx context.Context, jobID, domain string,
 			batch := urls[i:end]
 			batchNum := (i / batchSize) + 1
 
-			if err := jm.enqueueSitemapURLs(ctx, jobID, domain, batch); err != nil {
+			if err := jm.enqueueSitemapURLs(ctx, jobID, domain, batch, priorities); err != nil {
 				log.Warn().
 					Err(err).
 					Str("job_id", jobID).
@@ -1112,4 +1544,16 @@ func (jm *JobManager) processSitemap(ctx context.Context, jobID, domain string,
 	if jm.workerPool != nil {
 		jm.workerPool.NotifyNewTasks()
 	}
+
+	// Step 6: Give sitemaps that failed to load one more chance
+	jm.scheduleFailedSitemapRetry(ctx, failedSitemapRetry{
+		JobID:               jobID,
+		Domain:              domain,
+		Report:              report,
+		RobotsRules:         robotsRules,
+		IncludePaths:        includePaths,
+		ExcludePaths:        excludePaths,
+		FreshnessWindowDays: freshnessWindowDays,
+		PurgeFirst:          purgeFirst,
+	})
 }
@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxSitemapDiscoveriesFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{name: "unset uses default", value: "", expected: defaultMaxSitemapDiscoveries},
+		{name: "valid value", value: "3", expected: 3},
+		{name: "zero ignored", value: "0", expected: defaultMaxSitemapDiscoveries},
+		{name: "non-numeric ignored", value: "lots", expected: defaultMaxSitemapDiscoveries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BBB_MAX_CONCURRENT_SITEMAP_DISCOVERIES", tt.value)
+			assert.Equal(t, tt.expected, maxSitemapDiscoveriesFromEnv())
+		})
+	}
+}
+
+func TestAcquireSitemapDiscoverySlotRespectsContext(t *testing.T) {
+	held := 0
+	for range cap(sitemapDiscoverySem) {
+		require.NoError(t, acquireSitemapDiscoverySlot(context.Background()))
+		held++
+	}
+	defer func() {
+		for range held {
+			releaseSitemapDiscoverySlot()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := acquireSitemapDiscoverySlot(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestValidateMaxRetries(t *testing.T) {
+	assert.NoError(t, ValidateMaxRetries(0))
+	assert.NoError(t, ValidateMaxRetries(MaxTaskRetries))
+	assert.NoError(t, ValidateMaxRetries(10))
+	assert.Error(t, ValidateMaxRetries(-1))
+	assert.Error(t, ValidateMaxRetries(11))
+}
+
+func TestClampTaskTimeoutSeconds(t *testing.T) {
+	assert.Equal(t, DefaultTaskTimeoutSeconds, ClampTaskTimeoutSeconds(0))
+	assert.Equal(t, DefaultTaskTimeoutSeconds, ClampTaskTimeoutSeconds(-5))
+	assert.Equal(t, MinTaskTimeoutSeconds, ClampTaskTimeoutSeconds(5))
+	assert.Equal(t, 300, ClampTaskTimeoutSeconds(300))
+	assert.Equal(t, MaxTaskTimeoutSeconds, ClampTaskTimeoutSeconds(3600))
+}
+
+func TestStaleTimeoutForJob(t *testing.T) {
+	wp := &WorkerPool{jobInfoCache: map[string]*JobInfo{
+		"quick": {TaskTimeout: 45 * time.Second},
+		"slow":  {TaskTimeout: 300 * time.Second},
+	}}
+
+	assert.Equal(t, TaskStaleTimeout, wp.staleTimeoutForJob("quick"))
+	assert.Equal(t, TaskStaleTimeout+180*time.Second, wp.staleTimeoutForJob("slow"))
+	assert.Equal(t, TaskStaleTimeout, wp.staleTimeoutForJob("uncached"))
+}
+
+func TestValidateUserAgent(t *testing.T) {
+	assert.NoError(t, ValidateUserAgent(""))
+	assert.NoError(t, ValidateUserAgent("ClientBot/2.0 (+https://example.com/bot)"))
+	assert.Error(t, ValidateUserAgent("Bot\r\nX-Injected: 1"))
+	assert.Error(t, ValidateUserAgent(" Bot"))
+	assert.Error(t, ValidateUserAgent(strings.Repeat("a", maxUserAgentLength+1)))
+}
+
+func TestValidateCustomHeaders(t *testing.T) {
+	assert.NoError(t, ValidateCustomHeaders(nil))
+	assert.NoError(t, ValidateCustomHeaders(map[string]string{"X-Warm-Request": "1", "X-Forwarded-Host": "www.example.com"}))
+	assert.ErrorContains(t, ValidateCustomHeaders(map[string]string{"host": "evil.com"}), "Host can't be overridden")
+	assert.ErrorContains(t, ValidateCustomHeaders(map[string]string{"user-agent": "Bot"}), "use user_agent instead")
+	assert.ErrorContains(t, ValidateCustomHeaders(map[string]string{"X-Tag": "a", "x-tag": "b"}), "more than once")
+	assert.Error(t, ValidateCustomHeaders(map[string]string{"Bad Name": "1"}))
+	assert.Error(t, ValidateCustomHeaders(map[string]string{"X-Tag": "1\r\nX-Injected: 1"}))
+	assert.Error(t, ValidateCustomHeaders(map[string]string{"X-Tag": strings.Repeat("a", maxCustomHeaderValueLength+1)}))
+
+	tooMany := make(map[string]string, maxCustomHeaders+1)
+	for i := range maxCustomHeaders + 1 {
+		tooMany[fmt.Sprintf("X-Tag-%d", i)] = "1"
+	}
+	assert.Error(t, ValidateCustomHeaders(tooMany))
+}
+
+func TestValidateCredentials(t *testing.T) {
+	assert.NoError(t, ValidateCredentials(crawler.Credentials{Username: "staging", Password: "secret"}))
+	assert.NoError(t, ValidateCredentials(crawler.Credentials{BearerToken: "token"}))
+	assert.Error(t, ValidateCredentials(crawler.Credentials{Username: "staging", BearerToken: "token"}))
+	assert.Error(t, ValidateCredentials(crawler.Credentials{Password: "secret"}))
+	assert.Error(t, ValidateCredentials(crawler.Credentials{Username: "a:b", Password: "secret"}))
+	assert.Error(t, ValidateCredentials(crawler.Credentials{BearerToken: "token\r\nX-Injected: 1"}))
+	assert.Error(t, ValidateCredentials(crawler.Credentials{BearerToken: strings.Repeat("a", maxCredentialLength+1)}))
+}
+
+func TestParseWarmMethod(t *testing.T) {
+	method, err := ParseWarmMethod("")
+	require.NoError(t, err)
+	assert.Equal(t, WarmMethodGet, method)
+
+	method, err = ParseWarmMethod(" head ")
+	require.NoError(t, err)
+	assert.Equal(t, WarmMethodHead, method)
+
+	_, err = ParseWarmMethod("POST")
+	assert.Error(t, err)
+}
+
+func TestMaxRetriesForJob(t *testing.T) {
+	wp := &WorkerPool{jobInfoCache: map[string]*JobInfo{
+		"fail-fast": {MaxRetries: 0},
+		"flaky":     {MaxRetries: 8},
+	}}
+
+	assert.Equal(t, 0, wp.maxRetriesForJob("fail-fast"))
+	assert.Equal(t, 8, wp.maxRetriesForJob("flaky"))
+	assert.Equal(t, MaxTaskRetries, wp.maxRetriesForJob("uncached"))
+
+	retries := 2
+	assert.Equal(t, 2, (&JobOptions{MaxRetries: &retries}).effectiveMaxRetries())
+	assert.Equal(t, MaxTaskRetries, (&JobOptions{}).effectiveMaxRetries())
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import "fmt"
+
+// MaxDepthLimit caps a job's link depth limit; deeper crawls should leave it
+// unset
+const MaxDepthLimit = 100
+
+// ValidateMaxDepth checks a job's link depth limit, where 0 means no limit
+func ValidateMaxDepth(depth int) error {
+	if depth < 0 || depth > MaxDepthLimit {
+		return fmt.Errorf("max_depth must be between 0 and %d, got %d", MaxDepthLimit, depth)
+	}
+	return nil
+}
+
+// linkDepthAllowed reports whether links at depth may be enqueued under the
+// job's limit
+func linkDepthAllowed(depth, maxDepth int) bool {
+	return maxDepth == 0 || depth <= maxDepth
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMaxDepth(t *testing.T) {
+	assert.NoError(t, ValidateMaxDepth(0))
+	assert.NoError(t, ValidateMaxDepth(3))
+	assert.NoError(t, ValidateMaxDepth(MaxDepthLimit))
+	assert.Error(t, ValidateMaxDepth(-1))
+	assert.Error(t, ValidateMaxDepth(MaxDepthLimit+1))
+}
+
+func TestProcessDiscoveredLinksRespectsMaxDepth(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskDepth   int
+		maxDepth    int
+		wantPersist bool
+	}{
+		{name: "no limit", taskDepth: 7, maxDepth: 0, wantPersist: true},
+		{name: "homepage links within limit", taskDepth: 0, maxDepth: 1, wantPersist: true},
+		{name: "links at the limit", taskDepth: 1, maxDepth: 2, wantPersist: true},
+		{name: "links beyond the limit", taskDepth: 2, maxDepth: 2, wantPersist: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			persistCalls := 0
+			wp := &WorkerPool{
+				dbQueue: &MockDbQueue{
+					ExecuteFunc: func(ctx context.Context, fn func(*sql.Tx) error) error {
+						persistCalls++
+						return errors.New("stop before persisting")
+					},
+				},
+				crawler: &MockCrawler{},
+				jobInfoCache: map[string]*JobInfo{
+					"job-1": {DomainID: 1, DomainName: "example.com", MaxDepth: tt.maxDepth},
+				},
+			}
+
+			task := &Task{
+				ID:            "task-1",
+				JobID:         "job-1",
+				DomainID:      1,
+				DomainName:    "example.com",
+				Path:          "/blog",
+				Depth:         tt.taskDepth,
+				PriorityScore: 0.5,
+				FindLinks:     true,
+			}
+			result := &crawler.CrawlResult{Links: map[string][]string{"body": {"https://example.com/blog/post"}}}
+
+			wp.processDiscoveredLinks(context.Background(), task, result, "https://example.com/blog")
+
+			assert.Equal(t, tt.wantPersist, persistCalls > 0)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/events"
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxRuntimeAction is the terminal state a job takes when it exceeds its max runtime
+type MaxRuntimeAction string
+
+const (
+	// MaxRuntimeActionFail marks the job failed (default)
+	MaxRuntimeActionFail MaxRuntimeAction = "fail"
+	// MaxRuntimeActionComplete marks the job completed with the pages warmed so far
+	MaxRuntimeActionComplete MaxRuntimeAction = "complete"
+)
+
+// MaxRuntimeMinutesLimit caps a job's max runtime at one week
+const MaxRuntimeMinutesLimit = 7 * 24 * 60
+
+// maxRuntimeBatchSize bounds how many overdue jobs one cleanup tick stops
+const maxRuntimeBatchSize = 50
+
+// ParseMaxRuntimeAction validates an action name; empty means fail
+func ParseMaxRuntimeAction(raw string) (MaxRuntimeAction, error) {
+	switch action := MaxRuntimeAction(strings.ToLower(strings.TrimSpace(raw))); action {
+	case "":
+		return MaxRuntimeActionFail, nil
+	case MaxRuntimeActionFail, MaxRuntimeActionComplete:
+		return action, nil
+	default:
+		return "", fmt.Errorf("max_runtime_action must be fail or complete, got %q", raw)
+	}
+}
+
+// ValidateMaxRuntimeMinutes checks a job's max runtime; 0 means no limit
+func ValidateMaxRuntimeMinutes(minutes int) error {
+	if minutes < 0 || minutes > MaxRuntimeMinutesLimit {
+		return fmt.Errorf("max_runtime_minutes must be between 0 and %d", MaxRuntimeMinutesLimit)
+	}
+	return nil
+}
+
+// overdueJob is a running job past its max runtime
+type overdueJob struct {
+	ID      string
+	Minutes int
+	Action  MaxRuntimeAction
+}
+
+// EnforceMaxRuntime stops jobs that have run longer than their
+// max_runtime_minutes, measured from when they started
+func (wp *WorkerPool) EnforceMaxRuntime(ctx context.Context) error {
+	span := sentry.StartSpan(ctx, "jobs.enforce_max_runtime")
+	defer span.Finish()
+
+	var overdue []overdueJob
+	err := wp.dbQueue.ExecuteMaintenance(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id, max_runtime_minutes, max_runtime_action
+			FROM jobs
+			WHERE status IN ($1, $2)
+				AND max_runtime_minutes > 0
+				AND COALESCE(started_at, created_at) < $3 - make_interval(mins => max_runtime_minutes)
+			ORDER BY COALESCE(started_at, created_at)
+			LIMIT $4
+		`, JobStatusPending, JobStatusRunning, time.Now().UTC(), maxRuntimeBatchSize)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var job overdueJob
+			if err := rows.Scan(&job.ID, &job.Minutes, &job.Action); err != nil {
+				return err
+			}
+			overdue = append(overdue, job)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		span.SetTag("error", "true")
+		span.SetData("error.message", err.Error())
+		return fmt.Errorf("failed to find jobs past their max runtime: %w", err)
+	}
+
+	for _, job := range overdue {
+		wp.stopJobAtMaxRuntime(ctx, job)
+	}
+	return nil
+}
+
+// stopJobAtMaxRuntime moves an overdue job to its configured terminal state
+// and skips its pending/waiting tasks, as markJobFailedDueToConsecutiveFailures
+// does. Tasks already in flight finish normally.
+func (wp *WorkerPool) stopJobAtMaxRuntime(ctx context.Context, job overdueJob) {
+	message := fmt.Sprintf("Job stopped after reaching its %d minute max runtime", job.Minutes)
+
+	// Completed jobs keep the note as a warning rather than an error
+	status, eventType := JobStatusFailed, events.JobFailed
+	errorMessage := sql.NullString{String: message, Valid: true}
+	var warningMessage sql.NullString
+	if job.Action == MaxRuntimeActionComplete {
+		status, eventType = JobStatusCompleted, events.JobCompleted
+		errorMessage, warningMessage = warningMessage, errorMessage
+	}
+
+	stopped := false
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET status = $1,
+				completed_at = COALESCE(completed_at, $2),
+				error_message = COALESCE($3, error_message),
+				warning_message = COALESCE($4, warning_message)
+			WHERE id = $5
+				AND status IN ($6, $7)
+		`, status, now, errorMessage, warningMessage, job.ID, JobStatusPending, JobStatusRunning)
+		if err != nil {
+			return fmt.Errorf("failed to update job status: %w", err)
+		}
+		if updated, _ := result.RowsAffected(); updated == 0 {
+			return nil // Finished or cancelled since it was selected
+		}
+		stopped = true
+
+		result, err = tx.ExecContext(ctx, `
+			UPDATE tasks
+			SET status = 'skipped',
+				completed_at = $1,
+				error = $2
+			WHERE job_id = $3
+				AND status IN ('pending', 'waiting')
+		`, now, "Job reached its max runtime", job.ID)
+		if err != nil {
+			return fmt.Errorf("failed to clean up orphaned tasks: %w", err)
+		}
+
+		skipped, _ := result.RowsAffected()
+		log.Warn().
+			Str("job_id", job.ID).
+			Int("max_runtime_minutes", job.Minutes).
+			Str("status", string(status)).
+			Int64("skipped_tasks", skipped).
+			Msg("Stopped job at its max runtime")
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("Failed to stop job at its max runtime")
+		return
+	}
+	if !stopped {
+		return
+	}
+
+	wp.RemoveJob(job.ID)
+	wp.publishJobEvent(job.ID, eventType)
+	wp.notifyJobWebhook(job.ID)
+	if status == JobStatusFailed {
+		wp.notifyJobFailedSlack(job.ID)
+	}
+}
@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaxRuntimeAction(t *testing.T) {
+	action, err := ParseMaxRuntimeAction("")
+	require.NoError(t, err)
+	assert.Equal(t, MaxRuntimeActionFail, action)
+
+	action, err = ParseMaxRuntimeAction(" Complete ")
+	require.NoError(t, err)
+	assert.Equal(t, MaxRuntimeActionComplete, action)
+
+	_, err = ParseMaxRuntimeAction("cancel")
+	assert.Error(t, err)
+}
+
+func TestValidateMaxRuntimeMinutes(t *testing.T) {
+	assert.NoError(t, ValidateMaxRuntimeMinutes(0))
+	assert.NoError(t, ValidateMaxRuntimeMinutes(MaxRuntimeMinutesLimit))
+	assert.Error(t, ValidateMaxRuntimeMinutes(-1))
+	assert.Error(t, ValidateMaxRuntimeMinutes(MaxRuntimeMinutesLimit+1))
+}
+
+func TestEnforceMaxRuntime(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	sqlQueue := &mockDbQueueWrapper{mockDB: mockDB}
+	wp := &WorkerPool{dbQueue: &MockDbQueue{ExecuteFunc: sqlQueue.Execute}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, max_runtime_minutes, max_runtime_action").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_runtime_minutes", "max_runtime_action"}).
+			AddRow("job-1", 60, "complete").
+			AddRow("job-2", 30, "fail"))
+	mock.ExpectCommit()
+
+	// Completed jobs record the note as a warning
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs").
+		WithArgs(JobStatusCompleted, sqlmock.AnyArg(), sql.NullString{},
+			sql.NullString{String: "Job stopped after reaching its 60 minute max runtime", Valid: true},
+			"job-1", JobStatusPending, JobStatusRunning).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE tasks").
+		WithArgs(sqlmock.AnyArg(), "Job reached its max runtime", "job-1").
+		WillReturnResult(sqlmock.NewResult(0, 12))
+	mock.ExpectCommit()
+
+	// A job that finished since it was selected is left alone
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs").
+		WithArgs(JobStatusFailed, sqlmock.AnyArg(),
+			sql.NullString{String: "Job stopped after reaching its 30 minute max runtime", Valid: true},
+			sql.NullString{}, "job-2", JobStatusPending, JobStatusRunning).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	require.NoError(t, wp.EnforceMaxRuntime(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
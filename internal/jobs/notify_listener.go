@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultNotifyReconnectBase = 5 * time.Second
+	defaultNotifyReconnectMax  = 60 * time.Second
+)
+
+// NotificationListenerStatus reports the state of the LISTEN/NOTIFY connection
+type NotificationListenerStatus struct {
+	Enabled            bool       `json:"enabled"`
+	Connected          bool       `json:"connected"`
+	LastConnectedAt    *time.Time `json:"last_connected_at,omitempty"`
+	LastDisconnectedAt *time.Time `json:"last_disconnected_at,omitempty"`
+	Disconnects        int64      `json:"disconnects"`
+	ReconnectAttempts  int        `json:"reconnect_attempts"` // Consecutive failed attempts since the last disconnect
+}
+
+func notifyReconnectBaseFromEnv() time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("BBB_NOTIFY_RECONNECT_BASE_SECONDS")); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			return time.Duration(parsed) * time.Second
+		}
+	}
+	return defaultNotifyReconnectBase
+}
+
+func notifyReconnectMaxFromEnv(base time.Duration) time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("BBB_NOTIFY_RECONNECT_MAX_SECONDS")); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			return max(time.Duration(parsed)*time.Second, base)
+		}
+	}
+	return max(defaultNotifyReconnectMax, base)
+}
+
+// NotificationListenerStatus returns a snapshot of the LISTEN/NOTIFY connection state
+func (wp *WorkerPool) NotificationListenerStatus() NotificationListenerStatus {
+	wp.notifyStatusMutex.RLock()
+	defer wp.notifyStatusMutex.RUnlock()
+	return wp.notifyStatus
+}
+
+func (wp *WorkerPool) markNotifyConnected() {
+	now := time.Now().UTC()
+	wp.notifyStatusMutex.Lock()
+	wp.notifyStatus.Connected = true
+	wp.notifyStatus.LastConnectedAt = &now
+	wp.notifyStatus.ReconnectAttempts = 0
+	wp.notifyStatusMutex.Unlock()
+}
+
+func (wp *WorkerPool) markNotifyDisconnected() {
+	now := time.Now().UTC()
+	wp.notifyStatusMutex.Lock()
+	wp.notifyStatus.Connected = false
+	wp.notifyStatus.LastDisconnectedAt = &now
+	wp.notifyStatus.Disconnects++
+	wp.notifyStatusMutex.Unlock()
+}
+
+func (wp *WorkerPool) markNotifyReconnectFailed() {
+	wp.notifyStatusMutex.Lock()
+	wp.notifyStatus.ReconnectAttempts++
+	wp.notifyStatusMutex.Unlock()
+}
+
+// NotificationListenerStatus reports the worker pool's LISTEN/NOTIFY state.
+// Returns a disabled status when no worker pool is attached.
+func (jm *JobManager) NotificationListenerStatus() NotificationListenerStatus {
+	if jm.workerPool == nil {
+		return NotificationListenerStatus{}
+	}
+	return jm.workerPool.NotificationListenerStatus()
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyReconnectBackoffFromEnv(t *testing.T) {
+	t.Setenv("BBB_NOTIFY_RECONNECT_BASE_SECONDS", "")
+	t.Setenv("BBB_NOTIFY_RECONNECT_MAX_SECONDS", "")
+	base := notifyReconnectBaseFromEnv()
+	assert.Equal(t, defaultNotifyReconnectBase, base)
+	assert.Equal(t, defaultNotifyReconnectMax, notifyReconnectMaxFromEnv(base))
+
+	t.Setenv("BBB_NOTIFY_RECONNECT_BASE_SECONDS", "2")
+	t.Setenv("BBB_NOTIFY_RECONNECT_MAX_SECONDS", "30")
+	base = notifyReconnectBaseFromEnv()
+	assert.Equal(t, 2*time.Second, base)
+	assert.Equal(t, 30*time.Second, notifyReconnectMaxFromEnv(base))
+
+	// Max never drops below the base delay
+	t.Setenv("BBB_NOTIFY_RECONNECT_BASE_SECONDS", "90")
+	base = notifyReconnectBaseFromEnv()
+	assert.Equal(t, 90*time.Second, notifyReconnectMaxFromEnv(base))
+
+	t.Setenv("BBB_NOTIFY_RECONNECT_BASE_SECONDS", "invalid")
+	assert.Equal(t, defaultNotifyReconnectBase, notifyReconnectBaseFromEnv())
+}
+
+func TestNotificationListenerStatusTransitions(t *testing.T) {
+	wp := &WorkerPool{}
+	wp.notifyStatus.Enabled = true
+
+	wp.markNotifyConnected()
+	status := wp.NotificationListenerStatus()
+	assert.True(t, status.Connected)
+	assert.NotNil(t, status.LastConnectedAt)
+
+	wp.markNotifyDisconnected()
+	wp.markNotifyReconnectFailed()
+	wp.markNotifyReconnectFailed()
+	status = wp.NotificationListenerStatus()
+	assert.False(t, status.Connected)
+	assert.NotNil(t, status.LastDisconnectedAt)
+	assert.Equal(t, int64(1), status.Disconnects)
+	assert.Equal(t, 2, status.ReconnectAttempts)
+
+	wp.markNotifyConnected()
+	status = wp.NotificationListenerStatus()
+	assert.True(t, status.Connected)
+	assert.Equal(t, 0, status.ReconnectAttempts)
+	assert.Equal(t, int64(1), status.Disconnects)
+}
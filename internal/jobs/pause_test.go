@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseAndResumeJob(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM jobs WHERE id = \\$1 FOR UPDATE").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("running"))
+	mock.ExpectExec("UPDATE jobs SET status = \\$1 WHERE id = \\$2").
+		WithArgs("paused", "job-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	require.NoError(t, jm.PauseJob(ctx, "job-1"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM jobs WHERE id = \\$1 FOR UPDATE").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("paused"))
+	mock.ExpectExec("UPDATE jobs SET status = \\$1 WHERE id = \\$2").
+		WithArgs("running", "job-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	require.NoError(t, jm.ResumeJob(ctx, "job-1"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPauseJobRejectsWrongStatus(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM jobs WHERE id = \\$1 FOR UPDATE").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("completed"))
+	mock.ExpectRollback()
+	assert.ErrorIs(t, jm.PauseJob(ctx, "job-1"), ErrJobNotRunning)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM jobs WHERE id = \\$1 FOR UPDATE").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("running"))
+	mock.ExpectRollback()
+	assert.ErrorIs(t, jm.ResumeJob(ctx, "job-1"), ErrJobNotPaused)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM jobs WHERE id = \\$1 FOR UPDATE").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	err = jm.PauseJob(ctx, "missing")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Contains(t, err.Error(), "not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimPendingTaskSkipsPausedJobs(t *testing.T) {
+	wp := &WorkerPool{
+		jobs:         map[string]bool{"job-1": true},
+		pausedJobs:   make(map[string]bool),
+		jobInfoCache: make(map[string]*JobInfo),
+	}
+
+	wp.PauseJob("job-1")
+
+	// With its only job paused the pool must not query for tasks at all;
+	// a nil dbQueue would panic if it did
+	task, err := wp.claimPendingTask(context.Background())
+	assert.Nil(t, task)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	wp.RemoveJob("job-1")
+	assert.False(t, wp.pausedJobs["job-1"], "removing a job clears its paused flag")
+}
+
+// busyDbQueue reports a saturated pool before any query runs
+type busyDbQueue struct {
+	mockDbQueueWrapper
+}
+
+func (q *busyDbQueue) CheckWriteCapacity() error {
+	return &db.PoolBusyError{RetryAfter: db.PoolBusyRetryAfter}
+}
+
+func TestJobWritesShedWhilePoolSaturated(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &busyDbQueue{mockDbQueueWrapper{mockDB: mockDB}}}
+	ctx := context.Background()
+
+	var busy *db.PoolBusyError
+	assert.ErrorAs(t, jm.PauseJob(ctx, "job-1"), &busy)
+	assert.ErrorIs(t, jm.CancelJob(ctx, "job-1"), db.ErrPoolSaturated)
+
+	_, err = jm.CreateJob(ctx, &JobOptions{Domain: "example.com", Concurrency: 5})
+	assert.ErrorIs(t, err, db.ErrPoolSaturated)
+
+	assert.NoError(t, mock.ExpectationsWereMet(), "no queries should run while the pool is saturated")
+}
@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"sort"
+	"time"
+)
+
+// JobScalingState is one job's performance scaling state in a PoolSnapshot
+type JobScalingState struct {
+	JobID                string     `json:"job_id"`
+	BoostWorkers         int        `json:"boost_workers"`
+	LatencyCap           int        `json:"latency_cap"`          // 0 when uncapped
+	ErrorCap             int        `json:"error_cap"`            // 0 when uncapped
+	AvgResponseTimeMs    int64      `json:"avg_response_time_ms"` // Over the recent tasks window
+	RecentTasks          int        `json:"recent_tasks"`         // Samples behind the average, up to 5
+	ConcurrencyBlocked   bool       `json:"concurrency_blocked"`  // Hit its concurrency cap within the cooldown
+	LastConcurrencyBlock *time.Time `json:"last_concurrency_block,omitempty"`
+	LastCheck            *time.Time `json:"last_check,omitempty"`
+}
+
+// PoolSnapshot is a point-in-time view of the worker pool's scaling state
+type PoolSnapshot struct {
+	CurrentWorkers         int               `json:"current_workers"`
+	MaxWorkers             int               `json:"max_workers"`
+	BaseWorkerCount        int               `json:"base_worker_count"`
+	WorkerConcurrency      int               `json:"worker_concurrency"`
+	IdleWorkers            int               `json:"idle_workers"`
+	IdleThreshold          int               `json:"idle_threshold"` // 0 when idle scale-down is disabled
+	ActiveJobs             int               `json:"active_jobs"`
+	TotalBoostWorkers      int               `json:"total_boost_workers"`
+	ConcurrencyBlockedJobs int               `json:"concurrency_blocked_jobs"`
+	Jobs                   []JobScalingState `json:"jobs"` // Largest boost first
+	GeneratedAt            time.Time         `json:"generated_at"`
+}
+
+// Snapshot returns the pool's scaling state. Each lock is taken on its own, so
+// counts are individually consistent but may straddle a scaling change.
+func (wp *WorkerPool) Snapshot() *PoolSnapshot {
+	now := time.Now().UTC()
+
+	wp.workersMutex.RLock()
+	snapshot := &PoolSnapshot{
+		CurrentWorkers:    wp.currentWorkers,
+		MaxWorkers:        wp.maxWorkers,
+		BaseWorkerCount:   wp.baseWorkerCount,
+		WorkerConcurrency: wp.workerConcurrency,
+		IdleThreshold:     wp.idleThreshold,
+		GeneratedAt:       now,
+	}
+	wp.workersMutex.RUnlock()
+
+	snapshot.ActiveJobs = wp.activeJobCount()
+
+	wp.idleWorkersMutex.RLock()
+	snapshot.IdleWorkers = len(wp.idleWorkers)
+	wp.idleWorkersMutex.RUnlock()
+
+	wp.perfMutex.RLock()
+	snapshot.Jobs = make([]JobScalingState, 0, len(wp.jobPerformance))
+	for jobID, perf := range wp.jobPerformance {
+		snapshot.Jobs = append(snapshot.Jobs, jobScalingState(jobID, perf, now))
+	}
+	wp.perfMutex.RUnlock()
+
+	for _, job := range snapshot.Jobs {
+		snapshot.TotalBoostWorkers += job.BoostWorkers
+		if job.ConcurrencyBlocked {
+			snapshot.ConcurrencyBlockedJobs++
+		}
+	}
+
+	sort.Slice(snapshot.Jobs, func(i, j int) bool {
+		if snapshot.Jobs[i].BoostWorkers != snapshot.Jobs[j].BoostWorkers {
+			return snapshot.Jobs[i].BoostWorkers > snapshot.Jobs[j].BoostWorkers
+		}
+		return snapshot.Jobs[i].JobID < snapshot.Jobs[j].JobID
+	})
+
+	return snapshot
+}
+
+// jobScalingState copies a job's performance record; callers hold perfMutex
+func jobScalingState(jobID string, perf *JobPerformance, now time.Time) JobScalingState {
+	state := JobScalingState{
+		JobID:        jobID,
+		BoostWorkers: perf.CurrentBoost,
+		LatencyCap:   perf.LatencyCap,
+		ErrorCap:     perf.ErrorCap,
+		RecentTasks:  len(perf.RecentTasks),
+	}
+
+	if len(perf.RecentTasks) > 0 {
+		var total int64
+		for _, rt := range perf.RecentTasks {
+			total += rt
+		}
+		state.AvgResponseTimeMs = total / int64(len(perf.RecentTasks))
+	}
+	if !perf.LastConcurrencyBlock.IsZero() {
+		blockedAt := perf.LastConcurrencyBlock.UTC()
+		state.LastConcurrencyBlock = &blockedAt
+		state.ConcurrencyBlocked = now.Sub(perf.LastConcurrencyBlock) < concurrencyBlockCooldown
+	}
+	if !perf.LastCheck.IsZero() {
+		checkedAt := perf.LastCheck.UTC()
+		state.LastCheck = &checkedAt
+	}
+	return state
+}
+
+// PoolSnapshot returns the worker pool's scaling state
+func (jm *JobManager) PoolSnapshot() (*PoolSnapshot, error) {
+	if jm.workerPool == nil {
+		return nil, ErrWorkerPoolUnavailable
+	}
+	return jm.workerPool.Snapshot(), nil
+}
+
+// WorkerPoolStarted reports whether the worker pool has finished starting.
+// Returns false when no worker pool is attached.
+func (jm *JobManager) WorkerPoolStarted() bool {
+	return jm.workerPool != nil && jm.workerPool.Started()
+}
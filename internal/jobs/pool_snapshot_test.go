@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPoolSnapshot(t *testing.T) {
+	now := time.Now()
+	wp := &WorkerPool{
+		currentWorkers:    12,
+		maxWorkers:        50,
+		baseWorkerCount:   5,
+		workerConcurrency: 10,
+		idleThreshold:     3,
+		jobs:              map[string]bool{"job-a": true, "job-b": true},
+		idleWorkers:       map[int]time.Time{4: now},
+		jobPerformance: map[string]*JobPerformance{
+			"job-a": {
+				RecentTasks:          []int64{1000, 2000, 3000},
+				CurrentBoost:         5,
+				LastCheck:            now,
+				LastConcurrencyBlock: now.Add(-5 * time.Second),
+			},
+			"job-b": {
+				CurrentBoost:         10,
+				LatencyCap:           4,
+				LastConcurrencyBlock: now.Add(-2 * concurrencyBlockCooldown),
+			},
+		},
+	}
+
+	snapshot := wp.Snapshot()
+
+	assert.Equal(t, 12, snapshot.CurrentWorkers)
+	assert.Equal(t, 50, snapshot.MaxWorkers)
+	assert.Equal(t, 5, snapshot.BaseWorkerCount)
+	assert.Equal(t, 10, snapshot.WorkerConcurrency)
+	assert.Equal(t, 1, snapshot.IdleWorkers)
+	assert.Equal(t, 3, snapshot.IdleThreshold)
+	assert.Equal(t, 2, snapshot.ActiveJobs)
+	assert.Equal(t, 15, snapshot.TotalBoostWorkers)
+	assert.Equal(t, 1, snapshot.ConcurrencyBlockedJobs)
+
+	require.Len(t, snapshot.Jobs, 2)
+	assert.Equal(t, "job-b", snapshot.Jobs[0].JobID, "largest boost should be listed first")
+	assert.Equal(t, 4, snapshot.Jobs[0].LatencyCap)
+	assert.False(t, snapshot.Jobs[0].ConcurrencyBlocked, "block outside the cooldown shouldn't count")
+	assert.Nil(t, snapshot.Jobs[0].LastCheck)
+
+	jobA := snapshot.Jobs[1]
+	assert.Equal(t, int64(2000), jobA.AvgResponseTimeMs)
+	assert.Equal(t, 3, jobA.RecentTasks)
+	assert.True(t, jobA.ConcurrencyBlocked)
+	assert.NotNil(t, jobA.LastConcurrencyBlock)
+	assert.NotNil(t, jobA.LastCheck)
+}
+
+func TestJobManagerPoolSnapshotWithoutWorkerPool(t *testing.T) {
+	jm := &JobManager{}
+	_, err := jm.PoolSnapshot()
+	assert.ErrorIs(t, err, ErrWorkerPoolUnavailable)
+}
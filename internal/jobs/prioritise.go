@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxPrioritisePaths bounds how many pages one priority override can name
+const MaxPrioritisePaths = 100
+
+// ErrJobNotPrioritisable is returned when reprioritising a job that has finished
+var ErrJobNotPrioritisable = errors.New("only pending, running or paused jobs can be reprioritised")
+
+// ValidatePriorityOverride checks a manual priority override names a few
+// page paths and a priority in the task priority range
+func ValidatePriorityOverride(paths []string, priority float64) error {
+	if len(paths) == 0 {
+		return errors.New("paths must list at least one page path")
+	}
+	if len(paths) > MaxPrioritisePaths {
+		return fmt.Errorf("paths supports at most %d values", MaxPrioritisePaths)
+	}
+	for _, path := range paths {
+		if len(path) == 0 || path[0] != '/' {
+			return fmt.Errorf("paths must start with /, got %q", path)
+		}
+	}
+	if priority < 0 || priority > 1 {
+		return fmt.Errorf("priority must be between 0 and 1, got %g", priority)
+	}
+	return nil
+}
+
+// PrioritiseTasks sets the priority of a job's pending and waiting tasks for
+// the given page paths, so support can push a critical page to the front of a
+// running job's queue. Unlike updateTaskPriorities this is a manual override:
+// it isn't throttled and can lower a priority as well as raise it. It returns
+// how many tasks were repriced.
+func (jm *JobManager) PrioritiseTasks(ctx context.Context, jobID string, paths []string, priority float64) (int, error) {
+	span := sentry.StartSpan(ctx, "manager.prioritise_tasks")
+	defer span.Finish()
+
+	span.SetTag("job_id", jobID)
+
+	if err := jm.checkWriteCapacity(); err != nil {
+		return 0, err
+	}
+
+	var repriced int64
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		var status string
+		var domainID int
+		if err := tx.QueryRowContext(ctx, `
+			SELECT status, domain_id FROM jobs WHERE id = $1
+		`, jobID).Scan(&status, &domainID); err != nil {
+			return err
+		}
+		switch JobStatus(status) {
+		case JobStatusPending, JobStatusRunning, JobStatusPaused:
+		default:
+			return fmt.Errorf("%w: job is %s", ErrJobNotPrioritisable, status)
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE tasks t
+			SET priority_score = $1
+			FROM pages p
+			WHERE t.page_id = p.id
+			AND t.job_id = $2
+			AND p.domain_id = $3
+			AND p.path = ANY($4)
+			AND t.status IN ('pending', 'waiting')
+		`, priority, jobID, domainID, pq.Array(paths))
+		if err != nil {
+			return err
+		}
+
+		repriced, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		span.SetTag("error", "true")
+		span.SetData("error.message", err.Error())
+		if errors.Is(err, ErrJobNotPrioritisable) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to prioritise tasks: %w", err)
+	}
+
+	if repriced > 0 && jm.workerPool != nil {
+		jm.workerPool.NotifyNewTasks()
+	}
+
+	log.Info().
+		Str("job_id", jobID).
+		Int("paths", len(paths)).
+		Int64("tasks_repriced", repriced).
+		Float64("priority", priority).
+		Msg("Manually prioritised tasks")
+
+	return int(repriced), nil
+}
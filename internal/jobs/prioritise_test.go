@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePriorityOverride(t *testing.T) {
+	assert.NoError(t, ValidatePriorityOverride([]string{"/launch", "/"}, 1))
+	assert.NoError(t, ValidatePriorityOverride([]string{"/launch"}, 0))
+	assert.Error(t, ValidatePriorityOverride(nil, 1))
+	assert.Error(t, ValidatePriorityOverride([]string{"launch"}, 1))
+	assert.Error(t, ValidatePriorityOverride([]string{"/launch"}, 1.5))
+	assert.Error(t, ValidatePriorityOverride([]string{"/launch"}, -0.1))
+	assert.Error(t, ValidatePriorityOverride(make([]string, MaxPrioritisePaths+1), 1))
+}
+
+func TestPrioritiseTasks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	paths := []string{"/launch", "/pricing"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, domain_id FROM jobs WHERE id = \\$1").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "domain_id"}).AddRow("running", 7))
+	mock.ExpectExec("UPDATE tasks t\\s+SET priority_score = \\$1").
+		WithArgs(0.9, "job-1", 7, pq.Array(paths)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repriced, err := jm.PrioritiseTasks(context.Background(), "job-1", paths, 0.9)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repriced)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPrioritiseTasksRejectsFinishedJob(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, domain_id FROM jobs WHERE id = \\$1").
+		WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "domain_id"}).AddRow("completed", 7))
+	mock.ExpectRollback()
+
+	_, err = jm.PrioritiseTasks(context.Background(), "job-1", []string{"/launch"}, 1)
+	assert.ErrorIs(t, err, ErrJobNotPrioritisable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
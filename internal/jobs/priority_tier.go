@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PriorityTier ranks jobs when workers are saturated. It's separate from task
+// priority scores, which order pages within a job.
+type PriorityTier string
+
+const (
+	PriorityTierHigh   PriorityTier = "high"
+	PriorityTierNormal PriorityTier = "normal"
+	PriorityTierLow    PriorityTier = "low"
+)
+
+// defaultHighPriorityReservePercent is the share of task capacity held back
+// for high-tier jobs while any are active
+const defaultHighPriorityReservePercent = 20
+
+// maxHighPriorityReservePercent leaves standard jobs some capacity
+const maxHighPriorityReservePercent = 90
+
+// ParsePriorityTier validates a tier name; empty means normal
+func ParsePriorityTier(raw string) (PriorityTier, error) {
+	switch tier := PriorityTier(strings.ToLower(strings.TrimSpace(raw))); tier {
+	case "":
+		return PriorityTierNormal, nil
+	case PriorityTierHigh, PriorityTierNormal, PriorityTierLow:
+		return tier, nil
+	default:
+		return "", fmt.Errorf("priority_tier must be one of high, normal or low, got %q", raw)
+	}
+}
+
+// rank orders tiers for claiming; lower claims first
+func (t PriorityTier) rank() int {
+	switch t {
+	case PriorityTierHigh:
+		return 0
+	case PriorityTierLow:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func highPriorityReservePercentFromEnv() int {
+	if raw := strings.TrimSpace(os.Getenv("BBB_HIGH_PRIORITY_RESERVE_PERCENT")); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed >= 0 {
+			return min(parsed, maxHighPriorityReservePercent)
+		}
+	}
+	return defaultHighPriorityReservePercent
+}
+
+// jobPriorityTier returns a cached job's tier, defaulting to normal
+func (wp *WorkerPool) jobPriorityTier(jobID string) PriorityTier {
+	wp.jobInfoMutex.RLock()
+	defer wp.jobInfoMutex.RUnlock()
+	if info, exists := wp.jobInfoCache[jobID]; exists && info.PriorityTier != "" {
+		return info.PriorityTier
+	}
+	return PriorityTierNormal
+}
+
+// sortJobsByPriorityTier orders job IDs high tier first. The sort is stable so
+// jobs within a tier keep the caller's (randomised map) order.
+func sortJobsByPriorityTier(jobIDs []string, infos map[string]*JobInfo) {
+	tierOf := func(jobID string) PriorityTier {
+		if info, exists := infos[jobID]; exists {
+			return info.PriorityTier
+		}
+		return PriorityTierNormal
+	}
+	slices.SortStableFunc(jobIDs, func(a, b string) int {
+		return tierOf(a).rank() - tierOf(b).rank()
+	})
+}
+
+// hasActiveHighPriorityJob reports whether any cached job is high tier
+func hasActiveHighPriorityJob(infos map[string]*JobInfo) bool {
+	for _, info := range infos {
+		if info.PriorityTier == PriorityTierHigh {
+			return true
+		}
+	}
+	return false
+}
+
+// standardTierAtCapacity reports whether normal and low tier jobs have used
+// all capacity not reserved for active high-tier jobs. The in-flight count is
+// approximate since claims run concurrently, which is fine for a soft reserve.
+func (wp *WorkerPool) standardTierAtCapacity(infos map[string]*JobInfo) bool {
+	if wp.highPriorityReservePercent <= 0 || !hasActiveHighPriorityJob(infos) {
+		return false
+	}
+
+	wp.workersMutex.RLock()
+	capacity := wp.currentWorkers * max(wp.workerConcurrency, 1)
+	wp.workersMutex.RUnlock()
+
+	reserved := int(math.Ceil(float64(capacity) * float64(wp.highPriorityReservePercent) / 100))
+	return wp.standardTierInFlight.Load() >= int64(capacity-reserved)
+}
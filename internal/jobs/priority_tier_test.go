@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriorityTier(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    PriorityTier
+		wantErr bool
+	}{
+		{"", PriorityTierNormal, false},
+		{"high", PriorityTierHigh, false},
+		{" LOW ", PriorityTierLow, false},
+		{"normal", PriorityTierNormal, false},
+		{"urgent", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePriorityTier(tt.raw)
+		if tt.wantErr {
+			assert.Error(t, err, tt.raw)
+			continue
+		}
+		require.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.want, got, tt.raw)
+	}
+}
+
+func TestSortJobsByPriorityTier(t *testing.T) {
+	infos := map[string]*JobInfo{
+		"low":     {PriorityTier: PriorityTierLow},
+		"normal":  {PriorityTier: PriorityTierNormal},
+		"high":    {PriorityTier: PriorityTierHigh},
+		"high-2":  {PriorityTier: PriorityTierHigh},
+		"default": {},
+	}
+	jobIDs := []string{"low", "normal", "high", "uncached", "high-2", "default"}
+
+	sortJobsByPriorityTier(jobIDs, infos)
+
+	assert.Equal(t, []string{"high", "high-2", "normal", "uncached", "default", "low"}, jobIDs)
+}
+
+func TestStandardTierAtCapacity(t *testing.T) {
+	wp := &WorkerPool{
+		currentWorkers:             5,
+		workerConcurrency:          4,
+		highPriorityReservePercent: 25,
+	}
+	standardOnly := map[string]*JobInfo{"a": {PriorityTier: PriorityTierNormal}}
+	withHigh := map[string]*JobInfo{
+		"a": {PriorityTier: PriorityTierNormal},
+		"b": {PriorityTier: PriorityTierHigh},
+	}
+
+	// 20 slots, 5 reserved while a high tier job is active
+	wp.standardTierInFlight.Store(15)
+	assert.True(t, wp.standardTierAtCapacity(withHigh))
+	assert.False(t, wp.standardTierAtCapacity(standardOnly), "no reserve without a high tier job")
+
+	wp.standardTierInFlight.Store(14)
+	assert.False(t, wp.standardTierAtCapacity(withHigh))
+
+	wp.highPriorityReservePercent = 0
+	wp.standardTierInFlight.Store(20)
+	assert.False(t, wp.standardTierAtCapacity(withHigh), "reserve disabled")
+}
+
+func TestCalculateConcurrencyTargetReservesHighTier(t *testing.T) {
+	wp := &WorkerPool{
+		jobs:                       map[string]bool{"standard": true, "high": true},
+		jobInfoCache:               map[string]*JobInfo{"standard": {Concurrency: 40}, "high": {Concurrency: 2, PriorityTier: PriorityTierHigh}},
+		baseWorkerCount:            1,
+		maxWorkers:                 100,
+		workerConcurrency:          1,
+		highPriorityReservePercent: 20,
+	}
+	withReserve := wp.calculateConcurrencyTarget()
+
+	wp.highPriorityReservePercent = 0
+	withoutReserve := wp.calculateConcurrencyTarget()
+
+	// Standard demand of 40 sized to 80% of the pool: 50 slots rather than 42
+	assert.Greater(t, withReserve, withoutReserve)
+}
@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/cdnpurge"
+	"github.com/rs/zerolog/log"
+)
+
+// purgeBeforeWarmTimeout bounds the pre-warm purge so a slow CDN API can't
+// hold up warming for long
+const purgeBeforeWarmTimeout = 5 * time.Minute
+
+// errNoCDNPurgeConnection is recorded when a job asks to purge first but its
+// organisation has no enabled CDN purge connection
+var errNoCDNPurgeConnection = errors.New("no CDN purge connection is configured")
+
+// purgeBeforeWarm purges a job's discovered URLs from the organisation's CDN
+// so warming repopulates it with fresh content. Failures are recorded as a job
+// warning and never stop the warm.
+func (jm *JobManager) purgeBeforeWarm(ctx context.Context, jobID string, urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, purgeBeforeWarmTimeout)
+	defer cancel()
+
+	if err := jm.runPurgeBeforeWarm(ctx, jobID, urls); err != nil {
+		log.Warn().Err(err).Str("job_id", jobID).Msg("CDN purge before warming failed, warming anyway")
+		jm.updateJobWarning(context.WithoutCancel(ctx), jobID, fmt.Sprintf("CDN purge before warming failed: %v", err))
+	}
+}
+
+func (jm *JobManager) runPurgeBeforeWarm(ctx context.Context, jobID string, urls []string) error {
+	cfg, err := loadCDNPurgeConfig(ctx, jm.dbQueue, jobID)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return errNoCDNPurgeConnection
+	}
+
+	purger, err := cdnpurge.New(*cfg)
+	if err != nil {
+		return err
+	}
+
+	// Sitemap order puts the most important pages first
+	totalURLs := len(urls)
+	if totalURLs > cdnPurgeMaxURLs {
+		log.Info().
+			Str("job_id", jobID).
+			Int("total_urls", totalURLs).
+			Int("max_urls", cdnPurgeMaxURLs).
+			Msg("Capping CDN purge before warming")
+		urls = urls[:cdnPurgeMaxURLs]
+	}
+
+	start := time.Now()
+	if err := purger.Purge(ctx, urls); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("job_id", jobID).
+		Str("provider", purger.Provider()).
+		Int("urls_purged", len(urls)).
+		Bool("soft_purge", cfg.SoftPurge).
+		Dur("duration", time.Since(start)).
+		Msg("Purged CDN content before warming")
+
+	if totalURLs > len(urls) {
+		jm.updateJobWarning(ctx, jobID, fmt.Sprintf("CDN purge before warming covered the first %d of %d URLs; the rest may still serve stale content", len(urls), totalURLs))
+	}
+
+	return nil
+}
+
+// updateJobWarning records a non-fatal problem on the job without failing it
+func (jm *JobManager) updateJobWarning(ctx context.Context, jobID, warning string) {
+	if updateErr := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET warning_message = $1
+			WHERE id = $2
+		`, warning, jobID)
+		return err
+	}); updateErr != nil {
+		log.Error().Err(updateErr).Str("job_id", jobID).Msg("Failed to update job with warning message")
+	}
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeBeforeWarmWithoutConnectionRecordsWarning(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM jobs j\\s+JOIN cdn_purge_connections").
+		WithArgs("job-1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE jobs\\s+SET warning_message = \\$1").
+		WithArgs("CDN purge before warming failed: "+errNoCDNPurgeConnection.Error(), "job-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	jm.purgeBeforeWarm(context.Background(), "job-1", []string{"https://example.com/"})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeBeforeWarmSkipsEmptyURLList(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+	jm.purgeBeforeWarm(context.Background(), "job-1", nil)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
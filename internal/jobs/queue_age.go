@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultQueueAgeThreshold = 30 * time.Minute
+	// queueAgeAlertInterval throttles the Sentry warning; the metric and log
+	// are still recorded every task monitor tick
+	queueAgeAlertInterval = 15 * time.Minute
+	// queueAgeSampleJobs bounds the jobs named in the warning
+	queueAgeSampleJobs = 10
+)
+
+// queueAgeThresholdFromEnv is how long a task may sit pending or waiting
+// before it's reported as never claimed. 0 turns the check off.
+func queueAgeThresholdFromEnv() time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("BBB_TASK_QUEUE_AGE_ALERT_MINUTES")); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed >= 0 {
+			return time.Duration(parsed) * time.Minute
+		}
+	}
+	return defaultQueueAgeThreshold
+}
+
+// queuedJobAge is one job's tasks queued past the threshold
+type queuedJobAge struct {
+	JobID    string    `json:"job_id"`
+	Tasks    int       `json:"tasks"`
+	OldestAt time.Time `json:"oldest_at"`
+}
+
+// Tasks held back by the daily quota are expected to wait, so their jobs are
+// left out; the quota is checked once per running job rather than per task.
+// Time the job spent blocked by its own concurrency limit since the task was
+// queued is expected too, so a task's age runs from when it could have been
+// claimed: its queue time pushed back by that blocked time, or its last
+// attempt for a retry. Largest first so the sample names the worst-starved
+// jobs.
+const queuedTaskAgeQuery = `
+	WITH running_jobs AS MATERIALIZED (
+		SELECT j.id,
+		       j.concurrency_blocked_ms + COALESCE(
+		           (EXTRACT(EPOCH FROM (NOW() - j.concurrency_blocked_since)) * 1000)::bigint, 0) AS blocked_ms
+		FROM jobs j
+		WHERE j.status = 'running'
+		  AND (j.organisation_id IS NULL OR NOT is_org_over_daily_quota(j.organisation_id))
+	),
+	queued AS (
+		SELECT t.job_id,
+		       GREATEST(
+		           t.created_at + GREATEST(rj.blocked_ms - t.concurrency_blocked_ms_mark, 0) * INTERVAL '1 millisecond',
+		           COALESCE(t.started_at, t.created_at)
+		       ) AS claimable_at
+		FROM tasks t
+		JOIN running_jobs rj ON rj.id = t.job_id
+		WHERE t.status IN ('pending', 'waiting')
+	)
+	SELECT job_id, COUNT(*), MIN(claimable_at)
+	FROM queued
+	WHERE claimable_at < NOW() - make_interval(secs => $1)
+	GROUP BY job_id
+	ORDER BY COUNT(*) DESC
+`
+
+// checkQueuedTaskAge reports tasks of running jobs that have sat pending or
+// waiting longer than queueAgeThreshold without being claimed. Unlike the
+// stuck-task check, which looks at tasks left running, this catches
+// starvation, e.g. high-concurrency jobs crowding out low-concurrency ones.
+func (wp *WorkerPool) checkQueuedTaskAge(ctx context.Context) error {
+	rows, err := wp.db.QueryContext(ctx, queuedTaskAgeQuery, wp.queueAgeThreshold.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to query task queue age: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []queuedJobAge
+	total := 0
+	var oldestAt time.Time
+	for rows.Next() {
+		var job queuedJobAge
+		if err := rows.Scan(&job.JobID, &job.Tasks, &job.OldestAt); err != nil {
+			return fmt.Errorf("failed to scan task queue age: %w", err)
+		}
+		total += job.Tasks
+		if oldestAt.IsZero() || job.OldestAt.Before(oldestAt) {
+			oldestAt = job.OldestAt
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read task queue age: %w", err)
+	}
+
+	var oldest time.Duration
+	if total > 0 {
+		oldest = time.Since(oldestAt)
+	}
+	observability.RecordQueuedTooLong(ctx, total, oldest)
+	if total == 0 {
+		return nil
+	}
+
+	sample := jobs[:min(queueAgeSampleJobs, len(jobs))]
+	sampleIDs := make([]string, len(sample))
+	for i, job := range sample {
+		sampleIDs[i] = job.JobID
+	}
+
+	log.Warn().
+		Int("total_tasks", total).
+		Int("total_jobs", len(jobs)).
+		Dur("threshold", wp.queueAgeThreshold).
+		Dur("oldest_queued", oldest).
+		Strs("sample_job_ids", sampleIDs).
+		Msg("Tasks queued without being claimed for longer than the alert threshold")
+
+	now := time.Now()
+	if !wp.queueAgeAlertedAt.IsZero() && now.Sub(wp.queueAgeAlertedAt) < queueAgeAlertInterval {
+		return nil
+	}
+	wp.queueAgeAlertedAt = now
+
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(sentry.LevelWarning)
+		scope.SetTag("event_type", "queued_too_long")
+		scope.SetContext("queued_too_long", map[string]any{
+			"total_tasks":      total,
+			"total_jobs":       len(jobs),
+			"threshold":        wp.queueAgeThreshold.String(),
+			"oldest_queued_at": oldestAt,
+			"sample_job_ids":   sampleIDs,
+			"sample_jobs":      sample,
+		})
+		sentry.CaptureMessage(fmt.Sprintf("Found %d tasks across %d jobs queued for over %s without being claimed",
+			total, len(jobs), wp.queueAgeThreshold))
+	})
+	return nil
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckQueuedTaskAgeThrottlesAlerts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB, queueAgeThreshold: 30 * time.Minute}
+	oldest := time.Now().Add(-2 * time.Hour)
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"job_id", "count", "min"}).
+			AddRow("job-1", 40, oldest).
+			AddRow("job-2", 3, oldest.Add(time.Hour))
+	}
+
+	mock.ExpectQuery("WITH running_jobs AS MATERIALIZED").
+		WithArgs(float64(30 * 60)).
+		WillReturnRows(rows())
+	require.NoError(t, wp.checkQueuedTaskAge(context.Background()))
+	alertedAt := wp.queueAgeAlertedAt
+	assert.False(t, alertedAt.IsZero())
+
+	// A second tick inside the alert interval doesn't warn again
+	mock.ExpectQuery("WITH running_jobs AS MATERIALIZED").WillReturnRows(rows())
+	require.NoError(t, wp.checkQueuedTaskAge(context.Background()))
+	assert.Equal(t, alertedAt, wp.queueAgeAlertedAt)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckQueuedTaskAgeNothingQueued(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB, queueAgeThreshold: time.Minute}
+
+	mock.ExpectQuery("WITH running_jobs AS MATERIALIZED").
+		WillReturnRows(sqlmock.NewRows([]string{"job_id", "count", "min"}))
+	require.NoError(t, wp.checkQueuedTaskAge(context.Background()))
+	assert.True(t, wp.queueAgeAlertedAt.IsZero())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueueAgeThresholdFromEnv(t *testing.T) {
+	t.Setenv("BBB_TASK_QUEUE_AGE_ALERT_MINUTES", "")
+	assert.Equal(t, defaultQueueAgeThreshold, queueAgeThresholdFromEnv())
+
+	t.Setenv("BBB_TASK_QUEUE_AGE_ALERT_MINUTES", "90")
+	assert.Equal(t, 90*time.Minute, queueAgeThresholdFromEnv())
+
+	t.Setenv("BBB_TASK_QUEUE_AGE_ALERT_MINUTES", "0")
+	assert.Equal(t, time.Duration(0), queueAgeThresholdFromEnv())
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainLimiterApplyRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	dl := newDomainLimiter(nil)
+	dl.now = func() time.Time { return now }
+	dl.cfg.MaxRetryAfter = 5 * time.Minute
+
+	assert.Equal(t, 2*time.Minute, dl.ApplyRetryAfter("example.com", 2*time.Minute))
+	assert.Equal(t, 2*time.Minute, dl.EstimatedWait("example.com"))
+
+	// A shorter request never cuts an existing pause short
+	dl.ApplyRetryAfter("example.com", 10*time.Second)
+	assert.Equal(t, 2*time.Minute, dl.EstimatedWait("example.com"))
+
+	// Long requests are capped
+	assert.Equal(t, 5*time.Minute, dl.ApplyRetryAfter("example.com", time.Hour))
+	assert.Equal(t, 5*time.Minute, dl.EstimatedWait("example.com"))
+
+	assert.Zero(t, dl.ApplyRetryAfter("", time.Minute))
+	assert.Zero(t, dl.EstimatedWait("other.com"))
+}
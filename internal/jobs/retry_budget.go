@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MaxJobRetryBudget bounds a job's total retry budget
+const MaxJobRetryBudget = 100000
+
+// ValidateRetryBudget checks a job's total retry budget; 0 means no cap
+func ValidateRetryBudget(budget int) error {
+	if budget < 0 || budget > MaxJobRetryBudget {
+		return fmt.Errorf("retry_budget must be between 0 and %d", MaxJobRetryBudget)
+	}
+	return nil
+}
+
+func (wp *WorkerPool) retryBudgetForJob(jobID string) int {
+	wp.jobInfoMutex.RLock()
+	defer wp.jobInfoMutex.RUnlock()
+
+	if info, exists := wp.jobInfoCache[jobID]; exists {
+		return info.RetryBudget
+	}
+	return 0
+}
+
+// takeRetry spends one retry from the job's budget, reporting whether the
+// task may be retried. Jobs without a budget always may. Once the budget is
+// spent the job is flagged with a warning and retryable failures become
+// permanent. Database errors allow the retry rather than failing the task.
+func (wp *WorkerPool) takeRetry(ctx context.Context, jobID string) bool {
+	budget := wp.retryBudgetForJob(jobID)
+	if budget <= 0 {
+		return true
+	}
+
+	var used int
+	err := wp.db.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET retries_used = retries_used + 1
+		WHERE id = $1 AND retries_used < retry_budget
+		RETURNING retries_used
+	`, jobID).Scan(&used)
+	if errors.Is(err, sql.ErrNoRows) {
+		wp.flagRetryBudgetExhausted(ctx, jobID, budget)
+		return false
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("job_id", jobID).Msg("Failed to spend retry budget, allowing retry")
+		return true
+	}
+	return true
+}
+
+// flagRetryBudgetExhausted records the spent budget as a job warning, once
+func (wp *WorkerPool) flagRetryBudgetExhausted(ctx context.Context, jobID string, budget int) {
+	warning := fmt.Sprintf("Retry budget of %d exhausted; further retryable failures were not retried", budget)
+
+	result, err := wp.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET warning_message = $1
+		WHERE id = $2 AND warning_message IS DISTINCT FROM $1
+	`, warning, jobID)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", jobID).Msg("Failed to flag exhausted retry budget")
+		return
+	}
+	if updated, _ := result.RowsAffected(); updated > 0 {
+		log.Warn().
+			Str("job_id", jobID).
+			Int("retry_budget", budget).
+			Msg("Job retry budget exhausted, failing retryable errors permanently")
+	}
+}
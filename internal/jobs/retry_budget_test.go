@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRetryBudget(t *testing.T) {
+	assert.NoError(t, ValidateRetryBudget(0))
+	assert.NoError(t, ValidateRetryBudget(MaxJobRetryBudget))
+	assert.Error(t, ValidateRetryBudget(-1))
+	assert.Error(t, ValidateRetryBudget(MaxJobRetryBudget+1))
+}
+
+func TestTakeRetry(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB, jobInfoCache: map[string]*JobInfo{
+		"capped":   {RetryBudget: 2},
+		"uncapped": {},
+	}}
+	ctx := context.Background()
+
+	// No budget: no database round trip
+	assert.True(t, wp.takeRetry(ctx, "uncapped"))
+
+	mock.ExpectQuery("UPDATE jobs").WithArgs("capped").
+		WillReturnRows(sqlmock.NewRows([]string{"retries_used"}).AddRow(2))
+	assert.True(t, wp.takeRetry(ctx, "capped"))
+
+	// Spent: the job is flagged and the retry refused
+	mock.ExpectQuery("UPDATE jobs").WithArgs("capped").
+		WillReturnRows(sqlmock.NewRows([]string{"retries_used"}))
+	mock.ExpectExec("UPDATE jobs").
+		WithArgs("Retry budget of 2 exhausted; further retryable failures were not retried", "capped").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.False(t, wp.takeRetry(ctx, "capped"))
+
+	// Database trouble lets the retry through
+	mock.ExpectQuery("UPDATE jobs").WithArgs("capped").WillReturnError(errors.New("connection reset"))
+	assert.True(t, wp.takeRetry(ctx, "capped"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/getsentry/sentry-go"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrSourceJobActive is returned when rewarming a job that hasn't finished
+	ErrSourceJobActive = errors.New("only completed, failed or cancelled jobs can be rewarmed")
+	// ErrNothingToRewarm is returned when no pages in the source job match
+	ErrNothingToRewarm = errors.New("no pages in the source job matched the rewarm filter")
+	// ErrEmptyRewarmFilter is returned when neither statuses nor cache statuses are given
+	ErrEmptyRewarmFilter = errors.New("rewarm needs at least one status code or cache status")
+)
+
+// CreateRewarmJob creates a job that warms only the pages whose task in the
+// source job ended with one of the given status codes or cache statuses,
+// e.g. transient 5xx errors after a large warm. Status code 0 matches tasks
+// that failed without a response. The new job reuses the source job's
+// settings and credentials and runs through the normal worker pipeline as a
+// warm list, without sitemap or link discovery.
+func (jm *JobManager) CreateRewarmJob(ctx context.Context, sourceJobID string, statuses []int, cacheStatuses []string) (*Job, error) {
+	span := sentry.StartSpan(ctx, "manager.create_rewarm_job")
+	defer span.Finish()
+
+	span.SetTag("source_job_id", sourceJobID)
+
+	if len(statuses) == 0 && len(cacheStatuses) == 0 {
+		return nil, ErrEmptyRewarmFilter
+	}
+
+	source, err := jm.GetJob(ctx, sourceJobID)
+	if err != nil {
+		return nil, err
+	}
+	switch source.Status {
+	case JobStatusCompleted, JobStatusFailed, JobStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: job is %s", ErrSourceJobActive, source.Status)
+	}
+
+	paths, err := jm.rewarmPaths(ctx, sourceJobID, statuses, cacheStatuses)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, ErrNothingToRewarm
+	}
+
+	var creds *crawler.Credentials
+	if source.HasCredentials {
+		loaded, err := jm.loadJobCredentials(ctx, sourceJobID)
+		if err != nil {
+			return nil, err
+		}
+		creds = &loaded
+	}
+
+	maxRetries := source.MaxRetries
+	sourceType := "rewarm"
+	sourceInfoBytes, _ := json.Marshal(map[string]any{
+		"source_job_id":  sourceJobID,
+		"status_codes":   statuses,
+		"cache_statuses": cacheStatuses,
+		"warm_urls":      len(paths),
+	})
+	sourceInfo := string(sourceInfoBytes)
+
+	job, err := jm.CreateJob(ctx, &JobOptions{
+		Domain:               source.Domain,
+		UserID:               source.UserID,
+		OrganisationID:       source.OrganisationID,
+		UseSitemap:           false,
+		FindLinks:            false,
+		Concurrency:          source.Concurrency,
+		VerifyConcurrency:    source.VerifyConcurrency,
+		RequiredWorkers:      source.RequiredWorkers,
+		MaxRetries:           &maxRetries,
+		TaskTimeoutSeconds:   source.TaskTimeoutSeconds,
+		ConcurrencySchedule:  source.ConcurrencySchedule,
+		CacheableStatusCodes: source.CacheableStatusCodes,
+		PriorityTier:         source.PriorityTier,
+		SlowOriginPolicy:     source.SlowOriginPolicy,
+		AutoConcurrency:      source.AutoConcurrency,
+		SkipCachedURLs:       source.SkipCachedURLs,
+		ContentTypes:         source.ContentTypes,
+		URLScheme:            source.URLScheme,
+		Tags:                 source.Tags,
+		RetryBudget:          source.RetryBudget,
+		UserAgent:            source.UserAgent,
+		CustomHeaders:        source.CustomHeaders,
+		DetectSoft404:        source.DetectSoft404,
+		HashContent:          source.HashContent,
+		FullBodyDetection:    source.FullBodyDetection,
+		NotifyWebhookURL:     source.NotifyWebhookURL,
+		Method:               source.Method,
+		GroupSubdomains:      source.GroupSubdomains,
+		SecondRequest:        source.SecondRequest,
+		MinCrawlDelaySeconds: source.MinCrawlDelaySeconds,
+		MaxCrawlDelaySeconds: source.MaxCrawlDelaySeconds,
+		PrioritiseBySearch:   source.PrioritiseBySearch,
+		MaxRuntimeMinutes:    source.MaxRuntimeMinutes,
+		MaxRuntimeAction:     source.MaxRuntimeAction,
+		Credentials:          creds,
+		WarmURLs:             paths,
+		uncappedWarmList:     true,
+		SourceType:           &sourceType,
+		SourceDetail:         &sourceJobID,
+		SourceInfo:           &sourceInfo,
+	})
+	if err != nil {
+		span.SetTag("error", "true")
+		span.SetData("error.message", err.Error())
+		return nil, err
+	}
+
+	log.Info().
+		Str("job_id", job.ID).
+		Str("source_job_id", sourceJobID).
+		Int("warm_urls", len(paths)).
+		Msg("Created rewarm job")
+
+	return job, nil
+}
+
+// rewarmPaths lists the paths of the source job's finished tasks that match
+// any of the status codes or cache statuses
+func (jm *JobManager) rewarmPaths(ctx context.Context, jobID string, statuses []int, cacheStatuses []string) ([]string, error) {
+	codes := make([]int64, len(statuses))
+	for i, status := range statuses {
+		codes[i] = int64(status)
+	}
+	normalised := make([]string, len(cacheStatuses))
+	for i, status := range cacheStatuses {
+		normalised[i] = strings.ToUpper(strings.TrimSpace(status))
+	}
+
+	var paths []string
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT DISTINCT p.path
+			FROM tasks t
+			JOIN pages p ON t.page_id = p.id
+			WHERE t.job_id = $1
+			  AND t.status IN ('completed', 'failed')
+			  AND (COALESCE(t.status_code, 0) = ANY($2) OR UPPER(t.cache_status) = ANY($3))
+			ORDER BY p.path
+		`, jobID, pq.Array(codes), pq.Array(normalised))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var path string
+			if err := rows.Scan(&path); err != nil {
+				return err
+			}
+			paths = append(paths, path)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pages to rewarm: %w", err)
+	}
+	return paths, nil
+}
+
+// loadJobCredentials reads a job's site credentials from Vault
+func (jm *JobManager) loadJobCredentials(ctx context.Context, jobID string) (crawler.Credentials, error) {
+	var raw sql.NullString
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `SELECT get_job_credentials($1)`, jobID).Scan(&raw)
+	})
+	if err != nil {
+		return crawler.Credentials{}, fmt.Errorf("failed to load job credentials: %w", err)
+	}
+
+	var creds crawler.Credentials
+	if raw.Valid && raw.String != "" {
+		if err := json.Unmarshal([]byte(raw.String), &creds); err != nil {
+			return crawler.Credentials{}, fmt.Errorf("failed to decode job credentials: %w", err)
+		}
+	}
+	return creds, nil
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewarmPaths(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT DISTINCT p.path").
+		WithArgs("job-1", pq.Array([]int64{502, 0}), pq.Array([]string{"MISS"})).
+		WillReturnRows(sqlmock.NewRows([]string{"path"}).AddRow("/about").AddRow("/pricing"))
+	mock.ExpectCommit()
+
+	paths, err := jm.rewarmPaths(context.Background(), "job-1", []int{502, 0}, []string{" miss "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/about", "/pricing"}, paths)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateRewarmJobValidation(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	jm := &JobManager{db: mockDB, dbQueue: &mockDbQueueWrapper{mockDB: mockDB}}
+
+	_, err = jm.CreateRewarmJob(context.Background(), "job-1", nil, nil)
+	assert.ErrorIs(t, err, ErrEmptyRewarmFilter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRewarmWarmListSkipsCap(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	// A saturated pool stops CreateJob straight after validation
+	jm := &JobManager{db: mockDB, dbQueue: &busyDbQueue{mockDbQueueWrapper{mockDB: mockDB}}}
+	ctx := context.Background()
+
+	paths := make([]string, MaxWarmURLs+1)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("/page-%d", i)
+	}
+
+	_, err = jm.CreateJob(ctx, &JobOptions{Domain: "example.com", Concurrency: 5, WarmURLs: paths})
+	assert.ErrorIs(t, err, ErrInvalidJobOptions)
+	assert.Contains(t, err.Error(), "warm list has 1001 unique URLs")
+
+	_, err = jm.CreateJob(ctx, &JobOptions{Domain: "example.com", Concurrency: 5, WarmURLs: paths, uncappedWarmList: true})
+	assert.ErrorIs(t, err, db.ErrPoolSaturated)
+}
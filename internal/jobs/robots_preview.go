@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+// maxRobotsPreviewExamples caps the example URLs reported per blocking pattern
+const maxRobotsPreviewExamples = 5
+
+// RobotsBlockingPattern summarises the sitemap URLs blocked by one Disallow rule
+type RobotsBlockingPattern struct {
+	Pattern     string   `json:"pattern"`
+	BlockedURLs int      `json:"blocked_urls"`
+	Examples    []string `json:"examples"`
+}
+
+// RobotsPreview reports how robots.txt and path filters would shape a job
+type RobotsPreview struct {
+	Domain           string                  `json:"domain"`
+	Sitemaps         []string                `json:"sitemaps"`
+	CrawlDelay       int                     `json:"crawl_delay"`
+	DisallowPatterns []string                `json:"disallow_patterns"`
+	SitemapURLs      int                     `json:"sitemap_urls"`
+	ExcludedByPaths  int                     `json:"excluded_by_paths"` // Removed by include/exclude paths
+	Allowed          int                     `json:"allowed"`
+	Blocked          int                     `json:"blocked"`
+	BlockingPatterns []RobotsBlockingPattern `json:"blocking_patterns"`
+	FailedSitemaps   []string                `json:"failed_sitemaps,omitempty"` // Sitemaps that failed to load after retries
+}
+
+// PreviewRobots fetches robots.txt and sitemaps for a domain and reports how
+// many sitemap URLs a job with the given path filters would warm, and which
+// Disallow patterns block the rest. Nothing is persisted.
+func (jm *JobManager) PreviewRobots(ctx context.Context, domain string, includePaths, excludePaths []string) (*RobotsPreview, error) {
+	entries, robotsRules, report, err := jm.discoverAndParseSitemaps(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover sitemaps: %w", err)
+	}
+	urls := crawler.SitemapURLStrings(entries)
+
+	candidates := urls
+	if jm.crawler != nil && (len(includePaths) > 0 || len(excludePaths) > 0) {
+		candidates = jm.crawler.FilterURLs(urls, includePaths, excludePaths)
+	}
+
+	preview := summariseRobotsImpact(candidates, robotsRules)
+	preview.Domain = domain
+	preview.SitemapURLs = len(urls)
+	preview.ExcludedByPaths = len(urls) - len(candidates)
+	preview.FailedSitemaps = report.Failed
+	return preview, nil
+}
+
+// summariseRobotsImpact counts allowed vs blocked URLs and groups blocked
+// URLs by the Disallow pattern responsible
+func summariseRobotsImpact(urls []string, robotsRules *crawler.RobotsRules) *RobotsPreview {
+	preview := &RobotsPreview{
+		Sitemaps:         []string{},
+		DisallowPatterns: []string{},
+		BlockingPatterns: []RobotsBlockingPattern{},
+	}
+	if robotsRules != nil {
+		preview.CrawlDelay = robotsRules.CrawlDelay
+		if robotsRules.Sitemaps != nil {
+			preview.Sitemaps = robotsRules.Sitemaps
+		}
+		if robotsRules.DisallowPatterns != nil {
+			preview.DisallowPatterns = robotsRules.DisallowPatterns
+		}
+	}
+
+	byPattern := make(map[string]*RobotsBlockingPattern)
+	for _, urlStr := range urls {
+		parsedURL, err := url.Parse(urlStr)
+		if err != nil {
+			continue
+		}
+
+		pattern := crawler.DisallowedBy(robotsRules, parsedURL.Path)
+		if pattern == "" {
+			preview.Allowed++
+			continue
+		}
+
+		preview.Blocked++
+		entry, ok := byPattern[pattern]
+		if !ok {
+			entry = &RobotsBlockingPattern{Pattern: pattern, Examples: []string{}}
+			byPattern[pattern] = entry
+		}
+		entry.BlockedURLs++
+		if len(entry.Examples) < maxRobotsPreviewExamples {
+			entry.Examples = append(entry.Examples, urlStr)
+		}
+	}
+
+	for _, entry := range byPattern {
+		preview.BlockingPatterns = append(preview.BlockingPatterns, *entry)
+	}
+	sort.Slice(preview.BlockingPatterns, func(i, j int) bool {
+		a, b := preview.BlockingPatterns[i], preview.BlockingPatterns[j]
+		if a.BlockedURLs != b.BlockedURLs {
+			return a.BlockedURLs > b.BlockedURLs
+		}
+		return a.Pattern < b.Pattern
+	})
+
+	return preview
+}
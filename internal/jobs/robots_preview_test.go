@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummariseRobotsImpact(t *testing.T) {
+	rules := &crawler.RobotsRules{
+		CrawlDelay:       2,
+		Sitemaps:         []string{"https://example.com/sitemap.xml"},
+		DisallowPatterns: []string{"/private/", "/tmp/*"},
+		AllowPatterns:    []string{"/private/open"},
+	}
+	urls := []string{
+		"https://example.com/",
+		"https://example.com/about",
+		"https://example.com/private/a",
+		"https://example.com/private/b",
+		"https://example.com/private/open",
+		"https://example.com/tmp/x",
+	}
+
+	preview := summariseRobotsImpact(urls, rules)
+
+	assert.Equal(t, 3, preview.Allowed)
+	assert.Equal(t, 3, preview.Blocked)
+	assert.Equal(t, 2, preview.CrawlDelay)
+	assert.Equal(t, rules.DisallowPatterns, preview.DisallowPatterns)
+
+	require.Len(t, preview.BlockingPatterns, 2)
+	assert.Equal(t, "/private/", preview.BlockingPatterns[0].Pattern)
+	assert.Equal(t, 2, preview.BlockingPatterns[0].BlockedURLs)
+	assert.Equal(t, []string{"https://example.com/private/a", "https://example.com/private/b"}, preview.BlockingPatterns[0].Examples)
+	assert.Equal(t, "/tmp/*", preview.BlockingPatterns[1].Pattern)
+	assert.Equal(t, 1, preview.BlockingPatterns[1].BlockedURLs)
+}
+
+func TestSummariseRobotsImpactWithoutRules(t *testing.T) {
+	preview := summariseRobotsImpact([]string{"https://example.com/", "https://example.com/a"}, nil)
+
+	assert.Equal(t, 2, preview.Allowed)
+	assert.Equal(t, 0, preview.Blocked)
+	assert.Empty(t, preview.BlockingPatterns)
+	assert.NotNil(t, preview.Sitemaps)
+}
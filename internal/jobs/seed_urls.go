@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+// errAllSeedURLsDisallowed fails a seeded job when robots.txt blocks every URL
+var errAllSeedURLsDisallowed = errors.New("all seed URLs are disallowed by robots.txt")
+
+// SeedURLPaths validates a job's seed URLs and returns their unique paths in
+// order. Entries are absolute http(s) URLs on the job's domain or paths
+// starting with "/"; query strings and fragments are dropped since tasks are
+// keyed by path.
+func SeedURLPaths(urls []string, domain string) ([]string, error) {
+	domain = util.NormaliseDomain(strings.ToLower(strings.TrimSpace(domain)))
+
+	paths := make([]string, 0, len(urls))
+	seen := make(map[string]struct{}, len(urls))
+	for _, raw := range urls {
+		path, err := seedURLPath(strings.TrimSpace(raw), domain)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[path]; dup {
+			continue
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	if len(paths) > MaxWarmURLs {
+		return nil, fmt.Errorf("seed_urls has %d unique URLs; the maximum is %d", len(paths), MaxWarmURLs)
+	}
+	return paths, nil
+}
+
+// ValidateSeedURLs checks every seed URL belongs to the job's domain
+func ValidateSeedURLs(urls []string, domain string) error {
+	_, err := SeedURLPaths(urls, domain)
+	return err
+}
+
+func seedURLPath(raw, domain string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("seed_urls cannot contain empty entries")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid seed URL %q: %w", raw, err)
+	}
+	if parsed.IsAbs() || parsed.Host != "" {
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return "", fmt.Errorf("seed URL %q must use http or https", raw)
+		}
+		if util.NormaliseDomain(strings.ToLower(parsed.Hostname())) != domain {
+			return "", fmt.Errorf("seed URL %q is not on %s", raw, domain)
+		}
+	} else if !strings.HasPrefix(parsed.Path, "/") {
+		return "", fmt.Errorf("seed URL %q must be an absolute URL or a path starting with /", raw)
+	}
+
+	path := parsed.Path
+	if path == "" {
+		path = "/"
+	}
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path, nil
+}
+
+// enqueueSeedURLs queues a job's seed paths, dropping any robots.txt disallows.
+// Robots.txt fetch failures warm the seeds unfiltered, since the client chose
+// them explicitly.
+func (jm *JobManager) enqueueSeedURLs(ctx context.Context, job *Job, domain string, paths []string) error {
+	allowed := paths
+	if jm.crawler != nil {
+		discovery, err := jm.crawler.DiscoverSitemapsAndRobots(ctx, domain)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("job_id", job.ID).
+				Str("domain", domain).
+				Msg("Failed to fetch robots.txt for seed URLs, warming them unfiltered")
+		} else if rules := discovery.RobotsRules; rules != nil {
+			jm.updateDomainCrawlDelay(ctx, domain, rules.CrawlDelay)
+			allowed = jm.filterURLsAgainstRobots(paths, rules, nil, nil)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return errAllSeedURLsDisallowed
+	}
+	if blocked := len(paths) - len(allowed); blocked > 0 {
+		log.Info().
+			Str("job_id", job.ID).
+			Int("blocked_count", blocked).
+			Int("allowed_count", len(allowed)).
+			Msg("Dropped seed URLs disallowed by robots.txt")
+	}
+
+	return jm.enqueueURLsForJob(ctx, job.ID, domain, allowed, "seed", nil)
+}
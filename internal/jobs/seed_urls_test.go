@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// robotsCrawler serves fixed robots.txt rules from discovery
+type robotsCrawler struct {
+	MockCrawler
+	rules *crawler.RobotsRules
+}
+
+func (c *robotsCrawler) DiscoverSitemapsAndRobots(ctx context.Context, domain string) (*crawler.SitemapDiscoveryResult, error) {
+	return &crawler.SitemapDiscoveryResult{RobotsRules: c.rules}, nil
+}
+
+func TestSeedURLPaths(t *testing.T) {
+	paths, err := SeedURLPaths([]string{
+		"https://www.example.com/pricing/",
+		"http://example.com/pricing?ref=csv",
+		"/blog/post#intro",
+		" https://example.com ",
+	}, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/pricing", "/blog/post", "/"}, paths)
+}
+
+func TestSeedURLPathsRejectsInvalidURLs(t *testing.T) {
+	tests := map[string]string{
+		"other domain":      "https://other.com/page",
+		"subdomain":         "https://shop.example.com/page",
+		"unsupported proto": "ftp://example.com/file",
+		"relative path":     "pricing",
+		"empty entry":       "  ",
+	}
+	for name, seed := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := SeedURLPaths([]string{"https://example.com/ok", seed}, "example.com")
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := SeedURLPaths([]string{"https://other.com/page"}, "example.com")
+	assert.ErrorContains(t, err, "is not on example.com")
+}
+
+func TestSeedURLPathsEnforcesLimit(t *testing.T) {
+	seeds := make([]string, MaxWarmURLs+1)
+	for i := range seeds {
+		seeds[i] = fmt.Sprintf("/page-%d", i)
+	}
+	_, err := SeedURLPaths(seeds, "example.com")
+	assert.Error(t, err)
+}
+
+func TestEnqueueSeedURLsFailsWhenRobotsBlocksAll(t *testing.T) {
+	jm := &JobManager{crawler: &robotsCrawler{rules: &crawler.RobotsRules{DisallowPatterns: []string{"/private"}}}}
+
+	err := jm.enqueueSeedURLs(context.Background(), &Job{ID: "job-1"}, "example.com", []string{"/private/a", "/private/b"})
+	assert.ErrorIs(t, err, errAllSeedURLsDisallowed)
+}
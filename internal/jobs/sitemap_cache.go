@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// cachedSitemapURL is how a sitemap entry is stored in sitemap_cache
+type cachedSitemapURL struct {
+	URL        string    `json:"url"`
+	LastMod    time.Time `json:"lastmod,omitzero"`
+	Priority   *float64  `json:"priority,omitempty"`
+	ChangeFreq string    `json:"changefreq,omitempty"`
+	Scheme     string    `json:"scheme,omitempty"`
+}
+
+// dbSitemapCache keeps a domain's parsed sitemaps and their validators in
+// sitemap_cache, so scheduled jobs revalidate unchanged sitemaps with a 304
+// instead of downloading and parsing them again
+type dbSitemapCache struct {
+	dbQueue DbQueueProvider
+	domain  string
+}
+
+// withSitemapCache attaches the domain's sitemap cache to ctx for parsing
+func (jm *JobManager) withSitemapCache(ctx context.Context, domain string) context.Context {
+	if jm.dbQueue == nil {
+		return ctx
+	}
+	return crawler.WithSitemapCache(ctx, &dbSitemapCache{dbQueue: jm.dbQueue, domain: domain})
+}
+
+func (c *dbSitemapCache) GetSitemap(ctx context.Context, sitemapURL string) (*crawler.CachedSitemap, bool) {
+	sitemap := &crawler.CachedSitemap{}
+	var entries []byte
+	err := c.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+			SELECT sc.etag, sc.last_modified, sc.is_index, sc.sitemaps, sc.entries
+			FROM sitemap_cache sc
+			JOIN domains d ON d.id = sc.domain_id
+			WHERE d.name = $1 AND sc.sitemap_url = $2
+		`, c.domain, sitemapURL).Scan(
+			&sitemap.Validators.ETag, &sitemap.Validators.LastModified,
+			&sitemap.Index, pq.Array(&sitemap.Sitemaps), &entries,
+		)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("sitemap_url", sitemapURL).Msg("Failed to read cached sitemap, fetching in full")
+		return nil, false
+	}
+
+	var stored []cachedSitemapURL
+	if err := json.Unmarshal(entries, &stored); err != nil {
+		log.Warn().Err(err).Str("sitemap_url", sitemapURL).Msg("Failed to decode cached sitemap, fetching in full")
+		return nil, false
+	}
+	sitemap.URLs = make([]crawler.SitemapURL, len(stored))
+	for i, entry := range stored {
+		sitemap.URLs[i] = crawler.SitemapURL{
+			URL:          entry.URL,
+			LastMod:      entry.LastMod,
+			Priority:     entry.Priority,
+			ChangeFreq:   entry.ChangeFreq,
+			ListedScheme: entry.Scheme,
+		}
+	}
+	return sitemap, true
+}
+
+func (c *dbSitemapCache) PutSitemap(ctx context.Context, sitemapURL string, sitemap *crawler.CachedSitemap) {
+	stored := make([]cachedSitemapURL, len(sitemap.URLs))
+	for i, entry := range sitemap.URLs {
+		stored[i] = cachedSitemapURL{
+			URL:        entry.URL,
+			LastMod:    entry.LastMod,
+			Priority:   entry.Priority,
+			ChangeFreq: entry.ChangeFreq,
+			Scheme:     entry.ListedScheme,
+		}
+	}
+	entries, err := json.Marshal(stored)
+	if err != nil {
+		log.Warn().Err(err).Str("sitemap_url", sitemapURL).Msg("Failed to encode sitemap for cache")
+		return
+	}
+
+	// Domains without a row yet are simply not cached
+	if err := c.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO sitemap_cache (domain_id, sitemap_url, etag, last_modified, is_index, sitemaps, entries, updated_at)
+			SELECT d.id, $2, $3, $4, $5, COALESCE($6::text[], '{}'), $7, NOW()
+			FROM domains d
+			WHERE d.name = $1
+			ON CONFLICT (domain_id, sitemap_url) DO UPDATE SET
+				etag = EXCLUDED.etag,
+				last_modified = EXCLUDED.last_modified,
+				is_index = EXCLUDED.is_index,
+				sitemaps = EXCLUDED.sitemaps,
+				entries = EXCLUDED.entries,
+				updated_at = EXCLUDED.updated_at
+		`, c.domain, sitemapURL, sitemap.Validators.ETag, sitemap.Validators.LastModified,
+			sitemap.Index, pq.Array(sitemap.Sitemaps), entries)
+		return err
+	}); err != nil {
+		log.Warn().Err(err).Str("sitemap_url", sitemapURL).Msg("Failed to cache parsed sitemap")
+	}
+}
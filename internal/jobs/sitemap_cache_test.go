@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBSitemapCacheRoundTrip(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	cache := &dbSitemapCache{dbQueue: &mockDbQueueWrapper{mockDB: mockDB}, domain: "example.com"}
+	sitemapURL := "https://example.com/sitemap.xml"
+	priority := 0.8
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO sitemap_cache").
+		WithArgs("example.com", sitemapURL, `"v1"`, "", false, sqlmock.AnyArg(),
+			[]byte(`[{"url":"https://example.com/","lastmod":"2026-10-01T00:00:00Z","priority":0.8},{"url":"https://example.com/about"}]`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	cache.PutSitemap(context.Background(), sitemapURL, &crawler.CachedSitemap{
+		Validators: crawler.Validators{ETag: `"v1"`},
+		URLs: []crawler.SitemapURL{
+			{URL: "https://example.com/", LastMod: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Priority: &priority},
+			{URL: "https://example.com/about"},
+		},
+	})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT sc.etag, sc.last_modified").
+		WithArgs("example.com", sitemapURL).
+		WillReturnRows(sqlmock.NewRows([]string{"etag", "last_modified", "is_index", "sitemaps", "entries"}).
+			AddRow(`"v1"`, "", false, "{}", `[{"url":"https://example.com/","lastmod":"2026-10-01T00:00:00Z","priority":0.8},{"url":"https://example.com/about"}]`))
+	mock.ExpectCommit()
+
+	sitemap, ok := cache.GetSitemap(context.Background(), sitemapURL)
+	require.True(t, ok)
+	assert.Equal(t, `"v1"`, sitemap.Validators.ETag)
+	assert.False(t, sitemap.Index)
+	require.Len(t, sitemap.URLs, 2)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), sitemap.URLs[0].LastMod)
+	assert.Equal(t, &priority, sitemap.URLs[0].Priority)
+	assert.True(t, sitemap.URLs[1].LastMod.IsZero())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT sc.etag, sc.last_modified").
+		WithArgs("example.com", "https://example.com/other.xml").
+		WillReturnRows(sqlmock.NewRows([]string{"etag", "last_modified", "is_index", "sitemaps", "entries"}))
+	mock.ExpectRollback()
+
+	_, ok = cache.GetSitemap(context.Background(), "https://example.com/other.xml")
+	assert.False(t, ok)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,206 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// failedSitemapRetryDelay is how long a job warms before sitemaps that failed
+// discovery are tried once more
+var failedSitemapRetryDelay = 2 * time.Minute
+
+// failedSitemapRetryTimeout bounds the second attempt at failed sitemaps
+const failedSitemapRetryTimeout = 10 * time.Minute
+
+// sitemapReporter is implemented by crawlers that can report which sitemap
+// index entries failed to load
+type sitemapReporter interface {
+	ParseSitemapWithReport(ctx context.Context, sitemapURL string) (*crawler.SitemapParseResult, error)
+}
+
+// sitemapLoadReport counts the sitemaps discovery loaded and lists the ones
+// still unreachable after retries
+type sitemapLoadReport struct {
+	Loaded int
+	Failed []string
+	Found  []string // Sitemaps discovery found before parsing
+
+	// Entries listed with each scheme, to report the ones canonicalised to
+	// the job's url_scheme
+	HTTPURLs  int
+	HTTPSURLs int
+}
+
+// Total is every sitemap discovery tried to load
+func (r sitemapLoadReport) Total() int {
+	return r.Loaded + len(r.Failed)
+}
+
+// parseSitemapWithReport parses a sitemap, falling back to treating it as a
+// single sitemap when the crawler can't report failed index entries
+func parseSitemapWithReport(ctx context.Context, c CrawlerInterface, sitemapURL string) (*crawler.SitemapParseResult, error) {
+	if reporter, ok := c.(sitemapReporter); ok {
+		return reporter.ParseSitemapWithReport(ctx, sitemapURL)
+	}
+	urls, err := c.ParseSitemap(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	return &crawler.SitemapParseResult{URLs: urls, Loaded: 1}, nil
+}
+
+// parseSitemaps parses each sitemap, collecting URLs and the sitemaps that
+// failed to load rather than stopping at the first failure
+func parseSitemaps(ctx context.Context, c CrawlerInterface, sitemaps []string) ([]crawler.SitemapURL, sitemapLoadReport) {
+	var urls []crawler.SitemapURL
+	var report sitemapLoadReport
+	for _, sitemapURL := range sitemaps {
+		log.Info().
+			Str("sitemap_url", sitemapURL).
+			Msg("Processing sitemap")
+
+		result, err := parseSitemapWithReport(ctx, c, sitemapURL)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("sitemap_url", sitemapURL).
+				Msg("Error parsing sitemap")
+			report.Failed = append(report.Failed, sitemapURL)
+			continue
+		}
+
+		log.Info().
+			Str("sitemap_url", sitemapURL).
+			Int("url_count", len(result.URLs)).
+			Int("failed_sitemaps", len(result.Failed)).
+			Msg("Parsed URLs from sitemap")
+
+		urls = append(urls, result.URLs...)
+		httpURLs, httpsURLs := countListedSchemes(result.URLs)
+		report.HTTPURLs += httpURLs
+		report.HTTPSURLs += httpsURLs
+		report.Loaded += result.Loaded
+		report.Failed = append(report.Failed, result.Failed...)
+	}
+	return urls, report
+}
+
+// recordSitemapLoadReport stores which sitemaps a job found and how many it
+// tried to load and which failed, so its summary can report "3 of 12 sitemaps
+// failed to load". It also counts the entries listed with the scheme the job
+// doesn't warm over.
+func (jm *JobManager) recordSitemapLoadReport(ctx context.Context, jobID string, report sitemapLoadReport) {
+	if err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET sitemaps_total = $1,
+				failed_sitemaps = COALESCE($2::text[], '{}'),
+				discovered_sitemaps = COALESCE($3::text[], '{}'),
+				mixed_scheme_urls = CASE WHEN url_scheme = 'http' THEN $5 ELSE $4 END
+			WHERE id = $6
+		`, report.Total(), pq.Array(report.Failed), pq.Array(report.Found),
+			report.HTTPURLs, report.HTTPSURLs, jobID)
+		return err
+	}); err != nil {
+		log.Error().Err(err).Str("job_id", jobID).Msg("Failed to record sitemap load report")
+	}
+}
+
+// failedSitemapRetry is what the second attempt at failed sitemaps needs to
+// filter and enqueue whatever they yield
+type failedSitemapRetry struct {
+	JobID               string
+	Domain              string
+	Report              sitemapLoadReport
+	RobotsRules         *crawler.RobotsRules
+	IncludePaths        []string
+	ExcludePaths        []string
+	FreshnessWindowDays int
+	PurgeFirst          bool
+}
+
+// scheduleFailedSitemapRetry tries a job's failed sitemaps once more after
+// failedSitemapRetryDelay, giving transient outages time to clear
+func (jm *JobManager) scheduleFailedSitemapRetry(ctx context.Context, retry failedSitemapRetry) {
+	if len(retry.Report.Failed) == 0 {
+		return
+	}
+
+	// Keep the user agent and credentials but outlive discovery's timeout
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		time.Sleep(failedSitemapRetryDelay)
+
+		retryCtx, cancel := context.WithTimeout(ctx, failedSitemapRetryTimeout)
+		defer cancel()
+		jm.retryFailedSitemaps(retryCtx, retry)
+	}()
+}
+
+func (jm *JobManager) retryFailedSitemaps(ctx context.Context, retry failedSitemapRetry) {
+	var status string
+	err := jm.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `SELECT status FROM jobs WHERE id = $1`, retry.JobID).Scan(&status)
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("job_id", retry.JobID).Msg("Failed to check job before retrying sitemaps")
+		return
+	}
+	if status != string(JobStatusPending) && status != string(JobStatusRunning) {
+		return
+	}
+
+	entries, recovered := parseSitemaps(jm.withSitemapCache(ctx, retry.Domain), jm.crawler, retry.Report.Failed)
+	report := sitemapLoadReport{
+		Loaded:    retry.Report.Loaded + recovered.Loaded,
+		Failed:    recovered.Failed,
+		Found:     retry.Report.Found,
+		HTTPURLs:  retry.Report.HTTPURLs + recovered.HTTPURLs,
+		HTTPSURLs: retry.Report.HTTPSURLs + recovered.HTTPSURLs,
+	}
+	jm.recordSitemapLoadReport(ctx, retry.JobID, report)
+
+	log.Info().
+		Str("job_id", retry.JobID).
+		Int("retried_sitemaps", len(retry.Report.Failed)).
+		Int("recovered_sitemaps", recovered.Loaded).
+		Int("still_failed", len(report.Failed)).
+		Msg("Retried failed sitemaps")
+
+	urls := jm.filterURLsAgainstRobots(crawler.SitemapURLStrings(entries), retry.RobotsRules, retry.IncludePaths, retry.ExcludePaths)
+	if len(urls) == 0 {
+		return
+	}
+	if retry.PurgeFirst {
+		jm.purgeBeforeWarm(ctx, retry.JobID, urls)
+	}
+
+	priorities := newSitemapPriority(
+		newSitemapFreshness(entries, retry.Domain, retry.FreshnessWindowDays, time.Now().UTC()),
+		newSitemapHints(entries, retry.Domain),
+	)
+	for i := 0; i < len(urls); i += sitemapEnqueueBatchSize {
+		end := min(i+sitemapEnqueueBatchSize, len(urls))
+		if err := jm.enqueueSitemapURLs(ctx, retry.JobID, retry.Domain, urls[i:end], priorities); err != nil {
+			return
+		}
+	}
+	if jm.workerPool != nil {
+		jm.workerPool.NotifyNewTasks()
+	}
+}
+
+// SitemapLoadSummary renders a job's sitemap failures for its summary, or ""
+// when every sitemap loaded
+func SitemapLoadSummary(total int, failed []string) string {
+	if len(failed) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d of %d sitemaps failed to load", len(failed), total)
+}
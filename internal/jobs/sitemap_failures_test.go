@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+)
+
+// reportingCrawler serves canned sitemap reports keyed by URL
+type reportingCrawler struct {
+	MockCrawler
+	results map[string]*crawler.SitemapParseResult
+}
+
+func (c *reportingCrawler) ParseSitemapWithReport(_ context.Context, sitemapURL string) (*crawler.SitemapParseResult, error) {
+	if result, ok := c.results[sitemapURL]; ok {
+		return result, nil
+	}
+	return nil, errors.New("failed to fetch sitemap: 503")
+}
+
+func TestParseSitemapsReportsFailures(t *testing.T) {
+	c := &reportingCrawler{results: map[string]*crawler.SitemapParseResult{
+		"https://example.com/sitemap_index.xml": {
+			URLs:   []crawler.SitemapURL{{URL: "https://example.com/a"}, {URL: "https://example.com/b"}},
+			Loaded: 10,
+			Failed: []string{"https://example.com/posts-3.xml", "https://example.com/posts-4.xml"},
+		},
+	}}
+
+	urls, report := parseSitemaps(context.Background(), c, []string{
+		"https://example.com/sitemap_index.xml",
+		"https://example.com/news-sitemap.xml",
+	})
+
+	assert.Len(t, urls, 2)
+	assert.Equal(t, 10, report.Loaded)
+	assert.Equal(t, []string{
+		"https://example.com/posts-3.xml",
+		"https://example.com/posts-4.xml",
+		"https://example.com/news-sitemap.xml",
+	}, report.Failed)
+	assert.Equal(t, 13, report.Total())
+	assert.Equal(t, "3 of 13 sitemaps failed to load", SitemapLoadSummary(report.Total(), report.Failed))
+}
+
+func TestParseSitemapsFallsBackWithoutReporter(t *testing.T) {
+	_, report := parseSitemaps(context.Background(), &MockCrawler{}, []string{"https://example.com/sitemap.xml"})
+
+	assert.Equal(t, 1, report.Loaded)
+	assert.Empty(t, report.Failed)
+	assert.Empty(t, SitemapLoadSummary(report.Total(), report.Failed))
+}
@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"math"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+)
+
+const (
+	// defaultSitemapHintPriority is the sitemap protocol's default <priority>
+	defaultSitemapHintPriority = 0.5
+	// maxSitemapHintPriority is the task priority of a page with <priority>1.0.
+	// Even with the changefreq bonus it stays below maxFreshnessPriority, so a
+	// page the origin actually changed recently leads one it merely rates highly.
+	maxSitemapHintPriority = 0.4
+)
+
+// changeFreqBonus lifts pages the origin says change often
+var changeFreqBonus = map[string]float64{
+	"always": 0.05,
+	"hourly": 0.05,
+	"daily":  0.03,
+}
+
+// sitemapHints sets initial task priority from sitemap <priority> and <changefreq>
+type sitemapHints struct {
+	scores map[string]float64 // Keyed by normalised page path
+}
+
+// newSitemapHints indexes sitemap entries with a priority or changefreq by
+// page path. Returns nil when no entry has either.
+func newSitemapHints(entries []crawler.SitemapURL, domain string) *sitemapHints {
+	scores := make(map[string]float64)
+	for _, entry := range entries {
+		if entry.Priority == nil && entry.ChangeFreq == "" {
+			continue
+		}
+		path, err := db.NormaliseURLPath(entry.URL, domain)
+		if err != nil {
+			continue
+		}
+		// A page listed twice keeps its strongest hint
+		score := sitemapHintScore(entry.Priority, entry.ChangeFreq)
+		if existing, ok := scores[path]; !ok || score > existing {
+			scores[path] = score
+		}
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+	return &sitemapHints{scores: scores}
+}
+
+// sitemapHintScore maps the protocol's 0-1 <priority>, where 0.5 is the
+// default, onto task priority: 0.5 gives the default sitemap priority, 1.0
+// gives maxSitemapHintPriority and 0.0 sinks to zero. A frequent <changefreq>
+// adds a small bonus on top.
+func sitemapHintScore(priority *float64, changeFreq string) float64 {
+	hint := defaultSitemapHintPriority
+	if priority != nil {
+		hint = *priority
+	}
+
+	var score float64
+	if hint >= defaultSitemapHintPriority {
+		weight := (hint - defaultSitemapHintPriority) / (1 - defaultSitemapHintPriority)
+		score = defaultSitemapPriority + (maxSitemapHintPriority-defaultSitemapPriority)*weight
+	} else {
+		score = defaultSitemapPriority * hint / defaultSitemapHintPriority
+	}
+	score += changeFreqBonus[changeFreq]
+
+	// priority_score is NUMERIC(4,3)
+	return math.Round(score*1000) / 1000
+}
+
+// priority returns the hint score for a path, and false when the sitemap
+// gave no hints for it
+func (h *sitemapHints) priority(path string) (float64, bool) {
+	if h == nil {
+		return 0, false
+	}
+	score, ok := h.scores[path]
+	return score, ok
+}
+
+// sitemapPriority combines the sitemap signals that seed a task's priority
+type sitemapPriority struct {
+	freshness *sitemapFreshness
+	hints     *sitemapHints
+}
+
+// newSitemapPriority indexes a job's sitemap entries. Returns nil when
+// neither <lastmod> freshness nor <priority>/<changefreq> hints apply.
+func newSitemapPriority(freshness *sitemapFreshness, hints *sitemapHints) *sitemapPriority {
+	if freshness == nil && hints == nil {
+		return nil
+	}
+	return &sitemapPriority{freshness: freshness, hints: hints}
+}
+
+// priority resolves a page's initial priority. A recent <lastmod> boost and
+// the sitemap's own hints both raise a page, so the higher of the two wins.
+// When the page isn't fresh, its hints apply as-is, which lets a low
+// <priority> push it below the default. Pages with neither keep the default.
+// The homepage is handled by the caller and always leads.
+func (p *sitemapPriority) priority(path string) float64 {
+	if p == nil {
+		return defaultSitemapPriority
+	}
+
+	fresh := p.freshness.priority(path)
+	hint, ok := p.hints.priority(path)
+	switch {
+	case !ok:
+		return fresh
+	case fresh > defaultSitemapPriority:
+		return max(fresh, hint)
+	default:
+		return hint
+	}
+}
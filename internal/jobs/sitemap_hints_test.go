@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+func sitemapPriorityHint(v float64) *float64 { return &v }
+
+func TestSitemapHintScore(t *testing.T) {
+	assert.Equal(t, defaultSitemapPriority, sitemapHintScore(nil, ""), "no hints keeps the default")
+	assert.Equal(t, defaultSitemapPriority, sitemapHintScore(sitemapPriorityHint(0.5), ""))
+	assert.Equal(t, maxSitemapHintPriority, sitemapHintScore(sitemapPriorityHint(1.0), ""))
+	assert.Equal(t, 0.25, sitemapHintScore(sitemapPriorityHint(0.75), ""))
+	assert.Equal(t, 0.05, sitemapHintScore(sitemapPriorityHint(0.25), ""))
+	assert.Equal(t, 0.0, sitemapHintScore(sitemapPriorityHint(0), ""))
+
+	assert.Equal(t, 0.13, sitemapHintScore(nil, "daily"))
+	assert.Equal(t, 0.45, sitemapHintScore(sitemapPriorityHint(1.0), "hourly"))
+	assert.Less(t, sitemapHintScore(sitemapPriorityHint(1.0), "always"), maxFreshnessPriority)
+	assert.Equal(t, defaultSitemapPriority, sitemapHintScore(nil, "monthly"))
+}
+
+func TestSitemapPriorityPrecedence(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	entries := []crawler.SitemapURL{
+		{URL: "https://example.com/fresh-low", LastMod: now, Priority: sitemapPriorityHint(0.1)},
+		{URL: "https://example.com/stale-high", LastMod: now.Add(-30 * 24 * time.Hour), Priority: sitemapPriorityHint(1.0)},
+		{URL: "https://example.com/half-fresh-high", LastMod: now.Add(-6 * 24 * time.Hour), Priority: sitemapPriorityHint(1.0)},
+		{URL: "https://example.com/low", Priority: sitemapPriorityHint(0)},
+		{URL: "https://example.com/daily", ChangeFreq: "daily"},
+		{URL: "https://example.com/plain"},
+		{URL: "https://example.com/dup", Priority: sitemapPriorityHint(0.2)},
+		{URL: "https://example.com/dup", Priority: sitemapPriorityHint(0.9)},
+	}
+
+	priorities := newSitemapPriority(
+		newSitemapFreshness(entries, "example.com", 7, now),
+		newSitemapHints(entries, "example.com"),
+	)
+	require.NotNil(t, priorities)
+
+	assert.Equal(t, maxFreshnessPriority, priorities.priority("/fresh-low"), "a fresh page isn't demoted by a low hint")
+	assert.Equal(t, maxSitemapHintPriority, priorities.priority("/stale-high"), "hints apply when the page isn't fresh")
+	assert.Equal(t, maxSitemapHintPriority, priorities.priority("/half-fresh-high"), "the higher of freshness and hints wins")
+	assert.Equal(t, 0.0, priorities.priority("/low"))
+	assert.Equal(t, 0.13, priorities.priority("/daily"))
+	assert.Equal(t, defaultSitemapPriority, priorities.priority("/plain"))
+	assert.Equal(t, 0.34, priorities.priority("/dup"), "duplicates keep the strongest hint")
+}
+
+func TestNewSitemapPriorityWithoutSignals(t *testing.T) {
+	entries := []crawler.SitemapURL{{URL: "https://example.com/page"}}
+	assert.Nil(t, newSitemapHints(entries, "example.com"))
+	assert.Nil(t, newSitemapPriority(nil, nil))
+
+	var priorities *sitemapPriority
+	assert.Equal(t, defaultSitemapPriority, priorities.priority("/page"))
+
+	hintsOnly := newSitemapPriority(nil, newSitemapHints([]crawler.SitemapURL{
+		{URL: "https://example.com/page", Priority: sitemapPriorityHint(1.0)},
+	}, "example.com"))
+	assert.Equal(t, maxSitemapHintPriority, hintsOnly.priority("/page"))
+	assert.Equal(t, defaultSitemapPriority, hintsOnly.priority("/other"))
+}
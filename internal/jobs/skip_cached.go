@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/rs/zerolog/log"
+)
+
+// SkipReasonCached marks tasks skipped by skip-cached jobs because the edge
+// already reported a cache HIT for the page
+const SkipReasonCached = "cached"
+
+// errTaskCached tells processNextTask the page was skipped as already cached
+var errTaskCached = errors.New("page already cached at the edge")
+
+// pageCached reports whether a skip-cached task can skip its full warm.
+// Probe failures fall back to warming the page.
+func (wp *WorkerPool) pageCached(ctx context.Context, task *Task, url string) bool {
+	cached, err := wp.crawler.CheckCached(ctx, url)
+	if err != nil {
+		log.Debug().Err(err).Str("task_id", task.ID).Msg("Cache probe failed, warming page")
+		return false
+	}
+	return cached
+}
+
+// handleTaskCached records a skip-cached task as skipped-cached
+func (wp *WorkerPool) handleTaskCached(ctx context.Context, task *db.Task) error {
+	wp.resetJobFailureStreak(task.JobID)
+
+	task.Status = string(TaskStatusSkipped)
+	task.SkipReason = SkipReasonCached
+	task.CompletedAt = time.Now().UTC()
+
+	log.Debug().
+		Str("task_id", task.ID).
+		Str("job_id", task.JobID).
+		Msg("Skipped already cached page")
+
+	// Free the concurrency slot straight away, as for completed tasks
+	if err := wp.releaseRunningTaskSlot(task.JobID); err != nil {
+		log.Error().Err(err).Str("job_id", task.JobID).Str("task_id", task.ID).
+			Msg("Failed to decrement running_tasks counter")
+	}
+
+	wp.batchManager.QueueTaskUpdate(task)
+	return nil
+}
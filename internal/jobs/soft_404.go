@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+// defaultSoft404MaxBodyBytes treats HTML bodies smaller than this as empty
+// placeholders rather than real pages
+const defaultSoft404MaxBodyBytes = 512
+
+// defaultSoft404TitleMarkers are lower-case phrases CMS "not found" templates
+// commonly put in the page title
+var defaultSoft404TitleMarkers = []string{
+	"not found",
+	"404",
+	"page doesn't exist",
+	"page does not exist",
+	"no longer available",
+	"nothing found",
+}
+
+var htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// Soft404Markers configure the soft-404 heuristic. Jobs opt in with
+// DetectSoft404 because any marker can match a real page.
+type Soft404Markers struct {
+	MaxBodyBytes int      // HTML bodies below this size are flagged; 0 disables the size check
+	TitleMarkers []string // Lower-case phrases that flag a page when found in its <title>
+}
+
+// soft404MarkersFromEnv reads BBB_SOFT404_MAX_BODY_BYTES and a comma-separated
+// BBB_SOFT404_TITLE_MARKERS, falling back to the defaults
+func soft404MarkersFromEnv() Soft404Markers {
+	markers := Soft404Markers{
+		MaxBodyBytes: defaultSoft404MaxBodyBytes,
+		TitleMarkers: defaultSoft404TitleMarkers,
+	}
+	if raw := strings.TrimSpace(os.Getenv("BBB_SOFT404_MAX_BODY_BYTES")); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed >= 0 {
+			markers.MaxBodyBytes = parsed
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("BBB_SOFT404_TITLE_MARKERS")); raw != "" {
+		var titleMarkers []string
+		for marker := range strings.SplitSeq(raw, ",") {
+			if marker = strings.ToLower(strings.TrimSpace(marker)); marker != "" {
+				titleMarkers = append(titleMarkers, marker)
+			}
+		}
+		markers.TitleMarkers = titleMarkers
+	}
+	return markers
+}
+
+// IsSoft404 reports whether a 200 HTML response looks like a "not found" page:
+// a near-empty body, or a title containing one of the markers. HEAD warms and
+// other statuses are never flagged.
+func (m Soft404Markers) IsSoft404(result *crawler.CrawlResult) bool {
+	if result == nil || result.StatusCode != 200 || len(result.BodySample) == 0 {
+		return false
+	}
+	if !strings.Contains(strings.ToLower(result.ContentType), "text/html") {
+		return false
+	}
+
+	if m.MaxBodyBytes > 0 && len(bytes.TrimSpace(result.BodySample)) < m.MaxBodyBytes {
+		return true
+	}
+
+	match := htmlTitlePattern.FindSubmatch(result.BodySample)
+	if match == nil {
+		return false
+	}
+	title := strings.ToLower(string(match[1]))
+	for _, marker := range m.TitleMarkers {
+		if strings.Contains(title, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectsSoft404 reports whether a job opted in to soft-404 detection
+func (wp *WorkerPool) detectsSoft404(jobID string) bool {
+	wp.jobInfoMutex.RLock()
+	defer wp.jobInfoMutex.RUnlock()
+
+	info, exists := wp.jobInfoCache[jobID]
+	return exists && info.DetectSoft404
+}
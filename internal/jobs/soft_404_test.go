@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+)
+
+func htmlResult(status int, body string) *crawler.CrawlResult {
+	return &crawler.CrawlResult{
+		StatusCode:  status,
+		ContentType: "text/html; charset=utf-8",
+		BodySample:  []byte(body),
+	}
+}
+
+func TestSoft404MarkersIsSoft404(t *testing.T) {
+	markers := Soft404Markers{MaxBodyBytes: defaultSoft404MaxBodyBytes, TitleMarkers: defaultSoft404TitleMarkers}
+	page := "<html><head><title>Pricing | Example</title></head><body>" + strings.Repeat("<p>Plans</p>", 100) + "</body></html>"
+	notFound := "<html><head><title>Page Not Found – Example</title></head><body>" + strings.Repeat("<p>Sorry</p>", 100) + "</body></html>"
+
+	tests := []struct {
+		name   string
+		result *crawler.CrawlResult
+		want   bool
+	}{
+		{"real page", htmlResult(200, page), false},
+		{"not found title", htmlResult(200, notFound), true},
+		{"tiny body", htmlResult(200, "<html><body></body></html>"), true},
+		{"real 404", htmlResult(404, notFound), false},
+		{"head warm", htmlResult(200, ""), false},
+		{"non-html", &crawler.CrawlResult{StatusCode: 200, ContentType: "application/json", BodySample: []byte("{}")}, false},
+		{"nil result", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, markers.IsSoft404(tt.result))
+		})
+	}
+}
+
+func TestSoft404MarkersFromEnv(t *testing.T) {
+	t.Setenv("BBB_SOFT404_MAX_BODY_BYTES", "0")
+	t.Setenv("BBB_SOFT404_TITLE_MARKERS", " Oops , ,Gone ")
+
+	markers := soft404MarkersFromEnv()
+	assert.Equal(t, 0, markers.MaxBodyBytes)
+	assert.Equal(t, []string{"oops", "gone"}, markers.TitleMarkers)
+	assert.False(t, markers.IsSoft404(htmlResult(200, "<html></html>")))
+	assert.True(t, markers.IsSoft404(htmlResult(200, "<title>Oops!</title>")))
+}
+
+func TestDetectsSoft404(t *testing.T) {
+	wp := &WorkerPool{jobInfoCache: map[string]*JobInfo{
+		"opted-in": {DetectSoft404: true},
+		"default":  {},
+	}}
+
+	assert.True(t, wp.detectsSoft404("opted-in"))
+	assert.False(t, wp.detectsSoft404("default"))
+	assert.False(t, wp.detectsSoft404("uncached"))
+}
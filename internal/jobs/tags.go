@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+)
+
+// MaxJobTags caps how many tags a job can carry
+const MaxJobTags = 10
+
+// MaxJobTagLength bounds each tag
+const MaxJobTagLength = 50
+
+// NormaliseTags validates and normalises job tags such as "pre-deploy" or
+// "nightly". Tags are lower-cased and may contain letters, digits and
+// - _ . : /. Duplicates and blank entries are dropped.
+func NormaliseTags(tags []string) ([]string, error) {
+	if len(tags) > MaxJobTags {
+		return nil, fmt.Errorf("at most %d tags are allowed", MaxJobTags)
+	}
+
+	normalised := make([]string, 0, len(tags))
+	for _, raw := range tags {
+		tag, err := NormaliseTag(raw)
+		if err != nil {
+			return nil, err
+		}
+		if tag != "" && !slices.Contains(normalised, tag) {
+			normalised = append(normalised, tag)
+		}
+	}
+	return normalised, nil
+}
+
+// NormaliseTag validates and lower-cases a single tag, returning "" for a
+// blank one
+func NormaliseTag(raw string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(raw))
+	if len(tag) > MaxJobTagLength {
+		return "", fmt.Errorf("tag %q must be at most %d characters", raw, MaxJobTagLength)
+	}
+	for _, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case strings.ContainsRune("-_.:/", r):
+		default:
+			return "", fmt.Errorf("invalid tag %q: use letters, digits and - _ . : /", raw)
+		}
+	}
+	return tag, nil
+}
+
+// serialiseTags stores no tags as an empty array so tag filters never see NULL
+func serialiseTags(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	return db.Serialise(tags)
+}
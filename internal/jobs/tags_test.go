@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormaliseTags(t *testing.T) {
+	tags, err := NormaliseTags([]string{" Pre-Deploy ", "nightly", "", "pre-deploy", "release:2026.10"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pre-deploy", "nightly", "release:2026.10"}, tags)
+
+	tags, err = NormaliseTags(nil)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	for _, invalid := range []string{"has space", "emoji🐝", "semi;colon", strings.Repeat("a", MaxJobTagLength+1)} {
+		_, err := NormaliseTags([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+
+	_, err = NormaliseTags(make([]string, MaxJobTags+1))
+	assert.Error(t, err)
+}
+
+func TestSerialiseTags(t *testing.T) {
+	assert.Equal(t, "[]", serialiseTags(nil))
+	assert.Equal(t, `["nightly"]`, serialiseTags([]string{"nightly"}))
+}
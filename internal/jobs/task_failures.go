@@ -0,0 +1,99 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/rs/zerolog/log"
+)
+
+const taskFailureRecordTimeout = 10 * time.Second
+
+// Error categories recorded in task_failures.error_category
+const (
+	FailureCategoryBlocking  = "blocking"  // 403/429/503 - usually a WAF or rate limit
+	FailureCategoryRetryable = "retryable" // Timeouts, connection and 5xx errors that never recovered
+	FailureCategoryClient    = "client"    // 4xx and redirects that won't change on retry
+	FailureCategoryOther     = "other"
+)
+
+// taskFailureSkippedHeaders are never stored with a failure
+var taskFailureSkippedHeaders = []string{"Set-Cookie"}
+
+// classifyTaskFailure buckets a permanently failed task's error the same way
+// handleTaskError decides whether to retry it
+func classifyTaskFailure(err error) string {
+	switch class := ClassifyError(err); {
+	case class == ErrorClassBlocked:
+		return FailureCategoryBlocking
+	case class.Retryable():
+		return FailureCategoryRetryable
+	case class == ErrorClassClient:
+		return FailureCategoryClient
+	default:
+		return FailureCategoryOther
+	}
+}
+
+// recordTaskFailure keeps structured context for a task that has failed
+// permanently so job failures can be explained without digging through logs.
+// Runs in the background so it never delays task processing.
+func (wp *WorkerPool) recordTaskFailure(task *db.Task, result *crawler.CrawlResult, taskErr error) {
+	if wp.dbQueue == nil || taskErr == nil {
+		return
+	}
+
+	var statusCode sql.NullInt64
+	var headers http.Header
+	if result != nil {
+		if result.StatusCode > 0 {
+			statusCode = sql.NullInt64{Int64: int64(result.StatusCode), Valid: true}
+		}
+		headers = result.Headers.Clone()
+		for _, name := range taskFailureSkippedHeaders {
+			headers.Del(name)
+		}
+	}
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		headersJSON = []byte("{}")
+	}
+
+	category := classifyTaskFailure(taskErr)
+	attempts := task.RetryCount + 1
+	taskID, jobID, path, message := task.ID, task.JobID, task.Path, taskErr.Error()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), taskFailureRecordTimeout)
+		defer cancel()
+
+		err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO task_failures (
+					task_id, job_id, path, status_code, error_category, error_message,
+					attempts, response_headers
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8::jsonb)
+				ON CONFLICT (task_id) DO UPDATE SET
+					status_code = EXCLUDED.status_code,
+					error_category = EXCLUDED.error_category,
+					error_message = EXCLUDED.error_message,
+					attempts = EXCLUDED.attempts,
+					response_headers = EXCLUDED.response_headers,
+					failed_at = NOW()
+			`, taskID, jobID, path, statusCode, category, message, attempts, string(headersJSON))
+			return err
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Str("task_id", taskID).Msg("Failed to record task failure")
+		}
+	}()
+}
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyTaskFailure(t *testing.T) {
+	tests := []struct {
+		err      string
+		expected string
+	}{
+		{"non-success status code: 403 Forbidden", FailureCategoryBlocking},
+		{"non-success status code: 429 Too Many Requests", FailureCategoryBlocking},
+		{"non-success status code: 503 Service Unavailable", FailureCategoryBlocking},
+		{"context deadline exceeded", FailureCategoryRetryable},
+		{"non-success status code: 502 Bad Gateway", FailureCategoryRetryable},
+		{"crawler error: 404 Not Found", FailureCategoryClient},
+		{"crawler error: 301 Moved Permanently", FailureCategoryClient},
+		{`crawler error: Get "https://example.com/503": too many redirects: https://example.com/ → https://example.com/503 → https://example.com/ (loop)`, FailureCategoryClient},
+		{"invalid URL", FailureCategoryOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyTaskFailure(errors.New(tt.err)))
+		})
+	}
+}
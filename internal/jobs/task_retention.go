@@ -0,0 +1,230 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	taskRetentionSweepInterval = time.Hour
+	// taskRetentionJobLimit bounds how many jobs one sweep archives, so a
+	// backlog after enabling retention is worked through over several sweeps
+	taskRetentionJobLimit = 20
+	// taskRetentionBatchSize keeps each delete short enough not to hold
+	// locks the workers' queries are waiting on
+	taskRetentionBatchSize = 1000
+)
+
+// defaultTaskRetentionDaysFromEnv is the retention for jobs whose organisation
+// and plan don't set one, including jobs without an organisation. 0, the
+// default, keeps their tasks indefinitely.
+func defaultTaskRetentionDaysFromEnv() int {
+	if raw := strings.TrimSpace(os.Getenv("BBB_TASK_RETENTION_DAYS")); raw != "" {
+		if days, err := strconv.Atoi(raw); err == nil && days > 0 {
+			return days
+		}
+	}
+	return 0
+}
+
+// StartTaskRetentionSweeper periodically archives the tasks of jobs that
+// finished longer ago than their retention window
+func (wp *WorkerPool) StartTaskRetentionSweeper(ctx context.Context) {
+	defaultDays := defaultTaskRetentionDaysFromEnv()
+	wp.wg.Go(func() {
+		ticker := time.NewTicker(taskRetentionSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-wp.stopCh:
+				return
+			case <-ticker.C:
+				if err := wp.sweepTaskRetention(ctx, defaultDays); err != nil {
+					log.Error().Err(err).Msg("Failed to sweep expired job tasks")
+				}
+			}
+		}
+	})
+	log.Info().Int("default_retention_days", defaultDays).Msg("Task retention sweeper started")
+}
+
+// taskRetentionConfigured reports whether any plan or organisation has opted
+// in to task retention
+func (wp *WorkerPool) taskRetentionConfigured(ctx context.Context) (bool, error) {
+	var configured bool
+	err := wp.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM organisations WHERE task_retention_days IS NOT NULL)
+		    OR EXISTS (SELECT 1 FROM plans WHERE task_retention_days IS NOT NULL)
+	`).Scan(&configured)
+	if err != nil {
+		return false, fmt.Errorf("failed to check task retention settings: %w", err)
+	}
+	return configured, nil
+}
+
+// sweepTaskRetention archives the tasks of up to taskRetentionJobLimit
+// expired jobs, oldest first. Retention comes from the organisation, then its
+// plan, then defaultDays. Retention is opt-in, so the sweep does nothing
+// unless defaultDays is set or a plan or organisation sets a window.
+func (wp *WorkerPool) sweepTaskRetention(ctx context.Context, defaultDays int) error {
+	if defaultDays <= 0 {
+		configured, err := wp.taskRetentionConfigured(ctx)
+		if err != nil || !configured {
+			return err
+		}
+	}
+
+	span := sentry.StartSpan(ctx, "jobs.sweep_task_retention")
+	defer span.Finish()
+
+	rows, err := wp.db.QueryContext(ctx, `
+		SELECT j.id
+		FROM jobs j
+		LEFT JOIN organisations o ON o.id = j.organisation_id
+		LEFT JOIN plans p ON p.id = o.plan_id
+		WHERE j.status IN ('completed', 'failed', 'cancelled')
+		  AND j.tasks_archived_at IS NULL
+		  AND COALESCE(o.task_retention_days, p.task_retention_days, NULLIF($1, 0)) IS NOT NULL
+		  AND COALESCE(j.completed_at, j.created_at) <
+		      NOW() - make_interval(days => COALESCE(o.task_retention_days, p.task_retention_days, $1))
+		ORDER BY COALESCE(j.completed_at, j.created_at)
+		LIMIT $2
+	`, defaultDays, taskRetentionJobLimit)
+	if err != nil {
+		return fmt.Errorf("failed to find jobs past task retention: %w", err)
+	}
+	var jobIDs []string
+	for rows.Next() {
+		var jobID string
+		if err := rows.Scan(&jobID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan job past task retention: %w", err)
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read jobs past task retention: %w", err)
+	}
+
+	archived := 0
+	for _, jobID := range jobIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		deleted, err := wp.archiveJobTasks(ctx, jobID)
+		if err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Int64("tasks_deleted", deleted).
+				Msg("Failed to archive job tasks, will retry next sweep")
+			continue
+		}
+		archived++
+		log.Info().Str("job_id", jobID).Int64("tasks_deleted", deleted).Msg("Archived job tasks past retention")
+	}
+	span.SetData("jobs_archived", archived)
+	return nil
+}
+
+// archiveJobTasks records the job's task summary in job_results, then deletes
+// its tasks in batches and marks the job archived. An interrupted archive is
+// picked up by the next sweep; the summary from the first attempt is kept.
+func (wp *WorkerPool) archiveJobTasks(ctx context.Context, jobID string) (int64, error) {
+	if _, err := wp.db.ExecContext(ctx, `
+		INSERT INTO job_results (
+			job_id, total_tasks, completed_tasks, failed_tasks, skipped_tasks,
+			avg_response_time_ms, cache_hits, cache_misses, status_codes, archived_at
+		)
+		SELECT
+			$1,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'completed'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			COUNT(*) FILTER (WHERE status = 'skipped'),
+			AVG(response_time) FILTER (WHERE response_time > 0)::INTEGER,
+			COUNT(*) FILTER (WHERE UPPER(cache_status) = 'HIT'),
+			COUNT(*) FILTER (WHERE UPPER(cache_status) = 'MISS'),
+			COALESCE((
+				SELECT jsonb_object_agg(status_code::TEXT, n)
+				FROM (
+					SELECT status_code, COUNT(*) AS n
+					FROM tasks
+					WHERE job_id = $1 AND status_code IS NOT NULL
+					GROUP BY status_code
+				) codes
+			), '{}'::jsonb),
+			NOW()
+		FROM tasks
+		WHERE job_id = $1
+		ON CONFLICT (job_id) DO NOTHING
+	`, jobID); err != nil {
+		return 0, fmt.Errorf("failed to record job results: %w", err)
+	}
+
+	var total int64
+	for {
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+		deleted, err := wp.deleteTaskBatch(ctx, jobID)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < taskRetentionBatchSize {
+			break
+		}
+	}
+
+	if _, err := wp.db.ExecContext(ctx, `
+		UPDATE jobs SET tasks_archived_at = NOW() WHERE id = $1
+	`, jobID); err != nil {
+		return total, fmt.Errorf("failed to mark job tasks archived: %w", err)
+	}
+	return total, nil
+}
+
+// deleteTaskBatch deletes up to taskRetentionBatchSize of the job's tasks in
+// its own transaction, with the counter triggers switched off so the job
+// keeps its totals
+func (wp *WorkerPool) deleteTaskBatch(ctx context.Context, jobID string) (int64, error) {
+	tx, err := wp.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin task delete transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('bbb.archiving_tasks', 'on', true)`); err != nil {
+		return 0, fmt.Errorf("failed to disable task counter triggers: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM tasks
+		WHERE id IN (
+			SELECT id FROM tasks
+			WHERE job_id = $1
+			LIMIT $2
+		)
+	`, jobID, taskRetentionBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete task batch: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted tasks: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit task delete: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepTaskRetentionArchivesExpiredJobs(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB}
+
+	mock.ExpectQuery("SELECT j.id").
+		WithArgs(30, taskRetentionJobLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("job-1"))
+	mock.ExpectExec("INSERT INTO job_results").
+		WithArgs("job-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// A full batch means there may be more, a short one that it's done
+	for _, deleted := range []int64{taskRetentionBatchSize, 12} {
+		mock.ExpectBegin()
+		mock.ExpectExec("set_config\\('bbb.archiving_tasks'").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM tasks").
+			WithArgs("job-1", taskRetentionBatchSize).
+			WillReturnResult(sqlmock.NewResult(0, deleted))
+		mock.ExpectCommit()
+	}
+	mock.ExpectExec("UPDATE jobs SET tasks_archived_at").
+		WithArgs("job-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, wp.sweepTaskRetention(context.Background(), 30))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSweepTaskRetentionLeavesJobUnarchivedOnDeleteError(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB}
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT j.id").
+		WithArgs(0, taskRetentionJobLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("job-1"))
+	mock.ExpectExec("INSERT INTO job_results").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec("set_config").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM tasks").WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	// The job isn't marked archived, so the next sweep finishes it
+	require.NoError(t, wp.sweepTaskRetention(context.Background(), 0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSweepTaskRetentionDoesNothingUntilConfigured(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	wp := &WorkerPool{db: mockDB}
+
+	// No default, plan or organisation retention: no jobs are looked up
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	require.NoError(t, wp.sweepTaskRetention(context.Background(), 0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultTaskRetentionDaysFromEnv(t *testing.T) {
+	t.Setenv("BBB_TASK_RETENTION_DAYS", "")
+	assert.Equal(t, 0, defaultTaskRetentionDaysFromEnv())
+
+	t.Setenv("BBB_TASK_RETENTION_DAYS", "45")
+	assert.Equal(t, 45, defaultTaskRetentionDaysFromEnv())
+
+	t.Setenv("BBB_TASK_RETENTION_DAYS", "-1")
+	assert.Equal(t, 0, defaultTaskRetentionDaysFromEnv())
+}
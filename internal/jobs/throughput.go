@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWorkerPoolUnavailable is returned when the job manager has no worker pool
+var ErrWorkerPoolUnavailable = errors.New("worker pool is not available")
+
+// throughputWindowSeconds is the sliding window used for tasks/second and hit rate
+const throughputWindowSeconds = 60
+
+type throughputBucket struct {
+	second int64
+	tasks  int
+	hits   int
+	misses int
+}
+
+// throughputTracker counts completed tasks in one-second buckets so recent
+// throughput can be read without touching the tasks table
+type throughputTracker struct {
+	mu      sync.Mutex
+	buckets [throughputWindowSeconds]throughputBucket
+}
+
+func (t *throughputTracker) record(now time.Time, cacheStatus string) {
+	second := now.Unix()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := &t.buckets[second%throughputWindowSeconds]
+	if b.second != second {
+		*b = throughputBucket{second: second}
+	}
+	b.tasks++
+	switch strings.ToUpper(cacheStatus) {
+	case "HIT":
+		b.hits++
+	case "MISS", "EXPIRED":
+		b.misses++
+	}
+}
+
+// totals sums buckets within the window ending at now
+func (t *throughputTracker) totals(now time.Time) (tasks, hits, misses int) {
+	oldest := now.Unix() - throughputWindowSeconds + 1
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, b := range t.buckets {
+		if b.second >= oldest && b.second <= now.Unix() {
+			tasks += b.tasks
+			hits += b.hits
+			misses += b.misses
+		}
+	}
+	return tasks, hits, misses
+}
+
+// SystemTaskCounts aggregates task counters across running jobs
+type SystemTaskCounts struct {
+	ActiveJobs int `json:"active_jobs"`
+	Pending    int `json:"pending_tasks"`
+	Waiting    int `json:"waiting_tasks"`
+	Running    int `json:"running_tasks"`
+	Completed  int `json:"completed_tasks"`
+	Failed     int `json:"failed_tasks"`
+}
+
+// systemTaskCounts reads aggregate counts from the jobs table's counters
+func (wp *WorkerPool) systemTaskCounts(ctx context.Context) (SystemTaskCounts, error) {
+	var counts SystemTaskCounts
+	err := wp.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) AS active_jobs,
+			COALESCE(SUM(pending_tasks), 0) AS pending,
+			COALESCE(SUM(waiting_tasks), 0) AS waiting,
+			COALESCE(SUM(running_tasks), 0) AS running,
+			COALESCE(SUM(completed_tasks), 0) AS completed,
+			COALESCE(SUM(failed_tasks), 0) AS failed
+		FROM jobs
+		WHERE status = 'running'
+	`).Scan(&counts.ActiveJobs, &counts.Pending, &counts.Waiting, &counts.Running, &counts.Completed, &counts.Failed)
+	return counts, err
+}
+
+// SystemThroughput is a system-wide operational snapshot. Throughput and
+// cache figures cover tasks completed by this instance in the last window.
+type SystemThroughput struct {
+	SystemTaskCounts
+	TasksPerSecond float64   `json:"tasks_per_second"`
+	WindowSeconds  int       `json:"window_seconds"`
+	CacheHits      int       `json:"cache_hits"`
+	CacheMisses    int       `json:"cache_misses"`
+	CacheHitRate   *float64  `json:"cache_hit_rate"` // nil until a HIT or MISS is seen
+	Workers        int       `json:"workers"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// SystemThroughput returns current throughput, worker and task counts
+func (wp *WorkerPool) SystemThroughput(ctx context.Context) (*SystemThroughput, error) {
+	counts, err := wp.systemTaskCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	tasks, hits, misses := wp.throughput.totals(now)
+
+	wp.workersMutex.RLock()
+	workers := wp.currentWorkers
+	wp.workersMutex.RUnlock()
+
+	snapshot := &SystemThroughput{
+		SystemTaskCounts: counts,
+		TasksPerSecond:   float64(tasks) / throughputWindowSeconds,
+		WindowSeconds:    throughputWindowSeconds,
+		CacheHits:        hits,
+		CacheMisses:      misses,
+		Workers:          workers,
+		GeneratedAt:      now,
+	}
+	if hits+misses > 0 {
+		rate := float64(hits) / float64(hits+misses)
+		snapshot.CacheHitRate = &rate
+	}
+	return snapshot, nil
+}
+
+// SystemThroughput returns the worker pool's system-wide throughput snapshot
+func (jm *JobManager) SystemThroughput(ctx context.Context) (*SystemThroughput, error) {
+	if jm.workerPool == nil {
+		return nil, ErrWorkerPoolUnavailable
+	}
+	return jm.workerPool.SystemThroughput(ctx)
+}
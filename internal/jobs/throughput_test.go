@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThroughputTrackerWindow(t *testing.T) {
+	var tracker throughputTracker
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tracker.record(start, "HIT")
+	tracker.record(start, "miss")
+	tracker.record(start.Add(10*time.Second), "EXPIRED")
+	tracker.record(start.Add(10*time.Second), "")
+
+	tasks, hits, misses := tracker.totals(start.Add(30 * time.Second))
+	assert.Equal(t, 4, tasks)
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 2, misses)
+
+	// First second has left the window
+	tasks, hits, misses = tracker.totals(start.Add(throughputWindowSeconds * time.Second))
+	assert.Equal(t, 2, tasks)
+	assert.Equal(t, 0, hits)
+	assert.Equal(t, 1, misses)
+
+	// A bucket reused a full window later starts fresh
+	tracker.record(start.Add(throughputWindowSeconds*time.Second), "HIT")
+	tasks, hits, _ = tracker.totals(start.Add(throughputWindowSeconds * time.Second))
+	assert.Equal(t, 3, tasks)
+	assert.Equal(t, 1, hits)
+}
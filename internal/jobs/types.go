@@ -1,7 +1,16 @@
 package jobs
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
 	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"golang.org/x/net/http/httpguts"
 )
 
 // JobStatus represents the current status of a job
@@ -27,43 +36,95 @@ const (
 	TaskStatusCompleted TaskStatus = "completed"
 	TaskStatusFailed    TaskStatus = "failed"
 	TaskStatusSkipped   TaskStatus = "skipped"
+
+	// TaskStatusDiscovered marks a dry-run URL; workers never claim it
+	TaskStatusDiscovered TaskStatus = "discovered"
 )
 
 // Maximum time a task can be "in progress" before being considered stale
 const (
 	TaskStaleTimeout = 3 * time.Minute
-	MaxTaskRetries   = 5
+	MaxTaskRetries   = 5 // Default when a job doesn't set MaxRetries
+)
+
+// Allowed range for per-job MaxRetries overrides
+const (
+	MinJobMaxRetries = 0
+	MaxJobMaxRetries = 10
+)
+
+// Per-job task processing timeout; overrides are clamped to the min/max
+const (
+	DefaultTaskTimeoutSeconds = 120
+	MinTaskTimeoutSeconds     = 30
+	MaxTaskTimeoutSeconds     = 600
 )
 
 // Job represents a crawling job for a domain
 // CHECK: Do all of these currently get utilised somewhere in the app?
 type Job struct {
-	ID              string    `json:"id"`
-	Domain          string    `json:"domain"`
-	UserID          *string   `json:"user_id,omitempty"`
-	OrganisationID  *string   `json:"organisation_id,omitempty"`
-	Status          JobStatus `json:"status"`
-	Progress        float64   `json:"progress"`
-	TotalTasks      int       `json:"total_tasks"`
-	CompletedTasks  int       `json:"completed_tasks"`
-	FailedTasks     int       `json:"failed_tasks"`
-	SkippedTasks    int       `json:"skipped_tasks"`
-	FoundTasks      int       `json:"found_tasks"`
-	SitemapTasks    int       `json:"sitemap_tasks"`
-	CreatedAt       time.Time `json:"created_at"`
-	StartedAt       time.Time `json:"started_at"`
-	CompletedAt     time.Time `json:"completed_at"`
-	Concurrency     int       `json:"concurrency"`
-	FindLinks       bool      `json:"find_links"`
-	MaxPages        int       `json:"max_pages"`
-	IncludePaths    []string  `json:"include_paths,omitempty"`
-	ExcludePaths    []string  `json:"exclude_paths,omitempty"`
-	RequiredWorkers int       `json:"required_workers"`
-	SourceType      *string   `json:"source_type,omitempty"`
-	SourceDetail    *string   `json:"source_detail,omitempty"`
-	SourceInfo      *string   `json:"source_info,omitempty"`
-	ErrorMessage    string    `json:"error_message,omitempty"`
-	SchedulerID     *string   `json:"scheduler_id,omitempty"`
+	ID                 string    `json:"id"`
+	Domain             string    `json:"domain"`
+	UserID             *string   `json:"user_id,omitempty"`
+	OrganisationID     *string   `json:"organisation_id,omitempty"`
+	Status             JobStatus `json:"status"`
+	Progress           float64   `json:"progress"`
+	TotalTasks         int       `json:"total_tasks"`
+	CompletedTasks     int       `json:"completed_tasks"`
+	FailedTasks        int       `json:"failed_tasks"`
+	SkippedTasks       int       `json:"skipped_tasks"`
+	FoundTasks         int       `json:"found_tasks"`
+	SitemapTasks       int       `json:"sitemap_tasks"`
+	CreatedAt          time.Time `json:"created_at"`
+	StartedAt          time.Time `json:"started_at"`
+	CompletedAt        time.Time `json:"completed_at"`
+	Concurrency        int       `json:"concurrency"`
+	VerifyConcurrency  int       `json:"verify_concurrency"`
+	FindLinks          bool      `json:"find_links"`
+	MaxPages           int       `json:"max_pages"`
+	MaxDepth           int       `json:"max_depth"`
+	IncludePaths       []string  `json:"include_paths,omitempty"`
+	ExcludePaths       []string  `json:"exclude_paths,omitempty"`
+	RequiredWorkers    int       `json:"required_workers"`
+	MaxRetries         int       `json:"max_retries"`
+	RetryBudget        int       `json:"retry_budget,omitempty"` // Total retries across all tasks; 0 for no cap
+	TaskTimeoutSeconds int       `json:"task_timeout_seconds"`
+
+	ConcurrencySchedule  *ConcurrencySchedule `json:"concurrency_schedule,omitempty"`
+	CacheableStatusCodes []int                `json:"cacheable_status_codes,omitempty"`
+	ChangedOnly          bool                 `json:"changed_only"`
+	SkipCachedURLs       bool                 `json:"skip_cached_urls"`
+	ContentTypes         []string             `json:"content_types,omitempty"`
+	URLScheme            URLScheme            `json:"url_scheme"`
+	Tags                 []string             `json:"tags,omitempty"`
+	PriorityTier         PriorityTier         `json:"priority_tier"`
+	SlowOriginPolicy     SlowOriginPolicy     `json:"slow_origin_policy"`
+	AutoConcurrency      bool                 `json:"auto_concurrency"`
+	UserAgent            string               `json:"user_agent,omitempty"`
+	CustomHeaders        map[string]string    `json:"custom_headers,omitempty"`
+	ConditionalWarm      bool                 `json:"conditional_warm"`
+	DetectSoft404        bool                 `json:"detect_soft_404"`
+	HashContent          bool                 `json:"hash_content"`
+	FullBodyDetection    bool                 `json:"full_body_detection"`
+	NotifyWebhookURL     string               `json:"notify_webhook_url,omitempty"`
+	PurgeBeforeWarm      bool                 `json:"purge_before_warm"`
+	DryRun               bool                 `json:"dry_run"`
+	HasCredentials       bool                 `json:"has_credentials"` // Site credentials are stored in Vault; never returned
+	Method               WarmMethod           `json:"method"`
+	GroupSubdomains      bool                 `json:"group_subdomains"`
+	SecondRequest        bool                 `json:"second_request"`
+	MinCrawlDelaySeconds int                  `json:"min_crawl_delay_seconds"`
+	MaxCrawlDelaySeconds int                  `json:"max_crawl_delay_seconds"`
+	PrioritiseBySearch   bool                 `json:"prioritise_by_search"`
+	MaxRuntimeMinutes    int                  `json:"max_runtime_minutes"`
+	MaxRuntimeAction     MaxRuntimeAction     `json:"max_runtime_action"`
+	SourceType           *string              `json:"source_type,omitempty"`
+	SourceDetail         *string              `json:"source_detail,omitempty"`
+	SourceInfo           *string              `json:"source_info,omitempty"`
+	ErrorMessage         string               `json:"error_message,omitempty"`
+	WarningMessage       string               `json:"warning_message,omitempty"` // Non-fatal problems, e.g. a failed pre-warm purge
+	SchedulerID          *string              `json:"scheduler_id,omitempty"`
+	Reused               bool                 `json:"-"` // Returned by CreateJob in place of a new job under DuplicateReuse
 	// Calculated fields from database
 	DurationSeconds       *int     `json:"duration_seconds,omitempty"`
 	AvgTimePerTaskSeconds *float64 `json:"avg_time_per_task_seconds,omitempty"`
@@ -85,8 +146,9 @@ type Task struct {
 	Error       string     `json:"error,omitempty"`
 
 	// Source information
-	SourceType string `json:"source_type"`          // "sitemap", "link", "manual"
+	SourceType string `json:"source_type"`          // "sitemap", "link", "manual", "warm_list", "seed"
 	SourceURL  string `json:"source_url,omitempty"` // URL where this was discovered (for find_links)
+	Depth      int    `json:"depth"`                // Links followed from a root, sitemap or seed URL
 
 	// Result data
 	StatusCode         int    `json:"status_code,omitempty"`
@@ -103,26 +165,323 @@ type Task struct {
 	FindLinks          bool `json:"-"`
 	CrawlDelay         int  `json:"-"` // Crawl delay in seconds from robots.txt
 	JobConcurrency     int  `json:"-"`
+	VerifyConcurrency  int  `json:"-"` // 0 = verification shares the warming slot
 	AdaptiveDelay      int  `json:"-"`
 	AdaptiveDelayFloor int  `json:"-"`
+
+	ConcurrencySchedule  *ConcurrencySchedule `json:"-"` // Time-of-day concurrency, nil when unset
+	CacheableStatusCodes []int                `json:"-"` // Non-2xx codes warmed as successes
+	ChangedOnly          bool                 `json:"-"` // Skip pages unchanged since the previous job
+	SkipCachedURLs       bool                 `json:"-"` // Skip pages the edge already reports as a cache HIT
+	ContentTypes         []string             `json:"-"` // Media types warmed; others are skipped once headers arrive
+	URLScheme            URLScheme            `json:"-"` // Scheme pages are warmed over; empty means https
+	UserAgent            string               `json:"-"` // Per-job user agent override, empty for the crawler default
+	CustomHeaders        map[string]string    `json:"-"` // Extra request headers sent on every warming request
+	ConditionalWarm      bool                 `json:"-"` // Send the previous job's validators so unchanged pages return 304
+	TaskTimeout          time.Duration        `json:"-"` // Processing limit for this task, including waits for the domain limiter
+	Validators           crawler.Validators   `json:"-"` // Previous ETag/Last-Modified, loaded for conditional warms
+	Credentials          crawler.Credentials  `json:"-"` // Site credentials sent as an Authorization header
+	Method               WarmMethod           `json:"-"` // GET, or HEAD to prime the cache without transferring bodies
+	GroupSubdomains      bool                 `json:"-"` // Pace requests under the registrable domain rather than the host
+	SecondRequest        bool                 `json:"-"` // Re-fetch cache misses to measure the warmed response
+	MinCrawlDelay        int                  `json:"-"` // Job's crawl delay floor in seconds, held even under the robots multiplier
+	DenyHosts            []string             `json:"-"` // Organisation host patterns that are never crawled
 }
 
 // JobOptions defines configuration options for a crawl job
 type JobOptions struct {
-	Domain          string   `json:"domain"`
-	UserID          *string  `json:"user_id,omitempty"`
-	OrganisationID  *string  `json:"organisation_id,omitempty"`
-	UseSitemap      bool     `json:"use_sitemap"`
-	Concurrency     int      `json:"concurrency"`
-	FindLinks       bool     `json:"find_links"`
-	MaxPages        int      `json:"max_pages"`
-	IncludePaths    []string `json:"include_paths,omitempty"`
-	ExcludePaths    []string `json:"exclude_paths,omitempty"`
-	RequiredWorkers int      `json:"required_workers"`
-	SourceType      *string  `json:"source_type,omitempty"`
-	SourceDetail    *string  `json:"source_detail,omitempty"`
-	SourceInfo      *string  `json:"source_info,omitempty"`
-	SchedulerID     *string  `json:"scheduler_id,omitempty"`
+	Domain             string   `json:"domain"`
+	UserID             *string  `json:"user_id,omitempty"`
+	OrganisationID     *string  `json:"organisation_id,omitempty"`
+	UseSitemap         bool     `json:"use_sitemap"`
+	Concurrency        int      `json:"concurrency"`        // Warming phase (first request) concurrency
+	VerifyConcurrency  int      `json:"verify_concurrency"` // Verification phase concurrency; 0 shares the warming limit
+	FindLinks          bool     `json:"find_links"`
+	MaxPages           int      `json:"max_pages"`
+	MaxDepth           int      `json:"max_depth,omitempty"` // Deepest discovered link followed; 0 for no limit
+	IncludePaths       []string `json:"include_paths,omitempty"`
+	ExcludePaths       []string `json:"exclude_paths,omitempty"`
+	RequiredWorkers    int      `json:"required_workers"`
+	MaxRetries         *int     `json:"max_retries,omitempty"`          // Overrides MaxTaskRetries when set
+	RetryBudget        int      `json:"retry_budget,omitempty"`         // Total retries across all tasks; 0 for no cap
+	TaskTimeoutSeconds int      `json:"task_timeout_seconds,omitempty"` // 0 uses DefaultTaskTimeoutSeconds; clamped to the allowed range
+	SourceType         *string  `json:"source_type,omitempty"`
+	SourceDetail       *string  `json:"source_detail,omitempty"`
+	SourceInfo         *string  `json:"source_info,omitempty"`
+	SchedulerID        *string  `json:"scheduler_id,omitempty"`
+
+	ConcurrencySchedule  *ConcurrencySchedule `json:"concurrency_schedule,omitempty"`    // Lowers concurrency during set hours
+	CacheableStatusCodes []int                `json:"cacheable_status_codes,omitempty"`  // Error pages the CDN caches deliberately
+	ChangedOnly          bool                 `json:"changed_only,omitempty"`            // Only warm pages whose ETag/Last-Modified changed
+	SkipCachedURLs       bool                 `json:"skip_cached_urls,omitempty"`        // Skip the full warm when a HEAD probe finds the page already cached at the edge
+	ContentTypes         []string             `json:"content_types,omitempty"`           // Media types to warm, e.g. image/* or */*; defaults to HTML, or */* with warm or seed URLs
+	URLScheme            URLScheme            `json:"url_scheme,omitempty"`              // https (default) or http for sites that only serve plain HTTP
+	Tags                 []string             `json:"tags,omitempty"`                    // Labels such as pre-deploy or nightly for filtering job history
+	PriorityTier         PriorityTier         `json:"priority_tier,omitempty"`           // high, normal (default) or low
+	SlowOriginPolicy     SlowOriginPolicy     `json:"slow_origin_policy,omitempty"`      // boost (default) or back_off when the origin slows
+	AutoConcurrency      bool                 `json:"auto_concurrency,omitempty"`        // Tune concurrency from p95 latency and errors, up to Concurrency
+	UserAgent            string               `json:"user_agent,omitempty"`              // Overrides the crawler user agent, e.g. for WAF allow-lists
+	CustomHeaders        map[string]string    `json:"custom_headers,omitempty"`          // Extra headers on warming requests, e.g. to tag traffic for analytics exclusion
+	ConditionalWarm      bool                 `json:"conditional_warm,omitempty"`        // Send If-None-Match/If-Modified-Since; 304s count as warmed
+	DetectSoft404        bool                 `json:"detect_soft_404,omitempty"`         // Flag 200 pages that look like "not found" pages, e.g. dead sitemap entries
+	HashContent          bool                 `json:"hash_content,omitempty"`            // Hash page bodies to report which pages changed since the last warm
+	FullBodyDetection    bool                 `json:"full_body_detection,omitempty"`     // Detect technologies from the whole page rather than the body sample
+	NotifyWebhookURL     string               `json:"notify_webhook_url,omitempty"`      // Signed POST when the job completes, fails or is cancelled
+	PurgeBeforeWarm      bool                 `json:"purge_before_warm,omitempty"`       // Purge sitemap URLs from the organisation's CDN before warming
+	WarmURLs             []string             `json:"warm_urls,omitempty"`               // Explicit URLs/paths to warm instead of sitemap or root discovery
+	SeedURLs             []string             `json:"seed_urls,omitempty"`               // Client-supplied URLs on the job's domain to warm instead of sitemap or root discovery, after robots.txt
+	FreshnessWindowDays  *int                 `json:"freshness_window_days,omitempty"`   // Boost sitemap pages modified within this many days; 0 disables
+	DryRun               bool                 `json:"dry_run,omitempty"`                 // Discover and list URLs without warming them
+	Credentials          *crawler.Credentials `json:"-"`                                 // Basic auth or bearer token for protected sites; stored in Vault
+	Method               WarmMethod           `json:"method,omitempty"`                  // GET (default) or HEAD; HEAD skips link discovery and tech detection
+	GroupSubdomains      bool                 `json:"group_subdomains,omitempty"`        // Share the domain limiter with other subdomains of the same registrable domain
+	SecondRequest        bool                 `json:"second_request"`                    // Re-fetch cache misses to measure the warmed response (callers default to true); false only primes the cache
+	MinCrawlDelaySeconds int                  `json:"min_crawl_delay_seconds,omitempty"` // Floor on the robots.txt Crawl-delay; 0 for none
+	MaxCrawlDelaySeconds int                  `json:"max_crawl_delay_seconds,omitempty"` // Ceiling on the robots.txt Crawl-delay; 0 for none
+	PrioritiseBySearch   bool                 `json:"prioritise_by_search,omitempty"`    // Boost pages by Search Console impressions when the site is connected
+	MaxRuntimeMinutes    int                  `json:"max_runtime_minutes,omitempty"`     // Stop the job this long after it starts; 0 for no limit
+	MaxRuntimeAction     MaxRuntimeAction     `json:"max_runtime_action,omitempty"`      // fail (default) or complete with the pages warmed so far
+	OnDuplicate          DuplicatePolicy      `json:"on_duplicate,omitempty"`            // cancel (default), reuse or error when the domain already has an active job
+
+	// uncappedWarmList marks a warm list built from a finished job's pages,
+	// which can be as long as that job was and so skips MaxWarmURLs
+	uncappedWarmList bool
+}
+
+// ErrInvalidJobOptions matches CreateJob errors caused by the options
+// themselves, which callers should report as a bad request
+var ErrInvalidJobOptions = errors.New("invalid job options")
+
+// invalidJobOptionsError keeps a validator's message while matching
+// ErrInvalidJobOptions with errors.Is
+type invalidJobOptionsError struct {
+	err error
+}
+
+func (e *invalidJobOptionsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidJobOptionsError) Unwrap() []error {
+	return []error{ErrInvalidJobOptions, e.err}
+}
+
+func invalidJobOptions(err error) error {
+	return &invalidJobOptionsError{err: err}
+}
+
+// ValidateMaxRetries checks a per-job retry override is within the allowed range
+func ValidateMaxRetries(maxRetries int) error {
+	if maxRetries < MinJobMaxRetries || maxRetries > MaxJobMaxRetries {
+		return fmt.Errorf("max_retries must be between %d and %d", MinJobMaxRetries, MaxJobMaxRetries)
+	}
+	return nil
+}
+
+// maxCacheableStatusCodes bounds how many status codes a job can list
+const maxCacheableStatusCodes = 20
+
+// ValidateCacheableStatusCodes checks the status codes a job treats as
+// successful warms. Only 4xx and 5xx codes can be listed.
+func ValidateCacheableStatusCodes(codes []int) error {
+	if len(codes) > maxCacheableStatusCodes {
+		return fmt.Errorf("cacheable_status_codes supports at most %d codes", maxCacheableStatusCodes)
+	}
+	for _, code := range codes {
+		if code < 400 || code > 599 {
+			return fmt.Errorf("cacheable_status_codes must be 4xx or 5xx, got %d", code)
+		}
+	}
+	return nil
+}
+
+// maxUserAgentLength bounds a per-job user agent override
+const maxUserAgentLength = 512
+
+// ValidateUserAgent checks a user agent override is safe to send as a header
+func ValidateUserAgent(userAgent string) error {
+	if len(userAgent) > maxUserAgentLength {
+		return fmt.Errorf("user_agent must be at most %d characters", maxUserAgentLength)
+	}
+	if strings.TrimSpace(userAgent) != userAgent {
+		return fmt.Errorf("user_agent must not have leading or trailing whitespace")
+	}
+	for _, r := range userAgent {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("user_agent must not contain control characters")
+		}
+	}
+	return nil
+}
+
+// maxCustomHeaders bounds how many extra headers a job can send
+const maxCustomHeaders = 20
+
+// maxCustomHeaderValueLength bounds each custom header value
+const maxCustomHeaderValueLength = 1024
+
+// protectedHeaders can't be set as custom headers because the crawler manages
+// them, or another job option sets them. Keys are canonical header names.
+var protectedHeaders = map[string]string{
+	"Host":              "",
+	"User-Agent":        "use user_agent instead",
+	"Authorization":     "use credentials instead",
+	"Accept-Encoding":   "",
+	"Connection":        "",
+	"Content-Length":    "",
+	"Transfer-Encoding": "",
+	"Te":                "",
+	"Upgrade":           "",
+	"If-None-Match":     "use conditional_warm instead",
+	"If-Modified-Since": "use conditional_warm instead",
+}
+
+// ValidateCustomHeaders checks per-job request headers are well formed and
+// don't override headers the crawler manages
+func ValidateCustomHeaders(headers map[string]string) error {
+	if len(headers) > maxCustomHeaders {
+		return fmt.Errorf("custom_headers supports at most %d headers", maxCustomHeaders)
+	}
+
+	seen := make(map[string]bool, len(headers))
+	for name, value := range headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("custom_headers: invalid header name %q", name)
+		}
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		if hint, ok := protectedHeaders[canonical]; ok {
+			if hint != "" {
+				return fmt.Errorf("custom_headers: %s can't be overridden; %s", canonical, hint)
+			}
+			return fmt.Errorf("custom_headers: %s can't be overridden", canonical)
+		}
+		if seen[canonical] {
+			return fmt.Errorf("custom_headers: %s is set more than once", canonical)
+		}
+		seen[canonical] = true
+
+		if len(value) > maxCustomHeaderValueLength {
+			return fmt.Errorf("custom_headers: %s must be at most %d characters", canonical, maxCustomHeaderValueLength)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("custom_headers: %s must not contain control characters", canonical)
+		}
+	}
+	return nil
+}
+
+// serialiseCustomHeaders stores no headers as NULL rather than an empty object
+func serialiseCustomHeaders(headers map[string]string) any {
+	if len(headers) == 0 {
+		return nil
+	}
+	return db.Serialise(headers)
+}
+
+// parseCustomHeaders reads a jobs.custom_headers value; NULL is no headers
+func parseCustomHeaders(raw []byte) (map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var headers map[string]string
+	if err := json.Unmarshal(raw, &headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custom headers: %w", err)
+	}
+	return headers, nil
+}
+
+// maxCredentialLength bounds each credential field
+const maxCredentialLength = 1024
+
+// ValidateCredentials checks site credentials are usable as an Authorization
+// header: either a username and password or a bearer token, not both
+func ValidateCredentials(creds crawler.Credentials) error {
+	if creds.BearerToken != "" && (creds.Username != "" || creds.Password != "") {
+		return fmt.Errorf("credentials must use either username and password or bearer_token, not both")
+	}
+	if creds.BearerToken == "" && creds.Username == "" {
+		return fmt.Errorf("credentials require a username or bearer_token")
+	}
+	if strings.Contains(creds.Username, ":") {
+		return fmt.Errorf("credentials username must not contain ':'")
+	}
+	fields := []struct{ name, value string }{
+		{"username", creds.Username},
+		{"password", creds.Password},
+		{"bearer_token", creds.BearerToken},
+	}
+	for _, field := range fields {
+		if len(field.value) > maxCredentialLength {
+			return fmt.Errorf("credentials %s must be at most %d characters", field.name, maxCredentialLength)
+		}
+		for _, r := range field.value {
+			if r < 0x20 || r == 0x7f {
+				return fmt.Errorf("credentials %s must not contain control characters", field.name)
+			}
+		}
+	}
+	return nil
+}
+
+// statusCodesToInt64 converts status codes for an INTEGER[] column
+func statusCodesToInt64(codes []int) []int64 {
+	out := make([]int64, len(codes))
+	for i, code := range codes {
+		out[i] = int64(code)
+	}
+	return out
+}
+
+// statusCodesFromInt64 converts a scanned INTEGER[] column; empty means none
+func statusCodesFromInt64(codes []int64) []int {
+	if len(codes) == 0 {
+		return nil
+	}
+	out := make([]int, len(codes))
+	for i, code := range codes {
+		out[i] = int(code)
+	}
+	return out
+}
+
+// WarmMethod is the HTTP method a job warms pages with
+type WarmMethod string
+
+const (
+	// WarmMethodGet fetches full pages (default)
+	WarmMethodGet WarmMethod = "GET"
+	// WarmMethodHead primes CDNs that cache on HEAD, recording only status,
+	// timing and cache headers
+	WarmMethodHead WarmMethod = "HEAD"
+)
+
+// ParseWarmMethod validates a warm method; empty means GET
+func ParseWarmMethod(raw string) (WarmMethod, error) {
+	switch method := WarmMethod(strings.ToUpper(strings.TrimSpace(raw))); method {
+	case "":
+		return WarmMethodGet, nil
+	case WarmMethodGet, WarmMethodHead:
+		return method, nil
+	default:
+		return "", fmt.Errorf("method must be GET or HEAD, got %q", raw)
+	}
+}
+
+// ClampTaskTimeoutSeconds returns the task timeout a job runs with: the default
+// when unset, otherwise the requested value clamped to the allowed range
+func ClampTaskTimeoutSeconds(seconds int) int {
+	if seconds <= 0 {
+		return DefaultTaskTimeoutSeconds
+	}
+	return min(max(seconds, MinTaskTimeoutSeconds), MaxTaskTimeoutSeconds)
+}
+
+// effectiveMaxRetries returns the job's retry limit, falling back to MaxTaskRetries
+func (o *JobOptions) effectiveMaxRetries() int {
+	if o == nil || o.MaxRetries == nil {
+		return MaxTaskRetries
+	}
+	return *o.MaxRetries
 }
 
 // QuotaExceededError represents when an org has exceeded their daily quota
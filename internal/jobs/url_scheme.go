@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+// URLScheme is the scheme a job warms pages over. Pages are stored as paths,
+// so sitemap entries listed with the other scheme are canonicalised when the
+// task URL is built rather than followed through a redirect.
+type URLScheme string
+
+const (
+	// URLSchemeHTTPS warms every page over HTTPS (default)
+	URLSchemeHTTPS URLScheme = "https"
+	// URLSchemeHTTP warms over plain HTTP, for sites that genuinely serve it
+	URLSchemeHTTP URLScheme = "http"
+)
+
+// ParseURLScheme validates a scheme name; empty means https
+func ParseURLScheme(raw string) (URLScheme, error) {
+	switch scheme := URLScheme(strings.ToLower(strings.TrimSpace(raw))); scheme {
+	case "":
+		return URLSchemeHTTPS, nil
+	case URLSchemeHTTPS, URLSchemeHTTP:
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("url_scheme must be https or http")
+	}
+}
+
+// applyURLScheme rewrites an https URL to the job's scheme
+func applyURLScheme(rawURL string, scheme URLScheme) string {
+	if scheme != URLSchemeHTTP {
+		return rawURL
+	}
+	if rest, ok := strings.CutPrefix(rawURL, "https://"); ok {
+		return "http://" + rest
+	}
+	return rawURL
+}
+
+// countListedSchemes counts sitemap entries listed with http:// and https://
+func countListedSchemes(entries []crawler.SitemapURL) (httpURLs, httpsURLs int) {
+	for _, entry := range entries {
+		switch entry.ListedScheme {
+		case "http":
+			httpURLs++
+		case "https":
+			httpsURLs++
+		}
+	}
+	return httpURLs, httpsURLs
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURLScheme(t *testing.T) {
+	scheme, err := ParseURLScheme("")
+	require.NoError(t, err)
+	assert.Equal(t, URLSchemeHTTPS, scheme)
+
+	scheme, err = ParseURLScheme(" HTTP ")
+	require.NoError(t, err)
+	assert.Equal(t, URLSchemeHTTP, scheme)
+
+	_, err = ParseURLScheme("ftp")
+	assert.Error(t, err)
+}
+
+func TestConstructTaskURLAppliesScheme(t *testing.T) {
+	assert.Equal(t, "http://example.com/about", constructTaskURL("/about", "example.com", URLSchemeHTTP))
+	assert.Equal(t, "http://example.com/about", constructTaskURL("https://example.com/about", "", URLSchemeHTTP))
+	assert.Equal(t, "https://example.com/about", constructTaskURL("http://example.com/about", "", URLSchemeHTTPS))
+}
+
+func TestParseSitemapsCountsListedSchemes(t *testing.T) {
+	c := &reportingCrawler{results: map[string]*crawler.SitemapParseResult{
+		"https://example.com/sitemap.xml": {Loaded: 1, URLs: []crawler.SitemapURL{
+			{URL: "https://example.com/", ListedScheme: "https"},
+			{URL: "https://example.com/old", ListedScheme: "http"},
+			{URL: "https://example.com/older", ListedScheme: "http"},
+			{URL: "https://example.com/bare"},
+		}},
+	}}
+
+	_, report := parseSitemaps(t.Context(), c, []string{"https://example.com/sitemap.xml"})
+	assert.Equal(t, 2, report.HTTPURLs)
+	assert.Equal(t, 1, report.HTTPSURLs)
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import "context"
+
+// effectiveQueueConcurrency is the in-flight task budget used by the queue's
+// claim logic: the limiter's effective warming concurrency plus any slots
+// reserved for the verification phase.
+func (wp *WorkerPool) effectiveQueueConcurrency(jobID, domain string) int {
+	wp.jobInfoMutex.RLock()
+	info, ok := wp.jobInfoCache[jobID]
+	wp.jobInfoMutex.RUnlock()
+	if ok && info != nil {
+		// The queue passes the job's host; grouped jobs are limited under
+		// their registrable domain
+		domain = limiterDomain(domain, info.GroupSubdomains)
+	}
+
+	allowed := wp.domainLimiter.GetEffectiveConcurrency(jobID, domain)
+	if allowed <= 0 {
+		return allowed
+	}
+
+	if ok && info != nil {
+		allowed += info.VerifyConcurrency
+	}
+	return allowed
+}
+
+// acquireVerifySlot blocks until one of the job's verification slots is free.
+// The returned function releases the slot and is safe to call once.
+func (wp *WorkerPool) acquireVerifySlot(ctx context.Context, jobID string, limit int) (func(), error) {
+	wp.verifySlotsMutex.Lock()
+	slots, ok := wp.verifySlots[jobID]
+	if !ok || cap(slots) != limit {
+		slots = make(chan struct{}, limit)
+		wp.verifySlots[jobID] = slots
+	}
+	wp.verifySlotsMutex.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
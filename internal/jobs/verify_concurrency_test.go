@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireVerifySlotLimitsConcurrency(t *testing.T) {
+	wp := &WorkerPool{verifySlots: make(map[string]chan struct{})}
+	ctx := context.Background()
+
+	release1, err := wp.acquireVerifySlot(ctx, "job-1", 2)
+	require.NoError(t, err)
+	release2, err := wp.acquireVerifySlot(ctx, "job-1", 2)
+	require.NoError(t, err)
+
+	// Third slot blocks until the context expires
+	blockedCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = wp.acquireVerifySlot(blockedCtx, "job-1", 2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Other jobs have their own slots
+	releaseOther, err := wp.acquireVerifySlot(ctx, "job-2", 1)
+	require.NoError(t, err)
+	releaseOther()
+
+	release1()
+	release3, err := wp.acquireVerifySlot(ctx, "job-1", 2)
+	require.NoError(t, err)
+
+	release2()
+	release3()
+}
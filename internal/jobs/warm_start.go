@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	warmStartTimeout = 30 * time.Second
+	// warmStartRobotsConcurrency bounds parallel robots.txt fetches during warm start
+	warmStartRobotsConcurrency = 5
+)
+
+// warmStartEnabledFromEnv reports whether BBB_WORKER_WARM_START is enabled
+func warmStartEnabledFromEnv() bool {
+	v := strings.TrimSpace(os.Getenv("BBB_WORKER_WARM_START"))
+	return v == "1" || v == "true" || v == "TRUE"
+}
+
+// warmStart pre-seeds the job info cache, domain limiter and robots rules for
+// domains with active jobs, so a restart doesn't trigger a burst of robots.txt
+// fetches and limiter cold-starts as workers begin claiming tasks.
+// Best-effort: failures are logged and startup continues.
+func (wp *WorkerPool) warmStart(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, warmStartTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	var jobIDs []string
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id FROM jobs
+			WHERE status IN ($1, $2)
+		`, JobStatusRunning, JobStatusPending)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var jobID string
+			if err := rows.Scan(&jobID); err != nil {
+				return err
+			}
+			jobIDs = append(jobIDs, jobID)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Worker pool warm start failed to load active jobs")
+		return
+	}
+
+	// Group job info by domain, user agent and credentials so robots.txt is
+	// fetched once per combination; jobs with an override need rules matched
+	// against their agent, and protected sites need their credentials
+	type robotsKey struct {
+		domain, userAgent string
+		credentials       crawler.Credentials
+	}
+	jobsByRobots := make(map[robotsKey][]*JobInfo)
+	for _, jobID := range jobIDs {
+		info, err := wp.loadJobInfo(ctx, jobID, nil)
+		if err != nil {
+			log.Warn().Err(err).Str("job_id", jobID).Msg("Worker pool warm start failed to load job info")
+			continue
+		}
+		wp.ensureDomainLimiter().Seed(info.limiterDomain(), info.CrawlDelay, info.AdaptiveDelay, info.AdaptiveDelayFloor)
+		key := robotsKey{domain: info.DomainName, userAgent: info.UserAgent, credentials: info.Credentials}
+		jobsByRobots[key] = append(jobsByRobots[key], info)
+	}
+
+	sem := make(chan struct{}, warmStartRobotsConcurrency)
+	var wg sync.WaitGroup
+	for key, infos := range jobsByRobots {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			robotsCtx := crawler.WithCredentials(ctx, key.credentials)
+			rules := wp.fetchRobotsRules(robotsCtx, key.domain, key.userAgent)
+			if rules == nil {
+				// Left unset so AddJob fetches them again rather than
+				// treating the failure as allow-all
+				return
+			}
+
+			wp.jobInfoMutex.Lock()
+			for _, info := range infos {
+				if info.RobotsRules == nil {
+					info.RobotsRules = rules
+				}
+			}
+			wp.jobInfoMutex.Unlock()
+		})
+	}
+	wg.Wait()
+
+	log.Info().
+		Int("jobs", len(jobIDs)).
+		Int("robots_fetches", len(jobsByRobots)).
+		Dur("duration", time.Since(start)).
+		Msg("Worker pool warm start completed")
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmStartEnabledFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{value: "", expected: false},
+		{value: "0", expected: false},
+		{value: "false", expected: false},
+		{value: "1", expected: true},
+		{value: "true", expected: true},
+		{value: " TRUE ", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("BBB_WORKER_WARM_START", tt.value)
+			assert.Equal(t, tt.expected, warmStartEnabledFromEnv())
+		})
+	}
+}
+
+func TestFetchRobotsRulesLeavesRulesUnsetOnFailure(t *testing.T) {
+	// A warm start that runs out of time mustn't be cached as allow-all
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wp := &WorkerPool{}
+	assert.Nil(t, wp.fetchRobotsRules(ctx, "example.com", "TestBot/1.0"))
+}
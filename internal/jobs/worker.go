@@ -12,8 +12,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"regexp"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,10 +22,12 @@ import (
 
 	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
 	"github.com/Harvey-AU/blue-banded-bee/internal/db"
+	"github.com/Harvey-AU/blue-banded-bee/internal/events"
 	"github.com/Harvey-AU/blue-banded-bee/internal/observability"
 	"github.com/Harvey-AU/blue-banded-bee/internal/storage"
 	"github.com/Harvey-AU/blue-banded-bee/internal/techdetect"
 	"github.com/Harvey-AU/blue-banded-bee/internal/util"
+	"github.com/Harvey-AU/blue-banded-bee/internal/webhook"
 	"github.com/getsentry/sentry-go"
 	"github.com/jackc/pgx/v5"
 	"github.com/lib/pq"
@@ -36,9 +38,11 @@ import (
 )
 
 const (
-	taskProcessingTimeout      = 2 * time.Minute
+	taskProcessingTimeout      = DefaultTaskTimeoutSeconds * time.Second // Unless the job sets task_timeout_seconds
 	poolSaturationBackoff      = 2 * time.Second
 	defaultJobFailureThreshold = 20
+	defaultIdleThreshold       = 5
+	defaultScaleCooldown       = 30 * time.Second
 	defaultRunningTaskBatch    = 4
 	defaultRunningTaskFlush    = 50 * time.Millisecond
 	discoveredLinksDBTimeout   = 30 * time.Second
@@ -81,6 +85,16 @@ type JobPerformance struct {
 	LastCheck    time.Time // When we last evaluated this job
 	// LastConcurrencyBlock captures when this job last hit a concurrency cap.
 	LastConcurrencyBlock time.Time
+	// Latency back-off state, only used by jobs with the back_off slow origin policy
+	BaselineResponseTime float64   // EWMA of healthy average response times (ms)
+	LatencyCap           int       // Current concurrency cap, 0 when uncapped
+	LastLatencyAdjust    time.Time // When LatencyCap last changed
+	// Error back-off state, driven by the origin's 5xx and block responses
+	Errors          errorWindow // Rolling window of recent request outcomes
+	ErrorCap        int         // Current concurrency cap, 0 when uncapped
+	LastErrorAdjust time.Time   // When ErrorCap last changed
+	// Auto concurrency state, nil unless the job's concurrency is auto-tuned
+	Auto *autoConcurrencyState
 }
 
 type WorkerPool struct {
@@ -92,11 +106,16 @@ type WorkerPool struct {
 	batchManager     *db.BatchManager // Batch manager for task updates
 	numWorkers       int
 	jobs             map[string]bool
+	pausedJobs       map[string]bool // Jobs paused through this pool; guarded by jobsMutex
 	jobsMutex        sync.RWMutex
 	stopCh           chan struct{}
 	wg               sync.WaitGroup
 	recoveryInterval time.Duration
 	stopping         atomic.Bool
+	started          atomic.Bool   // Set once Start has launched workers and monitors
+	drainCh          chan struct{} // Closed by Drain so workers stop claiming tasks
+	draining         atomic.Bool
+	workersWG        sync.WaitGroup // Worker goroutines only, so Drain can wait on in-flight tasks
 	activeJobs       sync.WaitGroup
 	baseWorkerCount  int
 	currentWorkers   int
@@ -129,16 +148,51 @@ type WorkerPool struct {
 	priorityMutex         sync.Mutex
 	priorityUpdateTracker map[string]*priorityUpdateState
 
+	// HTTP -> HTTPS redirect probes per job
+	httpsCheckMutex  sync.Mutex
+	httpsCheckCounts map[string]int
+
+	// Verification phase slots per job (only for jobs with VerifyConcurrency set)
+	verifySlotsMutex sync.Mutex
+	verifySlots      map[string]chan struct{}
+
+	// Recent completions for the admin throughput snapshot
+	throughput throughputTracker
+
+	// Jobs last reported in the per-job backlog gauges; only the task monitor
+	// touches it
+	backlogJobs map[string]struct{}
+
+	// Capacity reserved for high priority tier jobs
+	highPriorityReservePercent int          // from BBB_HIGH_PRIORITY_RESERVE_PERCENT (default 20, 0 = disabled)
+	standardTierInFlight       atomic.Int64 // Tasks in flight for normal and low tier jobs
+
+	// Latency spike threshold for back_off slow origin policy jobs
+	latencySpikeMultiplier float64 // from BBB_LATENCY_SPIKE_MULTIPLIER (default 3)
+
+	// Error rate that cuts a job's concurrency on its domain
+	errorBackoffThreshold float64 // from BBB_ERROR_BACKOFF_THRESHOLD (default 0.2)
+
 	// Idle worker scaling
 	idleWorkers      map[int]time.Time // workerID -> when they went idle
 	idleWorkersMutex sync.RWMutex
 	lastScaleDown    time.Time
-	idleThreshold    int           // from BBB_WORKER_IDLE_THRESHOLD (default 0 = disabled)
-	scaleCooldown    time.Duration // from BBB_WORKER_SCALE_COOLDOWN_SECONDS (default 15s)
+	idleThreshold    int           // from BBB_WORKER_IDLE_THRESHOLD (default 5, 0 = disabled)
+	scaleCooldown    time.Duration // from BBB_WORKER_SCALE_COOLDOWN_SECONDS (default 30s)
 
 	// Health probe
 	probeInterval time.Duration // from BBB_HEALTH_PROBE_INTERVAL_SECONDS (default 0 = disabled)
 
+	// Queue age alerting
+	queueAgeThreshold time.Duration // from BBB_TASK_QUEUE_AGE_ALERT_MINUTES (default 30m, 0 = disabled)
+	queueAgeAlertedAt time.Time     // Last Sentry warning; only touched by the task monitor
+
+	// LISTEN/NOTIFY reconnection
+	notifyReconnectBase time.Duration // from BBB_NOTIFY_RECONNECT_BASE_SECONDS (default 5s)
+	notifyReconnectMax  time.Duration // from BBB_NOTIFY_RECONNECT_MAX_SECONDS (default 60s)
+	notifyStatusMutex   sync.RWMutex
+	notifyStatus        NotificationListenerStatus
+
 	// Running task release batching
 	runningTaskReleaseCh            chan string
 	runningTaskReleaseBatchSize     int
@@ -146,11 +200,27 @@ type WorkerPool struct {
 	runningTaskReleaseMu            sync.Mutex
 	runningTaskReleasePending       map[string]int
 
+	// Concurrency-blocked claims counted since the last flush
+	concurrencyBlockMu      sync.Mutex
+	concurrencyBlockPending map[string]*pendingConcurrencyBlocks
+
 	// Technology detection
 	techDetector        *techdetect.Detector
-	techDetectedDomains map[int]bool // Domains already detected in this session
+	techDetectedDomains map[int]bool // Domains already detected in this session; true once from a full body
 	techDetectedMutex   sync.RWMutex
-	storageClient       *storage.Client // For uploading HTML samples
+	storageClient       storage.Storage // For uploading HTML samples
+
+	// Heuristic for jobs with detect_soft_404 set
+	soft404Markers Soft404Markers
+
+	// Job lifecycle event publishing (nil when BBB_EVENT_QUEUE_URL is unset)
+	eventPublisher *events.Publisher
+
+	// Signed completion webhooks for jobs with notify_webhook_url set, and
+	// Slack failure alerts; both are delivered in the background
+	webhookSender   *webhook.Sender
+	webhookNotifier *webhook.Notifier
+	slackNotifier   *webhook.Notifier
 }
 
 func (wp *WorkerPool) ensureDomainLimiter() *DomainLimiter {
@@ -198,46 +268,16 @@ func (wp *WorkerPool) recordWaitingTask(ctx context.Context, task *db.Task, reas
 }
 
 func (wp *WorkerPool) fetchJobInfoFromDB(ctx context.Context, jobID string) (*JobInfo, error) {
-	var (
-		domainID      int
-		domainName    string
-		crawlDelay    sql.NullInt64
-		adaptiveDelay sql.NullInt64
-		adaptiveFloor sql.NullInt64
-		findLinks     bool
-		concurrency   int
-	)
-
+	var settings *jobSettings
 	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
-		return tx.QueryRowContext(ctx, `
-			SELECT d.id, d.name, d.crawl_delay_seconds, d.adaptive_delay_seconds, d.adaptive_delay_floor_seconds,
-			       j.find_links, j.concurrency
-			FROM domains d
-			JOIN jobs j ON j.domain_id = d.id
-			WHERE j.id = $1
-		`, jobID).Scan(&domainID, &domainName, &crawlDelay, &adaptiveDelay, &adaptiveFloor, &findLinks, &concurrency)
+		var scanErr error
+		settings, scanErr = scanJobSettings(ctx, tx, jobID)
+		return scanErr
 	})
 	if err != nil {
 		return nil, err
 	}
-
-	info := &JobInfo{
-		DomainID:    domainID,
-		DomainName:  domainName,
-		FindLinks:   findLinks,
-		Concurrency: concurrency,
-	}
-	if crawlDelay.Valid {
-		info.CrawlDelay = int(crawlDelay.Int64)
-	}
-	if adaptiveDelay.Valid {
-		info.AdaptiveDelay = int(adaptiveDelay.Int64)
-	}
-	if adaptiveFloor.Valid {
-		info.AdaptiveDelayFloor = int(adaptiveFloor.Int64)
-	}
-
-	return info, nil
+	return settings.jobInfo(jobID)
 }
 
 func (wp *WorkerPool) loadJobInfo(ctx context.Context, jobID string, options *JobOptions) (*JobInfo, error) {
@@ -262,6 +302,71 @@ func (wp *WorkerPool) loadJobInfo(ctx context.Context, jobID string, options *Jo
 			if options.Concurrency > 0 {
 				info.Concurrency = options.Concurrency
 			}
+			if options.VerifyConcurrency > 0 {
+				info.VerifyConcurrency = options.VerifyConcurrency
+			}
+			if options.MaxRetries != nil {
+				info.MaxRetries = *options.MaxRetries
+			}
+			if options.RetryBudget > 0 {
+				info.RetryBudget = options.RetryBudget
+			}
+			if options.TaskTimeoutSeconds > 0 {
+				info.TaskTimeout = time.Duration(ClampTaskTimeoutSeconds(options.TaskTimeoutSeconds)) * time.Second
+			}
+			if options.ConcurrencySchedule != nil {
+				info.Schedule = options.ConcurrencySchedule
+			}
+			if len(options.CacheableStatusCodes) > 0 {
+				info.CacheableStatuses = options.CacheableStatusCodes
+			}
+			if options.ChangedOnly {
+				info.ChangedOnly = true
+			}
+			if options.SkipCachedURLs {
+				info.SkipCachedURLs = true
+			}
+			if len(options.ContentTypes) > 0 {
+				info.ContentTypes = options.ContentTypes
+			}
+			if options.URLScheme != "" {
+				info.URLScheme = options.URLScheme
+			}
+			if options.PriorityTier != "" {
+				info.PriorityTier = options.PriorityTier
+			}
+			if options.SlowOriginPolicy != "" {
+				info.SlowOriginPolicy = options.SlowOriginPolicy
+			}
+			if options.UserAgent != "" {
+				info.UserAgent = options.UserAgent
+			}
+			if len(options.CustomHeaders) > 0 {
+				info.CustomHeaders = options.CustomHeaders
+			}
+			if options.ConditionalWarm {
+				info.ConditionalWarm = true
+			}
+			if len(options.IncludePaths) > 0 {
+				info.IncludePaths = options.IncludePaths
+			}
+			if len(options.ExcludePaths) > 0 {
+				info.ExcludePaths = options.ExcludePaths
+			}
+			if options.Credentials != nil && !options.Credentials.IsZero() {
+				info.Credentials = *options.Credentials
+			}
+			if options.Method != "" {
+				info.Method = options.Method
+			}
+			info.GroupSubdomains = info.GroupSubdomains || options.GroupSubdomains
+			info.DetectSoft404 = info.DetectSoft404 || options.DetectSoft404
+			info.HashContent = info.HashContent || options.HashContent
+			info.FullBodyDetection = info.FullBodyDetection || options.FullBodyDetection
+			info.AutoConcurrency = info.AutoConcurrency || options.AutoConcurrency
+			if options.MaxDepth > 0 {
+				info.MaxDepth = options.MaxDepth
+			}
 		}
 
 		wp.jobInfoMutex.Lock()
@@ -283,6 +388,31 @@ func (wp *WorkerPool) loadJobInfo(ctx context.Context, jobID string, options *Jo
 	return nil, fmt.Errorf("unexpected job info type for job %s", jobID)
 }
 
+// staleTimeoutForJob returns how long a job's task can run before it counts as
+// stale. Jobs with a longer task timeout get the extra time on top of
+// TaskStaleTimeout.
+func (wp *WorkerPool) staleTimeoutForJob(jobID string) time.Duration {
+	wp.jobInfoMutex.RLock()
+	defer wp.jobInfoMutex.RUnlock()
+
+	if info, exists := wp.jobInfoCache[jobID]; exists && info.TaskTimeout > taskProcessingTimeout {
+		return TaskStaleTimeout + info.TaskTimeout - taskProcessingTimeout
+	}
+	return TaskStaleTimeout
+}
+
+// maxRetriesForJob returns the retry limit for a job, falling back to
+// MaxTaskRetries when the job isn't cached
+func (wp *WorkerPool) maxRetriesForJob(jobID string) int {
+	wp.jobInfoMutex.RLock()
+	defer wp.jobInfoMutex.RUnlock()
+
+	if info, exists := wp.jobInfoCache[jobID]; exists {
+		return info.MaxRetries
+	}
+	return MaxTaskRetries
+}
+
 func (wp *WorkerPool) shouldThrottlePriorityUpdate(jobID string, priority float64) (bool, time.Duration) {
 	var cooldown time.Duration
 	var tier string
@@ -346,11 +476,44 @@ type JobInfo struct {
 	DomainID           int
 	DomainName         string
 	FindLinks          bool
-	CrawlDelay         int
+	CrawlDelay         int // robots.txt Crawl-delay clamped to the job's bounds
+	MinCrawlDelay      int // Job's crawl delay floor, 0 when unset
 	Concurrency        int
 	AdaptiveDelay      int
 	AdaptiveDelayFloor int
+	VerifyConcurrency  int                  // Verification phase concurrency; 0 shares the warming slot
+	MaxRetries         int                  // Per-job retry limit for retryable task errors
+	RetryBudget        int                  // Total retries across the job's tasks; 0 for no cap
+	Schedule           *ConcurrencySchedule // Time-of-day concurrency, nil when unset
+	CacheableStatuses  []int                // Non-2xx codes treated as successful warms
+	ChangedOnly        bool                 // Skip pages unchanged since the previous job
+	SkipCachedURLs     bool                 // Skip pages the edge already reports as a cache HIT
+	ContentTypes       []string             // Media types warmed; empty warms every response
+	URLScheme          URLScheme            // Scheme pages are warmed over; empty means https
+	PriorityTier       PriorityTier         // Claim order and capacity reservation tier
+	SlowOriginPolicy   SlowOriginPolicy     // Boost workers or back off when the origin slows
+	AutoConcurrency    bool                 // Tune concurrency from p95 latency and errors, up to Concurrency
+	UserAgent          string               // Per-job user agent override, empty for the crawler default
+	CustomHeaders      map[string]string    // Extra headers on warming requests, nil for none
+	ConditionalWarm    bool                 // Send previous validators so unchanged pages return 304
+	DetectSoft404      bool                 // Flag 200 responses that look like "not found" pages
+	HashContent        bool                 // Hash bodies and compare them with the page's previous warm
+	FullBodyDetection  bool                 // Run tech detection on the whole body instead of the sample
+	TaskTimeout        time.Duration        // Per-task processing limit
+	IncludePaths       []string             // Discovered links must match one of these, when set
+	ExcludePaths       []string             // Discovered links matching any of these are dropped
+	Credentials        crawler.Credentials  // Site credentials from Vault, zero when none
+	Method             WarmMethod           // GET, or HEAD to prime the cache without bodies
+	GroupSubdomains    bool                 // Pace requests under the registrable domain rather than the host
+	SecondRequest      bool                 // Re-fetch cache misses to measure the warmed response
 	RobotsRules        *crawler.RobotsRules // Cached robots.txt rules for URL filtering
+	DenyHosts          []string             // Organisation host patterns that are never crawled
+	MaxDepth           int                  // Deepest discovered link enqueued, 0 for no limit
+}
+
+// limiterDomain is the key the job's requests are paced under by the domain limiter
+func (info *JobInfo) limiterDomain() string {
+	return limiterDomain(info.DomainName, info.GroupSubdomains)
 }
 
 type jobFailureState struct {
@@ -379,7 +542,7 @@ func idleThresholdFromEnv() int {
 			return parsed
 		}
 	}
-	return 0 // Default disabled
+	return defaultIdleThreshold
 }
 
 func scaleCooldownFromEnv() time.Duration {
@@ -388,7 +551,7 @@ func scaleCooldownFromEnv() time.Duration {
 			return time.Duration(parsed) * time.Second
 		}
 	}
-	return 15 * time.Second // Default 15s
+	return defaultScaleCooldown
 }
 
 func probeIntervalFromEnv() time.Duration {
@@ -446,14 +609,9 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 	}
 
 	// Create batch manager before WorkerPool construction (db package reference must happen here)
-	batchMgr := db.NewBatchManager(dbQueue)
+	batchMgr := db.NewBatchManagerWithConfig(dbQueue, db.BatchConfigFromEnv())
 	domainLimiter := newDomainLimiter(dbQueue)
 
-	// Wire up domain limiter concurrency override to queue
-	dbQueue.SetConcurrencyOverride(func(jobID string, domain string) int {
-		return domainLimiter.GetEffectiveConcurrency(jobID, domain)
-	})
-
 	// Initialise per-worker structures for concurrency control
 	workerSemaphores := make([]chan struct{}, numWorkers)
 	workerWaitGroups := make([]*sync.WaitGroup, numWorkers)
@@ -466,6 +624,7 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 	idleThreshold := idleThresholdFromEnv()
 	scaleCooldown := scaleCooldownFromEnv()
 	probeInterval := probeIntervalFromEnv()
+	notifyReconnectBase := notifyReconnectBaseFromEnv()
 	runningTaskBatchSize := runningTaskBatchSizeFromEnv()
 	runningTaskFlushInterval := runningTaskFlushIntervalFromEnv()
 	runningTaskBuffer := max(numWorkers*workerConcurrency*2, 64)
@@ -482,8 +641,10 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 		currentWorkers:  numWorkers,
 		maxWorkers:      maxWorkers,
 		jobs:            make(map[string]bool),
+		pausedJobs:      make(map[string]bool),
 
 		stopCh:           make(chan struct{}),
+		drainCh:          make(chan struct{}),
 		notifyCh:         make(chan struct{}, 1), // Buffer of 1 to prevent blocking
 		recoveryInterval: 1 * time.Minute,
 		cleanupInterval:  time.Minute,
@@ -504,6 +665,8 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 		jobFailureThreshold: failureThreshold,
 
 		priorityUpdateTracker: make(map[string]*priorityUpdateState),
+		httpsCheckCounts:      make(map[string]int),
+		verifySlots:           make(map[string]chan struct{}),
 
 		// Idle worker scaling
 		idleWorkers:   make(map[int]time.Time),
@@ -513,6 +676,16 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 		// Health probe
 		probeInterval: probeInterval,
 
+		queueAgeThreshold: queueAgeThresholdFromEnv(),
+
+		highPriorityReservePercent: highPriorityReservePercentFromEnv(),
+		latencySpikeMultiplier:     latencySpikeMultiplierFromEnv(),
+		errorBackoffThreshold:      errorBackoffThresholdFromEnv(),
+
+		// LISTEN/NOTIFY reconnection
+		notifyReconnectBase: notifyReconnectBase,
+		notifyReconnectMax:  notifyReconnectMaxFromEnv(notifyReconnectBase),
+
 		// Running task release batching
 		runningTaskReleaseCh:            make(chan string, runningTaskBuffer),
 		runningTaskReleaseBatchSize:     runningTaskBatchSize,
@@ -521,6 +694,11 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 
 		// Technology detection (initialised lazily to avoid startup errors)
 		techDetectedDomains: make(map[int]bool),
+		soft404Markers:      soft404MarkersFromEnv(),
+
+		webhookSender:   webhook.NewSender(),
+		webhookNotifier: newJobWebhookNotifier(),
+		slackNotifier:   newSlackNotifier(),
 	}
 
 	// Initialise technology detector (non-fatal if it fails)
@@ -531,19 +709,31 @@ func NewWorkerPool(sqlDB *sql.DB, dbQueue DbQueueInterface, crawler CrawlerInter
 		log.Info().Msg("Technology detector initialised")
 	}
 
-	// Initialise storage client for HTML uploads (non-fatal if not configured)
-	// Uses existing SUPABASE_URL from project config
-	supabaseURL := strings.TrimSuffix(os.Getenv("SUPABASE_URL"), "/")
-	supabaseServiceKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
-	if supabaseURL != "" && supabaseServiceKey != "" {
-		wp.storageClient = storage.New(supabaseURL, supabaseServiceKey)
+	// Wire up domain limiter concurrency override to queue
+	dbQueue.SetConcurrencyOverride(wp.effectiveQueueConcurrency)
+
+	// Initialise storage backend for HTML uploads (non-fatal if not configured)
+	// BBB_STORAGE_BACKEND selects Supabase (default) or an S3-compatible store
+	if store, err := storage.NewFromEnv(); err != nil {
+		log.Warn().Err(err).Msg("Failed to initialise storage backend - page HTML will not be stored")
+	} else if store != nil {
+		wp.storageClient = store
 		log.Info().Msg("Storage client initialised for page crawl uploads")
 	} else {
-		log.Debug().Msg("Storage client not configured - page HTML will not be stored (set SUPABASE_SERVICE_ROLE_KEY)")
+		log.Debug().Msg("Storage client not configured - page HTML will not be stored (set SUPABASE_SERVICE_ROLE_KEY or BBB_STORAGE_BACKEND=s3)")
+	}
+
+	// Initialise lifecycle event publisher (non-fatal if misconfigured)
+	if publisher, err := events.NewFromEnv(); err != nil {
+		log.Warn().Err(err).Msg("Failed to initialise job event publisher - lifecycle events disabled")
+	} else if publisher != nil {
+		wp.eventPublisher = publisher
+		log.Info().Msg("Job lifecycle event publisher initialised")
 	}
 
 	// Start the notification listener when we have connection details available.
 	if hasNotificationConfig(dbConfig) {
+		wp.notifyStatus.Enabled = true
 		wp.wg.Go(func() {
 			wp.listenForNotifications(context.Background())
 		})
@@ -561,15 +751,22 @@ func (wp *WorkerPool) Start(ctx context.Context) {
 	// This prevents capacity leaks from deployments, crashes, or migration timing
 	reconcileCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
-	if err := wp.reconcileRunningTaskCounters(reconcileCtx); err != nil {
+	if _, err := wp.reconcileRunningTaskCounters(reconcileCtx); err != nil {
 		sentry.CaptureException(err)
 		log.Error().Err(err).Msg("Failed to reconcile running_tasks counters - workers may be blocked")
 		// Continue startup even if reconciliation fails (logged for monitoring)
 	}
 
+	// Optionally pre-seed limiter and robots caches before workers claim tasks
+	if warmStartEnabledFromEnv() {
+		wp.warmStart(ctx)
+	}
+
 	for i := 0; i < wp.numWorkers; i++ {
 		i := i
+		wp.workersWG.Add(1)
 		wp.wg.Go(func() {
+			defer wp.workersWG.Done()
 			time.Sleep(time.Duration(i*50) * time.Millisecond)
 			wp.worker(ctx, i)
 		})
@@ -595,12 +792,22 @@ func (wp *WorkerPool) Start(ctx context.Context) {
 	wp.StartTaskMonitor(ctx)
 	wp.StartCleanupMonitor(ctx)
 	wp.StartQuotaPromotionMonitor(ctx)
+	wp.StartTaskRetentionSweeper(ctx)
 	wp.startRunningTaskReleaseLoop(ctx)
+	wp.startConcurrencyBlockFlushLoop(ctx)
 
 	// Start orphaned task cleanup loop
 	wp.wg.Go(func() {
 		wp.cleanupOrphanedTasksLoop(ctx)
 	})
+
+	wp.started.Store(true)
+	log.Info().Msg("Worker pool started")
+}
+
+// Started reports whether Start has finished bringing the pool up
+func (wp *WorkerPool) Started() bool {
+	return wp.started.Load()
 }
 
 func (wp *WorkerPool) Stop() {
@@ -609,7 +816,9 @@ func (wp *WorkerPool) Stop() {
 		log.Debug().Msg("Stopping worker pool")
 		close(wp.stopCh)
 		wp.wg.Wait()
+		wp.collectQueuedRunningTaskReleases()
 		wp.flushRunningTaskReleases(context.Background())
+		wp.flushConcurrencyBlocks(context.Background())
 		// Stop batch manager to flush remaining updates
 		if wp.batchManager != nil {
 			wp.batchManager.Stop()
@@ -618,12 +827,65 @@ func (wp *WorkerPool) Stop() {
 	}
 }
 
+// Drain stops workers claiming new tasks and waits for in-flight tasks to
+// finish before stopping the pool, so their status updates and running_tasks
+// releases are flushed rather than left for reconcileRunningTaskCounters. If
+// ctx ends first it falls back to Stop, which waits only for the tasks
+// themselves.
+func (wp *WorkerPool) Drain(ctx context.Context) {
+	if wp.stopping.Load() {
+		return
+	}
+
+	// Held so scaleWorkers can't add a worker after the drain starts
+	wp.workersMutex.Lock()
+	if wp.draining.CompareAndSwap(false, true) {
+		log.Info().Msg("Draining worker pool")
+		close(wp.drainCh)
+	}
+	wp.workersMutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		wp.workersWG.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		log.Info().Msg("Worker pool drained, in-flight tasks finished")
+	case <-ctx.Done():
+		log.Warn().Err(ctx.Err()).Msg("Worker pool drain deadline passed, forcing stop")
+	}
+
+	wp.Stop()
+}
+
+// collectQueuedRunningTaskReleases moves releases still buffered in the
+// channel into the pending map once the release loop has exited, so the final
+// flush includes them
+func (wp *WorkerPool) collectQueuedRunningTaskReleases() {
+	if wp.runningTaskReleaseCh == nil {
+		return
+	}
+	for {
+		select {
+		case jobID := <-wp.runningTaskReleaseCh:
+			if jobID != "" {
+				wp.incrementPendingRunningTaskRelease(jobID)
+			}
+		default:
+			return
+		}
+	}
+}
+
 // reconcileRunningTaskCounters resets running_tasks to match actual task status
 // This fixes counter leaks from:
 // - Deployment race conditions (tasks completing during graceful shutdown)
 // - Crash recovery (batch manager unable to flush)
 // - Migration backfill timing (tasks counted as running but completed before new code started)
-func (wp *WorkerPool) reconcileRunningTaskCounters(ctx context.Context) error {
+func (wp *WorkerPool) reconcileRunningTaskCounters(ctx context.Context) (*ReconcileResult, error) {
 	log.Info().Msg("Reconciling running_tasks counters with actual task status")
 
 	// Atomic query: Reset all running_tasks based on current task.status = 'running'
@@ -675,12 +937,11 @@ func (wp *WorkerPool) reconcileRunningTaskCounters(ctx context.Context) error {
 
 	rows, err := wp.db.QueryContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to reconcile running_tasks counters: %w", err)
+		return nil, fmt.Errorf("failed to reconcile running_tasks counters: %w", err)
 	}
 	defer rows.Close()
 
-	totalLeaked := 0
-	jobsFixed := 0
+	result := &ReconcileResult{Jobs: []ReconciledCounter{}}
 
 	for rows.Next() {
 		var jobID string
@@ -691,8 +952,14 @@ func (wp *WorkerPool) reconcileRunningTaskCounters(ctx context.Context) error {
 			continue
 		}
 
-		totalLeaked += leaked
-		jobsFixed++
+		result.LeakedTasks += leaked
+		result.JobsFixed++
+		result.Jobs = append(result.Jobs, ReconciledCounter{
+			JobID:       jobID,
+			OldValue:    oldValue,
+			NewValue:    newValue,
+			LeakedTasks: leaked,
+		})
 
 		log.Info().
 			Str("job_id", jobID).
@@ -703,19 +970,19 @@ func (wp *WorkerPool) reconcileRunningTaskCounters(ctx context.Context) error {
 	}
 
 	if err := rows.Err(); err != nil {
-		return fmt.Errorf("error reading reconciliation results: %w", err)
+		return nil, fmt.Errorf("error reading reconciliation results: %w", err)
 	}
 
-	if totalLeaked > 0 {
+	if result.LeakedTasks > 0 {
 		log.Warn().
-			Int("total_leaked_tasks", totalLeaked).
-			Int("jobs_fixed", jobsFixed).
+			Int("total_leaked_tasks", result.LeakedTasks).
+			Int("jobs_fixed", result.JobsFixed).
 			Msg("Running_tasks counters reconciled - capacity leak detected and fixed")
 	} else {
 		log.Info().Msg("Running_tasks counters already accurate - no reconciliation needed")
 	}
 
-	return nil
+	return result, nil
 }
 
 type jobCapacity struct {
@@ -767,9 +1034,9 @@ var rebalanceJobPendingQuery = `
 // This is a safety guardrail against bugs that cause pending queue overflow
 // For each job, keeps only the highest-priority pending tasks (up to concurrency limit)
 // and demotes the rest to waiting status
-func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) error {
+func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) (*RebalanceResult, error) {
 	if wp.db == nil {
-		return errors.New("worker pool database is not configured")
+		return nil, errors.New("worker pool database is not configured")
 	}
 
 	runCtx := ctx
@@ -780,33 +1047,25 @@ func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) error {
 	log.Debug().Msg("Rebalancing pending queues across running jobs")
 
 	// Get system-wide task status counts for observability
-	var totalPending, totalWaiting, totalRunning, totalCompleted, totalFailed int
-	err := wp.db.QueryRowContext(runCtx, `
-		SELECT
-			COALESCE(SUM(pending_tasks), 0) AS pending,
-			COALESCE(SUM(waiting_tasks), 0) AS waiting,
-			COALESCE(SUM(running_tasks), 0) AS running,
-			COALESCE(SUM(completed_tasks), 0) AS completed,
-			COALESCE(SUM(failed_tasks), 0) AS failed
-		FROM jobs
-		WHERE status = 'running'
-	`).Scan(&totalPending, &totalWaiting, &totalRunning, &totalCompleted, &totalFailed)
+	counts, err := wp.systemTaskCounts(runCtx)
 
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to get system task status counts")
 	} else {
 		log.Info().
-			Int("pending", totalPending).
-			Int("waiting", totalWaiting).
-			Int("running", totalRunning).
-			Int("completed", totalCompleted).
-			Int("failed", totalFailed).
+			Int("active_jobs", counts.ActiveJobs).
+			Int("pending", counts.Pending).
+			Int("waiting", counts.Waiting).
+			Int("running", counts.Running).
+			Int("completed", counts.Completed).
+			Int("failed", counts.Failed).
 			Msg("System task status counts")
+		wp.recordTaskBacklog(runCtx, counts)
 	}
 
 	rows, err := wp.db.QueryContext(runCtx, pendingOverflowJobsQuery, pendingRebalanceJobLimit)
 	if err != nil {
-		return fmt.Errorf("failed to query pending queue overflows: %w", err)
+		return nil, fmt.Errorf("failed to query pending queue overflows: %w", err)
 	}
 	defer rows.Close()
 
@@ -814,25 +1073,23 @@ func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) error {
 	for rows.Next() {
 		var jc jobCapacity
 		if err := rows.Scan(&jc.id, &jc.cap, &jc.pending); err != nil {
-			return fmt.Errorf("failed to scan pending overflow row: %w", err)
+			return nil, fmt.Errorf("failed to scan pending overflow row: %w", err)
 		}
 		overflowJobs = append(overflowJobs, jc)
 	}
 
 	if err := rows.Err(); err != nil {
-		return fmt.Errorf("error reading pending overflow rows: %w", err)
+		return nil, fmt.Errorf("error reading pending overflow rows: %w", err)
 	}
 
+	result := &RebalanceResult{Jobs: []RebalancedQueue{}}
 	if len(overflowJobs) == 0 {
 		log.Debug().Msg("All pending queues within limits - no rebalancing needed")
-		return nil
+		return result, nil
 	}
 
-	totalDemoted := 0
-	correctedJobs := 0
-
 	for _, job := range overflowJobs {
-		result, err := wp.db.ExecContext(runCtx, rebalanceJobPendingQuery, job.id, job.cap)
+		res, err := wp.db.ExecContext(runCtx, rebalanceJobPendingQuery, job.id, job.cap)
 		if err != nil {
 			log.Error().
 				Err(err).
@@ -841,7 +1098,7 @@ func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) error {
 			continue
 		}
 
-		demoted, err := result.RowsAffected()
+		demoted, err := res.RowsAffected()
 		if err != nil {
 			log.Warn().
 				Err(err).
@@ -854,8 +1111,9 @@ func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) error {
 			continue
 		}
 
-		totalDemoted += int(demoted)
-		correctedJobs++
+		result.DemotedTasks += int(demoted)
+		result.JobsRebalanced++
+		result.Jobs = append(result.Jobs, RebalancedQueue{JobID: job.id, DemotedTasks: int(demoted)})
 
 		log.Info().
 			Str("job_id", job.id).
@@ -863,14 +1121,14 @@ func (wp *WorkerPool) rebalancePendingQueues(ctx context.Context) error {
 			Msg("Demoted excess pending tasks to waiting")
 	}
 
-	if totalDemoted > 0 {
+	if result.DemotedTasks > 0 {
 		log.Warn().
-			Int("total_demoted", totalDemoted).
-			Int("jobs_rebalanced", correctedJobs).
+			Int("total_demoted", result.DemotedTasks).
+			Int("jobs_rebalanced", result.JobsRebalanced).
 			Msg("Pending queue overflow detected and corrected")
 	}
 
-	return nil
+	return result, nil
 }
 
 // WaitForJobs waits for all active jobs to complete
@@ -901,22 +1159,15 @@ func (wp *WorkerPool) AddJob(jobID string, options *JobOptions) {
 	jobInfo, err := wp.loadJobInfo(ctx, jobID, options)
 
 	if err == nil {
-		wp.ensureDomainLimiter().Seed(jobInfo.DomainName, jobInfo.CrawlDelay, jobInfo.AdaptiveDelay, jobInfo.AdaptiveDelayFloor)
+		wp.ensureDomainLimiter().Seed(jobInfo.limiterDomain(), jobInfo.CrawlDelay, jobInfo.AdaptiveDelay, jobInfo.AdaptiveDelayFloor)
+		if jobInfo.AutoConcurrency {
+			wp.startAutoConcurrency(jobID, jobInfo)
+		}
 
-		// Parse robots.txt to get filtering rules
-		robotsRules, err := crawler.ParseRobotsTxt(ctx, jobInfo.DomainName, wp.crawler.GetUserAgent())
-		if err != nil {
-			log.Debug().
-				Err(err).
-				Str("domain", jobInfo.DomainName).
-				Msg("Failed to parse robots.txt, proceeding without restrictions")
-			// Only capture to Sentry if it's not a 404 (which is normal)
-			if !strings.Contains(err.Error(), "404") {
-				sentry.CaptureMessage(fmt.Sprintf("Failed to parse robots.txt for %s: %v", jobInfo.DomainName, err))
-			}
-			jobInfo.RobotsRules = &crawler.RobotsRules{} // Empty rules = no restrictions
-		} else {
-			jobInfo.RobotsRules = robotsRules
+		// Parse robots.txt to get filtering rules, unless warm start already cached them
+		if jobInfo.RobotsRules == nil {
+			robotsCtx := crawler.WithCredentials(ctx, jobInfo.Credentials)
+			jobInfo.RobotsRules = wp.fetchRobotsRules(robotsCtx, jobInfo.DomainName, jobInfo.UserAgent)
 		}
 
 		log.Trace().
@@ -924,7 +1175,7 @@ func (wp *WorkerPool) AddJob(jobID string, options *JobOptions) {
 			Str("domain", jobInfo.DomainName).
 			Int("crawl_delay", jobInfo.CrawlDelay).
 			Int("concurrency", jobInfo.Concurrency).
-			Int("disallow_patterns", len(jobInfo.RobotsRules.DisallowPatterns)).
+			Bool("robots_rules", jobInfo.RobotsRules != nil).
 			Msg("Cached job info with robots rules")
 	} else {
 		log.Error().Err(err).Str("job_id", jobID).Msg("Failed to cache job info")
@@ -944,6 +1195,9 @@ func (wp *WorkerPool) AddJob(jobID string, options *JobOptions) {
 		wp.scaleWorkers(context.Background(), targetWorkers)
 		decision = "scale_up"
 		reason = "job_added"
+		if err == nil && jobInfo.PriorityTier == PriorityTierHigh {
+			reason = "high_priority_job_added"
+		}
 	} else if currentWorkers >= wp.maxWorkers {
 		reason = "at_max_workers"
 	}
@@ -957,6 +1211,28 @@ func (wp *WorkerPool) AddJob(jobID string, options *JobOptions) {
 		Msg("Added job to worker pool")
 }
 
+// fetchRobotsRules parses robots.txt for a domain. Rules are matched against
+// the job's user agent override when set, else the crawler default. A missing
+// robots.txt (404) yields empty rules; any other failure, including a timed-out
+// context, returns nil so callers don't cache it as "no restrictions".
+func (wp *WorkerPool) fetchRobotsRules(ctx context.Context, domain string, userAgent string) *crawler.RobotsRules {
+	if userAgent == "" {
+		userAgent = wp.crawler.GetUserAgent()
+	}
+	robotsRules, err := crawler.ParseRobotsTxt(ctx, domain, userAgent)
+	if err != nil {
+		log.Debug().
+			Err(err).
+			Str("domain", domain).
+			Msg("Failed to parse robots.txt, leaving rules unset")
+		if ctx.Err() == nil {
+			sentry.CaptureMessage(fmt.Sprintf("Failed to parse robots.txt for %s: %v", domain, err))
+		}
+		return nil
+	}
+	return robotsRules
+}
+
 // NotifyNewTasks wakes workers to check for new tasks immediately
 // instead of waiting for the next task monitor tick (30 seconds).
 func (wp *WorkerPool) NotifyNewTasks() {
@@ -986,27 +1262,42 @@ func (wp *WorkerPool) calculateConcurrencyTarget() int {
 	}
 
 	totalConcurrency := 0
+	highConcurrency := 0
 
 	wp.jobInfoMutex.RLock()
 	for _, jobID := range jobIDs {
 		concurrency := fallbackJobConcurrency
+		highTier := false
 		if jobInfo, exists := wp.jobInfoCache[jobID]; exists {
+			highTier = jobInfo.PriorityTier == PriorityTierHigh
 			if jobInfo.Concurrency > 0 {
 				concurrency = jobInfo.Concurrency
 			}
 			if wp.domainLimiter != nil && jobInfo.DomainName != "" {
-				if effective := wp.domainLimiter.GetEffectiveConcurrency(jobID, jobInfo.DomainName); effective > 0 {
+				if effective := wp.domainLimiter.GetEffectiveConcurrency(jobID, jobInfo.limiterDomain()); effective > 0 {
 					concurrency = effective
 				}
 			}
+			concurrency += jobInfo.VerifyConcurrency
 		}
 		if concurrency < 1 {
 			concurrency = 1
 		}
 		totalConcurrency += concurrency
+		if highTier {
+			highConcurrency += concurrency
+		}
 	}
 	wp.jobInfoMutex.RUnlock()
 
+	// Size standard tier demand so it fits outside the high tier reserve,
+	// leaving the reserved share free for high tier jobs to claim
+	if highConcurrency > 0 && wp.highPriorityReservePercent > 0 {
+		standardConcurrency := totalConcurrency - highConcurrency
+		reserveFactor := 1 - float64(wp.highPriorityReservePercent)/100
+		totalConcurrency = max(totalConcurrency, int(math.Ceil(float64(standardConcurrency)/reserveFactor)))
+	}
+
 	perWorkerConcurrency := max(wp.workerConcurrency, 1)
 
 	target := min(max(int(math.Ceil(float64(totalConcurrency)/float64(perWorkerConcurrency)*concurrencyBufferFactor)), wp.baseWorkerCount), wp.maxWorkers)
@@ -1016,8 +1307,11 @@ func (wp *WorkerPool) calculateConcurrencyTarget() int {
 
 // recordConcurrencyBlock notes when a job hits its concurrency ceiling so that
 // performance scaling can avoid fighting those limits and gradually release any
-// boost that is no longer useful.
+// boost that is no longer useful. The block is also counted for the job's
+// concurrency wait stats.
 func (wp *WorkerPool) recordConcurrencyBlock(jobID string) {
+	wp.countConcurrencyBlock(jobID, time.Now().UTC())
+
 	wp.perfMutex.Lock()
 	if perf, exists := wp.jobPerformance[jobID]; exists {
 		perf.LastConcurrencyBlock = time.Now()
@@ -1031,6 +1325,7 @@ func (wp *WorkerPool) recordConcurrencyBlock(jobID string) {
 func (wp *WorkerPool) RemoveJob(jobID string) {
 	wp.jobsMutex.Lock()
 	delete(wp.jobs, jobID)
+	delete(wp.pausedJobs, jobID)
 	wp.jobsMutex.Unlock()
 
 	// Remove performance boost for this job
@@ -1057,6 +1352,14 @@ func (wp *WorkerPool) RemoveJob(jobID string) {
 	delete(wp.jobFailureCounters, jobID)
 	wp.jobFailureMutex.Unlock()
 
+	wp.httpsCheckMutex.Lock()
+	delete(wp.httpsCheckCounts, jobID)
+	wp.httpsCheckMutex.Unlock()
+
+	wp.verifySlotsMutex.Lock()
+	delete(wp.verifySlots, jobID)
+	wp.verifySlotsMutex.Unlock()
+
 	// Simple scaling: remove 5 workers per job + any performance boost, minimum of base count
 	wp.workersMutex.Lock()
 	oldWorkers := wp.currentWorkers
@@ -1212,6 +1515,11 @@ func (wp *WorkerPool) markJobFailedDueToConsecutiveFailures(ctx context.Context,
 
 	// Notifications are created by the database trigger (update_job_progress)
 	// when job status transitions to 'failed'.
+	if updateErr == nil {
+		wp.publishJobEvent(jobID, events.JobFailed)
+		wp.notifyJobWebhook(jobID)
+		wp.notifyJobFailedSlack(jobID)
+	}
 }
 
 // processTaskResult handles the result from a task execution and updates backoff state
@@ -1262,6 +1570,9 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 	maxSleep := 5 * time.Second         // Note: Changed from 30 to 5 seconds, to increase responsiveness when inactive.
 	baseSleep := 200 * time.Millisecond // Faster processing when active
 
+	// Set when a backoff elapses, so the worker claims once before sleeping again
+	retryClaim := false
+
 	// Channel to receive task results from concurrent goroutines
 	type taskResult struct {
 		err error
@@ -1288,6 +1599,9 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 		case <-wp.stopCh:
 			log.Debug().Int("worker_id", workerID).Msg("Worker received stop signal")
 			return
+		case <-wp.drainCh:
+			log.Debug().Int("worker_id", workerID).Msg("Worker draining, no new tasks will be claimed")
+			return
 		case <-ctx.Done():
 			log.Debug().Int("worker_id", workerID).Msg("Worker context cancelled")
 			return
@@ -1312,10 +1626,10 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 		}
 
 		// Apply backoff logic when no tasks are available
-		if consecutiveNoTasks > 0 {
+		if consecutiveNoTasks > 0 && !retryClaim {
 			// Track idle workers for scaling decisions (if feature enabled)
 			if wp.idleThreshold > 0 && consecutiveNoTasks >= wp.idleThreshold {
-				if wp.markWorkerIdle(workerID) {
+				if wp.markWorkerIdle(workerID) || wp.idleScaleDownDue() {
 					wp.maybeScaleDown()
 				}
 			}
@@ -1330,8 +1644,9 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 			// Wait for either the backoff duration, a notification, or task completion
 			select {
 			case <-time.After(sleepTime):
-				consecutiveNoTasks = 0 // Reset backoff to retry claiming
-				wp.clearWorkerIdle(workerID)
+				// Retry claiming but keep the streak, so backoff grows and the
+				// worker stays idle across a quiet spell until it finds work
+				retryClaim = true
 			case <-wp.notifyCh:
 				consecutiveNoTasks = 0
 				wp.clearWorkerIdle(workerID)
@@ -1339,11 +1654,14 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 				wp.processTaskResult(result.err, workerID, &consecutiveNoTasks)
 			case <-wp.stopCh:
 				return
+			case <-wp.drainCh:
+				return
 			case <-ctx.Done():
 				return
 			}
 			continue // Loop back to check signals before attempting to claim
 		}
+		retryClaim = false
 
 		// Try to acquire a semaphore slot (non-blocking)
 		select {
@@ -1375,6 +1693,8 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 				wp.processTaskResult(result.err, workerID, &consecutiveNoTasks)
 			case <-wp.stopCh:
 				return
+			case <-wp.drainCh:
+				return
 			case <-ctx.Done():
 				return
 			}
@@ -1388,6 +1708,9 @@ func (wp *WorkerPool) claimPendingTask(ctx context.Context) (*db.Task, error) {
 	wp.jobsMutex.RLock()
 	activeJobs := make([]string, 0, len(wp.jobs))
 	for jobID := range wp.jobs {
+		if wp.pausedJobs[jobID] {
+			continue // Paused jobs keep their tasks until resumed
+		}
 		activeJobs = append(activeJobs, jobID)
 	}
 	wp.jobsMutex.RUnlock()
@@ -1410,12 +1733,22 @@ func (wp *WorkerPool) claimPendingTask(ctx context.Context) (*db.Task, error) {
 	}
 	wp.jobInfoMutex.RUnlock()
 
+	// High tier jobs claim first, and standard tiers leave reserved capacity free
+	sortJobsByPriorityTier(activeJobs, jobInfoSnapshot)
+	standardAtCapacity := wp.standardTierAtCapacity(jobInfoSnapshot)
+
 	// Try to get a task from each active job
 	for _, jobID := range activeJobs {
+		if standardAtCapacity {
+			if jobInfo, exists := jobInfoSnapshot[jobID]; !exists || jobInfo.PriorityTier != PriorityTierHigh {
+				break // Remaining jobs are standard tier
+			}
+		}
+
 		// Skip jobs whose domain isn't available yet
 		if wp.domainLimiter != nil {
 			if jobInfo, exists := jobInfoSnapshot[jobID]; exists && jobInfo.DomainName != "" {
-				if wp.domainLimiter.EstimatedWait(jobInfo.DomainName) > 0 {
+				if wp.domainLimiter.EstimatedWait(jobInfo.limiterDomain()) > 0 {
 					continue // Domain not available yet, try other jobs
 				}
 			}
@@ -1475,6 +1808,7 @@ func (wp *WorkerPool) prepareTaskForProcessing(ctx context.Context, task *db.Tas
 		SourceType:    task.SourceType,
 		SourceURL:     task.SourceURL,
 		PriorityScore: task.PriorityScore,
+		Depth:         task.Depth,
 	}
 
 	// Get job info from cache
@@ -1488,8 +1822,25 @@ func (wp *WorkerPool) prepareTaskForProcessing(ctx context.Context, task *db.Tas
 		jobsTask.FindLinks = jobInfo.FindLinks
 		jobsTask.CrawlDelay = jobInfo.CrawlDelay
 		jobsTask.JobConcurrency = jobInfo.Concurrency
+		jobsTask.VerifyConcurrency = jobInfo.VerifyConcurrency
+		jobsTask.ConcurrencySchedule = jobInfo.Schedule
+		jobsTask.CacheableStatusCodes = jobInfo.CacheableStatuses
+		jobsTask.ChangedOnly = jobInfo.ChangedOnly
+		jobsTask.SkipCachedURLs = jobInfo.SkipCachedURLs
+		jobsTask.ContentTypes = jobInfo.ContentTypes
+		jobsTask.URLScheme = jobInfo.URLScheme
+		jobsTask.UserAgent = jobInfo.UserAgent
+		jobsTask.CustomHeaders = jobInfo.CustomHeaders
+		jobsTask.ConditionalWarm = jobInfo.ConditionalWarm
+		jobsTask.Credentials = jobInfo.Credentials
+		jobsTask.Method = jobInfo.Method
+		jobsTask.GroupSubdomains = jobInfo.GroupSubdomains
+		jobsTask.SecondRequest = jobInfo.SecondRequest
+		jobsTask.MinCrawlDelay = jobInfo.MinCrawlDelay
+		jobsTask.TaskTimeout = jobInfo.TaskTimeout
 		jobsTask.AdaptiveDelay = jobInfo.AdaptiveDelay
 		jobsTask.AdaptiveDelayFloor = jobInfo.AdaptiveDelayFloor
+		jobsTask.DenyHosts = jobInfo.DenyHosts
 	} else {
 		// Fallback to database if not in cache (shouldn't happen normally)
 		log.Warn().Str("job_id", task.JobID).Msg("Job info not in cache, querying database")
@@ -1503,15 +1854,42 @@ func (wp *WorkerPool) prepareTaskForProcessing(ctx context.Context, task *db.Tas
 			jobsTask.FindLinks = info.FindLinks
 			jobsTask.CrawlDelay = info.CrawlDelay
 			jobsTask.JobConcurrency = info.Concurrency
+			jobsTask.VerifyConcurrency = info.VerifyConcurrency
+			jobsTask.ConcurrencySchedule = info.Schedule
+			jobsTask.CacheableStatusCodes = info.CacheableStatuses
+			jobsTask.ChangedOnly = info.ChangedOnly
+			jobsTask.SkipCachedURLs = info.SkipCachedURLs
+			jobsTask.ContentTypes = info.ContentTypes
+			jobsTask.URLScheme = info.URLScheme
+			jobsTask.UserAgent = info.UserAgent
+			jobsTask.CustomHeaders = info.CustomHeaders
+			jobsTask.ConditionalWarm = info.ConditionalWarm
+			jobsTask.Credentials = info.Credentials
+			jobsTask.Method = info.Method
+			jobsTask.GroupSubdomains = info.GroupSubdomains
+			jobsTask.SecondRequest = info.SecondRequest
+			jobsTask.MinCrawlDelay = info.MinCrawlDelay
+			jobsTask.TaskTimeout = info.TaskTimeout
 			jobsTask.AdaptiveDelay = info.AdaptiveDelay
 			jobsTask.AdaptiveDelayFloor = info.AdaptiveDelayFloor
-			wp.ensureDomainLimiter().Seed(info.DomainName, info.CrawlDelay, info.AdaptiveDelay, info.AdaptiveDelayFloor)
+			jobsTask.DenyHosts = info.DenyHosts
+			wp.ensureDomainLimiter().Seed(info.limiterDomain(), info.CrawlDelay, info.AdaptiveDelay, info.AdaptiveDelayFloor)
 		}
 	}
 	if jobsTask.JobConcurrency <= 0 {
 		jobsTask.JobConcurrency = 1
 	}
 
+	// Conditional warms need the page's validators from its last warm
+	if jobsTask.ConditionalWarm {
+		validators, err := wp.previousValidators(ctx, jobsTask)
+		if err != nil {
+			log.Warn().Err(err).Str("task_id", task.ID).Msg("Failed to load previous validators, warming unconditionally")
+		} else {
+			jobsTask.Validators = validators
+		}
+	}
+
 	return jobsTask, nil
 }
 
@@ -1541,6 +1919,11 @@ func (wp *WorkerPool) processNextTask(ctx context.Context) (err error) {
 		return err
 	}
 	if task != nil {
+		if wp.jobPriorityTier(task.JobID) != PriorityTierHigh {
+			wp.standardTierInFlight.Add(1)
+			defer wp.standardTierInFlight.Add(-1)
+		}
+
 		// Prepare task for processing with job info
 		jobsTask, err := wp.prepareTaskForProcessing(ctx, task)
 		if err != nil {
@@ -1548,13 +1931,32 @@ func (wp *WorkerPool) processNextTask(ctx context.Context) (err error) {
 			return err
 		}
 
-		// Process the task
-		taskCtx, cancel := context.WithTimeout(ctx, taskProcessingTimeout)
+		// Process the task within the job's timeout
+		taskTimeout := jobsTask.TaskTimeout
+		if taskTimeout <= 0 {
+			taskTimeout = taskProcessingTimeout
+		}
+		taskCtx, cancel := context.WithTimeout(ctx, taskTimeout)
 		defer cancel()
 
 		result, err := wp.processTask(taskCtx, jobsTask)
+		if errors.Is(err, errTaskUnchanged) {
+			return wp.handleTaskUnchanged(ctx, task)
+		}
+		if errors.Is(err, errTaskCached) {
+			return wp.handleTaskCached(ctx, task)
+		}
+		if errors.Is(err, errTaskHostDenied) {
+			return wp.handleTaskHostDenied(ctx, task)
+		}
+		if errors.Is(err, errTaskContentType) {
+			return wp.handleTaskContentType(ctx, task, result)
+		}
+		if errors.Is(err, errCircuitOpen) {
+			return wp.handleTaskCircuitOpen(ctx, task)
+		}
 		if err != nil {
-			return wp.handleTaskError(ctx, task, err)
+			return wp.handleTaskError(ctx, task, result, err)
 		} else {
 			return wp.handleTaskSuccess(ctx, task, result)
 		}
@@ -1631,9 +2033,14 @@ func (wp *WorkerPool) StartTaskMonitor(ctx context.Context) {
 				if err := wp.checkForPendingTasks(ctx); err != nil {
 					log.Error().Err(err).Msg("Error checking for pending tasks")
 				}
+				if wp.queueAgeThreshold > 0 {
+					if err := wp.checkQueuedTaskAge(ctx); err != nil {
+						log.Error().Err(err).Msg("Error checking task queue age")
+					}
+				}
 			case <-rebalanceTicker.C:
 				log.Debug().Msg("Running pending queue rebalancer")
-				if err := wp.rebalancePendingQueues(ctx); err != nil {
+				if _, err := wp.rebalancePendingQueues(ctx); err != nil {
 					log.Error().Err(err).Msg("Error rebalancing pending queues")
 				}
 			case <-probeCh:
@@ -1716,20 +2123,29 @@ func (wp *WorkerPool) checkForPendingTasks(ctx context.Context) error {
 			wp.AddJob(jobID, options)
 
 			// Update job status if needed
+			var started bool
 			err = wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
-				_, err := tx.ExecContext(ctx, `
+				result, err := tx.ExecContext(ctx, `
 					UPDATE jobs SET
 						status = $1,
 						started_at = CASE WHEN started_at IS NULL THEN $2 ELSE started_at END
 					WHERE id = $3 AND status = $4
 				`, JobStatusRunning, time.Now().UTC(), jobID, JobStatusPending)
-				return err
+				if err != nil {
+					return err
+				}
+				rows, _ := result.RowsAffected()
+				started = rows > 0
+				return nil
 			})
 
 			if err != nil {
 				log.Error().Err(err).Str("job_id", jobID).Msg("Failed to update job status")
 			} else {
 				log.Info().Str("job_id", jobID).Msg("Updated job status to running")
+				if started {
+					wp.publishJobEvent(jobID, events.JobStarted)
+				}
 			}
 		} else {
 			log.Debug().Str("job_id", jobID).Msg("Job already active in worker pool")
@@ -1813,20 +2229,37 @@ func (wp *WorkerPool) ensureJobSafeToRemove(ctx context.Context, jobID string) (
 		return false, err
 	}
 
+	// Terminal jobs are usually finalised by the update_job_progress trigger, so
+	// this is the first point the pool observes the outcome
 	switch JobStatus(state.Status) {
-	case JobStatusCompleted, JobStatusCancelled, JobStatusFailed:
+	case JobStatusCompleted:
+		wp.onJobCompleted(ctx, jobID)
+		return true, nil
+	case JobStatusFailed:
+		wp.publishJobEvent(jobID, events.JobFailed)
+		wp.notifyJobWebhook(jobID)
+		return true, nil
+	case JobStatusCancelled:
+		wp.notifyJobWebhook(jobID)
+		return true, nil
+	case JobStatusPaused:
+		// Never complete a paused job; ResumeJob re-registers it if needed
 		return true, nil
 	}
 
 	if state.remainingWork() == 0 && state.Pending == 0 && state.Waiting == 0 && state.Running == 0 {
-		if err := wp.markJobCompleted(ctx, jobID); err != nil {
+		completed, err := wp.markJobCompleted(ctx, jobID)
+		if err != nil {
 			return false, fmt.Errorf("failed to mark job %s complete: %w", jobID, err)
 		}
+		if completed {
+			wp.onJobCompleted(ctx, jobID)
+		}
 		return true, nil
 	}
 
 	if state.Running > 0 {
-		recovered, recErr := wp.requeueStaleRunningTasks(ctx, jobID, time.Now().UTC().Add(-TaskStaleTimeout))
+		recovered, recErr := wp.requeueStaleRunningTasks(ctx, jobID, time.Now().UTC().Add(-wp.staleTimeoutForJob(jobID)))
 		if recErr != nil {
 			return false, recErr
 		}
@@ -1862,9 +2295,13 @@ func (wp *WorkerPool) ensureJobSafeToRemove(ctx context.Context, jobID string) (
 		return false, nil
 	}
 
-	if err := wp.markJobCompleted(ctx, jobID); err != nil {
+	completed, err := wp.markJobCompleted(ctx, jobID)
+	if err != nil {
 		return false, fmt.Errorf("failed to mark quiet job %s complete: %w", jobID, err)
 	}
+	if completed {
+		wp.onJobCompleted(ctx, jobID)
+	}
 	return true, nil
 }
 
@@ -1894,23 +2331,70 @@ func (wp *WorkerPool) loadJobQueueState(ctx context.Context, jobID string) (*job
 	return state, nil
 }
 
-func (wp *WorkerPool) markJobCompleted(ctx context.Context, jobID string) error {
+// onJobCompleted runs post-completion hooks once the pool observes a
+// completed job. Every instance tracking the job gets here, so the hooks only
+// run for the one that claims them.
+func (wp *WorkerPool) onJobCompleted(ctx context.Context, jobID string) {
+	claimed, err := wp.claimJobCompletionHooks(ctx, jobID)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", jobID).Msg("Failed to claim job completion hooks")
+		return
+	}
+	if !claimed {
+		return
+	}
+	wp.publishJobEvent(jobID, events.JobCompleted)
+	wp.purgeCDNForJob(jobID)
+	wp.notifyJobWebhook(jobID)
+}
+
+// claimJobCompletionHooks reports whether this call claimed the completed
+// job's hooks; false means another instance (or an earlier call) already has
+func (wp *WorkerPool) claimJobCompletionHooks(ctx context.Context, jobID string) (bool, error) {
+	var claimed bool
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			UPDATE jobs
+			SET completion_hooks_at = NOW()
+			WHERE id = $1
+			  AND status = $2
+			  AND completion_hooks_at IS NULL
+			RETURNING id
+		`, jobID, JobStatusCompleted).Scan(new(string))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		claimed = err == nil
+		return err
+	})
+	return claimed, err
+}
+
+// markJobCompleted reports whether the job was marked completed; false means
+// it was already completed, or was cancelled or failed first
+func (wp *WorkerPool) markJobCompleted(ctx context.Context, jobID string) (bool, error) {
 	// Notifications are now created by the database trigger (update_job_progress)
 	// when job status transitions to 'completed'. This ensures notifications are
 	// created regardless of which code path completes the job.
-	return wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
-		// Only update if job is not already in a terminal state (cancelled, failed)
-		// This prevents race conditions where a job was cancelled but running tasks complete
-		_, err := tx.ExecContext(ctx, `
+	var updated int64
+	err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
+		// Only update if job is not already in a terminal state. This prevents
+		// race conditions where a job was cancelled but running tasks complete.
+		result, err := tx.ExecContext(ctx, `
 			UPDATE jobs
 			SET status = $1,
 				completed_at = COALESCE(completed_at, $2),
 				progress = 100.0
 			WHERE id = $3
-			  AND status NOT IN ($4, $5)
+			  AND status NOT IN ($1, $4, $5)
 		`, JobStatusCompleted, time.Now().UTC(), jobID, JobStatusCancelled, JobStatusFailed)
+		if err != nil {
+			return err
+		}
+		updated, err = result.RowsAffected()
 		return err
 	})
+	return updated > 0, err
 }
 
 func (wp *WorkerPool) promoteWaitingTasks(ctx context.Context, jobID string, limit int) (int64, error) {
@@ -2059,7 +2543,7 @@ func (wp *WorkerPool) recoverStaleTasks(ctx context.Context) error {
 			Int("batches_processed", batchNum).
 			Msg("Completed stale task recovery")
 
-		if err := wp.reconcileRunningTaskCounters(ctx); err != nil {
+		if _, err := wp.reconcileRunningTaskCounters(ctx); err != nil {
 			log.Error().Err(err).Msg("Failed to reconcile running task counters after stale task recovery")
 		}
 	}
@@ -2127,14 +2611,17 @@ func (wp *WorkerPool) recoverStaleBatch(ctx context.Context, staleTime time.Time
 		// Query for one batch of stale tasks, oldest first
 		// Note: We recover stuck tasks regardless of job status to prevent tasks
 		// from being orphaned when jobs are marked completed/cancelled/failed
+		// Jobs with a longer task timeout get the extra time before their
+		// tasks count as stale, so slow pages aren't requeued mid-request
 		rows, err := tx.QueryContext(ctx, `
-			SELECT t.id, t.retry_count, t.job_id
+			SELECT t.id, t.retry_count, t.job_id, j.max_retries
 			FROM tasks t
+			JOIN jobs j ON t.job_id = j.id
 			WHERE t.status = $1
-				AND t.started_at < $2
+				AND t.started_at < $2::timestamptz - make_interval(secs => GREATEST(j.task_timeout_seconds - $4, 0))
 			ORDER BY t.started_at ASC
 			LIMIT $3
-		`, TaskStatusRunning, staleTime, batchSize)
+		`, TaskStatusRunning, staleTime, batchSize, DefaultTaskTimeoutSeconds)
 
 		if err != nil {
 			return err
@@ -2145,12 +2632,13 @@ func (wp *WorkerPool) recoverStaleBatch(ctx context.Context, staleTime time.Time
 			id         string
 			retryCount int
 			jobID      string
+			maxRetries int
 		}
 
 		var tasks []staleTask
 		for rows.Next() {
 			var task staleTask
-			if err := rows.Scan(&task.id, &task.retryCount, &task.jobID); err != nil {
+			if err := rows.Scan(&task.id, &task.retryCount, &task.jobID, &task.maxRetries); err != nil {
 				log.Warn().Err(err).Msg("Failed to scan stale task row")
 				continue
 			}
@@ -2173,7 +2661,7 @@ func (wp *WorkerPool) recoverStaleBatch(ctx context.Context, staleTime time.Time
 		// Update tasks in this batch
 		now := time.Now().UTC()
 		for _, task := range tasks {
-			if task.retryCount >= MaxTaskRetries {
+			if task.retryCount >= task.maxRetries {
 				_, err = tx.ExecContext(ctx, `
 					UPDATE tasks
 					SET status = $1,
@@ -2304,7 +2792,7 @@ func (wp *WorkerPool) recoverRunningJobs(ctx context.Context) error {
 			Strs("job_ids", recoveredJobs).
 			Msg("Successfully recovered running jobs from restart")
 
-		if err := wp.reconcileRunningTaskCounters(ctx); err != nil {
+		if _, err := wp.reconcileRunningTaskCounters(ctx); err != nil {
 			log.Error().Err(err).Msg("Failed to reconcile running task counters after job recovery")
 		}
 	} else {
@@ -2351,6 +2839,10 @@ func (wp *WorkerPool) scaleWorkers(ctx context.Context, targetWorkers int) {
 	wp.workersMutex.Lock()
 	defer wp.workersMutex.Unlock()
 
+	if wp.draining.Load() {
+		return // Draining pools don't start new workers
+	}
+
 	if targetWorkers <= wp.currentWorkers {
 		return // No need to scale up
 	}
@@ -2374,8 +2866,10 @@ func (wp *WorkerPool) scaleWorkers(ctx context.Context, targetWorkers int) {
 		}
 
 		wp.wg.Add(1)
+		wp.workersWG.Add(1)
 		go func(id, idx int) {
 			defer wp.wg.Done()
+			defer wp.workersWG.Done()
 			time.Sleep(time.Duration(idx*50) * time.Millisecond)
 			wp.worker(ctx, id)
 		}(workerID, i)
@@ -2445,7 +2939,7 @@ func (wp *WorkerPool) maybeEmergencyScaleDown() {
 		if !exists {
 			continue
 		}
-		effective := wp.domainLimiter.GetEffectiveConcurrency(jobID, jobInfo.DomainName)
+		effective := wp.domainLimiter.GetEffectiveConcurrency(jobID, jobInfo.limiterDomain())
 		totalConcurrency += effective
 	}
 	wp.jobInfoMutex.RUnlock()
@@ -2487,6 +2981,18 @@ func (wp *WorkerPool) maybeEmergencyScaleDown() {
 	wp.scaleDownWorkers(optimalWorkers)
 }
 
+// idleScaleDownDue reports whether a pool left above its base size with no
+// jobs is past the scale-down cooldown. Workers that were already idle when
+// the last job finished never mark idle again, so they re-check with this.
+func (wp *WorkerPool) idleScaleDownDue() bool {
+	if wp.activeJobCount() > 0 {
+		return false
+	}
+	wp.workersMutex.RLock()
+	defer wp.workersMutex.RUnlock()
+	return wp.currentWorkers > wp.baseWorkerCount && time.Since(wp.lastScaleDown) >= wp.scaleCooldown
+}
+
 // maybeScaleDown checks if all workers are idle and scales down if appropriate
 func (wp *WorkerPool) maybeScaleDown() {
 	// Feature disabled
@@ -2527,7 +3033,7 @@ func (wp *WorkerPool) maybeScaleDown() {
 		if !exists {
 			continue
 		}
-		effective := wp.domainLimiter.GetEffectiveConcurrency(jobID, jobInfo.DomainName)
+		effective := wp.domainLimiter.GetEffectiveConcurrency(jobID, jobInfo.limiterDomain())
 		neededSlots += effective
 	}
 	wp.jobInfoMutex.RUnlock()
@@ -2696,6 +3202,9 @@ func (wp *WorkerPool) StartCleanupMonitor(ctx context.Context) {
 				if err := wp.CleanupStuckJobs(ctx); err != nil {
 					log.Error().Err(err).Msg("Failed to cleanup stuck jobs")
 				}
+				if err := wp.EnforceMaxRuntime(ctx); err != nil {
+					log.Error().Err(err).Msg("Failed to enforce job max runtime")
+				}
 			}
 		}
 	})
@@ -2769,19 +3278,28 @@ func (wp *WorkerPool) promoteWaitingTasksWithQuota(ctx context.Context) error {
 	for _, job := range jobs {
 		// If job is pending, transition it to running and add to worker pool
 		if job.Status == "pending" {
+			var started bool
 			err := wp.dbQueue.Execute(ctx, func(tx *sql.Tx) error {
-				_, err := tx.ExecContext(ctx, `
+				result, err := tx.ExecContext(ctx, `
 					UPDATE jobs SET
 						status = $1,
 						started_at = CASE WHEN started_at IS NULL THEN $2 ELSE started_at END
 					WHERE id = $3 AND status = 'pending'
 				`, JobStatusRunning, time.Now().UTC(), job.ID)
-				return err
+				if err != nil {
+					return err
+				}
+				rows, _ := result.RowsAffected()
+				started = rows > 0
+				return nil
 			})
 			if err != nil {
 				log.Warn().Err(err).Str("job_id", job.ID).Msg("Failed to transition job to running")
 				continue
 			}
+			if started {
+				wp.publishJobEvent(job.ID, events.JobStarted)
+			}
 			wp.AddJob(job.ID, nil)
 			log.Info().Str("job_id", job.ID).Msg("Transitioned pending job to running after quota became available")
 		}
@@ -2852,7 +3370,8 @@ func (wp *WorkerPool) CleanupStuckJobs(ctx context.Context) error {
 	span := sentry.StartSpan(ctx, "jobs.cleanup_stuck_jobs")
 	defer span.Finish()
 
-	var completedJobs, timedOutJobs int64
+	var completedJobs int64
+	var timedOutJobIDs []string
 
 	err := wp.dbQueue.ExecuteMaintenance(ctx, func(tx *sql.Tx) error {
 		// 1. Mark jobs as completed when all tasks are done
@@ -2879,7 +3398,7 @@ func (wp *WorkerPool) CleanupStuckJobs(ctx context.Context) error {
 		// - Pending jobs with 0 tasks for 5 minutes (sitemap processing likely failed)
 		// - Running jobs with no task progress for 30 minutes (excluding jobs with waiting tasks)
 		// - Jobs running for all tasks failed
-		result, err = tx.ExecContext(ctx, `
+		rows, err := tx.QueryContext(ctx, `
 			UPDATE jobs
 			SET status = $1,
 				completed_at = $2,
@@ -2910,14 +3429,22 @@ func (wp *WorkerPool) CleanupStuckJobs(ctx context.Context) error {
 						WHERE job_id = jobs.id
 					), created_at) < $6)
 			)
+			RETURNING id
 		`, JobStatusFailed, time.Now().UTC(), JobStatusPending, time.Now().UTC().Add(-5*time.Minute), JobStatusRunning, time.Now().UTC().Add(-30*time.Minute))
 
 		if err != nil {
 			return err
 		}
 
-		timedOutJobs, err = result.RowsAffected()
-		if err != nil {
+		defer rows.Close()
+		for rows.Next() {
+			var jobID string
+			if err := rows.Scan(&jobID); err != nil {
+				return err
+			}
+			timedOutJobIDs = append(timedOutJobIDs, jobID)
+		}
+		if err := rows.Err(); err != nil {
 			return err
 		}
 
@@ -2939,11 +3466,14 @@ func (wp *WorkerPool) CleanupStuckJobs(ctx context.Context) error {
 			Msg("Marked stuck jobs as completed")
 	}
 
-	if timedOutJobs > 0 {
+	if len(timedOutJobIDs) > 0 {
 		log.Warn().
-			Int64("jobs_failed", timedOutJobs).
+			Int("jobs_failed", len(timedOutJobIDs)).
 			Msg("Marked timed-out jobs as failed")
 	}
+	for _, jobID := range timedOutJobIDs {
+		wp.notifyJobFailedSlack(jobID)
+	}
 
 	return nil
 }
@@ -3239,17 +3769,18 @@ func (wp *WorkerPool) releaseRunningTaskSlot(jobID string) error {
 }
 
 // processTask processes an individual task
-// constructTaskURL builds a proper URL from task path and domain information
-func constructTaskURL(path, domainName string) string {
+// constructTaskURL builds a proper URL from task path and domain information,
+// over the job's scheme
+func constructTaskURL(path, domainName string, scheme URLScheme) string {
 	// Check if path is already a full URL
 	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
-		return util.NormaliseURL(path)
+		return applyURLScheme(util.NormaliseURL(path), scheme)
 	} else if domainName != "" {
 		// Use centralized URL construction
-		return util.ConstructURL(domainName, path)
+		return applyURLScheme(util.ConstructURL(domainName, path), scheme)
 	} else {
 		// Fallback case - assume path is a full URL but missing protocol
-		return util.NormaliseURL(path)
+		return applyURLScheme(util.NormaliseURL(path), scheme)
 	}
 }
 
@@ -3283,14 +3814,31 @@ func (wp *WorkerPool) processDiscoveredLinks(ctx context.Context, task *Task, re
 		return
 	}
 
-	// Get robots rules from cache for URL filtering
+	// Get robots rules and path patterns from cache for URL filtering
 	var robotsRules *crawler.RobotsRules
+	var includePaths, excludePaths, denyHosts []string
+	var maxDepth int
 	wp.jobInfoMutex.RLock()
 	if jobInfo, exists := wp.jobInfoCache[task.JobID]; exists {
 		robotsRules = jobInfo.RobotsRules
+		includePaths = jobInfo.IncludePaths
+		excludePaths = jobInfo.ExcludePaths
+		denyHosts = jobInfo.DenyHosts
+		maxDepth = jobInfo.MaxDepth
 	}
 	wp.jobInfoMutex.RUnlock()
 
+	// Links from this page are one hop deeper than it
+	linkDepth := task.Depth + 1
+	if !linkDepthAllowed(linkDepth, maxDepth) {
+		log.Debug().
+			Str("task_id", task.ID).
+			Int("link_depth", linkDepth).
+			Int("max_depth", maxDepth).
+			Msg("Skipping discovered links beyond job max depth")
+		return
+	}
+
 	isHomepage := task.Path == "/"
 
 	processLinkCategory := func(links []string, priority float64) {
@@ -3308,14 +3856,29 @@ func (wp *WorkerPool) processDiscoveredLinks(ctx context.Context, task *Task, re
 		}
 
 		// 1. Filter links for same-domain and robots.txt compliance
-		var filtered []string
-		var blockedCount int
+		var filtered, deniedPatterns []string
+		var blockedCount, deniedCount int
 		for _, link := range links {
 			linkURL, err := url.Parse(link)
 			if err != nil {
 				continue
 			}
 			if isSameOrSubDomain(linkURL.Hostname(), task.DomainName) {
+				// Subdomains pass the same-domain check, so drop denied hosts
+				// before they become pages
+				if pattern, denied := matchDeniedHost(linkURL.Hostname(), denyHosts); denied {
+					deniedCount++
+					if !slices.Contains(deniedPatterns, pattern) {
+						deniedPatterns = append(deniedPatterns, pattern)
+					}
+					log.Debug().
+						Str("url", linkURL.String()).
+						Str("pattern", pattern).
+						Str("source", sourceURL).
+						Msg("Link blocked by organisation deny list")
+					continue
+				}
+
 				linkURL.Fragment = ""
 				if linkURL.Path != "/" && strings.HasSuffix(linkURL.Path, "/") {
 					linkURL.Path = strings.TrimSuffix(linkURL.Path, "/")
@@ -3336,6 +3899,8 @@ func (wp *WorkerPool) processDiscoveredLinks(ctx context.Context, task *Task, re
 			}
 		}
 
+		recordDeniedURLs(ctx, task.JobID, denyStageDiscovery, deniedCount, deniedPatterns)
+
 		if blockedCount > 0 {
 			log.Info().
 				Str("task_id", task.ID).
@@ -3344,6 +3909,20 @@ func (wp *WorkerPool) processDiscoveredLinks(ctx context.Context, task *Task, re
 				Msg("Filtered discovered links against robots.txt")
 		}
 
+		// Scoped jobs only follow links inside their include/exclude paths,
+		// using the same full-URL substring match as sitemap URLs
+		if len(filtered) > 0 && (len(includePaths) > 0 || len(excludePaths) > 0) {
+			inScope := wp.crawler.FilterURLs(filtered, includePaths, excludePaths)
+			if outOfScope := len(filtered) - len(inScope); outOfScope > 0 {
+				log.Debug().
+					Str("task_id", task.ID).
+					Int("out_of_scope_count", outOfScope).
+					Int("allowed_count", len(inScope)).
+					Msg("Filtered discovered links against job path patterns")
+			}
+			filtered = inScope
+		}
+
 		if len(filtered) == 0 {
 			return
 		}
@@ -3390,8 +3969,9 @@ func (wp *WorkerPool) processDiscoveredLinks(ctx context.Context, task *Task, re
 		pagesToEnqueue := make([]db.Page, len(pageIDs))
 		for i := range pageIDs {
 			pagesToEnqueue[i] = db.Page{
-				ID:   pageIDs[i],
-				Path: paths[i],
+				ID:    pageIDs[i],
+				Path:  paths[i],
+				Depth: linkDepth,
 				// Priority will be set by the caller of processLinkCategory
 			}
 		}
@@ -3422,14 +4002,17 @@ func (wp *WorkerPool) processDiscoveredLinks(ctx context.Context, task *Task, re
 }
 
 // handleTaskError processes task failures with appropriate retry logic and status updates
-func (wp *WorkerPool) handleTaskError(ctx context.Context, task *db.Task, taskErr error) error {
+func (wp *WorkerPool) handleTaskError(ctx context.Context, task *db.Task, result *crawler.CrawlResult, taskErr error) error {
 	now := time.Now().UTC()
 	retryReason := "non_retryable"
 
 	// Check if this is a blocking error (403/429/503)
 	if isBlockingError(taskErr) {
+		// Hold off the whole domain for as long as the server asked
+		wp.applyRetryAfter(task, result)
+
 		maxRetries := wp.domainLimiter.cfg.MaxBlockingRetries
-		if task.RetryCount < maxRetries {
+		if task.RetryCount < maxRetries && wp.takeRetry(ctx, task.JobID) {
 			retryReason = "blocking"
 			task.RetryCount++
 			// Route retries through waiting to respect pending queue cap
@@ -3454,10 +4037,11 @@ func (wp *WorkerPool) handleTaskError(ctx context.Context, task *db.Task, taskEr
 				Int("retry_count", task.RetryCount).
 				Msg("Task blocked permanently after exhausting retries")
 			wp.recordJobFailure(ctx, task.JobID, task.ID, taskErr)
+			wp.recordTaskFailure(task, result, taskErr)
 			observability.RecordWorkerTaskFailure(ctx, task.JobID, "blocking")
 		}
-	} else if isRetryableError(taskErr) && task.RetryCount < MaxTaskRetries {
-		// For other retryable errors, use normal retry limit
+	} else if isRetryableError(taskErr) && task.RetryCount < wp.maxRetriesForJob(task.JobID) && wp.takeRetry(ctx, task.JobID) {
+		// For other retryable errors, use the job's retry limit
 		retryReason = "retryable"
 		task.RetryCount++
 		// Route retries through waiting to respect pending queue cap
@@ -3485,6 +4069,7 @@ func (wp *WorkerPool) handleTaskError(ctx context.Context, task *db.Task, taskEr
 			Int("retry_count", task.RetryCount).
 			Msg("Task failed permanently")
 		wp.recordJobFailure(ctx, task.JobID, task.ID, taskErr)
+		wp.recordTaskFailure(task, result, taskErr)
 		failureReason := retryReason
 		if !isBlockingError(taskErr) && !isRetryableError(taskErr) {
 			failureReason = "non_retryable"
@@ -3509,11 +4094,55 @@ func (wp *WorkerPool) handleTaskError(ctx context.Context, task *db.Task, taskEr
 	return nil
 }
 
+// applyRetryAfter seeds the domain limiter from a 429/503 Retry-After header.
+// Without one, the limiter's own exponential backoff applies.
+func (wp *WorkerPool) applyRetryAfter(task *db.Task, result *crawler.CrawlResult) {
+	if result == nil || result.RetryAfter <= 0 {
+		return
+	}
+
+	wp.jobInfoMutex.RLock()
+	info, exists := wp.jobInfoCache[task.JobID]
+	wp.jobInfoMutex.RUnlock()
+	if !exists || info.DomainName == "" {
+		return
+	}
+
+	applied := wp.ensureDomainLimiter().ApplyRetryAfter(info.limiterDomain(), result.RetryAfter)
+	if applied > 0 {
+		log.Info().
+			Str("job_id", task.JobID).
+			Str("task_id", task.ID).
+			Str("domain", info.DomainName).
+			Int("status_code", result.StatusCode).
+			Dur("retry_after", result.RetryAfter).
+			Dur("applied_pause", applied).
+			Msg("Pausing domain for Retry-After")
+	}
+}
+
+// recordCacheStatusMetrics counts a warmed page's cache statuses by domain
+func (wp *WorkerPool) recordCacheStatusMetrics(ctx context.Context, jobID string, result *crawler.CrawlResult) {
+	wp.jobInfoMutex.RLock()
+	info, exists := wp.jobInfoCache[jobID]
+	wp.jobInfoMutex.RUnlock()
+	if !exists || info.DomainName == "" {
+		return
+	}
+
+	observability.RecordCacheStatus(ctx, info.DomainName, result.CacheStatus)
+	if result.SecondCacheStatus != "" {
+		observability.RecordSecondCacheStatus(ctx, info.DomainName, result.SecondCacheStatus)
+	}
+}
+
 // handleTaskSuccess processes successful task completion with metrics and database updates
 func (wp *WorkerPool) handleTaskSuccess(ctx context.Context, task *db.Task, result *crawler.CrawlResult) error {
 	now := time.Now().UTC()
 
 	wp.resetJobFailureStreak(task.JobID)
+	wp.throughput.record(now, result.CacheStatus)
+	wp.recordCacheStatusMetrics(ctx, task.JobID, result)
 
 	// Mark as completed with basic metrics
 	task.Status = string(TaskStatusCompleted)
@@ -3521,12 +4150,22 @@ func (wp *WorkerPool) handleTaskSuccess(ctx context.Context, task *db.Task, resu
 	task.StatusCode = result.StatusCode
 	task.ResponseTime = result.ResponseTime
 	task.CacheStatus = result.CacheStatus
+	task.OriginCacheStatus = result.OriginCacheStatus
+	task.NotModified = result.NotModified
+	if wp.detectsSoft404(task.JobID) && wp.soft404Markers.IsSoft404(result) {
+		task.Soft404 = true
+		log.Debug().Str("task_id", task.ID).Str("url", result.URL).Msg("Response looks like a soft 404")
+	}
+	if wp.hashesContent(task.JobID) {
+		wp.recordContentHash(ctx, task, result)
+	}
 	task.ContentType = result.ContentType
 	task.ContentLength = result.ContentLength
 	// Only store redirect_url if it's a significant redirect (different domain or path)
 	if util.IsSignificantRedirect(result.URL, result.RedirectURL) {
 		task.RedirectURL = result.RedirectURL
 	}
+	wp.checkHTTPSRedirect(task.JobID, result)
 
 	// Performance metrics
 	task.DNSLookupTime = result.Performance.DNSLookupTime
@@ -3609,7 +4248,8 @@ func (wp *WorkerPool) handleTaskSuccess(ctx context.Context, task *db.Task, resu
 	}
 
 	// Run technology detection for this domain (once per session, async)
-	// Use bounded context to ensure detection doesn't hang during shutdown
+	// Use bounded context to ensure detection doesn't hang during shutdown.
+	// HEAD warms have no body, so there's nothing to detect or upload.
 	if result.StatusCode >= 200 && result.StatusCode < 300 && len(result.BodySample) > 0 {
 		go func() {
 			detectCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -3622,7 +4262,8 @@ func (wp *WorkerPool) handleTaskSuccess(ctx context.Context, task *db.Task, resu
 }
 
 // detectTechnologies runs wappalyzer detection on the crawl result and updates the domain.
-// Only runs once per domain per worker pool session to avoid redundant detection.
+// Only runs once per domain per worker pool session to avoid redundant detection,
+// except that a full_body_detection job re-runs it once over a sampled result.
 // If storage is configured, uploads the full HTML body to Supabase Storage.
 func (wp *WorkerPool) detectTechnologies(ctx context.Context, task *db.Task, result *crawler.CrawlResult) {
 	if wp.techDetector == nil {
@@ -3641,28 +4282,34 @@ func (wp *WorkerPool) detectTechnologies(ctx context.Context, task *db.Task, res
 
 	domainID := jobInfo.DomainID
 	domainName := jobInfo.DomainName
+	fullBody := jobInfo.FullBodyDetection
 
 	// Check if already detected for this domain in this session
 	wp.techDetectedMutex.RLock()
-	alreadyDetected := wp.techDetectedDomains[domainID]
+	detectedFull, alreadyDetected := wp.techDetectedDomains[domainID]
 	wp.techDetectedMutex.RUnlock()
 
-	if alreadyDetected {
+	if alreadyDetected && (detectedFull || !fullBody) {
 		return
 	}
 
 	// Mark as detected before processing to prevent duplicate detection
 	wp.techDetectedMutex.Lock()
 	// Double-check after acquiring write lock
-	if wp.techDetectedDomains[domainID] {
+	if detectedFull, alreadyDetected = wp.techDetectedDomains[domainID]; alreadyDetected && (detectedFull || !fullBody) {
 		wp.techDetectedMutex.Unlock()
 		return
 	}
-	wp.techDetectedDomains[domainID] = true
+	wp.techDetectedDomains[domainID] = fullBody
 	wp.techDetectedMutex.Unlock()
 
-	// Run detection using truncated body sample
-	detectResult := wp.techDetector.Detect(result.Headers, result.BodySample)
+	// Run detection on the truncated body sample, or the whole page when the
+	// job trades the extra CPU and memory for markers past the sample
+	body := result.BodySample
+	if fullBody && len(result.Body) > 0 {
+		body = result.Body
+	}
+	detectResult := wp.techDetector.Detect(result.Headers, body)
 
 	// Marshal technologies and headers for storage
 	techJSON, err := detectResult.TechnologiesJSON()
@@ -3743,15 +4390,26 @@ func (wp *WorkerPool) processTask(ctx context.Context, task *Task) (*crawler.Cra
 	}()
 
 	// Construct a proper URL for processing
-	urlStr := constructTaskURL(task.Path, task.DomainName)
+	urlStr := constructTaskURL(task.Path, task.DomainName, task.URLScheme)
+
+	// Safety net for tasks queued before a host was added to the deny list
+	if pattern, denied := matchDeniedHost(taskURLHost(urlStr), task.DenyHosts); denied {
+		status = "skipped"
+		recordDeniedURLs(ctx, task.JobID, denyStageClaim, 1, []string{pattern})
+		return nil, errTaskHostDenied
+	}
 
 	log.Debug().Str("url", urlStr).Str("task_id", task.ID).Msg("Starting URL warm")
 
 	limiter := wp.ensureDomainLimiter()
+	domain := limiterDomain(task.DomainName, task.GroupSubdomains)
 	permit, err := limiter.Acquire(ctx, DomainRequest{
-		Domain:      task.DomainName,
+		Domain:      domain,
+		Host:        task.DomainName,
 		JobID:       task.JobID,
 		RobotsDelay: time.Duration(task.CrawlDelay) * time.Second,
+		MinDelay:    time.Duration(task.MinCrawlDelay) * time.Second,
+		Schedule:    task.ConcurrencySchedule,
 		JobConcurrency: func() int {
 			if task.JobConcurrency > 0 {
 				return task.JobConcurrency
@@ -3759,6 +4417,10 @@ func (wp *WorkerPool) processTask(ctx context.Context, task *Task) (*crawler.Cra
 			return 1
 		}(),
 	})
+	if errors.Is(err, errCircuitOpen) {
+		status = "circuit_open"
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3769,7 +4431,63 @@ func (wp *WorkerPool) processTask(ctx context.Context, task *Task) (*crawler.Cra
 		}
 	}()
 
+	if task.VerifyConcurrency > 0 {
+		// Hand the warming slot back once the first request succeeds so the
+		// verification phase runs under its own limit. Called synchronously
+		// from WarmURL, so released needs no locking.
+		ctx = crawler.WithVerificationGate(ctx, func(gateCtx context.Context) (func(), error) {
+			permit.Release(true, false)
+			released = true
+			return wp.acquireVerifySlot(gateCtx, task.JobID, task.VerifyConcurrency)
+		})
+	}
+
+	if task.UserAgent != "" {
+		ctx = crawler.WithUserAgent(ctx, task.UserAgent)
+	}
+	ctx = crawler.WithCustomHeaders(ctx, task.CustomHeaders)
+	ctx = crawler.WithCredentials(ctx, task.Credentials)
+	ctx = crawler.WithMethod(ctx, string(task.Method))
+	ctx = crawler.WithSecondRequest(ctx, task.SecondRequest)
+
+	// Jobs with a longer task timeout let a single slow request use it; the
+	// permit is still released by the deferred Release if the context expires
+	if task.TaskTimeout > taskProcessingTimeout {
+		ctx = crawler.WithRequestTimeout(ctx, task.TaskTimeout)
+	}
+
+	if task.ChangedOnly && wp.pageUnchanged(ctx, task, urlStr) {
+		status = "skipped"
+		permit.Release(true, false)
+		released = true
+		return nil, errTaskUnchanged
+	}
+
+	if task.SkipCachedURLs && wp.pageCached(ctx, task, urlStr) {
+		status = "skipped"
+		permit.Release(true, false)
+		released = true
+		return nil, errTaskCached
+	}
+
+	if len(task.CacheableStatusCodes) > 0 {
+		ctx = crawler.WithCacheableStatusCodes(ctx, task.CacheableStatusCodes)
+	}
+
+	if !task.Validators.IsZero() {
+		ctx = crawler.WithValidators(ctx, task.Validators)
+	}
+
+	ctx = crawler.WithContentTypes(ctx, task.ContentTypes)
+
 	result, err := wp.crawler.WarmURL(ctx, urlStr, task.FindLinks)
+	if errors.Is(err, crawler.ErrContentTypeNotAllowed) {
+		// The origin answered fine; the page just isn't one we warm
+		status = "skipped"
+		permit.Release(true, false)
+		released = true
+		return result, errTaskContentType
+	}
 	if err != nil {
 		status = "error"
 		span.RecordError(err)
@@ -3787,19 +4505,19 @@ func (wp *WorkerPool) processTask(ctx context.Context, task *Task) (*crawler.Cra
 		log.Debug().Err(err).
 			Str("task_id", task.ID).
 			Bool("rate_limited", rateLimited).
-			Int("status_code", func() int {
-				if result != nil {
-					return result.StatusCode
-				}
-				return 0
-			}()).
+			Int("status_code", resultStatusCode(result)).
 			Msg("Crawler failed")
 		permit.Release(false, rateLimited)
 		released = true
+		wp.recordOriginOutcome(task, resultStatusCode(result), rateLimited)
+		// No response at all counts against the breaker too
+		limiter.RecordBreakerOutcome(domain, resultStatusCode(result) == 0 || isOriginDistress(resultStatusCode(result), rateLimited))
 		return result, fmt.Errorf("crawler error: %w", err)
 	}
 	permit.Release(true, false)
 	released = true
+	wp.recordOriginOutcome(task, resultStatusCode(result), false)
+	limiter.RecordBreakerOutcome(domain, isOriginDistress(resultStatusCode(result), false))
 
 	if result != nil {
 		span.SetAttributes(
@@ -3827,92 +4545,17 @@ func (wp *WorkerPool) processTask(ctx context.Context, task *Task) (*crawler.Cra
 
 // isRetryableError checks if an error should trigger a retry
 func isRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errorStr := strings.ToLower(err.Error())
-
-	// Network/timeout errors that should be retried
-	networkErrors := strings.Contains(errorStr, "timeout") ||
-		strings.Contains(errorStr, "deadline exceeded") ||
-		strings.Contains(errorStr, "connection") ||
-		strings.Contains(errorStr, "network") ||
-		strings.Contains(errorStr, "temporary") ||
-		strings.Contains(errorStr, "reset by peer") ||
-		strings.Contains(errorStr, "broken pipe") ||
-		strings.Contains(errorStr, "unexpected eof")
-
-	// Server errors that should be retried (likely due to load/temporary issues)
-	// Note: 503 Service Unavailable is treated as a blocking error (see isBlockingError)
-	serverErrors := strings.Contains(errorStr, "internal server error") ||
-		strings.Contains(errorStr, "bad gateway") ||
-		strings.Contains(errorStr, "gateway timeout") ||
-		strings.Contains(errorStr, "502") ||
-		strings.Contains(errorStr, "504") ||
-		strings.Contains(errorStr, "500")
-
-	return networkErrors || serverErrors
+	return ClassifyError(err).Retryable()
 }
 
 // isBlockingError checks if an error indicates we're being blocked
 func isBlockingError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errorStr := strings.ToLower(err.Error())
-
-	// Blocking/rate limit errors that need special handling with exponential backoff
-	return strings.Contains(errorStr, "403") ||
-		strings.Contains(errorStr, "forbidden") ||
-		strings.Contains(errorStr, "429") ||
-		strings.Contains(errorStr, "too many requests") ||
-		strings.Contains(errorStr, "rate limit") ||
-		strings.Contains(errorStr, "503") ||
-		strings.Contains(errorStr, "service unavailable")
+	return ClassifyError(err) == ErrorClassBlocked
 }
 
-var statusCodePattern = regexp.MustCompile(`\b(\d{3})\b`)
-
+// isClientOrRedirectError checks for 4xx and redirect errors that won't change on retry
 func isClientOrRedirectError(err error) bool {
-	if err == nil {
-		return false
-	}
-	lower := strings.ToLower(err.Error())
-	if strings.Contains(lower, "crawler error") {
-		keywords := []string{
-			"not found",
-			"bad request",
-			"unauthorized",
-			"forbidden",
-			"gone",
-			"method not allowed",
-			"temporary redirect",
-			"permanent redirect",
-			"moved permanently",
-			"see other",
-		}
-		for _, kw := range keywords {
-			if strings.Contains(lower, kw) {
-				return true
-			}
-		}
-	}
-	matches := statusCodePattern.FindAllStringSubmatch(lower, -1)
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		code, err := strconv.Atoi(match[1])
-		if err != nil {
-			continue
-		}
-		if code >= 400 && code < 500 {
-			return true
-		}
-	}
-	return false
+	return ClassifyError(err) == ErrorClassClient
 }
 
 // calculateBackoffDuration computes exponential backoff duration for retry attempts
@@ -4060,8 +4703,19 @@ func (wp *WorkerPool) evaluateJobPerformance(jobID string, responseTime int64) {
 		oldBoost        int
 		neededBoost     int
 		recentBlocking  bool
+		backOff         bool
+		domain          string
+		jobConcurrency  int
 	)
 
+	wp.jobInfoMutex.RLock()
+	if info, ok := wp.jobInfoCache[jobID]; ok && info.SlowOriginPolicy == SlowOriginPolicyBackOff {
+		backOff = true
+		domain = info.limiterDomain()
+		jobConcurrency = info.Concurrency
+	}
+	wp.jobInfoMutex.RUnlock()
+
 	wp.perfMutex.Lock()
 	perf, exists := wp.jobPerformance[jobID]
 	if !exists {
@@ -4069,6 +4723,14 @@ func (wp *WorkerPool) evaluateJobPerformance(jobID string, responseTime int64) {
 		return // Job not tracked
 	}
 
+	// Auto-tuned jobs find their own limit instead of boosting or backing off
+	if perf.Auto != nil {
+		perf.Auto.recordLatency(responseTime)
+		perf.LastCheck = time.Now()
+		wp.perfMutex.Unlock()
+		return
+	}
+
 	// Add response time to recent tasks (sliding window of 5)
 	perf.RecentTasks = append(perf.RecentTasks, responseTime)
 	if len(perf.RecentTasks) > 5 {
@@ -4088,6 +4750,17 @@ func (wp *WorkerPool) evaluateJobPerformance(jobID string, responseTime int64) {
 	}
 	avgResponseTime = total / int64(len(perf.RecentTasks))
 
+	// Back-off jobs shed concurrency on latency spikes instead of boosting
+	if backOff {
+		decision, changed := nextLatencyCap(perf, avgResponseTime, jobConcurrency, wp.latencySpikeMultiplier, time.Now())
+		perf.LastCheck = time.Now()
+		wp.perfMutex.Unlock()
+		if changed {
+			wp.applyLatencyCap(jobID, domain, avgResponseTime, decision)
+		}
+		return
+	}
+
 	oldBoost = perf.CurrentBoost
 	recentBlocking = !perf.LastConcurrencyBlock.IsZero() && time.Since(perf.LastConcurrencyBlock) < concurrencyBlockCooldown
 
@@ -4192,8 +4865,14 @@ func (wp *WorkerPool) listenForNotifications(ctx context.Context) {
 		log.Error().Err(err).Msg("Failed to connect for notifications initially")
 		return
 	}
-	defer conn.Close(ctx)
+	wp.markNotifyConnected()
+	defer func() {
+		if conn != nil {
+			_ = conn.Close(ctx)
+		}
+	}()
 
+	failedAttempts := 0
 	for {
 		select {
 		case <-wp.stopCh:
@@ -4206,20 +4885,42 @@ func (wp *WorkerPool) listenForNotifications(ctx context.Context) {
 			// Non-blocking check for stop signal before waiting for notification
 		}
 
+		if conn == nil {
+			// Back off with jitter so a struggling database isn't hammered
+			delay := calculateBackoffSleep(failedAttempts, wp.notifyReconnectBase, wp.notifyReconnectMax)
+			select {
+			case <-wp.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			conn, err = connect()
+			if err != nil {
+				failedAttempts++
+				wp.markNotifyReconnectFailed()
+				log.Warn().
+					Err(err).
+					Int("attempt", failedAttempts).
+					Msg("Failed to reconnect for notifications")
+				continue
+			}
+			log.Info().Int("failed_attempts", failedAttempts).Msg("Notification listener reconnected")
+			failedAttempts = 0
+			wp.markNotifyConnected()
+		}
+
 		notification, err := conn.WaitForNotification(ctx)
 		if err != nil {
 			if ctx.Err() != nil || wp.stopping.Load() {
 				return // Context cancelled or pool is stopping
 			}
 			log.Warn().Err(err).Msg("Error waiting for notification, reconnecting...")
+			wp.markNotifyDisconnected()
+			observability.RecordNotifyListenerDisconnect(ctx)
 			_ = conn.Close(ctx)
-			time.Sleep(5 * time.Second) // Wait before reconnecting
-
-			conn, err = connect()
-			if err != nil {
-				log.Warn().Err(err).Msg("Failed to reconnect for notifications")
-				continue
-			}
+			conn = nil
 			continue
 		}
 
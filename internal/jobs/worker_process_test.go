@@ -70,8 +70,8 @@ func (m *MockCrawler) DiscoverSitemapsAndRobots(ctx context.Context, domain stri
 	return &crawler.SitemapDiscoveryResult{}, nil
 }
 
-func (m *MockCrawler) ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error) {
-	return []string{}, nil
+func (m *MockCrawler) ParseSitemap(ctx context.Context, sitemapURL string) ([]crawler.SitemapURL, error) {
+	return []crawler.SitemapURL{}, nil
 }
 
 func (m *MockCrawler) FilterURLs(urls []string, includePaths, excludePaths []string) []string {
@@ -82,6 +82,14 @@ func (m *MockCrawler) GetUserAgent() string {
 	return "TestBot/1.0"
 }
 
+func (m *MockCrawler) CheckUnchanged(ctx context.Context, url string, previous crawler.Validators) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCrawler) CheckCached(ctx context.Context, url string) (bool, error) {
+	return false, nil
+}
+
 // MockDbQueue implements a minimal DbQueue interface for testing
 type MockDbQueue struct {
 	GetNextTaskFunc             func(ctx context.Context, jobID string) (*db.Task, error)
@@ -35,14 +35,14 @@ func (m *MockCrawler) DiscoverSitemapsAndRobots(ctx context.Context, domain stri
 }
 
 // ParseSitemap mocks the ParseSitemap method
-func (m *MockCrawler) ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error) {
+func (m *MockCrawler) ParseSitemap(ctx context.Context, sitemapURL string) ([]crawler.SitemapURL, error) {
 	args := m.Called(ctx, sitemapURL)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).([]string), args.Error(1)
+	return args.Get(0).([]crawler.SitemapURL), args.Error(1)
 }
 
 // FilterURLs mocks the FilterURLs method
@@ -56,6 +56,18 @@ func (m *MockCrawler) FilterURLs(urls []string, includePaths, excludePaths []str
 	return args.Get(0).([]string)
 }
 
+// CheckUnchanged mocks the CheckUnchanged method
+func (m *MockCrawler) CheckUnchanged(ctx context.Context, url string, previous crawler.Validators) (bool, error) {
+	args := m.Called(ctx, url, previous)
+	return args.Bool(0), args.Error(1)
+}
+
+// CheckCached mocks the CheckCached method
+func (m *MockCrawler) CheckCached(ctx context.Context, url string) (bool, error) {
+	args := m.Called(ctx, url)
+	return args.Bool(0), args.Error(1)
+}
+
 // GetUserAgent mocks the GetUserAgent method
 func (m *MockCrawler) GetUserAgent() string {
 	args := m.Called()
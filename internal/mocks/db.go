@@ -117,17 +117,13 @@ func (m *MockDB) ListJobs(organisationID string, limit, offset int, status, date
 	return jobs, total, args.Error(2)
 }
 
-func (m *MockDB) ListJobsWithOffset(organisationID string, limit, offset int, status, dateRange string, tzOffsetMinutes int) ([]db.JobWithDomain, int, error) {
-	args := m.Called(organisationID, limit, offset, status, dateRange, tzOffsetMinutes)
-	var jobs []db.JobWithDomain
-	if v := args.Get(0); v != nil {
-		jobs = v.([]db.JobWithDomain)
-	}
-	total := 0
-	if v := args.Get(1); v != nil {
-		total = v.(int)
+// ListJobsPage mocks the ListJobsPage method
+func (m *MockDB) ListJobsPage(organisationID string, opts db.JobListOptions) (*db.JobListPage, error) {
+	args := m.Called(organisationID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return jobs, total, args.Error(2)
+	return args.Get(0).(*db.JobListPage), args.Error(1)
 }
 
 // GetJobStats mocks the GetJobStats method
@@ -262,6 +258,36 @@ func (m *MockDB) GetDomainsForOrganisation(ctx context.Context, organisationID s
 	return args.Get(0).([]db.OrganisationDomain), args.Error(1)
 }
 
+// GetDomainStats mocks the GetDomainStats method
+func (m *MockDB) GetDomainStats(ctx context.Context, organisationID, domain string, since *time.Time) (*db.DomainStats, error) {
+	args := m.Called(ctx, organisationID, domain, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.DomainStats), args.Error(1)
+}
+
+// GetDomainTechnologies mocks the GetDomainTechnologies method
+func (m *MockDB) GetDomainTechnologies(ctx context.Context, domainID int) (*db.DomainTechnologies, error) {
+	args := m.Called(ctx, domainID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.DomainTechnologies), args.Error(1)
+}
+
+// GetOrganisationWebhookSecret mocks the GetOrganisationWebhookSecret method
+func (m *MockDB) GetOrganisationWebhookSecret(ctx context.Context, organisationID string) (string, error) {
+	args := m.Called(ctx, organisationID)
+	return args.String(0), args.Error(1)
+}
+
+// RotateOrganisationWebhookSecret mocks the RotateOrganisationWebhookSecret method
+func (m *MockDB) RotateOrganisationWebhookSecret(ctx context.Context, organisationID string) (string, error) {
+	args := m.Called(ctx, organisationID)
+	return args.String(0), args.Error(1)
+}
+
 // GetLastJobStartTimeForScheduler mocks the GetLastJobStartTimeForScheduler method
 func (m *MockDB) GetLastJobStartTimeForScheduler(ctx context.Context, schedulerID string) (*time.Time, error) {
 	args := m.Called(ctx, schedulerID)
@@ -271,6 +297,12 @@ func (m *MockDB) GetLastJobStartTimeForScheduler(ctx context.Context, schedulerI
 	return args.Get(0).(*time.Time), args.Error(1)
 }
 
+// HasActiveJobForScheduler mocks the HasActiveJobForScheduler method
+func (m *MockDB) HasActiveJobForScheduler(ctx context.Context, schedulerID string) (bool, error) {
+	args := m.Called(ctx, schedulerID)
+	return args.Bool(0), args.Error(1)
+}
+
 // ListUserOrganisations mocks the ListUserOrganisations method
 func (m *MockDB) ListUserOrganisations(userID string) ([]db.UserOrganisation, error) {
 	args := m.Called(userID)
@@ -638,6 +670,49 @@ func (m *MockDB) DeleteSiteSettingsByConnection(ctx context.Context, connectionI
 	return args.Error(0)
 }
 
+// CDN purge integration methods
+
+func (m *MockDB) UpsertCDNPurgeConnection(ctx context.Context, conn *db.CDNPurgeConnection) error {
+	args := m.Called(ctx, conn)
+	return args.Error(0)
+}
+
+func (m *MockDB) GetCDNPurgeConnection(ctx context.Context, organisationID string) (*db.CDNPurgeConnection, error) {
+	args := m.Called(ctx, organisationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.CDNPurgeConnection), args.Error(1)
+}
+
+func (m *MockDB) DeleteCDNPurgeConnection(ctx context.Context, organisationID string) error {
+	args := m.Called(ctx, organisationID)
+	return args.Error(0)
+}
+
+func (m *MockDB) StoreCDNPurgeToken(ctx context.Context, connectionID, token string) error {
+	args := m.Called(ctx, connectionID, token)
+	return args.Error(0)
+}
+
+// ListHealthWarnings mocks listing health monitor warnings
+func (m *MockDB) ListHealthWarnings(ctx context.Context, includeResolved bool, limit int) ([]*db.HealthWarning, error) {
+	args := m.Called(ctx, includeResolved, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*db.HealthWarning), args.Error(1)
+}
+
+// AcknowledgeHealthWarning mocks acknowledging a health warning
+func (m *MockDB) AcknowledgeHealthWarning(ctx context.Context, warningID int64, userID string) (*db.HealthWarning, error) {
+	args := m.Called(ctx, warningID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.HealthWarning), args.Error(1)
+}
+
 func (m *MockDB) GetSiteSettingBySiteID(ctx context.Context, orgID, webflowSiteID string) (*db.WebflowSiteSetting, error) {
 	args := m.Called(ctx, orgID, webflowSiteID)
 	if args.Get(0) == nil {
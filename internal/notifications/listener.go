@@ -148,6 +148,19 @@ func StartWithFallback(ctx context.Context, connStr string, service *Service) {
 	go startPolling(ctx, service)
 }
 
+// ListenConnString returns a connection string that supports LISTEN, preferring
+// DATABASE_DIRECT_URL when it connects. Returns "" when only a pooled
+// connection is available, so callers should fall back to polling.
+func ListenConnString(connStr string) string {
+	if directURL := os.Getenv("DATABASE_DIRECT_URL"); directURL != "" && testConnection(directURL) {
+		return directURL
+	}
+	if canUseListen(connStr) {
+		return connStr
+	}
+	return ""
+}
+
 // testConnection tests if a database connection can be established.
 func testConnection(connStr string) bool {
 	db, err := sql.Open("postgres", connStr)
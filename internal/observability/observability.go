@@ -65,6 +65,13 @@ var (
 	workerTaskRetryCounter   metric.Int64Counter
 	workerTaskFailureCounter metric.Int64Counter
 	workerTaskWaitingCounter metric.Int64Counter
+	workerDeniedURLCounter   metric.Int64Counter
+	workerBreakerCounter     metric.Int64Counter
+
+	cacheStatusCounter       metric.Int64Counter
+	secondCacheStatusCounter metric.Int64Counter
+
+	notifyListenerDisconnectCounter metric.Int64Counter
 
 	jobRunningTasksGauge     metric.Int64Gauge
 	jobConcurrencyLimitGauge metric.Int64Gauge
@@ -72,6 +79,10 @@ var (
 	jobInfoCacheMissCounter  metric.Int64Counter
 	jobInfoCacheInvalidation metric.Int64Counter
 	jobInfoCacheSizeGauge    metric.Int64Gauge
+	jobTaskBacklogGauge      metric.Int64Gauge
+	taskBacklogGauge         metric.Int64Gauge
+	queuedTooLongGauge       metric.Int64Gauge
+	oldestQueuedTaskGauge    metric.Float64Gauge
 
 	dbPoolInUseGauge        metric.Int64Gauge
 	dbPoolIdleGauge         metric.Int64Gauge
@@ -81,6 +92,9 @@ var (
 	dbPoolMaxOpenGauge      metric.Int64Gauge
 	dbPoolReservedGauge     metric.Int64Gauge
 	dbPoolRejectCounter     metric.Int64Counter
+
+	batchQueueDepthGauge    metric.Int64Gauge
+	batchQueueCapacityGauge metric.Int64Gauge
 )
 
 // Init configures tracing and metrics exporters. When cfg.Enabled is false the function is a no-op.
@@ -218,7 +232,7 @@ func WrapHandler(handler http.Handler, prov *Providers) http.Handler {
 		}),
 		// Skip tracing for health checks to reduce noise
 		otelhttp.WithFilter(func(r *http.Request) bool {
-			return r.URL.Path != "/health"
+			return r.URL.Path != "/health" && r.URL.Path != "/health/ready"
 		}),
 	}
 
@@ -313,6 +327,46 @@ func initWorkerInstruments(meterProvider *sdkmetric.MeterProvider) error {
 		"bee.worker.task.waiting_total",
 		metric.WithDescription("Number of times tasks enter waiting state"),
 	)
+	if err != nil {
+		return err
+	}
+
+	workerDeniedURLCounter, err = meter.Int64Counter(
+		"bee.worker.denied_urls_total",
+		metric.WithDescription("URLs dropped because their host is on the organisation deny list"),
+	)
+	if err != nil {
+		return err
+	}
+
+	workerBreakerCounter, err = meter.Int64Counter(
+		"bee.worker.circuit_breaker.transitions_total",
+		metric.WithDescription("Domain circuit breaker state changes, by the state entered"),
+	)
+	if err != nil {
+		return err
+	}
+
+	cacheStatusCounter, err = meter.Int64Counter(
+		"bee.worker.cache.status_total",
+		metric.WithDescription("Warmed pages by domain and cache status of the first request"),
+	)
+	if err != nil {
+		return err
+	}
+
+	secondCacheStatusCounter, err = meter.Int64Counter(
+		"bee.worker.cache.second_status_total",
+		metric.WithDescription("Warmed pages by domain and cache status of the verification request"),
+	)
+	if err != nil {
+		return err
+	}
+
+	notifyListenerDisconnectCounter, err = meter.Int64Counter(
+		"bee.worker.notify_listener.disconnects_total",
+		metric.WithDescription("Number of times the LISTEN/NOTIFY connection was lost"),
+	)
 	return err
 }
 
@@ -368,6 +422,39 @@ func initJobInstruments(meterProvider *sdkmetric.MeterProvider) error {
 		"bee.jobs.cache_size",
 		metric.WithDescription("Current job info cache size"),
 	)
+	if err != nil {
+		return err
+	}
+
+	jobTaskBacklogGauge, err = meter.Int64Gauge(
+		"bee.jobs.task_backlog",
+		metric.WithDescription("Pending, waiting and running tasks for the running jobs with the largest backlogs"),
+	)
+	if err != nil {
+		return err
+	}
+
+	taskBacklogGauge, err = meter.Int64Gauge(
+		"bee.tasks.backlog",
+		metric.WithDescription("Pending, waiting and running tasks across all running jobs"),
+	)
+	if err != nil {
+		return err
+	}
+
+	queuedTooLongGauge, err = meter.Int64Gauge(
+		"bee.tasks.queued_too_long",
+		metric.WithDescription("Pending or waiting tasks of running jobs queued longer than the alert threshold"),
+	)
+	if err != nil {
+		return err
+	}
+
+	oldestQueuedTaskGauge, err = meter.Float64Gauge(
+		"bee.tasks.oldest_queued_seconds",
+		metric.WithDescription("Age of the oldest task queued longer than the alert threshold"),
+		metric.WithUnit("s"),
+	)
 	return err
 }
 
@@ -440,6 +527,22 @@ func initDBPoolInstruments(meterProvider *sdkmetric.MeterProvider) error {
 		"bee.db.pool.rejects_total",
 		metric.WithDescription("Number of pool rejections when context expires before acquiring connection"),
 	)
+	if err != nil {
+		return err
+	}
+
+	batchQueueDepthGauge, err = meter.Int64Gauge(
+		"bee.db.batch.queue_depth",
+		metric.WithDescription("Task updates waiting to be flushed by the batch manager"),
+	)
+	if err != nil {
+		return err
+	}
+
+	batchQueueCapacityGauge, err = meter.Int64Gauge(
+		"bee.db.batch.queue_capacity",
+		metric.WithDescription("Task updates the batch manager buffers before callers block"),
+	)
 	return err
 }
 
@@ -567,6 +670,37 @@ func RecordJobInfoCacheSize(ctx context.Context, size int) {
 	jobInfoCacheSizeGauge.Record(ctx, int64(size))
 }
 
+// RecordTaskBacklog records system-wide pending, waiting and running task counts.
+func RecordTaskBacklog(ctx context.Context, pending, waiting, running int) {
+	recordBacklog(ctx, taskBacklogGauge, nil, pending, waiting, running)
+}
+
+// RecordQueuedTooLong records how many tasks have sat pending or waiting past
+// the alert threshold, and how long the oldest of them has been queued.
+func RecordQueuedTooLong(ctx context.Context, count int, oldest time.Duration) {
+	if queuedTooLongGauge != nil {
+		queuedTooLongGauge.Record(ctx, int64(count))
+	}
+	if oldestQueuedTaskGauge != nil {
+		oldestQueuedTaskGauge.Record(ctx, oldest.Seconds())
+	}
+}
+
+// RecordJobTaskBacklog records a job's pending, waiting and running task counts.
+func RecordJobTaskBacklog(ctx context.Context, jobID string, pending, waiting, running int) {
+	recordBacklog(ctx, jobTaskBacklogGauge, []attribute.KeyValue{attribute.String("job.id", jobID)}, pending, waiting, running)
+}
+
+func recordBacklog(ctx context.Context, gauge metric.Int64Gauge, attrs []attribute.KeyValue, pending, waiting, running int) {
+	if gauge == nil {
+		return
+	}
+	for status, count := range map[string]int{"pending": pending, "waiting": waiting, "running": running} {
+		gauge.Record(ctx, int64(count),
+			metric.WithAttributes(append(attrs, attribute.String("task.status", status))...))
+	}
+}
+
 // DBPoolSnapshot describes a database connection pool state.
 type DBPoolSnapshot struct {
 	InUse        int
@@ -603,6 +737,16 @@ func RecordDBPoolStats(ctx context.Context, snapshot DBPoolSnapshot) {
 	}
 }
 
+// RecordBatchQueueDepth records how many task updates are waiting to be flushed.
+func RecordBatchQueueDepth(ctx context.Context, depth, capacity int) {
+	if batchQueueDepthGauge != nil {
+		batchQueueDepthGauge.Record(ctx, int64(depth))
+	}
+	if batchQueueCapacityGauge != nil {
+		batchQueueCapacityGauge.Record(ctx, int64(capacity))
+	}
+}
+
 // RecordTaskClaimAttempt records the latency of claiming a task from the queue.
 func RecordTaskClaimAttempt(ctx context.Context, jobID string, latency time.Duration, status string) {
 	if workerTaskClaimLatency != nil {
@@ -636,6 +780,40 @@ func RecordWorkerTaskFailure(ctx context.Context, jobID string, reason string) {
 	}
 }
 
+// RecordCacheStatus counts a warmed page's first-request cache status for its domain.
+func RecordCacheStatus(ctx context.Context, domain string, status string) {
+	recordCacheStatus(ctx, cacheStatusCounter, domain, status)
+}
+
+// RecordSecondCacheStatus counts the cache status of the verification request
+// made after a miss, showing whether warming actually populated the cache.
+func RecordSecondCacheStatus(ctx context.Context, domain string, status string) {
+	recordCacheStatus(ctx, secondCacheStatusCounter, domain, status)
+}
+
+func recordCacheStatus(ctx context.Context, counter metric.Int64Counter, domain string, status string) {
+	if counter == nil {
+		return
+	}
+	counter.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("domain", domain),
+			attribute.String("cache.status", cacheStatusLabel(status)),
+		))
+}
+
+// cacheStatusLabel bounds label cardinality to the statuses worth graphing
+func cacheStatusLabel(status string) string {
+	switch upper := strings.ToUpper(strings.TrimSpace(status)); upper {
+	case "HIT", "MISS", "EXPIRED", "DYNAMIC", "BYPASS", "STALE", "REVALIDATED":
+		return upper
+	case "":
+		return "NONE"
+	default:
+		return "OTHER"
+	}
+}
+
 // RecordTaskWaiting records when tasks move into the waiting queue along with the reason.
 func RecordTaskWaiting(ctx context.Context, jobID string, reason string, count int) {
 	if workerTaskWaitingCounter == nil || count <= 0 {
@@ -652,6 +830,41 @@ func RecordTaskWaiting(ctx context.Context, jobID string, reason string, count i
 	workerTaskWaitingCounter.Add(ctx, int64(count), metric.WithAttributes(attrs...))
 }
 
+// RecordDeniedURLs records URLs dropped by the organisation deny list, by the
+// stage that caught them.
+func RecordDeniedURLs(ctx context.Context, jobID string, stage string, count int) {
+	if workerDeniedURLCounter == nil || count <= 0 {
+		return
+	}
+
+	workerDeniedURLCounter.Add(ctx, int64(count),
+		metric.WithAttributes(
+			attribute.String("job.id", jobID),
+			attribute.String("deny.stage", stage),
+		))
+}
+
+// RecordCircuitBreakerTransition records a domain's circuit breaker entering
+// a new state: open, half_open or closed.
+func RecordCircuitBreakerTransition(ctx context.Context, domain string, state string) {
+	if workerBreakerCounter == nil {
+		return
+	}
+
+	workerBreakerCounter.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("domain", domain),
+			attribute.String("breaker.state", state),
+		))
+}
+
+// RecordNotifyListenerDisconnect records a lost LISTEN/NOTIFY connection.
+func RecordNotifyListenerDisconnect(ctx context.Context) {
+	if notifyListenerDisconnectCounter != nil {
+		notifyListenerDisconnectCounter.Add(ctx, 1)
+	}
+}
+
 // RecordDBPoolRejection increments the pool rejection counter when requests are rejected before acquiring a connection.
 func RecordDBPoolRejection(ctx context.Context) {
 	if dbPoolRejectCounter != nil {
@@ -0,0 +1,295 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3DefaultRegion   = "us-east-1"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3MaxPresignSecs  = 7 * 24 * 60 * 60 // SigV4 limit of 7 days
+)
+
+// S3Client stores objects in any S3-compatible service (AWS S3, MinIO, R2)
+// using Signature Version 4
+type S3Client struct {
+	endpoint        *url.URL
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	pathStyle       bool
+	httpClient      *http.Client
+	now             func() time.Time
+}
+
+// S3Config configures an S3Client
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.ap-southeast-2.amazonaws.com or http://minio:9000
+	Region          string // Defaults to us-east-1
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // Address buckets as endpoint/bucket (MinIO) rather than bucket.endpoint
+}
+
+// NewS3 creates an S3-compatible storage client
+func NewS3(cfg S3Config) (*S3Client, error) {
+	if cfg.Endpoint == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 storage requires an endpoint, access key ID and secret access key")
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = s3DefaultRegion
+	}
+
+	return &S3Client{
+		endpoint:        endpoint,
+		region:          region,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		pathStyle:       cfg.PathStyle,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		now: time.Now,
+	}, nil
+}
+
+// NewS3FromEnv creates an S3 client from BBB_S3_* environment variables.
+// Path-style addressing is on by default as most self-hosted stores need it.
+func NewS3FromEnv() (*S3Client, error) {
+	pathStyle := true
+	if raw := strings.TrimSpace(os.Getenv("BBB_S3_PATH_STYLE")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BBB_S3_PATH_STYLE %q", raw)
+		}
+		pathStyle = parsed
+	}
+
+	return NewS3(S3Config{
+		Endpoint:        strings.TrimSpace(os.Getenv("BBB_S3_ENDPOINT")),
+		Region:          strings.TrimSpace(os.Getenv("BBB_S3_REGION")),
+		AccessKeyID:     os.Getenv("BBB_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("BBB_S3_SECRET_ACCESS_KEY"),
+		PathStyle:       pathStyle,
+	})
+}
+
+// Upload puts an object, overwriting any existing object at the same key
+func (c *S3Client) Upload(ctx context.Context, bucket, path string, data []byte, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(bucket, path), bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	c.sign(req, sha256Hex(data))
+
+	if err := c.do(req, http.StatusOK); err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+	return fmt.Sprintf("%s/%s", bucket, path), nil
+}
+
+// GetSignedURL returns a presigned GET URL valid for expiresIn seconds
+func (c *S3Client) GetSignedURL(_ context.Context, bucket, path string, expiresIn int) (string, error) {
+	if expiresIn <= 0 || expiresIn > s3MaxPresignSecs {
+		return "", fmt.Errorf("expiresIn must be between 1 and %d seconds", s3MaxPresignSecs)
+	}
+	return c.presign(http.MethodGet, c.objectURL(bucket, path), expiresIn), nil
+}
+
+// Delete removes an object. Deleting a missing key succeeds, as with S3.
+func (c *S3Client) Delete(ctx context.Context, bucket, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.objectURL(bucket, path), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	c.sign(req, sha256Hex(nil))
+
+	if err := c.do(req, http.StatusNoContent, http.StatusOK); err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
+
+func (c *S3Client) do(req *http.Request, okStatuses ...int) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range okStatuses {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+}
+
+// objectURL addresses bucket/key in path or virtual-hosted style
+func (c *S3Client) objectURL(bucket, key string) string {
+	u := *c.endpoint
+	base := strings.TrimSuffix(u.Path, "/")
+	key = strings.TrimPrefix(key, "/")
+	if c.pathStyle {
+		u.Path = base + "/" + bucket + "/" + key
+		u.RawPath = base + "/" + s3Escape(bucket) + "/" + s3EscapePath(key)
+	} else {
+		u.Host = bucket + "." + u.Host
+		u.Path = base + "/" + key
+		u.RawPath = base + "/" + s3EscapePath(key)
+	}
+	return u.String()
+}
+
+// sign adds SigV4 Authorization headers to req
+func (c *S3Client) sign(req *http.Request, payloadHash string) {
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := c.scope(now)
+	signature := c.signature(now, amzDate, scope, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, c.accessKeyID, scope, signedHeaders, signature))
+}
+
+// presign returns rawURL with SigV4 query authentication
+func (c *S3Client) presign(method, rawURL string, expiresIn int) string {
+	u, _ := url.Parse(rawURL)
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := c.scope(now)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", c.accessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(expiresIn))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		s3CanonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	signature := c.signature(now, amzDate, scope, canonicalRequest)
+	u.RawQuery = s3CanonicalQuery(query) + "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+func (c *S3Client) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + c.region + "/" + s3Service + "/aws4_request"
+}
+
+func (c *S3Client) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// s3CanonicalQuery sorts and RFC 3986-encodes query parameters
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath encodes each segment of an object key, keeping slashes
+func s3EscapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3Escape percent-encodes everything except RFC 3986 unreserved characters
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
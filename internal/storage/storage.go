@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Storage is an object store for crawl artefacts. Paths returned by Upload
+// are "bucket/path" regardless of backend.
+type Storage interface {
+	Upload(ctx context.Context, bucket, path string, data []byte, contentType string) (string, error)
+	GetSignedURL(ctx context.Context, bucket, path string, expiresIn int) (string, error)
+	Delete(ctx context.Context, bucket, path string) error
+}
+
+var (
+	_ Storage = (*Client)(nil)
+	_ Storage = (*S3Client)(nil)
+)
+
+// Backend names accepted by BBB_STORAGE_BACKEND
+const (
+	BackendSupabase = "supabase"
+	BackendS3       = "s3"
+)
+
+// NewFromEnv builds the storage backend selected by BBB_STORAGE_BACKEND
+// (default supabase). Returns nil, nil when the backend isn't configured so
+// callers can treat storage as optional.
+func NewFromEnv() (Storage, error) {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv("BBB_STORAGE_BACKEND")))
+
+	switch backend {
+	case "", BackendSupabase:
+		supabaseURL := strings.TrimSuffix(os.Getenv("SUPABASE_URL"), "/")
+		serviceKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
+		if supabaseURL == "" || serviceKey == "" {
+			return nil, nil
+		}
+		return New(supabaseURL, serviceKey), nil
+	case BackendS3:
+		return NewS3FromEnv()
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (expected %s or %s)", backend, BackendSupabase, BackendS3)
+	}
+}
@@ -0,0 +1,111 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// DefaultTrustedProxyCIDRs covers loopback and private ranges, which is where
+// Fly.io's edge proxy connects to the app from (including 6PN fdaa::/16)
+const DefaultTrustedProxyCIDRs = "127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7"
+
+// TrustedProxies resolves a request's client IP from X-Forwarded-For, honouring
+// the header only when it was written by a known proxy
+type TrustedProxies struct {
+	prefixes []netip.Prefix
+}
+
+// ParseTrustedProxies parses a comma-separated CIDR list; bare addresses are
+// treated as single-host prefixes
+func ParseTrustedProxies(cidrs string) (*TrustedProxies, error) {
+	tp := &TrustedProxies{}
+	for entry := range strings.SplitSeq(cidrs, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+			}
+			addr = addr.Unmap()
+			tp.prefixes = append(tp.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy CIDR %q: %w", entry, err)
+		}
+		tp.prefixes = append(tp.prefixes, prefix.Masked())
+	}
+	return tp, nil
+}
+
+// Trusted reports whether addr belongs to a trusted proxy
+func (tp *TrustedProxies) Trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range tp.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address of the first untrusted hop. X-Forwarded-For is
+// only read when the direct peer is a trusted proxy, and is walked right to
+// left, so entries a client prepends itself are never reached.
+func (tp *TrustedProxies) ClientIP(r *http.Request) string {
+	client, ok := parseHopAddr(r.RemoteAddr)
+	if !ok {
+		return r.RemoteAddr
+	}
+	if !tp.Trusted(client) {
+		return client.String()
+	}
+
+	// Proxies may append separate headers rather than extend one
+	var hops []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseHopAddr(hops[i])
+		if !ok {
+			break // Garbled entry; nothing further left can be trusted
+		}
+		client = hop
+		if !tp.Trusted(hop) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// parseHopAddr reads an address as proxies write it: bare IPv4 or IPv6, with
+// an optional port, IPv6 in brackets, or a quoted RFC 7239 style value
+func parseHopAddr(raw string) (netip.Addr, bool) {
+	raw = strings.Trim(strings.TrimSpace(raw), `"`)
+	if raw == "" {
+		return netip.Addr{}, false
+	}
+
+	if addr, err := netip.ParseAddr(raw); err == nil {
+		return addr.WithZone("").Unmap(), true
+	}
+	if host, _, err := net.SplitHostPort(raw); err == nil {
+		raw = host
+	} else {
+		raw = strings.TrimSuffix(strings.TrimPrefix(raw, "["), "]")
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.WithZone("").Unmap(), true
+}
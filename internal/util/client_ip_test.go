@@ -0,0 +1,118 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies(DefaultTrustedProxyCIDRs + ", 198.51.100.10")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		expected   string
+	}{
+		{
+			name:       "direct client ignores forwarded header",
+			remoteAddr: "203.0.113.9:5000",
+			xff:        []string{"192.0.2.1"},
+			expected:   "203.0.113.9",
+		},
+		{
+			name:       "trusted proxy forwards client",
+			remoteAddr: "172.16.4.2:5000",
+			xff:        []string{"203.0.113.50"},
+			expected:   "203.0.113.50",
+		},
+		{
+			name:       "spoofed entries left of the real client are skipped",
+			remoteAddr: "172.16.4.2:5000",
+			xff:        []string{"192.0.2.1, 203.0.113.50"},
+			expected:   "203.0.113.50",
+		},
+		{
+			name:       "walks past trusted hops",
+			remoteAddr: "[fdaa:0:1::3]:5000",
+			xff:        []string{"203.0.113.50, 198.51.100.10, 10.0.0.4"},
+			expected:   "203.0.113.50",
+		},
+		{
+			name:       "multiple headers are one chain",
+			remoteAddr: "10.0.0.5:5000",
+			xff:        []string{"192.0.2.1", "203.0.113.50"},
+			expected:   "203.0.113.50",
+		},
+		{
+			name:       "bracketed IPv6 with port",
+			remoteAddr: "10.0.0.5:5000",
+			xff:        []string{"[2001:db8::1]:443"},
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "bare IPv6",
+			remoteAddr: "10.0.0.5:5000",
+			xff:        []string{"2001:db8::2"},
+			expected:   "2001:db8::2",
+		},
+		{
+			name:       "IPv4 with port",
+			remoteAddr: "10.0.0.5:5000",
+			xff:        []string{"203.0.113.50:8080"},
+			expected:   "203.0.113.50",
+		},
+		{
+			name:       "garbled hop stops the walk",
+			remoteAddr: "10.0.0.5:5000",
+			xff:        []string{"203.0.113.50, not-an-ip, 10.0.0.6"},
+			expected:   "10.0.0.6",
+		},
+		{
+			name:       "no forwarded header uses the proxy",
+			remoteAddr: "10.0.0.5:5000",
+			expected:   "10.0.0.5",
+		},
+		{
+			name:       "IPv6 remote address",
+			remoteAddr: "[2001:db8::9]:5000",
+			expected:   "2001:db8::9",
+		},
+		{
+			name:       "IPv4-mapped IPv6 remote address",
+			remoteAddr: "[::ffff:203.0.113.9]:5000",
+			expected:   "203.0.113.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.xff {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			assert.Equal(t, tt.expected, proxies.ClientIP(r))
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies(" 10.0.0.0/8, 2001:db8::1 ,")
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "[2001:db8::1]:443"
+	r.Header.Set("X-Forwarded-For", "203.0.113.50")
+	assert.Equal(t, "203.0.113.50", proxies.ClientIP(r))
+
+	_, err = ParseTrustedProxies("10.0.0.0/33")
+	assert.Error(t, err)
+	_, err = ParseTrustedProxies("fly-edge")
+	assert.Error(t, err)
+}
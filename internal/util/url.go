@@ -76,6 +76,21 @@ func ValidateDomain(domain string) error {
 	return nil
 }
 
+// RegistrableDomain returns the eTLD+1 of a host using the Public Suffix
+// List, e.g. "shop.example.co.uk" becomes "example.co.uk". Hosts without one,
+// such as IP addresses or bare suffixes, are returned lowercased as-is.
+func RegistrableDomain(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	registrable, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return registrable
+}
+
 // NormaliseURL ensures a URL has proper https:// scheme and validates format
 func NormaliseURL(rawURL string) string {
 	// Clean up the URL by trimming spaces
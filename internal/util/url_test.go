@@ -119,6 +119,27 @@ func TestValidateDomain(t *testing.T) {
 	}
 }
 
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{"example.com", "example.com"},
+		{"www.example.com", "example.com"},
+		{"Shop.Example.com", "example.com"},
+		{"a.b.example.co.uk", "example.co.uk"},
+		{"example.com.", "example.com"},
+		{"192.168.1.10", "192.168.1.10"},
+		{"co.uk", "co.uk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RegistrableDomain(tt.host))
+		})
+	}
+}
+
 func TestNormaliseURL(t *testing.T) {
 	tests := []struct {
 		name     string
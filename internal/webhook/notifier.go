@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+// NotifierConfig sizes a Notifier
+type NotifierConfig struct {
+	Name        string        // Used in logs
+	Concurrency int           // Deliveries in flight at once; extra ones are dropped
+	Timeout     time.Duration // Deadline for each delivery, including retries
+	RateLimit   rate.Limit    // Deliveries per second per key; 0 disables limiting
+	Burst       int           // Deliveries a key may send at once before RateLimit applies
+}
+
+// Notifier runs best-effort outbound deliveries in the background so callers
+// never block on a slow endpoint. Deliveries over the concurrency or per-key
+// rate limit are dropped rather than queued.
+type Notifier struct {
+	cfg   NotifierConfig
+	slots chan struct{}
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewNotifier creates a notifier; a non-positive concurrency defaults to 1
+func NewNotifier(cfg NotifierConfig) *Notifier {
+	cfg.Concurrency = max(cfg.Concurrency, 1)
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.RateLimit > 0 && cfg.Burst <= 0 {
+		cfg.Burst = 1
+	}
+	return &Notifier{
+		cfg:      cfg,
+		slots:    make(chan struct{}, cfg.Concurrency),
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Notify starts deliver in the background and reports whether it was
+// accepted. An empty key skips rate limiting.
+func (n *Notifier) Notify(key string, deliver func(ctx context.Context) error) bool {
+	if key != "" && !n.Allow(key) {
+		return false
+	}
+
+	select {
+	case n.slots <- struct{}{}:
+	default:
+		log.Warn().Str("notifier", n.cfg.Name).Msg("Notifier at capacity, dropping delivery")
+		return false
+	}
+
+	go func() {
+		defer func() { <-n.slots }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), n.cfg.Timeout)
+		defer cancel()
+
+		if err := deliver(ctx); err != nil {
+			log.Warn().Err(err).Str("notifier", n.cfg.Name).Msg("Notification delivery failed")
+		}
+	}()
+	return true
+}
+
+// Allow reports whether key is within its rate limit, using up one delivery
+// if so. Callers that only learn the key during delivery check it themselves.
+func (n *Notifier) Allow(key string) bool {
+	if n.cfg.RateLimit <= 0 {
+		return true
+	}
+
+	n.mu.Lock()
+	limiter, ok := n.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(n.cfg.RateLimit, n.cfg.Burst)
+		n.limiters[key] = limiter
+	}
+	n.mu.Unlock()
+
+	if limiter.Allow() {
+		return true
+	}
+	log.Debug().Str("notifier", n.cfg.Name).Msg("Notification rate limited, dropping delivery")
+	return false
+}
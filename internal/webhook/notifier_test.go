@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestNotifierRateLimitsPerKey(t *testing.T) {
+	n := NewNotifier(NotifierConfig{Name: "test", Concurrency: 10, RateLimit: rate.Every(time.Hour), Burst: 2})
+
+	var wg sync.WaitGroup
+	deliver := func(ctx context.Context) error {
+		wg.Done()
+		return nil
+	}
+
+	wg.Add(3)
+	assert.True(t, n.Notify("a", deliver))
+	assert.True(t, n.Notify("a", deliver))
+	assert.False(t, n.Notify("a", deliver), "third delivery to the same key should be dropped")
+	assert.True(t, n.Notify("b", deliver), "other keys have their own budget")
+	wg.Wait()
+}
+
+func TestNotifierDropsAtCapacity(t *testing.T) {
+	n := NewNotifier(NotifierConfig{Name: "test", Concurrency: 1})
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	assert.True(t, n.Notify("", func(ctx context.Context) error {
+		<-release
+		close(done)
+		return nil
+	}))
+	assert.False(t, n.Notify("", func(ctx context.Context) error { return nil }), "a busy notifier should drop rather than block")
+
+	close(release)
+	<-done
+}
@@ -0,0 +1,182 @@
+// Package webhook delivers signed JSON notifications to customer endpoints.
+// Payloads are signed with HMAC-SHA256 so receivers can verify they came
+// from Blue Banded Bee and weren't replayed.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harvey-AU/blue-banded-bee/internal/crawler"
+)
+
+const (
+	// SignatureHeader carries "sha256=<hex>" over "<timestamp>.<body>"
+	SignatureHeader = "X-BBB-Signature"
+	// TimestampHeader carries the Unix time the payload was signed
+	TimestampHeader = "X-BBB-Timestamp"
+
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 4
+	defaultBackoff     = 2 * time.Second
+	maxErrorBodyBytes  = 1024
+	maxURLLength       = 2048
+)
+
+// Delivery reports the outcome of a send
+type Delivery struct {
+	Attempts   int
+	StatusCode int // Last response status; 0 if no response was received
+}
+
+// Sender posts signed payloads, retrying with exponential backoff
+type Sender struct {
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration // Delay before the second attempt, doubled each retry
+	now         func() time.Time
+}
+
+// NewSender creates a sender with the default timeout and retry policy.
+// Endpoints are customer-supplied, so connections go through the crawler's
+// SSRF check and redirects aren't followed: a public endpoint could otherwise
+// bounce the request to an internal address.
+func NewSender() *Sender {
+	return &Sender{
+		httpClient: &http.Client{
+			Timeout:       defaultTimeout,
+			Transport:     &http.Transport{DialContext: crawler.SSRFSafeDialContext(defaultTimeout)},
+			CheckRedirect: refuseRedirect,
+		},
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		now:         time.Now,
+	}
+}
+
+// NewSenderWithClient creates a sender that posts through httpClient instead
+// of the SSRF-checked default, e.g. so tests can reach a loopback server
+func NewSenderWithClient(httpClient *http.Client) *Sender {
+	s := NewSender()
+	s.httpClient = httpClient
+	return s
+}
+
+// refuseRedirect hands the redirect response back to the caller, where it's
+// reported as a non-2xx delivery
+func refuseRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// ValidateURL checks a webhook URL is an absolute HTTPS URL that doesn't
+// point at localhost or a private address. Hostnames resolving to private
+// addresses are caught when the sender dials.
+func ValidateURL(rawURL string) error {
+	if len(rawURL) > maxURLLength {
+		return fmt.Errorf("webhook URL must be at most %d characters", maxURLLength)
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("webhook URL must be an absolute https URL")
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("webhook URL must not point at localhost")
+	}
+	if ip := net.ParseIP(host); ip != nil && crawler.IsPrivateOrLocalIP(ip) {
+		return fmt.Errorf("webhook URL must not point at a private or local address")
+	}
+	return nil
+}
+
+// Sign returns the signature header value for a payload signed at timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts payload as JSON to endpoint. Non-2xx responses and transport
+// errors are retried until the attempts run out or ctx is done.
+func (s *Sender) Send(ctx context.Context, endpoint, secret string, payload any) (Delivery, error) {
+	return s.send(ctx, endpoint, secret, true, payload)
+}
+
+// SendUnsigned posts payload like Send but without signature headers, for
+// third-party endpoints such as Slack incoming webhooks
+func (s *Sender) SendUnsigned(ctx context.Context, endpoint string, payload any) (Delivery, error) {
+	return s.send(ctx, endpoint, "", false, payload)
+}
+
+func (s *Sender) send(ctx context.Context, endpoint, secret string, sign bool, payload any) (Delivery, error) {
+	var delivery Delivery
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return delivery, fmt.Errorf("webhook: failed to marshal payload: %w", err)
+	}
+
+	backoff := s.backoff
+	for {
+		delivery.Attempts++
+		delivery.StatusCode, err = s.post(ctx, endpoint, secret, sign, body)
+		if err == nil {
+			return delivery, nil
+		}
+		if delivery.Attempts >= s.maxAttempts {
+			return delivery, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return delivery, fmt.Errorf("webhook: gave up after %d attempts: %w", delivery.Attempts, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *Sender) post(ctx context.Context, endpoint, secret string, sign bool, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if sign {
+		// Signing the timestamp lets receivers reject replayed deliveries
+		timestamp := strconv.FormatInt(s.now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return resp.StatusCode, fmt.Errorf("webhook: endpoint returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return resp.StatusCode, nil
+}
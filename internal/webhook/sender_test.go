@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSender dials directly so tests can reach httptest servers on loopback
+func newTestSender() *Sender {
+	s := NewSenderWithClient(&http.Client{CheckRedirect: refuseRedirect})
+	s.backoff = time.Millisecond
+	s.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return s
+}
+
+func TestSendSignsPayload(t *testing.T) {
+	var gotBody []byte
+	var gotSignature, gotTimestamp string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery, err := newTestSender().Send(context.Background(), server.URL, "secret", map[string]string{"job_id": "job-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.StatusCode)
+	assert.JSONEq(t, `{"job_id":"job-1"}`, string(gotBody))
+	assert.Equal(t, "1700000000", gotTimestamp)
+	assert.Equal(t, Sign("secret", gotTimestamp, gotBody), gotSignature)
+	assert.True(t, strings.HasPrefix(gotSignature, "sha256="))
+	assert.NotEqual(t, Sign("other", gotTimestamp, gotBody), gotSignature)
+}
+
+func TestSendRetriesUntilSuccess(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	delivery, err := newTestSender().Send(context.Background(), server.URL, "secret", struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.StatusCode)
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	delivery, err := newTestSender().Send(context.Background(), server.URL, "secret", struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+	assert.Equal(t, defaultMaxAttempts, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+	assert.Equal(t, int32(defaultMaxAttempts), calls.Load())
+}
+
+func TestValidateURL(t *testing.T) {
+	assert.NoError(t, ValidateURL("https://hooks.example.com/bbb"))
+	assert.Error(t, ValidateURL("http://hooks.example.com/bbb"))
+	assert.Error(t, ValidateURL("/relative"))
+	assert.Error(t, ValidateURL("https://"))
+	assert.Error(t, ValidateURL("https://example.com/"+strings.Repeat("a", maxURLLength)))
+	assert.Error(t, ValidateURL("https://localhost/bbb"))
+	assert.Error(t, ValidateURL("https://api.localhost./bbb"))
+	assert.Error(t, ValidateURL("https://127.0.0.1/bbb"))
+	assert.Error(t, ValidateURL("https://10.0.0.5:8443/bbb"))
+	assert.Error(t, ValidateURL("https://169.254.169.254/latest/meta-data"))
+	assert.Error(t, ValidateURL("https://[::1]/bbb"))
+	assert.NoError(t, ValidateURL("https://203.0.113.10/bbb"))
+}
+
+func TestSendBlocksPrivateAddresses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	s := NewSender()
+	s.maxAttempts = 1
+	_, err := s.Send(context.Background(), server.URL, "secret", struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "private/local IP")
+	assert.Zero(t, calls.Load())
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	var internalCalls atomic.Int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalCalls.Add(1)
+	}))
+	defer internal.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	s := newTestSender()
+	s.maxAttempts = 1
+	delivery, err := s.Send(context.Background(), server.URL, "secret", struct{}{})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTemporaryRedirect, delivery.StatusCode)
+	assert.Zero(t, internalCalls.Load())
+}
+
+func TestSendUnsignedOmitsSignature(t *testing.T) {
+	var gotSignature, gotTimestamp string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := newTestSender().SendUnsigned(context.Background(), server.URL, map[string]string{"text": "hi"})
+	require.NoError(t, err)
+	assert.Empty(t, gotSignature)
+	assert.Empty(t, gotTimestamp)
+}
@@ -0,0 +1,103 @@
+-- CDN purge connections
+-- Stores per-organisation CDN purge configuration used after a job completes to
+-- drop stale cache entries for URLs that were confirmed freshly warmed.
+-- API credentials are kept in Supabase Vault; only the backend (service_role)
+-- can read or write them.
+
+CREATE TABLE IF NOT EXISTS cdn_purge_connections (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organisation_id UUID NOT NULL REFERENCES organisations(id) ON DELETE CASCADE,
+  provider TEXT NOT NULL CHECK (provider IN ('cloudflare', 'fastly')),
+  zone_id TEXT NOT NULL,                   -- Cloudflare zone ID or Fastly service ID
+  soft_purge BOOLEAN NOT NULL DEFAULT FALSE, -- Mark stale rather than evict (Fastly only)
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  vault_secret_name TEXT,                  -- Name of secret in Supabase Vault
+  created_by_user_id UUID REFERENCES users(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE(organisation_id),
+  -- Cloudflare has no soft purge, so don't store a setting that does nothing
+  CHECK (provider = 'fastly' OR NOT soft_purge)
+);
+
+ALTER TABLE cdn_purge_connections ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "cdn_purge_connections_select_own_org" ON cdn_purge_connections
+  FOR SELECT USING (
+    organisation_id IN (SELECT public.user_organisations())
+  );
+
+CREATE TRIGGER update_cdn_purge_connections_updated_at
+  BEFORE UPDATE ON cdn_purge_connections
+  FOR EACH ROW
+  EXECUTE FUNCTION update_updated_at_column();
+
+-- Store a CDN API token in Vault (backend only)
+CREATE OR REPLACE FUNCTION store_cdn_purge_token(connection_id UUID, token TEXT)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  existing_secret_id UUID;
+BEGIN
+  secret_name := 'cdn_purge_token_' || connection_id::TEXT;
+
+  SELECT id INTO existing_secret_id
+  FROM vault.secrets
+  WHERE name = secret_name;
+
+  IF existing_secret_id IS NOT NULL THEN
+    PERFORM vault.update_secret(existing_secret_id, token, secret_name, NULL);
+  ELSE
+    PERFORM vault.create_secret(token, secret_name);
+  END IF;
+
+  UPDATE cdn_purge_connections
+  SET vault_secret_name = secret_name
+  WHERE id = connection_id;
+
+  RETURN secret_name;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Retrieve a decrypted CDN API token from Vault (backend only)
+CREATE OR REPLACE FUNCTION get_cdn_purge_token(connection_id UUID)
+RETURNS TEXT AS $$
+DECLARE
+  token TEXT;
+BEGIN
+  SELECT decrypted_secret INTO token
+  FROM vault.decrypted_secrets
+  WHERE name = 'cdn_purge_token_' || connection_id::TEXT;
+
+  RETURN token;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Remove the Vault secret when a connection is deleted
+CREATE OR REPLACE FUNCTION delete_cdn_purge_token()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM vault.secrets WHERE name = 'cdn_purge_token_' || OLD.id::TEXT;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+CREATE TRIGGER delete_cdn_purge_token_on_delete
+  AFTER DELETE ON cdn_purge_connections
+  FOR EACH ROW
+  EXECUTE FUNCTION delete_cdn_purge_token();
+
+ALTER FUNCTION store_cdn_purge_token(UUID, TEXT) OWNER TO postgres;
+ALTER FUNCTION get_cdn_purge_token(UUID) OWNER TO postgres;
+ALTER FUNCTION delete_cdn_purge_token() OWNER TO postgres;
+
+-- Supabase grants EXECUTE to anon and authenticated by default, so revoke
+-- those explicitly as well as PUBLIC
+REVOKE EXECUTE ON FUNCTION store_cdn_purge_token(UUID, TEXT) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION get_cdn_purge_token(UUID) FROM PUBLIC, anon, authenticated;
+GRANT EXECUTE ON FUNCTION store_cdn_purge_token(UUID, TEXT) TO service_role;
+GRANT EXECUTE ON FUNCTION get_cdn_purge_token(UUID) TO service_role;
+
+COMMENT ON TABLE cdn_purge_connections IS 'Per-organisation CDN purge settings used after job completion';
+COMMENT ON FUNCTION store_cdn_purge_token IS 'Stores CDN purge API token securely in vault';
+COMMENT ON FUNCTION get_cdn_purge_token IS 'Retrieves CDN purge API token from vault';
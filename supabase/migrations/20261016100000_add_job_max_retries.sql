@@ -0,0 +1,11 @@
+-- Per-job retry limit for retryable task errors (previously a fixed constant of 5).
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS max_retries INTEGER NOT NULL DEFAULT 5;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_max_retries_range;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_max_retries_range CHECK (max_retries BETWEEN 0 AND 10);
+
+COMMENT ON COLUMN jobs.max_retries IS 'Maximum retries for retryable task errors (0-10, default 5)';
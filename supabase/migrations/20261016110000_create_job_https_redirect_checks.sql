@@ -0,0 +1,34 @@
+-- HTTP -> HTTPS redirect checks recorded while warming, surfaced via /v1/jobs/{id}/issues
+CREATE TABLE IF NOT EXISTS job_https_redirect_checks (
+    id BIGSERIAL PRIMARY KEY,
+    job_id TEXT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    http_url TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('redirects_to_https', 'no_redirect', 'insecure_redirect')),
+    status_code INTEGER,
+    final_url TEXT,
+    redirect_chain JSONB NOT NULL DEFAULT '[]'::jsonb,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT job_https_redirect_checks_job_url_key UNIQUE (job_id, http_url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_https_redirect_checks_findings
+    ON job_https_redirect_checks(job_id)
+    WHERE status <> 'redirects_to_https';
+
+ALTER TABLE job_https_redirect_checks ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view active org https redirect checks" ON job_https_redirect_checks;
+
+CREATE POLICY "Users can view active org https redirect checks"
+ON job_https_redirect_checks FOR SELECT
+USING (
+    EXISTS (
+        SELECT 1 FROM jobs j
+        WHERE j.id = job_https_redirect_checks.job_id
+          AND j.organisation_id = public.user_organisation_id()
+          AND public.user_is_member_of(j.organisation_id)
+    )
+);
+
+COMMENT ON TABLE job_https_redirect_checks IS 'Per-job checks that HTTP URLs redirect to HTTPS';
+COMMENT ON COLUMN job_https_redirect_checks.status IS 'redirects_to_https, no_redirect (served over HTTP) or insecure_redirect (redirect chain never reached HTTPS)';
@@ -0,0 +1,13 @@
+-- Separate concurrency for the cache verification (second request) phase.
+-- jobs.concurrency remains the total in-flight task budget used by the claim
+-- functions; verify_concurrency is the portion reserved for verification.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS verify_concurrency INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_verify_concurrency_non_negative;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_verify_concurrency_non_negative CHECK (verify_concurrency >= 0);
+
+COMMENT ON COLUMN jobs.verify_concurrency IS 'Concurrent verification requests (0 = share the warming concurrency). Included in jobs.concurrency';
@@ -0,0 +1,6 @@
+-- Origin-level cache status (reverse proxy or CDN shield behind the edge).
+-- tasks.cache_status continues to hold the edge status.
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS origin_cache_status TEXT;
+
+COMMENT ON COLUMN tasks.origin_cache_status IS 'Normalised cache status of the origin/shield tier when reported alongside the edge cache header';
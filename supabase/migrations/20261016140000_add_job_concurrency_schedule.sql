@@ -0,0 +1,7 @@
+-- Time-of-day concurrency schedule. Windows lower the job's concurrency during
+-- set hours in the schedule's timezone; jobs.concurrency remains the ceiling.
+-- Shape: {"timezone": "Australia/Sydney", "windows": [{"start": "09:00", "end": "17:00", "concurrency": 2}]}
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS concurrency_schedule JSONB;
+
+COMMENT ON COLUMN jobs.concurrency_schedule IS 'Optional time-of-day concurrency windows applied by the domain limiter (NULL = fixed concurrency)';
@@ -0,0 +1,6 @@
+-- Non-2xx status codes a job treats as successful warms, for sites whose CDN
+-- deliberately caches error pages (e.g. cached 404s or stale 503 pages)
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS cacheable_status_codes INTEGER[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN jobs.cacheable_status_codes IS '4xx/5xx status codes recorded as completed rather than failed (empty = 2xx only)';
@@ -0,0 +1,25 @@
+-- Concurrency-block tracking: shows when queue delay comes from a job's own
+-- concurrency limit rather than origin latency.
+--
+-- Jobs accumulate claim attempts rejected at the concurrency cap and the time
+-- spent blocked (closed on the next successful claim). Tasks snapshot both
+-- totals when queued and store the difference when claimed.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS concurrency_blocks BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS concurrency_blocked_ms BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS concurrency_blocked_since TIMESTAMPTZ;
+
+COMMENT ON COLUMN jobs.concurrency_blocks IS 'Claim attempts rejected because the job was at its concurrency limit with work queued';
+COMMENT ON COLUMN jobs.concurrency_blocked_ms IS 'Total time the job spent blocked by its concurrency limit';
+COMMENT ON COLUMN jobs.concurrency_blocked_since IS 'Start of the current blocked period (NULL when not blocked)';
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS concurrency_blocks_mark BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS concurrency_blocked_ms_mark BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS concurrency_block_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS concurrency_wait_ms BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN tasks.concurrency_blocks_mark IS 'jobs.concurrency_blocks when the task was queued';
+COMMENT ON COLUMN tasks.concurrency_blocked_ms_mark IS 'jobs.concurrency_blocked_ms when the task was queued';
+COMMENT ON COLUMN tasks.concurrency_block_count IS 'Times the job was concurrency-blocked while this task waited';
+COMMENT ON COLUMN tasks.concurrency_wait_ms IS 'Time the job was concurrency-blocked while this task waited';
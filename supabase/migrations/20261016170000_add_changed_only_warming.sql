@@ -0,0 +1,12 @@
+-- Changed-only warming: recurring jobs send a conditional HEAD first and only
+-- fully warm pages whose ETag/Last-Modified changed since the previous job.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS changed_only BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.changed_only IS 'Skip pages whose ETag/Last-Modified match the previous job (recorded as skipped, reason unchanged)';
+
+-- Why a skipped task wasn't warmed. NULL for max-pages and cancellation skips.
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS skip_reason TEXT;
+
+COMMENT ON COLUMN tasks.skip_reason IS 'Reason a task was skipped by the worker, e.g. unchanged';
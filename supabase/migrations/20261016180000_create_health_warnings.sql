@@ -0,0 +1,30 @@
+-- Operational health warnings raised by the health monitor (stuck jobs/tasks,
+-- counter leaks, cleanup backlog). One open row per warning type; the row is
+-- resolved when the check next comes back clean.
+CREATE TABLE IF NOT EXISTS health_warnings (
+    id BIGSERIAL PRIMARY KEY,
+    warning_type TEXT NOT NULL CHECK (warning_type IN ('stuck_jobs', 'stuck_tasks', 'counter_leak', 'cleanup_backlog')),
+    message TEXT NOT NULL,
+    affected_count INTEGER NOT NULL DEFAULT 0,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurrences INTEGER NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    acknowledged_at TIMESTAMPTZ,
+    acknowledged_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_health_warnings_open_type
+    ON health_warnings(warning_type)
+    WHERE resolved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_health_warnings_last_seen
+    ON health_warnings(last_seen_at DESC);
+
+-- Service role only: surfaced to system admins via /v1/admin/health-warnings
+ALTER TABLE health_warnings ENABLE ROW LEVEL SECURITY;
+
+COMMENT ON TABLE health_warnings IS 'Operational warnings from the health monitor, acknowledged by system admins';
+COMMENT ON COLUMN health_warnings.occurrences IS 'Number of consecutive health checks that raised this warning';
+COMMENT ON COLUMN health_warnings.acknowledged_at IS 'Set when an admin acknowledges the warning; stays set until the warning resolves';
@@ -0,0 +1,12 @@
+-- Job priority tier: high tier jobs claim tasks first and keep a reserved
+-- share of worker capacity (BBB_HIGH_PRIORITY_RESERVE_PERCENT) while active.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS priority_tier TEXT NOT NULL DEFAULT 'normal';
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_priority_tier_check;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_priority_tier_check CHECK (priority_tier IN ('high', 'normal', 'low'));
+
+COMMENT ON COLUMN jobs.priority_tier IS 'Scheduling tier: high, normal or low. Separate from task priority_score, which orders pages within a job';
@@ -0,0 +1,13 @@
+-- Slow origin policy: 'boost' adds workers as response times rise, while
+-- 'back_off' cuts the job's concurrency when latency spikes well above its
+-- baseline (BBB_LATENCY_SPIKE_MULTIPLIER) and restores it on recovery.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS slow_origin_policy TEXT NOT NULL DEFAULT 'boost';
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_slow_origin_policy_check;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_slow_origin_policy_check CHECK (slow_origin_policy IN ('boost', 'back_off'));
+
+COMMENT ON COLUMN jobs.slow_origin_policy IS 'Reaction to origin latency spikes: boost (add workers) or back_off (reduce job concurrency)';
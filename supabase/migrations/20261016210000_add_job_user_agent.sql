@@ -0,0 +1,12 @@
+-- Per-job user agent override for sites whose WAF only allows specific bot
+-- agents. NULL uses the crawler default. Stored so recovered jobs keep it.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS user_agent TEXT;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_user_agent_length_check;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_user_agent_length_check CHECK (user_agent IS NULL OR char_length(user_agent) <= 512);
+
+COMMENT ON COLUMN jobs.user_agent IS 'User-Agent sent for this job''s requests and used for robots.txt matching; NULL means the crawler default';
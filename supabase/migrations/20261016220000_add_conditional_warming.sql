@@ -0,0 +1,11 @@
+-- Conditional warming: jobs can send the previous crawl's ETag and
+-- Last-Modified as If-None-Match / If-Modified-Since, and tasks record when
+-- the origin answered 304 Not Modified so "already fresh" pages can be counted.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS conditional_warm BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS not_modified BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.conditional_warm IS 'Send stored ETag/Last-Modified validators and treat 304 Not Modified as a successful warm';
+COMMENT ON COLUMN tasks.not_modified IS 'True when a conditional warm request was answered with 304 Not Modified';
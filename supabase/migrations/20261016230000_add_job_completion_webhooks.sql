@@ -0,0 +1,101 @@
+-- Job completion webhooks: jobs can name an HTTPS endpoint that receives a
+-- signed POST when they complete, fail or are cancelled. Payloads are signed
+-- with a per-organisation HMAC secret so receivers can verify them. The
+-- secret is kept in Supabase Vault rather than on organisations, which
+-- members can read and update through RLS.
+
+-- Return an organisation's webhook signing secret, creating it on first use
+-- (backend only)
+CREATE OR REPLACE FUNCTION get_organisation_webhook_secret(p_organisation_id UUID)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  secret TEXT;
+BEGIN
+  secret_name := 'org_webhook_secret_' || p_organisation_id::TEXT;
+
+  SELECT decrypted_secret INTO secret
+  FROM vault.decrypted_secrets
+  WHERE name = secret_name;
+
+  IF secret IS NULL THEN
+    secret := encode(gen_random_bytes(32), 'hex');
+    PERFORM vault.create_secret(secret, secret_name);
+  END IF;
+
+  RETURN secret;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault, extensions;
+
+-- Replace an organisation's webhook signing secret and return the new one
+-- (backend only)
+CREATE OR REPLACE FUNCTION rotate_organisation_webhook_secret(p_organisation_id UUID)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  secret TEXT;
+  existing_secret_id UUID;
+BEGIN
+  secret_name := 'org_webhook_secret_' || p_organisation_id::TEXT;
+  secret := encode(gen_random_bytes(32), 'hex');
+
+  SELECT id INTO existing_secret_id
+  FROM vault.secrets
+  WHERE name = secret_name;
+
+  IF existing_secret_id IS NOT NULL THEN
+    PERFORM vault.update_secret(existing_secret_id, secret, secret_name, NULL);
+  ELSE
+    PERFORM vault.create_secret(secret, secret_name);
+  END IF;
+
+  RETURN secret;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault, extensions;
+
+-- Remove the Vault secret when an organisation is deleted
+CREATE OR REPLACE FUNCTION delete_organisation_webhook_secret()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM vault.secrets WHERE name = 'org_webhook_secret_' || OLD.id::TEXT;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+DROP TRIGGER IF EXISTS delete_organisation_webhook_secret_on_delete ON organisations;
+CREATE TRIGGER delete_organisation_webhook_secret_on_delete
+  AFTER DELETE ON organisations
+  FOR EACH ROW
+  EXECUTE FUNCTION delete_organisation_webhook_secret();
+
+ALTER FUNCTION get_organisation_webhook_secret(UUID) OWNER TO postgres;
+ALTER FUNCTION rotate_organisation_webhook_secret(UUID) OWNER TO postgres;
+ALTER FUNCTION delete_organisation_webhook_secret() OWNER TO postgres;
+
+REVOKE EXECUTE ON FUNCTION get_organisation_webhook_secret(UUID) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION rotate_organisation_webhook_secret(UUID) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION delete_organisation_webhook_secret() FROM PUBLIC, anon, authenticated;
+GRANT EXECUTE ON FUNCTION get_organisation_webhook_secret(UUID) TO service_role;
+GRANT EXECUTE ON FUNCTION rotate_organisation_webhook_secret(UUID) TO service_role;
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS notify_webhook_url TEXT,
+ADD COLUMN IF NOT EXISTS notify_webhook_status TEXT,
+ADD COLUMN IF NOT EXISTS notify_webhook_attempts INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS notify_webhook_error TEXT,
+ADD COLUMN IF NOT EXISTS notify_webhook_sent_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_notify_webhook_status_check;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_notify_webhook_status_check CHECK (
+  notify_webhook_status IS NULL OR notify_webhook_status IN ('sending', 'delivered', 'failed')
+);
+
+COMMENT ON FUNCTION get_organisation_webhook_secret(UUID) IS 'HMAC-SHA256 key used to sign job completion webhooks, from Vault (service role only)';
+COMMENT ON COLUMN jobs.notify_webhook_url IS 'HTTPS endpoint POSTed when the job completes, fails or is cancelled; NULL disables';
+COMMENT ON COLUMN jobs.notify_webhook_status IS 'Webhook delivery state: NULL until claimed, then sending, delivered or failed';
+COMMENT ON COLUMN jobs.notify_webhook_attempts IS 'Delivery attempts made, including retries';
+COMMENT ON COLUMN jobs.notify_webhook_error IS 'Last delivery error when the webhook failed';
+COMMENT ON COLUMN jobs.notify_webhook_sent_at IS 'When the final delivery attempt finished';
@@ -0,0 +1,10 @@
+-- Pre-warm CDN purge: jobs can purge their sitemap URLs through the
+-- organisation's CDN purge connection before warming, so the cache is
+-- repopulated with fresh content. Purge failures are recorded as a job
+-- warning rather than failing the job.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS purge_before_warm BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS warning_message TEXT;
+
+COMMENT ON COLUMN jobs.purge_before_warm IS 'Purge discovered sitemap URLs from the organisation''s CDN before warming';
+COMMENT ON COLUMN jobs.warning_message IS 'Non-fatal problem during the job, e.g. a failed pre-warm CDN purge';
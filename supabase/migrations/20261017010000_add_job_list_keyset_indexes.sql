@@ -0,0 +1,12 @@
+-- Keyset pagination for GET /v1/jobs orders by (sort column, id) within an
+-- organisation. Including id lets deep pages seek straight to the cursor
+-- instead of scanning past earlier rows. Both indexes serve ascending order
+-- via backward scans.
+CREATE INDEX IF NOT EXISTS idx_jobs_org_created_id
+ON jobs(organisation_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_org_completed_id
+ON jobs(organisation_id, completed_at DESC NULLS LAST, id DESC);
+
+COMMENT ON INDEX idx_jobs_org_created_id IS 'Keyset pagination of organisation jobs by created_at';
+COMMENT ON INDEX idx_jobs_org_completed_id IS 'Keyset pagination of organisation jobs by completed_at';
@@ -0,0 +1,26 @@
+-- Migration: Add cron expressions to schedulers
+-- A scheduler runs either on a fixed interval or on a cron expression
+-- evaluated in its own timezone (e.g. nightly at 2am Sydney time)
+
+ALTER TABLE schedulers
+ADD COLUMN IF NOT EXISTS cron_expression TEXT,
+ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE schedulers
+ALTER COLUMN schedule_interval_hours DROP NOT NULL;
+
+ALTER TABLE schedulers
+DROP CONSTRAINT IF EXISTS schedulers_interval_or_cron;
+
+ALTER TABLE schedulers
+ADD CONSTRAINT schedulers_interval_or_cron
+CHECK ((schedule_interval_hours IS NULL) <> (cron_expression IS NULL));
+
+-- Supports the scheduler loop's check for a still-running previous job
+CREATE INDEX IF NOT EXISTS idx_jobs_scheduler_active
+ON jobs(scheduler_id)
+WHERE scheduler_id IS NOT NULL AND status IN ('pending', 'initializing', 'running', 'paused');
+
+COMMENT ON COLUMN schedulers.schedule_interval_hours IS 'Recurring job interval in hours (6, 12, 24, or 48); NULL when cron_expression is set';
+COMMENT ON COLUMN schedulers.cron_expression IS 'Five-field cron expression; NULL when schedule_interval_hours is set';
+COMMENT ON COLUMN schedulers.timezone IS 'IANA timezone the cron expression is evaluated in';
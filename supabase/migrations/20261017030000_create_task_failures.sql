@@ -0,0 +1,37 @@
+-- Dead-letter record for tasks that failed permanently, surfaced via /v1/jobs/{id}/failures
+CREATE TABLE IF NOT EXISTS task_failures (
+    id BIGSERIAL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    job_id TEXT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    status_code INTEGER,
+    error_category TEXT NOT NULL CHECK (error_category IN ('blocking', 'retryable', 'client', 'other')),
+    error_message TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    response_headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+    failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT task_failures_task_key UNIQUE (task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_failures_job_category
+    ON task_failures(job_id, error_category, failed_at DESC);
+
+ALTER TABLE task_failures ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view active org task failures" ON task_failures;
+
+CREATE POLICY "Users can view active org task failures"
+ON task_failures FOR SELECT
+USING (
+    EXISTS (
+        SELECT 1 FROM jobs j
+        WHERE j.id = task_failures.job_id
+          AND j.organisation_id = public.user_organisation_id()
+          AND public.user_is_member_of(j.organisation_id)
+    )
+);
+
+COMMENT ON TABLE task_failures IS 'Structured context for tasks that failed permanently after exhausting retries';
+COMMENT ON COLUMN task_failures.error_category IS 'blocking (403/429/503), retryable (timeouts, 5xx), client (4xx, redirects) or other';
+COMMENT ON COLUMN task_failures.attempts IS 'Requests made for the task, including retries';
+COMMENT ON COLUMN task_failures.response_headers IS 'Headers from the last response, without Set-Cookie';
@@ -0,0 +1,14 @@
+-- Migration: Add per-job task timeout
+-- Slow origins can need longer than the default 120 seconds per page
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS task_timeout_seconds INTEGER NOT NULL DEFAULT 120;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_task_timeout_seconds_range;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_task_timeout_seconds_range
+CHECK (task_timeout_seconds BETWEEN 30 AND 600);
+
+COMMENT ON COLUMN jobs.task_timeout_seconds IS 'Seconds a single task may run before it is cancelled (30-600)';
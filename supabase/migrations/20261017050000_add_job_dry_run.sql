@@ -0,0 +1,13 @@
+-- Migration: Add dry-run discovery
+-- A dry-run job runs sitemap discovery and robots filtering, records each URL
+-- as a 'discovered' task that workers never claim, then completes
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check
+CHECK (status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'waiting', 'discovered'));
+
+COMMENT ON COLUMN jobs.dry_run IS 'Discover and list URLs without warming them';
@@ -0,0 +1,28 @@
+-- Migration: Notify job progress
+-- Fires pg_notify('job_progress', job_id) whenever a job's status or task
+-- counters change so the API can push updates to SSE clients
+
+CREATE OR REPLACE FUNCTION notify_job_progress()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status IS DISTINCT FROM NEW.status
+       OR OLD.total_tasks IS DISTINCT FROM NEW.total_tasks
+       OR OLD.completed_tasks IS DISTINCT FROM NEW.completed_tasks
+       OR OLD.failed_tasks IS DISTINCT FROM NEW.failed_tasks
+       OR OLD.skipped_tasks IS DISTINCT FROM NEW.skipped_tasks THEN
+        PERFORM pg_notify('job_progress', NEW.id::text);
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS on_job_progress_change ON jobs;
+CREATE TRIGGER on_job_progress_change
+    AFTER UPDATE OF status, total_tasks, completed_tasks, failed_tasks, skipped_tasks ON jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_job_progress();
+
+COMMENT ON FUNCTION notify_job_progress() IS
+  'Notifies the job_progress channel with the job id when status or task counts change.
+   Postgres folds duplicate payloads within a transaction, so batch updates notify once.';
@@ -0,0 +1,81 @@
+-- Migration: Add job site credentials
+-- Jobs can warm sites behind basic auth or a bearer token (e.g. staging).
+-- Credentials are kept in Supabase Vault as JSON; the job row only records the
+-- secret name. Only the backend (service_role) can read or write them.
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS credentials_secret_name TEXT;
+
+COMMENT ON COLUMN jobs.credentials_secret_name IS 'Name of the Vault secret holding site credentials, NULL when none';
+
+-- Store a job's site credentials in Vault (backend only)
+CREATE OR REPLACE FUNCTION store_job_credentials(p_job_id TEXT, credentials TEXT)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  existing_secret_id UUID;
+BEGIN
+  secret_name := 'job_credentials_' || p_job_id;
+
+  SELECT id INTO existing_secret_id
+  FROM vault.secrets
+  WHERE name = secret_name;
+
+  IF existing_secret_id IS NOT NULL THEN
+    PERFORM vault.update_secret(existing_secret_id, credentials, secret_name, NULL);
+  ELSE
+    PERFORM vault.create_secret(credentials, secret_name);
+  END IF;
+
+  UPDATE jobs
+  SET credentials_secret_name = secret_name
+  WHERE id = p_job_id;
+
+  RETURN secret_name;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Retrieve a job's decrypted site credentials from Vault (backend only)
+CREATE OR REPLACE FUNCTION get_job_credentials(p_job_id TEXT)
+RETURNS TEXT AS $$
+DECLARE
+  credentials TEXT;
+BEGIN
+  SELECT decrypted_secret INTO credentials
+  FROM vault.decrypted_secrets
+  WHERE name = 'job_credentials_' || p_job_id;
+
+  RETURN credentials;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Remove the Vault secret when a job is deleted
+CREATE OR REPLACE FUNCTION delete_job_credentials()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF OLD.credentials_secret_name IS NOT NULL THEN
+    DELETE FROM vault.secrets WHERE name = OLD.credentials_secret_name;
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+DROP TRIGGER IF EXISTS delete_job_credentials_on_delete ON jobs;
+CREATE TRIGGER delete_job_credentials_on_delete
+  AFTER DELETE ON jobs
+  FOR EACH ROW
+  EXECUTE FUNCTION delete_job_credentials();
+
+ALTER FUNCTION store_job_credentials(TEXT, TEXT) OWNER TO postgres;
+ALTER FUNCTION get_job_credentials(TEXT) OWNER TO postgres;
+ALTER FUNCTION delete_job_credentials() OWNER TO postgres;
+
+-- anon and authenticated hold EXECUTE by default on Supabase, so PUBLIC alone
+-- would leave the credentials readable over RPC
+REVOKE EXECUTE ON FUNCTION store_job_credentials(TEXT, TEXT) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION get_job_credentials(TEXT) FROM PUBLIC, anon, authenticated;
+GRANT EXECUTE ON FUNCTION store_job_credentials(TEXT, TEXT) TO service_role;
+GRANT EXECUTE ON FUNCTION get_job_credentials(TEXT) TO service_role;
+
+COMMENT ON FUNCTION store_job_credentials IS 'Stores job site credentials securely in vault';
+COMMENT ON FUNCTION get_job_credentials IS 'Retrieves job site credentials from vault';
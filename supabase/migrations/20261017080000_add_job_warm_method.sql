@@ -0,0 +1,13 @@
+-- Warm method: 'GET' transfers full pages, while 'HEAD' primes CDNs that
+-- populate their edge cache on HEAD requests without transferring bodies.
+-- HEAD jobs record status, timing and cache headers only (no link discovery).
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS method TEXT NOT NULL DEFAULT 'GET';
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_method_check;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_method_check CHECK (method IN ('GET', 'HEAD'));
+
+COMMENT ON COLUMN jobs.method IS 'HTTP method used to warm pages: GET (full page) or HEAD (headers only)';
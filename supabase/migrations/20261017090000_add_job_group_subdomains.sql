@@ -0,0 +1,7 @@
+-- Group subdomains: when true, the domain limiter paces the job's requests
+-- under the registrable domain (eTLD+1), so jobs for www.example.com and
+-- shop.example.com share one politeness budget when they share an origin.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS group_subdomains BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.group_subdomains IS 'Rate limit the job under its registrable domain rather than its hostname';
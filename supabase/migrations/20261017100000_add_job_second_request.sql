@@ -0,0 +1,7 @@
+-- Second request: when false, the crawler primes the cache and skips the
+-- HEAD checks and second fetch it otherwise makes after a cache miss to
+-- measure the warmed response, roughly halving origin load.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS second_request BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN jobs.second_request IS 'Re-fetch cache misses to measure the warmed response; false only primes the cache';
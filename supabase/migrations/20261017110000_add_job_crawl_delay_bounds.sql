@@ -0,0 +1,19 @@
+-- Crawl delay bounds: clamp the robots.txt Crawl-delay a job honours. The
+-- floor applies even when robots.txt sets no delay; the ceiling stops an
+-- absurd directive stalling the job. 0 leaves that side unbounded.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS min_crawl_delay_seconds INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS max_crawl_delay_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_crawl_delay_bounds_range;
+
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_crawl_delay_bounds_range CHECK (
+    min_crawl_delay_seconds BETWEEN 0 AND 300
+    AND max_crawl_delay_seconds BETWEEN 0 AND 300
+    AND (max_crawl_delay_seconds = 0 OR min_crawl_delay_seconds <= max_crawl_delay_seconds)
+);
+
+COMMENT ON COLUMN jobs.min_crawl_delay_seconds IS 'Floor on the robots.txt Crawl-delay in seconds (0-300, 0 for none)';
+COMMENT ON COLUMN jobs.max_crawl_delay_seconds IS 'Ceiling on the robots.txt Crawl-delay in seconds (0-300, 0 for none)';
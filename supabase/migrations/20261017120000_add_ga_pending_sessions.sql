@@ -0,0 +1,90 @@
+-- Pending Google Analytics OAuth sessions: accounts, properties and tokens held
+-- between the OAuth callback and property selection. Shared across app
+-- instances because the callback may land on a different machine than the
+-- follow-up requests. Rows expire after 10 minutes and are deleted on save.
+-- The OAuth tokens are kept in Supabase Vault; the row only holds non-secret
+-- session data and the secret name.
+CREATE TABLE IF NOT EXISTS google_analytics_pending_sessions (
+    id UUID PRIMARY KEY,
+    data JSONB NOT NULL,
+    vault_secret_name TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ga_pending_sessions_expires
+ON google_analytics_pending_sessions(expires_at);
+
+-- No policies: only the service role can read it
+ALTER TABLE google_analytics_pending_sessions ENABLE ROW LEVEL SECURITY;
+
+-- Store a pending session's OAuth tokens in Vault (backend only)
+CREATE OR REPLACE FUNCTION store_ga_pending_tokens(p_session_id UUID, tokens TEXT)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  existing_secret_id UUID;
+BEGIN
+  secret_name := 'ga_pending_' || p_session_id::TEXT;
+
+  SELECT id INTO existing_secret_id
+  FROM vault.secrets
+  WHERE name = secret_name;
+
+  IF existing_secret_id IS NOT NULL THEN
+    PERFORM vault.update_secret(existing_secret_id, tokens, secret_name, NULL);
+  ELSE
+    PERFORM vault.create_secret(tokens, secret_name);
+  END IF;
+
+  UPDATE google_analytics_pending_sessions
+  SET vault_secret_name = secret_name
+  WHERE id = p_session_id;
+
+  RETURN secret_name;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Retrieve a pending session's decrypted OAuth tokens from Vault (backend only)
+CREATE OR REPLACE FUNCTION get_ga_pending_tokens(p_session_id UUID)
+RETURNS TEXT AS $$
+DECLARE
+  tokens TEXT;
+BEGIN
+  SELECT decrypted_secret INTO tokens
+  FROM vault.decrypted_secrets
+  WHERE name = 'ga_pending_' || p_session_id::TEXT;
+
+  RETURN tokens;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Remove the Vault secret when a session is deleted, on save or expiry
+CREATE OR REPLACE FUNCTION delete_ga_pending_tokens()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF OLD.vault_secret_name IS NOT NULL THEN
+    DELETE FROM vault.secrets WHERE name = OLD.vault_secret_name;
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+DROP TRIGGER IF EXISTS delete_ga_pending_tokens_on_delete ON google_analytics_pending_sessions;
+CREATE TRIGGER delete_ga_pending_tokens_on_delete
+  AFTER DELETE ON google_analytics_pending_sessions
+  FOR EACH ROW
+  EXECUTE FUNCTION delete_ga_pending_tokens();
+
+ALTER FUNCTION store_ga_pending_tokens(UUID, TEXT) OWNER TO postgres;
+ALTER FUNCTION get_ga_pending_tokens(UUID) OWNER TO postgres;
+ALTER FUNCTION delete_ga_pending_tokens() OWNER TO postgres;
+
+REVOKE EXECUTE ON FUNCTION store_ga_pending_tokens(UUID, TEXT) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION get_ga_pending_tokens(UUID) FROM PUBLIC, anon, authenticated;
+GRANT EXECUTE ON FUNCTION store_ga_pending_tokens(UUID, TEXT) TO service_role;
+GRANT EXECUTE ON FUNCTION get_ga_pending_tokens(UUID) TO service_role;
+
+COMMENT ON TABLE google_analytics_pending_sessions IS 'Short-lived GA OAuth sessions awaiting property selection (service role only)';
+COMMENT ON COLUMN google_analytics_pending_sessions.data IS 'Accounts, properties and user details; never the OAuth tokens';
+COMMENT ON COLUMN google_analytics_pending_sessions.vault_secret_name IS 'Name of the Vault secret holding the session''s OAuth tokens';
@@ -0,0 +1,196 @@
+-- Google Search Console integration
+-- Mirrors the Google Analytics integration: one connection per verified site,
+-- refresh tokens in Supabase Vault. Jobs with prioritise_by_search boost pages
+-- by their search impressions.
+
+-- ============================================================================
+-- 1. Create search_console_connections table
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS search_console_connections (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organisation_id UUID NOT NULL REFERENCES organisations(id) ON DELETE CASCADE,
+  site_url TEXT NOT NULL,                     -- e.g. "sc-domain:example.com" or "https://www.example.com/"
+  domain_id INTEGER REFERENCES domains(id) ON DELETE SET NULL,
+  google_user_id TEXT,                        -- Google user ID who authorised
+  google_email TEXT,                          -- Google email for display
+  vault_secret_name TEXT,                     -- Refresh token stored in Vault
+  installing_user_id UUID REFERENCES users(id),
+  status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive')),
+  last_synced_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE(organisation_id, site_url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gsc_connections_org_domain
+ON search_console_connections(organisation_id, domain_id);
+
+ALTER TABLE search_console_connections ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "gsc_connections_select_own_org" ON search_console_connections
+  FOR SELECT USING (
+    organisation_id IN (SELECT organisation_id FROM users WHERE id = auth.uid())
+  );
+
+CREATE POLICY "gsc_connections_delete_own_org" ON search_console_connections
+  FOR DELETE USING (
+    organisation_id IN (SELECT organisation_id FROM users WHERE id = auth.uid())
+  );
+
+CREATE TRIGGER update_gsc_connections_updated_at
+  BEFORE UPDATE ON search_console_connections
+  FOR EACH ROW
+  EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- 2. Vault helper functions for Search Console tokens
+-- ============================================================================
+
+-- Store a refresh token for a Search Console connection in Supabase Vault
+CREATE OR REPLACE FUNCTION store_gsc_token(connection_id UUID, refresh_token TEXT)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  secret_updated INT;
+  connection_org_id UUID;
+  caller_org_id UUID;
+BEGIN
+  SELECT organisation_id INTO connection_org_id
+  FROM search_console_connections
+  WHERE id = connection_id;
+
+  IF connection_org_id IS NULL THEN
+    RAISE EXCEPTION 'Connection % not found', connection_id;
+  END IF;
+
+  IF auth.role() <> 'service_role' THEN
+    SELECT organisation_id INTO caller_org_id
+    FROM users
+    WHERE id = auth.uid();
+
+    IF caller_org_id IS NULL OR caller_org_id <> connection_org_id THEN
+      RAISE EXCEPTION 'Not authorised to access connection %', connection_id;
+    END IF;
+  END IF;
+
+  secret_name := 'gsc_token_' || connection_id::TEXT;
+
+  UPDATE vault.secrets SET secret = refresh_token WHERE name = secret_name;
+  GET DIAGNOSTICS secret_updated = ROW_COUNT;
+
+  IF secret_updated = 0 THEN
+    PERFORM vault.create_secret(refresh_token, secret_name);
+  END IF;
+
+  UPDATE search_console_connections
+  SET vault_secret_name = secret_name
+  WHERE id = connection_id;
+
+  RETURN secret_name;
+EXCEPTION
+  WHEN unique_violation THEN
+    -- Race condition: another call created the secret between our UPDATE and CREATE
+    UPDATE vault.secrets SET secret = refresh_token WHERE name = secret_name;
+    UPDATE search_console_connections SET vault_secret_name = secret_name WHERE id = connection_id;
+    RETURN secret_name;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Retrieve a refresh token for a Search Console connection from Supabase Vault
+CREATE OR REPLACE FUNCTION get_gsc_token(connection_id UUID)
+RETURNS TEXT AS $$
+DECLARE
+  secret_name TEXT;
+  token TEXT;
+  connection_org_id UUID;
+  caller_org_id UUID;
+BEGIN
+  SELECT organisation_id, vault_secret_name INTO connection_org_id, secret_name
+  FROM search_console_connections
+  WHERE id = connection_id;
+
+  IF connection_org_id IS NULL THEN
+    RAISE EXCEPTION 'Connection % not found', connection_id;
+  END IF;
+
+  IF auth.role() <> 'service_role' THEN
+    SELECT organisation_id INTO caller_org_id
+    FROM users
+    WHERE id = auth.uid();
+
+    IF caller_org_id IS NULL OR caller_org_id <> connection_org_id THEN
+      RAISE EXCEPTION 'Not authorised to access connection %', connection_id;
+    END IF;
+  END IF;
+
+  IF secret_name IS NULL THEN
+    RETURN NULL;
+  END IF;
+
+  SELECT decrypted_secret INTO token
+  FROM vault.decrypted_secrets
+  WHERE name = secret_name;
+
+  RETURN token;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Delete a refresh token for a Search Console connection from Supabase Vault
+CREATE OR REPLACE FUNCTION delete_gsc_token(connection_id UUID)
+RETURNS VOID AS $$
+DECLARE
+  secret_name TEXT;
+  connection_org_id UUID;
+  caller_org_id UUID;
+BEGIN
+  SELECT organisation_id, vault_secret_name INTO connection_org_id, secret_name
+  FROM search_console_connections
+  WHERE id = connection_id;
+
+  IF connection_org_id IS NULL THEN
+    RAISE EXCEPTION 'Connection % not found', connection_id;
+  END IF;
+
+  IF auth.role() <> 'service_role' THEN
+    SELECT organisation_id INTO caller_org_id
+    FROM users
+    WHERE id = auth.uid();
+
+    IF caller_org_id IS NULL OR caller_org_id <> connection_org_id THEN
+      RAISE EXCEPTION 'Not authorised to access connection %', connection_id;
+    END IF;
+  END IF;
+
+  UPDATE search_console_connections
+  SET vault_secret_name = NULL
+  WHERE id = connection_id;
+
+  IF secret_name IS NOT NULL THEN
+    DELETE FROM vault.secrets WHERE name = secret_name;
+  END IF;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+GRANT EXECUTE ON FUNCTION store_gsc_token(UUID, TEXT) TO authenticated;
+GRANT EXECUTE ON FUNCTION get_gsc_token(UUID) TO authenticated;
+GRANT EXECUTE ON FUNCTION delete_gsc_token(UUID) TO authenticated;
+
+GRANT EXECUTE ON FUNCTION store_gsc_token(UUID, TEXT) TO service_role;
+GRANT EXECUTE ON FUNCTION get_gsc_token(UUID) TO service_role;
+GRANT EXECUTE ON FUNCTION delete_gsc_token(UUID) TO service_role;
+
+-- ============================================================================
+-- 3. Search data on page_analytics and the job option
+-- ============================================================================
+ALTER TABLE page_analytics
+ADD COLUMN IF NOT EXISTS search_clicks_28d BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS search_impressions_28d BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS search_score FLOAT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS gsc_connection_id UUID REFERENCES search_console_connections(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS search_fetched_at TIMESTAMPTZ;
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS prioritise_by_search BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN page_analytics.search_score IS 'Log-scaled Search Console impressions (0, or 0.10-0.99)';
+COMMENT ON COLUMN jobs.prioritise_by_search IS 'Boost task priority by Search Console impressions when the site is connected';
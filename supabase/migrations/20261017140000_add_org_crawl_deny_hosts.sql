@@ -0,0 +1,7 @@
+-- Organisation crawl deny list: host patterns that are never crawled, even
+-- when discovered links pass same-domain filtering. Patterns are hostnames
+-- (covering their subdomains) or "*.host" (subdomains only).
+ALTER TABLE organisations
+ADD COLUMN IF NOT EXISTS crawl_deny_hosts TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN organisations.crawl_deny_hosts IS 'Host patterns never crawled for this organisation, checked on discovered links and again at task claim';
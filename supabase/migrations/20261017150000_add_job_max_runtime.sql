@@ -0,0 +1,24 @@
+-- Per-job runtime ceiling. The cleanup monitor stops jobs running longer than
+-- max_runtime_minutes (0 = no limit), either failing them or completing them
+-- with whatever was warmed, and skips their pending/waiting tasks.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS max_runtime_minutes INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS max_runtime_action TEXT NOT NULL DEFAULT 'fail';
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_max_runtime_minutes_check;
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_max_runtime_minutes_check CHECK (max_runtime_minutes >= 0);
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_max_runtime_action_check;
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_max_runtime_action_check CHECK (max_runtime_action IN ('fail', 'complete'));
+
+COMMENT ON COLUMN jobs.max_runtime_minutes IS 'Minutes after starting that the job is stopped; 0 for no limit';
+COMMENT ON COLUMN jobs.max_runtime_action IS 'Terminal state when max_runtime_minutes is exceeded: fail, or complete with the pages warmed so far';
+
+-- Lets the cleanup monitor find capped jobs without scanning every active job
+CREATE INDEX IF NOT EXISTS idx_jobs_max_runtime_active
+ON jobs (started_at)
+WHERE max_runtime_minutes > 0 AND status IN ('pending', 'running');
@@ -0,0 +1,74 @@
+-- Organisation Slack incoming webhook for job failure alerts. When set, a
+-- short message is posted whenever one of the organisation's jobs fails.
+-- Anyone holding the URL can post to the channel, so it's kept in Supabase
+-- Vault rather than on organisations, which members can read and update
+-- through RLS.
+
+-- Set or, with NULL or '', clear an organisation's Slack webhook (backend only)
+CREATE OR REPLACE FUNCTION set_organisation_slack_webhook_url(p_organisation_id UUID, url TEXT)
+RETURNS BOOLEAN AS $$
+DECLARE
+  secret_name TEXT;
+  existing_secret_id UUID;
+BEGIN
+  secret_name := 'org_slack_webhook_' || p_organisation_id::TEXT;
+
+  SELECT id INTO existing_secret_id
+  FROM vault.secrets
+  WHERE name = secret_name;
+
+  IF url IS NULL OR url = '' THEN
+    DELETE FROM vault.secrets WHERE id = existing_secret_id;
+    RETURN FALSE;
+  END IF;
+
+  IF existing_secret_id IS NOT NULL THEN
+    PERFORM vault.update_secret(existing_secret_id, url, secret_name, NULL);
+  ELSE
+    PERFORM vault.create_secret(url, secret_name);
+  END IF;
+
+  RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Retrieve an organisation's Slack webhook, NULL when unset (backend only)
+CREATE OR REPLACE FUNCTION get_organisation_slack_webhook_url(p_organisation_id UUID)
+RETURNS TEXT AS $$
+DECLARE
+  url TEXT;
+BEGIN
+  SELECT decrypted_secret INTO url
+  FROM vault.decrypted_secrets
+  WHERE name = 'org_slack_webhook_' || p_organisation_id::TEXT;
+
+  RETURN url;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+-- Remove the Vault secret when an organisation is deleted
+CREATE OR REPLACE FUNCTION delete_organisation_slack_webhook_url()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM vault.secrets WHERE name = 'org_slack_webhook_' || OLD.id::TEXT;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, vault;
+
+DROP TRIGGER IF EXISTS delete_organisation_slack_webhook_url_on_delete ON organisations;
+CREATE TRIGGER delete_organisation_slack_webhook_url_on_delete
+  AFTER DELETE ON organisations
+  FOR EACH ROW
+  EXECUTE FUNCTION delete_organisation_slack_webhook_url();
+
+ALTER FUNCTION set_organisation_slack_webhook_url(UUID, TEXT) OWNER TO postgres;
+ALTER FUNCTION get_organisation_slack_webhook_url(UUID) OWNER TO postgres;
+ALTER FUNCTION delete_organisation_slack_webhook_url() OWNER TO postgres;
+
+REVOKE EXECUTE ON FUNCTION set_organisation_slack_webhook_url(UUID, TEXT) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION get_organisation_slack_webhook_url(UUID) FROM PUBLIC, anon, authenticated;
+REVOKE EXECUTE ON FUNCTION delete_organisation_slack_webhook_url() FROM PUBLIC, anon, authenticated;
+GRANT EXECUTE ON FUNCTION set_organisation_slack_webhook_url(UUID, TEXT) TO service_role;
+GRANT EXECUTE ON FUNCTION get_organisation_slack_webhook_url(UUID) TO service_role;
+
+COMMENT ON FUNCTION get_organisation_slack_webhook_url(UUID) IS 'Slack incoming webhook URL posted when a job fails, from Vault (service role only)';
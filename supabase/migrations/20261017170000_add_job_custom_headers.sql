@@ -0,0 +1,7 @@
+-- Per-job extra request headers sent on every warming request, e.g. to tag
+-- traffic for analytics exclusion or to match caches that vary on a header.
+-- NULL when the job sends none.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS custom_headers JSONB;
+
+COMMENT ON COLUMN jobs.custom_headers IS 'Header name to value map added to warming requests; NULL for none';
@@ -0,0 +1,16 @@
+-- Opt-in soft-404 detection: jobs with detect_soft_404 flag completed tasks
+-- whose 200 response looks like a "not found" page (a near-empty HTML body or
+-- a "not found" title), so clients can find dead sitemap entries.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS detect_soft_404 BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS soft_404 BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.detect_soft_404 IS 'Flag completed tasks whose 200 response looks like a not found page';
+COMMENT ON COLUMN tasks.soft_404 IS 'True when a 200 response matched the soft-404 heuristic';
+
+-- Job summaries count flagged tasks; most tasks are never flagged
+CREATE INDEX IF NOT EXISTS idx_tasks_soft_404
+ON tasks (job_id)
+WHERE soft_404;
@@ -0,0 +1,10 @@
+-- Sitemap discovery retries transient failures, then records which sitemaps
+-- (including sitemap index entries) still failed to load, so job summaries can
+-- report "3 of 12 sitemaps failed to load" instead of silently warming fewer
+-- pages.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS sitemaps_total INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS failed_sitemaps TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN jobs.sitemaps_total IS 'Sitemaps discovery tried to load, counting each sitemap index entry';
+COMMENT ON COLUMN jobs.failed_sitemaps IS 'Sitemap URLs still unreachable after retries';
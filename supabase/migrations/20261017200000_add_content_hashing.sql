@@ -0,0 +1,18 @@
+-- Opt-in content hashing: jobs with hash_content store a SHA-256 of each
+-- completed page's normalised body and whether it differs from the page's
+-- previous warm, so recurring warms can report changed vs unchanged pages.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS hash_content BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS content_hash TEXT,
+ADD COLUMN IF NOT EXISTS content_changed BOOLEAN;
+
+COMMENT ON COLUMN jobs.hash_content IS 'Hash page bodies and compare them with the previous warm';
+COMMENT ON COLUMN tasks.content_hash IS 'SHA-256 of the normalised response body';
+COMMENT ON COLUMN tasks.content_changed IS 'Whether content_hash differs from the page''s previous warm; NULL when there was none';
+
+-- Workers look up each page's most recent hash from earlier jobs
+CREATE INDEX IF NOT EXISTS idx_tasks_page_content_hash
+ON tasks (page_id, completed_at DESC)
+WHERE content_hash IS NOT NULL;
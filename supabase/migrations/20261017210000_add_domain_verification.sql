@@ -0,0 +1,46 @@
+-- Domain verification: an organisation proves it owns a domain (DNS TXT
+-- record or well-known file) before jobs can warm it, unless the
+-- organisation is trusted.
+--
+-- Trust lives in its own table rather than on organisations, since members
+-- can update their organisation's row through RLS.
+CREATE TABLE IF NOT EXISTS trusted_organisations (
+    organisation_id UUID PRIMARY KEY REFERENCES organisations(id) ON DELETE CASCADE,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Service role only: no policies, so only system admins can grant trust
+ALTER TABLE trusted_organisations ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE organisation_domains
+ADD COLUMN IF NOT EXISTS verification_token TEXT,
+ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS verification_method TEXT;
+
+COMMENT ON TABLE trusted_organisations IS 'Organisations whose jobs skip domain verification, granted by system admins';
+COMMENT ON COLUMN trusted_organisations.note IS 'Why the organisation is trusted';
+COMMENT ON COLUMN organisation_domains.verification_token IS 'Token the organisation publishes to prove it owns the domain';
+COMMENT ON COLUMN organisation_domains.verified_at IS 'When ownership was proven; NULL until verified';
+COMMENT ON COLUMN organisation_domains.verification_method IS 'How ownership was proven: dns, file or existing';
+
+-- Grandfather domains organisations have already warmed
+INSERT INTO organisation_domains (organisation_id, domain_id)
+SELECT DISTINCT organisation_id, domain_id
+FROM jobs
+WHERE organisation_id IS NOT NULL
+ON CONFLICT (organisation_id, domain_id) DO NOTHING;
+
+UPDATE organisation_domains
+SET verified_at = NOW(), verification_method = 'existing'
+WHERE verified_at IS NULL;
+
+-- Clients may still register domains, but only the API can mark them verified
+DROP POLICY IF EXISTS "org_domains_insert_own_org" ON organisation_domains;
+
+CREATE POLICY "org_domains_insert_own_org" ON organisation_domains
+  FOR INSERT WITH CHECK (
+    organisation_id IN (SELECT organisation_id FROM users WHERE id = auth.uid())
+    AND verified_at IS NULL
+    AND verification_method IS NULL
+  );
@@ -0,0 +1,16 @@
+-- Link depth limit for find_links jobs. Tasks record how many links were
+-- followed to reach them (root, sitemap and seed URLs are depth 0), and jobs
+-- stop enqueuing discovered links deeper than max_depth (0 = no limit).
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS depth INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS max_depth INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS jobs_max_depth_check;
+ALTER TABLE jobs
+ADD CONSTRAINT jobs_max_depth_check CHECK (max_depth >= 0);
+
+COMMENT ON COLUMN tasks.depth IS 'Links followed from a root, sitemap or seed URL to reach this page';
+COMMENT ON COLUMN jobs.max_depth IS 'Deepest link depth enqueued from discovered links; 0 for no limit';
@@ -0,0 +1,11 @@
+-- Auto-tuned concurrency: the job's concurrency becomes a ceiling. Warming
+-- starts low and steps up while p95 latency and the error rate stay healthy,
+-- halving when they degrade, until it settles on a limit the origin handles.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS auto_concurrency BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS auto_concurrency_limit INTEGER,
+ADD COLUMN IF NOT EXISTS auto_concurrency_stable BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.auto_concurrency IS 'Tune concurrency from observed p95 latency and error rate, up to jobs.concurrency';
+COMMENT ON COLUMN jobs.auto_concurrency_limit IS 'Latest auto-tuned concurrency limit; NULL until tuning starts';
+COMMENT ON COLUMN jobs.auto_concurrency_stable IS 'Whether the auto-tuned limit has settled';
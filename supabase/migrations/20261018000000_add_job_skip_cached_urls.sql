@@ -0,0 +1,6 @@
+-- Skip-cached warming: a HEAD probe runs before each warm and pages the edge
+-- already reports as a cache HIT are marked skipped with skip_reason 'cached'.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS skip_cached_urls BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.skip_cached_urls IS 'Skip the full warm of pages the edge cache already reports as a HIT';
@@ -0,0 +1,7 @@
+-- Sitemaps found by a job's discovery, reported alongside its robots.txt
+-- rules. NULL until discovery runs and for jobs that never use sitemaps
+-- (root URL, seed URL and HAR jobs).
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS discovered_sitemaps TEXT[];
+
+COMMENT ON COLUMN jobs.discovered_sitemaps IS 'Sitemap URLs found by discovery, from robots.txt or the common locations';
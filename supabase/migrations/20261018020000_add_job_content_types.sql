@@ -0,0 +1,7 @@
+-- Content type filtering: responses whose Content-Type isn't in the list are
+-- aborted once their headers arrive and the task is skipped with skip_reason
+-- 'content_type'. Empty warms every response, as jobs did before.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS content_types TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN jobs.content_types IS 'Media types warmed, e.g. text/html or image/*; empty warms every response';
@@ -0,0 +1,9 @@
+-- Job tags: free-form labels such as 'pre-deploy' or 'nightly' so job history
+-- can be filtered by why a warm ran
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN jobs.tags IS 'Lower-cased labels for organising and filtering jobs';
+
+-- Backs GET /v1/jobs?tag= (jsonb ? operator)
+CREATE INDEX IF NOT EXISTS idx_jobs_tags ON jobs USING GIN (tags);
@@ -0,0 +1,8 @@
+-- Retry budget: caps total task retries per job so a flaky origin can't drive
+-- a retry storm. Workers spend retries_used atomically before each retry.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS retry_budget INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS retries_used INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN jobs.retry_budget IS 'Total retries allowed across the job''s tasks; 0 for no cap';
+COMMENT ON COLUMN jobs.retries_used IS 'Retries spent from retry_budget';
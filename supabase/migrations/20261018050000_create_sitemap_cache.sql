@@ -0,0 +1,21 @@
+-- Parsed sitemaps per domain with the ETag/Last-Modified they were served
+-- with, so scheduled jobs revalidate unchanged sitemaps with a conditional
+-- request instead of downloading and parsing them again
+CREATE TABLE IF NOT EXISTS sitemap_cache (
+    domain_id INTEGER NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    sitemap_url TEXT NOT NULL,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    is_index BOOLEAN NOT NULL DEFAULT FALSE,
+    sitemaps TEXT[] NOT NULL DEFAULT '{}',
+    entries JSONB NOT NULL DEFAULT '[]'::jsonb,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (domain_id, sitemap_url)
+);
+
+-- Only the backend reads and writes the cache
+ALTER TABLE sitemap_cache ENABLE ROW LEVEL SECURITY;
+
+COMMENT ON TABLE sitemap_cache IS 'Last parsed contents of each sitemap, reused when a conditional fetch returns 304';
+COMMENT ON COLUMN sitemap_cache.sitemaps IS 'Child sitemaps, for a sitemap index';
+COMMENT ON COLUMN sitemap_cache.entries IS 'Page entries (url, lastmod, priority, changefreq), for a regular sitemap';
@@ -0,0 +1,10 @@
+-- Scheme pages are warmed over. Sitemap entries listed with the other scheme
+-- are canonicalised before warming instead of followed through a redirect,
+-- and counted in mixed_scheme_urls for the job report.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS url_scheme TEXT NOT NULL DEFAULT 'https'
+    CHECK (url_scheme IN ('https', 'http')),
+ADD COLUMN IF NOT EXISTS mixed_scheme_urls INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN jobs.url_scheme IS 'https (default) or http for sites that only serve plain HTTP';
+COMMENT ON COLUMN jobs.mixed_scheme_urls IS 'Sitemap entries listed with the other scheme and canonicalised to url_scheme';
@@ -0,0 +1,7 @@
+-- Opt-in technology detection over the whole page body. By default only the
+-- first BBB_CRAWLER_BODY_SAMPLE_BYTES are checked, which misses markers near
+-- the bottom of long pages.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS full_body_detection BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN jobs.full_body_detection IS 'Run technology detection on the full body rather than the body sample';
@@ -0,0 +1,76 @@
+-- Task retention: once a finished job is older than its organisation's
+-- retention window, its summary is kept in job_results and its task rows are
+-- deleted so the hot tasks table stays small
+ALTER TABLE plans
+ADD COLUMN IF NOT EXISTS task_retention_days INTEGER CHECK (task_retention_days IS NULL OR task_retention_days > 0);
+
+ALTER TABLE organisations
+ADD COLUMN IF NOT EXISTS task_retention_days INTEGER CHECK (task_retention_days IS NULL OR task_retention_days > 0);
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS tasks_archived_at TIMESTAMPTZ;
+
+-- Retention is opt-in: plans and organisations start NULL, so no existing
+-- task history is deleted until a window is set deliberately
+
+CREATE TABLE IF NOT EXISTS job_results (
+    job_id TEXT PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE,
+    total_tasks INTEGER NOT NULL DEFAULT 0,
+    completed_tasks INTEGER NOT NULL DEFAULT 0,
+    failed_tasks INTEGER NOT NULL DEFAULT 0,
+    skipped_tasks INTEGER NOT NULL DEFAULT 0,
+    avg_response_time_ms INTEGER,
+    cache_hits INTEGER NOT NULL DEFAULT 0,
+    cache_misses INTEGER NOT NULL DEFAULT 0,
+    status_codes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Finished jobs waiting for their retention window to pass
+CREATE INDEX IF NOT EXISTS idx_jobs_tasks_unarchived
+    ON jobs (completed_at)
+    WHERE tasks_archived_at IS NULL AND status IN ('completed', 'failed', 'cancelled');
+
+ALTER TABLE job_results ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view active org job results" ON job_results;
+
+CREATE POLICY "Users can view active org job results"
+ON job_results FOR SELECT
+USING (
+    EXISTS (
+        SELECT 1 FROM jobs j
+        WHERE j.id = job_results.job_id
+          AND j.organisation_id = public.user_organisation_id()
+          AND public.user_is_member_of(j.organisation_id)
+    )
+);
+
+-- The sweeper sets bbb.archiving_tasks while deleting, so the counter
+-- triggers leave the job's totals as they were when it finished
+DROP TRIGGER IF EXISTS trigger_update_job_counters ON tasks;
+CREATE TRIGGER trigger_update_job_counters
+    AFTER INSERT OR UPDATE OF status OR DELETE ON tasks
+    FOR EACH ROW
+    WHEN (current_setting('bbb.archiving_tasks', true) IS DISTINCT FROM 'on')
+    EXECUTE FUNCTION update_job_counters();
+
+DROP TRIGGER IF EXISTS trigger_update_job_progress ON tasks;
+CREATE TRIGGER trigger_update_job_progress
+    AFTER INSERT OR UPDATE OF status OR DELETE ON tasks
+    FOR EACH ROW
+    WHEN (current_setting('bbb.archiving_tasks', true) IS DISTINCT FROM 'on')
+    EXECUTE FUNCTION update_job_progress();
+
+DROP TRIGGER IF EXISTS trg_update_job_queue_counters ON tasks;
+CREATE TRIGGER trg_update_job_queue_counters
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW
+    WHEN (current_setting('bbb.archiving_tasks', true) IS DISTINCT FROM 'on')
+    EXECUTE FUNCTION update_job_queue_counters();
+
+COMMENT ON COLUMN plans.task_retention_days IS 'Days task rows are kept after a job finishes; NULL keeps them indefinitely';
+COMMENT ON COLUMN organisations.task_retention_days IS 'Overrides the plan''s task retention; NULL uses the plan''s';
+COMMENT ON COLUMN jobs.tasks_archived_at IS 'When the job''s tasks were summarised into job_results and deleted';
+COMMENT ON TABLE job_results IS 'Summary of a finished job''s tasks, kept after the task rows are deleted by retention';
+COMMENT ON COLUMN job_results.status_codes IS 'Task count per HTTP status code, e.g. {"200": 950, "404": 3}';
@@ -0,0 +1,14 @@
+-- Completion hooks (lifecycle event, CDN purge, webhook) run on whichever
+-- instance first observes a completed job. Each instance claims them by
+-- setting completion_hooks_at, so they run once however many instances see
+-- the job.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS completion_hooks_at TIMESTAMPTZ;
+
+-- Jobs already completed have had their hooks run
+UPDATE jobs
+SET completion_hooks_at = COALESCE(completed_at, NOW())
+WHERE status = 'completed'
+  AND completion_hooks_at IS NULL;
+
+COMMENT ON COLUMN jobs.completion_hooks_at IS 'When an instance claimed the job''s completion hooks; NULL until then';
@@ -0,0 +1,37 @@
+-- Job creation checks for an active job on the domain and then inserts the
+-- new one in a separate transaction, so two concurrent requests could both
+-- find nothing and both insert. These partial unique indexes allow one active
+-- job per domain for each organisation (or, without one, each user); the
+-- losing insert fails and job creation applies its duplicate policy again.
+
+-- Keep the newest active job where duplicates already exist
+UPDATE jobs
+SET status = 'cancelled',
+    completed_at = NOW(),
+    error_message = 'Cancelled: another active job exists for this domain'
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id,
+               ROW_NUMBER() OVER (
+                   PARTITION BY organisation_id,
+                                CASE WHEN organisation_id IS NULL THEN user_id END,
+                                domain_id
+                   ORDER BY created_at DESC
+               ) AS rn
+        FROM jobs
+        WHERE status IN ('pending', 'initializing', 'running', 'paused')
+          AND (organisation_id IS NOT NULL OR user_id IS NOT NULL)
+    ) ranked
+    WHERE rn > 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_active_org_domain
+ON jobs (organisation_id, domain_id)
+WHERE status IN ('pending', 'initializing', 'running', 'paused')
+  AND organisation_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_active_user_domain
+ON jobs (user_id, domain_id)
+WHERE status IN ('pending', 'initializing', 'running', 'paused')
+  AND organisation_id IS NULL
+  AND user_id IS NOT NULL;